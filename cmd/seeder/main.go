@@ -95,29 +95,56 @@ func main() {
 	postRepo := mysql.NewPostRepository(db, logger)
 	postDetailRepo := mysql.NewPostDetailRepository(db)
 	postDetailImageRepo := mysql.NewPostDetailImageRepository(db)
+	postTargetingRepo := mysql.NewPostTargetingRepository(db, logger)
+	postFAQRepo := mysql.NewPostFAQRepository(db, logger)
 
 	rdb, redisErr := dependencies.InitRedis(&cfg.RedisConfig, logger)
 	if redisErr != nil {
 		logger.Warn("初始化 Redis 失败 (Seeder)，部分依赖 Redis 的功能可能受限", zap.Error(redisErr))
 	}
 	var postViewRepo redisRepo.PostViewRepository
+	var postLikeRepo redisRepo.PostLikeRepository
+	var postCache redisRepo.PostReadCache
+	var cosDeleteQueue redisRepo.COSDeleteQueue
+	var idempotencyStore redisRepo.IdempotencyStore
 	if rdb != nil {
-		postViewRepo = redisRepo.NewPostViewRepository(rdb, logger, 10000, 3, 0.01, cfg.ViewSyncConfig)
+		postBatchRepo := mysql.NewPostBatchOperationsRepository(db, logger, cfg.ViewSyncConfig)
+		postViewRepo = redisRepo.NewPostViewRepository(rdb, postBatchRepo, logger, 10000, 3, 0.01, cfg.ViewSyncConfig, cfg.ViewCountConfig)
+		postLikeRepo = redisRepo.NewPostLikeRepository(rdb, postBatchRepo, logger)
+		postCache = redisRepo.NewPostReadCache(postBatchRepo, rdb, logger)
+		cosDeleteQueue = redisRepo.NewCOSDeleteQueue(rdb, logger)
+		idempotencyStore = redisRepo.NewIdempotencyStore(rdb, logger)
 	} else {
 		logger.Warn("PostViewRepository (Redis) 未初始化，依赖此仓库的功能将不可用")
 	}
 
 	// --- 7. 初始化 Service ---
-	postSvc := postServicePkg.NewPostService(
-		db,
-		postRepo,
-		postDetailRepo,
-		postDetailImageRepo,
-		cos,
-		postViewRepo,
-		kafkaProducer,
-		logger,
-	)
+	postSvc := postServicePkg.NewPostService(postServicePkg.PostServiceDeps{
+		DB:                  db,
+		PostRepo:            postRepo,
+		PostDetailRepo:      postDetailRepo,
+		PostDetailImageRepo: postDetailImageRepo,
+		PostTargetingRepo:   postTargetingRepo,
+		PostFAQRepo:         postFAQRepo,
+		PostReportRepo:      mysql.NewPostReportRepository(db, logger),
+		PostAuditRepo:       mysql.NewPostAuditLogRepository(db, logger),
+		SegmentRepo:         mysql.NewPostContentSegmentRepository(db, logger),
+		COSClient:           cos,
+		COSDeleteQueue:      cosDeleteQueue,
+		PostViewRepo:        postViewRepo,
+		ViewAggregator:      nil, // 种子数据不产生浏览
+		PostLikeRepo:        postLikeRepo,
+		PostCache:           postCache,
+		KafkaSvc:            kafkaProducer,
+		OutboxRepo:          mysql.NewOutboxRepository(db, logger),
+		AuditPriorityCfg:    cfg.AuditPriority,
+		AccessGuard:         postServicePkg.NewPostAccessGuard(logger, postServicePkg.NewLoginRequiredHook()),
+		ContentSanitizer:    postServicePkg.NewContentSanitizer(cfg.ContentSanitize),
+		ComplianceChecker:   postServicePkg.NewContentComplianceChecker(cfg.ContentCompliance),
+		ImageUploadCfg:      cfg.ImageUpload,
+		IdempotencyStore:    idempotencyStore,
+		Logger:              logger,
+	})
 	logger.Info("PostService 已初始化 (Seeder)")
 
 	// --- 8. 执行数据填充 ---
@@ -0,0 +1,8 @@
+package config
+
+// AdminDeleteConfig 包含管理员删除帖子相关的配置
+type AdminDeleteConfig struct {
+	// ConfirmViewThreshold 是需要二次确认的浏览量阈值：浏览量超过该值的帖子，删除请求必须带 confirm=true。
+	// 为 0 或未配置时退回 constant.DefaultDeleteConfirmViewThreshold；为负数时关闭二次确认。
+	ConfirmViewThreshold int64 `mapstructure:"confirmViewThreshold" json:"confirmViewThreshold" yaml:"confirmViewThreshold"`
+}
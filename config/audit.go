@@ -0,0 +1,8 @@
+package config
+
+// AuditPriorityConfig 包含帖子审核优先级相关的配置
+type AuditPriorityConfig struct {
+	// HighPriorityMinAuthorLevel 是作者等级达到该值即自动按高优先级送审的阈值。
+	// 为 0 时不按作者等级提升优先级，只有带加急标记的帖子才走高优先级。
+	HighPriorityMinAuthorLevel int `mapstructure:"highPriorityMinAuthorLevel" json:"highPriorityMinAuthorLevel" yaml:"highPriorityMinAuthorLevel"`
+}
@@ -0,0 +1,16 @@
+package config
+
+// BodyLimitConfig 包含写接口请求体大小限制相关的配置（单位均为字节）
+type BodyLimitConfig struct {
+	// DefaultMaxBytes 是普通写接口（JSON 请求体，如审核、搜索）的请求体上限。
+	// 为 0 或未配置时退回 constant.DefaultRequestBodyMaxBytes。
+	DefaultMaxBytes int64 `mapstructure:"defaultMaxBytes" json:"defaultMaxBytes" yaml:"defaultMaxBytes"`
+
+	// MultipartMaxBytes 是 multipart/form-data 请求（如带图创建帖子）的请求体上限。
+	// 为 0 或未配置时退回 constant.DefaultMultipartBodyMaxBytes。
+	MultipartMaxBytes int64 `mapstructure:"multipartMaxBytes" json:"multipartMaxBytes" yaml:"multipartMaxBytes"`
+
+	// RouteMaxBytes 按路由单独设置上限，key 为 Gin 注册的完整路由路径（如 "/api/v1/post/admin/posts/batch-audit"），
+	// 优先级高于上面两个按请求类型的默认值。注意 viper 会把 key 转为小写，因此按小写路径匹配。
+	RouteMaxBytes map[string]int64 `mapstructure:"routeMaxBytes" json:"routeMaxBytes" yaml:"routeMaxBytes"`
+}
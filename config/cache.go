@@ -1,11 +1,13 @@
 package config
 
+import "time"
+
 // ViewSyncConfig 包含浏览量同步任务相关的配置
 type ViewSyncConfig struct {
 	// BatchSize 是将 Redis 中的浏览量同步到 MySQL 数据库时，每个数据库操作批次处理的帖子数量。
 	// 例如，如果从 Redis 获取到 200,000 条帖子的浏览量需要同步，且 BatchSize 设置为 500，
 	// 则 BatchUpdatePostViewCounts 方法会将这 200,000 条数据分割成 200,000 / 500 = 400 个小批次。
-	// 每个小批次包含 500 条帖子的更新数据，将通过一次数据库 UPDATE 操作（例如使用 CASE WHEN 语句）完成。
+	// 每个小批次包含 500 条帖子的更新数据，将通过一次批量写入操作（按 UpdateStrategy 选择 ON DUPLICATE KEY UPDATE 或 CASE WHEN）完成。
 	// 这个参数主要影响单次数据库 UPDATE 语句的复杂度和处理的数据行数。
 	BatchSize int `mapstructure:"batchSize" json:"batchSize" yaml:"batchSize"`
 
@@ -23,4 +25,103 @@ type ViewSyncConfig struct {
 	// 较大的值可能会减少 SCAN 的迭代次数，但单次操作可能稍慢；较小的值则相反。
 	// 例如，如果设置为 1000，则 GetAllViewCounts 方法每次会尝试从 Redis 获取约 1000 个匹配的 Key。
 	ScanBatchSize int64 `mapstructure:"scanBatchSize" json:"scanBatchSize" yaml:"scanBatchSize"`
+
+	// UpdateStrategy 是单个批次写回 MySQL 的方式，可选值: "upsert"（默认）、"case_when"。
+	// 两种方式都只更新已存在且未删除帖子的 view_count 列，可以切换后对比任务日志中每个批次的 db耗时。
+	UpdateStrategy string `mapstructure:"updateStrategy" json:"updateStrategy" yaml:"updateStrategy"`
+
+	// SyncMode 是同步任务的数据来源，可选值: "incremental"（默认，只同步脏集合中的帖子）、"full"（SCAN 全量同步）。
+	// 增量模式下同步失败的脏标记会保留到下一轮重试；需要全量校准时临时切换为 "full"。
+	SyncMode string `mapstructure:"syncMode" json:"syncMode" yaml:"syncMode"`
+}
+
+// ViewCountConfig 包含浏览计数（防刷）相关的配置
+type ViewCountConfig struct {
+	// DedupWindow 是同一用户对同一帖子的浏览去重窗口，即 Bloom Filter 的过期时间。
+	// 在该窗口内同一用户重复浏览只计数一次。支持 "30m"、"12h" 这样的时长写法。
+	// 为 0 或未配置时退回 constant.BloomViewTTL。
+	DedupWindow time.Duration `mapstructure:"dedupWindow" json:"dedupWindow" yaml:"dedupWindow"`
+
+	// SlidingExpire 控制去重 Bloom Filter 过期时间的刷新方式，两种模式的防刷语义不同：
+	// - true（滑动窗口）：每位新访客都把整个过滤器的过期时间续期为 DedupWindow。只要帖子持续有新访客，过滤器就一直不过期，
+	//   已浏览过的用户在此期间再次浏览都不会计数，实际窗口变成“最后一位新访客之后的 DedupWindow”，热门帖子的老访客可能被长期拒绝计数。
+	// - false（固定窗口，默认）：只在过滤器创建时设置一次过期时间，到期后整体清空，所有用户重新获得一次计数机会。
+	//   同一用户在每个窗口内至多计数一次；跨越窗口边界时，两次计数的间隔可能短于 DedupWindow。
+	SlidingExpire bool `mapstructure:"slidingExpire" json:"slidingExpire" yaml:"slidingExpire"`
+
+	// CountAnonymousViews 控制是否统计未登录用户的浏览。
+	// 开启后匿名访客以网关透传的设备标识（X-Device-ID）或客户端 IP 参与去重，同一 NAT 出口后的多个访客可能只计一次。
+	CountAnonymousViews bool `mapstructure:"countAnonymousViews" json:"countAnonymousViews" yaml:"countAnonymousViews"`
+
+	// Whitelist 是浏览量防刷白名单中的用户 ID（内部测试账号、运营账号等），这些用户的浏览不计入真实浏览量。
+	// 运行期间可以通过 Redis Set (constant.ViewWhitelistKey) 追加白名单，无需重启服务。
+	Whitelist []string `mapstructure:"whitelist" json:"whitelist" yaml:"whitelist"`
+
+	// WhitelistRefreshInterval 是从 Redis 重新加载白名单到本地内存的间隔。
+	// 为 0 或未配置时退回 constant.ViewWhitelistRefreshInterval。
+	WhitelistRefreshInterval time.Duration `mapstructure:"whitelistRefreshInterval" json:"whitelistRefreshInterval" yaml:"whitelistRefreshInterval"`
+
+	// ColdThreshold 是浏览量计数器的冷数据阈值：超过该时长没有新增浏览、且不在热榜中的帖子，
+	// 其 Redis 计数器会被归档到 MySQL 后删除，之后有新浏览时再从 MySQL 回源。
+	// 为 0 或未配置时退回 constant.ViewColdThreshold。
+	ColdThreshold time.Duration `mapstructure:"coldThreshold" json:"coldThreshold" yaml:"coldThreshold"`
+
+	// ArchiveBatchSize 是归档任务每批处理的帖子数量，为 0 或未配置时退回 constant.ViewArchiveBatchSize。
+	ArchiveBatchSize int `mapstructure:"archiveBatchSize" json:"archiveBatchSize" yaml:"archiveBatchSize"`
+
+	// BloomCheckTopN 是每次检查去重 Bloom Filter 填充率的帖子数量（当前热榜前 N 个），
+	// 为 0 或未配置时退回 constant.BloomCapacityCheckTopN。
+	BloomCheckTopN int `mapstructure:"bloomCheckTopN" json:"bloomCheckTopN" yaml:"bloomCheckTopN"`
+
+	// BloomFillRatio 是触发 Bloom Filter 扩容的填充率（已插入数量 / 容量，取值 (0, 1]），
+	// 为 0 或未配置时退回 constant.BloomFillRatioThreshold。
+	BloomFillRatio float64 `mapstructure:"bloomFillRatio" json:"bloomFillRatio" yaml:"bloomFillRatio"`
+
+	// BloomExpansion 是 Bloom Filter 的扩容倍数，同时用作 RedisBloom 自动扩展子过滤器的 EXPANSION 参数，
+	// 为 0 或未配置时退回 constant.BloomExpansionFactor。
+	BloomExpansion int `mapstructure:"bloomExpansion" json:"bloomExpansion" yaml:"bloomExpansion"`
+
+	// AggregateWindow 是浏览增量在进程内聚合的窗口：去重仍逐次浏览判断，通过去重的浏览按帖子累加，每个窗口批量写入 Redis 一次。
+	// 窗口越大，热点帖子计数 Key 的写入越少，浏览量与热榜分数的延迟也越大；进程异常退出时最多丢失一个窗口内的增量。
+	// 为 0 或未配置时退回 constant.ViewCountAggregateWindow。
+	AggregateWindow time.Duration `mapstructure:"aggregateWindow" json:"aggregateWindow" yaml:"aggregateWindow"`
+
+	// RealtimeListViewCount 控制列表接口（时间线、用户帖子列表、搜索等）是否用 Redis 实时浏览量覆盖 MySQL 中的持久化值。
+	// 开启后每次列表请求额外发起一次 MGET（单次往返，超时 constant.ListViewCountReadTimeout），读取失败或超时时退回 MySQL 值。
+	RealtimeListViewCount bool `mapstructure:"realtimeListViewCount" json:"realtimeListViewCount" yaml:"realtimeListViewCount"`
+}
+
+// ViewConsistencyConfig 包含浏览量一致性校验任务（抽样比对 Redis 计数器与 MySQL posts.view_count）的配置
+type ViewConsistencyConfig struct {
+	// SampleSize 是每次校验抽样的帖子数量，为 0 或未配置时退回 constant.ViewConsistencySampleSize。
+	SampleSize int `mapstructure:"sampleSize" json:"sampleSize" yaml:"sampleSize"`
+
+	// BatchSize 是抽样时每批从 MySQL 读取、从 Redis MGET 的帖子数量，为 0 或未配置时退回 constant.ViewConsistencyBatchSize。
+	BatchSize int `mapstructure:"batchSize" json:"batchSize" yaml:"batchSize"`
+
+	// MinAbsDrift 是判定为显著漂移的最小绝对差值，为 0 或未配置时退回 constant.ViewConsistencyMinAbsDrift。
+	MinAbsDrift int64 `mapstructure:"minAbsDrift" json:"minAbsDrift" yaml:"minAbsDrift"`
+
+	// MinDriftRatio 是判定为显著漂移的最小相对差值（差值 / 两者中的较大值），为 0 或未配置时退回 constant.ViewConsistencyMinDriftRatio。
+	// 绝对差值与相对差值需同时超过阈值才视为显著漂移，避免小帖子的少量差异或大帖子的正常误差触发告警。
+	MinDriftRatio float64 `mapstructure:"minDriftRatio" json:"minDriftRatio" yaml:"minDriftRatio"`
+
+	// AutoFix 为 true 时，以 Redis 为准把显著漂移帖子的浏览量写回 MySQL。
+	// 只修正 Redis 大于 MySQL 的情况；MySQL 大于 Redis 通常意味着 Redis 计数器丢失，只告警不修正，避免把浏览量改小。
+	AutoFix bool `mapstructure:"autoFix" json:"autoFix" yaml:"autoFix"`
+}
+
+// TimelineCacheConfig 包含时间线首页缓存相关的配置
+// - 只缓存匿名用户、无游标、无筛选条件的首页请求，结果在 Redis 中按 pageSize、地区与是否折叠区分。
+type TimelineCacheConfig struct {
+	// Enabled 控制是否启用首页缓存，关闭时所有时间线请求直接查库。
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+
+	// TTL 是首页缓存的过期时间，期间新发布或新审核通过的帖子不会出现在首页（除非开启 InvalidateOnAudit）。
+	// 为 0 或未配置时退回 constant.TimelineHomeCacheTTL。
+	TTL time.Duration `mapstructure:"ttl" json:"ttl" yaml:"ttl"`
+
+	// InvalidateOnAudit 控制帖子因审核变为公开（或从公开变为不公开）时是否主动清除全部首页缓存。
+	// 审核量大时频繁清除会降低命中率，可关闭后只依赖 TTL 过期。
+	InvalidateOnAudit bool `mapstructure:"invalidateOnAudit" json:"invalidateOnAudit" yaml:"invalidateOnAudit"`
 }
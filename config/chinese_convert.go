@@ -0,0 +1,10 @@
+package config
+
+// ChineseConvertConfig 包含帖子标题/正文繁简转换相关的配置
+type ChineseConvertConfig struct {
+	// Enabled 为 true 时按用户偏好对返回的帖子做繁简转换；默认（未配置）关闭，始终返回原文。
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+
+	// CacheSize 转换结果 LRU 缓存的最大条目数，0 表示使用 constant.ChineseConvertDefaultCacheSize，负数关闭缓存。
+	CacheSize int `mapstructure:"cacheSize" json:"cacheSize" yaml:"cacheSize"`
+}
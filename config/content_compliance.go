@@ -0,0 +1,21 @@
+package config
+
+// ContentComplianceConfig 包含按地区区分的内容合规预检配置。
+// - Default 为所有帖子都要应用的通用规则集；Regions 以地区编码（与帖子投放定向的地区取值一致）为键，配置该地区额外的规则集。
+// - 帖子面向多个地区时合并默认规则与各目标地区的规则，取最严：词库取并集，任一规则集要求人工复审即要求人工复审。
+type ContentComplianceConfig struct {
+	// Enabled 为 false 时不做合规预检，待审核事件仍携带帖子的目标地区。
+	Enabled bool                         `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+	Default ComplianceRuleSet            `mapstructure:"default" json:"default" yaml:"default"`
+	Regions map[string]ComplianceRuleSet `mapstructure:"regions" json:"regions" yaml:"regions"`
+}
+
+// ComplianceRuleSet 是单个地区的合规规则集。
+type ComplianceRuleSet struct {
+	// BlockedWords 命中即拒绝创建/发布帖子的敏感词（忽略大小写的子串匹配）。
+	BlockedWords []string `mapstructure:"blockedWords" json:"blockedWords" yaml:"blockedWords"`
+	// ReviewWords 命中后允许送审，但在待审核事件中标记命中的词，提示审核服务重点审核。
+	ReviewWords []string `mapstructure:"reviewWords" json:"reviewWords" yaml:"reviewWords"`
+	// ManualReview 为 true 时该地区的帖子必须人工复审，不能只走自动审核。
+	ManualReview bool `mapstructure:"manualReview" json:"manualReview" yaml:"manualReview"`
+}
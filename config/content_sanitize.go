@@ -0,0 +1,8 @@
+package config
+
+// ContentSanitizeConfig 包含帖子正文 HTML 清洗相关的配置
+type ContentSanitizeConfig struct {
+	// Disabled 为 true 时关闭正文清洗，帖子内容按原文写库。
+	// 默认（未配置）开启清洗，避免遗漏配置时引入存储型 XSS。
+	Disabled bool `mapstructure:"disabled" json:"disabled" yaml:"disabled"`
+}
@@ -0,0 +1,13 @@
+package config
+
+// GatewayConfig 包含可信网关相关的配置
+// - 只有来自可信网关的请求才保留网关注入的用户画像请求头（地区、等级、标签），其余请求中的这些请求头会被移除。
+// - 两项都未配置时不信任任何请求，投放定向按匿名画像处理。
+type GatewayConfig struct {
+	// SharedSecret 是网关在每个请求上通过 X-Gateway-Token 请求头携带的共享密钥，为空时不按密钥识别网关。
+	SharedSecret string `mapstructure:"sharedSecret" json:"sharedSecret" yaml:"sharedSecret"`
+
+	// TrustedCIDRs 是网关所在的网段（如 "10.0.0.0/8"），直连来源地址落在其中的请求视为来自网关。
+	// 只看 TCP 连接的对端地址，不读取 X-Forwarded-For 等可伪造的请求头。
+	TrustedCIDRs []string `mapstructure:"trustedCIDRs" json:"trustedCIDRs" yaml:"trustedCIDRs"`
+}
@@ -0,0 +1,69 @@
+package config
+
+import "time"
+
+// ImageUploadConfig 包含创建帖子时上传图片的校验配置
+type ImageUploadConfig struct {
+	// MaxFileBytes 是单张图片的大小上限（字节），为 0 或未配置时退回 constant.DefaultPostImageMaxBytes。
+	MaxFileBytes int64 `mapstructure:"maxFileBytes" json:"maxFileBytes" yaml:"maxFileBytes"`
+
+	// MinCount 是单个帖子的图片数量下限，默认 0 表示可以不上传图片。
+	MinCount int `mapstructure:"minCount" json:"minCount" yaml:"minCount"`
+
+	// MaxCount 是单个帖子的图片数量上限，为 0 或未配置时退回 constant.DefaultPostImageMaxCount。
+	// - 请求绑定时 image_urls 与 image_object_keys 各自最多 9 个（与默认值一致），合计数量在下载 URL 图片之前按本上限校验。
+	MaxCount int `mapstructure:"maxCount" json:"maxCount" yaml:"maxCount"`
+
+	// KindCountLimits 按帖子类型覆盖图片数量上下限，键为 constant.PostImageKind*（如 goods、quote）。
+	// - 某个类型中为 0 的字段沿用上面的全局 MinCount / MaxCount。
+	KindCountLimits map[string]ImageCountLimit `mapstructure:"kindCountLimits" json:"kindCountLimits" yaml:"kindCountLimits"`
+
+	// Compression 上传前的有损压缩配置。
+	Compression ImageCompressionConfig `mapstructure:"compression" json:"compression" yaml:"compression"`
+
+	// URLImport 从 URL 导入图片（服务端下载后上传 COS）的配置。
+	URLImport ImageURLImportConfig `mapstructure:"urlImport" json:"urlImport" yaml:"urlImport"`
+
+	// PresignExpiry 是客户端直传 COS 的预签名上传 URL 的有效期，为 0 或未配置时退回 constant.DefaultImagePresignExpiry。
+	PresignExpiry time.Duration `mapstructure:"presignExpiry" json:"presignExpiry" yaml:"presignExpiry"`
+}
+
+// ImageCountLimit 描述某类帖子的图片数量上下限
+type ImageCountLimit struct {
+	MinCount int `mapstructure:"minCount" json:"minCount" yaml:"minCount"`
+	MaxCount int `mapstructure:"maxCount" json:"maxCount" yaml:"maxCount"`
+}
+
+// ImageCompressionConfig 包含上传前对图片做有损压缩的配置
+//   - 压缩失败、超时或压缩后体积没有变小时回退原图，不影响帖子创建。
+type ImageCompressionConfig struct {
+	// Enabled 是否在上传 COS 前压缩图片。
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+
+	// JPEGQuality 是 JPEG 重新编码的质量 (1-100)，为 0 或越界时退回 constant.DefaultImageJPEGQuality。
+	// - PNG 为无损格式，按最高压缩级别重新编码；GIF、WebP 不压缩，直接上传原图。
+	JPEGQuality int `mapstructure:"jpegQuality" json:"jpegQuality" yaml:"jpegQuality"`
+
+	// MinBytes 是触发压缩的最小文件大小（字节），更小的图片收益有限，直接上传原图；为 0 时退回 constant.DefaultImageCompressMinBytes。
+	MinBytes int64 `mapstructure:"minBytes" json:"minBytes" yaml:"minBytes"`
+
+	// MaxPixels 是允许解码压缩的最大像素数（宽 × 高），超出时直接上传原图，避免超大图片占满内存；为 0 时退回 constant.DefaultImageCompressMaxPixels。
+	MaxPixels int `mapstructure:"maxPixels" json:"maxPixels" yaml:"maxPixels"`
+
+	// Timeout 是单张图片的压缩耗时上限，超时回退原图；为 0 时退回 constant.DefaultImageCompressTimeout。
+	Timeout time.Duration `mapstructure:"timeout" json:"timeout" yaml:"timeout"`
+}
+
+// ImageURLImportConfig 包含创建帖子时从 URL 导入图片的配置
+//   - 下载的图片与直接上传的文件一样经过数量、大小、类型校验与压缩。
+//   - 只允许 http/https，且拒绝解析到回环、内网、链路本地等地址的 URL（含重定向后的地址），防止 SSRF。
+type ImageURLImportConfig struct {
+	// Enabled 是否允许通过 image_urls 导入图片，关闭时携带 image_urls 的请求返回 400。
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+
+	// Timeout 是单张图片下载的总耗时上限（含重定向与读取响应体）；为 0 时退回 constant.DefaultImageURLImportTimeout。
+	Timeout time.Duration `mapstructure:"timeout" json:"timeout" yaml:"timeout"`
+
+	// MaxRedirects 是最多跟随的重定向次数；为 0 时退回 constant.DefaultImageURLImportMaxRedirects，为负数时不跟随重定向。
+	MaxRedirects int `mapstructure:"maxRedirects" json:"maxRedirects" yaml:"maxRedirects"`
+}
@@ -1,14 +1,32 @@
 package config
 
+import "time"
+
 type KafkaConfig struct {
 	Brokers         []string `mapstructure:"brokers" json:"brokers" yaml:"brokers"`
 	Topics          Topics   `mapstructure:"topics" json:"topics" yaml:"topics"`
 	ConsumerGroupID string   `mapstructure:"consumer_group_id" json:"consumer_group_id" yaml:"consumer_group_id"`
+	// ConsumerConcurrency 每个消费者并发处理消息的 worker 数，同一帖子的消息始终由同一个 worker 顺序处理；为 0 时退回 constant.KafkaConsumerDefaultConcurrency
+	ConsumerConcurrency int `mapstructure:"consumer_concurrency" json:"consumer_concurrency" yaml:"consumer_concurrency"`
+	// SendMaxRetries 发送失败后的本地重试次数（不含首次发送），为 0 时退回 constant.KafkaSendMaxRetries
+	SendMaxRetries int `mapstructure:"send_max_retries" json:"send_max_retries" yaml:"send_max_retries"`
+	// SendRetryBackoff 首次重试前的等待时间，之后每次翻倍；为 0 时退回 constant.KafkaSendRetryBackoff
+	SendRetryBackoff time.Duration `mapstructure:"send_retry_backoff" json:"send_retry_backoff" yaml:"send_retry_backoff"`
 }
 
 type Topics struct {
-	PostPendingAudit  string `mapstructure:"postPendingAudit" yaml:"postPendingAudit"`   //  提交审核主题
-	PostAuditApproved string `mapstructure:"postAuditApproved" yaml:"postAuditApproved"` //  审核通过主题
-	PostAuditRejected string `mapstructure:"postAuditRejected" yaml:"postAuditRejected"` //  审核拒绝主题
-	PostDeleted       string `mapstructure:"postDeleted" yaml:"postDeleted"`             //  帖子删除主题
+	PostPendingAudit string `mapstructure:"postPendingAudit" yaml:"postPendingAudit"` //  提交审核主题
+	// PostPendingAuditHigh 高优先级提交审核主题，可选；为空时高优先级帖子仍发往 PostPendingAudit，仅通过消息头标记优先级
+	PostPendingAuditHigh string `mapstructure:"postPendingAuditHigh" yaml:"postPendingAuditHigh"`
+	PostAuditApproved    string `mapstructure:"postAuditApproved" yaml:"postAuditApproved"` //  审核通过主题
+	PostAuditRejected    string `mapstructure:"postAuditRejected" yaml:"postAuditRejected"` //  审核拒绝主题
+	PostDeleted          string `mapstructure:"postDeleted" yaml:"postDeleted"`             //  帖子删除主题
+	// TagNewPost 标签新帖推送主题，可选；为空时不推送标签订阅的新帖事件
+	TagNewPost string `mapstructure:"tagNewPost" yaml:"tagNewPost"`
+	// PostReconcileSnapshot 对账快照主题，可选；为空时不启动对账任务
+	PostReconcileSnapshot string `mapstructure:"postReconcileSnapshot" yaml:"postReconcileSnapshot"`
+	// PostReportThreshold 举报数达到阈值触发自动下架审查的主题，可选；为空时只记录举报，不发送事件
+	PostReportThreshold string `mapstructure:"postReportThreshold" yaml:"postReportThreshold"`
+	// UserInfoUpdated 用户服务发布的用户信息变更主题，可选；为空时不同步帖子中的作者头像与昵称
+	UserInfoUpdated string `mapstructure:"userInfoUpdated" yaml:"userInfoUpdated"`
 }
@@ -3,13 +3,28 @@ package config
 import "github.com/Xushengqwer/go-common/config"
 
 type PostConfig struct {
-	ZapConfig      config.ZapConfig     `mapstructure:"zapConfig" json:"zapConfig" yaml:"zapConfig"`
-	GormLogConfig  config.GormLogConfig `mapstructure:"gormLogConfig" json:"gormLogConfig" yaml:"gormLogConfig"`
-	ServerConfig   config.ServerConfig  `mapstructure:"serverConfig" json:"serverConfig" yaml:"serverConfig"`
-	TracerConfig   config.TracerConfig  `mapstructure:"tracerConfig" json:"tracerConfig" yaml:"tracerConfig"`
-	ViewSyncConfig ViewSyncConfig       `mapstructure:"viewSyncConfig" json:"viewSyncConfig" yaml:"viewSyncConfig"`
-	MySQLConfig    MySQLConfig          `mapstructure:"mysqlConfig" json:"mysqlConfig" yaml:"mysqlConfig"`
-	RedisConfig    RedisConfig          `mapstructure:"redisConfig" json:"redisConfig" yaml:"redisConfig"`
-	KafkaConfig    KafkaConfig          `mapstructure:"kafkaConfig" json:"kafkaConfig" yaml:"kafkaConfig"`
-	COSConfig      COSConfig            `mapstructure:"postDetailImagesCosConfig" json:"postDetailImagesCosConfig" yaml:"postDetailImagesCosConfig"`
+	ZapConfig         config.ZapConfig        `mapstructure:"zapConfig" json:"zapConfig" yaml:"zapConfig"`
+	GormLogConfig     config.GormLogConfig    `mapstructure:"gormLogConfig" json:"gormLogConfig" yaml:"gormLogConfig"`
+	ServerConfig      config.ServerConfig     `mapstructure:"serverConfig" json:"serverConfig" yaml:"serverConfig"`
+	TracerConfig      config.TracerConfig     `mapstructure:"tracerConfig" json:"tracerConfig" yaml:"tracerConfig"`
+	ViewSyncConfig    ViewSyncConfig          `mapstructure:"viewSyncConfig" json:"viewSyncConfig" yaml:"viewSyncConfig"`
+	ViewCountConfig   ViewCountConfig         `mapstructure:"viewCountConfig" json:"viewCountConfig" yaml:"viewCountConfig"`
+	MySQLConfig       MySQLConfig             `mapstructure:"mysqlConfig" json:"mysqlConfig" yaml:"mysqlConfig"`
+	RedisConfig       RedisConfig             `mapstructure:"redisConfig" json:"redisConfig" yaml:"redisConfig"`
+	KafkaConfig       KafkaConfig             `mapstructure:"kafkaConfig" json:"kafkaConfig" yaml:"kafkaConfig"`
+	COSConfig         COSConfig               `mapstructure:"postDetailImagesCosConfig" json:"postDetailImagesCosConfig" yaml:"postDetailImagesCosConfig"`
+	ReportConfig      ReportConfig            `mapstructure:"reportConfig" json:"reportConfig" yaml:"reportConfig"`
+	AuditPriority     AuditPriorityConfig     `mapstructure:"auditPriorityConfig" json:"auditPriorityConfig" yaml:"auditPriorityConfig"`
+	BodyLimit         BodyLimitConfig         `mapstructure:"bodyLimitConfig" json:"bodyLimitConfig" yaml:"bodyLimitConfig"`
+	TaskLock          TaskLockConfig          `mapstructure:"taskLockConfig" json:"taskLockConfig" yaml:"taskLockConfig"`
+	Task              TaskConfig              `mapstructure:"taskConfig" json:"taskConfig" yaml:"taskConfig"`
+	ContentSanitize   ContentSanitizeConfig   `mapstructure:"contentSanitizeConfig" json:"contentSanitizeConfig" yaml:"contentSanitizeConfig"`
+	ChineseConvert    ChineseConvertConfig    `mapstructure:"chineseConvertConfig" json:"chineseConvertConfig" yaml:"chineseConvertConfig"`
+	AdminDelete       AdminDeleteConfig       `mapstructure:"adminDeleteConfig" json:"adminDeleteConfig" yaml:"adminDeleteConfig"`
+	ViewConsistency   ViewConsistencyConfig   `mapstructure:"viewConsistencyConfig" json:"viewConsistencyConfig" yaml:"viewConsistencyConfig"`
+	ImageUpload       ImageUploadConfig       `mapstructure:"imageUploadConfig" json:"imageUploadConfig" yaml:"imageUploadConfig"`
+	Snapshot          SnapshotConfig          `mapstructure:"snapshotConfig" json:"snapshotConfig" yaml:"snapshotConfig"`
+	ContentCompliance ContentComplianceConfig `mapstructure:"contentComplianceConfig" json:"contentComplianceConfig" yaml:"contentComplianceConfig"`
+	TimelineCache     TimelineCacheConfig     `mapstructure:"timelineCacheConfig" json:"timelineCacheConfig" yaml:"timelineCacheConfig"`
+	Gateway           GatewayConfig           `mapstructure:"gatewayConfig" json:"gatewayConfig" yaml:"gatewayConfig"`
 }
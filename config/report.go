@@ -0,0 +1,21 @@
+package config
+
+// ReportConfig 包含帖子数据报表定时生成任务的配置
+type ReportConfig struct {
+	// Enabled 是否启用报表定时生成任务。
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+
+	// Period 报表统计周期，可选值: "daily"（日报）、"weekly"（周报）。
+	// - 决定默认的 cron 调度时间以及报表在 COS 中的存放目录。
+	Period string `mapstructure:"period" json:"period" yaml:"period"`
+
+	// CronSpec 可选，自定义 cron 表达式；为空时按 Period 使用默认调度
+	// （日报每天 02:00，周报每周一 03:00）。
+	CronSpec string `mapstructure:"cronSpec" json:"cronSpec" yaml:"cronSpec"`
+
+	// GroupBy 报表分组维度，可选值: "none"（不分组，全站统一排名）、"official_tag"（按官方标签分组，组内排名）。
+	GroupBy string `mapstructure:"groupBy" json:"groupBy" yaml:"groupBy"`
+
+	// TopN 每个分组最多输出的帖子数量，0 表示不限制。
+	TopN int `mapstructure:"topN" json:"topN" yaml:"topN"`
+}
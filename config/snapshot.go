@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// SnapshotConfig 包含帖子指标定时快照任务的配置
+// - 未配置（为 0）的参数退回 constant.Snapshot* 默认值。
+type SnapshotConfig struct {
+	// Enabled 是否启用帖子指标快照任务。
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
+
+	// ActiveWindow 活跃帖子的判定窗口：该时长内有新增浏览的帖子才做小时级快照。
+	ActiveWindow time.Duration `mapstructure:"activeWindow" json:"activeWindow" yaml:"activeWindow"`
+
+	// MaxPostsPerRun 单次快照最多覆盖的帖子数量，按最近活跃时间倒序截取，控制单次写入量。
+	MaxPostsPerRun int `mapstructure:"maxPostsPerRun" json:"maxPostsPerRun" yaml:"maxPostsPerRun"`
+
+	// HourlyRetentionDays 小时级快照的保留天数。
+	HourlyRetentionDays int `mapstructure:"hourlyRetentionDays" json:"hourlyRetentionDays" yaml:"hourlyRetentionDays"`
+
+	// DailyRetentionDays 日级快照的保留天数。
+	DailyRetentionDays int `mapstructure:"dailyRetentionDays" json:"dailyRetentionDays" yaml:"dailyRetentionDays"`
+}
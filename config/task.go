@@ -0,0 +1,12 @@
+package config
+
+// TaskConfig 包含定时任务的调度配置
+//   - 调度表达式使用标准 5 段 cron 格式，也支持 "@every 15m"、"@daily" 等描述符。
+//   - 启动时校验表达式，非法时服务拒绝启动。
+type TaskConfig struct {
+	// ViewCountSyncCron 是浏览量同步任务的调度表达式，为空时退回 constant.SyncViewCountInterval。
+	ViewCountSyncCron string `mapstructure:"viewCountSyncCron" json:"viewCountSyncCron" yaml:"viewCountSyncCron"`
+
+	// HotCacheCron 是热帖缓存刷新任务的调度表达式，为空时退回 constant.HotPostsCacheCronSpec。
+	HotCacheCron string `mapstructure:"hotCacheCron" json:"hotCacheCron" yaml:"hotCacheCron"`
+}
@@ -0,0 +1,28 @@
+package config
+
+import "time"
+
+// TaskLockConfig 包含定时任务分布式锁相关的配置
+// 服务多副本部署时，每个副本都会启动相同的进程内 cron，依靠分布式锁保证同一时刻只有一个副本真正执行任务。
+type TaskLockConfig struct {
+	// ViewCountSyncKey 是浏览量同步任务的锁 Key，为空时退回 constant.ViewCountSyncLockKey。
+	ViewCountSyncKey string `mapstructure:"viewCountSyncKey" json:"viewCountSyncKey" yaml:"viewCountSyncKey"`
+
+	// ViewCountSyncTTL 是浏览量同步任务锁的过期时间，必须大于任务超时 constant.ViewCountSyncTimeout；
+	// 为 0 或不大于任务超时时退回 constant.ViewCountSyncLockTTL。
+	ViewCountSyncTTL time.Duration `mapstructure:"viewCountSyncTTL" json:"viewCountSyncTTL" yaml:"viewCountSyncTTL"`
+
+	// HotPostsCacheKey 是热帖缓存刷新任务的锁 Key，为空时退回 constant.HotPostsCacheLockKey。
+	HotPostsCacheKey string `mapstructure:"hotPostsCacheKey" json:"hotPostsCacheKey" yaml:"hotPostsCacheKey"`
+
+	// HotPostsCacheTTL 是热帖缓存刷新任务锁的过期时间，必须大于任务超时 constant.HotPostsCacheTimeout；
+	// 为 0 或不大于任务超时时退回 constant.HotPostsCacheLockTTL。
+	HotPostsCacheTTL time.Duration `mapstructure:"hotPostsCacheTTL" json:"hotPostsCacheTTL" yaml:"hotPostsCacheTTL"`
+
+	// LikeCountSyncKey 是点赞数同步任务的锁 Key，为空时退回 constant.LikeCountSyncLockKey。
+	LikeCountSyncKey string `mapstructure:"likeCountSyncKey" json:"likeCountSyncKey" yaml:"likeCountSyncKey"`
+
+	// LikeCountSyncTTL 是点赞数同步任务锁的过期时间，必须大于任务超时 constant.LikeCountSyncTimeout；
+	// 为 0 或不大于任务超时时退回 constant.LikeCountSyncLockTTL。
+	LikeCountSyncTTL time.Duration `mapstructure:"likeCountSyncTTL" json:"likeCountSyncTTL" yaml:"likeCountSyncTTL"`
+}
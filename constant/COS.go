@@ -1,3 +1,86 @@
 package constant
 
+import "time"
+
 const COSObjectKeyPrefixPostImages = "posts/images/"
+
+// COSObjectKeyPrefixPostDirectUploads 客户端凭预签名 URL 直传的帖子图片在 COS 中的存放前缀，完整路径为 posts/direct/{userID}/{yyyyMMdd}/{uuid}.{ext}
+// - 发帖时引用的对象键必须位于作者本人的 posts/direct/{userID}/ 之下，防止引用他人的对象
+// - 该前缀只是暂存区：发帖时对象被复制到 COSObjectKeyPrefixPostImages 下，帖子只引用复制后的对象
+// - 暂存对象不会被本服务删除，应在存储桶上为该前缀配置按天过期的生命周期规则
+const COSObjectKeyPrefixPostDirectUploads = "posts/direct/"
+
+// COSObjectKeyPrefixPostReports 帖子数据报表在 COS 中的存放前缀，完整路径为 reports/posts/{period}/{yyyyMMdd}_{groupBy}.csv
+const COSObjectKeyPrefixPostReports = "reports/posts/"
+
+// 创建帖子时上传图片的默认校验参数，可通过 ImageUploadConfig 覆盖
+const (
+	DefaultPostImageMaxBytes int64 = 10 << 20 // 单张图片默认上限 10MB
+	DefaultPostImageMaxCount       = 9        // 单个帖子默认最多 9 张图片
+
+	// PostImageSniffBytes 是识别图片真实类型时读取的文件头长度，与 http.DetectContentType 使用的长度一致
+	PostImageSniffBytes = 512
+)
+
+// 上传前压缩图片的默认参数，可通过 ImageUploadConfig.Compression 覆盖
+const (
+	DefaultImageJPEGQuality             = 85              // JPEG 重新编码的默认质量
+	DefaultImageCompressMinBytes  int64 = 200 << 10       // 小于 200KB 的图片不压缩
+	DefaultImageCompressMaxPixels       = 40_000_000      // 超过 4000 万像素的图片不解码压缩
+	DefaultImageCompressTimeout         = 3 * time.Second // 单张图片压缩耗时上限
+
+	// ImageCompressConcurrency 是同一请求内并发压缩的图片数量上限，压缩为 CPU 密集操作，不宜过大。
+	ImageCompressConcurrency = 4
+
+	// ImageUploadConcurrency 是同一请求内并发上传到 COS 的图片数量上限，保留原图的图片与其原图在同一个任务内上传。
+	ImageUploadConcurrency = 5
+)
+
+// 创建帖子时从 URL 导入图片的默认参数，可通过 ImageUploadConfig.URLImport 覆盖
+const (
+	DefaultImageURLImportTimeout      = 10 * time.Second // 单张图片下载的总耗时上限（含重定向）
+	DefaultImageURLImportMaxRedirects = 3                // 下载时最多跟随的重定向次数
+	ImageURLImportDialTimeout         = 3 * time.Second  // 建立 TCP 连接的超时
+
+	// ImageURLImportConcurrency 是同一请求内并发下载的图片数量上限。
+	ImageURLImportConcurrency = 4
+)
+
+// 客户端直传图片的默认参数，可通过 ImageUploadConfig 覆盖
+const (
+	// DefaultImagePresignExpiry 是预签名上传 URL 的默认有效期。
+	DefaultImagePresignExpiry = 15 * time.Minute
+
+	// ImageDirectUploadCheckConcurrency 是发帖时并发查询直传对象元数据的数量上限。
+	ImageDirectUploadCheckConcurrency = 5
+)
+
+// 按图片数量上下限区分的帖子类型，作为 ImageUploadConfig.KindCountLimits 的键
+const (
+	PostImageKindDefault = "default" // 普通帖子
+	PostImageKindGoods   = "goods"   // 商品帖：填写了单价 (PricePerUnit > 0)
+	PostImageKindQuote   = "quote"   // 转发帖：设置了 QuotedPostID
+)
+
+// 软删除帖子的 COS 图片延迟删除参数
+//   - 帖子软删除后图片先保留 COSDeleteRetention，期间管理员恢复帖子可以完整找回图片；超过保留期后才真正删除 COS 对象。
+const (
+	// COSDeleteRetention 软删除帖子的图片在 COS 中的保留期。
+	COSDeleteRetention = 7 * 24 * time.Hour
+
+	// COSDeleteCronSpec COS 延迟删除任务的执行频率。
+	COSDeleteCronSpec = "@every 10m"
+
+	// COSDeleteTimeout COS 延迟删除任务单次执行的超时。
+	COSDeleteTimeout = 5 * time.Minute
+
+	// COSDeleteLockKey / COSDeleteLockTTL COS 延迟删除任务分布式锁，TTL 必须大于任务超时。
+	COSDeleteLockKey = "task_lock:cos_delete"
+	COSDeleteLockTTL = 6 * time.Minute
+
+	// COSDeleteBatchSize 每轮从队列中读取的到期对象键数量上限。
+	COSDeleteBatchSize = 500
+
+	// COSDeleteRetryDelay 删除失败的对象键重新入队后的重试延迟。
+	COSDeleteRetryDelay = 30 * time.Minute
+)
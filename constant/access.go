@@ -0,0 +1,21 @@
+package constant
+
+// 帖子详情访问策略 (Post.AccessPolicy)，按位组合，0 表示公开
+// - 校验顺序固定为：需登录 -> 需付费 -> 需关注，第一个不通过的策略决定返回给前端的引导操作。
+// - 作者本人访问自己的帖子时不做校验。
+const (
+	AccessPolicyPublic   = 0      // 公开，任何人可访问
+	AccessPolicyLogin    = 1 << 0 // 需登录
+	AccessPolicyPaid     = 1 << 1 // 需付费
+	AccessPolicyFollower = 1 << 2 // 需关注作者
+
+	// AccessPolicyMask 是所有已定义策略位的并集，用于校验请求中的策略取值
+	AccessPolicyMask = AccessPolicyLogin | AccessPolicyPaid | AccessPolicyFollower
+)
+
+// 访问策略位对应的名称，返回给前端用于引导用户操作（登录 / 付费 / 关注）
+const (
+	AccessPolicyNameLogin    = "login"
+	AccessPolicyNamePaid     = "paid"
+	AccessPolicyNameFollower = "follower"
+)
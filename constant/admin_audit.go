@@ -0,0 +1,43 @@
+package constant
+
+import "time"
+
+// 管理员操作审计日志的操作类型 (AdminAuditLog.Action)
+const (
+	AdminActionAuditPost         = "audit_post"          // 审核单个帖子
+	AdminActionBatchAuditPosts   = "batch_audit_posts"   // 批量审核帖子
+	AdminActionUpdateOfficialTag = "update_official_tag" // 修改帖子官方标签
+	AdminActionBatchOfficialTag  = "batch_official_tag"  // 批量修改帖子官方标签
+	AdminActionAddOfficialTag    = "add_official_tag"    // 为帖子追加一个官方标签
+	AdminActionRemoveOfficialTag = "remove_official_tag" // 移除帖子的一个官方标签
+	AdminActionDeletePost        = "delete_post"         // 管理员删除帖子
+	AdminActionRestorePost       = "restore_post"        // 恢复已删除的帖子
+	AdminActionReconcileResync   = "reconcile_resync"    // 对账补偿：重新发送帖子同步事件
+	AdminActionAppealPost        = "appeal_post"         // 作者申诉被拒帖子，帖子重新送审（操作人为 PostAuditOperatorAuthorPrefix + 作者ID）
+)
+
+// 管理员操作审计日志的操作结果 (AdminAuditLog.Result)
+const (
+	AdminAuditResultSuccess = "success" // 操作成功
+	AdminAuditResultFailure = "failure" // 操作失败
+	AdminAuditResultPartial = "partial" // 批量操作部分成功
+)
+
+// 管理员操作审计日志的目标类型 (AdminAuditLog.TargetType)
+const (
+	AdminAuditTargetPost      = "post"       // 单个帖子，TargetID 为帖子 ID
+	AdminAuditTargetPostBatch = "post_batch" // 一批帖子，帖子 ID 列表记录在 Params 中
+)
+
+// AdminAuditOperatorAuditService 是审核服务通过 Kafka 回传审核结果时，审计日志中记录的操作人。
+const AdminAuditOperatorAuditService = "system:audit_service"
+
+// PostAuditOperatorAuthorPrefix 是作者本人触发状态流转（如申诉）时，帖子审计日志中操作人的前缀，完整取值为 "author:{userID}"。
+const PostAuditOperatorAuthorPrefix = "author:"
+
+// AdminAuditWriteTimeout 是写入一条审计日志的超时时间。
+// 审计日志与主操作解耦：使用脱离请求取消的上下文写入，失败只记录日志，不影响主操作结果。
+const AdminAuditWriteTimeout = 3 * time.Second
+
+// AdminAuditErrorMessageMaxLen 是审计日志中失败原因的最大字符数，超出部分截断。
+const AdminAuditErrorMessageMaxLen = 512
@@ -0,0 +1,63 @@
+package constant
+
+// 帖子审核优先级 (Post.AuditPriority)
+const (
+	AuditPriorityNormal = 0 // 普通优先级
+	AuditPriorityHigh   = 1 // 高优先级（热门作者或付费加急）
+)
+
+// KafkaHeaderAuditPriority 是待审核事件中携带审核优先级的 Kafka 消息头，取值为 "normal" 或 "high"。
+// 审核服务可据此优先处理高优先级帖子（即使高优先级主题未单独配置）。
+const KafkaHeaderAuditPriority = "audit-priority"
+
+// 地区差异化合规审核相关的待审核事件消息头（kafkaevents.PostData 由公共库定义，附加信息通过消息头携带）
+const (
+	// KafkaHeaderAuditRegions 携带帖子的目标地区，逗号分隔；未设置地区定向时不携带。
+	KafkaHeaderAuditRegions = "audit-regions"
+	// KafkaHeaderAuditFlaggedWords 携带预检命中的待复审敏感词，逗号分隔；未命中时不携带。
+	KafkaHeaderAuditFlaggedWords = "audit-flagged-words"
+	// KafkaHeaderAuditManualReview 取值 "true" 表示目标地区的规则要求人工复审；不要求时不携带。
+	KafkaHeaderAuditManualReview = "audit-manual-review"
+)
+
+// 申诉重审相关的待审核事件消息头，只在作者申诉被拒帖子后重新送审时携带
+const (
+	// KafkaHeaderAuditAppealReason 携带作者填写的申诉理由。
+	KafkaHeaderAuditAppealReason = "audit-appeal-reason"
+	// KafkaHeaderAuditAppealCount 携带包含本次在内的累计申诉次数。
+	KafkaHeaderAuditAppealCount = "audit-appeal-count"
+)
+
+// PostAppealMaxCount 是单个帖子允许申诉的最大次数，防止作者反复申诉占用人工审核资源。
+const PostAppealMaxCount = 3
+
+// 本服务发往审核结果主题的事件通过消息头标记来源；这类事件对应的状态已由本服务直接写入，
+// 自身的审核结果消费者收到后直接跳过，避免回环消费把之后的人工改判覆盖掉。
+const (
+	// KafkaHeaderEventSource 携带事件的发送方。
+	KafkaHeaderEventSource = "event-source"
+	// EventSourcePostService 表示事件由本服务发送。
+	EventSourcePostService = "post-service"
+)
+
+// 分段增量审核相关的消息头：作者编辑帖子后重新送审时只把变更的正文段落放入事件的 Content。
+// 段落序号是该段在编辑后完整正文中的位置（按行切分、忽略空行，从 1 开始），逗号分隔。
+const (
+	// KafkaHeaderAuditIncremental 取值 "true" 表示事件 Content 只包含变更的段落，未携带的段落沿用上次审核结果；整篇送审时不携带。
+	KafkaHeaderAuditIncremental = "audit-incremental"
+	// KafkaHeaderAuditSegments 携带 Content 中各段落的序号，与 Content 中的段落一一对应；没有变更的段落时不携带。
+	KafkaHeaderAuditSegments = "audit-segments"
+	// KafkaHeaderAuditAddedSegments 携带其中新增段落的序号，其余段落是对原有段落的修改；没有新增段落时不携带。
+	KafkaHeaderAuditAddedSegments = "audit-added-segments"
+	// KafkaHeaderAuditRejectedSegments 由审核服务在审核拒绝事件中回传未通过的段落序号（与送审时的序号一致）；
+	// 未携带时本服务按拒绝详情中的命中内容定位段落，仍无法定位则视为本次送审的段落全部未通过。
+	KafkaHeaderAuditRejectedSegments = "audit-rejected-segments"
+)
+
+// 分段增量审核的降级阈值：变更的段落超过任一阈值时整篇重新送审，增量审核不再节省成本，整篇审核的上下文也更完整。
+const (
+	// IncrementalAuditMaxSegments 是单次增量送审的最大段落数，同时保证段落序号消息头不超过发件箱消息头的列宽。
+	IncrementalAuditMaxSegments = 50
+	// IncrementalAuditMaxChangedPercent 是变更段落占编辑后全部段落的最大百分比。
+	IncrementalAuditMaxChangedPercent = 50
+)
@@ -0,0 +1,20 @@
+package constant
+
+// 浏览量里程碑徽章参数
+//   - 帖子浏览量（以同步到 MySQL 的值为准）首次达到某个里程碑时，为作者颁发一枚对应的徽章，每个帖子的每个里程碑只颁发一次。
+const (
+	// BadgeAwardBatchSize 颁发徽章时每批查询帖子与已有徽章的数量。
+	BadgeAwardBatchSize = 500
+)
+
+// ViewMilestones 浏览量里程碑，按从小到大排列。
+var ViewMilestones = []int64{100, 1000, 10000, 100000, 1000000}
+
+// ViewMilestoneBadgeNames 里程碑对应的徽章名称。
+var ViewMilestoneBadgeNames = map[int64]string{
+	100:     "初露锋芒",
+	1000:    "小有名气",
+	10000:   "人气之作",
+	100000:  "现象级",
+	1000000: "百万传阅",
+}
@@ -11,3 +11,72 @@ const (
 	// 这个时间窗口决定了在多长时间内，同一用户的浏览只被计数一次。
 	BloomViewTTL time.Duration = 12 * time.Hour
 )
+
+// 浏览去重 Bloom Filter 扩容参数
+const (
+	// BloomCapacityCheckCronSpec 是检查 Bloom Filter 填充率的执行频率。
+	BloomCapacityCheckCronSpec = "@every 5m"
+	// BloomCapacityCheckTimeout 是单次检查的超时。
+	BloomCapacityCheckTimeout = time.Minute
+	// BloomCapacityCheckTopN 是每次检查的帖子数量（当前热榜前 N 个），只有访问用户多的帖子才可能接近容量。
+	BloomCapacityCheckTopN = 200
+	// BloomFillRatioThreshold 是触发扩容的填充率（已插入数量 / 容量）。
+	BloomFillRatioThreshold = 0.8
+	// BloomExpansionFactor 是扩容倍数，同时作为 RedisBloom 自动扩展子过滤器时的 EXPANSION 参数。
+	BloomExpansionFactor = 2
+)
+
+// 分钟级浏览量时间桶参数
+const (
+	// ViewBucketRetentionMinutes 是分钟桶的最大保留数量，也是 GetViewsInWindow 可查询的最大窗口。
+	ViewBucketRetentionMinutes = 60
+	// ViewBucketTTL 是每个分钟桶的过期时间，比保留窗口多留一分钟以覆盖桶边界。
+	ViewBucketTTL time.Duration = (ViewBucketRetentionMinutes + 1) * time.Minute
+)
+
+// 匿名浏览计数参数：未登录用户以客户端标识作为去重 Bloom Filter 的成员
+const (
+	// AnonymousViewerPrefix 是匿名访客在去重 Bloom Filter 中的成员前缀，与登录用户 ID 区分开。
+	// 完整成员: "anon:device:{设备ID}" 或 "anon:ip:{客户端IP}"
+	AnonymousViewerPrefix = "anon:"
+
+	// AnonymousClientIDMaxLen 是网关透传的设备标识的最大长度，超长时视为无效并退回客户端 IP。
+	AnonymousClientIDMaxLen = 128
+
+	// AnonymousDeviceClientPrefix 是带设备标识的客户端标识前缀，只有设备标识足够稳定，可以在登录后与用户关联。
+	AnonymousDeviceClientPrefix = "device:"
+
+	// AnonymousDeviceViewsMaxPosts 是每个设备最多保留的匿名浏览记录数量，超出时丢弃最早的记录。
+	AnonymousDeviceViewsMaxPosts = 500
+)
+
+// 浏览增量进程内聚合参数
+const (
+	// ViewCountAggregateWindow 是浏览增量在进程内聚合、批量写入 Redis 的默认窗口。
+	ViewCountAggregateWindow = time.Second
+	// ViewCountMarkTimeout 是单次浏览去重判断的超时。
+	ViewCountMarkTimeout = 2 * time.Second
+	// ViewCountFlushTimeout 是单次批量写入浏览增量的超时。
+	ViewCountFlushTimeout = 5 * time.Second
+	// ListViewCountReadTimeout 是列表接口批量读取实时浏览量的超时，超时后退回 MySQL 值，避免 Redis 抖动拖慢列表接口。
+	ListViewCountReadTimeout = 100 * time.Millisecond
+	// ViewCountAggregateRetryMaxAttempts 是同一帖子的浏览增量连续写入失败后最多保留重试的窗口数，超过后丢弃。
+	ViewCountAggregateRetryMaxAttempts = 10
+	// ViewCountAggregateRetryMaxPosts 是单个窗口写入失败后最多放回本地重试的帖子数，Redis 长时间不可用时限制本地增量占用的内存。
+	ViewCountAggregateRetryMaxPosts = 10000
+)
+
+// ViewWhitelistRefreshInterval 是浏览量白名单从 Redis 重新加载到本地内存的默认间隔。
+const ViewWhitelistRefreshInterval time.Duration = 30 * time.Second
+
+// 浏览量冷数据归档参数
+const (
+	// ViewColdThreshold 是浏览量计数器的默认冷数据阈值：超过该时长没有新增浏览的帖子，其 Redis 计数器会被归档到 MySQL 后删除。
+	ViewColdThreshold time.Duration = 7 * 24 * time.Hour
+	// ViewArchiveBatchSize 是归档任务每批处理的帖子数量。
+	ViewArchiveBatchSize = 200
+)
+
+// BatchIncrementRankMaxMembers 是 BatchIncrementRank 单个 Lua 脚本最多处理的帖子数量。
+// - 超出时拆分为多个脚本依次执行，避免单个脚本长时间阻塞 Redis；每个脚本内部的更新是原子的。
+const BatchIncrementRankMaxMembers = 200
@@ -0,0 +1,64 @@
+package constant
+
+import "time"
+
+// 帖子详情缓存的过期时间
+const (
+	// HotPostDetailFallbackTTL 是热门帖子详情缓存未命中、回源数据库后写回 Redis 的过期时间。
+	// 定时任务写入的热门详情不过期，回源写入的数据设置较短 TTL，避免已跌出热榜的帖子长期占用缓存。
+	HotPostDetailFallbackTTL time.Duration = 10 * time.Minute
+
+	// PostDetailNormalCacheTTL 是普通帖子详情缓存的过期时间。
+	PostDetailNormalCacheTTL time.Duration = 5 * time.Minute
+)
+
+// 帖子投放定向条件缓存参数
+const (
+	// PostTargetingCacheTTL 是投放定向条件缓存的过期时间。定向条件创建后不再修改，TTL 只用于回收不再访问的帖子。
+	PostTargetingCacheTTL = 30 * time.Minute
+
+	// PostTargetingCacheNone 是帖子未配置定向条件时写入的占位值，避免无定向的帖子每次都回源 MySQL。
+	PostTargetingCacheNone = "none"
+)
+
+// HotPostsTagFillMaxRounds 是按官方标签查询热门帖子时，单次请求最多读取标签热榜的轮数。
+// 过滤（投放定向、缓存缺失）后不足一页时会继续向后读取，直到凑满一页、榜单读完或达到该轮数。
+const HotPostsTagFillMaxRounds = 3
+
+// HotListZAddBatchSize 是生成热榜快照时 Lua 脚本每次 ZADD 写入的成员数量。
+// 分批写入避免 unpack 一次展开全部成员触发 Lua 栈/参数数量上限（热榜 N 达到数千时会报错）。
+const HotListZAddBatchSize = 500
+
+// RealtimeViewCountReadTimeout 是帖子详情读取 Redis 实时浏览量的超时时间。
+// 超时或 Redis 不可用时降级为 MySQL/缓存中的浏览量，避免拖慢详情接口。
+const RealtimeViewCountReadTimeout = 200 * time.Millisecond
+
+// 热榜降级参数：Redis 不可用时热门列表改为从 MySQL 按浏览量查询近似热榜
+const (
+	// HotPostsRedisRetryInterval 是热榜读取 Redis 失败后直接走降级路径的时长，期满后的下一次请求重新尝试 Redis，成功即切回。
+	HotPostsRedisRetryInterval = 10 * time.Second
+
+	// HotPostsFallbackCacheTTL 是降级热榜在本地内存中的缓存时间，避免 Redis 故障期间每次请求都查询 MySQL。
+	HotPostsFallbackCacheTTL = 30 * time.Second
+)
+
+// 帖子详情缓存损坏的自动修复参数：反序列化失败的 Key 会被删除，由上层回源重建
+const (
+	// PostDetailCacheRepairInterval 是同一帖子两次删除损坏详情缓存的最小间隔，防止写入端持续写入坏数据时反复删除、回源。
+	PostDetailCacheRepairInterval = time.Minute
+
+	// PostDetailCorruptSampleBytes 是记录损坏事件时保留的缓存内容前缀长度，用于排查序列化兼容问题。
+	PostDetailCorruptSampleBytes = 256
+)
+
+// PostStatsCacheTTL 是管理后台帖子数量统计的缓存时间，避免看板频繁刷新时反复做全表聚合。
+const PostStatsCacheTTL = time.Minute
+
+// 时间线首页缓存参数：匿名用户、无游标、无筛选条件的首页请求结果在 Redis 中短期缓存
+const (
+	// TimelineHomeCacheTTL 是首页缓存的默认过期时间。
+	TimelineHomeCacheTTL = 30 * time.Second
+
+	// TimelineHomeCacheMaxRegionLen 是参与首页缓存 Key 的地区编码最大长度，超长的地区（异常请求头）不走缓存，避免 Key 数量失控。
+	TimelineHomeCacheMaxRegionLen = 16
+)
@@ -0,0 +1,7 @@
+package constant
+
+// 繁简转换参数
+const (
+	ChineseConvertDefaultCacheSize = 4096 // 转换结果 LRU 缓存的默认条目数
+	ChineseConvertCacheMinRunes    = 16   // 短于该长度的文本直接转换，不进入缓存（查表比缓存命中更便宜）
+)
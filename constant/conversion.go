@@ -0,0 +1,44 @@
+package constant
+
+import "time"
+
+// 帖子转化归因统计参数
+//   - 漏斗分为三个阶段：浏览 (view) → 联系 (contact) → 成交 (deal)，由客户端上报，只统计登录用户。
+//   - 同一用户对同一帖子的同一阶段每天只计一次；统计按自然日分桶存储在 Redis，保留 ConversionStatsRetention。
+//   - 浏览事件携带来源并记为用户的最近触点；归因窗口内的联系、成交计入该来源，窗口内没有浏览时计入 ConversionSourceDirect。
+const (
+	ConversionEventView    = "view"    // 浏览详情
+	ConversionEventContact = "contact" // 联系作者（查看联系方式、发起咨询等）
+	ConversionEventDeal    = "deal"    // 成交
+
+	// ConversionSourceDirect 归因窗口内没有浏览记录的联系、成交计入的来源。
+	ConversionSourceDirect = "direct"
+
+	// ConversionAttributionWindow 浏览触点的有效期：联系、成交只归因到该时长内最近一次浏览的来源。
+	ConversionAttributionWindow = 7 * 24 * time.Hour
+
+	// ConversionDedupWindow 同一用户对同一帖子同一阶段的去重窗口。
+	ConversionDedupWindow = 24 * time.Hour
+
+	// ConversionStatsRetention 每日统计 Hash 的保留时长，超过后自动过期。
+	ConversionStatsRetention = 90 * 24 * time.Hour
+
+	// ConversionStatsDays 查询转化漏斗时返回的最近天数（含当天）。
+	ConversionStatsDays = 30
+
+	// ConversionStatsDateLayout 每日统计 Key 中日期的格式。
+	ConversionStatsDateLayout = "20060102"
+
+	// ConversionStatsSourceSeparator 每日统计 Hash 中按来源计数的字段分隔符，字段为 "{阶段}:{来源}"。
+	ConversionStatsSourceSeparator = ":"
+)
+
+// ConversionSources 浏览事件允许携带的来源白名单。
+var ConversionSources = map[string]bool{
+	"timeline": true, // 时间线/关注流
+	"hot":      true, // 热榜
+	"search":   true, // 搜索
+	"profile":  true, // 作者主页
+	"share":    true, // 站外分享链接
+	"other":    true, // 其他入口
+}
@@ -0,0 +1,9 @@
+package constant
+
+// 帖子版权声明类型 (Post.CopyrightType)
+// - 转载帖必须填写来源地址 (SourceURL)，其余类型不保存来源地址。
+const (
+	CopyrightTypeOriginal = 0 // 原创（默认）
+	CopyrightTypeRepost   = 1 // 转载
+	CopyrightTypeNoRepost = 2 // 原创，禁止转载
+)
@@ -0,0 +1,10 @@
+package constant
+
+// 帖子 A/B 封面实验参数
+const (
+	CoverCandidatesMin = 2 // 开启 A/B 封面实验所需的最少候选封面数量，少于该数量时不做分桶
+	CoverCandidatesMax = 4 // 单个帖子最多可设置的候选封面数量
+
+	CoverStatsImpressionField = "imp:" // 封面曝光数在统计 Hash 中的字段前缀，后接 imageID
+	CoverStatsClickField      = "clk:" // 封面点击数在统计 Hash 中的字段前缀，后接 imageID
+)
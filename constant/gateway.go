@@ -0,0 +1,18 @@
+package constant
+
+// 网关相关的请求头与上下文 Key
+const (
+	// HeaderGatewayToken 是网关携带共享密钥的请求头，对应 config.GatewayConfig.SharedSecret
+	HeaderGatewayToken = "X-Gateway-Token"
+
+	// 网关透传的用户画像请求头，用于帖子投放定向过滤；只在请求来自可信网关时保留
+	HeaderUserRegion = "X-User-Region" // 用户所在地区编码
+	HeaderUserLevel  = "X-User-Level"  // 用户等级（整数）
+	HeaderUserTags   = "X-User-Tags"   // 用户标签，逗号分隔
+
+	// GatewayTrustedContextKey 是 gin 上下文中标记请求是否来自可信网关的 Key (bool)
+	GatewayTrustedContextKey = "gatewayTrusted"
+)
+
+// GatewayProfileHeaders 是只接受可信网关注入的用户画像请求头
+var GatewayProfileHeaders = []string{HeaderUserRegion, HeaderUserLevel, HeaderUserTags}
@@ -0,0 +1,7 @@
+package constant
+
+import "time"
+
+// HealthCheckTimeout 是就绪探针 (/readyz) 探测全部依赖的总超时，各依赖并发探测。
+// 应小于 K8s readinessProbe 的 timeoutSeconds，避免探针超时被直接判定为失败而看不到依赖详情。
+const HealthCheckTimeout = 2 * time.Second
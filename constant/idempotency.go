@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+// 创建帖子的幂等键参数
+const (
+	// IdempotencyKeyHeader 是客户端携带幂等键的请求头，重试同一次提交时应使用相同的值。
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotencyKeyMaxLength 是幂等键的最大长度，客户端通常使用 UUID。
+	IdempotencyKeyMaxLength = 128
+
+	// PostCreateIdempotencyTTL 是幂等键记录的保留时间，覆盖处理中与已完成两个阶段；
+	// 处理中的请求异常退出未能释放时，最多在该时间后允许重新提交。
+	PostCreateIdempotencyTTL = 10 * time.Minute
+)
@@ -0,0 +1,26 @@
+package constant
+
+import "time"
+
+// Kafka 消费者并发处理参数
+//   - 消息读取后按帖子 ID 哈希分发到固定的 worker，同一帖子的消息始终由同一个 worker 按顺序处理；
+//   - offset 在同一分区内更早的消息全部处理完成后才提交，服务异常退出时未处理完的消息会被重新投递。
+const (
+	// KafkaConsumerDefaultConcurrency 每个消费者的默认 worker 数，未配置 consumer_concurrency 时使用。
+	KafkaConsumerDefaultConcurrency = 1
+
+	// KafkaConsumerWorkerQueueSize 每个 worker 待处理消息队列的容量，队列满时暂停读取新消息。
+	KafkaConsumerWorkerQueueSize = 64
+
+	// KafkaConsumerHandleTimeout 单条消息的处理超时。关停时在途消息使用独立的 context，不会因关停被中断。
+	KafkaConsumerHandleTimeout = 30 * time.Second
+)
+
+// 作者冗余信息同步参数
+const (
+	// AuthorInfoSyncBatchSize 同步作者头像与昵称时每条 UPDATE 语句最多修改的帖子数，作者帖子很多时分批执行，避免长事务与大范围行锁。
+	AuthorInfoSyncBatchSize = 500
+
+	// AuthorInfoSyncTimeout 处理单条用户信息变更事件的总超时，覆盖全部批次。
+	AuthorInfoSyncTimeout = 2 * time.Minute
+)
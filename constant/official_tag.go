@@ -0,0 +1,5 @@
+package constant
+
+// PostLegacyOfficialTagColumn 是 posts 表中早期存储单个官方标签的列名。
+// 官方标签现存储在位掩码列 official_tags 中，启动迁移时把该列中残留的标签并入位掩码后清零，之后不再写入。
+const PostLegacyOfficialTagColumn = "official_tag"
@@ -0,0 +1,37 @@
+package constant
+
+import "time"
+
+// Kafka 发送重试与事务性发件箱 (outbox) 参数
+//   - 需要可靠投递的事件（帖子待审核、帖子删除）在业务事务内写入 outbox 表，事务提交后立即尝试投递，成功后删除记录；
+//     投递失败的记录由发件箱补偿任务定时扫描重新投递，保证事件至少投递一次，下游按 EventID 幂等处理。
+const (
+	// KafkaSendMaxRetries 单次发送失败后的默认重试次数（不含首次发送）。
+	KafkaSendMaxRetries = 3
+
+	// KafkaSendRetryBackoff 首次重试前的默认等待时间，之后每次翻倍。
+	KafkaSendRetryBackoff = 200 * time.Millisecond
+
+	// OutboxRelayCronSpec 发件箱补偿任务的执行频率。
+	OutboxRelayCronSpec = "@every 30s"
+
+	// OutboxRelayTimeout 发件箱补偿任务单次执行的超时。
+	OutboxRelayTimeout = 2 * time.Minute
+
+	// OutboxRelayLockKey / OutboxRelayLockTTL 发件箱补偿任务分布式锁，TTL 必须大于任务超时。
+	OutboxRelayLockKey = "task_lock:outbox_relay"
+	OutboxRelayLockTTL = 3 * time.Minute
+
+	// OutboxRelayBatchSize 每次扫描读取的到期记录数量上限。
+	OutboxRelayBatchSize = 100
+
+	// OutboxRelayDelay 新记录首次允许被补偿任务投递的延迟。
+	// - 事务提交后服务会立即投递一次，延迟期间补偿任务不扫描该记录，避免与即时投递重复发送。
+	OutboxRelayDelay = time.Minute
+
+	// OutboxRetryMaxBackoff 补偿投递失败后的最大退避间隔，退避从 OutboxRelayDelay 起按失败次数翻倍。
+	OutboxRetryMaxBackoff = 30 * time.Minute
+
+	// OutboxLastErrorMaxLen 记录最近一次投递错误的最大长度（字符数）。
+	OutboxLastErrorMaxLen = 500
+)
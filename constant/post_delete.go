@@ -0,0 +1,8 @@
+package constant
+
+// 帖子删除事件的来源 (producer.PostDeletedEvent.Source)，下游可据此区分用户自删与管理员下架
+const (
+	PostDeleteSourceUser      = "user"      // 作者本人删除
+	PostDeleteSourceAdmin     = "admin"     // 管理员删除
+	PostDeleteSourceReconcile = "reconcile" // 对账补偿重发，帖子可能早已删除或从未公开
+)
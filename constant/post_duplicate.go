@@ -0,0 +1,24 @@
+package constant
+
+import "time"
+
+// 发布前查重
+//   - 复用时间线相似折叠的 SimHash 指纹，汉明距离不超过 SimilarPostMaxHammingDistance 的帖子视为高度相似。
+//   - 为控制开销只在两个范围内查重：作者本人近期的帖子，以及全站近期已审核通过的帖子；比较在内存中进行。
+//   - 转发帖与草稿不做查重，作者可以通过 ignore_similar=true 忽略提示继续发布。
+const (
+	// DuplicateCheckAuthorWindow 查重时回看作者本人帖子的时间范围。
+	DuplicateCheckAuthorWindow = 30 * 24 * time.Hour
+
+	// DuplicateCheckPlatformWindow 查重时回看全站已审核通过帖子的时间范围。
+	DuplicateCheckPlatformWindow = 7 * 24 * time.Hour
+
+	// DuplicateCheckAuthorScanLimit 每次查重最多比较的作者本人帖子数量。
+	DuplicateCheckAuthorScanLimit = 200
+
+	// DuplicateCheckPlatformScanLimit 每次查重最多比较的全站帖子数量，只取时间范围内最新的帖子。
+	DuplicateCheckPlatformScanLimit = 2000
+
+	// DuplicateCheckMaxSuggestions 返回给作者的相似帖子数量上限。
+	DuplicateCheckMaxSuggestions = 5
+)
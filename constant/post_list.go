@@ -0,0 +1,48 @@
+package constant
+
+// PostStatusViewCountIndexName 是 posts (status, view_count, id) 联合索引的名称，支撑按浏览量倒序的公开列表。
+// id 来自 go-common 的 BaseModel，无法通过结构体标签声明联合索引，由迁移步骤单独创建。
+const PostStatusViewCountIndexName = "idx_posts_status_view_count_id"
+
+// 管理员帖子列表的组合排序（order_by=view_count:desc,created_at:desc）
+//   - 只有白名单中的字段可以参与排序，字段名即 posts 表的列名，不会拼接任何用户输入到 SQL 中。
+//   - 每个字段都有 (字段, id) 联合索引支撑其作为首个排序字段时的排序：updated_at 复用 PostUpdatedAtIndexName，
+//     其余见下方索引名；第二个及之后的排序字段只在首字段取值相同时生效，不再单独建索引。
+const (
+	// AdminPostSortDefaultField 未指定 order_by 时的排序字段。
+	AdminPostSortDefaultField = "created_at"
+
+	// AdminPostSortMaxFields 一次最多组合的排序字段数量。
+	AdminPostSortMaxFields = 3
+
+	// PostCreatedAtIndexName / PostViewCountIndexName / PostLikeCountIndexName 是管理员列表排序字段的联合索引名称。
+	// created_at 来自 go-common 的 BaseModel，与 id 的联合索引同样由迁移步骤单独创建。
+	PostCreatedAtIndexName = "idx_posts_created_at_id"
+	PostViewCountIndexName = "idx_posts_view_count_id"
+	PostLikeCountIndexName = "idx_posts_like_count_id"
+)
+
+// AdminPostSortFields 管理员帖子列表允许排序的字段白名单。
+var AdminPostSortFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"view_count": true,
+	"like_count": true,
+}
+
+// AdminPostExportBatchSize 管理员导出帖子 CSV 时每批按游标读取的帖子数量，逐批写出，不一次性加载全部结果。
+const AdminPostExportBatchSize = 500
+
+// TimelineMaxAuthorIDs 时间线按作者过滤（关注流）时一次最多携带的作者 ID 数量，避免超大 IN 查询。
+const TimelineMaxAuthorIDs = 200
+
+// 时间线相似帖子折叠（collapseSimilar=true）
+//   - 帖子创建时按标题与正文纯文本计算 64 位 SimHash 指纹，汉明距离不超过阈值的帖子视为内容高度相似。
+//   - 折叠只在当前页内进行，不额外查询数据库；指纹为 0（历史帖子或正文过短）的帖子不参与折叠。
+const (
+	// SimilarPostMaxHammingDistance 两个帖子被视为相似的最大汉明距离。
+	SimilarPostMaxHammingDistance = 3
+
+	// SimHashShingleRunes 计算 SimHash 时每个特征（连续字符片段）的字符数。
+	SimHashShingleRunes = 3
+)
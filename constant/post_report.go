@@ -0,0 +1,8 @@
+package constant
+
+// 用户举报帖子相关参数
+const (
+	// PostReportReviewThreshold 帖子举报数达到该值时发送一次自动下架审查事件。
+	// - 只在举报数恰好达到阈值的那次举报时发送，之后的举报不再重复触发。
+	PostReportReviewThreshold = 10
+)
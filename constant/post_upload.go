@@ -0,0 +1,27 @@
+package constant
+
+import "time"
+
+// 带图帖子创建流程（先写库占位、再上传图片、最后回填 URL）的参数
+//   - 占位记录在图片上传完成前对所有人不可见；上传或回填失败时服务会立即补偿（删除已上传对象与占位记录），
+//     补偿失败或服务中途退出遗留的占位记录由上传清理任务定时回收。
+const (
+	// PostUploadCleanupCronSpec 上传清理任务的执行频率。
+	PostUploadCleanupCronSpec = "@every 10m"
+
+	// PostUploadCleanupTimeout 上传清理任务单次执行的超时。
+	PostUploadCleanupTimeout = 5 * time.Minute
+
+	// PostUploadCleanupLockKey / PostUploadCleanupLockTTL 上传清理任务分布式锁，TTL 必须大于任务超时。
+	PostUploadCleanupLockKey = "task_lock:post_upload_cleanup"
+	PostUploadCleanupLockTTL = 6 * time.Minute
+
+	// PostUploadStaleAfter 占位记录被视为遗留的最短存活时间，必须远大于单次创建请求的耗时，避免回收正在上传的帖子。
+	PostUploadStaleAfter = 30 * time.Minute
+
+	// PostUploadCleanupBatchSize 上传清理任务每次读取的遗留帖子数量上限。
+	PostUploadCleanupBatchSize = 100
+
+	// PostUploadCompensateTimeout 创建失败后同步补偿（删除 COS 对象与占位记录）的超时。
+	PostUploadCompensateTimeout = 30 * time.Second
+)
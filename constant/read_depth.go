@@ -0,0 +1,20 @@
+package constant
+
+import "time"
+
+// 帖子阅读深度统计参数
+//   - 客户端在阅读过程中上报滚动到的内容百分比 (0-100)，同一用户的同一次阅读 (read_id) 只保留最大深度。
+//   - 按 ReadDepthBucketSize 分桶统计分布，深度 100 单独成桶，表示读完。
+const (
+	ReadDepthBucketSize = 10 // 阅读深度分布的桶宽（百分点）
+
+	// ReadDepthSessionTTL 单次阅读的去重窗口：自最后一次上报起超过该时长，同一 read_id 的上报按新的一次阅读计数。
+	ReadDepthSessionTTL = 30 * time.Minute
+
+	// ReadDepthReportInterval 同一次阅读两次上报之间的最小间隔，间隔内的上报被拒绝。
+	ReadDepthReportInterval = 3 * time.Second
+
+	ReadDepthStatsReadsField  = "reads" // 统计 Hash 中的阅读次数字段
+	ReadDepthStatsSumField    = "sum"   // 统计 Hash 中的深度总和字段，平均深度 = sum / reads
+	ReadDepthStatsBucketField = "b:"    // 统计 Hash 中分布桶的字段前缀，后接桶序号 (深度 / ReadDepthBucketSize)
+)
@@ -0,0 +1,44 @@
+package constant
+
+import "time"
+
+// 跨服务数据一致性对账任务参数
+//   - 对账任务按变更时间增量导出帖子状态快照到 Kafka，由搜索、推荐等下游服务与自身数据比对；
+//     下游发现缺失或过期的帖子后调用管理员补偿接口，由本服务重新发送同步事件。
+const (
+	// PostReconcileCronSpec 增量快照导出频率。
+	PostReconcileCronSpec = "@every 30m"
+
+	// PostReconcileTimeout 对账任务单次执行的超时。
+	PostReconcileTimeout = 10 * time.Minute
+
+	// PostReconcileLockKey / PostReconcileLockTTL 对账任务分布式锁，TTL 必须大于任务超时。
+	PostReconcileLockKey = "task_lock:post_reconcile"
+	PostReconcileLockTTL = 15 * time.Minute
+
+	// PostReconcileInitialLookback 首次运行（没有检查点）时导出的时间范围。
+	PostReconcileInitialLookback = 24 * time.Hour
+
+	// PostReconcileWindowOverlap 每次窗口相对上次检查点向前重叠的时长，覆盖检查点附近提交较晚的事务与实例间的时钟偏差。
+	PostReconcileWindowOverlap = 5 * time.Minute
+
+	// PostReconcileSettleDelay 窗口终点相对当前时间的延迟，避免导出仍在提交中的变更。
+	PostReconcileSettleDelay = time.Minute
+
+	// PostReconcilePageSize 每页读取的帖子数量，也是单条快照事件携带的帖子数量上限。
+	PostReconcilePageSize = 500
+
+	// PostReconcileResyncMaxPosts 单次补偿请求最多包含的帖子数量。
+	PostReconcileResyncMaxPosts = 500
+)
+
+// 增量对账时用于识别变更的时间列
+// - 软删除只更新 deleted_at，不会更新 updated_at，因此删除的帖子需要单独按 deleted_at 扫描。
+const (
+	PostReconcileColumnUpdatedAt = "updated_at"
+	PostReconcileColumnDeletedAt = "deleted_at"
+)
+
+// PostUpdatedAtIndexName 是 posts (updated_at, id) 索引的名称，供增量对账按更新时间范围扫描。
+// updated_at 来自 go-common 的 BaseModel，无法通过结构体标签声明索引，由迁移步骤单独创建。
+const PostUpdatedAtIndexName = "idx_posts_updated_at_id"
@@ -11,6 +11,18 @@ const (
 	// Redis 类型: String (由 RedisBloom 模块管理)
 	PostViewBloomPrefix = "post_view_bloom:"
 
+	// PostViewBloomPrevPrefix 是扩容期间旧 Bloom Filter 的 Key 前缀。
+	// 扩容时原过滤器改名为该前缀并保留剩余过期时间，计数脚本同时检查新旧两个过滤器，旧过滤器过期后迁移完成。
+	// 示例 Key: "post_view_bloom_prev:123"
+	// Redis 类型: String (由 RedisBloom 模块管理)
+	PostViewBloomPrevPrefix = "post_view_bloom_prev:"
+
+	// AnonymousDeviceViewsPrefix 是匿名设备浏览记录的 Key 前缀，记录某个设备在去重窗口内未登录时计入过浏览的帖子。
+	// 用户在该设备登录后，这些帖子的去重过滤器会补入用户 ID，避免用户换设备登录后再次浏览时重复计数。
+	// 示例 Key: "post_view_device:abc123" (其中 abc123 是设备标识)
+	// Redis 类型: Sorted Set，成员是帖子 ID，分数是浏览时的 Redis 服务器时间 (Unix 秒)
+	AnonymousDeviceViewsPrefix = "post_view_device:"
+
 	// PostViewCountPrefix 是帖子浏览量计数器的 Key 前缀。
 	// 每个帖子会有一个对应的 String 类型的 Key，用于原子性计数。
 	// 示例 Key: "post_view_count:123" (其中 123 是 postID)
@@ -33,6 +45,58 @@ const (
 	// 示例值: "{\"title\":\"帖子标题\",\"content\":\"帖子内容...\"}"
 	PostDetailCacheKeyPrefix = "post_detail:"
 
+	// GlobalViewBucketPrefix 是全站分钟级浏览量时间桶的 Key 前缀。
+	// 每分钟一个 String 计数器，分钟值为 Redis 服务器时间的 Unix 秒数 / 60，
+	// 用于统计“最近 N 分钟浏览量”这类滑动窗口指标。
+	// 示例 Key: "global_view:28512345"
+	// Redis 类型: String
+	GlobalViewBucketPrefix = "global_view:"
+
+	// PostDetailNormalCacheKeyPrefix 是普通（非热门）帖子详情缓存的 Key 前缀。
+	// 与热门详情 Key (PostDetailCacheKeyPrefix) 区分开，避免被热榜刷新任务覆盖或清理。
+	// 写入时带较短的 TTL (constant.PostDetailNormalCacheTTL)，帖子删除时主动清除。
+	// 示例 Key: "post_detail_normal:123"
+	// Redis 类型: String (JSON 序列化的 vo.PostDetailVO)
+	PostDetailNormalCacheKeyPrefix = "post_detail_normal:"
+
+	// PostStatsCacheKey 缓存管理后台的帖子数量统计（按状态/官方标签聚合），过期时间为 constant.PostStatsCacheTTL。
+	// Redis 类型: String (JSON 序列化的 vo.PostStatsVO)
+	PostStatsCacheKey = "post_stats"
+
+	// TimelineHomeCacheKeyPrefix 是时间线首页缓存的 Key 前缀，过期时间为 config.TimelineCacheConfig.TTL。
+	// 完整 Key: TimelineHomeCacheKeyPrefix + "{pageSize}:{地区}:{是否折叠}"
+	// 示例 Key: "timeline_home:10:cn-sh:0"
+	// Redis 类型: String (JSON 序列化的 vo.PostTimelineCardPageVO)
+	TimelineHomeCacheKeyPrefix = "timeline_home:"
+
+	// TimelineHomeCacheIndexKey 记录当前存在的全部首页缓存 Key，主动失效时据此一次删除，无需 SCAN。
+	// 与首页缓存同时写入并续期为相同的过期时间。
+	// Redis 类型: Set
+	TimelineHomeCacheIndexKey = "timeline_home:index"
+
+	// PostDetailCacheRepairLockPrefix 是删除损坏详情缓存的频率控制 Key 前缀。
+	// 完整 Key: PostDetailCacheRepairLockPrefix + postID
+	// Redis 类型: String，SET NX 成功才删除损坏的 Key，过期时间为 constant.PostDetailCacheRepairInterval。
+	PostDetailCacheRepairLockPrefix = "post_detail_repair:"
+
+	// HotPostsTagRankKeyPrefix 是按官方标签拆分的热门帖子榜单的 Key 前缀。
+	// 由热帖缓存任务根据热榜快照 (HotPostsRankKey) 按帖子的官方标签拆分生成，拥有多个标签的帖子出现在每个标签的榜单中，成员与分数同热榜快照。
+	// 示例 Key: "hot_post_rank:tag:1" (官方认证标签)
+	// Redis 类型: Sorted Set
+	HotPostsTagRankKeyPrefix = "hot_post_rank:tag:"
+
+	// PostLikeUsersPrefix 是帖子点赞用户集合的 Key 前缀。
+	// 成员为点过赞的用户 ID，SADD/SREM 的返回值用于判断点赞/取消点赞是否真正生效，保证重复请求幂等。
+	// 示例 Key: "post_like:123"
+	// Redis 类型: Set
+	PostLikeUsersPrefix = "post_like:"
+
+	// PostLikeCountPrefix 是帖子点赞数计数器的 Key 前缀。
+	// 与点赞用户集合分开维护，读取点赞数时无需 SCARD；计数器不存在时从 MySQL posts.like_count 回源初始化。
+	// 示例 Key: "post_like_count:123"
+	// Redis 类型: String
+	PostLikeCountPrefix = "post_like_count:"
+
 	// --- 固定 Key 名称 (全局使用的 Key) ---
 
 	// PostsRankKey 是全局帖子排行榜的 Key 名称。
@@ -48,4 +112,110 @@ const (
 	// Redis 类型: Sorted Set
 	// 示例成员与分数: (与 PostsRankKey 类似，但通常条目较少)
 	HotPostsRankKey = "hot_post_rank"
+
+	// HotPostsSnapshotVersionKey 是热榜快照版本号的 Key 名称。
+	// CreateHotList 每次生成热榜快照时在同一个 Lua 脚本内 INCR，帖子 Hash 与热门详情缓存写入时记录所基于的版本，
+	// 读取时版本与当前快照不一致的缓存视为过期并回源 MySQL。
+	// Redis 类型: String
+	// 示例值: "1024"
+	HotPostsSnapshotVersionKey = "hot_post_snapshot_version"
+
+	// HotPostsHashVersionField 是帖子 Hash (PostsHashKey) 中记录快照版本号的保留字段，不会与数字形式的帖子 ID 冲突。
+	HotPostsHashVersionField = "_version"
+
+	// ViewWhitelistKey 是浏览量防刷白名单的 Key 名称。
+	// 成员为白名单用户 ID，这些用户的浏览不计入帖子浏览量；与配置文件中的白名单合并生效。
+	// 服务定期将其加载到本地内存 (constant.ViewWhitelistRefreshInterval)，修改后无需重启即可生效。
+	// Redis 类型: Set
+	// 示例成员: "internal_tester_01"
+	ViewWhitelistKey = "view_whitelist"
+
+	// ViewWhitelistSkippedKey 记录各帖子被白名单跳过的浏览次数，便于与真实浏览量区分。
+	// Redis 类型: Hash
+	// 示例字段与值: Field="123" (postID), Value="7"
+	ViewWhitelistSkippedKey = "view_whitelist_skipped"
+
+	// ViewLastActiveKey 记录每个帖子最近一次被计入浏览量的时间，用于判定浏览量计数器的冷热。
+	// 成员是帖子 ID，分数是 Redis 服务器时间的 Unix 秒数；计数器被归档到 MySQL 后成员随之移除。
+	// Redis 类型: Sorted Set
+	// 示例成员与分数: Member="123", Score=1718000000
+	ViewLastActiveKey = "post_view_last_active"
+
+	// DirtyViewCountsKey 记录上次同步之后浏览量发生过变化的帖子（“脏集合”）。
+	// IncrementViewCount / IncrementViewCountBy 的 Lua 脚本在计数的同时写入，增量同步任务只同步其中的帖子。
+	// Redis 类型: Set
+	// 示例成员: "123" (postID)
+	DirtyViewCountsKey = "dirty_view_counts"
+
+	// DirtyViewCountsSyncingKey 是增量同步任务正在处理的脏集合快照。
+	// 同步开始时把 DirtyViewCountsKey 整体转入此 Key（已存在时合并），同步成功后才删除；
+	// 同步失败时保留，下一轮与新的脏集合合并后重试，保证脏标记不丢失。
+	// Redis 类型: Set
+	DirtyViewCountsSyncingKey = "dirty_view_counts:syncing"
+
+	// ViewCountRetryBatchesKey 是浏览量同步写入 MySQL 失败的批次，按失败时间先后追加到队尾，下一轮同步开始时从队首读取重试。
+	// Redis 类型: List
+	// 示例元素: {"counts":{"123":456},"attempts":1,"first_failed_at":1718000000,"last_failed_at":1718003600}
+	ViewCountRetryBatchesKey = "view_count_sync:retry_batches"
+
+	// DirtyLikeCountsKey 记录上次同步之后点赞数发生过变化的帖子，点赞数同步任务只同步其中的帖子。
+	// Redis 类型: Set
+	// 示例成员: "123" (postID)
+	DirtyLikeCountsKey = "dirty_like_counts"
+
+	// DirtyLikeCountsSyncingKey 是点赞数同步任务正在处理的脏集合快照，语义同 DirtyViewCountsSyncingKey。
+	// Redis 类型: Set
+	DirtyLikeCountsSyncingKey = "dirty_like_counts:syncing"
+
+	// PostReconcileCheckpointKey 记录对账任务上次成功导出的窗口终点（Unix 毫秒），下次从该时间点继续增量导出。
+	// Redis 类型: String
+	PostReconcileCheckpointKey = "post_reconcile:checkpoint"
+
+	// PostCoverStatsPrefix 是帖子 A/B 封面曝光/点击计数的 Key 前缀。
+	// 完整 Key: PostCoverStatsPrefix + postID
+	// Redis 类型: Hash，字段为 "imp:{imageID}" (曝光数) 与 "clk:{imageID}" (点击数)
+	// 作者重新设置候选封面时整体删除，统计随新一轮实验重新开始。
+	PostCoverStatsPrefix = "post_cover_stats:"
+
+	// COSPendingDeleteKey 是 COS 图片延迟删除队列的 Key 名称。
+	// 帖子被软删除后，其图片的对象键以计划删除时间 (Unix 秒) 为分数写入，到期后由 COS 延迟删除任务真正删除；帖子恢复时移除。
+	// Redis 类型: Sorted Set，成员为 "{postID}:{objectKey}"
+	COSPendingDeleteKey = "cos_pending_delete"
+
+	// PostReadDepthStatsPrefix 是帖子阅读深度统计的 Key 前缀。
+	// 完整 Key: PostReadDepthStatsPrefix + postID
+	// Redis 类型: Hash，字段为 "reads" (阅读次数)、"sum" (深度总和) 与 "b:{桶序号}" (各深度区间的阅读次数)
+	PostReadDepthStatsPrefix = "post_read_depth:"
+
+	// PostReadSessionPrefix 是单次阅读已记录的最大深度的 Key 前缀，用于去重与频率控制。
+	// 完整 Key: PostReadSessionPrefix + postID + ":" + userID + ":" + readID
+	// Redis 类型: Hash，字段 "depth" 为已记录的最大深度，"ts" 为最后一次接受上报的时间（Unix 毫秒）
+	// 过期时间为 constant.ReadDepthSessionTTL，每次接受上报时刷新。
+	PostReadSessionPrefix = "post_read_session:"
+
+	// PostConversionStatsPrefix 是帖子每日转化统计的 Key 前缀。
+	// 完整 Key: PostConversionStatsPrefix + postID + ":" + 日期 (constant.ConversionStatsDateLayout)
+	// Redis 类型: Hash，字段为 "{阶段}" (当天该阶段的人数) 与 "{阶段}:{来源}" (按归因来源拆分的人数)
+	// 过期时间为 constant.ConversionStatsRetention。
+	PostConversionStatsPrefix = "post_conversion:"
+
+	// PostConversionDedupPrefix 是同一用户同一阶段转化事件的去重 Key 前缀。
+	// 完整 Key: PostConversionDedupPrefix + postID + ":" + 阶段 + ":" + userID + ":" + 日期
+	// Redis 类型: String，过期时间为 constant.ConversionDedupWindow。
+	PostConversionDedupPrefix = "post_conversion_dedup:"
+
+	// PostConversionTouchPrefix 是用户对帖子最近一次浏览来源（触点）的 Key 前缀，用于归因联系与成交。
+	// 完整 Key: PostConversionTouchPrefix + postID + ":" + userID
+	// Redis 类型: String，值为来源，过期时间为 constant.ConversionAttributionWindow，每次浏览时刷新。
+	PostConversionTouchPrefix = "post_conversion_touch:"
+
+	// PostCreateIdempotencyPrefix 是创建帖子幂等键记录的 Key 前缀。
+	// 完整 Key: PostCreateIdempotencyPrefix + authorID + ":" + Idempotency-Key
+	// Redis 类型: String，值为 JSON（处理中时记录占用者令牌，完成后记录首次创建的结果），过期时间为 constant.PostCreateIdempotencyTTL。
+	PostCreateIdempotencyPrefix = "post_create_idempotency:"
+
+	// PostTargetingCacheKeyPrefix 是帖子投放定向条件缓存的 Key 前缀。
+	// 完整 Key: PostTargetingCacheKeyPrefix + postID
+	// Redis 类型: String，值为定向条件的 JSON，帖子未配置定向时为 PostTargetingCacheNone；过期时间为 constant.PostTargetingCacheTTL。
+	PostTargetingCacheKeyPrefix = "post_targeting:"
 )
@@ -0,0 +1,28 @@
+package constant
+
+import "time"
+
+// 帖子数据报表的统计周期
+const (
+	ReportPeriodDaily  = "daily"  // 日报
+	ReportPeriodWeekly = "weekly" // 周报
+)
+
+// 帖子数据报表的分组维度
+const (
+	ReportGroupByNone        = "none"         // 不分组，全站统一排名
+	ReportGroupByOfficialTag = "official_tag" // 按官方标签分组，组内排名
+)
+
+// ReportQueryBatchSize 生成报表时每次从从库分页读取的帖子数量。
+const ReportQueryBatchSize = 1000
+
+// 帖子数据报表定时任务的超时与分布式锁，多副本部署时保证每个周期只生成一份报表。
+const (
+	// ReportTimeout 是单次报表生成（全量扫描从库并上传 COS）的超时时间。
+	ReportTimeout = 30 * time.Minute
+	// ReportLockKey 是报表任务的分布式锁 Key，Redis 类型: String。
+	ReportLockKey = "task_lock:post_report"
+	// ReportLockTTL 是报表任务锁的过期时间，必须大于 ReportTimeout。
+	ReportLockTTL = 35 * time.Minute
+)
@@ -0,0 +1,14 @@
+package constant
+
+// 帖子全文搜索参数
+const (
+	PostTitleFullTextIndexName   = "ftx_posts_title"          // posts(title) 全文索引名称
+	PostContentFullTextIndexName = "ftx_post_details_content" // post_details(content) 全文索引名称
+
+	SearchKeywordMaxTerms = 8    // 关键词拆分后最多参与匹配的词数，多余的词被忽略
+	SearchMaxResults      = 1000 // 单个关键词最多可翻页到的结果数，游标（偏移量）超过该值时返回空页
+	SearchTitleWeight     = 3    // 标题相关性在排序中的权重，标题命中的帖子优先于仅正文命中的帖子
+)
+
+// SearchKeywordOperators 是 MySQL BOOLEAN MODE 中具有特殊含义的字符，拼接查询表达式前从关键词中剔除。
+const SearchKeywordOperators = `+-<>()~*"@`
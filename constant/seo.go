@@ -0,0 +1,18 @@
+package constant
+
+import "github.com/Xushengqwer/go-common/models/enums"
+
+// 帖子 SEO 元数据生成参数
+const (
+	SEODescriptionMaxRunes = 160 // meta description 最大字符数（按 rune 计，超出截断并追加省略号）
+	SEOKeywordsMax         = 10  // meta keywords 最多保留的关键词数量
+	SEOKeywordMinRunes     = 2   // 从标题拆分出的关键词最短字符数，过短的片段不作为关键词
+	SEOKeywordMaxRunes     = 20  // 从标题拆分出的关键词最长字符数，过长的片段视为句子而非关键词
+)
+
+// OfficialTagLabels 是官方标签的展示名称，用于生成 SEO 关键词。
+var OfficialTagLabels = map[enums.OfficialTag]string{
+	enums.OfficialTagCertified: "官方认证",
+	enums.OfficialTagDeposit:   "预付保证金",
+	enums.OfficialTagRapid:     "急速响应",
+}
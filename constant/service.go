@@ -1,6 +1,33 @@
 package constant
 
+import "time"
+
 const (
 	ServiceName    = "post-service" // 定义服务名
 	ServiceVersion = "v1.0.0"       // 定义服务版本
 )
+
+// 多作者帖子查询（推荐/关注流）参数
+const (
+	// AuthorsQueryBatchSize 是 author_id IN (...) 单条 SQL 中包含的最大作者数量。
+	// 作者列表超过该值时分批查询再在内存中归并，避免过长的 IN 列表导致优化器放弃索引。
+	AuthorsQueryBatchSize = 200
+
+	// MaxFeedAuthors 是单次多作者查询允许的最大作者数量。
+	MaxFeedAuthors = 2000
+)
+
+// PostRestoreCascadeWindow 是恢复帖子时关联记录（详情、图片、FAQ）的删除时间容差。
+// 只有删除时间不早于“帖子删除时间 - 该窗口”的关联记录才会随帖子一起恢复，
+// 避免把帖子删除之前就被单独删除的旧 FAQ、旧图片一并复活。
+const PostRestoreCascadeWindow time.Duration = time.Minute
+
+// 请求体大小限制的默认值，可通过 BodyLimitConfig 覆盖。
+const (
+	DefaultRequestBodyMaxBytes   int64 = 1 << 20  // 普通 JSON 写接口默认上限 1MB
+	DefaultMultipartBodyMaxBytes int64 = 64 << 20 // 带图 multipart 接口默认上限 64MB
+)
+
+// DefaultDeleteConfirmViewThreshold 是管理员删除帖子需要二次确认的默认浏览量阈值，可通过 AdminDeleteConfig 覆盖。
+// 浏览量超过该值的帖子删除时必须带 confirm=true，防止误删热门帖子。
+const DefaultDeleteConfirmViewThreshold int64 = 10000
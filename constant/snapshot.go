@@ -0,0 +1,43 @@
+package constant
+
+import "time"
+
+// 帖子指标快照的粒度 (PostMetricSnapshot.Granularity)
+const (
+	// SnapshotGranularityHourly 小时级快照：每小时对最近活跃的帖子做一次快照。
+	SnapshotGranularityHourly = "hourly"
+	// SnapshotGranularityDaily 日级快照：每天零点对前一天活跃过的帖子做一次快照，保留时间更长。
+	SnapshotGranularityDaily = "daily"
+)
+
+// 帖子指标快照任务参数
+const (
+	// SnapshotCronSpec 定义了快照任务的执行频率：每小时第 5 分钟执行，零点那一轮生成日级快照。
+	SnapshotCronSpec = "5 * * * *"
+	// SnapshotTimeout 是单次快照任务（含过期快照清理）的超时时间。
+	SnapshotTimeout time.Duration = 10 * time.Minute
+	// SnapshotLockKey 是快照任务的分布式锁 Key，多副本部署时保证每轮只生成一份快照。
+	SnapshotLockKey = "task_lock:post_snapshot"
+	// SnapshotLockTTL 是快照任务锁的过期时间，必须大于 SnapshotTimeout。
+	SnapshotLockTTL time.Duration = 15 * time.Minute
+
+	// SnapshotActiveWindow 是活跃帖子的默认判定窗口，与快照任务的执行间隔一致。
+	SnapshotActiveWindow time.Duration = time.Hour
+	// SnapshotDailyActiveWindow 是日级快照的活跃判定窗口：前一天内有过浏览的帖子。
+	SnapshotDailyActiveWindow time.Duration = 24 * time.Hour
+	// SnapshotMaxPostsPerRun 是单次快照默认最多覆盖的帖子数量。
+	SnapshotMaxPostsPerRun = 5000
+	// SnapshotBatchSize 是快照任务分批读取帖子数据与写入快照的批大小。
+	SnapshotBatchSize = 500
+	// SnapshotHourlyRetentionDays 是小时级快照的默认保留天数。
+	SnapshotHourlyRetentionDays = 7
+	// SnapshotDailyRetentionDays 是日级快照的默认保留天数。
+	SnapshotDailyRetentionDays = 180
+	// SnapshotPurgeBatchSize 是清理过期快照时单条 DELETE 删除的最大行数，避免长事务锁表。
+	SnapshotPurgeBatchSize = 5000
+
+	// SnapshotQueryMaxRange 是查询单个帖子历史快照时允许的最大时间跨度。
+	SnapshotQueryMaxRange time.Duration = 90 * 24 * time.Hour
+	// SnapshotQueryMaxRows 是查询单个帖子历史快照时最多返回的快照数量。
+	SnapshotQueryMaxRows = 2000
+)
@@ -0,0 +1,10 @@
+package constant
+
+import "time"
+
+// TagNewPostBatchSize 是新帖推送时每批读取的订阅者数量，同时也是单条 tag.new_post 事件携带的最大订阅者数量。
+// - 热门标签订阅者较多时按主键游标分批扫描，避免一次性加载全部订阅者。
+const TagNewPostBatchSize = 500
+
+// TagNewPostPushTimeout 是一次新帖推送（扫描全部订阅者并写入 Kafka）的最长耗时。
+const TagNewPostPushTimeout = 2 * time.Minute
@@ -1,8 +1,10 @@
 package constant
 
+import "time"
+
 // 定时任务调度表达式 (Cron Spec)
 const (
-	// HotPostsCacheCronSpec 定义了热门帖子相关缓存（包括热榜快照、帖子基本信息Hash、帖子详情）的刷新频率。
+	// HotPostsCacheCronSpec 定义了热门帖子相关缓存（包括热榜快照、帖子基本信息Hash、帖子详情）的默认刷新频率，可通过 TaskConfig.HotCacheCron 覆盖。
 	// - 目标: 保持热门内容在缓存中的新鲜度，以加速用户访问。
 	// - 场景: 例如，每15分钟或每30分钟执行一次。
 	//   - "@every 15m": 每15分钟执行一次。对于大多数社区，这是一个比较均衡的选择。
@@ -13,7 +15,7 @@ const (
 	// - 当前值参考: "@every 15m"
 	HotPostsCacheCronSpec = "@every 15m" // 热帖缓存刷新频率
 
-	// SyncViewCountInterval 定义了将 Redis 中的帖子浏览量同步到 MySQL 数据库的频率。
+	// SyncViewCountInterval 定义了将 Redis 中的帖子浏览量同步到 MySQL 数据库的默认频率，可通过 TaskConfig.ViewCountSyncCron 覆盖。
 	// - 目标: 将实时的浏览量计数持久化到数据库，用于数据分析或在某些非实时场景展示。
 	// - 场景: 此操作对数据库有写入压力，频率不宜过高。
 	//   - "0 0 * * *": 每天的零点执行一次（即 @daily）。如果对浏览量持久化的实时性要求不高，此频率可显著降低数据库压力。
@@ -21,6 +23,20 @@ const (
 	// - 影响: 此任务会从 Redis SCAN 所有帖子的浏览量计数器，然后批量更新到 MySQL。主要压力点在于 MySQL 的批量写入。
 	// - 当前值参考: "0 0 * * *" (每天零点)
 	SyncViewCountInterval = "0 0 * * *" // 浏览量同步频率 (修改为每天零点执行)
+
+	// ViewCountArchiveCronSpec 定义了浏览量冷数据归档任务的执行频率。
+	// - 目标: 把长期无浏览增量的帖子计数器从 Redis 归档到 MySQL 并删除，控制 post_view_count:* 的数量。
+	// - 与浏览量同步任务共用分布式锁，两者不会同时执行，错开在凌晨 03:30。
+	ViewCountArchiveCronSpec = "30 3 * * *"
+
+	// ViewConsistencyCheckCronSpec 定义了浏览量一致性校验任务的执行频率。
+	// - 目标: 抽样比对 Redis 计数器与 MySQL posts.view_count，发现同步失败或清理逻辑缺陷导致的漂移。
+	// - 与浏览量同步、归档任务共用分布式锁，错开在凌晨 04:30，此时当天的同步与归档均已完成。
+	ViewConsistencyCheckCronSpec = "30 4 * * *"
+
+	// SyncLikeCountCronSpec 定义了将 Redis 中的帖子点赞数同步到 MySQL 的频率。
+	// - 点赞只同步脏集合中发生过变化的帖子，写入量远小于浏览量，因此频率更高，列表页展示的持久化点赞数更及时。
+	SyncLikeCountCronSpec = "@every 10m"
 )
 
 const (
@@ -30,3 +46,120 @@ const (
 	// 参考值: 100 到 500 之间通常是比较合理的范围，具体取决于系统负载和业务需求。
 	HotPostsCacheSize = 100 // 示例值：缓存Top100的热门帖子
 )
+
+// 帖子数据报表任务的默认调度表达式
+const (
+	// ReportDailyCronSpec 日报默认调度：每天凌晨 02:00 生成前一天的报表，避开业务高峰。
+	ReportDailyCronSpec = "0 2 * * *"
+
+	// ReportWeeklyCronSpec 周报默认调度：每周一凌晨 03:00 生成上一周的报表。
+	ReportWeeklyCronSpec = "0 3 * * 1"
+)
+
+// 浏览量同步到 MySQL 时单个批次的更新方式（ViewSyncConfig.UpdateStrategy）
+const (
+	// ViewSyncStrategyUpsert 使用 INSERT ... ON DUPLICATE KEY UPDATE view_count = VALUES(view_count) 批量写入（默认）。
+	// - 每行只有 (id, view_count) 两个有效参数，SQL 长度与批次大小线性相关，MySQL 按主键逐行命中，解析开销小。
+	ViewSyncStrategyUpsert = "upsert"
+
+	// ViewSyncStrategyCaseWhen 使用 UPDATE ... SET view_count = CASE id WHEN ? THEN ? ... END WHERE id IN (...) 更新。
+	// - 批次较大时 CASE 分支过多，解析与逐行匹配分支的开销明显，保留用于对比与回退。
+	ViewSyncStrategyCaseWhen = "case_when"
+)
+
+// 浏览量同步任务的数据来源（ViewSyncConfig.SyncMode）
+const (
+	// ViewSyncModeIncremental 只同步脏集合 (DirtyViewCountsKey) 中浏览量发生过变化的帖子（默认）。
+	ViewSyncModeIncremental = "incremental"
+
+	// ViewSyncModeFull 使用 SCAN 遍历全部浏览量计数器做全量同步，用于首次上线增量同步前或数据修复时的兜底。
+	ViewSyncModeFull = "full"
+)
+
+// 定时任务单次执行的超时时间
+const (
+	// ViewCountSyncTimeout 浏览量同步任务单次执行的超时，需足够完成 Redis 数据获取和 MySQL 批量更新。
+	ViewCountSyncTimeout = 3 * time.Minute
+
+	// HotPostsCacheTimeout 热帖缓存刷新任务单次执行的超时，应大于各子任务正常执行时间的总和并留有余量。
+	HotPostsCacheTimeout = 10 * time.Minute
+
+	// ViewCountArchiveTimeout 浏览量冷数据归档任务单次执行的超时。
+	// - 与浏览量同步任务共用同一把锁，因此与 ViewCountSyncTimeout 一致，保证小于该锁的 TTL。
+	ViewCountArchiveTimeout = ViewCountSyncTimeout
+
+	// ViewConsistencyCheckTimeout 浏览量一致性校验任务单次执行的超时。
+	// - 与浏览量同步任务共用同一把锁，因此与 ViewCountSyncTimeout 一致，保证小于该锁的 TTL。
+	ViewConsistencyCheckTimeout = ViewCountSyncTimeout
+
+	// LikeCountSyncTimeout 点赞数同步任务单次执行的超时。
+	LikeCountSyncTimeout = 3 * time.Minute
+)
+
+// 浏览量一致性校验任务的默认参数，可通过 ViewConsistencyConfig 覆盖。
+const (
+	ViewConsistencySampleSize           = 2000 // 每次抽样的帖子数量
+	ViewConsistencyBatchSize            = 200  // 每批读取的帖子数量
+	ViewConsistencyMinAbsDrift    int64 = 100  // 显著漂移的最小绝对差值
+	ViewConsistencyMinDriftRatio        = 0.05 // 显著漂移的最小相对差值
+	ViewConsistencyMaxLoggedPosts       = 20   // 单次校验最多逐条记录的漂移帖子数量，其余只计入汇总
+)
+
+// 浏览量同步失败批次的重试参数
+const (
+	ViewCountRetryMaxAttempts = 5   // 失败批次的最大重试次数，超过后告警并丢弃，不再重试
+	ViewCountRetryRecordSize  = 500 // 持久化失败批次时每条重试记录包含的帖子数量上限
+	ViewCountRetryReadLimit   = 200 // 每轮同步最多读取的重试记录数量，其余留到后续轮次
+
+	// ViewCountRetryStoreTimeout 写回、移除重试记录的超时，与任务超时独立，任务超时后仍能保存重试进度。
+	ViewCountRetryStoreTimeout = 5 * time.Second
+)
+
+// LikeSyncScanBatchSize 是点赞数同步任务 SSCAN 读取脏集合时每批的成员数量。
+const LikeSyncScanBatchSize = 1000
+
+// 定时任务分布式锁的默认 Key 与过期时间，可通过 TaskLockConfig 覆盖。
+// - 锁没有自动续期，TTL 必须大于对应任务的超时时间。
+const (
+	ViewCountSyncLockKey = "task_lock:view_count_sync" // 浏览量同步任务锁，Redis 类型: String
+	ViewCountSyncLockTTL = 5 * time.Minute
+
+	HotPostsCacheLockKey = "task_lock:hot_posts_cache" // 热帖缓存刷新任务锁，Redis 类型: String
+	HotPostsCacheLockTTL = 15 * time.Minute
+
+	LikeCountSyncLockKey = "task_lock:like_count_sync" // 点赞数同步任务锁，Redis 类型: String
+	LikeCountSyncLockTTL = 5 * time.Minute
+)
+
+// 热帖缓存刷新任务上报指标时使用的任务与步骤名称（metrics 标签 task/step）
+const (
+	HotPostsCacheTaskName       = "hot_posts_cache"        // 热帖缓存刷新任务
+	HotPostsCacheStepHotList    = "create_hot_list"        // 步骤1: 创建/更新热榜快照
+	HotPostsCacheStepPostsHash  = "cache_hot_posts"        // 步骤2: 同步热门帖子基本信息到 Hash
+	HotPostsCacheStepPostDetail = "cache_hot_post_details" // 步骤3: 同步热门帖子详情
+)
+
+// 浏览量同步任务上报事件计数时使用的任务与事件名称（metrics 标签 task/event）
+const (
+	ViewCountSyncTaskName           = "view_count_sync"      // 浏览量同步任务
+	ViewCountSyncEventFailedBatches = "failed_batches"       // 写入 MySQL 失败的批次
+	ViewCountSyncEventRetryEnqueued = "retry_enqueued"       // 持久化的重试记录
+	ViewCountSyncEventRetryPersist  = "retry_persist_failed" // 持久化失败、已丢失的重试记录
+	ViewCountSyncEventRetryRecover  = "retry_recovered"      // 重试成功的记录
+	ViewCountSyncEventRetryDropped  = "retry_dropped"        // 超过最大重试次数被丢弃的记录
+)
+
+// Bloom Filter 扩容检查任务上报指标时使用的任务、事件与观测值名称（metrics 标签 task/event/name）
+const (
+	BloomCapacityTaskName         = "view_bloom_capacity" // Bloom Filter 扩容检查任务
+	BloomCapacityEventRebuilt     = "rebuilt"             // 完成扩容重建的过滤器
+	BloomCapacityGaugeMemoryBytes = "memory_bytes"        // 热榜帖子过滤器的内存占用总和（字节）
+	BloomCapacityGaugeMaxFill     = "max_fill_ratio"      // 热榜帖子过滤器中最高的填充率
+)
+
+// 浏览增量聚合器上报事件计数时使用的任务与事件名称（metrics 标签 task/event）
+const (
+	ViewCountAggregatorTaskName          = "view_count_aggregator" // 浏览增量进程内聚合器
+	ViewCountAggregatorEventDroppedPosts = "dropped_posts"         // 写入 Redis 失败且超过重试上限被丢弃的帖子数
+	ViewCountAggregatorEventDroppedViews = "dropped_views"         // 随之丢弃的浏览增量
+)
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// BadgeController 定义浏览量里程碑徽章控制器的结构体
+type BadgeController struct {
+	badgeService service.BadgeService
+}
+
+// NewBadgeController 构造函数，注入服务层依赖
+func NewBadgeController(badgeService service.BadgeService) *BadgeController {
+	return &BadgeController{
+		badgeService: badgeService,
+	}
+}
+
+// GetAuthorBadges 处理查询作者徽章的 HTTP 请求
+// @Summary      查询作者徽章 (公开)
+// @Description  返回作者因帖子浏览量达到里程碑而获得的全部徽章，按颁发时间倒序。浏览量以同步到数据库的值为准，徽章颁发相对实时浏览量有数分钟延迟。
+// @Tags         badges (徽章)
+// @Produce      json
+// @Param        author_id path string true "作者ID"
+// @Success      200 {object} vo.AuthorBadgesResponseWrapper "作者徽章获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "作者ID为空"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/badges/authors/{author_id} [get]
+func (ctrl *BadgeController) GetAuthorBadges(c *gin.Context) {
+	authorID := c.Param("author_id")
+	if authorID == "" {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "作者ID不能为空")
+		return
+	}
+
+	result, err := ctrl.badgeService.GetAuthorBadges(c.Request.Context(), authorID)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询作者徽章失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess(c, result, "作者徽章获取成功")
+}
+
+// GetPostMilestoneProgress 处理查询帖子里程碑进度的 HTTP 请求
+// @Summary      查询帖子浏览量里程碑进度 (公开)
+// @Description  返回帖子已达成的里程碑徽章、下一个里程碑及完成进度。仅对已审核通过的帖子可用。
+// @Tags         badges (徽章)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostMilestoneProgressResponseWrapper "里程碑进度获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或未审核通过"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/badges/posts/{post_id} [get]
+func (ctrl *BadgeController) GetPostMilestoneProgress(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	result, err := ctrl.badgeService.GetPostMilestoneProgress(c.Request.Context(), postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子不存在")
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询里程碑进度失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess(c, result, "里程碑进度获取成功")
+}
+
+// RegisterRoutes 注册 BadgeController 的路由
+func (ctrl *BadgeController) RegisterRoutes(group *gin.RouterGroup) {
+	badges := group.Group("/badges")
+	{
+		badges.GET("/authors/:author_id", ctrl.GetAuthorBadges)      // GET /api/v1/post/badges/authors/:author_id
+		badges.GET("/posts/:post_id", ctrl.GetPostMilestoneProgress) // GET /api/v1/post/badges/posts/:post_id
+	}
+}
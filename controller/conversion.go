@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// ConversionController 定义帖子转化归因分析控制器的结构体
+type ConversionController struct {
+	conversionService service.PostConversionService
+}
+
+// NewConversionController 构造函数，注入服务层依赖
+func NewConversionController(conversionService service.PostConversionService) *ConversionController {
+	return &ConversionController{
+		conversionService: conversionService,
+	}
+}
+
+// ReportConversion 处理转化事件上报的 HTTP 请求
+// @Summary      上报转化事件
+// @Description  客户端在用户浏览帖子详情 (view)、联系作者 (contact)、成交 (deal) 时上报。浏览事件携带来源，联系与成交按该用户 7 天内最近一次浏览的来源归因。同一用户同一阶段每天只计一次；只统计登录用户，匿名用户与作者本人的上报被静默忽略。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.ReportConversionRequest true "转化阶段与浏览来源"
+// @Success      200 {object} vo.BaseResponseWrapper "转化事件已记录"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或未审核通过"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{id}/conversions [post]
+func (ctrl *ConversionController) ReportConversion(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	var req dto.ReportConversionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if err := ctrl.conversionService.ReportConversion(c.Request.Context(), postID, userID, req.EventType, req.Source); err != nil {
+		mapServiceError(c, err, "记录转化事件失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "转化事件已记录")
+}
+
+// GetMyPostConversions 处理作者查询自己帖子转化漏斗的 HTTP 请求
+// @Summary      查询自己帖子的转化漏斗
+// @Description  帖子作者查询最近 30 天的浏览 → 联系 → 成交漏斗与转化率，按天与浏览来源拆分，并附帖子累计浏览量作为对照。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostConversionResponseWrapper "转化漏斗获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{post_id}/conversions [get]
+func (ctrl *ConversionController) GetMyPostConversions(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	conversions, err := ctrl.conversionService.GetAuthorPostConversions(c.Request.Context(), postID, userID)
+	if err != nil {
+		mapServiceError(c, err, "查询转化漏斗失败")
+		return
+	}
+	response.RespondSuccess(c, conversions, "转化漏斗获取成功")
+}
+
+// GetPostConversions 处理管理员查询帖子转化漏斗的 HTTP 请求
+// @Summary      查询帖子转化漏斗 (管理员)
+// @Description  返回帖子最近 30 天的浏览 → 联系 → 成交漏斗与转化率，按天与浏览来源拆分，并附帖子累计浏览量作为对照。
+// @Tags         admin-reports (管理员-报表)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostConversionResponseWrapper "转化漏斗获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/admin/posts/{post_id}/conversions [get]
+func (ctrl *ConversionController) GetPostConversions(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return
+	}
+
+	conversions, err := ctrl.conversionService.GetPostConversions(c.Request.Context(), postID)
+	if err != nil {
+		mapServiceError(c, err, "查询转化漏斗失败")
+		return
+	}
+	response.RespondSuccess(c, conversions, "转化漏斗获取成功")
+}
+
+// RegisterRoutes 注册 ConversionController 的路由
+// - 与 PostController 共用 /posts 前缀：写接口使用 :id，读接口使用 :post_id，与同方法的已有路由保持一致。
+func (ctrl *ConversionController) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/posts/:id/conversions", ctrl.ReportConversion)             // POST /api/v1/post/posts/:id/conversions
+	group.GET("/posts/:post_id/conversions", ctrl.GetMyPostConversions)     // GET /api/v1/post/posts/:post_id/conversions
+	group.GET("/admin/posts/:post_id/conversions", ctrl.GetPostConversions) // GET /api/v1/post/admin/posts/:post_id/conversions
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// CoverExperimentController 定义帖子 A/B 封面实验控制器的结构体
+type CoverExperimentController struct {
+	coverService service.CoverExperimentService
+}
+
+// NewCoverExperimentController 构造函数，注入服务层依赖
+func NewCoverExperimentController(coverService service.CoverExperimentService) *CoverExperimentController {
+	return &CoverExperimentController{
+		coverService: coverService,
+	}
+}
+
+// SetCoverCandidates 处理帖子作者设置候选封面的 HTTP 请求
+// @Summary      设置 A/B 候选封面
+// @Description  帖子作者从帖子已上传的图片中选择 2~4 张作为候选封面，公开列表会按用户稳定分桶展示其中一张；传入空数组表示关闭实验。每次设置都会清空旧的实验统计。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.SetCoverCandidatesRequest true "候选封面图片ID列表"
+// @Success      200 {object} vo.BaseResponseWrapper "候选封面设置成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID、请求负载，或候选封面数量/归属不合法"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者，无权设置"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{id}/cover-candidates [put]
+func (ctrl *CoverExperimentController) SetCoverCandidates(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	var req dto.SetCoverCandidatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	if err := ctrl.coverService.SetCoverCandidates(c.Request.Context(), postID, userID, req.ImageIDs); err != nil {
+		switch {
+		case errors.Is(err, commonerrors.ErrRepoNotFound):
+			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子未找到")
+		case errors.Is(err, myErrors.ErrPermissionDenied):
+			response.RespondError(c, http.StatusForbidden, response.ErrCodeClientForbidden, "只有帖子作者可以设置候选封面")
+		case errors.Is(err, myErrors.ErrInvalidCoverCandidates):
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, err.Error())
+		default:
+			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "设置候选封面失败: "+err.Error())
+		}
+		return
+	}
+	response.RespondSuccess[any](c, nil, "候选封面设置成功")
+}
+
+// RecordCoverClick 处理封面点击上报的 HTTP 请求
+// @Summary      上报 A/B 封面点击
+// @Description  用户从列表点击进入帖子时上报看到的封面（列表响应中的 cover_image_id）。只统计登录用户，且封面必须与该用户的分桶结果一致，其余上报被静默忽略。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.RecordCoverClickRequest true "点击的封面图片ID"
+// @Success      200 {object} vo.BaseResponseWrapper "点击已记录"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{id}/cover-click [post]
+func (ctrl *CoverExperimentController) RecordCoverClick(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	var req dto.RecordCoverClickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if err := ctrl.coverService.RecordCoverClick(c.Request.Context(), postID, req.ImageID, userID); err != nil {
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "记录封面点击失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess[any](c, nil, "点击已记录")
+}
+
+// GetCoverStats 处理查询 A/B 封面表现的 HTTP 请求
+// @Summary      查询 A/B 封面表现统计
+// @Description  帖子作者查询各候选封面的曝光数、点击数与点击率。统计只包含登录用户，自最近一次设置候选封面起累计。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostCoverStatsResponseWrapper "封面统计获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者，无权查看"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{post_id}/cover-stats [get]
+func (ctrl *CoverExperimentController) GetCoverStats(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	stats, err := ctrl.coverService.GetCoverStats(c.Request.Context(), postID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, commonerrors.ErrRepoNotFound):
+			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子未找到")
+		case errors.Is(err, myErrors.ErrPermissionDenied):
+			response.RespondError(c, http.StatusForbidden, response.ErrCodeClientForbidden, "只有帖子作者可以查看封面统计")
+		default:
+			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询封面统计失败: "+err.Error())
+		}
+		return
+	}
+	response.RespondSuccess(c, stats, "封面统计获取成功")
+}
+
+// RegisterRoutes 注册 CoverExperimentController 的路由
+// - 与 PostController 共用 /posts 前缀：写接口使用 :id，读接口使用 :post_id，与同一方法下已有路由的参数名保持一致。
+func (ctrl *CoverExperimentController) RegisterRoutes(group *gin.RouterGroup) {
+	posts := group.Group("/posts")
+	{
+		posts.PUT("/:id/cover-candidates", ctrl.SetCoverCandidates) // PUT /api/v1/post/posts/:id/cover-candidates
+		posts.POST("/:id/cover-click", ctrl.RecordCoverClick)       // POST /api/v1/post/posts/:id/cover-click
+		posts.GET("/:post_id/cover-stats", ctrl.GetCoverStats)      // GET /api/v1/post/posts/:post_id/cover-stats
+	}
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/health"
+)
+
+// HealthController 提供 K8s liveness / readiness 探针使用的健康检查端点。
+// - 探针端点挂在根路由上，不经过 /api/v1/post 分组，返回体不使用统一的业务响应结构。
+type HealthController struct {
+	checker *health.HealthChecker
+}
+
+// NewHealthController 构造函数，注入依赖探活器
+func NewHealthController(checker *health.HealthChecker) *HealthController {
+	return &HealthController{checker: checker}
+}
+
+// Liveness 处理存活探针请求，只要进程能处理 HTTP 请求即返回 200，不探测任何依赖。
+// @Summary      存活探针
+// @Description  进程存活即返回 200，不检查依赖，依赖故障不应导致容器被重启。
+// @Tags         health (健康检查)
+// @Produce      json
+// @Success      200 {object} map[string]string "进程存活"
+// @Router       /healthz [get]
+func (ctrl *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness 处理就绪探针请求，并发探测 MySQL、Redis、Kafka 与 COS。
+// @Summary      就绪探针
+// @Description  任一关键依赖（MySQL、Redis、已配置的 Kafka）不可用时返回 503；COS 为非关键依赖，只展示状态。响应体列出每个依赖的状态与探测耗时。
+// @Tags         health (健康检查)
+// @Produce      json
+// @Success      200 {object} health.Report "所有关键依赖可用"
+// @Failure      503 {object} health.Report "存在不可用的关键依赖"
+// @Router       /readyz [get]
+func (ctrl *HealthController) Readiness(c *gin.Context) {
+	report := ctrl.checker.Check(c.Request.Context())
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// RegisterRoutes 在根路由上注册健康检查端点
+func (ctrl *HealthController) RegisterRoutes(r gin.IRoutes) {
+	r.GET("/healthz", ctrl.Liveness) // GET /healthz
+	r.GET("/readyz", ctrl.Readiness) // GET /readyz
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/go-common/response" // 假设这是你的通用响应包
 	"github.com/gin-gonic/gin"
 
@@ -14,23 +15,30 @@ import (
 // HotPostController 定义热门帖子控制器的结构体
 type HotPostController struct {
 	postService service.PostServiceInterface // 服务层接口
+	converter   service.ChineseConverter     // 按用户偏好对返回的帖子做繁简转换
 }
 
 // NewHotPostController 构造函数，注入服务层依赖
-func NewHotPostController(postService service.PostServiceInterface) *HotPostController {
+func NewHotPostController(postService service.PostServiceInterface, converter service.ChineseConverter) *HotPostController {
 	return &HotPostController{
 		postService: postService,
+		converter:   converter,
 	}
 }
 
 // GetHotPostsByCursor 处理获取热门帖子的 HTTP 请求
 // @Summary      通过游标获取热门帖子
-// @Description  使用基于游标的分页方式，检索热门帖子列表。使用查询参数来传递游标和数量限制。
+// @Description  使用基于游标的分页方式，检索热门帖子列表。使用查询参数来传递游标和数量限制。传入 official_tag 时只返回该官方标签下的热门帖子。缓存不可用时返回数据库中按浏览量排序的近似热榜，此时 degraded 为 true。热榜定期重建，last_post_id 已不在当前热榜中时不报错，从估算位置（按官方标签过滤时为榜首）继续返回，此时 cursor_reset 为 true，客户端应按帖子 ID 去重。
 // @Tags         hot-posts (热门帖子)
 // @Accept       json
 // @Produce      json
 // @Param        last_post_id query uint64 false "上一页最后一个帖子的 ID，首页省略" Format(uint64)
 // @Param        limit query int true "每页帖子数量" Format(int) minimum(1)
+// @Param        official_tag query int false "官方标签过滤 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)，省略时返回全部标签的热门帖子" Enums(0,1,2,3)
+// @Param        X-User-Region header string false "用户地区编码 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Level header int false "用户等级 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Tags header string false "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
 // @Success      200 {object} vo.ListPostsByCursorResponseWrapper "热门帖子检索成功。" // <--- 修改
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的输入参数（例如，无效的 limit 或 last_post_id 格式）" // <--- 修改
 // @Failure      500 {object} vo.BaseResponseWrapper "检索热门帖子时发生内部服务器错误" // <--- 修改
@@ -61,23 +69,35 @@ func (ctrl *HotPostController) GetHotPostsByCursor(c *gin.Context) {
 		return
 	}
 
-	// 3. 调用服务层获取热门帖子
-	posts, nextCursor, err := ctrl.postService.GetHotPostsByCursor(c.Request.Context(), lastPostID, limit)
+	// 3. 处理 official_tag 参数（可选）
+	var officialTag *enums.OfficialTag
+	if tagStr := c.Query("official_tag"); tagStr != "" {
+		tag, err := strconv.Atoi(tagStr)
+		if err != nil || tag < int(enums.OfficialTagNone) || tag > int(enums.OfficialTagRapid) {
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的 official_tag，必须是 0-3 之间的整数")
+			return
+		}
+		t := enums.OfficialTag(tag)
+		officialTag = &t
+	}
+
+	// 4. 调用服务层获取热门帖子（Redis 不可用时服务层返回数据库近似热榜，degraded 为 true）
+	var result *vo.ListHotPostsByCursorResponse
+	if officialTag != nil {
+		result, err = ctrl.postService.GetHotPostsByTag(c.Request.Context(), *officialTag, lastPostID, limit, viewerFromRequest(c))
+	} else {
+		result, err = ctrl.postService.GetHotPostsByCursor(c.Request.Context(), lastPostID, limit, viewerFromRequest(c))
+	}
 	if err != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "检索热门帖子失败: "+err.Error())
+		mapServiceError(c, err, "检索热门帖子失败")
 		return
 	}
 
-	//  todo 有问题
-	// 4. 构造响应结构体 - 如注释所述，复用 ListHotPostsByCursorResponse
-	// 确保 vo.ListHotPostsByCursorResponse 结构体匹配预期的输出 {posts, next_cursor}
-	responseData := vo.ListHotPostsByCursorResponse{ // 这里的业务逻辑仍然使用原始的 VO
-		Posts:      posts, // 假设 GetHotPostsByCursor 返回 []*vo.PostResponse
-		NextCursor: nextCursor,
-	}
+	// 5. 按请求的中文字形转换后返回
+	result.Posts = ctrl.converter.ConvertPostResponses(result.Posts, chineseScriptFromRequest(c))
 
-	// 5. 返回成功响应
-	response.RespondSuccess(c, responseData, "热门帖子检索成功")
+	// 6. 返回成功响应
+	response.RespondSuccess(c, result, "热门帖子检索成功")
 }
 
 // GetHotPostDetail 处理获取热门帖子详情的 HTTP 请求
@@ -87,9 +107,13 @@ func (ctrl *HotPostController) GetHotPostsByCursor(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Param        X-Device-ID header string false "客户端设备标识 (由网关透传，开启匿名浏览统计时用于未登录用户的浏览去重，缺失时使用客户端 IP)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
 // @Success      200 {object} vo.PostDetailResponseWrapper "热门帖子详情检索成功" // <--- 修改
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式" // <--- 修改
 // @Failure      401 {object} vo.BaseResponseWrapper "在上下文中未找到用户 ID（未授权）" // <--- 修改
+// @Failure      402 {object} vo.PostAccessDeniedResponseWrapper "帖子需要付费后查看"
+// @Failure      403 {object} vo.PostAccessDeniedResponseWrapper "不满足帖子的访问策略（如未关注作者）"
 // @Failure      404 {object} vo.BaseResponseWrapper "热门帖子详情未找到" // <-- 添加404情况
 // @Failure      500 {object} vo.BaseResponseWrapper "检索热门帖子详情时发生内部服务器错误" // <--- 修改
 // @Router       /api/v1/post/hot-posts/{post_id} [get]
@@ -112,9 +136,11 @@ func (ctrl *HotPostController) GetHotPostDetail(c *gin.Context) {
 	}
 
 	// 3. 调用服务层获取热门帖子详情
-	responseData, err := ctrl.postService.GetHotPostDetail(c.Request.Context(), postID, userIDStr)
+	responseData, err := ctrl.postService.GetHotPostDetail(c.Request.Context(), postID, userIDStr, viewerFromRequest(c))
 	if err != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "检索热门帖子详情失败: "+err.Error())
+		// 访问策略鉴权未通过返回 401/402/403；缓存未命中会在服务层回源数据库，
+		// 只有帖子确实不存在（或不满足投放定向条件）时才返回 404
+		mapServiceError(c, err, "检索热门帖子详情失败")
 		return
 	}
 	// 检查 responseData 是否为 nil（如果服务对于未找到的情况返回 nil, nil，则可能发生）
@@ -125,7 +151,7 @@ func (ctrl *HotPostController) GetHotPostDetail(c *gin.Context) {
 
 	// 5. 返回成功响应
 	// 因为服务返回 *vo.PostDetailResponse，所以需要解引用 responseData
-	response.RespondSuccess(c, *responseData, "热门帖子详情检索成功")
+	response.RespondSuccess(c, *ctrl.converter.ConvertPostDetail(responseData, chineseScriptFromRequest(c)), "热门帖子详情检索成功")
 }
 
 // RegisterRoutes 注册 HotPostController 的路由
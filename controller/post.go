@@ -1,14 +1,23 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/Xushengqwer/go-common/constants"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/Xushengqwer/go-common/response" // 你的通用响应包
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
 	"github.com/Xushengqwer/post_service/service"
 )
 
@@ -16,13 +25,15 @@ import (
 type PostController struct {
 	postService     service.PostService // 服务层接口，通过依赖注入传入
 	PostListService service.PostListService
+	converter       service.ChineseConverter // 按用户偏好对返回的帖子做繁简转换
 }
 
 // NewPostController 构造函数，用于创建 PostController 实例
-func NewPostController(postService service.PostService, PostListService service.PostListService) *PostController {
+func NewPostController(postService service.PostService, PostListService service.PostListService, converter service.ChineseConverter) *PostController {
 	return &PostController{
 		postService:     postService,
 		PostListService: PostListService,
+		converter:       converter,
 	}
 }
 
@@ -37,6 +48,8 @@ func NewPostController(postService service.PostService, PostListService service.
 // @Param        officialTag query int false "官方标签 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)" format(int32) Enums(0,1,2,3)
 // @Param        title query string false "标题模糊搜索关键词 (最大长度 255)" maxLength(255)
 // @Param        status query int false "帖子状态 (0:待审核, 1:审核通过, 2:拒绝)" format(int32) Enums(0,1,2)
+// @Param        isDraft query bool false "是否只看草稿 (true:仅草稿, false:仅已提交的帖子, 不传:全部)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
 // @Success      200 {object} vo.ListUserPostPageResponseWrapper "成功响应，包含用户帖子列表和总记录数"
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数"
 // @Failure      401 {object} vo.BaseResponseWrapper "用户未授权或认证失败"
@@ -69,32 +82,35 @@ func (ctrl *PostController) GetUserPosts(c *gin.Context) {
 	// UserID 将在服务层从 c.Request.Context() 中获取
 	ListUserPostPageVO, err := ctrl.PostListService.GetUserPosts(c.Request.Context(), userID, &reqDTO) // <--- 修改了这里
 	if err != nil {
-		if err.Error() == "unauthorized" { // 简单示例，实际应使用 errors.Is 和 commonerrors.ErrUnauthorized
-			response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "用户未授权: "+err.Error())
-		} else {
-			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "获取用户帖子列表失败: "+err.Error())
-		}
+		mapServiceError(c, err, "获取用户帖子列表失败")
 		return
 	}
 
 	// 3. 成功响应
+	ListUserPostPageVO.Posts = ctrl.converter.ConvertPostResponses(ListUserPostPageVO.Posts, chineseScriptFromRequest(c))
 	response.RespondSuccess(c, ListUserPostPageVO, "用户帖子列表获取成功")
 }
 
 // GetPostsTimeline 获取帖子时间线列表 (游标分页)
 // @Summary      获取帖子时间线列表 (公开)
-// @Description  根据指定条件（官方标签、标题、作者用户名）和游标分页获取帖子列表，按时间倒序排列。
+// @Description  根据指定条件（官方标签、标题、作者用户名）和游标分页获取帖子列表，按发布先后倒序排列。翻页时只需回传上一页的 nextPostId。
 // @Tags         posts (帖子)
 // @Accept       json
 // @Produce      json
-// @Param        lastCreatedAt query string false "上一页最后一条记录的创建时间 (RFC3339格式, e.g., 2023-01-01T15:04:05Z)" format(date-time)
-// @Param        lastPostId query uint64 false "上一页最后一条记录的帖子ID" format(uint64) minimum(1)
+// @Param        lastCreatedAt query string false "已废弃，传入后忽略：上一页最后一条记录的创建时间 (RFC3339格式)" format(date-time)
+// @Param        lastPostId query uint64 false "上一页最后一条记录的帖子ID (即上一页响应的 nextPostId)" format(uint64) minimum(1)
 // @Param        pageSize query int true "每页数量" format(int32) minimum(1) maximum(100) default(10)
 // @Param        officialTag query int false "官方标签 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)" format(int32) Enums(0,1,2,3)
 // @Param        title query string false "标题模糊搜索关键词 (最大长度 255)" maxLength(255)
 // @Param        authorUsername query string false "作者用户名模糊搜索关键词 (最大长度 50)" maxLength(50)
-// @Success      200 {object} vo.PostTimelinePageResponseWrapper "成功响应，包含帖子列表和下一页游标信息"
-// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数"
+// @Param        author_id query []string false "只看这些作者的帖子（关注流），可重复传参或逗号分隔，去重后最多 200 个" collectionFormat(multi)
+// @Param        collapseSimilar query bool false "是否折叠当前页内容高度相似的帖子，被折叠的帖子放在代表帖的 similar_posts 中" default(false)
+// @Param        X-User-Region header string false "用户地区编码 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Level header int false "用户等级 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Tags header string false "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
+// @Success      200 {object} vo.PostTimelineCardPageResponseWrapper "成功响应，包含帖子卡片列表（不含审核状态与原因）和下一页游标信息"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数，或作者 ID 数量超过上限"
 // @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
 // @Router       /api/v1/post/posts/timeline [get]
 func (ctrl *PostController) GetPostsTimeline(c *gin.Context) {
@@ -103,22 +119,104 @@ func (ctrl *PostController) GetPostsTimeline(c *gin.Context) {
 		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
 		return
 	}
+	authorIDs, err := reqDTO.NormalizeAuthorIDs()
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
 	serviceQueryDTO := &dto.TimelineQueryDTO{
-		LastCreatedAt:  reqDTO.LastCreatedAt,
-		LastPostID:     reqDTO.LastPostID,
-		PageSize:       reqDTO.PageSize,
-		OfficialTag:    reqDTO.OfficialTag,
-		Title:          reqDTO.Title,
-		AuthorUsername: reqDTO.AuthorUsername,
+		LastCreatedAt:   reqDTO.LastCreatedAt,
+		LastPostID:      reqDTO.LastPostID,
+		PageSize:        reqDTO.PageSize,
+		OfficialTag:     reqDTO.OfficialTag,
+		Title:           reqDTO.Title,
+		AuthorUsername:  reqDTO.AuthorUsername,
+		AuthorIDs:       authorIDs,
+		CollapseSimilar: reqDTO.CollapseSimilar,
+		Viewer:          viewerFromRequest(c),
 	}
 	timelinePageVO, err := ctrl.PostListService.GetPostsByTimeline(c.Request.Context(), serviceQueryDTO)
 	if err != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "获取帖子列表失败: "+err.Error())
+		mapServiceError(c, err, "获取帖子列表失败")
 		return
 	}
+	timelinePageVO.Posts = ctrl.converter.ConvertPostCards(timelinePageVO.Posts, chineseScriptFromRequest(c))
 	response.RespondSuccess(c, timelinePageVO, "帖子时间线获取成功")
 }
 
+// SearchPosts 按关键词全文搜索帖子
+// @Summary      全文搜索帖子 (公开)
+// @Description  在已审核通过帖子的标题与正文中检索关键词，按相关性排序（标题命中优先）。多个词以空格分隔，每个词都必须命中；全文检索运算符会被忽略。翻页时回传上一页响应的 next_cursor。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        keyword query string true "搜索关键词 (最大长度 100)" maxLength(100)
+// @Param        cursor query uint64 false "上一页响应的 next_cursor，首次加载不传" format(uint64)
+// @Param        pageSize query int true "每页数量" format(int32) minimum(1) maximum(50) default(10)
+// @Param        X-User-Region header string false "用户地区编码 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Level header int false "用户等级 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Tags header string false "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
+// @Success      200 {object} vo.ListPostsByCursorResponseWrapper "成功响应，包含帖子列表和下一页游标"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数，或关键词不包含有效内容"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/search [get]
+func (ctrl *PostController) SearchPosts(c *gin.Context) {
+	var reqDTO dto.SearchPostsRequestDTO
+	if err := c.ShouldBindQuery(&reqDTO); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+	result, err := ctrl.PostListService.SearchPosts(c.Request.Context(), reqDTO.Keyword, reqDTO.Cursor, reqDTO.PageSize, viewerFromRequest(c))
+	if err != nil {
+		mapServiceError(c, err, "搜索帖子失败")
+		return
+	}
+	result.Posts = ctrl.converter.ConvertPostResponses(result.Posts, chineseScriptFromRequest(c))
+	response.RespondSuccess(c, result, "帖子搜索成功")
+}
+
+// GetPopularPosts 获取按浏览量倒序排列的公开帖子列表
+// @Summary      按浏览量倒序获取帖子列表 (公开)
+// @Description  返回全部已审核通过帖子按浏览量倒序的列表（非实时热榜，浏览量为定时同步的持久化值），浏览量相同时按帖子ID倒序。翻页时同时回传上一页响应的 nextViewCount 与 nextPostId。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        lastViewCount query int64 false "上一页响应的 nextViewCount，首次加载不传" format(int64) minimum(0)
+// @Param        lastPostId query uint64 false "上一页响应的 nextPostId，首次加载不传" format(uint64) minimum(1)
+// @Param        pageSize query int true "每页数量" format(int32) minimum(1) maximum(50) default(10)
+// @Param        X-User-Region header string false "用户地区编码 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Level header int false "用户等级 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Tags header string false "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
+// @Success      200 {object} vo.PopularPostsPageResponseWrapper "成功响应，包含帖子列表和下一页游标"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数，或游标参数不完整"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/popular [get]
+func (ctrl *PostController) GetPopularPosts(c *gin.Context) {
+	var reqDTO dto.ListPopularPostsRequestDTO
+	if err := c.ShouldBindQuery(&reqDTO); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+	if (reqDTO.LastViewCount == nil) != (reqDTO.LastPostID == nil) {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "lastViewCount 与 lastPostId 必须同时提供")
+		return
+	}
+	var cursor *dto.PostViewCountCursor
+	if reqDTO.LastPostID != nil {
+		cursor = &dto.PostViewCountCursor{ViewCount: *reqDTO.LastViewCount, PostID: *reqDTO.LastPostID}
+	}
+
+	result, err := ctrl.PostListService.GetPostsByViewCount(c.Request.Context(), cursor, reqDTO.PageSize, viewerFromRequest(c))
+	if err != nil {
+		mapServiceError(c, err, "获取帖子列表失败")
+		return
+	}
+	result.Posts = ctrl.converter.ConvertPostResponses(result.Posts, chineseScriptFromRequest(c))
+	response.RespondSuccess(c, result, "帖子列表获取成功")
+}
+
 // CreatePost 处理创建帖子的 HTTP 请求，包含图片上传。
 // DTO 字段作为独立的表单字段提交。
 // @Summary      创建新帖子 (独立表单字段及图片)
@@ -133,17 +231,43 @@ func (ctrl *PostController) GetPostsTimeline(c *gin.Context) {
 // @Param        author_id formData string true "作者ID"
 // @Param        author_avatar formData string false "作者头像 URL (可选, 需为有效URL)" format(url)
 // @Param        author_username formData string true "作者用户名" maxLength(50)
-// @Param        images formData file true "帖子图片文件 (可多选)"
+// @Param        copyright_type formData int false "版权声明类型 (0:原创, 1:转载, 2:禁止转载)" Enums(0,1,2) default(0)
+// @Param        source_url formData string false "转载来源地址 (copyright_type=1 时必填)" format(url) maxLength(512)
+// @Param        urgent formData bool false "是否付费加急审核 (可选, 加急帖按高优先级送审)" default(false)
+// @Param        X-User-Level header int false "作者等级 (由网关注入，达到阈值的作者按高优先级送审)"
+// @Param        Idempotency-Key header string false "幂等键 (可选, 最长 128 字符; 重试同一次提交时携带相同的值，10 分钟内重复请求直接返回首次创建的结果)"
+// @Param        faqs formData string false "FAQ 列表 (可选, JSON 数组字符串, 例如 [{\"question\":\"...\",\"answer\":\"...\"}], 最多20条)"
+// @Param        target_regions formData []string false "投放地区编码列表 (可选, 不填表示不限)" collectionFormat(multi)
+// @Param        target_min_level formData int false "投放最低用户等级 (可选, 0 表示不限)" minimum(0)
+// @Param        target_tags formData []string false "投放用户标签列表 (可选, 命中任意一个即可见)" collectionFormat(multi)
+// @Param        access_policy formData int false "详情访问策略 (可选, 按位组合: 1=需登录, 2=需付费, 4=需关注作者, 0=公开)" minimum(0) maximum(7)
+// @Param        quoted_post_id formData uint64 false "转发的原帖ID (可选, 设置后 content 即为转发语)" minimum(1)
+// @Param        save_as_draft formData bool false "是否保存为草稿 (可选, 草稿不送审、仅作者可见，之后通过发布接口提交审核)" default(false)
+// @Param        ignore_similar formData bool false "忽略查重提示继续发布 (可选, 查重命中相似帖子时返回 409，确认后带上 true 重新提交)" default(false)
+// @Param        keep_original_images formData bool false "是否保留原图 (可选, 上传前会压缩图片，开启后被压缩的图片额外保存一份原图供下载)" default(false)
+// @Param        images formData file false "帖子图片文件 (可多选，与 image_urls 合计的数量上下限按帖子类型配置，如商品帖至少 1 张)"
+// @Param        image_urls formData []string false "从 URL 导入的图片 (可选, 仅支持 http/https 且不能指向内网地址，服务端下载后与上传的文件一起校验)" collectionFormat(multi)
+// @Param        image_url_positions formData []int false "URL 图片在最终图片列表中的位置 (可选, 从 0 开始, 与 image_urls 一一对应; 省略时排在上传的文件之后)" collectionFormat(multi)
+// @Param        image_object_keys formData []string false "已直传到 COS 的图片对象键 (可选, 由 /posts/images/presign 生成，排在其他图片之后，计入图片数量上下限)" collectionFormat(multi)
 // @Success      200 {object} vo.PostDetailResponseWrapper "帖子创建成功"
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载或文件处理错误"
+// @Failure      400 {object} vo.BaseResponseWrapper "被转发的原帖不存在、已删除或未审核通过，访问策略不受支持，或正文清洗后为空"
+// @Failure      400 {object} vo.BaseResponseWrapper "图片数量不在该类帖子的上下限内、单张大小超过上限，或上传的文件不是图片"
+// @Failure      400 {object} vo.BaseResponseWrapper "图片 URL 无效、指向内网地址、下载失败或超时，或未开启 URL 导入"
+// @Failure      400 {object} vo.BaseResponseWrapper "直传图片的对象键不属于当前作者、对象尚未上传、不是图片或超过大小上限"
+// @Failure      403 {object} vo.BaseResponseWrapper "原帖声明禁止转载，不允许转发"
+// @Failure      409 {object} vo.SimilarPostsResponseWrapper "平台上已有高度相似的帖子，data 中携带相似帖子"
+// @Failure      409 {object} vo.BaseResponseWrapper "相同幂等键的请求仍在处理中"
 // @Failure      500 {object} vo.BaseResponseWrapper "创建帖子时发生内部服务器错误"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
 // @Router       /api/v1/post/posts [post]
 func (ctrl *PostController) CreatePost(c *gin.Context) {
 	// 1. 解析 Multipart Form (确保在访问表单数据或文件之前调用)
 	// 设置表单解析的最大内存，超出部分会存到临时磁盘文件
 	// 例如：32MB (32 << 20)
+	// 请求体总大小由 RequestBodyLimitMiddleware 限制，超限时返回 413
 	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
-		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "解析表单数据失败: "+err.Error())
+		respondBodyParseError(c, "解析表单数据失败: ", err)
 		return
 	}
 
@@ -158,6 +282,27 @@ func (ctrl *PostController) CreatePost(c *gin.Context) {
 		return
 	}
 
+	// 2.0 作者等级由网关注入的请求头决定，用于判断送审优先级
+	req.AuthorLevel = viewerFromRequest(c).Level
+	// 2.0.1 幂等键用于识别客户端因网络抖动重试的同一次提交
+	req.IdempotencyKey = strings.TrimSpace(c.GetHeader(constant.IdempotencyKeyHeader))
+	if len(req.IdempotencyKey) > constant.IdempotencyKeyMaxLength {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, fmt.Sprintf("%s 请求头过长，最多 %d 个字符", constant.IdempotencyKeyHeader, constant.IdempotencyKeyMaxLength))
+		return
+	}
+
+	// 2.1 解析 FAQ 列表（multipart 表单中以 JSON 数组字符串提交）
+	if req.FAQsJSON != "" {
+		if err := json.Unmarshal([]byte(req.FAQsJSON), &req.FAQs); err != nil {
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "faqs 字段不是合法的 JSON 数组: "+err.Error())
+			return
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "FAQ 数据校验失败: "+err.Error())
+			return
+		}
+	}
+
 	// 3. 获取图片文件部分
 	// "images" 是前端 FormData.append("images", file) 时使用的字段名
 	// c.Request.MultipartForm 可以在 ParseMultipartForm 之后安全调用
@@ -177,7 +322,7 @@ func (ctrl *PostController) CreatePost(c *gin.Context) {
 	// 4. 调用服务层处理
 	postDetailVO, serviceErr := ctrl.postService.CreatePost(c.Request.Context(), &req, imageFiles)
 	if serviceErr != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "创建帖子失败: "+serviceErr.Error())
+		mapServiceError(c, serviceErr, "创建帖子失败")
 		return
 	}
 
@@ -193,6 +338,7 @@ func (ctrl *PostController) CreatePost(c *gin.Context) {
 // @Param        id path uint64 true "帖子 ID" Format(uint64)
 // @Success      200 {object} vo.BaseResponseWrapper "帖子删除成功"
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
 // @Failure      500 {object} vo.BaseResponseWrapper "删除帖子时发生内部服务器错误"
 // @Router       /api/v1/post/posts/{id} [delete]
 func (ctrl *PostController) DeletePost(c *gin.Context) {
@@ -203,7 +349,7 @@ func (ctrl *PostController) DeletePost(c *gin.Context) {
 		return
 	}
 	if err := ctrl.postService.DeletePost(c.Request.Context(), id); err != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "删除帖子失败: "+err.Error())
+		mapServiceError(c, err, "删除帖子失败")
 		return
 	}
 	response.RespondSuccess[any](c, nil, "帖子删除成功")
@@ -211,13 +357,16 @@ func (ctrl *PostController) DeletePost(c *gin.Context) {
 
 // ListPostsByUserID 处理获取指定用户公开发布的帖子列表 (游标加载)
 // @Summary      获取指定用户的帖子列表 (公开, 游标加载)
-// @Description  使用游标分页方式，检索特定用户公开发布的帖子列表。
+// @Description  使用游标分页方式，检索特定用户公开发布的帖子列表。查询者是作者本人时返回全部状态的帖子（包括草稿、待审核与被拒绝的帖子），可用 status 筛选；查看他人时只返回已审核通过的帖子。
 // @Tags         posts (帖子)
 // @Accept       json
 // @Produce      json
 // @Param        user_id query string true "要查询其帖子的用户 ID"
 // @Param        cursor query uint64 false "游标（上一页最后一个帖子的 ID），首页省略" Format(uint64)
 // @Param        page_size query int true "每页帖子数量" Format(int) minimum(1)
+// @Param        status query int false "状态筛选 (0:待审核, 1:已审核, 2:已拒绝)，只在查询者是作者本人时生效，省略表示全部状态" Enums(0,1,2)
+// @Param        X-User-ID header string false "用户 ID (由网关/中间件注入)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
 // @Success      200 {object} vo.ListPostsByCursorResponseWrapper "帖子检索成功" // 确保 vo.ListPostsByUserIDResponseWrapper 对应游标加载的响应结构
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的输入参数"
 // @Failure      500 {object} vo.BaseResponseWrapper "检索帖子时发生内部服务器错误"
@@ -242,10 +391,13 @@ func (ctrl *PostController) ListPostsByUserID(c *gin.Context) {
 		return
 	}
 
+	// 3. 查询者身份决定是否返回非公开状态的帖子
+	req.ViewerID = c.GetString(string(constants.UserIDKey))
+
 	// 5. 调用服务层获取帖子列表
 	result, err := ctrl.PostListService.ListPostsByUserID(c.Request.Context(), &req) // 传递绑定好的请求 DTO
 	if err != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "检索帖子失败: "+err.Error())
+		mapServiceError(c, err, "检索帖子失败")
 		return
 	}
 
@@ -254,19 +406,64 @@ func (ctrl *PostController) ListPostsByUserID(c *gin.Context) {
 	// 并且你的 response.RespondSuccess 能够正确处理它。
 	// 如果 ListPostsByUserID 返回的是指针，而 RespondSuccess 期望值，你可能需要解引用 *result。
 	// 但根据你之前的 CreatePost 和 GetPostDetailByPostID，你传递的是 *post 和 *detail，所以这里保持一致。
+	result.Posts = ctrl.converter.ConvertPostResponses(result.Posts, chineseScriptFromRequest(c))
 	response.RespondSuccess(c, result, "帖子检索成功")
 }
 
+// ListPostsByAuthors 处理按多个作者获取帖子时间线的请求 (游标加载)
+// @Summary      获取多个作者的帖子时间线 (公开, 游标加载)
+// @Description  一次查询多个作者已审核通过的帖子，按创建时间倒序混合排列，用于推荐/关注流。作者列表通过请求体传递，最多 2000 个。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ListPostsByAuthorsRequest true "作者列表与分页游标"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
+// @Success      200 {object} vo.PostTimelinePageResponseWrapper "成功响应，包含帖子列表和下一页游标信息"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Router       /api/v1/post/posts/by-authors [post]
+func (ctrl *PostController) ListPostsByAuthors(c *gin.Context) {
+	var req dto.ListPostsByAuthorsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求参数: ", err)
+		return
+	}
+
+	// 翻页只依赖 lastPostId；lastCreatedAt 为兼容旧客户端保留，不参与翻页
+	var cursor *dto.PostTimelineCursor
+	if req.LastPostID != nil {
+		cursor = &dto.PostTimelineCursor{CreatedAt: req.LastCreatedAt, PostID: *req.LastPostID}
+	}
+
+	result, err := ctrl.PostListService.ListPostsByAuthors(c.Request.Context(), req.AuthorIDs, cursor, req.PageSize)
+	if err != nil {
+		mapServiceError(c, err, "获取帖子列表失败")
+		return
+	}
+	result.Posts = ctrl.converter.ConvertPostResponses(result.Posts, chineseScriptFromRequest(c))
+	response.RespondSuccess(c, result, "帖子时间线获取成功")
+}
+
 // GetPostDetailByPostID 处理获取帖子详情的 HTTP 请求
 // @Summary      获取指定ID的帖子详情 (公开)
-// @Description  通过帖子的 ID 检索特定帖子的详细信息。同时，如果用户已登录（通过中间件注入UserID），则会尝试增加浏览量。
+// @Description  通过帖子的 ID 检索特定帖子的详细信息。同时会尝试增加浏览量：登录用户按 UserID 去重；开启匿名浏览统计时，未登录用户按设备标识 (X-Device-ID) 或客户端 IP 去重。
 // @Tags         posts (帖子)
 // @Accept       json
 // @Produce      json
 // @Param        post_id path uint64 true "帖子 ID" Format(uint64)
 // @Param        X-User-ID header string false "用户 ID (由网关/中间件注入)"
+// @Param        X-Device-ID header string false "客户端设备标识 (由网关透传，用于匿名浏览去重，缺失时使用客户端 IP)"
+// @Param        X-User-Region header string false "用户地区编码 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Level header int false "用户等级 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Tags header string false "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
 // @Success      200 {object} vo.PostDetailResponseWrapper "帖子详情检索成功"
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      401 {object} vo.PostAccessDeniedResponseWrapper "帖子需要登录后查看"
+// @Failure      402 {object} vo.PostAccessDeniedResponseWrapper "帖子需要付费后查看"
+// @Failure      403 {object} vo.PostAccessDeniedResponseWrapper "不满足帖子的访问策略（如未关注作者）"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或对当前用户不可见"
 // @Failure      500 {object} vo.BaseResponseWrapper "检索帖子详情时发生内部服务器错误"
 // @Router       /api/v1/post/posts/{post_id} [get]
 func (ctrl *PostController) GetPostDetailByPostID(c *gin.Context) {
@@ -282,26 +479,427 @@ func (ctrl *PostController) GetPostDetailByPostID(c *gin.Context) {
 	userID := c.GetString(string(constants.UserIDKey)) // 使用 GetString 更安全，如果 key 不存在会返回 ""
 
 	// 将 gin.Context 中的 Request.Context() 和获取到的 UserID 传递给服务层
-	detail, err := ctrl.postService.GetPostDetailByPostID(c.Request.Context(), postID, userID)
+	detail, err := ctrl.postService.GetPostDetailByPostID(c.Request.Context(), postID, userID, viewerFromRequest(c))
+	if err != nil {
+		// 访问策略鉴权未通过返回 401/402/403；帖子不存在或不满足投放定向条件时统一返回 404，避免暴露定向帖的存在
+		mapServiceError(c, err, "检索帖子详情失败")
+		return
+	}
+
+	response.RespondSuccess(c, ctrl.converter.ConvertPostDetail(detail, chineseScriptFromRequest(c)), "帖子详情检索成功")
+}
+
+// UpdatePostFAQs 处理帖子作者整体替换 FAQ 列表的 HTTP 请求
+// @Summary      更新帖子 FAQ
+// @Description  帖子作者整体替换帖子的 FAQ 列表，列表顺序即展示顺序；传入空列表表示清空。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.UpdatePostFAQsRequest true "FAQ 列表"
+// @Success      200 {object} vo.PostFAQsResponseWrapper "FAQ 更新成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者，无权修改"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      500 {object} vo.BaseResponseWrapper "更新 FAQ 时发生内部服务器错误"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Router       /api/v1/post/posts/{id}/faqs [put]
+func (ctrl *PostController) UpdatePostFAQs(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	var req dto.UpdatePostFAQsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	faqs, err := ctrl.postService.UpdatePostFAQs(c.Request.Context(), postID, userID, req.FAQs)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrPermissionDenied) {
+			response.RespondError(c, http.StatusForbidden, response.ErrCodeClientForbidden, "只有帖子作者可以修改 FAQ")
+			return
+		}
+		mapServiceError(c, err, "更新 FAQ 失败")
+		return
+	}
+	response.RespondSuccess(c, faqs, "FAQ 更新成功")
+}
+
+// PublishDraft 处理作者发布草稿的 HTTP 请求
+// @Summary      发布草稿
+// @Description  将作者自己的草稿提交审核：帖子转为待审核状态并发送待审核事件。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.BaseResponseWrapper "草稿已提交审核"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者，无权发布"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      409 {object} vo.BaseResponseWrapper "帖子不是草稿或已发布"
+// @Failure      500 {object} vo.BaseResponseWrapper "发布草稿时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{id}/publish [post]
+func (ctrl *PostController) PublishDraft(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	if err := ctrl.postService.PublishDraft(c.Request.Context(), postID, userID); err != nil {
+		if errors.Is(err, myErrors.ErrPermissionDenied) {
+			response.RespondError(c, http.StatusForbidden, response.ErrCodeClientForbidden, "只有帖子作者可以发布草稿")
+			return
+		}
+		mapServiceError(c, err, "发布草稿失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "草稿已提交审核")
+}
+
+// ReportPost 处理用户举报帖子的 HTTP 请求
+// @Summary      举报帖子
+// @Description  举报已审核通过的违规帖子，同一用户对同一帖子只能举报一次。举报数达到阈值时自动提交下架审查。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.ReportPostRequest true "举报请求"
+// @Success      200 {object} vo.BaseResponseWrapper "举报成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或未审核通过"
+// @Failure      409 {object} vo.BaseResponseWrapper "已经举报过该帖子"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "举报时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{id}/report [post]
+func (ctrl *PostController) ReportPost(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	var req dto.ReportPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	if err := ctrl.postService.ReportPost(c.Request.Context(), postID, userID, req.Reason); err != nil {
+		mapServiceError(c, err, "举报帖子失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "举报成功")
+}
+
+// PresignImageUpload 处理申请图片直传 URL 的 HTTP 请求
+// @Summary      申请图片直传 URL
+// @Description  生成帖子图片的 COS 对象键与预签名上传 URL。客户端在有效期内以 PUT 方法把图片上传到 upload_url（需设置图片的 Content-Type），之后在创建帖子时通过 image_object_keys 提交 object_key，图片内容不经过本服务。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.PresignImageUploadRequest true "待上传图片的文件名"
+// @Success      200 {object} vo.PresignedImageUploadResponseWrapper "生成成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载或不支持的图片格式"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "生成上传 URL 时发生内部服务器错误"
+// @Router       /api/v1/post/posts/images/presign [post]
+func (ctrl *PostController) PresignImageUpload(c *gin.Context) {
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	var req dto.PresignImageUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	result, err := ctrl.postService.PrepareImageUpload(c.Request.Context(), userID, req.Filename)
 	if err != nil {
-		// 这里可以根据 service 返回的错误类型，决定返回 404 还是 500
-		// 暂时保持 500，但可以细化
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "检索帖子详情失败: "+err.Error())
+		mapServiceError(c, err, "生成图片上传 URL 失败")
 		return
 	}
+	response.RespondSuccess(c, result, "生成图片上传 URL 成功")
+}
 
-	response.RespondSuccess(c, detail, "帖子详情检索成功")
+// AppealPost 处理作者申诉被拒帖子的 HTTP 请求
+// @Summary      申诉被拒帖子
+// @Description  作者对审核被拒的帖子提交申诉，帖子重新变为待审核并携带申诉理由送人工复审。每个帖子最多申诉 3 次。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.AppealPostRequest true "申诉请求"
+// @Success      200 {object} vo.BaseResponseWrapper "申诉已提交"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载，或内容不符合目标地区的内容规范"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
+// @Failure      409 {object} vo.BaseResponseWrapper "帖子不是审核被拒状态，或申诉次数已用完"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "申诉时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{id}/appeal [post]
+func (ctrl *PostController) AppealPost(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	var req dto.AppealPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	if err := ctrl.postService.AppealPost(c.Request.Context(), postID, userID, req.Reason); err != nil {
+		mapServiceError(c, err, "申诉帖子失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "申诉已提交，等待重新审核")
+}
+
+// UpdatePost 处理作者编辑帖子的 HTTP 请求
+// @Summary      编辑帖子
+// @Description  作者编辑审核通过或被拒的帖子，编辑后重新变为待审核。与上次送审的正文逐段（按行）比对，只有新增与修改的段落送审，未变更的段落沿用已有的审核结果；变更过多时整篇送审。任一段落未通过时整篇帖子被拒，拒绝原因注明未通过的段落序号。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.UpdatePostRequest true "编辑后的帖子内容"
+// @Success      200 {object} vo.BaseResponseWrapper "帖子已编辑并重新送审"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载，或内容不符合目标地区的内容规范"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      403 {object} vo.BaseResponseWrapper "非帖子作者"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
+// @Failure      409 {object} vo.BaseResponseWrapper "帖子是草稿或正在审核中"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "编辑帖子时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{id} [put]
+func (ctrl *PostController) UpdatePost(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	var req dto.UpdatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	if err := ctrl.postService.UpdatePost(c.Request.Context(), postID, userID, &req); err != nil {
+		mapServiceError(c, err, "编辑帖子失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "帖子已编辑，等待重新审核")
+}
+
+// GetPostSEO 处理获取帖子 SEO 元数据的 HTTP 请求
+// @Summary      获取帖子 SEO 元数据 (公开)
+// @Description  基于帖子标题、正文摘要与官方标签实时生成 meta description 与 keywords，description 最长 160 个字符。仅对已审核通过且对匿名访客可见的帖子生成，受限访问的帖子以标题代替正文摘要。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostSEOResponseWrapper "SEO 元数据获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或不可公开访问"
+// @Failure      500 {object} vo.BaseResponseWrapper "生成 SEO 元数据时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{post_id}/seo [get]
+func (ctrl *PostController) GetPostSEO(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+
+	seo, err := ctrl.postService.GetPostSEO(c.Request.Context(), postID)
+	if err != nil {
+		mapServiceError(c, err, "生成 SEO 元数据失败")
+		return
+	}
+	response.RespondSuccess(c, seo, "SEO 元数据获取成功")
+}
+
+// GetPostReferences 处理查询帖子被引用（转发）列表的 HTTP 请求
+// @Summary      获取转发了指定帖子的帖子列表 (公开, 游标加载)
+// @Description  按时间倒序返回转发/引用了该帖子的已审核通过帖子，已删除的转发帖不会出现。原帖不存在或未审核通过时返回 404。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        post_id path uint64 true "原帖 ID" Format(uint64)
+// @Param        lastPostId query uint64 false "上一页最后一条记录的 ID，首页省略"
+// @Param        pageSize query int true "每页数量 (1-100)"
+// @Param        X-User-Script header string false "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断" Enums(hans,hant,original)
+// @Success      200 {object} vo.PostTimelinePageResponseWrapper "成功响应，包含转发帖列表和下一页游标信息"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或查询参数"
+// @Failure      404 {object} vo.BaseResponseWrapper "原帖不存在或未审核通过"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{post_id}/references [get]
+func (ctrl *PostController) GetPostReferences(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	var req dto.GetPostReferencesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+
+	var cursor *dto.PostTimelineCursor
+	if req.LastPostID != nil {
+		cursor = &dto.PostTimelineCursor{PostID: *req.LastPostID}
+	}
+
+	result, err := ctrl.PostListService.GetPostReferences(c.Request.Context(), postID, cursor, req.PageSize)
+	if err != nil {
+		mapServiceError(c, err, "获取帖子引用列表失败")
+		return
+	}
+	result.Posts = ctrl.converter.ConvertPostResponses(result.Posts, chineseScriptFromRequest(c))
+	response.RespondSuccess(c, result, "帖子引用列表获取成功")
+}
+
+// LikePost 处理用户点赞帖子的 HTTP 请求
+// @Summary      点赞帖子
+// @Description  为已审核通过的帖子点赞，重复点赞是幂等的，不会重复计数。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostLikeResponseWrapper "点赞成功，返回实时点赞数"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或未审核通过"
+// @Failure      500 {object} vo.BaseResponseWrapper "点赞时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{id}/like [post]
+func (ctrl *PostController) LikePost(c *gin.Context) {
+	ctrl.handleLike(c, ctrl.postService.LikePost, "点赞成功")
+}
+
+// UnlikePost 处理用户取消点赞的 HTTP 请求
+// @Summary      取消点赞帖子
+// @Description  取消对帖子的点赞，取消未点赞的帖子是幂等的，点赞数不变。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostLikeResponseWrapper "取消点赞成功，返回实时点赞数"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
+// @Failure      500 {object} vo.BaseResponseWrapper "取消点赞时发生内部服务器错误"
+// @Router       /api/v1/post/posts/{id}/like [delete]
+func (ctrl *PostController) UnlikePost(c *gin.Context) {
+	ctrl.handleLike(c, ctrl.postService.UnlikePost, "取消点赞成功")
+}
+
+// handleLike 是点赞与取消点赞共用的参数解析与错误映射。
+func (ctrl *PostController) handleLike(c *gin.Context, action func(ctx context.Context, postID uint64, userID string) (*vo.PostLikeVO, error), successMsg string) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+
+	result, err := action(c.Request.Context(), postID, userID)
+	if err != nil {
+		mapServiceError(c, err, "点赞操作失败")
+		return
+	}
+	response.RespondSuccess(c, result, successMsg)
+}
+
+// MergeAnonymousViews 处理登录后归并设备匿名浏览记录的 HTTP 请求
+// @Summary      归并设备匿名浏览记录
+// @Description  客户端在用户登录成功后调用，将当前设备登录前的匿名浏览记录归并到用户，之后用户在任何设备上浏览这些帖子都不会重复计数。浏览量本身不变。UserID 从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Produce      json
+// @Param        X-Device-ID header string true "客户端设备标识，需与登录前浏览时使用的一致"
+// @Success      200 {object} vo.MergeAnonymousViewsResponseWrapper "归并完成"
+// @Failure      400 {object} vo.BaseResponseWrapper "请求缺少设备标识"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      500 {object} vo.BaseResponseWrapper "归并时发生内部服务器错误"
+// @Router       /api/v1/post/posts/views/merge-anonymous [post]
+func (ctrl *PostController) MergeAnonymousViews(c *gin.Context) {
+	viewer := viewerFromRequest(c)
+	result, err := ctrl.postService.MergeAnonymousViews(c.Request.Context(), viewer.UserID, viewer)
+	if err != nil {
+		mapServiceError(c, err, "归并匿名浏览记录失败")
+		return
+	}
+	response.RespondSuccess(c, result, "匿名浏览记录归并成功")
 }
 
 // RegisterRoutes 注册 PostController 的路由
 func (ctrl *PostController) RegisterRoutes(group *gin.RouterGroup) {
 	posts := group.Group("/posts")
 	{
-		posts.POST("", ctrl.CreatePost)                    // POST /api/v1/post/posts
-		posts.DELETE("/:id", ctrl.DeletePost)              // DELETE /api/v1/post/posts/:id
-		posts.GET("/timeline", ctrl.GetPostsTimeline)      // GET /api/v1/post/posts/timeline
-		posts.GET("/mine", ctrl.GetUserPosts)              // GET /api/v1/post/posts/mine
-		posts.GET("/by-author", ctrl.ListPostsByUserID)    // GET /api/v1/post/posts/by-author (路径已修改)
-		posts.GET("/:post_id", ctrl.GetPostDetailByPostID) // GET /api/v1/post/posts/:post_id
+		posts.POST("", ctrl.CreatePost)                                // POST /api/v1/post/posts
+		posts.POST("/similar-check", ctrl.CheckSimilarPosts)           // POST /api/v1/post/posts/similar-check
+		posts.POST("/images/presign", ctrl.PresignImageUpload)         // POST /api/v1/post/posts/images/presign
+		posts.DELETE("/:id", ctrl.DeletePost)                          // DELETE /api/v1/post/posts/:id
+		posts.PUT("/:id", ctrl.UpdatePost)                             // PUT /api/v1/post/posts/:id
+		posts.PUT("/:id/faqs", ctrl.UpdatePostFAQs)                    // PUT /api/v1/post/posts/:id/faqs
+		posts.POST("/:id/publish", ctrl.PublishDraft)                  // POST /api/v1/post/posts/:id/publish
+		posts.POST("/:id/like", ctrl.LikePost)                         // POST /api/v1/post/posts/:id/like
+		posts.DELETE("/:id/like", ctrl.UnlikePost)                     // DELETE /api/v1/post/posts/:id/like
+		posts.POST("/:id/report", ctrl.ReportPost)                     // POST /api/v1/post/posts/:id/report
+		posts.POST("/:id/appeal", ctrl.AppealPost)                     // POST /api/v1/post/posts/:id/appeal
+		posts.GET("/timeline", ctrl.GetPostsTimeline)                  // GET /api/v1/post/posts/timeline
+		posts.GET("/mine", ctrl.GetUserPosts)                          // GET /api/v1/post/posts/mine
+		posts.GET("/search", ctrl.SearchPosts)                         // GET /api/v1/post/posts/search
+		posts.GET("/popular", ctrl.GetPopularPosts)                    // GET /api/v1/post/posts/popular
+		posts.GET("/by-author", ctrl.ListPostsByUserID)                // GET /api/v1/post/posts/by-author (路径已修改)
+		posts.POST("/by-authors", ctrl.ListPostsByAuthors)             // POST /api/v1/post/posts/by-authors
+		posts.POST("/views/merge-anonymous", ctrl.MergeAnonymousViews) // POST /api/v1/post/posts/views/merge-anonymous
+		posts.GET("/:post_id", ctrl.GetPostDetailByPostID)             // GET /api/v1/post/posts/:post_id
+		posts.GET("/:post_id/seo", ctrl.GetPostSEO)                    // GET /api/v1/post/posts/:post_id/seo
+		posts.GET("/:post_id/references", ctrl.GetPostReferences)      // GET /api/v1/post/posts/:post_id/references
 	}
 }
@@ -2,30 +2,49 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"github.com/Xushengqwer/go-common/constants"
 	"net/http"
 	"strconv" // 如果需要在路径中添加 ID 参数，则需要此包
+	"time"
 
-	"github.com/Xushengqwer/go-common/commonerrors" // 假设包含 ErrRepoNotFound 等通用错误
-	"github.com/Xushengqwer/go-common/response"     // 假设这是你的通用响应包
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/go-common/response" // 假设这是你的通用响应包
 	"github.com/gin-gonic/gin"
 
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
 	"github.com/Xushengqwer/post_service/service"
 )
 
 // PostAdminController 定义帖子管理员控制器的结构体
 type PostAdminController struct {
-	adminService service.PostAdminService // 服务层接口
+	adminService    service.PostAdminService     // 服务层接口
+	auditLogService service.AdminAuditLogService // 管理员操作审计日志查询
 }
 
 // NewPostAdminController 构造函数，注入服务层依赖
-func NewPostAdminController(adminService service.PostAdminService) *PostAdminController {
+func NewPostAdminController(adminService service.PostAdminService, auditLogService service.AdminAuditLogService) *PostAdminController {
 	return &PostAdminController{
-		adminService: adminService,
+		adminService:    adminService,
+		auditLogService: auditLogService,
 	}
 }
 
+// adminUserIDFromContext 从 Gin 上下文中获取当前管理员的用户 ID（由 UserContextMiddleware 设置）。
+// - 获取失败时直接写入 401 响应并返回 false，调用方应立即返回。
+// - 管理员 ID 作为操作人写入审计日志，因此所有写操作都必须能取到。
+func adminUserIDFromContext(c *gin.Context) (string, bool) {
+	adminID, ok := c.Get(string(constants.UserIDKey))
+	adminIDStr, isString := adminID.(string)
+	if !ok || !isString || adminIDStr == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取管理员ID，用户可能未登录或凭证缺失")
+		return "", false
+	}
+	return adminIDStr, true
+}
+
 // AuditPost 处理管理员审核帖子的 HTTP 请求
 // @Summary      审核帖子
 // @Description  管理员更新帖子的状态（以及可选的原因）。需要在请求体中提供审核详情。
@@ -35,29 +54,34 @@ func NewPostAdminController(adminService service.PostAdminService) *PostAdminCon
 // @Param        request body dto.AuditPostRequest true "审核帖子请求体"
 // @Success      200 {object} vo.BaseResponseWrapper "帖子审核成功" // <--- 修改 (无 Data)
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载（例如，缺少字段，无效的状态）" // <--- 修改
+// @Failure      400 {object} vo.BaseResponseWrapper "帖子版权声明不合理（例如转载帖未注明来源），无法审核通过"
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
 // @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到" // <-- 添加404情况
+// @Failure      409 {object} vo.BaseResponseWrapper "帖子仍是草稿，尚未提交审核"
 // @Failure      500 {object} vo.BaseResponseWrapper "审核过程中发生内部服务器错误" // <--- 修改
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
 // @Router       /api/v1/post/admin/posts/audit [post]
 func (ctrl *PostAdminController) AuditPost(c *gin.Context) {
 	// 1. 从请求体绑定 JSON 数据
 	var req dto.AuditPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的请求负载: "+err.Error())
+		respondBodyParseError(c, "无效的请求负载: ", err)
 		return
 	}
 
 	// 如果绑定不能覆盖 Status 枚举的验证，可以在这里添加潜在的验证
 	// 例如：if req.Status < enums.Pending || req.Status > enums.Rejected { ... }
 
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
 	// 2. 调用服务层审核帖子
 	// 假设 AuditPost 能恰当处理未找到的错误
-	if err := ctrl.adminService.AuditPost(c.Request.Context(), &req); err != nil {
-		// 处理服务层可能返回的 '未找到' 错误
-		if errors.Is(err, commonerrors.ErrRepoNotFound) {
-			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "审核的帖子未找到")
-		} else {
-			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "审核帖子失败: "+err.Error())
-		}
+	if err := ctrl.adminService.AuditPost(c.Request.Context(), &req, adminID); err != nil {
+		// 帖子不存在返回 404，版权声明不合理返回 400，仍是草稿返回 409
+		mapServiceError(c, err, "审核帖子失败")
 		return
 	}
 
@@ -65,6 +89,122 @@ func (ctrl *PostAdminController) AuditPost(c *gin.Context) {
 	response.RespondSuccess[any](c, nil, "帖子审核成功") // 运行时仍然可以传 nil data
 }
 
+// BatchAuditPosts 处理管理员批量审核帖子的 HTTP 请求
+// @Summary      批量审核帖子
+// @Description  管理员一次审核多个帖子。单个帖子审核失败不影响其他帖子，响应中返回每个帖子的处理结果。
+// @Tags         admin-posts (管理员-帖子)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.BatchAuditRequest true "批量审核请求体 (最多 100 条)"
+// @Success      200 {object} vo.BatchAuditResponseWrapper "批量审核处理完成（需检查每条结果）"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载"
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
+// @Failure      500 {object} vo.BaseResponseWrapper "批量审核过程中发生内部服务器错误"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Router       /api/v1/post/admin/posts/batch-audit [post]
+func (ctrl *PostAdminController) BatchAuditPosts(c *gin.Context) {
+	// 1. 绑定并校验请求体
+	var req dto.BatchAuditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	// 2. 调用服务层批量审核（部分失败体现在结果中，不作为整体错误）
+	result, err := ctrl.adminService.BatchAuditPosts(c.Request.Context(), &req, adminID)
+	if err != nil {
+		mapServiceError(c, err, "批量审核帖子失败")
+		return
+	}
+
+	// 3. 返回成功响应
+	response.RespondSuccess(c, result, "批量审核处理完成")
+}
+
+// BatchUpdateOfficialTag 处理管理员批量更新帖子官方标签的 HTTP 请求
+// @Summary      批量更新帖子官方标签 (管理员)
+// @Description  为一批帖子打上同一个官方标签（official_tag 为 0 表示批量移除标签），单次最多 500 个帖子。响应中返回实际更新的数量与不存在或已删除的帖子 ID。
+// @Tags         admin-posts (管理员-帖子)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.BatchUpdateOfficialTagRequest true "批量更新官方标签请求体"
+// @Success      200 {object} vo.BatchResultResponseWrapper "批量更新完成（需检查未找到的帖子）"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载或标签值"
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
+// @Failure      500 {object} vo.BaseResponseWrapper "批量更新标签时发生内部服务器错误"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Router       /api/v1/post/admin/posts/batch-official-tag [put]
+func (ctrl *PostAdminController) BatchUpdateOfficialTag(c *gin.Context) {
+	var req dto.BatchUpdateOfficialTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.adminService.BatchUpdateOfficialTag(c.Request.Context(), req.PostIDs, req.OfficialTag, adminID)
+	if err != nil {
+		mapServiceError(c, err, "批量更新官方标签失败")
+		return
+	}
+	response.RespondSuccess(c, result, "批量更新官方标签完成")
+}
+
+// GetRecentViews 处理获取最近 N 分钟全站浏览量的 HTTP 请求
+// @Summary      最近 N 分钟全站浏览量 (管理员)
+// @Description  基于 Redis 分钟级时间桶聚合最近 N 分钟的全站浏览量，供运营大屏使用。窗口包含当前未结束的分钟。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        minutes query int false "统计窗口（分钟），默认 5，最大 60" minimum(1) maximum(60) default(5)
+// @Success      200 {object} vo.RecentViewsResponseWrapper "获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的 minutes 参数"
+// @Failure      500 {object} vo.BaseResponseWrapper "获取浏览量时发生内部服务器错误"
+// @Router       /api/v1/post/admin/posts/views/recent [get]
+func (ctrl *PostAdminController) GetRecentViews(c *gin.Context) {
+	minutes := 5
+	if minutesStr := c.Query("minutes"); minutesStr != "" {
+		parsed, err := strconv.Atoi(minutesStr)
+		if err != nil || parsed <= 0 || parsed > constant.ViewBucketRetentionMinutes {
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的 minutes 参数")
+			return
+		}
+		minutes = parsed
+	}
+
+	result, err := ctrl.adminService.GetRecentViews(c.Request.Context(), minutes)
+	if err != nil {
+		mapServiceError(c, err, "获取最近浏览量失败")
+		return
+	}
+	response.RespondSuccess(c, result, "获取最近浏览量成功")
+}
+
+// GetPostStats 处理帖子数量统计的 HTTP 请求
+// @Summary      帖子数量统计 (管理员)
+// @Description  按审核状态与官方标签聚合帖子数量，供运营看板展示。不含已删除的帖子，草稿单独计数；结果缓存 1 分钟。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Success      200 {object} vo.PostStatsResponseWrapper "获取成功"
+// @Failure      500 {object} vo.BaseResponseWrapper "统计时发生内部服务器错误"
+// @Router       /api/v1/post/admin/posts/stats [get]
+func (ctrl *PostAdminController) GetPostStats(c *gin.Context) {
+	result, err := ctrl.adminService.GetPostStats(c.Request.Context())
+	if err != nil {
+		mapServiceError(c, err, "获取帖子数量统计失败")
+		return
+	}
+	response.RespondSuccess(c, result, "获取帖子数量统计成功")
+}
+
 // ListPostsByCondition 处理按条件查询帖子列表的 HTTP 请求
 // @Summary      按条件列出帖子 (管理员)
 // @Description  出于管理目的，根据各种过滤条件检索分页的帖子列表。使用查询参数进行过滤和分页。
@@ -75,15 +215,18 @@ func (ctrl *PostAdminController) AuditPost(c *gin.Context) {
 // @Param        title query string false "按帖子标题过滤（模糊匹配）"
 // @Param        author_username query string false "按作者用户名过滤（模糊匹配）"
 // @Param        status query int false "按帖子状态过滤 (0=待审核, 1=已审核, 2=已拒绝)" Enums(0, 1, 2)
-// @Param        official_tag query int false "按官方标签过滤 (例如, 0=无, 1=官方认证)" Enums(0, 1, 2, 3)
+// @Param        official_tag query int false "按官方标签过滤，拥有该标签即匹配（帖子可同时拥有多个标签）；0 只匹配没有标签的帖子 (例如, 0=无, 1=官方认证)" Enums(0, 1, 2, 3)
 // @Param        view_count_min query int64 false "按最小浏览量过滤" Format(int64)
 // @Param        view_count_max query int64 false "按最大浏览量过滤" Format(int64)
-// @Param        order_by query string false "排序字段 (created_at 或 updated_at)" Enums(created_at, updated_at) default(created_at)
-// @Param        order_desc query bool false "是否降序排序 (true 为 DESC, false/省略为 ASC)" default(false)
-// @Param        page query int true "页码（从 1 开始）" Format(int) minimum(1)
+// @Param        created_at_start query string false "按创建时间下限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T00:00:00+08:00）" Format(date-time)
+// @Param        created_at_end query string false "按创建时间上限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T23:59:59+08:00）" Format(date-time)
+// @Param        order_by query string false "排序条件，逗号分隔的 字段[:asc|desc]，最多 3 个，如 view_count:desc,created_at:desc。可用字段: created_at, updated_at, view_count, like_count" default(created_at)
+// @Param        order_desc query bool false "未指定方向的排序字段是否降序 (true 为 DESC, false/省略为 ASC)" default(false)
+// @Param        page query int false "页码（从 1 开始，未携带 cursor_id 时必填）" Format(int) minimum(1)
 // @Param        page_size query int true "每页帖子数量" Format(int) minimum(1)
+// @Param        cursor_id query uint64 false "游标（上一页响应的 next_cursor）。携带时按 ID 游标分页，忽略 page 与 order_by，不统计总数 (total 为 -1)" Format(uint64)
 // @Success      200 {object} vo.ListPostsAdminResponseWrapper "帖子检索成功" // <--- 修改
-// @Failure      400 {object} vo.BaseResponseWrapper "无效的输入参数（例如，无效的 page, page_size, status）" // <--- 修改
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的输入参数（例如，无效的 page, page_size, status, order_by，或创建时间下限晚于上限）" // <--- 修改
 // @Failure      500 {object} vo.BaseResponseWrapper "检索帖子时发生内部服务器错误" // <--- 修改
 // @Router       /api/v1/post/admin/posts [get]
 func (ctrl *PostAdminController) ListPostsByCondition(c *gin.Context) {
@@ -102,15 +245,20 @@ func (ctrl *PostAdminController) ListPostsByCondition(c *gin.Context) {
 	if req.PageSize <= 0 {
 		req.PageSize = 10 // 如果无效或缺失，默认页面大小为 10
 	}
-	// 如果需要，验证 OrderBy
-	if req.OrderBy != "created_at" && req.OrderBy != "updated_at" {
-		req.OrderBy = "created_at" // 默认排序字段
+	if req.CreatedAtStart != nil && req.CreatedAtEnd != nil && req.CreatedAtStart.After(*req.CreatedAtEnd) {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "created_at_start 不能晚于 created_at_end")
+		return
+	}
+	// 解析组合排序条件，字段不在白名单中时直接拒绝
+	if err := req.ParseOrderBy(); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的排序参数: "+err.Error())
+		return
 	}
 
 	// 2. 调用服务层查询帖子列表
 	result, err := ctrl.adminService.ListPostsByCondition(c.Request.Context(), &req)
 	if err != nil {
-		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "检索帖子失败: "+err.Error())
+		mapServiceError(c, err, "检索帖子失败")
 		return
 	}
 
@@ -123,9 +271,57 @@ func (ctrl *PostAdminController) ListPostsByCondition(c *gin.Context) {
 	response.RespondSuccess(c, *result, "帖子检索成功")
 }
 
+// ExportPostsByCondition 处理按条件导出帖子 CSV 的 HTTP 请求
+// @Summary      按条件导出帖子 CSV (管理员)
+// @Description  按与帖子列表相同的筛选条件导出全部匹配的帖子（不分页），以 CSV 附件流式返回，列为 id、title、author_username、status、view_count、official_tag、created_at。按帖子 ID 顺序导出。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      text/csv
+// @Param        id query uint64 false "按精确的帖子 ID 过滤" Format(uint64)
+// @Param        title query string false "按帖子标题过滤（模糊匹配）"
+// @Param        author_username query string false "按作者用户名过滤（模糊匹配）"
+// @Param        status query int false "按帖子状态过滤 (0=待审核, 1=已审核, 2=已拒绝)" Enums(0, 1, 2)
+// @Param        official_tag query int false "按官方标签过滤，拥有该标签即匹配（帖子可同时拥有多个标签）；0 只匹配没有标签的帖子 (例如, 0=无, 1=官方认证)" Enums(0, 1, 2, 3)
+// @Param        view_count_min query int64 false "按最小浏览量过滤" Format(int64)
+// @Param        view_count_max query int64 false "按最大浏览量过滤" Format(int64)
+// @Param        created_at_start query string false "按创建时间下限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T00:00:00+08:00）" Format(date-time)
+// @Param        created_at_end query string false "按创建时间上限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T23:59:59+08:00）" Format(date-time)
+// @Param        order_desc query bool false "是否按帖子 ID 降序导出（从最新的帖子开始）" default(false)
+// @Success      200 {file} file "CSV 文件 (UTF-8 BOM)"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的输入参数（例如，创建时间下限晚于上限）"
+// @Failure      500 {object} vo.BaseResponseWrapper "开始导出前发生内部服务器错误；导出过程中出错时文件被截断"
+// @Router       /api/v1/post/admin/posts/export [get]
+func (ctrl *PostAdminController) ExportPostsByCondition(c *gin.Context) {
+	var req dto.ExportPostsByConditionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+	if req.CreatedAtStart != nil && req.CreatedAtEnd != nil && req.CreatedAtStart.After(*req.CreatedAtEnd) {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "created_at_start 不能晚于 created_at_end")
+		return
+	}
+
+	// 结果边查边写，响应头在第一次写出时发送；之后出错无法再改状态码，只能中止响应，客户端得到被截断的文件
+	filename := fmt.Sprintf("posts_export_%s.csv", time.Now().Format("20060102150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	if err := ctrl.adminService.ExportPostsByCondition(c.Request.Context(), &req, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			mapServiceError(c, err, "导出帖子失败")
+			return
+		}
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
 // UpdateOfficialTag 处理管理员更新帖子官方标签的 HTTP 请求
 // @Summary      更新帖子官方标签 (管理员)
-// @Description  管理员更新特定帖子的官方标签。需要在 URL 路径中提供帖子 ID，并在请求体中提供标签详情。
+// @Description  管理员更新特定帖子的官方标签，帖子已有的全部标签被替换为请求中的一个标签（0 表示清空标签）；需要保留其他标签时使用追加/移除单个标签接口。需要在 URL 路径中提供帖子 ID，并在请求体中提供标签详情。
 // @Tags         admin-posts (管理员-帖子)
 // @Accept       json
 // @Produce      json
@@ -133,8 +329,10 @@ func (ctrl *PostAdminController) ListPostsByCondition(c *gin.Context) {
 // @Param        request body dto.UpdateOfficialTagRequest true "更新官方标签请求体 (请求体中的 PostID 是冗余的，请使用路径中的 ID)"
 // @Success      200 {object} vo.BaseResponseWrapper "官方标签更新成功" // <--- 修改 (无 Data)
 // @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载，无效的标签值，或路径 ID 与请求体 ID 不匹配" // <--- 修改
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
 // @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到" // <--- 修改
 // @Failure      500 {object} vo.BaseResponseWrapper "更新标签时发生内部服务器错误" // <--- 修改
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
 // @Router       /api/v1/post/admin/posts/{id}/official-tag [put] // 改为 PUT，因为是更新操作
 func (ctrl *PostAdminController) UpdateOfficialTag(c *gin.Context) {
 	// 1. 从 URL 路径参数获取帖子 ID
@@ -148,7 +346,7 @@ func (ctrl *PostAdminController) UpdateOfficialTag(c *gin.Context) {
 	// 2. 从请求体绑定 JSON 数据
 	var req dto.UpdateOfficialTagRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的请求负载: "+err.Error())
+		respondBodyParseError(c, "无效的请求负载: ", err)
 		return
 	}
 
@@ -160,14 +358,14 @@ func (ctrl *PostAdminController) UpdateOfficialTag(c *gin.Context) {
 	// 使用路径中的 ID 进行服务调用
 	req.PostID = pathPostID
 
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
 	// 4. 调用服务层更新官方标签
-	if err := ctrl.adminService.UpdateOfficialTag(c.Request.Context(), &req); err != nil {
-		// 根据服务层返回的错误类型判断是 404 还是 500
-		if errors.Is(err, commonerrors.ErrRepoNotFound) { // 假设服务层返回或包装了此错误
-			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子未找到")
-		} else {
-			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "更新官方标签失败: "+err.Error())
-		}
+	if err := ctrl.adminService.UpdateOfficialTag(c.Request.Context(), &req, adminID); err != nil {
+		mapServiceError(c, err, "更新官方标签失败")
 		return
 	}
 
@@ -175,17 +373,93 @@ func (ctrl *PostAdminController) UpdateOfficialTag(c *gin.Context) {
 	response.RespondSuccess[any](c, nil, "官方标签更新成功") // 运行时仍然可以传 nil data
 }
 
+// AddOfficialTagFlag 处理管理员为帖子追加官方标签的 HTTP 请求
+// @Summary      追加帖子官方标签 (管理员)
+// @Description  为帖子追加一个官方标签，保留帖子已有的其他标签；帖子已有该标签时同样成功。已审核通过的帖子新增标签时推送给该标签的订阅者。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        tag path int true "官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)" Enums(1, 2, 3)
+// @Success      200 {object} vo.BaseResponseWrapper "官方标签追加成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "帖子 ID 或官方标签不合法"
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      500 {object} vo.BaseResponseWrapper "内部服务器错误"
+// @Router       /api/v1/post/admin/posts/{id}/official-tags/{tag} [post]
+func (ctrl *PostAdminController) AddOfficialTagFlag(c *gin.Context) {
+	postID, tag, ok := parsePostOfficialTagPath(c)
+	if !ok {
+		return
+	}
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.adminService.AddOfficialTagFlag(c.Request.Context(), postID, tag, adminID); err != nil {
+		mapServiceError(c, err, "追加官方标签失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "官方标签追加成功")
+}
+
+// RemoveOfficialTagFlag 处理管理员移除帖子官方标签的 HTTP 请求
+// @Summary      移除帖子官方标签 (管理员)
+// @Description  移除帖子的一个官方标签，保留其他标签；帖子本就没有该标签时同样成功。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        tag path int true "官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)" Enums(1, 2, 3)
+// @Success      200 {object} vo.BaseResponseWrapper "官方标签移除成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "帖子 ID 或官方标签不合法"
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      500 {object} vo.BaseResponseWrapper "内部服务器错误"
+// @Router       /api/v1/post/admin/posts/{id}/official-tags/{tag} [delete]
+func (ctrl *PostAdminController) RemoveOfficialTagFlag(c *gin.Context) {
+	postID, tag, ok := parsePostOfficialTagPath(c)
+	if !ok {
+		return
+	}
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.adminService.RemoveOfficialTagFlag(c.Request.Context(), postID, tag, adminID); err != nil {
+		mapServiceError(c, err, "移除官方标签失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "官方标签移除成功")
+}
+
+// parsePostOfficialTagPath 解析路径中的帖子 ID 与官方标签，格式错误时直接返回 400。
+// - 标签是否在合法范围内由服务层校验（myErrors.ErrInvalidOfficialTag）。
+func parsePostOfficialTagPath(c *gin.Context) (uint64, enums.OfficialTag, bool) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return 0, 0, false
+	}
+	tag, err := strconv.Atoi(c.Param("tag"))
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的官方标签格式无效")
+		return 0, 0, false
+	}
+	return postID, enums.OfficialTag(tag), true
+}
+
 // DeletePostByAdmin 处理管理员删除帖子的请求
 // @Summary 管理员删除帖子 (Admin delete post)
-// @Description 管理员软删除指定ID的帖子 (Admin soft deletes a post with the specified ID)
+// @Description 管理员软删除指定ID的帖子 (Admin soft deletes a post with the specified ID)。浏览量超过配置阈值的帖子需要带 confirm=true 二次确认，否则返回 409 及删除影响面（浏览量、是否在热榜）。
 // @Tags Admin
 // @Accept json
 // @Produce json
 // @Param post_id path string true "帖子ID (Post ID)"
+// @Param confirm query bool false "确认删除高浏览量帖子" default(false)
 // @Success 200 {object} vo.BaseResponseWrapper "帖子删除成功"
-// @Failure 400 {object} vo.BaseResponseWrapper "无效的帖子ID格式"
+// @Failure 400 {object} vo.BaseResponseWrapper "无效的帖子ID格式或 confirm 参数"
 // @Failure 401 {object} vo.BaseResponseWrapper "管理员未登录或无权限"
 // @Failure 404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure 409 {object} vo.DeleteConfirmRequiredResponseWrapper "帖子浏览量较高，需要带 confirm=true 二次确认"
 // @Failure 500 {object} vo.BaseResponseWrapper "删除帖子时发生内部服务器错误"
 // @Router /api/v1/post/admin/posts/{post_id} [delete]
 func (s *PostAdminController) DeletePostByAdmin(c *gin.Context) {
@@ -217,17 +491,30 @@ func (s *PostAdminController) DeletePostByAdmin(c *gin.Context) {
 		return
 	}
 
+	// 2.1 解析二次确认参数（缺省为未确认）
+	confirm := false
+	if confirmStr := c.Query("confirm"); confirmStr != "" {
+		if confirm, err = strconv.ParseBool(confirmStr); err != nil {
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的 confirm 参数")
+			return
+		}
+	}
+
 	// 3. 调用服务层方法删除帖子
 	// 确保 s.adminService 字段在 PostAdminController 中已正确初始化
 	// DeletePostByAdmin(ctx context.Context, postID uint64, adminUserID string) error
-	err = s.adminService.DeletePostByAdmin(c.Request.Context(), postID, adminID)
+	err = s.adminService.DeletePostByAdmin(c.Request.Context(), postID, adminID, confirm)
 	if err != nil {
-		if errors.Is(err, commonerrors.ErrRepoNotFound) { // 假设 myErrors.ErrPostNotFound 存在
-			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子未找到")
-		} else {
-			// 对于其他来自服务层的错误，统一处理为内部服务器错误
-			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "删除帖子失败: "+err.Error())
+		var confirmErr *service.DeleteConfirmRequiredError
+		if errors.As(err, &confirmErr) {
+			c.JSON(http.StatusConflict, response.APIResponse[*vo.DeleteConfirmRequiredVO]{
+				Code:    response.ErrCodeClientInvalidInput,
+				Message: "该帖子浏览量较高，请确认后带 confirm=true 重新提交删除",
+				Data:    confirmErr.VO(),
+			})
+			return
 		}
+		mapServiceError(c, err, "删除帖子失败")
 		return
 	}
 
@@ -235,13 +522,212 @@ func (s *PostAdminController) DeletePostByAdmin(c *gin.Context) {
 	response.RespondSuccess[any](c, nil, "帖子删除成功")
 }
 
+// RestorePost 处理管理员恢复已删除帖子的请求
+// @Summary 管理员恢复已删除的帖子 (Admin restore deleted post)
+// @Description 撤销软删除，恢复帖子及其详情、图片和 FAQ。恢复后帖子状态重置为待审核，需要重新审核通过后才会公开。
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param post_id path string true "帖子ID (Post ID)"
+// @Success 200 {object} vo.BaseResponseWrapper "帖子恢复成功，已重新提交审核"
+// @Failure 400 {object} vo.BaseResponseWrapper "无效的帖子ID格式"
+// @Failure 401 {object} vo.BaseResponseWrapper "管理员未登录或无权限"
+// @Failure 404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure 409 {object} vo.BaseResponseWrapper "帖子未被删除，无需恢复"
+// @Failure 500 {object} vo.BaseResponseWrapper "恢复帖子时发生内部服务器错误"
+// @Router /api/v1/post/admin/posts/{post_id}/restore [post]
+func (s *PostAdminController) RestorePost(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return
+	}
+
+	adminID, ok := c.Get(string(constants.UserIDKey))
+	adminIDStr, isString := adminID.(string)
+	if !ok || !isString || adminIDStr == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取管理员ID，用户可能未登录或凭证缺失")
+		return
+	}
+
+	if err := s.adminService.RestorePost(c.Request.Context(), postID, adminIDStr); err != nil {
+		mapServiceError(c, err, "恢复帖子失败")
+		return
+	}
+	response.RespondSuccess[any](c, nil, "帖子恢复成功，已重新提交审核")
+}
+
+// ListPostAuditLogs 处理管理员查询单个帖子审计历史的 HTTP 请求
+// @Summary      查询帖子审计历史 (管理员)
+// @Description  分页查询指定帖子的审核、改标签、删除记录（含操作人、操作前后状态与原因），按操作时间倒序。已删除帖子同样可查。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Param        page query int true "页码（从 1 开始）" Format(int) minimum(1)
+// @Param        page_size query int true "每页数量" Format(int) minimum(1) maximum(100)
+// @Success      200 {object} vo.ListPostAuditLogsResponseWrapper "帖子审计历史获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或查询参数"
+// @Failure      500 {object} vo.BaseResponseWrapper "查询审计历史时发生内部服务器错误"
+// @Router       /api/v1/post/admin/posts/{post_id}/audit-logs [get]
+func (ctrl *PostAdminController) ListPostAuditLogs(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return
+	}
+	var req dto.ListPostAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+
+	result, err := ctrl.adminService.ListPostAuditLogs(c.Request.Context(), postID, req.Page, req.PageSize)
+	if err != nil {
+		mapServiceError(c, err, "查询帖子审计历史失败")
+		return
+	}
+	response.RespondSuccess(c, result, "帖子审计历史获取成功")
+}
+
+// ListReportedPosts 处理管理员查询被举报帖子的 HTTP 请求
+// @Summary      查询被举报帖子 (管理员)
+// @Description  按举报数倒序分页列出被用户举报的帖子（举报数相同时新帖在前），附带最近一次被举报的时间。已删除的帖子不再列出。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        page query int true "页码（从 1 开始）" Format(int) minimum(1)
+// @Param        page_size query int true "每页数量" Format(int) minimum(1) maximum(100)
+// @Success      200 {object} vo.ListReportedPostsResponseWrapper "被举报帖子列表获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的查询参数"
+// @Failure      500 {object} vo.BaseResponseWrapper "查询被举报帖子时发生内部服务器错误"
+// @Router       /api/v1/post/admin/posts/reported [get]
+func (ctrl *PostAdminController) ListReportedPosts(c *gin.Context) {
+	var req dto.ListReportedPostsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+
+	result, err := ctrl.adminService.ListReportedPosts(c.Request.Context(), req.Page, req.PageSize)
+	if err != nil {
+		mapServiceError(c, err, "查询被举报帖子失败")
+		return
+	}
+	response.RespondSuccess(c, result, "被举报帖子列表获取成功")
+}
+
+// GetPostFullDetail 处理管理员查询单个帖子完整详情的 HTTP 请求
+// @Summary      查询帖子完整详情 (管理员)
+// @Description  返回帖子的标题、正文、图片以及审核状态、审核原因、审核优先级与删除信息，供审核时查看。已删除的帖子同样可查。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.AdminPostDetailResponseWrapper "帖子完整详情获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子未找到"
+// @Failure      500 {object} vo.BaseResponseWrapper "查询帖子详情时发生内部服务器错误"
+// @Router       /api/v1/post/admin/posts/{post_id}/detail [get]
+func (ctrl *PostAdminController) GetPostFullDetail(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return
+	}
+
+	detail, err := ctrl.adminService.GetPostFullDetail(c.Request.Context(), postID)
+	if err != nil {
+		mapServiceError(c, err, "查询帖子完整详情失败")
+		return
+	}
+	response.RespondSuccess(c, detail, "帖子完整详情获取成功")
+}
+
+// ListAuditLogs 处理管理员查询操作审计日志的 HTTP 请求
+// @Summary      查询管理员操作审计日志 (管理员)
+// @Description  分页查询管理员对帖子的审核、删除、改标签、恢复及批量操作记录，按操作时间倒序。
+// @Tags         admin-posts (管理员-帖子)
+// @Produce      json
+// @Param        admin_user_id query string false "按操作人过滤"
+// @Param        action query string false "按操作类型过滤" Enums(audit_post, batch_audit_posts, update_official_tag, batch_official_tag, add_official_tag, remove_official_tag, delete_post, restore_post, reconcile_resync)
+// @Param        target_id query string false "按目标 ID（帖子 ID）过滤"
+// @Param        result query string false "按操作结果过滤" Enums(success, failure, partial)
+// @Param        start_time query string false "操作时间下限（包含，RFC3339）" Format(date-time)
+// @Param        end_time query string false "操作时间上限（不包含，RFC3339）" Format(date-time)
+// @Param        page query int true "页码（从 1 开始）" Format(int) minimum(1)
+// @Param        page_size query int true "每页数量" Format(int) minimum(1) maximum(100)
+// @Success      200 {object} vo.ListAdminAuditLogsResponseWrapper "审计日志获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的查询参数"
+// @Failure      500 {object} vo.BaseResponseWrapper "查询审计日志时发生内部服务器错误"
+// @Router       /api/v1/post/admin/audit-logs [get]
+func (ctrl *PostAdminController) ListAuditLogs(c *gin.Context) {
+	var req dto.ListAdminAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+
+	result, err := ctrl.auditLogService.ListAuditLogs(c.Request.Context(), &req)
+	if err != nil {
+		mapServiceError(c, err, "查询审计日志失败")
+		return
+	}
+	response.RespondSuccess(c, result, "审计日志获取成功")
+}
+
+// ResyncPosts 处理下游服务提交的对账补偿请求
+// @Summary      对账补偿：重新发送帖子同步事件 (管理员)
+// @Description  下游服务（搜索、推荐等）比对对账快照后，提交缺失或过期的帖子 ID。已审核通过的帖子重新发送审核通过事件，已删除、不存在或未审核通过的帖子发送删除事件。每次调用记录一条审计日志作为差异记录。
+// @Tags         admin-posts (管理员-帖子)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ReconcileResyncRequest true "对账补偿请求体 (最多 500 个帖子)"
+// @Success      200 {object} vo.ReconcileResyncResponseWrapper "对账补偿处理完成（需检查失败列表）"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载"
+// @Failure      401 {object} vo.BaseResponseWrapper "无法获取管理员ID"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "对账补偿过程中发生内部服务器错误"
+// @Failure      503 {object} vo.BaseResponseWrapper "未配置 Kafka，无法发送同步事件"
+// @Router       /api/v1/post/admin/reconcile/resync [post]
+func (ctrl *PostAdminController) ResyncPosts(c *gin.Context) {
+	var req dto.ReconcileResyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	adminID, ok := adminUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.adminService.ResyncPosts(c.Request.Context(), &req, adminID)
+	if err != nil {
+		mapServiceError(c, err, "对账补偿失败")
+		return
+	}
+	response.RespondSuccess(c, result, "对账补偿处理完成")
+}
+
 // RegisterRoutes 注册 PostAdminController 的路由
 func (ctrl *PostAdminController) RegisterRoutes(group *gin.RouterGroup) {
 	adminPosts := group.Group("/admin/posts") // 基础路径 /admin/posts
 	{
-		adminPosts.POST("/audit", ctrl.AuditPost)                   // POST /admin/posts/audit
-		adminPosts.GET("", ctrl.ListPostsByCondition)               // GET /admin/posts
-		adminPosts.PUT("/:id/official-tag", ctrl.UpdateOfficialTag) // PUT /admin/posts/{id}/official-tag
+		adminPosts.POST("/audit", ctrl.AuditPost)                                // POST /admin/posts/audit
+		adminPosts.POST("/batch-audit", ctrl.BatchAuditPosts)                    // POST /admin/posts/batch-audit
+		adminPosts.GET("/views/recent", ctrl.GetRecentViews)                     // GET /admin/posts/views/recent
+		adminPosts.GET("/stats", ctrl.GetPostStats)                              // GET /admin/posts/stats
+		adminPosts.GET("/reported", ctrl.ListReportedPosts)                      // GET /admin/posts/reported
+		adminPosts.GET("", ctrl.ListPostsByCondition)                            // GET /admin/posts
+		adminPosts.GET("/export", ctrl.ExportPostsByCondition)                   // GET /admin/posts/export
+		adminPosts.PUT("/:id/official-tag", ctrl.UpdateOfficialTag)              // PUT /admin/posts/{id}/official-tag
+		adminPosts.PUT("/batch-official-tag", ctrl.BatchUpdateOfficialTag)       // PUT /admin/posts/batch-official-tag
+		adminPosts.POST("/:id/official-tags/:tag", ctrl.AddOfficialTagFlag)      // POST /admin/posts/{id}/official-tags/{tag}
+		adminPosts.DELETE("/:id/official-tags/:tag", ctrl.RemoveOfficialTagFlag) // DELETE /admin/posts/{id}/official-tags/{tag}
 		adminPosts.DELETE("/:post_id", ctrl.DeletePostByAdmin)
+		adminPosts.POST("/:post_id/restore", ctrl.RestorePost)         // POST /admin/posts/{post_id}/restore
+		adminPosts.GET("/:post_id/audit-logs", ctrl.ListPostAuditLogs) // GET /admin/posts/{post_id}/audit-logs
+		adminPosts.GET("/:post_id/detail", ctrl.GetPostFullDetail)     // GET /admin/posts/{post_id}/detail
 	}
+	group.GET("/admin/audit-logs", ctrl.ListAuditLogs)      // GET /admin/audit-logs
+	group.POST("/admin/reconcile/resync", ctrl.ResyncPosts) // POST /admin/reconcile/resync
+
 }
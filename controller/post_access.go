@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// respondPostAccessDenied 在 err 为帖子详情访问鉴权失败时写入响应并返回 true。
+// - 需登录返回 401，需付费返回 402，其他无权限（如未关注作者）返回 403。
+// - data 中携带帖子的访问策略与本次未通过的策略，前端据此引导用户登录、付费或关注。
+func respondPostAccessDenied(c *gin.Context, err error) bool {
+	var deniedErr *service.PostAccessDeniedError
+	if !errors.As(err, &deniedErr) {
+		return false
+	}
+
+	status, code, msg := http.StatusForbidden, response.ErrCodeClientForbidden, "无权查看该帖子"
+	switch {
+	case errors.Is(deniedErr, myErrors.ErrAccessLoginRequired):
+		status, code, msg = http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "请登录后查看该帖子"
+	case errors.Is(deniedErr, myErrors.ErrAccessPaymentRequired):
+		status, msg = http.StatusPaymentRequired, "该帖子需要付费后查看"
+	case deniedErr.DeniedPolicy == constant.AccessPolicyFollower:
+		msg = "需要关注作者后才能查看该帖子"
+	}
+	c.JSON(status, response.APIResponse[*vo.PostAccessDeniedVO]{
+		Code:    code,
+		Message: msg,
+		Data:    deniedErr.VO(),
+	})
+	return true
+}
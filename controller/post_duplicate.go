@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// respondSimilarPostsFound 在 err 为发布前查重命中相似帖子时写入 409 响应并返回 true。
+// - data 中携带相似帖子，前端据此提示作者引用已有帖（quoted_post_id）或带 ignore_similar=true 重新提交。
+func respondSimilarPostsFound(c *gin.Context, err error) bool {
+	var similarErr *service.SimilarPostsFoundError
+	if !errors.As(err, &similarErr) {
+		return false
+	}
+	c.JSON(http.StatusConflict, response.APIResponse[*vo.SimilarPostsVO]{
+		Code:    response.ErrCodeClientInvalidInput,
+		Message: "平台上已有高度相似的帖子，可以引用已有帖子或确认后继续发布",
+		Data:    similarErr.VO(),
+	})
+	return true
+}
+
+// CheckSimilarPosts 处理发布前查重预览的 HTTP 请求
+// @Summary      发布前查重预览
+// @Description  按标题+内容相似度，在作者本人近期帖子与全站近期已审核通过帖子中查找高度相似的帖子，不创建帖子。作者ID从请求上下文中获取。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CheckSimilarPostsRequest true "待发布的标题与内容"
+// @Success      200 {object} vo.SimilarPostsResponseWrapper "查重完成，similar 为空表示没有相似帖子"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求参数，或正文清洗后为空"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "查重时发生内部服务器错误"
+// @Router       /api/v1/post/posts/similar-check [post]
+func (ctrl *PostController) CheckSimilarPosts(c *gin.Context) {
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return
+	}
+	var req dto.CheckSimilarPostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求参数: ", err)
+		return
+	}
+
+	result, err := ctrl.postService.CheckSimilarPosts(c.Request.Context(), userID, &req)
+	if err != nil {
+		mapServiceError(c, err, "发布前查重失败")
+		return
+	}
+	response.RespondSuccess(c, result, "查重完成")
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/gin-gonic/gin"
+
+	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/middleware"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// countingPostService 只实现 CheckSimilarPosts，记录 handler 是否调用了 service。
+type countingPostService struct {
+	service.PostService
+	calls int
+}
+
+func (s *countingPostService) CheckSimilarPosts(context.Context, string, *dto.CheckSimilarPostsRequest) (*vo.SimilarPostsVO, error) {
+	s.calls++
+	return &vo.SimilarPostsVO{}, nil
+}
+
+func TestCheckSimilarPostsRejectsOversizedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	svc := &countingPostService{}
+	ctrl := NewPostController(svc, nil, nil)
+
+	router := gin.New()
+	router.Use(middleware.RequestBodyLimitMiddleware(appConfig.BodyLimitConfig{DefaultMaxBytes: 64}, logger))
+	router.POST("/similar", func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), "user-1")
+		ctrl.CheckSimilarPosts(c)
+	})
+
+	body := `{"title":"t","content":"` + strings.Repeat("x", 256) + `"}`
+	cases := []struct {
+		name          string
+		contentLength int64
+	}{
+		{name: "声明的长度超限", contentLength: int64(len(body))},
+		{name: "未声明长度，读取时超限", contentLength: -1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/similar", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.ContentLength = tc.contentLength
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("status = %d, want 413 (body %s)", w.Code, w.Body.String())
+			}
+		})
+	}
+	if svc.calls != 0 {
+		t.Fatalf("CheckSimilarPosts service calls = %d, want none for oversized bodies", svc.calls)
+	}
+}
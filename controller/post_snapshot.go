@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// PostSnapshotController 定义帖子指标快照控制器的结构体
+type PostSnapshotController struct {
+	snapshotService service.PostSnapshotService
+}
+
+// NewPostSnapshotController 构造函数，注入服务层依赖
+func NewPostSnapshotController(snapshotService service.PostSnapshotService) *PostSnapshotController {
+	return &PostSnapshotController{
+		snapshotService: snapshotService,
+	}
+}
+
+// ListPostSnapshots 处理管理员查询单个帖子历史指标快照的 HTTP 请求
+// @Summary      查询帖子历史指标快照 (管理员)
+// @Description  返回帖子在时间范围内的浏览量、点赞数、审核状态与排行榜排名快照，按快照时间升序。小时级快照只覆盖当时活跃（最近一小时有浏览）的帖子，保留 7 天；日级快照覆盖前一天活跃过的帖子，保留 180 天。
+// @Tags         admin-reports (管理员-报表)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Param        granularity query string false "快照粒度，默认 hourly" Enums(hourly, daily)
+// @Param        start_time query string false "快照时间下限（包含，RFC3339），默认为 end_time 前 7 天" Format(date-time)
+// @Param        end_time query string false "快照时间上限（不包含，RFC3339），默认为当前时间" Format(date-time)
+// @Success      200 {object} vo.ListPostSnapshotsResponseWrapper "帖子历史快照获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID、查询参数或时间范围（最大跨度 90 天）"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/admin/posts/{post_id}/snapshots [get]
+func (ctrl *PostSnapshotController) ListPostSnapshots(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return
+	}
+	var req dto.ListPostSnapshotsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+
+	result, err := ctrl.snapshotService.ListPostSnapshots(c.Request.Context(), postID, &req)
+	if err != nil {
+		if errors.Is(err, myErrors.ErrInvalidTimeRange) {
+			response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "时间范围无效：起始时间必须早于结束时间，且跨度不超过 90 天")
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询帖子历史快照失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess(c, result, "帖子历史快照获取成功")
+}
+
+// RegisterRoutes 注册 PostSnapshotController 的路由
+func (ctrl *PostSnapshotController) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/admin/posts/:post_id/snapshots", ctrl.ListPostSnapshots) // GET /admin/posts/{post_id}/snapshots
+}
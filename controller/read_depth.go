@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// ReadDepthController 定义帖子阅读深度统计控制器的结构体
+type ReadDepthController struct {
+	readDepthService service.PostReadDepthService
+}
+
+// NewReadDepthController 构造函数，注入服务层依赖
+func NewReadDepthController(readDepthService service.PostReadDepthService) *ReadDepthController {
+	return &ReadDepthController{
+		readDepthService: readDepthService,
+	}
+}
+
+// ReportReadDepth 处理阅读深度上报的 HTTP 请求
+// @Summary      上报阅读深度
+// @Description  客户端在阅读过程中上报用户滚动到的内容百分比 (0-100)。同一次阅读（read_id 相同）只保留最大深度；同一次阅读两次上报至少间隔 3 秒。只统计登录用户，匿名上报被静默忽略。
+// @Tags         posts (帖子)
+// @Accept       json
+// @Produce      json
+// @Param        id path uint64 true "帖子 ID" Format(uint64)
+// @Param        request body dto.ReportReadDepthRequest true "阅读标识与深度"
+// @Success      200 {object} vo.BaseResponseWrapper "阅读深度已记录"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 或请求负载"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在或未审核通过"
+// @Failure      429 {object} vo.BaseResponseWrapper "上报过于频繁"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/posts/{id}/read-depth [post]
+func (ctrl *ReadDepthController) ReportReadDepth(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的帖子 ID 格式")
+		return
+	}
+	var req dto.ReportReadDepthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	userID := c.GetString(string(constants.UserIDKey))
+	if err := ctrl.readDepthService.ReportReadDepth(c.Request.Context(), postID, userID, req.ReadID, *req.Depth); err != nil {
+		switch {
+		case errors.Is(err, commonerrors.ErrRepoNotFound):
+			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子不存在")
+		case errors.Is(err, myErrors.ErrReadDepthTooFrequent):
+			response.RespondError(c, http.StatusTooManyRequests, response.ErrCodeClientRateLimitExceeded, "阅读深度上报过于频繁")
+		default:
+			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "记录阅读深度失败: "+err.Error())
+		}
+		return
+	}
+	response.RespondSuccess[any](c, nil, "阅读深度已记录")
+}
+
+// GetReadDepthStats 处理管理员查询帖子阅读深度统计的 HTTP 请求
+// @Summary      查询帖子阅读深度统计 (管理员)
+// @Description  返回帖子的阅读次数、平均阅读深度、读完率与按 10% 分段的深度分布（深度 100 单独成段）。统计只包含登录用户，同一次阅读按最大深度计一次。
+// @Tags         admin-reports (管理员-报表)
+// @Produce      json
+// @Param        post_id path uint64 true "帖子 ID" Format(uint64)
+// @Success      200 {object} vo.PostReadDepthResponseWrapper "阅读深度统计获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的帖子 ID 格式"
+// @Failure      404 {object} vo.BaseResponseWrapper "帖子不存在"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/admin/posts/{post_id}/read-depth [get]
+func (ctrl *ReadDepthController) GetReadDepthStats(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("post_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "URL 路径中的帖子 ID 格式无效")
+		return
+	}
+
+	stats, err := ctrl.readDepthService.GetReadDepthStats(c.Request.Context(), postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "帖子不存在")
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询阅读深度统计失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess(c, stats, "阅读深度统计获取成功")
+}
+
+// RegisterRoutes 注册 ReadDepthController 的路由
+// - 上报接口与 PostController 共用 /posts 前缀与 :id 参数名；统计接口属于管理员路由，与 /admin/posts 下同方法的路由一样使用 :post_id。
+func (ctrl *ReadDepthController) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/posts/:id/read-depth", ctrl.ReportReadDepth)             // POST /api/v1/post/posts/:id/read-depth
+	group.GET("/admin/posts/:post_id/read-depth", ctrl.GetReadDepthStats) // GET /api/v1/post/admin/posts/:post_id/read-depth
+}
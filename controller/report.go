@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// ReportController 定义帖子数据报表管理控制器的结构体
+type ReportController struct {
+	reportService service.ReportService
+}
+
+// NewReportController 构造函数，注入服务层依赖
+func NewReportController(reportService service.ReportService) *ReportController {
+	return &ReportController{
+		reportService: reportService,
+	}
+}
+
+// ListReports 处理管理员查询历史数据报表的 HTTP 请求
+// @Summary      列出历史数据报表 (管理员)
+// @Description  分页查询定时任务生成的帖子数据报表，可按统计周期过滤。
+// @Tags         admin-reports (管理员-报表)
+// @Accept       json
+// @Produce      json
+// @Param        period query string false "统计周期" Enums(daily, weekly)
+// @Param        page query int true "页码（从 1 开始）" Format(int) minimum(1)
+// @Param        page_size query int true "每页数量" Format(int) minimum(1) maximum(100)
+// @Success      200 {object} vo.ListDataReportsResponseWrapper "报表列表获取成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的查询参数"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/admin/reports [get]
+func (ctrl *ReportController) ListReports(c *gin.Context) {
+	var req dto.ListDataReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的查询参数: "+err.Error())
+		return
+	}
+
+	result, err := ctrl.reportService.ListReports(c.Request.Context(), req.Period, req.Page, req.PageSize)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询历史报表失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess(c, result, "报表列表获取成功")
+}
+
+// DownloadReport 处理管理员下载历史数据报表的 HTTP 请求
+// @Summary      下载数据报表 (管理员)
+// @Description  重定向到报表文件在 COS 中的下载地址。
+// @Tags         admin-reports (管理员-报表)
+// @Produce      json
+// @Param        report_id path uint64 true "报表 ID" Format(uint64)
+// @Success      302 "重定向到报表文件地址"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的报表 ID 格式"
+// @Failure      404 {object} vo.BaseResponseWrapper "报表不存在"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/admin/reports/{report_id}/download [get]
+func (ctrl *ReportController) DownloadReport(c *gin.Context) {
+	reportID, err := strconv.ParseUint(c.Param("report_id"), 10, 64)
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的报表 ID 格式")
+		return
+	}
+
+	fileURL, err := ctrl.reportService.GetReportDownloadURL(c.Request.Context(), reportID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			response.RespondError(c, http.StatusNotFound, response.ErrCodeClientResourceNotFound, "报表不存在")
+		} else {
+			response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "获取报表下载地址失败: "+err.Error())
+		}
+		return
+	}
+	c.Redirect(http.StatusFound, fileURL)
+}
+
+// RegisterRoutes 注册 ReportController 的路由
+func (ctrl *ReportController) RegisterRoutes(group *gin.RouterGroup) {
+	adminReports := group.Group("/admin/reports") // 基础路径 /admin/reports
+	{
+		adminReports.GET("", ctrl.ListReports)                        // GET /admin/reports
+		adminReports.GET("/:report_id/download", ctrl.DownloadReport) // GET /admin/reports/{report_id}/download
+	}
+}
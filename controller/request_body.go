@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/middleware"
+)
+
+// respondBodyParseError 统一处理请求体解析/绑定失败。
+// - 请求体超过 RequestBodyLimitMiddleware 设置的上限时返回 413，而不是透出底层的解析错误。
+// - 其他错误按参数无效返回 400，msgPrefix 作为提示前缀。
+func respondBodyParseError(c *gin.Context, msgPrefix string, err error) {
+	if limit, tooLarge := middleware.AsRequestBodyTooLarge(err); tooLarge {
+		middleware.RespondRequestBodyTooLarge(c, limit)
+		return
+	}
+	response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, msgPrefix+err.Error())
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// serviceErrorStatus 描述一类 service 错误对应的 HTTP 状态码、业务错误码与默认提示
+type serviceErrorStatus struct {
+	target     error
+	status     int
+	code       int
+	message    string
+	withDetail bool // 为 true 时在提示后附加 err.Error()，用于错误信息本身可直接展示给用户的场景
+}
+
+// serviceErrorStatuses 是 service 错误到 HTTP 响应的统一映射表，按顺序匹配第一个 errors.Is 成立的条目。
+var serviceErrorStatuses = []serviceErrorStatus{
+	// 资源不存在；缓存未命中本应由 service 回源，透传到这里说明回源后依然没有数据
+	{target: commonerrors.ErrRepoNotFound, status: http.StatusNotFound, code: response.ErrCodeClientResourceNotFound, message: "帖子不存在"},
+	{target: myErrors.ErrCacheMiss, status: http.StatusNotFound, code: response.ErrCodeClientResourceNotFound, message: "帖子不存在"},
+
+	// 未登录或无权操作
+	{target: commonerrors.ErrUserNotLoggedIn, status: http.StatusUnauthorized, code: response.ErrCodeClientUnauthorized, message: "用户未登录"},
+	{target: myErrors.ErrPermissionDenied, status: http.StatusForbidden, code: response.ErrCodeClientForbidden, message: "无权操作该帖子"},
+	{target: myErrors.ErrRepostNotAllowed, status: http.StatusForbidden, code: response.ErrCodeClientForbidden, message: "原帖声明禁止转载，不允许转发"},
+
+	// 参数类错误
+	{target: myErrors.ErrInvalidCopyright, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "帖子版权声明不合理", withDetail: true},
+	{target: myErrors.ErrInvalidPostImage, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "帖子图片不合法", withDetail: true},
+	{target: myErrors.ErrPostContentEmpty, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "帖子内容不能为空（不允许的 HTML 内容已被过滤）"},
+	{target: myErrors.ErrContentNotCompliant, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "帖子内容不符合目标地区的内容规范", withDetail: true},
+	{target: myErrors.ErrUnsupportedAccessPolicy, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "不支持的访问策略"},
+	{target: myErrors.ErrQuotedPostUnavailable, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "被转发的原帖不存在、已删除或未审核通过"},
+	{target: myErrors.ErrInvalidSearchKeyword, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "搜索关键词不能为空或只包含特殊字符"},
+	{target: myErrors.ErrInvalidCoverCandidates, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "候选封面设置不合法", withDetail: true},
+	{target: myErrors.ErrInvalidTimeRange, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "时间范围无效", withDetail: true},
+	{target: myErrors.ErrDeviceIDRequired, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "请求缺少设备标识 (X-Device-ID)"},
+	{target: myErrors.ErrInvalidOfficialTag, status: http.StatusBadRequest, code: response.ErrCodeClientInvalidInput, message: "官方标签不合法", withDetail: true},
+
+	// 与资源当前状态冲突
+	{target: myErrors.ErrPostNotDraft, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "帖子不是草稿或已发布"},
+	{target: myErrors.ErrPostIsDraft, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "帖子仍是草稿，作者发布后才能审核"},
+	{target: myErrors.ErrPostNotDeleted, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "帖子未被删除，无需恢复"},
+	{target: myErrors.ErrPostAlreadyReported, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "已经举报过该帖子"},
+	{target: myErrors.ErrPostNotRejected, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "只有审核被拒的帖子可以申诉"},
+	{target: myErrors.ErrPostNotEditable, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "只有审核通过或被拒的帖子可以编辑"},
+	{target: myErrors.ErrAppealLimitExceeded, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "该帖子的申诉次数已用完"},
+	{target: myErrors.ErrIdempotentRequestInProgress, status: http.StatusConflict, code: response.ErrCodeClientInvalidInput, message: "相同的提交正在处理中，请稍后重试"},
+
+	// 频率限制与依赖不可用
+	{target: myErrors.ErrReadDepthTooFrequent, status: http.StatusTooManyRequests, code: response.ErrCodeClientRateLimitExceeded, message: "阅读深度上报过于频繁"},
+	{target: commonerrors.ErrServiceBusy, status: http.StatusServiceUnavailable, code: response.ErrCodeServerInternal, message: "服务繁忙，请稍后再试"},
+	{target: myErrors.ErrEventPublishingUnavailable, status: http.StatusServiceUnavailable, code: response.ErrCodeServerInternal, message: "未配置 Kafka，无法发送同步事件"},
+}
+
+// mapServiceError 将 service 返回的错误统一映射为 HTTP 响应。
+// - 帖子详情访问鉴权失败交给 respondPostAccessDenied，响应中携带需要引导的操作。
+// - 发布前查重命中相似帖子交给 respondSimilarPostsFound，响应中携带相似帖子。
+// - 其余错误按 serviceErrorStatuses 用 errors.Is 匹配，未匹配的错误返回 500，提示为 "action: err"。
+// - 个别接口需要更具体的提示时，在调用前自行处理对应的错误即可。
+func mapServiceError(c *gin.Context, err error, action string) {
+	if respondPostAccessDenied(c, err) || respondSimilarPostsFound(c, err) {
+		return
+	}
+	for _, mapping := range serviceErrorStatuses {
+		if !errors.Is(err, mapping.target) {
+			continue
+		}
+		message := mapping.message
+		if mapping.withDetail {
+			message += ": " + err.Error()
+		}
+		response.RespondError(c, mapping.status, mapping.code, message)
+		return
+	}
+	response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, action+": "+err.Error())
+}
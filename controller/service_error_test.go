@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// serveMappedError 以 mapServiceError 响应 err，返回状态码与解析后的响应体。
+func serveMappedError(t *testing.T, err error) (int, response.APIResponse[json.RawMessage]) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	mapServiceError(c, err, "测试操作")
+
+	var body response.APIResponse[json.RawMessage]
+	if decodeErr := json.Unmarshal(w.Body.Bytes(), &body); decodeErr != nil {
+		t.Fatalf("decode response %q: %v", w.Body.String(), decodeErr)
+	}
+	return w.Code, body
+}
+
+func TestMapServiceErrorStatus(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   int
+	}{
+		{commonerrors.ErrRepoNotFound, http.StatusNotFound, response.ErrCodeClientResourceNotFound},
+		{myErrors.ErrCacheMiss, http.StatusNotFound, response.ErrCodeClientResourceNotFound},
+		{commonerrors.ErrUserNotLoggedIn, http.StatusUnauthorized, response.ErrCodeClientUnauthorized},
+		{myErrors.ErrPermissionDenied, http.StatusForbidden, response.ErrCodeClientForbidden},
+		{myErrors.ErrRepostNotAllowed, http.StatusForbidden, response.ErrCodeClientForbidden},
+		{myErrors.ErrInvalidCopyright, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrInvalidPostImage, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostContentEmpty, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrContentNotCompliant, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrUnsupportedAccessPolicy, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrQuotedPostUnavailable, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrInvalidSearchKeyword, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrInvalidCoverCandidates, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrInvalidTimeRange, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrDeviceIDRequired, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrInvalidOfficialTag, http.StatusBadRequest, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostNotDraft, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostIsDraft, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostNotDeleted, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostAlreadyReported, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostNotRejected, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrPostNotEditable, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrAppealLimitExceeded, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrIdempotentRequestInProgress, http.StatusConflict, response.ErrCodeClientInvalidInput},
+		{myErrors.ErrReadDepthTooFrequent, http.StatusTooManyRequests, response.ErrCodeClientRateLimitExceeded},
+		{commonerrors.ErrServiceBusy, http.StatusServiceUnavailable, response.ErrCodeServerInternal},
+		{myErrors.ErrEventPublishingUnavailable, http.StatusServiceUnavailable, response.ErrCodeServerInternal},
+	}
+
+	covered := make(map[error]bool, len(cases))
+	for _, tc := range cases {
+		covered[tc.err] = true
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			// service 层通常会包装错误，映射必须按 errors.Is 而不是按值匹配
+			status, body := serveMappedError(t, fmt.Errorf("service: %w", tc.err))
+			if status != tc.status || body.Code != tc.code {
+				t.Fatalf("status/code = %d/%d, want %d/%d (message %q)", status, body.Code, tc.status, tc.code, body.Message)
+			}
+		})
+	}
+	// 新增映射时必须同步补充上面的用例
+	for _, mapping := range serviceErrorStatuses {
+		if !covered[mapping.target] {
+			t.Errorf("serviceErrorStatuses entry for %q has no test case", mapping.target)
+		}
+	}
+}
+
+func TestMapServiceErrorWithDetail(t *testing.T) {
+	_, body := serveMappedError(t, fmt.Errorf("%w: 图片数量超过 9 张", myErrors.ErrInvalidPostImage))
+	if !strings.HasPrefix(body.Message, "帖子图片不合法: ") || !strings.Contains(body.Message, "图片数量超过 9 张") {
+		t.Fatalf("message = %q, want the default message followed by the error detail", body.Message)
+	}
+
+	_, body = serveMappedError(t, fmt.Errorf("secret: %w", myErrors.ErrPermissionDenied))
+	if strings.Contains(body.Message, "secret") {
+		t.Fatalf("message = %q, want the error detail hidden", body.Message)
+	}
+}
+
+func TestMapServiceErrorUnknown(t *testing.T) {
+	status, body := serveMappedError(t, errors.New("db down"))
+	if status != http.StatusInternalServerError || body.Code != response.ErrCodeServerInternal || body.Message != "测试操作: db down" {
+		t.Fatalf("status/code/message = %d/%d/%q, want 500 with the action prefix", status, body.Code, body.Message)
+	}
+}
+
+func TestMapServiceErrorAccessDenied(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    *service.PostAccessDeniedError
+		status int
+	}{
+		{name: "需登录", err: &service.PostAccessDeniedError{PostID: 1, DeniedPolicy: constant.AccessPolicyLogin, Err: myErrors.ErrAccessLoginRequired}, status: http.StatusUnauthorized},
+		{name: "需付费", err: &service.PostAccessDeniedError{PostID: 1, DeniedPolicy: constant.AccessPolicyPaid, Err: myErrors.ErrAccessPaymentRequired}, status: http.StatusPaymentRequired},
+		{name: "需关注", err: &service.PostAccessDeniedError{PostID: 1, DeniedPolicy: constant.AccessPolicyFollower, Err: myErrors.ErrAccessForbidden}, status: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := serveMappedError(t, fmt.Errorf("获取帖子详情: %w", tc.err))
+			if status != tc.status || len(body.Data) == 0 {
+				t.Fatalf("status = %d, data = %s, want %d with the access policy in data", status, body.Data, tc.status)
+			}
+		})
+	}
+}
+
+func TestMapServiceErrorSimilarPostsFound(t *testing.T) {
+	err := &service.SimilarPostsFoundError{Similar: []*vo.SimilarPostVO{{PostID: 42}}}
+	status, body := serveMappedError(t, fmt.Errorf("创建帖子: %w", err))
+	if status != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", status)
+	}
+	var data vo.SimilarPostsVO
+	if decodeErr := json.Unmarshal(body.Data, &data); decodeErr != nil || len(data.Similar) != 1 || data.Similar[0].PostID != 42 {
+		t.Fatalf("data = %s, %v, want the similar post", body.Data, decodeErr)
+	}
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// TagSubscriptionController 定义用户标签订阅控制器的结构体
+type TagSubscriptionController struct {
+	tagSubService service.TagSubscriptionService
+}
+
+// NewTagSubscriptionController 构造函数，注入服务层依赖
+func NewTagSubscriptionController(tagSubService service.TagSubscriptionService) *TagSubscriptionController {
+	return &TagSubscriptionController{
+		tagSubService: tagSubService,
+	}
+}
+
+// subscriberIDFromContext 从上下文中获取当前用户 ID，缺失时直接写入 401 响应并返回 false。
+func subscriberIDFromContext(c *gin.Context) (string, bool) {
+	userID := c.GetString(string(constants.UserIDKey))
+	if userID == "" {
+		response.RespondError(c, http.StatusUnauthorized, response.ErrCodeClientUnauthorized, "无法获取有效的用户 ID")
+		return "", false
+	}
+	return userID, true
+}
+
+// Subscribe 处理用户订阅标签的 HTTP 请求
+// @Summary      订阅标签
+// @Description  订阅指定的官方标签，帖子审核通过且带有该标签时会收到新帖推送。重复订阅是幂等的。
+// @Tags         tag-subscriptions (标签订阅)
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.SubscribeTagRequest true "订阅请求"
+// @Success      200 {object} vo.BaseResponseWrapper "订阅成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的请求负载或标签值"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      413 {object} vo.BaseResponseWrapper "请求体超过大小限制"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/tag-subscriptions [post]
+func (ctrl *TagSubscriptionController) Subscribe(c *gin.Context) {
+	userID, ok := subscriberIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SubscribeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBodyParseError(c, "无效的请求负载: ", err)
+		return
+	}
+
+	if err := ctrl.tagSubService.Subscribe(c.Request.Context(), userID, req.Tag); err != nil {
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "订阅标签失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess[any](c, nil, "订阅成功")
+}
+
+// Unsubscribe 处理用户取消订阅标签的 HTTP 请求
+// @Summary      取消订阅标签
+// @Description  取消对指定官方标签的订阅，未订阅时同样返回成功。
+// @Tags         tag-subscriptions (标签订阅)
+// @Produce      json
+// @Param        tag path int true "官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)" minimum(1) maximum(3)
+// @Success      200 {object} vo.BaseResponseWrapper "取消订阅成功"
+// @Failure      400 {object} vo.BaseResponseWrapper "无效的标签值"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/tag-subscriptions/{tag} [delete]
+func (ctrl *TagSubscriptionController) Unsubscribe(c *gin.Context) {
+	tagValue, err := strconv.Atoi(c.Param("tag"))
+	if err != nil || tagValue < int(enums.OfficialTagCertified) || tagValue > int(enums.OfficialTagRapid) {
+		response.RespondError(c, http.StatusBadRequest, response.ErrCodeClientInvalidInput, "无效的标签值")
+		return
+	}
+
+	userID, ok := subscriberIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.tagSubService.Unsubscribe(c.Request.Context(), userID, enums.OfficialTag(tagValue)); err != nil {
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "取消订阅标签失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess[any](c, nil, "取消订阅成功")
+}
+
+// ListMySubscriptions 处理用户查询自己订阅的标签的 HTTP 请求
+// @Summary      我的标签订阅
+// @Description  返回当前用户已订阅的官方标签列表。
+// @Tags         tag-subscriptions (标签订阅)
+// @Produce      json
+// @Success      200 {object} vo.TagSubscriptionsResponseWrapper "订阅列表获取成功"
+// @Failure      401 {object} vo.BaseResponseWrapper "用户未登录"
+// @Failure      500 {object} vo.BaseResponseWrapper "服务器内部错误"
+// @Router       /api/v1/post/tag-subscriptions/mine [get]
+func (ctrl *TagSubscriptionController) ListMySubscriptions(c *gin.Context) {
+	userID, ok := subscriberIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.tagSubService.ListSubscriptions(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, response.ErrCodeServerInternal, "查询标签订阅失败: "+err.Error())
+		return
+	}
+	response.RespondSuccess(c, result, "订阅列表获取成功")
+}
+
+// RegisterRoutes 注册 TagSubscriptionController 的路由
+func (ctrl *TagSubscriptionController) RegisterRoutes(group *gin.RouterGroup) {
+	subs := group.Group("/tag-subscriptions")
+	{
+		subs.POST("", ctrl.Subscribe)               // POST /api/v1/post/tag-subscriptions
+		subs.GET("/mine", ctrl.ListMySubscriptions) // GET /api/v1/post/tag-subscriptions/mine
+		subs.DELETE("/:tag", ctrl.Unsubscribe)      // DELETE /api/v1/post/tag-subscriptions/:tag
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/gin-gonic/gin"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// 网关透传的其他请求头；用户画像请求头 (constant.HeaderUser*) 只在可信网关的请求中保留，见 middleware.TrustedGatewayMiddleware。
+const (
+	headerUserScript = "X-User-Script" // 用户设置中的中文字形偏好 (hans / hant / original)，优先于 Accept-Language
+	headerDeviceID   = "X-Device-ID"   // 客户端设备标识（设备指纹），用于匿名浏览去重
+)
+
+// viewerFromRequest 从请求头中解析当前用户的画像属性，并附带上下文中的登录用户ID。
+// - 请求头缺失或格式不正确时对应字段取零值，不视为错误（按匿名用户处理）。
+// - 画像请求头只采信可信网关注入的值：未经 TrustedGatewayMiddleware 确认来自网关的请求一律按匿名画像处理。
+func viewerFromRequest(c *gin.Context) *dto.ViewerAttributes {
+	viewer := &dto.ViewerAttributes{
+		UserID:   c.GetString(string(constants.UserIDKey)),
+		ClientID: clientIDFromRequest(c),
+	}
+	if !c.GetBool(constant.GatewayTrustedContextKey) {
+		return viewer
+	}
+	viewer.Region = strings.TrimSpace(c.GetHeader(constant.HeaderUserRegion))
+	if levelStr := c.GetHeader(constant.HeaderUserLevel); levelStr != "" {
+		if level, err := strconv.Atoi(strings.TrimSpace(levelStr)); err == nil && level > 0 {
+			viewer.Level = level
+		}
+	}
+	if tagsStr := c.GetHeader(constant.HeaderUserTags); tagsStr != "" {
+		for _, tag := range strings.Split(tagsStr, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				viewer.Tags = append(viewer.Tags, tag)
+			}
+		}
+	}
+	return viewer
+}
+
+// clientIDFromRequest 返回匿名访客的客户端标识：优先使用网关透传的设备标识，缺失或超长时退回客户端 IP。
+func clientIDFromRequest(c *gin.Context) string {
+	if deviceID := strings.TrimSpace(c.GetHeader(headerDeviceID)); deviceID != "" && len(deviceID) <= constant.AnonymousClientIDMaxLen {
+		return constant.AnonymousDeviceClientPrefix + deviceID
+	}
+	if ip := c.ClientIP(); ip != "" {
+		return "ip:" + ip
+	}
+	return ""
+}
+
+// chineseScriptFromRequest 解析当前用户偏好的中文字形，用于返回帖子时的繁简转换。
+// - 优先使用网关注入的用户设置 (X-User-Script)，未设置时按 Accept-Language 中权重最高的中文语言标签判断。
+// - zh-TW / zh-HK / zh-MO / zh-Hant 视为繁体，zh-CN / zh-SG / zh-Hans 视为简体，其余（包括不带地区的 zh）不转换。
+func chineseScriptFromRequest(c *gin.Context) service.ChineseScript {
+	switch strings.ToLower(strings.TrimSpace(c.GetHeader(headerUserScript))) {
+	case "hans":
+		return service.ChineseScriptSimplified
+	case "hant":
+		return service.ChineseScriptTraditional
+	case "original":
+		return service.ChineseScriptOriginal
+	}
+
+	script, bestQ := service.ChineseScriptOriginal, 0.0
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		candidate, ok := chineseScriptOfLanguageTag(strings.ToLower(strings.TrimSpace(tag)))
+		if ok && q > bestQ {
+			script, bestQ = candidate, q
+		}
+	}
+	return script
+}
+
+// chineseScriptOfLanguageTag 返回中文语言标签对应的字形；非中文标签返回 false。
+func chineseScriptOfLanguageTag(tag string) (service.ChineseScript, bool) {
+	if tag != "zh" && !strings.HasPrefix(tag, "zh-") {
+		return service.ChineseScriptOriginal, false
+	}
+	switch {
+	case strings.Contains(tag, "hant"), strings.HasSuffix(tag, "-tw"), strings.HasSuffix(tag, "-hk"), strings.HasSuffix(tag, "-mo"):
+		return service.ChineseScriptTraditional, true
+	case strings.Contains(tag, "hans"), strings.HasSuffix(tag, "-cn"), strings.HasSuffix(tag, "-sg"):
+		return service.ChineseScriptSimplified, true
+	}
+	return service.ChineseScriptOriginal, true
+}
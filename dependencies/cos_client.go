@@ -8,7 +8,7 @@ import (
 	"net/url"
 	// "path/filepath" // 移除未使用的导入
 	"strings"
-	// "time" // 移除未使用的导入
+	"time"
 
 	"github.com/Xushengqwer/go-common/core"
 	"github.com/Xushengqwer/post_service/config" // 确保这里指向 post_service 的配置包
@@ -25,6 +25,19 @@ type COSClientInterface interface {
 	UploadFile(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (string, error)
 	// DeleteObject 从COS删除一个对象
 	DeleteObject(ctx context.Context, objectKey string) error
+	// GeneratePresignedPutURL 生成指定对象键的预签名上传 URL，客户端在 expiry 内可凭该 URL 直接 PUT 上传，无需经过本服务中转
+	// 调用方需要负责生成合适的 objectKey，URL 只对该对象键有效
+	GeneratePresignedPutURL(ctx context.Context, objectKey string, expiry time.Duration) (string, error)
+	// HeadObject 查询对象的元数据，对象不存在时返回 (nil, nil)
+	HeadObject(ctx context.Context, objectKey string) (*ObjectInfo, error)
+	// CopyObject 在同一存储桶内将 srcKey 复制到 dstKey，并返回目标对象公开可访问的 URL
+	CopyObject(ctx context.Context, srcKey, dstKey string) (string, error)
+}
+
+// ObjectInfo 是 COS 对象的元数据
+type ObjectInfo struct {
+	Size        int64  // 对象大小（字节）
+	ContentType string // 上传时声明的 Content-Type
 }
 
 type cosClient struct {
@@ -163,3 +176,47 @@ func (c *cosClient) DeleteObject(ctx context.Context, objectKey string) error {
 	c.logger.Info("COS 对象删除成功", zap.String("对象键", objectKey))
 	return nil
 }
+
+// CopyObject 在同一存储桶内复制对象
+// - 复制在 COS 服务端完成，对象内容不经过本服务；目标对象沿用源对象的元数据（包括 Content-Type）
+func (c *cosClient) CopyObject(ctx context.Context, srcKey, dstKey string) (string, error) {
+	sourceURL := c.sdkBucketURL.Host + "/" + strings.TrimPrefix(srcKey, "/")
+	_, resp, err := c.client.Object.Copy(ctx, dstKey, sourceURL, nil)
+	if err != nil {
+		c.logger.Error("COS 对象复制 API 调用失败", zap.String("源对象键", srcKey), zap.String("目标对象键", dstKey), zap.Error(err))
+		return "", fmt.Errorf("复制 COS 对象 '%s' 到 '%s' 失败: %w", srcKey, dstKey, err)
+	}
+	resp.Body.Close()
+
+	publicURL := c.buildPublicObjectURL(dstKey)
+	c.logger.Info("COS 对象复制成功", zap.String("源对象键", srcKey), zap.String("目标对象键", dstKey))
+	return publicURL, nil
+}
+
+// GeneratePresignedPutURL 生成预签名上传 URL
+// - 签名使用服务端的 SecretID/SecretKey 在本地计算，不发起网络请求
+func (c *cosClient) GeneratePresignedPutURL(ctx context.Context, objectKey string, expiry time.Duration) (string, error) {
+	presignedURL, err := c.client.Object.GetPresignedURL(ctx, http.MethodPut, objectKey, c.cfg.SecretID, c.cfg.SecretKey, expiry, nil)
+	if err != nil {
+		c.logger.Error("生成 COS 预签名上传 URL 失败", zap.String("对象键", objectKey), zap.Error(err))
+		return "", fmt.Errorf("生成对象 '%s' 的预签名上传 URL 失败: %w", objectKey, err)
+	}
+	return presignedURL.String(), nil
+}
+
+// HeadObject 查询对象的元数据
+func (c *cosClient) HeadObject(ctx context.Context, objectKey string) (*ObjectInfo, error) {
+	resp, err := c.client.Object.Head(ctx, objectKey, nil)
+	if err != nil {
+		if cos.IsNotFoundError(err) {
+			return nil, nil
+		}
+		c.logger.Error("COS 对象元数据查询失败", zap.String("对象键", objectKey), zap.Error(err))
+		return nil, fmt.Errorf("查询 COS 对象 '%s' 元数据失败: %w", objectKey, err)
+	}
+	defer resp.Body.Close()
+	return &ObjectInfo{
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}, nil
+}
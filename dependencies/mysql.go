@@ -13,6 +13,7 @@ import (
 	"gorm.io/gorm"
 
 	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/entities"
 )
 
@@ -142,12 +143,86 @@ func InitMySQL(cfg *appConfig.PostConfig, logger *core.ZapLogger) (*gorm.DB, err
 		&entities.Post{},
 		&entities.PostDetail{},
 		&entities.PostDetailImage{},
+		&entities.PostTargeting{},
+		&entities.PostFAQ{},
+		&entities.PostDataReport{},
+		&entities.AdminAuditLog{},
+		&entities.PostAuditLog{},
+		&entities.TagSubscription{},
+		&entities.OutboxEvent{},
+		&entities.AuthorBadge{},
+		&entities.PostMetricSnapshot{},
+		&entities.PostReport{},
+		&entities.PostContentSegment{},
 		// ... 其他需要迁移的实体 ...
 	)
 	if migrateErr != nil {
 		logger.Error("数据库自动迁移失败", zap.Error(migrateErr))
 		return nil, fmt.Errorf("数据库自动迁移失败: %w", migrateErr)
 	}
+	// 官方标签由单值列 official_tag 改为位掩码列 official_tags：把旧列中的标签并入位掩码后清零旧列。
+	// 只处理旧列仍非 0 的行，重复执行是幂等的；滚动发布期间旧版本实例写入的旧列也会在下次启动时并入。
+	if db.Migrator().HasColumn(&entities.Post{}, constant.PostLegacyOfficialTagColumn) {
+		result := db.Exec("UPDATE posts SET official_tags = official_tags | (1 << (" + constant.PostLegacyOfficialTagColumn + " - 1)), " +
+			constant.PostLegacyOfficialTagColumn + " = 0 WHERE " + constant.PostLegacyOfficialTagColumn + " > 0")
+		if result.Error != nil {
+			logger.Error("迁移帖子官方标签到位掩码失败", zap.Error(result.Error))
+			return nil, fmt.Errorf("迁移帖子官方标签到位掩码失败: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			logger.Info("已将帖子的单值官方标签迁移为位掩码", zap.Int64("rows", result.RowsAffected))
+		}
+	}
+	// posts.updated_at 来自 go-common 的 BaseModel，无法通过结构体标签声明索引；增量对账按更新时间范围扫描，需要单独创建
+	if !db.Migrator().HasIndex(&entities.Post{}, constant.PostUpdatedAtIndexName) {
+		if err := db.Exec("CREATE INDEX " + constant.PostUpdatedAtIndexName + " ON posts (updated_at, id)").Error; err != nil {
+			logger.Error("创建帖子更新时间索引失败", zap.Error(err), zap.String("index", constant.PostUpdatedAtIndexName))
+			return nil, fmt.Errorf("创建帖子更新时间索引失败: %w", err)
+		}
+	}
+	// 按浏览量倒序的公开列表在 status 过滤后按 (view_count, id) 排序与翻页，联合索引避免全表排序
+	if !db.Migrator().HasIndex(&entities.Post{}, constant.PostStatusViewCountIndexName) {
+		if err := db.Exec("CREATE INDEX " + constant.PostStatusViewCountIndexName + " ON posts (status, view_count, id)").Error; err != nil {
+			logger.Error("创建帖子浏览量排序索引失败", zap.Error(err), zap.String("index", constant.PostStatusViewCountIndexName))
+			return nil, fmt.Errorf("创建帖子浏览量排序索引失败: %w", err)
+		}
+	}
+	// 管理员帖子列表支持按这些字段组合排序，(字段, id) 联合索引支撑首个排序字段，避免全表排序
+	sortIndexes := []struct {
+		name    string
+		columns string
+	}{
+		{constant.PostCreatedAtIndexName, "(created_at, id)"},
+		{constant.PostViewCountIndexName, "(view_count, id)"},
+		{constant.PostLikeCountIndexName, "(like_count, id)"},
+	}
+	for _, idx := range sortIndexes {
+		if db.Migrator().HasIndex(&entities.Post{}, idx.name) {
+			continue
+		}
+		if err := db.Exec("CREATE INDEX " + idx.name + " ON posts " + idx.columns).Error; err != nil {
+			logger.Error("创建帖子排序索引失败", zap.Error(err), zap.String("index", idx.name))
+			return nil, fmt.Errorf("创建帖子排序索引 %s 失败: %w", idx.name, err)
+		}
+	}
+	// 全文搜索依赖 posts(title) 与 post_details(content) 的 FULLTEXT 索引；使用 ngram 分词器，中文无需空格分词也能命中
+	fullTextIndexes := []struct {
+		model interface{}
+		name  string
+		ddl   string
+	}{
+		{&entities.Post{}, constant.PostTitleFullTextIndexName, "ON posts (title)"},
+		{&entities.PostDetail{}, constant.PostContentFullTextIndexName, "ON post_details (content)"},
+	}
+	for _, idx := range fullTextIndexes {
+		if db.Migrator().HasIndex(idx.model, idx.name) {
+			continue
+		}
+		if err := db.Exec("CREATE FULLTEXT INDEX " + idx.name + " " + idx.ddl + " WITH PARSER ngram").Error; err != nil {
+			logger.Error("创建全文索引失败", zap.Error(err), zap.String("index", idx.name))
+			return nil, fmt.Errorf("创建全文索引 %s 失败: %w", idx.name, err)
+		}
+	}
 	logger.Info("数据库自动迁移完成")
 
 	logger.Info("成功初始化 MySQL 连接 (包括读写分离和自动迁移)")
@@ -0,0 +1,86 @@
+package dependencies
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// unlockScript 只有锁仍由当前持有者（value 等于 token）持有时才删除，避免误删其他实例在锁过期后重新获取的锁。
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLock 是基于 Redis SET NX PX 的简单分布式互斥锁，用于多副本部署时保证同一时刻只有一个实例执行某项操作。
+//   - 锁没有自动续期，TTL 必须大于受保护操作的最长执行时间，否则操作未结束锁就可能过期被其他实例获取。
+//   - 每次加锁生成随机 token 作为锁的值，解锁时校验 token，保证只释放自己持有的锁。
+//   - 同一个 RedisLock 可以被多次、并发地 TryLock，各次加锁通过返回的 token 区分。
+type RedisLock struct {
+	client redis.Cmdable
+	key    string
+	ttl    time.Duration
+}
+
+// NewRedisLock 创建一个分布式锁。
+// - key 为锁在 Redis 中的 Key，ttl 为锁的自动过期时间。
+func NewRedisLock(client redis.Cmdable, key string, ttl time.Duration) *RedisLock {
+	return &RedisLock{
+		client: client,
+		key:    key,
+		ttl:    ttl,
+	}
+}
+
+// Key 返回锁的 Redis Key。
+func (l *RedisLock) Key() string {
+	return l.key
+}
+
+// TTL 返回锁的自动过期时间。
+func (l *RedisLock) TTL() time.Duration {
+	return l.ttl
+}
+
+// TryLock 尝试获取锁，不等待。
+// - 获取成功返回本次加锁的 token 与 true，调用方需要用该 token 调用 Unlock。
+// - 锁已被其他持有者占用时返回 "", false, nil。
+func (l *RedisLock) TryLock(ctx context.Context) (string, bool, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return "", false, err
+	}
+	ok, err := l.client.SetNX(ctx, l.key, token, l.ttl).Result()
+	if err != nil {
+		return "", false, fmt.Errorf("获取分布式锁 %s 失败: %w", l.key, err)
+	}
+	if !ok {
+		return "", false, nil
+	}
+	return token, true, nil
+}
+
+// Unlock 释放由 token 对应的那次 TryLock 获取的锁。
+// - 锁已过期或已被其他持有者获取时不做任何操作，返回 false。
+func (l *RedisLock) Unlock(ctx context.Context, token string) (bool, error) {
+	deleted, err := unlockScript.Run(ctx, l.client, []string{l.key}, token).Int()
+	if err != nil {
+		return false, fmt.Errorf("释放分布式锁 %s 失败: %w", l.key, err)
+	}
+	return deleted == 1, nil
+}
+
+// newLockToken 生成 16 字节随机数的十六进制字符串，作为锁持有者的唯一标识。
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成分布式锁 token 失败: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
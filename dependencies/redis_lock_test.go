@@ -0,0 +1,100 @@
+package dependencies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+func TestRedisLockAcquireAndContention(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+	lock := NewRedisLock(client, "task_lock:test", time.Minute)
+
+	token, ok, err := lock.TryLock(ctx)
+	if err != nil || !ok || token == "" {
+		t.Fatalf("first TryLock = %q, %v, %v, want a token", token, ok, err)
+	}
+	if ttl := mr.TTL("task_lock:test"); ttl != time.Minute {
+		t.Fatalf("lock TTL = %v, want %v", ttl, time.Minute)
+	}
+
+	// 其他实例（同一 Key 的另一把锁）在锁释放前无法获取
+	other := NewRedisLock(client, "task_lock:test", time.Minute)
+	if token2, ok, err := other.TryLock(ctx); err != nil || ok || token2 != "" {
+		t.Fatalf("contended TryLock = %q, %v, %v, want not acquired", token2, ok, err)
+	}
+
+	released, err := lock.Unlock(ctx, token)
+	if err != nil || !released {
+		t.Fatalf("Unlock = %v, %v, want released", released, err)
+	}
+	if _, ok, err := other.TryLock(ctx); err != nil || !ok {
+		t.Fatalf("TryLock after unlock = %v, %v, want acquired", ok, err)
+	}
+}
+
+func TestRedisLockUnlockChecksToken(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+	lock := NewRedisLock(client, "task_lock:test", time.Minute)
+
+	token, _, err := lock.TryLock(ctx)
+	if err != nil {
+		t.Fatalf("TryLock: %v", err)
+	}
+	if released, err := lock.Unlock(ctx, "someone-else"); err != nil || released {
+		t.Fatalf("Unlock with a foreign token = %v, %v, want not released", released, err)
+	}
+	if got, _ := mr.Get("task_lock:test"); got != token {
+		t.Fatalf("lock value = %q, want it still held by %q", got, token)
+	}
+}
+
+func TestRedisLockExpiry(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+	lock := NewRedisLock(client, "task_lock:test", time.Second)
+
+	staleToken, _, err := lock.TryLock(ctx)
+	if err != nil {
+		t.Fatalf("TryLock: %v", err)
+	}
+	mr.FastForward(2 * time.Second)
+
+	// 锁过期后其他持有者可以获取，原持有者的解锁不能删除新持有者的锁
+	newToken, ok, err := lock.TryLock(ctx)
+	if err != nil || !ok {
+		t.Fatalf("TryLock after expiry = %v, %v, want acquired", ok, err)
+	}
+	if released, err := lock.Unlock(ctx, staleToken); err != nil || released {
+		t.Fatalf("Unlock with the expired token = %v, %v, want not released", released, err)
+	}
+	if got, _ := mr.Get("task_lock:test"); got != newToken {
+		t.Fatalf("lock value = %q, want the new holder %q", got, newToken)
+	}
+}
+
+func TestRedisLockReportsRedisErrors(t *testing.T) {
+	mr, client := newTestRedis(t)
+	lock := NewRedisLock(client, "task_lock:test", time.Minute)
+	mr.Close()
+
+	if _, ok, err := lock.TryLock(context.Background()); err == nil || ok {
+		t.Fatalf("TryLock with Redis down = %v, %v, want an error", ok, err)
+	}
+	if _, err := lock.Unlock(context.Background(), "token"); err == nil {
+		t.Fatal("Unlock with Redis down returned no error")
+	}
+}
@@ -20,6 +20,113 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/post/admin/audit-logs": {
+            "get": {
+                "description": "分页查询管理员对帖子的审核、删除、改标签、恢复及批量操作记录，按操作时间倒序。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-posts (管理员-帖子)"
+                ],
+                "summary": "查询管理员操作审计日志 (管理员)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "按操作人过滤",
+                        "name": "admin_user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "audit_post",
+                            "batch_audit_posts",
+                            "update_official_tag",
+                            "batch_official_tag",
+                            "add_official_tag",
+                            "remove_official_tag",
+                            "delete_post",
+                            "restore_post",
+                            "reconcile_resync"
+                        ],
+                        "type": "string",
+                        "description": "按操作类型过滤",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按目标 ID（帖子 ID）过滤",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "success",
+                            "failure",
+                            "partial"
+                        ],
+                        "type": "string",
+                        "description": "按操作结果过滤",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "操作时间下限（包含，RFC3339）",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "操作时间上限（不包含，RFC3339）",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "页码（从 1 开始）",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "审计日志获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListAdminAuditLogsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的查询参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "查询审计日志时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/post/admin/posts": {
             "get": {
                 "description": "出于管理目的，根据各种过滤条件检索分页的帖子列表。使用查询参数进行过滤和分页。",
@@ -72,7 +179,7 @@ const docTemplate = `{
                             3
                         ],
                         "type": "integer",
-                        "description": "按官方标签过滤 (例如, 0=无, 1=官方认证)",
+                        "description": "按官方标签过滤，拥有该标签即匹配（帖子可同时拥有多个标签）；0 只匹配没有标签的帖子 (例如, 0=无, 1=官方认证)",
                         "name": "official_tag",
                         "in": "query"
                     },
@@ -91,20 +198,30 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "created_at",
-                            "updated_at"
-                        ],
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "按创建时间下限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T00:00:00+08:00）",
+                        "name": "created_at_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "按创建时间上限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T23:59:59+08:00）",
+                        "name": "created_at_end",
+                        "in": "query"
+                    },
+                    {
                         "type": "string",
                         "default": "created_at",
-                        "description": "排序字段 (created_at 或 updated_at)",
+                        "description": "排序条件，逗号分隔的 字段[:asc|desc]，最多 3 个，如 view_count:desc,created_at:desc。可用字段: created_at, updated_at, view_count, like_count",
                         "name": "order_by",
                         "in": "query"
                     },
                     {
                         "type": "boolean",
                         "default": false,
-                        "description": "是否降序排序 (true 为 DESC, false/省略为 ASC)",
+                        "description": "未指定方向的排序字段是否降序 (true 为 DESC, false/省略为 ASC)",
                         "name": "order_desc",
                         "in": "query"
                     },
@@ -112,10 +229,9 @@ const docTemplate = `{
                         "minimum": 1,
                         "type": "integer",
                         "format": "int",
-                        "description": "页码（从 1 开始）",
+                        "description": "页码（从 1 开始，未携带 cursor_id 时必填）",
                         "name": "page",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "minimum": 1,
@@ -125,6 +241,13 @@ const docTemplate = `{
                         "name": "page_size",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "游标（上一页响应的 next_cursor）。携带时按 ID 游标分页，忽略 page 与 order_by，不统计总数 (total 为 -1)",
+                        "name": "cursor_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -135,7 +258,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "无效的输入参数（例如，无效的 page, page_size, status）\" // \u003c--- 修改",
+                        "description": "无效的输入参数（例如，无效的 page, page_size, status, order_by，或创建时间下限晚于上限）\" // \u003c--- 修改",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -181,7 +304,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "无效的请求负载（例如，缺少字段，无效的状态）\" // \u003c--- 修改",
+                        "description": "帖子版权声明不合理（例如转载帖未注明来源），无法审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "无法获取管理员ID",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -192,6 +321,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
+                    "409": {
+                        "description": "帖子仍是草稿，尚未提交审核",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
                     "500": {
                         "description": "审核过程中发生内部服务器错误\" // \u003c--- 修改",
                         "schema": {
@@ -201,9 +342,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/admin/posts/{id}/official-tag": {
-            "put": {
-                "description": "管理员更新特定帖子的官方标签。需要在 URL 路径中提供帖子 ID，并在请求体中提供标签详情。",
+        "/api/v1/post/admin/posts/batch-audit": {
+            "post": {
+                "description": "管理员一次审核多个帖子。单个帖子审核失败不影响其他帖子，响应中返回每个帖子的处理结果。",
                 "consumes": [
                     "application/json"
                 ],
@@ -213,47 +354,45 @@ const docTemplate = `{
                 "tags": [
                     "admin-posts (管理员-帖子)"
                 ],
-                "summary": "更新帖子官方标签 (管理员)",
+                "summary": "批量审核帖子",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "uint64",
-                        "description": "要更新的帖子 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "更新官方标签请求体 (请求体中的 PostID 是冗余的，请使用路径中的 ID)",
+                        "description": "批量审核请求体 (最多 100 条)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateOfficialTagRequest"
+                            "$ref": "#/definitions/dto.BatchAuditRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "官方标签更新成功\" // \u003c--- 修改 (无 Data)",
+                        "description": "批量审核处理完成（需检查每条结果）",
                         "schema": {
-                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                            "$ref": "#/definitions/vo.BatchAuditResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的请求负载，无效的标签值，或路径 ID 与请求体 ID 不匹配\" // \u003c--- 修改",
+                        "description": "无效的请求负载",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
-                    "404": {
-                        "description": "帖子未找到\" // \u003c--- 修改",
+                    "401": {
+                        "description": "无法获取管理员ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "更新标签时发生内部服务器错误\" // \u003c--- 修改",
+                        "description": "批量审核过程中发生内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -261,9 +400,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/admin/posts/{post_id}": {
-            "delete": {
-                "description": "管理员软删除指定ID的帖子 (Admin soft deletes a post with the specified ID)",
+        "/api/v1/post/admin/posts/batch-official-tag": {
+            "put": {
+                "description": "为一批帖子打上同一个官方标签（official_tag 为 0 表示批量移除标签），单次最多 500 个帖子。响应中返回实际更新的数量与不存在或已删除的帖子 ID。",
                 "consumes": [
                     "application/json"
                 ],
@@ -271,45 +410,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "管理员删除帖子 (Admin delete post)",
+                "summary": "批量更新帖子官方标签 (管理员)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "帖子ID (Post ID)",
-                        "name": "post_id",
-                        "in": "path",
-                        "required": true
+                        "description": "批量更新官方标签请求体",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchUpdateOfficialTagRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "帖子删除成功",
+                        "description": "批量更新完成（需检查未找到的帖子）",
                         "schema": {
-                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                            "$ref": "#/definitions/vo.BatchResultResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的帖子ID格式",
+                        "description": "无效的请求负载或标签值",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "401": {
-                        "description": "管理员未登录或无权限",
+                        "description": "无法获取管理员ID",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
-                    "404": {
-                        "description": "帖子未找到",
+                    "413": {
+                        "description": "请求体超过大小限制",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "删除帖子时发生内部服务器错误",
+                        "description": "批量更新标签时发生内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -317,52 +458,110 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/hot-posts": {
+        "/api/v1/post/admin/posts/export": {
             "get": {
-                "description": "使用基于游标的分页方式，检索热门帖子列表。使用查询参数来传递游标和数量限制。",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按与帖子列表相同的筛选条件导出全部匹配的帖子（不分页），以 CSV 附件流式返回，列为 id、title、author_username、status、view_count、official_tag、created_at。按帖子 ID 顺序导出。",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "hot-posts (热门帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "通过游标获取热门帖子",
+                "summary": "按条件导出帖子 CSV (管理员)",
                 "parameters": [
                     {
                         "type": "integer",
                         "format": "uint64",
-                        "description": "上一页最后一个帖子的 ID，首页省略",
-                        "name": "last_post_id",
+                        "description": "按精确的帖子 ID 过滤",
+                        "name": "id",
                         "in": "query"
                     },
                     {
-                        "minimum": 1,
+                        "type": "string",
+                        "description": "按帖子标题过滤（模糊匹配）",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按作者用户名过滤（模糊匹配）",
+                        "name": "author_username",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2
+                        ],
                         "type": "integer",
-                        "format": "int",
-                        "description": "每页帖子数量",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "按帖子状态过滤 (0=待审核, 1=已审核, 2=已拒绝)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "description": "按官方标签过滤，拥有该标签即匹配（帖子可同时拥有多个标签）；0 只匹配没有标签的帖子 (例如, 0=无, 1=官方认证)",
+                        "name": "official_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "按最小浏览量过滤",
+                        "name": "view_count_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "按最大浏览量过滤",
+                        "name": "view_count_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "按创建时间下限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T00:00:00+08:00）",
+                        "name": "created_at_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "按创建时间上限过滤（包含，RFC3339 且必须带时区偏移，如 2025-06-10T23:59:59+08:00）",
+                        "name": "created_at_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "是否按帖子 ID 降序导出（从最新的帖子开始）",
+                        "name": "order_desc",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "热门帖子检索成功。\" // \u003c--- 修改",
+                        "description": "CSV 文件 (UTF-8 BOM)",
                         "schema": {
-                            "$ref": "#/definitions/vo.ListPostsByCursorResponseWrapper"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "无效的输入参数（例如，无效的 limit 或 last_post_id 格式）\" // \u003c--- 修改",
+                        "description": "无效的输入参数（例如，创建时间下限晚于上限）",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "检索热门帖子时发生内部服务器错误\" // \u003c--- 修改",
+                        "description": "开始导出前发生内部服务器错误；导出过程中出错时文件被截断",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -370,56 +569,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/hot-posts/{post_id}": {
+        "/api/v1/post/admin/posts/reported": {
             "get": {
-                "description": "通过帖子的 ID 检索特定热门帖子的详细信息。需要在 URL 路径中提供帖子 ID，并从上下文中获取 UserID。",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按举报数倒序分页列出被用户举报的帖子（举报数相同时新帖在前），附带最近一次被举报的时间。已删除的帖子不再列出。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "hot-posts (热门帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "根据帖子 ID 获取热门帖子详情",
+                "summary": "查询被举报帖子 (管理员)",
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "format": "uint64",
-                        "description": "帖子 ID",
-                        "name": "post_id",
-                        "in": "path",
-                        "required": true
-                    }
+                        "format": "int",
+                        "description": "页码（从 1 开始）",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "热门帖子详情检索成功\" // \u003c--- 修改",
+                        "description": "被举报帖子列表获取成功",
                         "schema": {
-                            "$ref": "#/definitions/vo.PostDetailResponseWrapper"
+                            "$ref": "#/definitions/vo.ListReportedPostsResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的帖子 ID 格式\" // \u003c--- 修改",
+                        "description": "无效的查询参数",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
-                    "401": {
-                        "description": "在上下文中未找到用户 ID（未授权）\" // \u003c--- 修改",
+                    "500": {
+                        "description": "查询被举报帖子时发生内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
-                    },
-                    "404": {
-                        "description": "热门帖子详情未找到\" // \u003c-- 添加404情况",
+                    }
+                }
+            }
+        },
+        "/api/v1/post/admin/posts/stats": {
+            "get": {
+                "description": "按审核状态与官方标签聚合帖子数量，供运营看板展示。不含已删除的帖子，草稿单独计数；结果缓存 1 分钟。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-posts (管理员-帖子)"
+                ],
+                "summary": "帖子数量统计 (管理员)",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
                         "schema": {
-                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                            "$ref": "#/definitions/vo.PostStatsResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "检索热门帖子详情时发生内部服务器错误\" // \u003c--- 修改",
+                        "description": "统计时发生内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -427,94 +648,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/posts": {
-            "post": {
-                "description": "使用提供的详情（作为独立表单字段）和图片文件创建一个新帖子。请求体应为 multipart/form-data。",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/api/v1/post/admin/posts/views/recent": {
+            "get": {
+                "description": "基于 Redis 分钟级时间桶聚合最近 N 分钟的全站浏览量，供运营大屏使用。窗口包含当前未结束的分钟。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts (帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "创建新帖子 (独立表单字段及图片)",
+                "summary": "最近 N 分钟全站浏览量 (管理员)",
                 "parameters": [
                     {
-                        "maxLength": 100,
-                        "type": "string",
-                        "description": "帖子标题",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "maxLength": 1000,
-                        "type": "string",
-                        "description": "帖子内容",
-                        "name": "content",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "number",
-                        "description": "单价 (可选, 大于等于0)",
-                        "name": "price_per_unit",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "联系方式 (可选)",
-                        "name": "contact_info",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "作者ID",
-                        "name": "author_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "url",
-                        "description": "作者头像 URL (可选, 需为有效URL)",
-                        "name": "author_avatar",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 50,
-                        "type": "string",
-                        "description": "作者用户名",
-                        "name": "author_username",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "帖子图片文件 (可多选)",
-                        "name": "images",
-                        "in": "formData",
-                        "required": true
+                        "maximum": 60,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 5,
+                        "description": "统计窗口（分钟），默认 5，最大 60",
+                        "name": "minutes",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "帖子创建成功",
+                        "description": "获取成功",
                         "schema": {
-                            "$ref": "#/definitions/vo.PostDetailResponseWrapper"
+                            "$ref": "#/definitions/vo.RecentViewsResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的请求负载或文件处理错误",
+                        "description": "无效的 minutes 参数",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "创建帖子时发生内部服务器错误",
+                        "description": "获取浏览量时发生内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -522,9 +691,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/posts/by-author": {
-            "get": {
-                "description": "使用游标分页方式，检索特定用户公开发布的帖子列表。",
+        "/api/v1/post/admin/posts/{id}/official-tag": {
+            "put": {
+                "description": "管理员更新特定帖子的官方标签，帖子已有的全部标签被替换为请求中的一个标签（0 表示清空标签）；需要保留其他标签时使用追加/移除单个标签接口。需要在 URL 路径中提供帖子 ID，并在请求体中提供标签详情。",
                 "consumes": [
                     "application/json"
                 ],
@@ -532,49 +701,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts (帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "获取指定用户的帖子列表 (公开, 游标加载)",
+                "summary": "更新帖子官方标签 (管理员)",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "要查询其帖子的用户 ID",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "format": "uint64",
-                        "description": "游标（上一页最后一个帖子的 ID），首页省略",
-                        "name": "cursor",
-                        "in": "query"
+                        "description": "要更新的帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "format": "int",
-                        "description": "每页帖子数量",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "更新官方标签请求体 (请求体中的 PostID 是冗余的，请使用路径中的 ID)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOfficialTagRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "帖子检索成功\" // 确保 vo.ListPostsByUserIDResponseWrapper 对应游标加载的响应结构",
+                        "description": "官方标签更新成功\" // \u003c--- 修改 (无 Data)",
                         "schema": {
-                            "$ref": "#/definitions/vo.ListPostsByCursorResponseWrapper"
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的输入参数",
+                        "description": "无效的请求负载，无效的标签值，或路径 ID 与请求体 ID 不匹配\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "无法获取管理员ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "检索帖子时发生内部服务器错误",
+                        "description": "更新标签时发生内部服务器错误\" // \u003c--- 修改",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -582,185 +763,129 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/posts/mine": {
-            "get": {
-                "description": "获取当前登录用户发布的帖子列表，支持按官方标签、标题、帖子状态筛选，并使用分页加载。UserID 从请求上下文中获取。",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/post/admin/posts/{id}/official-tags/{tag}": {
+            "post": {
+                "description": "为帖子追加一个官方标签，保留帖子已有的其他标签；帖子已有该标签时同样成功。已审核通过的帖子新增标签时推送给该标签的订阅者。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts (帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "获取我的帖子列表",
+                "summary": "追加帖子官方标签 (管理员)",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "format": "int32",
-                        "default": 1,
-                        "description": "页码 (从1开始)",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
                         "type": "integer",
-                        "format": "int32",
-                        "default": 10,
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "enum": [
-                            0,
                             1,
                             2,
                             3
                         ],
                         "type": "integer",
-                        "format": "int32",
-                        "description": "官方标签 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)",
-                        "name": "officialTag",
-                        "in": "query"
-                    },
-                    {
-                        "maxLength": 255,
-                        "type": "string",
-                        "description": "标题模糊搜索关键词 (最大长度 255)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            0,
-                            1,
-                            2
-                        ],
-                        "type": "integer",
-                        "format": "int32",
-                        "description": "帖子状态 (0:待审核, 1:审核通过, 2:拒绝)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功响应，包含用户帖子列表和总记录数",
+                        "description": "官方标签追加成功",
                         "schema": {
-                            "$ref": "#/definitions/vo.ListUserPostPageResponseWrapper"
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的请求参数",
+                        "description": "帖子 ID 或官方标签不合法",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "401": {
-                        "description": "用户未授权或认证失败",
+                        "description": "无法获取管理员ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/post/posts/timeline": {
-            "get": {
-                "description": "根据指定条件（官方标签、标题、作者用户名）和游标分页获取帖子列表，按时间倒序排列。",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "移除帖子的一个官方标签，保留其他标签；帖子本就没有该标签时同样成功。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts (帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "获取帖子时间线列表 (公开)",
+                "summary": "移除帖子官方标签 (管理员)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "上一页最后一条记录的创建时间 (RFC3339格式, e.g., 2023-01-01T15:04:05Z)",
-                        "name": "lastCreatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
                         "type": "integer",
                         "format": "uint64",
-                        "description": "上一页最后一条记录的帖子ID",
-                        "name": "lastPostId",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "format": "int32",
-                        "default": 10,
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "enum": [
-                            0,
                             1,
                             2,
                             3
                         ],
                         "type": "integer",
-                        "format": "int32",
-                        "description": "官方标签 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)",
-                        "name": "officialTag",
-                        "in": "query"
-                    },
-                    {
-                        "maxLength": 255,
-                        "type": "string",
-                        "description": "标题模糊搜索关键词 (最大长度 255)",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "maxLength": 50,
-                        "type": "string",
-                        "description": "作者用户名模糊搜索关键词 (最大长度 50)",
-                        "name": "authorUsername",
-                        "in": "query"
+                        "description": "官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功响应，包含帖子列表和下一页游标信息",
+                        "description": "官方标签移除成功",
                         "schema": {
-                            "$ref": "#/definitions/vo.PostTimelinePageResponseWrapper"
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的请求参数",
+                        "description": "帖子 ID 或官方标签不合法",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "无法获取管理员ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
@@ -768,9 +893,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/posts/{id}": {
+        "/api/v1/post/admin/posts/{post_id}": {
             "delete": {
-                "description": "通过帖子的 ID 软删除一个帖子。",
+                "description": "管理员软删除指定ID的帖子 (Admin soft deletes a post with the specified ID)。浏览量超过配置阈值的帖子需要带 confirm=true 二次确认，否则返回 409 及删除影响面（浏览量、是否在热榜）。",
                 "consumes": [
                     "application/json"
                 ],
@@ -778,17 +903,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts (帖子)"
+                    "Admin"
                 ],
-                "summary": "删除指定ID的帖子",
+                "summary": "管理员删除帖子 (Admin delete post)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "uint64",
-                        "description": "帖子 ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "帖子ID (Post ID)",
+                        "name": "post_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "确认删除高浏览量帖子",
+                        "name": "confirm",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -799,11 +930,29 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "无效的帖子 ID 格式",
+                        "description": "无效的帖子ID格式或 confirm 参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "管理员未登录或无权限",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "帖子浏览量较高，需要带 confirm=true 二次确认",
+                        "schema": {
+                            "$ref": "#/definitions/vo.DeleteConfirmRequiredResponseWrapper"
+                        }
+                    },
                     "500": {
                         "description": "删除帖子时发生内部服务器错误",
                         "schema": {
@@ -813,19 +962,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/post/posts/{post_id}": {
+        "/api/v1/post/admin/posts/{post_id}/audit-logs": {
             "get": {
-                "description": "通过帖子的 ID 检索特定帖子的详细信息。同时，如果用户已登录（通过中间件注入UserID），则会尝试增加浏览量。",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "分页查询指定帖子的审核、改标签、删除记录（含操作人、操作前后状态与原因），按操作时间倒序。已删除帖子同样可查。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts (帖子)"
+                    "admin-posts (管理员-帖子)"
                 ],
-                "summary": "获取指定ID的帖子详情 (公开)",
+                "summary": "查询帖子审计历史 (管理员)",
                 "parameters": [
                     {
                         "type": "integer",
@@ -836,187 +982,5410 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "用户 ID (由网关/中间件注入)",
-                        "name": "X-User-ID",
-                        "in": "header"
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "页码（从 1 开始）",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "帖子详情检索成功",
+                        "description": "帖子审计历史获取成功",
                         "schema": {
-                            "$ref": "#/definitions/vo.PostDetailResponseWrapper"
+                            "$ref": "#/definitions/vo.ListPostAuditLogsResponseWrapper"
                         }
                     },
                     "400": {
-                        "description": "无效的帖子 ID 格式",
+                        "description": "无效的帖子 ID 或查询参数",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     },
                     "500": {
-                        "description": "检索帖子详情时发生内部服务器错误",
+                        "description": "查询审计历史时发生内部服务器错误",
                         "schema": {
                             "$ref": "#/definitions/vo.BaseResponseWrapper"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AuditPostRequest": {
-            "type": "object",
-            "required": [
-                "post_id"
-            ],
-            "properties": {
-                "post_id": {
-                    "description": "为 PostID 也添加一个 example",
-                    "type": "integer",
-                    "example": 123
-                },
-                "reason": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "example": "内容符合规范"
-                },
-                "status": {
-                    "description": "Status 表示帖子的审核状态。\n0: 待审核 (Pending)\n1: 审核通过 (Approved)\n2: 拒绝 (Rejected)",
-                    "type": "integer",
-                    "maximum": 2,
-                    "minimum": 0
+        },
+        "/api/v1/post/admin/posts/{post_id}/conversions": {
+            "get": {
+                "description": "返回帖子最近 30 天的浏览 → 联系 → 成交漏斗与转化率，按天与浏览来源拆分，并附帖子累计浏览量作为对照。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reports (管理员-报表)"
+                ],
+                "summary": "查询帖子转化漏斗 (管理员)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "转化漏斗获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostConversionResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
                 }
             }
         },
-        "dto.UpdateOfficialTagRequest": {
-            "type": "object",
-            "required": [
-                "official_tag",
-                "post_id"
-            ],
-            "properties": {
-                "official_tag": {
-                    "description": "新的官方标签值，必填，并限制范围 (假设最大值为 3)",
-                    "type": "integer",
-                    "maximum": 3,
-                    "minimum": 0
-                },
-                "post_id": {
-                    "description": "帖子ID，必填",
-                    "type": "integer"
+        "/api/v1/post/admin/posts/{post_id}/detail": {
+            "get": {
+                "description": "返回帖子的标题、正文、图片以及审核状态、审核原因、审核优先级与删除信息，供审核时查看。已删除的帖子同样可查。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-posts (管理员-帖子)"
+                ],
+                "summary": "查询帖子完整详情 (管理员)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子完整详情获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.AdminPostDetailResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "查询帖子详情时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
                 }
             }
         },
-        "enums.OfficialTag": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3
-            ],
-            "x-enum-comments": {
-                "OfficialTagCertified": "官方认证",
-                "OfficialTagDeposit": "预付保证金",
-                "OfficialTagNone": "无标签",
-                "OfficialTagRapid": "急速响应"
-            },
-            "x-enum-varnames": [
-                "OfficialTagNone",
-                "OfficialTagCertified",
-                "OfficialTagDeposit",
+        "/api/v1/post/admin/posts/{post_id}/read-depth": {
+            "get": {
+                "description": "返回帖子的阅读次数、平均阅读深度、读完率与按 10% 分段的深度分布（深度 100 单独成段）。统计只包含登录用户，同一次阅读按最大深度计一次。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reports (管理员-报表)"
+                ],
+                "summary": "查询帖子阅读深度统计 (管理员)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "阅读深度统计获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostReadDepthResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/admin/posts/{post_id}/restore": {
+            "post": {
+                "description": "撤销软删除，恢复帖子及其详情、图片和 FAQ。恢复后帖子状态重置为待审核，需要重新审核通过后才会公开。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "管理员恢复已删除的帖子 (Admin restore deleted post)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "帖子ID (Post ID)",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子恢复成功，已重新提交审核",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子ID格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "管理员未登录或无权限",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "帖子未被删除，无需恢复",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "恢复帖子时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/admin/posts/{post_id}/snapshots": {
+            "get": {
+                "description": "返回帖子在时间范围内的浏览量、点赞数、审核状态与排行榜排名快照，按快照时间升序。小时级快照只覆盖当时活跃（最近一小时有浏览）的帖子，保留 7 天；日级快照覆盖前一天活跃过的帖子，保留 180 天。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reports (管理员-报表)"
+                ],
+                "summary": "查询帖子历史指标快照 (管理员)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "hourly",
+                            "daily"
+                        ],
+                        "type": "string",
+                        "description": "快照粒度，默认 hourly",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "快照时间下限（包含，RFC3339），默认为 end_time 前 7 天",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "快照时间上限（不包含，RFC3339），默认为当前时间",
+                        "name": "end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子历史快照获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListPostSnapshotsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID、查询参数或时间范围（最大跨度 90 天）",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/admin/reconcile/resync": {
+            "post": {
+                "description": "下游服务（搜索、推荐等）比对对账快照后，提交缺失或过期的帖子 ID。已审核通过的帖子重新发送审核通过事件，已删除、不存在或未审核通过的帖子发送删除事件。每次调用记录一条审计日志作为差异记录。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-posts (管理员-帖子)"
+                ],
+                "summary": "对账补偿：重新发送帖子同步事件 (管理员)",
+                "parameters": [
+                    {
+                        "description": "对账补偿请求体 (最多 500 个帖子)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconcileResyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "对账补偿处理完成（需检查失败列表）",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ReconcileResyncResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求负载",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "无法获取管理员ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "对账补偿过程中发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "503": {
+                        "description": "未配置 Kafka，无法发送同步事件",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/admin/reports": {
+            "get": {
+                "description": "分页查询定时任务生成的帖子数据报表，可按统计周期过滤。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reports (管理员-报表)"
+                ],
+                "summary": "列出历史数据报表 (管理员)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly"
+                        ],
+                        "type": "string",
+                        "description": "统计周期",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "页码（从 1 开始）",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "报表列表获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListDataReportsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的查询参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/admin/reports/{report_id}/download": {
+            "get": {
+                "description": "重定向到报表文件在 COS 中的下载地址。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reports (管理员-报表)"
+                ],
+                "summary": "下载数据报表 (管理员)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "报表 ID",
+                        "name": "report_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "重定向到报表文件地址"
+                    },
+                    "400": {
+                        "description": "无效的报表 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "报表不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/badges/authors/{author_id}": {
+            "get": {
+                "description": "返回作者因帖子浏览量达到里程碑而获得的全部徽章，按颁发时间倒序。浏览量以同步到数据库的值为准，徽章颁发相对实时浏览量有数分钟延迟。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "badges (徽章)"
+                ],
+                "summary": "查询作者徽章 (公开)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "作者ID",
+                        "name": "author_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "作者徽章获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.AuthorBadgesResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "作者ID为空",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/badges/posts/{post_id}": {
+            "get": {
+                "description": "返回帖子已达成的里程碑徽章、下一个里程碑及完成进度。仅对已审核通过的帖子可用。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "badges (徽章)"
+                ],
+                "summary": "查询帖子浏览量里程碑进度 (公开)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "里程碑进度获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostMilestoneProgressResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或未审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/hot-posts": {
+            "get": {
+                "description": "使用基于游标的分页方式，检索热门帖子列表。使用查询参数来传递游标和数量限制。传入 official_tag 时只返回该官方标签下的热门帖子。缓存不可用时返回数据库中按浏览量排序的近似热榜，此时 degraded 为 true。热榜定期重建，last_post_id 已不在当前热榜中时不报错，从估算位置（按官方标签过滤时为榜首）继续返回，此时 cursor_reset 为 true，客户端应按帖子 ID 去重。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hot-posts (热门帖子)"
+                ],
+                "summary": "通过游标获取热门帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "上一页最后一个帖子的 ID，首页省略",
+                        "name": "last_post_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "每页帖子数量",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "description": "官方标签过滤 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)，省略时返回全部标签的热门帖子",
+                        "name": "official_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户地区编码 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户等级 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Level",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Tags",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "热门帖子检索成功。\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListPostsByCursorResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的输入参数（例如，无效的 limit 或 last_post_id 格式）\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "检索热门帖子时发生内部服务器错误\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/hot-posts/{post_id}": {
+            "get": {
+                "description": "通过帖子的 ID 检索特定热门帖子的详细信息。需要在 URL 路径中提供帖子 ID，并从上下文中获取 UserID。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hot-posts (热门帖子)"
+                ],
+                "summary": "根据帖子 ID 获取热门帖子详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端设备标识 (由网关透传，开启匿名浏览统计时用于未登录用户的浏览去重，缺失时使用客户端 IP)",
+                        "name": "X-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "热门帖子详情检索成功\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostDetailResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "在上下文中未找到用户 ID（未授权）\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "402": {
+                        "description": "帖子需要付费后查看",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostAccessDeniedResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "不满足帖子的访问策略（如未关注作者）",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostAccessDeniedResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "热门帖子详情未找到\" // \u003c-- 添加404情况",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "检索热门帖子详情时发生内部服务器错误\" // \u003c--- 修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts": {
+            "post": {
+                "description": "使用提供的详情（作为独立表单字段）和图片文件创建一个新帖子。请求体应为 multipart/form-data。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "创建新帖子 (独立表单字段及图片)",
+                "parameters": [
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "帖子标题",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 1000,
+                        "type": "string",
+                        "description": "帖子内容",
+                        "name": "content",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "单价 (可选, 大于等于0)",
+                        "name": "price_per_unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "联系方式 (可选)",
+                        "name": "contact_info",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "作者ID",
+                        "name": "author_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "url",
+                        "description": "作者头像 URL (可选, 需为有效URL)",
+                        "name": "author_avatar",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "作者用户名",
+                        "name": "author_username",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "default": 0,
+                        "description": "版权声明类型 (0:原创, 1:转载, 2:禁止转载)",
+                        "name": "copyright_type",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 512,
+                        "type": "string",
+                        "format": "url",
+                        "description": "转载来源地址 (copyright_type=1 时必填)",
+                        "name": "source_url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "是否付费加急审核 (可选, 加急帖按高优先级送审)",
+                        "name": "urgent",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "作者等级 (由网关注入，达到阈值的作者按高优先级送审)",
+                        "name": "X-User-Level",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键 (可选, 最长 128 字符; 重试同一次提交时携带相同的值，10 分钟内重复请求直接返回首次创建的结果)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "FAQ 列表 (可选, JSON 数组字符串, 例如 [{\\",
+                        "name": "faqs",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "投放地区编码列表 (可选, 不填表示不限)",
+                        "name": "target_regions",
+                        "in": "formData"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "投放最低用户等级 (可选, 0 表示不限)",
+                        "name": "target_min_level",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "投放用户标签列表 (可选, 命中任意一个即可见)",
+                        "name": "target_tags",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 7,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "详情访问策略 (可选, 按位组合: 1=需登录, 2=需付费, 4=需关注作者, 0=公开)",
+                        "name": "access_policy",
+                        "in": "formData"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "转发的原帖ID (可选, 设置后 content 即为转发语)",
+                        "name": "quoted_post_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "是否保存为草稿 (可选, 草稿不送审、仅作者可见，之后通过发布接口提交审核)",
+                        "name": "save_as_draft",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "忽略查重提示继续发布 (可选, 查重命中相似帖子时返回 409，确认后带上 true 重新提交)",
+                        "name": "ignore_similar",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "是否保留原图 (可选, 上传前会压缩图片，开启后被压缩的图片额外保存一份原图供下载)",
+                        "name": "keep_original_images",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "帖子图片文件 (可多选，与 image_urls 合计的数量上下限按帖子类型配置，如商品帖至少 1 张)",
+                        "name": "images",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "从 URL 导入的图片 (可选, 仅支持 http/https 且不能指向内网地址，服务端下载后与上传的文件一起校验)",
+                        "name": "image_urls",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "URL 图片在最终图片列表中的位置 (可选, 从 0 开始, 与 image_urls 一一对应; 省略时排在上传的文件之后)",
+                        "name": "image_url_positions",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "已直传到 COS 的图片对象键 (可选, 由 /posts/images/presign 生成，排在其他图片之后，计入图片数量上下限)",
+                        "name": "image_object_keys",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostDetailResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "直传图片的对象键不属于当前作者、对象尚未上传、不是图片或超过大小上限",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "原帖声明禁止转载，不允许转发",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "相同幂等键的请求仍在处理中",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "创建帖子时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/by-author": {
+            "get": {
+                "description": "使用游标分页方式，检索特定用户公开发布的帖子列表。查询者是作者本人时返回全部状态的帖子（包括草稿、待审核与被拒绝的帖子），可用 status 筛选；查看他人时只返回已审核通过的帖子。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取指定用户的帖子列表 (公开, 游标加载)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "要查询其帖子的用户 ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "游标（上一页最后一个帖子的 ID），首页省略",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int",
+                        "description": "每页帖子数量",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "状态筛选 (0:待审核, 1:已审核, 2:已拒绝)，只在查询者是作者本人时生效，省略表示全部状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户 ID (由网关/中间件注入)",
+                        "name": "X-User-ID",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子检索成功\" // 确保 vo.ListPostsByUserIDResponseWrapper 对应游标加载的响应结构",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListPostsByCursorResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的输入参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "检索帖子时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/by-authors": {
+            "post": {
+                "description": "一次查询多个作者已审核通过的帖子，按创建时间倒序混合排列，用于推荐/关注流。作者列表通过请求体传递，最多 2000 个。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取多个作者的帖子时间线 (公开, 游标加载)",
+                "parameters": [
+                    {
+                        "description": "作者列表与分页游标",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListPostsByAuthorsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功响应，包含帖子列表和下一页游标信息",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostTimelinePageResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/images/presign": {
+            "post": {
+                "description": "生成帖子图片的 COS 对象键与预签名上传 URL。客户端在有效期内以 PUT 方法把图片上传到 upload_url（需设置图片的 Content-Type），之后在创建帖子时通过 image_object_keys 提交 object_key，图片内容不经过本服务。UserID 从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "申请图片直传 URL",
+                "parameters": [
+                    {
+                        "description": "待上传图片的文件名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PresignImageUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "生成成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PresignedImageUploadResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求负载或不支持的图片格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "生成上传 URL 时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/mine": {
+            "get": {
+                "description": "获取当前登录用户发布的帖子列表，支持按官方标签、标题、帖子状态筛选，并使用分页加载。UserID 从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取我的帖子列表",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int32",
+                        "default": 1,
+                        "description": "页码 (从1开始)",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int32",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "format": "int32",
+                        "description": "官方标签 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)",
+                        "name": "officialTag",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "标题模糊搜索关键词 (最大长度 255)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "format": "int32",
+                        "description": "帖子状态 (0:待审核, 1:审核通过, 2:拒绝)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否只看草稿 (true:仅草稿, false:仅已提交的帖子, 不传:全部)",
+                        "name": "isDraft",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功响应，包含用户帖子列表和总记录数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListUserPostPageResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未授权或认证失败",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/popular": {
+            "get": {
+                "description": "返回全部已审核通过帖子按浏览量倒序的列表（非实时热榜，浏览量为定时同步的持久化值），浏览量相同时按帖子ID倒序。翻页时同时回传上一页响应的 nextViewCount 与 nextPostId。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "按浏览量倒序获取帖子列表 (公开)",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "上一页响应的 nextViewCount，首次加载不传",
+                        "name": "lastViewCount",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "上一页响应的 nextPostId，首次加载不传",
+                        "name": "lastPostId",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int32",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户地区编码 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户等级 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Level",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Tags",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功响应，包含帖子列表和下一页游标",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PopularPostsPageResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求参数，或游标参数不完整",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/search": {
+            "get": {
+                "description": "在已审核通过帖子的标题与正文中检索关键词，按相关性排序（标题命中优先）。多个词以空格分隔，每个词都必须命中；全文检索运算符会被忽略。翻页时回传上一页响应的 next_cursor。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "全文搜索帖子 (公开)",
+                "parameters": [
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "搜索关键词 (最大长度 100)",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "上一页响应的 next_cursor，首次加载不传",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int32",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户地区编码 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户等级 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Level",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Tags",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功响应，包含帖子列表和下一页游标",
+                        "schema": {
+                            "$ref": "#/definitions/vo.ListPostsByCursorResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求参数，或关键词不包含有效内容",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/similar-check": {
+            "post": {
+                "description": "按标题+内容相似度，在作者本人近期帖子与全站近期已审核通过帖子中查找高度相似的帖子，不创建帖子。作者ID从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "发布前查重预览",
+                "parameters": [
+                    {
+                        "description": "待发布的标题与内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CheckSimilarPostsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查重完成，similar 为空表示没有相似帖子",
+                        "schema": {
+                            "$ref": "#/definitions/vo.SimilarPostsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求参数，或正文清洗后为空",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "查重时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/timeline": {
+            "get": {
+                "description": "根据指定条件（官方标签、标题、作者用户名）和游标分页获取帖子列表，按发布先后倒序排列。翻页时只需回传上一页的 nextPostId。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取帖子时间线列表 (公开)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "已废弃，传入后忽略：上一页最后一条记录的创建时间 (RFC3339格式)",
+                        "name": "lastCreatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "上一页最后一条记录的帖子ID (即上一页响应的 nextPostId)",
+                        "name": "lastPostId",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "format": "int32",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "format": "int32",
+                        "description": "官方标签 (0:无标签, 1:官方认证, 2:预付保证金, 3:急速响应)",
+                        "name": "officialTag",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "标题模糊搜索关键词 (最大长度 255)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "作者用户名模糊搜索关键词 (最大长度 50)",
+                        "name": "authorUsername",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "只看这些作者的帖子（关注流），可重复传参或逗号分隔，去重后最多 200 个",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "是否折叠当前页内容高度相似的帖子，被折叠的帖子放在代表帖的 similar_posts 中",
+                        "name": "collapseSimilar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户地区编码 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户等级 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Level",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Tags",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功响应，包含帖子卡片列表（不含审核状态与原因）和下一页游标信息",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostTimelineCardPageResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求参数，或作者 ID 数量超过上限",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/views/merge-anonymous": {
+            "post": {
+                "description": "客户端在用户登录成功后调用，将当前设备登录前的匿名浏览记录归并到用户，之后用户在任何设备上浏览这些帖子都不会重复计数。浏览量本身不变。UserID 从请求上下文中获取。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "归并设备匿名浏览记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端设备标识，需与登录前浏览时使用的一致",
+                        "name": "X-Device-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "归并完成",
+                        "schema": {
+                            "$ref": "#/definitions/vo.MergeAnonymousViewsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "请求缺少设备标识",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "归并时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}": {
+            "put": {
+                "description": "作者编辑审核通过或被拒的帖子，编辑后重新变为待审核。与上次送审的正文逐段（按行）比对，只有新增与修改的段落送审，未变更的段落沿用已有的审核结果；变更过多时整篇送审。任一段落未通过时整篇帖子被拒，拒绝原因注明未通过的段落序号。UserID 从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "编辑帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "编辑后的帖子内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子已编辑并重新送审",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载，或内容不符合目标地区的内容规范",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "帖子是草稿或正在审核中",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "编辑帖子时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "通过帖子的 ID 软删除一个帖子。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "删除指定ID的帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "删除帖子时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/appeal": {
+            "post": {
+                "description": "作者对审核被拒的帖子提交申诉，帖子重新变为待审核并携带申诉理由送人工复审。每个帖子最多申诉 3 次。UserID 从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "申诉被拒帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "申诉请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AppealPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "申诉已提交",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载，或内容不符合目标地区的内容规范",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "帖子不是审核被拒状态，或申诉次数已用完",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "申诉时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/conversions": {
+            "post": {
+                "description": "客户端在用户浏览帖子详情 (view)、联系作者 (contact)、成交 (deal) 时上报。浏览事件携带来源，联系与成交按该用户 7 天内最近一次浏览的来源归因。同一用户同一阶段每天只计一次；只统计登录用户，匿名用户与作者本人的上报被静默忽略。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "上报转化事件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "转化阶段与浏览来源",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportConversionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "转化事件已记录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或未审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/cover-candidates": {
+            "put": {
+                "description": "帖子作者从帖子已上传的图片中选择 2~4 张作为候选封面，公开列表会按用户稳定分桶展示其中一张；传入空数组表示关闭实验。每次设置都会清空旧的实验统计。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "设置 A/B 候选封面",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "候选封面图片ID列表",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCoverCandidatesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "候选封面设置成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID、请求负载，或候选封面数量/归属不合法",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者，无权设置",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/cover-click": {
+            "post": {
+                "description": "用户从列表点击进入帖子时上报看到的封面（列表响应中的 cover_image_id）。只统计登录用户，且封面必须与该用户的分桶结果一致，其余上报被静默忽略。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "上报 A/B 封面点击",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "点击的封面图片ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecordCoverClickRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "点击已记录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/faqs": {
+            "put": {
+                "description": "帖子作者整体替换帖子的 FAQ 列表，列表顺序即展示顺序；传入空列表表示清空。UserID 从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "更新帖子 FAQ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "FAQ 列表",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePostFAQsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FAQ 更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostFAQsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者，无权修改",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "更新 FAQ 时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/like": {
+            "post": {
+                "description": "为已审核通过的帖子点赞，重复点赞是幂等的，不会重复计数。UserID 从请求上下文中获取。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "点赞帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "点赞成功，返回实时点赞数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostLikeResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或未审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "点赞时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "取消对帖子的点赞，取消未点赞的帖子是幂等的，点赞数不变。UserID 从请求上下文中获取。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "取消点赞帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取消点赞成功，返回实时点赞数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostLikeResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "取消点赞时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/publish": {
+            "post": {
+                "description": "将作者自己的草稿提交审核：帖子转为待审核状态并发送待审核事件。UserID 从请求上下文中获取。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "发布草稿",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "草稿已提交审核",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者，无权发布",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "帖子不是草稿或已发布",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "发布草稿时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/read-depth": {
+            "post": {
+                "description": "客户端在阅读过程中上报用户滚动到的内容百分比 (0-100)。同一次阅读（read_id 相同）只保留最大深度；同一次阅读两次上报至少间隔 3 秒。只统计登录用户，匿名上报被静默忽略。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "上报阅读深度",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "阅读标识与深度",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportReadDepthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "阅读深度已记录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或未审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "429": {
+                        "description": "上报过于频繁",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{id}/report": {
+            "post": {
+                "description": "举报已审核通过的违规帖子，同一用户对同一帖子只能举报一次。举报数达到阈值时自动提交下架审查。UserID 从请求上下文中获取。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "举报帖子",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "举报请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "举报成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或请求负载",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或未审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "409": {
+                        "description": "已经举报过该帖子",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "举报时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{post_id}": {
+            "get": {
+                "description": "通过帖子的 ID 检索特定帖子的详细信息。同时会尝试增加浏览量：登录用户按 UserID 去重；开启匿名浏览统计时，未登录用户按设备标识 (X-Device-ID) 或客户端 IP 去重。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取指定ID的帖子详情 (公开)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户 ID (由网关/中间件注入)",
+                        "name": "X-User-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端设备标识 (由网关透传，用于匿名浏览去重，缺失时使用客户端 IP)",
+                        "name": "X-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户地区编码 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户等级 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Level",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户标签，逗号分隔 (由网关注入，用于投放定向过滤)",
+                        "name": "X-User-Tags",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "帖子详情检索成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostDetailResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "帖子需要登录后查看",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostAccessDeniedResponseWrapper"
+                        }
+                    },
+                    "402": {
+                        "description": "帖子需要付费后查看",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostAccessDeniedResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "不满足帖子的访问策略（如未关注作者）",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostAccessDeniedResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或对当前用户不可见",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "检索帖子详情时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{post_id}/conversions": {
+            "get": {
+                "description": "帖子作者查询最近 30 天的浏览 → 联系 → 成交漏斗与转化率，按天与浏览来源拆分，并附帖子累计浏览量作为对照。UserID 从请求上下文中获取。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "查询自己帖子的转化漏斗",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "转化漏斗获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostConversionResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{post_id}/cover-stats": {
+            "get": {
+                "description": "帖子作者查询各候选封面的曝光数、点击数与点击率。统计只包含登录用户，自最近一次设置候选封面起累计。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "查询 A/B 封面表现统计",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "封面统计获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostCoverStatsResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "403": {
+                        "description": "非帖子作者，无权查看",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子未找到",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{post_id}/references": {
+            "get": {
+                "description": "按时间倒序返回转发/引用了该帖子的已审核通过帖子，已删除的转发帖不会出现。原帖不存在或未审核通过时返回 404。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取转发了指定帖子的帖子列表 (公开, 游标加载)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "原帖 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "上一页最后一条记录的 ID，首页省略",
+                        "name": "lastPostId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量 (1-100)",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "hans",
+                            "hant",
+                            "original"
+                        ],
+                        "type": "string",
+                        "description": "中文字形偏好 (hans:简体, hant:繁体, original:原文)，未设置时按 Accept-Language 判断",
+                        "name": "X-User-Script",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功响应，包含转发帖列表和下一页游标信息",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostTimelinePageResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 或查询参数",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "原帖不存在或未审核通过",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/posts/{post_id}/seo": {
+            "get": {
+                "description": "基于帖子标题、正文摘要与官方标签实时生成 meta description 与 keywords，description 最长 160 个字符。仅对已审核通过且对匿名访客可见的帖子生成，受限访问的帖子以标题代替正文摘要。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts (帖子)"
+                ],
+                "summary": "获取帖子 SEO 元数据 (公开)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "uint64",
+                        "description": "帖子 ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SEO 元数据获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.PostSEOResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的帖子 ID 格式",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "404": {
+                        "description": "帖子不存在或不可公开访问",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "生成 SEO 元数据时发生内部服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/tag-subscriptions": {
+            "post": {
+                "description": "订阅指定的官方标签，帖子审核通过且带有该标签时会收到新帖推送。重复订阅是幂等的。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag-subscriptions (标签订阅)"
+                ],
+                "summary": "订阅标签",
+                "parameters": [
+                    {
+                        "description": "订阅请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscribeTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "订阅成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的请求负载或标签值",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体超过大小限制",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/tag-subscriptions/mine": {
+            "get": {
+                "description": "返回当前用户已订阅的官方标签列表。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag-subscriptions (标签订阅)"
+                ],
+                "summary": "我的标签订阅",
+                "responses": {
+                    "200": {
+                        "description": "订阅列表获取成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.TagSubscriptionsResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/post/tag-subscriptions/{tag}": {
+            "delete": {
+                "description": "取消对指定官方标签的订阅，未订阅时同样返回成功。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag-subscriptions (标签订阅)"
+                ],
+                "summary": "取消订阅标签",
+                "parameters": [
+                    {
+                        "maximum": 3,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取消订阅成功",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "400": {
+                        "description": "无效的标签值",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "401": {
+                        "description": "用户未登录",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/vo.BaseResponseWrapper"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "进程存活即返回 200，不检查依赖，依赖故障不应导致容器被重启。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health (健康检查)"
+                ],
+                "summary": "存活探针",
+                "responses": {
+                    "200": {
+                        "description": "进程存活",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "任一关键依赖（MySQL、Redis、已配置的 Kafka）不可用时返回 503；COS 为非关键依赖，只展示状态。响应体列出每个依赖的状态与探测耗时。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health (健康检查)"
+                ],
+                "summary": "就绪探针",
+                "responses": {
+                    "200": {
+                        "description": "所有关键依赖可用",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "存在不可用的关键依赖",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AppealPostRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "申诉理由，必填，最多 255 个字符",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "帖子内容为原创，不涉及违规"
+                }
+            }
+        },
+        "dto.AuditPostRequest": {
+            "type": "object",
+            "required": [
+                "post_id"
+            ],
+            "properties": {
+                "post_id": {
+                    "description": "为 PostID 也添加一个 example",
+                    "type": "integer",
+                    "example": 123
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "内容符合规范"
+                },
+                "status": {
+                    "description": "Status 表示帖子的审核状态。\n0: 待审核 (Pending)\n1: 审核通过 (Approved)\n2: 拒绝 (Rejected)",
+                    "type": "integer",
+                    "maximum": 2,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.BatchAuditRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "description": "待审核的帖子列表，必填",
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditPostRequest"
+                    }
+                }
+            }
+        },
+        "dto.BatchUpdateOfficialTagRequest": {
+            "type": "object",
+            "required": [
+                "post_ids"
+            ],
+            "properties": {
+                "official_tag": {
+                    "description": "新的官方标签值",
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 0
+                },
+                "post_ids": {
+                    "description": "帖子ID列表，必填",
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.CheckSimilarPostsRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "description": "待发布的帖子内容",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "待发布的帖子标题",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ListPostsByAuthorsRequest": {
+            "type": "object",
+            "required": [
+                "authorIds",
+                "pageSize"
+            ],
+            "properties": {
+                "authorIds": {
+                    "description": "AuthorIDs 作者ID列表。\n- binding:\"required,min=1,max=2000\"` + "`" + `: 必填，最多 constant.MaxFeedAuthors 个。",
+                    "type": "array",
+                    "maxItems": 2000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lastCreatedAt": {
+                    "description": "LastCreatedAt 上一页最后一条记录的创建时间。\n- 已废弃：翻页只依赖 LastPostID，保留该字段仅为兼容旧客户端，传入后会被忽略。",
+                    "type": "string"
+                },
+                "lastPostId": {
+                    "description": "LastPostID 上一页最后一条记录的 ID，首页省略。",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "pageSize": {
+                    "description": "PageSize 每页期望返回的记录数。\n- binding:\"required,gte=1,lte=100\"` + "`" + `: 必填，值必须在1到100之间。",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.PostFAQItem": {
+            "type": "object",
+            "required": [
+                "answer",
+                "question"
+            ],
+            "properties": {
+                "answer": {
+                    "description": "答案，必填，最大1000字符",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "question": {
+                    "description": "问题，必填，最大255字符",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.PresignImageUploadRequest": {
+            "type": "object",
+            "required": [
+                "filename"
+            ],
+            "properties": {
+                "filename": {
+                    "description": "Filename 待上传图片的原始文件名，只取其扩展名生成对象键，扩展名必须是支持的图片格式。",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "photo.jpg"
+                }
+            }
+        },
+        "dto.ReconcileResyncRequest": {
+            "type": "object",
+            "required": [
+                "post_ids",
+                "source"
+            ],
+            "properties": {
+                "post_ids": {
+                    "description": "需要重新同步的帖子 ID 列表，必填，最多 500 个",
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reason": {
+                    "description": "差异说明，可选（如 missing、stale）",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "source": {
+                    "description": "差异来源（下游服务名，如 search、recommend），必填",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "dto.RecordCoverClickRequest": {
+            "type": "object",
+            "required": [
+                "image_id"
+            ],
+            "properties": {
+                "image_id": {
+                    "description": "ImageID 用户点击时看到的封面图片ID（即列表响应中的 cover_image_id）。",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReportConversionRequest": {
+            "type": "object",
+            "required": [
+                "event_type"
+            ],
+            "properties": {
+                "event_type": {
+                    "description": "EventType 转化阶段：view=浏览详情, contact=联系作者, deal=成交。",
+                    "type": "string",
+                    "enum": [
+                        "view",
+                        "contact",
+                        "deal"
+                    ]
+                },
+                "source": {
+                    "description": "Source 浏览来源，只对 view 事件生效：timeline/hot/search/profile/share/other，省略时按 other 处理。\n- 联系与成交不需要传来源，服务端按该用户最近一次浏览的来源归因。",
+                    "type": "string",
+                    "enum": [
+                        "timeline",
+                        "hot",
+                        "search",
+                        "profile",
+                        "share",
+                        "other"
+                    ]
+                }
+            }
+        },
+        "dto.ReportPostRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "举报原因，必填，最多 255 个字符",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "虚假广告"
+                }
+            }
+        },
+        "dto.ReportReadDepthRequest": {
+            "type": "object",
+            "required": [
+                "depth",
+                "read_id"
+            ],
+            "properties": {
+                "depth": {
+                    "description": "Depth 用户滚动到的内容百分比，0-100。",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "read_id": {
+                    "description": "ReadID 客户端为每次打开帖子生成的阅读标识（如 UUID），同一次阅读的多次上报只保留最大深度。",
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "dto.SetCoverCandidatesRequest": {
+            "type": "object",
+            "properties": {
+                "image_ids": {
+                    "description": "ImageIDs 候选封面的图片ID列表，必须是该帖子已上传的图片。\n- 传空数组表示关闭 A/B 封面实验；非空时数量需在 constant.CoverCandidatesMin 与 constant.CoverCandidatesMax 之间。",
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.SubscribeTagRequest": {
+            "type": "object",
+            "required": [
+                "tag"
+            ],
+            "properties": {
+                "tag": {
+                    "description": "要订阅的官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)，必填",
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.UpdateOfficialTagRequest": {
+            "type": "object",
+            "required": [
+                "official_tag",
+                "post_id"
+            ],
+            "properties": {
+                "official_tag": {
+                    "description": "新的官方标签值，必填，并限制范围 (假设最大值为 3)",
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 0
+                },
+                "post_id": {
+                    "description": "帖子ID，必填",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.UpdatePostFAQsRequest": {
+            "type": "object",
+            "properties": {
+                "faqs": {
+                    "description": "FAQ 列表，最多20条",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/dto.PostFAQItem"
+                    }
+                }
+            }
+        },
+        "dto.UpdatePostRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "contact_info": {
+                    "description": "联系方式，可选",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "帖子内容，必填，最多1000个字符；按行分段，只有变更的段落会重新送审",
+                    "type": "string"
+                },
+                "price_per_unit": {
+                    "description": "单价，可选，大于等于0",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "title": {
+                    "description": "帖子标题，必填，最多100个字符",
+                    "type": "string"
+                }
+            }
+        },
+        "enums.OfficialTag": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-comments": {
+                "OfficialTagCertified": "官方认证",
+                "OfficialTagDeposit": "预付保证金",
+                "OfficialTagNone": "无标签",
+                "OfficialTagRapid": "急速响应"
+            },
+            "x-enum-varnames": [
+                "OfficialTagNone",
+                "OfficialTagCertified",
+                "OfficialTagDeposit",
                 "OfficialTagRapid"
             ]
         },
-        "enums.Status": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2
-            ],
-            "x-enum-comments": {
-                "Approved": "1 审核通过 - 对象已通过审核，变为有效或激活状态。",
-                "Pending": "0 待审核 - 对象已提交，等待处理。",
-                "Rejected": "2 拒绝 - 对象未通过审核，变为无效或非激活状态。"
-            },
-            "x-enum-varnames": [
-                "Pending",
-                "Approved",
-                "Rejected"
-            ]
+        "enums.Status": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-comments": {
+                "Approved": "1 审核通过 - 对象已通过审核，变为有效或激活状态。",
+                "Pending": "0 待审核 - 对象已提交，等待处理。",
+                "Rejected": "2 拒绝 - 对象未通过审核，变为无效或非激活状态。"
+            },
+            "x-enum-varnames": [
+                "Pending",
+                "Approved",
+                "Rejected"
+            ]
+        },
+        "health.DependencyStatus": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/health.DependencyStatus"
+                    }
+                },
+                "ready": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "vo.AdminAuditLogVO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作类型",
+                    "type": "string"
+                },
+                "admin_user_id": {
+                    "description": "操作人",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "操作时间",
+                    "type": "string"
+                },
+                "error_message": {
+                    "description": "失败原因或批量结果摘要",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "日志ID",
+                    "type": "integer"
+                },
+                "params": {
+                    "description": "操作参数 (JSON 字符串)",
+                    "type": "string"
+                },
+                "result": {
+                    "description": "操作结果 (success / failure / partial)",
+                    "type": "string"
+                },
+                "target_id": {
+                    "description": "目标 ID，批量操作为空",
+                    "type": "string"
+                },
+                "target_type": {
+                    "description": "目标类型 (post / post_batch)",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.AdminPostDetailResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.AdminPostDetailVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.AdminPostDetailVO": {
+            "type": "object",
+            "properties": {
+                "access_policy": {
+                    "description": "详情访问策略 (按位组合)",
+                    "type": "integer"
+                },
+                "audit_priority": {
+                    "description": "审核优先级 (0=普通, 1=高)",
+                    "type": "integer"
+                },
+                "audit_reason": {
+                    "description": "审核原因（拒绝原因等），未填写时为空",
+                    "type": "string"
+                },
+                "author_avatar": {
+                    "description": "作者头像URL",
+                    "type": "string"
+                },
+                "author_id": {
+                    "description": "作者ID",
+                    "type": "string"
+                },
+                "author_username": {
+                    "description": "作者用户名",
+                    "type": "string"
+                },
+                "contact_info": {
+                    "description": "联系方式",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "--- 来自 PostDetail 实体，详情缺失时为零值 ---",
+                    "type": "string"
+                },
+                "copyright_type": {
+                    "description": "版权声明类型 (0=原创, 1=转载, 2=禁止转载)",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "删除时间，未删除时为空",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "--- 来自 Post 实体 ---",
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "--- 来自 PostDetailImage 实体列表，按 DisplayOrder 排序 ---",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostImageVO"
+                    }
+                },
+                "is_deleted": {
+                    "description": "是否已被软删除",
+                    "type": "boolean"
+                },
+                "is_draft": {
+                    "description": "是否为草稿",
+                    "type": "boolean"
+                },
+                "like_count": {
+                    "description": "点赞数（MySQL 中的持久化值）",
+                    "type": "integer"
+                },
+                "official_tag": {
+                    "description": "主官方标签（帖子拥有的标签值最小者）",
+                    "type": "integer"
+                },
+                "official_tags": {
+                    "description": "帖子拥有的全部官方标签，按标签值升序",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "price_per_unit": {
+                    "description": "单价 (单位：元)",
+                    "type": "number"
+                },
+                "quoted_post_id": {
+                    "description": "转发的原帖ID，非转发帖为空",
+                    "type": "integer"
+                },
+                "repost_count": {
+                    "description": "被转发次数",
+                    "type": "integer"
+                },
+                "source_url": {
+                    "description": "转载来源地址",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "审核状态 (0=待审核, 1=已审核, 2=已拒绝)",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "帖子标题",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "view_count": {
+                    "description": "浏览量（MySQL 中的持久化值）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.AuthorBadgeVO": {
+            "type": "object",
+            "properties": {
+                "awarded_at": {
+                    "description": "颁发时间",
+                    "type": "string"
+                },
+                "badge_name": {
+                    "description": "徽章名称",
+                    "type": "string"
+                },
+                "milestone": {
+                    "description": "浏览量里程碑",
+                    "type": "integer"
+                },
+                "post_id": {
+                    "description": "达成里程碑的帖子ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.AuthorBadgesResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "作者徽章列表",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.AuthorBadgesVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.AuthorBadgesVO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "作者ID",
+                    "type": "string"
+                },
+                "badges": {
+                    "description": "徽章列表，按颁发时间倒序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.AuthorBadgeVO"
+                    }
+                }
+            }
+        },
+        "vo.BaseResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "成功时为 0, 错误时为具体错误码",
+                    "type": "integer",
+                    "example": 0
+                },
+                "message": {
+                    "description": "成功或错误消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.BatchAuditItemResultVO": {
+            "type": "object",
+            "properties": {
+                "error_type": {
+                    "description": "失败类型 (not_found / invalid_copyright / is_draft / internal_error)，成功时为空",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "失败详情，成功时为空",
+                    "type": "string"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "success": {
+                    "description": "是否处理成功",
+                    "type": "boolean"
+                }
+            }
+        },
+        "vo.BatchAuditResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.BatchAuditResultVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.BatchAuditResultVO": {
+            "type": "object",
+            "properties": {
+                "failure_count": {
+                    "description": "失败数量",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "每个帖子的处理结果，顺序与请求一致",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.BatchAuditItemResultVO"
+                    }
+                },
+                "success_count": {
+                    "description": "成功数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.BatchResultResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.BatchResultVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.BatchResultVO": {
+            "type": "object",
+            "properties": {
+                "affected_count": {
+                    "description": "实际更新的帖子数量",
+                    "type": "integer"
+                },
+                "not_found_ids": {
+                    "description": "不存在或已删除、未被更新的帖子ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "description": "请求中的帖子数量（去重后）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ConversionFunnelVO": {
+            "type": "object",
+            "properties": {
+                "contact_rate": {
+                    "description": "联系率，Contacts / Views，Views 为 0 时为 0",
+                    "type": "number"
+                },
+                "contacts": {
+                    "description": "联系人数",
+                    "type": "integer"
+                },
+                "deal_rate": {
+                    "description": "成交率，Deals / Contacts，Contacts 为 0 时为 0",
+                    "type": "number"
+                },
+                "deals": {
+                    "description": "成交人数",
+                    "type": "integer"
+                },
+                "overall_rate": {
+                    "description": "整体转化率，Deals / Views，Views 为 0 时为 0",
+                    "type": "number"
+                },
+                "views": {
+                    "description": "浏览人数（同一用户每天只计一次）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.DailyConversionVO": {
+            "type": "object",
+            "properties": {
+                "contact_rate": {
+                    "description": "联系率，Contacts / Views，Views 为 0 时为 0",
+                    "type": "number"
+                },
+                "contacts": {
+                    "description": "联系人数",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "日期 (YYYY-MM-DD，服务端时区)",
+                    "type": "string"
+                },
+                "deal_rate": {
+                    "description": "成交率，Deals / Contacts，Contacts 为 0 时为 0",
+                    "type": "number"
+                },
+                "deals": {
+                    "description": "成交人数",
+                    "type": "integer"
+                },
+                "overall_rate": {
+                    "description": "整体转化率，Deals / Views，Views 为 0 时为 0",
+                    "type": "number"
+                },
+                "views": {
+                    "description": "浏览人数（同一用户每天只计一次）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.DataReportVO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "生成时间",
+                    "type": "string"
+                },
+                "file_url": {
+                    "description": "报表文件下载地址",
+                    "type": "string"
+                },
+                "group_by": {
+                    "description": "分组维度 (none / official_tag)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "报表记录ID",
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "统计周期 (daily / weekly)",
+                    "type": "string"
+                },
+                "period_end": {
+                    "description": "统计区间结束时间",
+                    "type": "string"
+                },
+                "period_start": {
+                    "description": "统计区间起始时间",
+                    "type": "string"
+                },
+                "row_count": {
+                    "description": "报表行数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.DeleteConfirmRequiredResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 40001
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.DeleteConfirmRequiredVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "该帖子浏览量较高，请确认后带 confirm=true 重新提交删除"
+                }
+            }
+        },
+        "vo.DeleteConfirmRequiredVO": {
+            "type": "object",
+            "properties": {
+                "hot_rank": {
+                    "description": "热榜排名（0 开始），不在热榜时为空",
+                    "type": "integer"
+                },
+                "in_hot_list": {
+                    "description": "帖子当前是否在热榜中",
+                    "type": "boolean"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "view_count": {
+                    "description": "帖子浏览量（MySQL 中的持久化值，可能略低于实时值）",
+                    "type": "integer"
+                },
+                "view_count_threshold": {
+                    "description": "触发二次确认的浏览量阈值",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ListAdminAuditLogsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.ListAdminAuditLogsVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListAdminAuditLogsVO": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "description": "当前页的日志列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.AdminAuditLogVO"
+                    }
+                },
+                "total": {
+                    "description": "符合条件的总记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ListDataReportsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "报表列表分页数据",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.ListDataReportsVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListDataReportsVO": {
+            "type": "object",
+            "properties": {
+                "reports": {
+                    "description": "当前页的报表列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.DataReportVO"
+                    }
+                },
+                "total": {
+                    "description": "符合条件的总记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ListHotPostsByCursorResponse": {
+            "type": "object",
+            "properties": {
+                "cursor_reset": {
+                    "description": "CursorReset 为 true 表示请求的游标帖子已不在当前热榜中（热榜已重建），本页从估算位置或榜首重新开始，\n可能与已加载的帖子重复，客户端应按帖子 ID 去重。",
+                    "type": "boolean"
+                },
+                "degraded": {
+                    "description": "是否为 Redis 不可用时从数据库查询的近似热榜（排序可能与实时热榜不同）",
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "description": "下一个游标，nil 表示无更多数据",
+                    "type": "integer"
+                },
+                "posts": {
+                    "description": "帖子列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostResponse"
+                    }
+                }
+            }
+        },
+        "vo.ListPostAuditLogsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.ListPostAuditLogsVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListPostAuditLogsVO": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "description": "当前页的日志列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostAuditLogVO"
+                    }
+                },
+                "total": {
+                    "description": "该帖子的日志总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ListPostSnapshotsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.ListPostSnapshotsVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListPostSnapshotsVO": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "description": "实际查询的时间上限（不包含）",
+                    "type": "string"
+                },
+                "granularity": {
+                    "description": "快照粒度 (hourly / daily)",
+                    "type": "string"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "snapshots": {
+                    "description": "快照列表，按快照时间升序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostMetricSnapshotVO"
+                    }
+                },
+                "start_time": {
+                    "description": "实际查询的时间下限（包含）",
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "是否因超过 constant.SnapshotQueryMaxRows 被截断",
+                    "type": "boolean"
+                }
+            }
+        },
+        "vo.ListPostsAdminByConditionResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "NextCursor 下一页的游标，作为 cursor_id 传入即可继续翻页；没有更多数据或按更新时间排序时为空",
+                    "type": "integer"
+                },
+                "posts": {
+                    "description": "帖子列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostResponse"
+                    }
+                },
+                "total": {
+                    "description": "帖子总数；游标分页模式下不统计，固定为 -1",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ListPostsAdminResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "使用具体的 vo.ListPostsAdminByConditionResponse",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.ListPostsAdminByConditionResponse"
+                        }
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListPostsByCursorResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "使用具体的 vo.ListHotPostsByCursorResponse",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.ListHotPostsByCursorResponse"
+                        }
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListReportedPostsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.ListReportedPostsVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListReportedPostsVO": {
+            "type": "object",
+            "properties": {
+                "posts": {
+                    "description": "当前页的帖子，按举报数倒序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.ReportedPostVO"
+                    }
+                },
+                "total": {
+                    "description": "被举报的帖子总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ListUserPostPageResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "实际的用户帖子列表分页数据",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.ListUserPostPageVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.ListUserPostPageVO": {
+            "type": "object",
+            "properties": {
+                "posts": {
+                    "description": "当前页的帖子列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostResponse"
+                    }
+                },
+                "total": {
+                    "description": "符合条件的总记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.MergeAnonymousViewsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "归并结果",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.MergeAnonymousViewsVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.MergeAnonymousViewsVO": {
+            "type": "object",
+            "properties": {
+                "merged_posts": {
+                    "description": "本次补入用户去重记录的帖子数量（浏览量不变）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PopularPostsPageResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.PopularPostsPageVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PopularPostsPageVO": {
+            "type": "object",
+            "properties": {
+                "nextPostId": {
+                    "description": "下一页游标：最后一条记录的帖子ID",
+                    "type": "integer"
+                },
+                "nextViewCount": {
+                    "description": "下一页游标：最后一条记录的浏览量",
+                    "type": "integer"
+                },
+                "posts": {
+                    "description": "当前页的帖子摘要列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostResponse"
+                    }
+                }
+            }
+        },
+        "vo.PostAccessDeniedResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "错误码",
+                    "type": "integer",
+                    "example": 40301
+                },
+                "data": {
+                    "description": "访问策略与未通过的策略",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostAccessDeniedVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "错误消息",
+                    "type": "string",
+                    "example": "需要关注作者后才能查看"
+                }
+            }
+        },
+        "vo.PostAccessDeniedVO": {
+            "type": "object",
+            "properties": {
+                "access_policy": {
+                    "description": "帖子的访问策略 (按位组合)",
+                    "type": "integer"
+                },
+                "denied_policy": {
+                    "description": "本次未通过的策略名称: login (引导登录) / paid (引导付费) / follower (引导关注作者)",
+                    "type": "string"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "required_policies": {
+                    "description": "帖子要求的全部策略名称，如 [\"login\", \"paid\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "vo.PostAuditLogVO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作类型 (audit_post / update_official_tag / delete_post / restore_post)",
+                    "type": "string"
+                },
+                "admin_user_id": {
+                    "description": "操作人",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "操作时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "日志ID",
+                    "type": "integer"
+                },
+                "new_status": {
+                    "description": "操作后状态",
+                    "type": "integer"
+                },
+                "old_status": {
+                    "description": "操作前状态 (0=待审核, 1=已审核, 2=已拒绝)",
+                    "type": "integer"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "操作原因或说明",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.PostCardVO": {
+            "type": "object",
+            "properties": {
+                "author_avatar": {
+                    "description": "作者头像",
+                    "type": "string"
+                },
+                "author_id": {
+                    "description": "作者ID",
+                    "type": "string"
+                },
+                "author_username": {
+                    "description": "作者用户名",
+                    "type": "string"
+                },
+                "cover_image_id": {
+                    "description": "A/B 封面实验中分配给当前用户的封面图片ID，未开启实验时省略",
+                    "type": "integer"
+                },
+                "cover_image_url": {
+                    "description": "列表封面图片URL：A/B 封面实验中分配给当前用户的封面，未开启实验时为首图，无图帖子省略",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "official_tag": {
+                    "description": "主官方标签（帖子拥有的标签值最小者，0=无），兼容只认识单个标签的客户端",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.OfficialTag"
+                        }
+                    ]
+                },
+                "official_tags": {
+                    "description": "帖子拥有的全部官方标签，按标签值升序",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "similar_posts": {
+                    "description": "SimilarPosts 时间线开启相似折叠时，被折叠到该代表帖下的内容相似帖子；未折叠时省略",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostCardVO"
+                    }
+                },
+                "title": {
+                    "description": "帖子标题",
+                    "type": "string"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostConversionResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "转化归因分析",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostConversionVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostConversionVO": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "按天的漏斗，按日期升序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.DailyConversionVO"
+                    }
+                },
+                "days": {
+                    "description": "统计的最近天数（含当天）",
+                    "type": "integer"
+                },
+                "funnel": {
+                    "description": "统计期内的汇总漏斗",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.ConversionFunnelVO"
+                        }
+                    ]
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "sources": {
+                    "description": "按浏览来源拆分的漏斗，按浏览人数降序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.SourceConversionVO"
+                    }
+                },
+                "total_views": {
+                    "description": "帖子累计浏览量（浏览计数器的实时值，包含未上报转化事件的浏览）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostCoverStatsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "各候选封面的表现统计",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostCoverStatsVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostCoverStatsVO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "实验是否生效（候选封面达到最少数量）",
+                    "type": "boolean"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "variants": {
+                    "description": "各候选封面的表现，按展示顺序排列",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostCoverVariantStatsVO"
+                    }
+                }
+            }
+        },
+        "vo.PostCoverVariantStatsVO": {
+            "type": "object",
+            "properties": {
+                "click_through_rate": {
+                    "description": "点击率 (clicks / impressions)，无曝光时为 0",
+                    "type": "number"
+                },
+                "clicks": {
+                    "description": "点击数",
+                    "type": "integer"
+                },
+                "image_id": {
+                    "description": "候选封面图片ID",
+                    "type": "integer"
+                },
+                "image_url": {
+                    "description": "候选封面图片URL",
+                    "type": "string"
+                },
+                "impressions": {
+                    "description": "曝光数（登录用户在公开列表中看到该封面的次数）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostDetailResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.PostDetailVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostDetailVO": {
+            "type": "object",
+            "properties": {
+                "access_policy": {
+                    "description": "详情访问策略 (按位组合: 1=需登录, 2=需付费, 4=需关注作者, 0=公开)",
+                    "type": "integer"
+                },
+                "audit_reason": {
+                    "description": "审核原因（通常为拒绝原因），仅作者本人查看时返回",
+                    "type": "string"
+                },
+                "author_avatar": {
+                    "description": "作者头像URL",
+                    "type": "string"
+                },
+                "author_id": {
+                    "description": "作者ID",
+                    "type": "string"
+                },
+                "author_username": {
+                    "description": "作者用户名",
+                    "type": "string"
+                },
+                "contact_info": {
+                    "description": "联系方式 (手机号、微信号、QQ号等)",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "--- 来自 PostDetail 实体 ---",
+                    "type": "string"
+                },
+                "copyright_type": {
+                    "description": "版权声明类型 (0=原创, 1=转载, 2=禁止转载)",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "faqs": {
+                    "description": "--- 来自 PostFAQ 实体列表 ---\nFAQs 字段存储了帖子的常见问题解答，已按 DisplayOrder 排序。",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostFAQVO"
+                    }
+                },
+                "id": {
+                    "description": "--- 来自 Post 实体 ---",
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "--- 来自 PostDetailImage 实体列表 ---\nImages 字段存储了帖子的所有详情图片，并已按 DisplayOrder 排序。",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostImageVO"
+                    }
+                },
+                "is_draft": {
+                    "description": "是否为草稿，只有作者本人能看到草稿",
+                    "type": "boolean"
+                },
+                "like_count": {
+                    "description": "点赞数（MySQL 中的持久化值，定时同步，可能略低于实时值）",
+                    "type": "integer"
+                },
+                "official_tag": {
+                    "description": "主官方标签（帖子拥有的标签值最小者，参考 enums.OfficialTag），兼容只认识单个标签的客户端",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.OfficialTag"
+                        }
+                    ]
+                },
+                "official_tags": {
+                    "description": "帖子拥有的全部官方标签，按标签值升序",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "price_per_unit": {
+                    "description": "单价 (单位：元)",
+                    "type": "number"
+                },
+                "quoted_post": {
+                    "description": "--- 转发帖引用的原帖卡片，非转发帖为 nil ---",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.QuotedPostVO"
+                        }
+                    ]
+                },
+                "repost_count": {
+                    "description": "被转发次数",
+                    "type": "integer"
+                },
+                "source_url": {
+                    "description": "转载来源地址，非转载帖为空",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "审核状态 (0=待审核, 1=已审核, 2=已拒绝)，新建帖子为待审核；前端可据此二次校验",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.Status"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "帖子标题",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostFAQVO": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "description": "答案",
+                    "type": "string"
+                },
+                "display_order": {
+                    "description": "展示顺序",
+                    "type": "integer"
+                },
+                "question": {
+                    "description": "问题",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.PostFAQsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostFAQVO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostImageVO": {
+            "type": "object",
+            "properties": {
+                "display_order": {
+                    "description": "图片展示顺序",
+                    "type": "integer"
+                },
+                "image_url": {
+                    "description": "图片URL",
+                    "type": "string"
+                },
+                "object_key": {
+                    "description": "图片在COS中的ObjectKey",
+                    "type": "string"
+                },
+                "original_image_url": {
+                    "description": "原图URL，仅在上传时保留了原图才有值，用于下载原图",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.PostLikeResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.PostLikeVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostLikeVO": {
+            "type": "object",
+            "properties": {
+                "like_count": {
+                    "description": "操作后的实时点赞数",
+                    "type": "integer"
+                },
+                "liked": {
+                    "description": "当前用户操作后是否处于已点赞状态",
+                    "type": "boolean"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostMetricSnapshotVO": {
+            "type": "object",
+            "properties": {
+                "like_count": {
+                    "description": "点赞数（定时同步的持久化值）",
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "全站排行榜排名（0 开始），不在排行榜中时为空",
+                    "type": "integer"
+                },
+                "snapshot_at": {
+                    "description": "快照时刻",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "审核状态 (0=待审核, 1=已审核, 2=已拒绝)",
+                    "type": "integer"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostMilestoneProgressResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "里程碑进度",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostMilestoneProgressVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostMilestoneProgressVO": {
+            "type": "object",
+            "properties": {
+                "achieved": {
+                    "description": "已颁发的里程碑徽章，按里程碑升序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.AuthorBadgeVO"
+                    }
+                },
+                "next_badge_name": {
+                    "description": "下一个里程碑的徽章名称",
+                    "type": "string"
+                },
+                "next_milestone": {
+                    "description": "下一个里程碑，已全部达成时为空",
+                    "type": "integer"
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "progress": {
+                    "description": "距下一个里程碑的进度 (0~1)，已全部达成时为 1",
+                    "type": "number"
+                },
+                "view_count": {
+                    "description": "已同步的浏览量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostReadDepthResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "阅读深度统计",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostReadDepthVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostReadDepthVO": {
+            "type": "object",
+            "properties": {
+                "average_depth": {
+                    "description": "平均阅读深度（百分比，0-100）",
+                    "type": "number"
+                },
+                "completed_reads": {
+                    "description": "读完（深度 100）的阅读次数",
+                    "type": "integer"
+                },
+                "completion_rate": {
+                    "description": "读完率，CompletedReads / Reads",
+                    "type": "number"
+                },
+                "distribution": {
+                    "description": "深度分布，按深度升序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.ReadDepthBucketVO"
+                    }
+                },
+                "post_id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "reads": {
+                    "description": "阅读次数（同一用户的同一次阅读只计一次）",
+                    "type": "integer"
+                }
+            }
         },
-        "vo.BaseResponseWrapper": {
+        "vo.PostResponse": {
+            "type": "object",
+            "properties": {
+                "appeal_count": {
+                    "description": "作者对被拒帖子的累计申诉次数",
+                    "type": "integer"
+                },
+                "audit_reason": {
+                    "description": "审核原因 (如果 Status 为拒绝，则可能包含原因)",
+                    "type": "string"
+                },
+                "author_avatar": {
+                    "description": "作者头像",
+                    "type": "string"
+                },
+                "author_id": {
+                    "description": "作者ID",
+                    "type": "string"
+                },
+                "author_username": {
+                    "description": "作者用户名",
+                    "type": "string"
+                },
+                "copyright_type": {
+                    "description": "版权声明类型 (0=原创, 1=转载, 2=禁止转载)",
+                    "type": "integer"
+                },
+                "cover_image_id": {
+                    "description": "A/B 封面实验中分配给当前用户的封面图片ID，未开启实验时省略",
+                    "type": "integer"
+                },
+                "cover_image_url": {
+                    "description": "列表封面图片URL：A/B 封面实验中分配给当前用户的封面，未开启实验时为首图，无图帖子省略",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "帖子ID",
+                    "type": "integer"
+                },
+                "is_draft": {
+                    "description": "是否为草稿（草稿的状态为待审核，但尚未送审）",
+                    "type": "boolean"
+                },
+                "like_count": {
+                    "description": "点赞数（MySQL 中的持久化值，定时同步，可能略低于实时值）",
+                    "type": "integer"
+                },
+                "official_tag": {
+                    "description": "主官方标签（帖子拥有的标签值最小者，0=无），兼容只认识单个标签的客户端",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.OfficialTag"
+                        }
+                    ]
+                },
+                "official_tags": {
+                    "description": "帖子拥有的全部官方标签，按标签值升序",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quoted_post_id": {
+                    "description": "转发的原帖ID，非转发帖为 null",
+                    "type": "integer"
+                },
+                "repost_count": {
+                    "description": "被转发次数",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "帖子状态，0=待审核, 1=已审核, 2=拒绝",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.Status"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "帖子标题",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostSEOResponseWrapper": {
             "type": "object",
             "properties": {
                 "code": {
-                    "description": "成功时为 0, 错误时为具体错误码",
                     "type": "integer",
                     "example": 0
                 },
+                "data": {
+                    "$ref": "#/definitions/vo.PostSEOVO"
+                },
                 "message": {
-                    "description": "成功或错误消息",
                     "type": "string",
                     "example": "success"
                 }
             }
         },
-        "vo.ListHotPostsByCursorResponse": {
+        "vo.PostSEOVO": {
             "type": "object",
             "properties": {
-                "next_cursor": {
-                    "description": "下一个游标，nil 表示无更多数据",
+                "description": {
+                    "description": "meta description，最长 constant.SEODescriptionMaxRunes 个字符",
+                    "type": "string"
+                },
+                "keywords": {
+                    "description": "meta keywords",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meta_tags": {
+                    "description": "已转义的 HTML 标签片段",
+                    "type": "string"
+                },
+                "post_id": {
+                    "description": "帖子 ID",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "页面标题",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.PostStatsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/vo.PostStatsVO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostStatsVO": {
+            "type": "object",
+            "properties": {
+                "by_official_tag": {
+                    "description": "各官方标签的帖子数量（含草稿），只返回数量大于 0 的标签",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostTagCountVO"
+                    }
+                },
+                "by_status": {
+                    "description": "各审核状态的帖子数量（不含草稿），每个状态都会返回",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.PostStatusCountVO"
+                    }
+                },
+                "drafts": {
+                    "description": "草稿数量，不计入 by_status",
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "description": "统计生成时间",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "帖子总数（含草稿）",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.PostStatusCountVO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "帖子数量",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "审核状态 (0=待审核, 1=已审核, 2=已拒绝)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.Status"
+                        }
+                    ]
+                }
+            }
+        },
+        "vo.PostTagCountVO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "帖子数量",
+                    "type": "integer"
+                },
+                "official_tag": {
+                    "description": "官方标签 (0=无标签)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/enums.OfficialTag"
+                        }
+                    ]
+                }
+            }
+        },
+        "vo.PostTimelineCardPageResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "实际的帖子卡片分页数据",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostTimelineCardPageVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostTimelineCardPageVO": {
+            "type": "object",
+            "properties": {
+                "nextCreatedAt": {
+                    "description": "下一页最后一条记录的创建时间（已废弃，仅为兼容保留，翻页只需 nextPostId）",
+                    "type": "string"
+                },
+                "nextPostId": {
+                    "description": "下一页游标：帖子ID，如果为nil表示没有下一页",
                     "type": "integer"
                 },
                 "posts": {
-                    "description": "帖子列表",
+                    "description": "当前页的帖子卡片列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/vo.PostResponse"
+                        "$ref": "#/definitions/vo.PostCardVO"
                     }
                 }
             }
         },
-        "vo.ListPostsAdminByConditionResponse": {
+        "vo.PostTimelinePageResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "description": "实际的帖子时间线分页数据",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.PostTimelinePageVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.PostTimelinePageVO": {
             "type": "object",
             "properties": {
+                "nextCreatedAt": {
+                    "description": "下一页最后一条记录的创建时间（已废弃，仅为兼容保留，翻页只需 nextPostId）",
+                    "type": "string"
+                },
+                "nextPostId": {
+                    "description": "下一页游标：帖子ID，如果为nil表示没有下一页",
+                    "type": "integer"
+                },
                 "posts": {
-                    "description": "帖子列表",
+                    "description": "当前页的帖子摘要列表",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/vo.PostResponse"
                     }
-                },
-                "total": {
-                    "description": "帖子总数",
-                    "type": "integer"
                 }
             }
         },
-        "vo.ListPostsAdminResponseWrapper": {
+        "vo.PresignedImageUploadResponseWrapper": {
             "type": "object",
             "properties": {
                 "code": {
+                    "description": "响应码，0 表示成功",
                     "type": "integer",
                     "example": 0
                 },
                 "data": {
-                    "description": "使用具体的 vo.ListPostsAdminByConditionResponse",
+                    "description": "对象键与上传 URL",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/vo.ListPostsAdminByConditionResponse"
+                            "$ref": "#/definitions/vo.PresignedImageUploadVO"
                         }
                     ]
                 },
                 "message": {
+                    "description": "响应消息",
                     "type": "string",
                     "example": "success"
                 }
             }
         },
-        "vo.ListPostsByCursorResponseWrapper": {
+        "vo.PresignedImageUploadVO": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "上传 URL 的过期时间",
+                    "type": "string"
+                },
+                "object_key": {
+                    "description": "对象键，上传完成后在创建帖子时通过 image_object_keys 提交",
+                    "type": "string"
+                },
+                "upload_url": {
+                    "description": "预签名上传 URL，客户端以 PUT 方法上传图片内容，并设置图片的 Content-Type",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.QuotedPostVO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "原帖作者ID（快照）",
+                    "type": "string"
+                },
+                "author_username": {
+                    "description": "原帖作者用户名（快照）",
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "原帖是否已被删除",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "原帖ID",
+                    "type": "integer"
+                },
+                "notice": {
+                    "description": "原帖不可见时的提示语，例如“原帖已删除”",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "原帖标题（快照）",
+                    "type": "string"
+                }
+            }
+        },
+        "vo.ReadDepthBucketVO": {
+            "type": "object",
+            "properties": {
+                "max_depth": {
+                    "description": "区间上限（包含）",
+                    "type": "integer"
+                },
+                "min_depth": {
+                    "description": "区间下限（包含）",
+                    "type": "integer"
+                },
+                "reads": {
+                    "description": "最大深度落在该区间的阅读次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.RecentViewsResponseWrapper": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1024,12 +6393,7 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "description": "使用具体的 vo.ListHotPostsByCursorResponse",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/vo.ListHotPostsByCursorResponse"
-                        }
-                    ]
+                    "$ref": "#/definitions/vo.RecentViewsVO"
                 },
                 "message": {
                     "type": "string",
@@ -1037,7 +6401,20 @@ const docTemplate = `{
                 }
             }
         },
-        "vo.ListUserPostPageResponseWrapper": {
+        "vo.RecentViewsVO": {
+            "type": "object",
+            "properties": {
+                "minutes": {
+                    "description": "统计窗口（分钟），包含当前未结束的分钟",
+                    "type": "integer"
+                },
+                "view_count": {
+                    "description": "窗口内的全站浏览量",
+                    "type": "integer"
+                }
+            }
+        },
+        "vo.ReconcileResyncResponseWrapper": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1046,10 +6423,10 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "description": "实际的用户帖子列表分页数据",
+                    "description": "各帖子的处理结果",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/vo.ListUserPostPageVO"
+                            "$ref": "#/definitions/vo.ReconcileResyncResultVO"
                         }
                     ]
                 },
@@ -1060,45 +6437,35 @@ const docTemplate = `{
                 }
             }
         },
-        "vo.ListUserPostPageVO": {
+        "vo.ReconcileResyncResultVO": {
             "type": "object",
             "properties": {
-                "posts": {
-                    "description": "当前页的帖子列表",
+                "failed_post_ids": {
+                    "description": "事件发送失败的帖子，可稍后重试",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/vo.PostResponse"
+                        "type": "integer"
                     }
                 },
-                "total": {
-                    "description": "符合条件的总记录数",
-                    "type": "integer"
-                }
-            }
-        },
-        "vo.PostDetailResponseWrapper": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "data": {
-                    "$ref": "#/definitions/vo.PostDetailVO"
+                "removed_post_ids": {
+                    "description": "重新发送删除事件的帖子（已删除、不存在或未审核通过）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "message": {
-                    "type": "string",
-                    "example": "success"
+                "upserted_post_ids": {
+                    "description": "重新发送审核通过事件的帖子",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "vo.PostDetailVO": {
+        "vo.ReportedPostVO": {
             "type": "object",
             "properties": {
-                "author_avatar": {
-                    "description": "作者头像URL",
-                    "type": "string"
-                },
                 "author_id": {
                     "description": "作者ID",
                     "type": "string"
@@ -1107,109 +6474,57 @@ const docTemplate = `{
                     "description": "作者用户名",
                     "type": "string"
                 },
-                "contact_info": {
-                    "description": "联系方式 (手机号、微信号、QQ号等)",
-                    "type": "string"
-                },
-                "content": {
-                    "description": "--- 来自 PostDetail 实体 ---",
-                    "type": "string"
-                },
-                "created_at": {
-                    "description": "创建时间",
+                "last_reported_at": {
+                    "description": "最近一次被举报的时间",
                     "type": "string"
                 },
-                "id": {
-                    "description": "--- 来自 Post 实体 ---",
+                "post_id": {
+                    "description": "帖子ID",
                     "type": "integer"
                 },
-                "images": {
-                    "description": "--- 来自 PostDetailImage 实体列表 ---\nImages 字段存储了帖子的所有详情图片，并已按 DisplayOrder 排序。",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/vo.PostImageVO"
-                    }
-                },
-                "official_tag": {
-                    "description": "官方标签 (参考 enums.OfficialTag)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/enums.OfficialTag"
-                        }
-                    ]
+                "report_count": {
+                    "description": "举报数",
+                    "type": "integer"
                 },
-                "price_per_unit": {
-                    "description": "单价 (单位：元)",
-                    "type": "number"
+                "status": {
+                    "description": "帖子当前状态 (0=待审核, 1=已审核, 2=已拒绝)",
+                    "type": "integer"
                 },
                 "title": {
                     "description": "帖子标题",
                     "type": "string"
-                },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
-                },
-                "view_count": {
-                    "description": "浏览量",
-                    "type": "integer"
-                }
-            }
-        },
-        "vo.PostImageVO": {
-            "type": "object",
-            "properties": {
-                "display_order": {
-                    "description": "图片展示顺序",
-                    "type": "integer"
-                },
-                "image_url": {
-                    "description": "图片URL",
-                    "type": "string"
-                },
-                "object_key": {
-                    "description": "图片在COS中的ObjectKey",
-                    "type": "string"
                 }
             }
         },
-        "vo.PostResponse": {
+        "vo.SimilarPostVO": {
             "type": "object",
             "properties": {
-                "audit_reason": {
-                    "description": "审核原因 (如果 Status 为拒绝，则可能包含原因)",
-                    "type": "string"
-                },
-                "author_avatar": {
-                    "description": "作者头像",
-                    "type": "string"
-                },
                 "author_id": {
-                    "description": "作者ID",
+                    "description": "已有帖子作者ID",
                     "type": "string"
                 },
                 "author_username": {
-                    "description": "作者用户名",
+                    "description": "已有帖子作者用户名",
                     "type": "string"
                 },
                 "created_at": {
-                    "description": "创建时间",
+                    "description": "已有帖子创建时间",
                     "type": "string"
                 },
-                "id": {
-                    "description": "帖子ID",
+                "distance": {
+                    "description": "与待发布内容 SimHash 指纹的汉明距离，越小越相似",
                     "type": "integer"
                 },
-                "official_tag": {
-                    "description": "官方标签 (0=无, 1=官方认证, ...)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/enums.OfficialTag"
-                        }
-                    ]
+                "is_own_post": {
+                    "description": "是否为作者本人的帖子",
+                    "type": "boolean"
+                },
+                "post_id": {
+                    "description": "已有帖子ID，引用时作为 quoted_post_id 提交",
+                    "type": "integer"
                 },
                 "status": {
-                    "description": "帖子状态，0=待审核, 1=已审核, 2=拒绝",
+                    "description": "审核状态，作者本人的帖子可能仍在待审核",
                     "allOf": [
                         {
                             "$ref": "#/definitions/enums.Status"
@@ -1217,20 +6532,12 @@ const docTemplate = `{
                     ]
                 },
                 "title": {
-                    "description": "帖子标题",
-                    "type": "string"
-                },
-                "updated_at": {
-                    "description": "更新时间",
+                    "description": "已有帖子标题",
                     "type": "string"
-                },
-                "view_count": {
-                    "description": "浏览量",
-                    "type": "integer"
                 }
             }
         },
-        "vo.PostTimelinePageResponseWrapper": {
+        "vo.SimilarPostsResponseWrapper": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1239,10 +6546,10 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "description": "实际的帖子时间线分页数据",
+                    "description": "相似帖子列表",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/vo.PostTimelinePageVO"
+                            "$ref": "#/definitions/vo.SimilarPostsVO"
                         }
                     ]
                 },
@@ -1253,22 +6560,82 @@ const docTemplate = `{
                 }
             }
         },
-        "vo.PostTimelinePageVO": {
+        "vo.SimilarPostsVO": {
             "type": "object",
             "properties": {
-                "nextCreatedAt": {
-                    "description": "下一页游标：创建时间，如果为nil表示没有下一页",
+                "similar": {
+                    "description": "按相似度从高到低排列",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vo.SimilarPostVO"
+                    }
+                }
+            }
+        },
+        "vo.SourceConversionVO": {
+            "type": "object",
+            "properties": {
+                "contact_rate": {
+                    "description": "联系率，Contacts / Views，Views 为 0 时为 0",
+                    "type": "number"
+                },
+                "contacts": {
+                    "description": "联系人数",
+                    "type": "integer"
+                },
+                "deal_rate": {
+                    "description": "成交率，Deals / Contacts，Contacts 为 0 时为 0",
+                    "type": "number"
+                },
+                "deals": {
+                    "description": "成交人数",
+                    "type": "integer"
+                },
+                "overall_rate": {
+                    "description": "整体转化率，Deals / Views，Views 为 0 时为 0",
+                    "type": "number"
+                },
+                "source": {
+                    "description": "浏览来源 (timeline/hot/search/profile/share/other)，direct 表示归因窗口内没有浏览记录",
                     "type": "string"
                 },
-                "nextPostId": {
-                    "description": "下一页游标：帖子ID，如果为nil表示没有下一页",
+                "views": {
+                    "description": "浏览人数（同一用户每天只计一次）",
                     "type": "integer"
+                }
+            }
+        },
+        "vo.TagSubscriptionsResponseWrapper": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "响应码，0 表示成功",
+                    "type": "integer",
+                    "example": 0
                 },
-                "posts": {
-                    "description": "当前页的帖子摘要列表",
+                "data": {
+                    "description": "已订阅的标签列表",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/vo.TagSubscriptionsVO"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "响应消息",
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "vo.TagSubscriptionsVO": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "description": "已订阅的官方标签，按标签值升序",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/vo.PostResponse"
+                        "type": "integer"
                     }
                 }
             }
@@ -0,0 +1,134 @@
+// Package health 提供进程存活与依赖就绪检查，供 K8s liveness / readiness 探针使用。
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/mq/producer"
+)
+
+// Dependency 描述一个需要探活的外部依赖。
+// - Critical 为 true 的依赖不可用时服务判定为未就绪；非关键依赖只在结果中展示状态。
+type Dependency struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+// DependencyStatus 是单个依赖的探活结果。
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Critical  bool   `json:"critical"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Report 是一次就绪检查的结果，Dependencies 的顺序与注册顺序一致。
+type Report struct {
+	Ready        bool               `json:"ready"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// HealthChecker 并发探测所有已注册的依赖。
+type HealthChecker struct {
+	dependencies []Dependency
+	timeout      time.Duration
+	logger       *core.ZapLogger
+}
+
+// NewHealthChecker 创建依赖探活器，timeout 是一次检查中全部探测共享的超时。
+func NewHealthChecker(timeout time.Duration, logger *core.ZapLogger, dependencies ...Dependency) *HealthChecker {
+	return &HealthChecker{
+		dependencies: dependencies,
+		timeout:      timeout,
+		logger:       logger,
+	}
+}
+
+// Check 并发探测所有依赖，任一关键依赖不可用时 Report.Ready 为 false。
+func (h *HealthChecker) Check(ctx context.Context) *Report {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	report := &Report{Ready: true, Dependencies: make([]DependencyStatus, len(h.dependencies))}
+	var wg sync.WaitGroup
+	for i, dep := range h.dependencies {
+		wg.Add(1)
+		go func(i int, dep Dependency) {
+			defer wg.Done()
+			startTime := time.Now()
+			err := dep.Probe(ctx)
+			status := DependencyStatus{
+				Name:      dep.Name,
+				Critical:  dep.Critical,
+				Healthy:   err == nil,
+				LatencyMs: time.Since(startTime).Milliseconds(),
+			}
+			if err != nil {
+				status.Error = err.Error()
+			}
+			report.Dependencies[i] = status
+		}(i, dep)
+	}
+	wg.Wait()
+
+	for _, status := range report.Dependencies {
+		if status.Healthy {
+			continue
+		}
+		if status.Critical {
+			report.Ready = false
+		}
+		h.logger.Warn("依赖探活失败", zap.String("dependency", status.Name), zap.Bool("critical", status.Critical), zap.String("error", status.Error))
+	}
+	return report
+}
+
+// MySQLDependency 通过主库连接池 PingContext 探测 MySQL。
+func MySQLDependency(db *gorm.DB) Dependency {
+	return Dependency{Name: "mysql", Critical: true, Probe: func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("获取 MySQL 连接池失败: %w", err)
+		}
+		return sqlDB.PingContext(ctx)
+	}}
+}
+
+// RedisDependency 通过 PING 探测 Redis。
+func RedisDependency(rdb *redis.Client) Dependency {
+	return Dependency{Name: "redis", Critical: true, Probe: func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	}}
+}
+
+// KafkaDependency 探测 Kafka 生产者配置的 broker 是否可连接。
+// - 未配置 Kafka（kafkaProducer 为 nil）时视为不可用，调用方应在未配置时不注册该依赖。
+func KafkaDependency(kafkaProducer *producer.KafkaProducer) Dependency {
+	return Dependency{Name: "kafka", Critical: true, Probe: func(ctx context.Context) error {
+		if kafkaProducer == nil {
+			return errors.New("未配置 Kafka 生产者")
+		}
+		return kafkaProducer.Ping(ctx)
+	}}
+}
+
+// COSDependency 通过 HEAD Bucket 探测 COS。
+// - 作为非关键依赖：COS 不可用只影响带图帖子的创建，不应让整个实例下线。
+func COSDependency(cosClient dependencies.COSClientInterface) Dependency {
+	return Dependency{Name: "cos", Critical: false, Probe: func(ctx context.Context) error {
+		_, err := cosClient.GetClient().Bucket.Head(ctx)
+		return err
+	}}
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/controller"
 	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/health"
+	"github.com/Xushengqwer/post_service/metrics"
 	// "post_service/middleware" // middleware 在 router 中使用
 	// "post_service/models/entities"
 	"github.com/Xushengqwer/post_service/mq/consumer"
@@ -35,6 +37,7 @@ import (
 	sharedCore "github.com/Xushengqwer/go-common/core"
 	sharedTracing "github.com/Xushengqwer/go-common/core/tracing"
 
+	"github.com/gin-gonic/gin"
 	// 导入 OTel HTTP Client Instrumentation
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	// 导入 Zap
@@ -157,36 +160,130 @@ func main() {
 
 	// --- 5. 初始化数据仓库层 (Repositories) ---
 	postRepo := mysql.NewPostRepository(db, logger)
+	postReportRepo := mysql.NewPostReportRepository(db, logger)
 	postDetailRepo := mysql.NewPostDetailRepository(db)
 	postAdminRepo := mysql.NewPostAdminRepository(db, logger)
 	postBatchRepo := mysql.NewPostBatchOperationsRepository(db, logger, cfg.ViewSyncConfig)
 	postDetailImageRepo := mysql.NewPostDetailImageRepository(db)
+	postTargetingRepo := mysql.NewPostTargetingRepository(db, logger)
+	postFAQRepo := mysql.NewPostFAQRepository(db, logger)
+	dataReportRepo := mysql.NewDataReportRepository(db, logger)
+	adminAuditLogRepo := mysql.NewAdminAuditLogRepository(db, logger)
+	postAuditLogRepo := mysql.NewPostAuditLogRepository(db, logger)
+	postContentSegmentRepo := mysql.NewPostContentSegmentRepository(db, logger)
+	tagSubscriptionRepo := mysql.NewTagSubscriptionRepository(db, logger)
+	outboxRepo := mysql.NewOutboxRepository(db, logger)
+	authorBadgeRepo := mysql.NewAuthorBadgeRepository(db, logger)
+	postSnapshotRepo := mysql.NewPostSnapshotRepository(db, logger)
 
 	logger.Debug("MySQL Repositories 初始化完成")
 
 	postViewRepo := redisrepo.NewPostViewRepository(
 		rdb,
+		postBatchRepo,
 		logger,
 		constant.BloomFilterDefaultSize, // 使用常量
 		constant.BloomFilterDefaultHashes,
 		constant.BloomFilterDefaultErrorRate,
 		cfg.ViewSyncConfig,
+		cfg.ViewCountConfig,
 	)
-	cacheRepo := redisrepo.NewCache(postViewRepo, postBatchRepo, rdb, logger)
-	taskRepo := redisrepo.NewPostTaskCacheImpl(rdb, logger, postBatchRepo)
+	postLikeRepo := redisrepo.NewPostLikeRepository(rdb, postBatchRepo, logger)
+	coverExperimentRepo := redisrepo.NewCoverExperimentRepository(rdb, logger)
+	cosDeleteQueue := redisrepo.NewCOSDeleteQueue(rdb, logger)
+	readDepthRepo := redisrepo.NewPostReadDepthRepository(rdb, logger)
+	idempotencyStore := redisrepo.NewIdempotencyStore(rdb, logger)
+	conversionRepo := redisrepo.NewPostConversionRepository(rdb, logger)
+	cacheRepo := redisrepo.NewPostReadCache(postBatchRepo, rdb, logger)
+	taskRepo := redisrepo.NewPostTaskCache(rdb, logger, postBatchRepo)
+	// 定向条件创建后不再修改，详情与热榜等读路径统一经 Redis 读穿缓存访问，不再每次请求查询 MySQL
+	postTargetingRepo = redisrepo.NewCachedPostTargetingRepository(postTargetingRepo, rdb, logger)
 	logger.Debug("Redis Repositories 初始化完成")
 
 	// --- 6. 初始化服务层 (Services) ---
-	postService := service.NewPostService(db, postRepo, postDetailRepo, postDetailImageRepo, cos, postViewRepo, kafkaProducer, logger)
-	hotPostService := service.NewHotPostService(cacheRepo, postViewRepo, logger)
-	postAdminService := service.NewPostAdminService(postAdminRepo, postRepo, postDetailRepo, logger, db, kafkaProducer)
-	postListService := service.NewPostListService(logger, postRepo)
+	// 帖子详情访问鉴权钩子链：当前部署只接入“需登录”策略；需付费/需关注策略在接入支付、用户关系服务的客户端后
+	// 通过 service.NewPaidAccessHook / service.NewFollowerAccessHook 注册，未注册的策略在创建帖子时会被拒绝。
+	accessGuard := service.NewPostAccessGuard(logger, service.NewLoginRequiredHook())
+	// 地区合规预检器由用户创建/发布与管理员恢复送审共用，规则库按地区在 contentComplianceConfig 中配置
+	complianceChecker := service.NewContentComplianceChecker(cfg.ContentCompliance)
+	// 浏览增量按帖子在进程内聚合后批量写入 Redis，详情页与热门详情共用同一个聚合器
+	// 任务运行指标（成功/失败次数、耗时、最后成功时间），通过 /metrics 以 Prometheus 文本格式暴露；浏览增量聚合器也上报丢弃计数
+	metricsReporter := metrics.NewTextReporter()
+	viewCountAggregator := service.NewViewCountAggregator(postViewRepo, cfg.ViewCountConfig.AggregateWindow, metricsReporter, logger)
+	postService := service.NewPostService(service.PostServiceDeps{
+		DB:                  db,
+		PostRepo:            postRepo,
+		PostDetailRepo:      postDetailRepo,
+		PostDetailImageRepo: postDetailImageRepo,
+		PostTargetingRepo:   postTargetingRepo,
+		PostFAQRepo:         postFAQRepo,
+		PostReportRepo:      postReportRepo,
+		PostAuditRepo:       postAuditLogRepo,
+		SegmentRepo:         postContentSegmentRepo,
+		COSClient:           cos,
+		COSDeleteQueue:      cosDeleteQueue,
+		PostViewRepo:        postViewRepo,
+		ViewAggregator:      viewCountAggregator,
+		PostLikeRepo:        postLikeRepo,
+		PostCache:           cacheRepo,
+		KafkaSvc:            kafkaProducer,
+		OutboxRepo:          outboxRepo,
+		AuditPriorityCfg:    cfg.AuditPriority,
+		AccessGuard:         accessGuard,
+		ContentSanitizer:    service.NewContentSanitizer(cfg.ContentSanitize),
+		ComplianceChecker:   complianceChecker,
+		ImageUploadCfg:      cfg.ImageUpload,
+		IdempotencyStore:    idempotencyStore,
+		Logger:              logger,
+	})
+	readDepthService := service.NewPostReadDepthService(postRepo, readDepthRepo, logger)
+	conversionService := service.NewPostConversionService(postRepo, conversionRepo, postViewRepo, logger)
+	coverExperimentService := service.NewCoverExperimentService(db, postRepo, postDetailRepo, postDetailImageRepo, coverExperimentRepo, logger)
+	hotPostService := service.NewHotPostService(cacheRepo, postViewRepo, viewCountAggregator, postTargetingRepo, postRepo, postService, accessGuard, coverExperimentService, logger)
+	adminAuditLogService := service.NewAdminAuditLogService(adminAuditLogRepo, logger)
+	tagSubscriptionService := service.NewTagSubscriptionService(tagSubscriptionRepo, kafkaProducer, logger)
+	badgeService := service.NewBadgeService(authorBadgeRepo, postRepo, postBatchRepo, logger)
+	postAdminService := service.NewPostAdminService(service.PostAdminServiceDeps{
+		PostAdminRepo:  postAdminRepo,
+		PostRepo:       postRepo,
+		PostDetailRepo: postDetailRepo,
+		PostBatchRepo:  postBatchRepo,
+		PostViewRepo:   postViewRepo,
+		PostCache:      cacheRepo,
+		Logger:         logger,
+		DB:             db,
+		KafkaSvc:       kafkaProducer,
+		AuditLogSvc:    adminAuditLogService,
+		PostAuditRepo:  postAuditLogRepo,
+		SegmentRepo:    postContentSegmentRepo,
+		OutboxRepo:     outboxRepo,
+		DeleteCfg:      cfg.AdminDelete,
+		TagSubSvc:      tagSubscriptionService,
+		PostReportRepo: postReportRepo,
+		COSDeleteQueue: cosDeleteQueue,
+		TargetingRepo:  postTargetingRepo,
+		Compliance:     complianceChecker,
+		TimelineCfg:    cfg.TimelineCache,
+	})
+	authorInfoSyncService := service.NewAuthorInfoSyncService(postRepo, logger)
+	postListService := service.NewPostListService(logger, postRepo, postDetailImageRepo, coverExperimentService, postViewRepo, cfg.ViewCountConfig.RealtimeListViewCount, cacheRepo, cfg.TimelineCache)
+	// 评论服务尚未接入，报表的评论数列留空
+	reportService := service.NewReportService(dataReportRepo, nil, cos, cfg.ReportConfig, logger)
+	postSnapshotService := service.NewPostSnapshotService(postSnapshotRepo, postBatchRepo, postViewRepo, cfg.Snapshot, logger)
 	logger.Debug("Services 初始化完成")
 
 	// --- 7. 初始化控制器层 (Controllers) ---
-	postController := controller.NewPostController(postService, postListService)
-	hotPostController := controller.NewHotPostController(hotPostService)
-	postAdminController := controller.NewPostAdminController(postAdminService)
+	chineseConverter := service.NewChineseConverter(cfg.ChineseConvert)
+	postController := controller.NewPostController(postService, postListService, chineseConverter)
+	hotPostController := controller.NewHotPostController(hotPostService, chineseConverter)
+	postAdminController := controller.NewPostAdminController(postAdminService, adminAuditLogService)
+	reportController := controller.NewReportController(reportService)
+	tagSubscriptionController := controller.NewTagSubscriptionController(tagSubscriptionService)
+	badgeController := controller.NewBadgeController(badgeService)
+	postSnapshotController := controller.NewPostSnapshotController(postSnapshotService)
+	coverExperimentController := controller.NewCoverExperimentController(coverExperimentService)
+	readDepthController := controller.NewReadDepthController(readDepthService)
+	conversionController := controller.NewConversionController(conversionService)
 	logger.Debug("Controllers 初始化完成")
 
 	// --- 8. 初始化 Kafka 消费者 ---
@@ -250,7 +347,27 @@ func main() {
 			logger.Warn("PostAuditRejected topic 未配置，跳过 Rejected 消费者创建")
 		}
 
-		// --- 8.3 启动所有已初始化的消费者 ---
+		// --- 8.3 初始化并添加用户信息变更消费者（同步帖子中的作者头像与昵称） ---
+		userInfoTopic := cfg.KafkaConfig.Topics.UserInfoUpdated
+		if userInfoTopic != "" {
+			userInfoHandler := consumer.NewUserInfoUpdatedHandler(logger, authorInfoSyncService)
+			userInfoConsumer, err := consumer.NewConsumer(
+				&cfg.KafkaConfig,
+				groupID,
+				userInfoTopic,
+				userInfoHandler,
+				logger,
+			)
+			if err != nil {
+				logger.Fatal("初始化用户信息变更 Kafka 消费者失败", zap.Error(err))
+			}
+			consumers = append(consumers, userInfoConsumer)
+			logger.Info("用户信息变更 Kafka 消费者已准备就绪", zap.String("topic", userInfoTopic))
+		} else {
+			logger.Warn("UserInfoUpdated topic 未配置，帖子中的作者头像与昵称不会随用户资料更新")
+		}
+
+		// --- 8.4 启动所有已初始化的消费者 ---
 		if len(consumers) > 0 {
 			logger.Info(fmt.Sprintf("准备启动 %d 个 Kafka 消费者...", len(consumers)))
 			for _, c := range consumers {
@@ -269,13 +386,80 @@ func main() {
 	}
 
 	// --- 9. 初始化定时任务 ---
-	syncTask := tasks.NewViewCountSyncTask(postViewRepo, postBatchRepo, logger)
-	cacheTask := tasks.NewHotPostsCacheTask(taskRepo, logger)
+	// 调度表达式来自配置，非法时拒绝启动，避免任务静默不执行
+	if err := tasks.ValidateTaskConfig(cfg.Task); err != nil {
+		logger.Fatal("定时任务调度配置无效", zap.Error(err))
+	}
+	viewSyncLock := tasks.NewTaskLock(rdb, cfg.TaskLock.ViewCountSyncKey, cfg.TaskLock.ViewCountSyncTTL,
+		constant.ViewCountSyncLockKey, constant.ViewCountSyncLockTTL, constant.ViewCountSyncTimeout, logger)
+	hotCacheLock := tasks.NewTaskLock(rdb, cfg.TaskLock.HotPostsCacheKey, cfg.TaskLock.HotPostsCacheTTL,
+		constant.HotPostsCacheLockKey, constant.HotPostsCacheLockTTL, constant.HotPostsCacheTimeout, logger)
+	viewCountRetryStore := redisrepo.NewViewCountRetryStore(rdb, logger)
+	syncTask := tasks.NewViewCountSyncTask(postViewRepo, postBatchRepo, viewCountRetryStore, viewSyncLock, metricsReporter, cfg.ViewSyncConfig.SyncMode, cfg.Task.ViewCountSyncCron, badgeService, logger)
+	cacheTask := tasks.NewHotPostsCacheTask(taskRepo, hotCacheLock, metricsReporter, cfg.Task.HotCacheCron, logger)
+	archiveTask := tasks.NewViewCountArchiveTask(postViewRepo, viewSyncLock, cfg.ViewCountConfig, logger)
+	consistencyTask := tasks.NewViewCountConsistencyTask(postViewRepo, postBatchRepo, viewSyncLock, cfg.ViewConsistency, logger)
+	likeSyncLock := tasks.NewTaskLock(rdb, cfg.TaskLock.LikeCountSyncKey, cfg.TaskLock.LikeCountSyncTTL,
+		constant.LikeCountSyncLockKey, constant.LikeCountSyncLockTTL, constant.LikeCountSyncTimeout, logger)
+	likeSyncTask := tasks.NewLikeCountSyncTask(postLikeRepo, postBatchRepo, likeSyncLock, logger)
+	whitelistTask := tasks.NewViewWhitelistRefreshTask(postViewRepo, cfg.ViewCountConfig.WhitelistRefreshInterval, logger)
+	bloomCapacityTask := tasks.NewViewBloomCapacityTask(postViewRepo, cacheRepo, cfg.ViewCountConfig, metricsReporter, logger)
+	uploadCleanupLock := tasks.NewTaskLock(rdb, "", 0,
+		constant.PostUploadCleanupLockKey, constant.PostUploadCleanupLockTTL, constant.PostUploadCleanupTimeout, logger)
+	uploadCleanupTask := tasks.NewPostUploadCleanupTask(postRepo, cos, uploadCleanupLock, logger)
+	cosDeleteLock := tasks.NewTaskLock(rdb, "", 0,
+		constant.COSDeleteLockKey, constant.COSDeleteLockTTL, constant.COSDeleteTimeout, logger)
+	cosDeleteTask := tasks.NewCOSDeleteTask(cosDeleteQueue, postDetailImageRepo, cos, cosDeleteLock, logger)
+	var reportTask *tasks.PostReportTask
+	if cfg.ReportConfig.Enabled {
+		reportLock := tasks.NewTaskLock(rdb, "", 0,
+			constant.ReportLockKey, constant.ReportLockTTL, constant.ReportTimeout, logger)
+		reportTask = tasks.NewPostReportTask(reportService, reportLock, cfg.ReportConfig, logger)
+	} else {
+		logger.Info("帖子数据报表定时任务未启用")
+	}
+	var snapshotTask *tasks.PostSnapshotTask
+	if cfg.Snapshot.Enabled {
+		snapshotLock := tasks.NewTaskLock(rdb, "", 0,
+			constant.SnapshotLockKey, constant.SnapshotLockTTL, constant.SnapshotTimeout, logger)
+		snapshotTask = tasks.NewPostSnapshotTask(postSnapshotService, snapshotLock, logger)
+	} else {
+		logger.Info("帖子指标快照定时任务未启用")
+	}
+	var reconcileTask *tasks.PostReconcileTask
+	if kafkaProducer != nil && kafkaProducer.ReconcileSnapshotEnabled() {
+		reconcileLock := tasks.NewTaskLock(rdb, "", 0,
+			constant.PostReconcileLockKey, constant.PostReconcileLockTTL, constant.PostReconcileTimeout, logger)
+		reconcileTask = tasks.NewPostReconcileTask(postBatchRepo, kafkaProducer, rdb, reconcileLock, logger)
+	} else {
+		logger.Info("帖子对账快照导出定时任务未启用（未配置 Kafka 或对账快照 Topic）")
+	}
+	var outboxRelayTask *tasks.OutboxRelayTask
+	if kafkaProducer != nil {
+		outboxRelayLock := tasks.NewTaskLock(rdb, "", 0,
+			constant.OutboxRelayLockKey, constant.OutboxRelayLockTTL, constant.OutboxRelayTimeout, logger)
+		outboxRelayTask = tasks.NewOutboxRelayTask(outboxRepo, kafkaProducer, outboxRelayLock, logger)
+	} else {
+		logger.Info("发件箱补偿投递定时任务未启用（未配置 Kafka）")
+	}
 	logger.Info("后台定时任务已初始化并启动")
 
 	// --- 10. 设置 Gin 路由器 ---
 	// 将初始化好的控制器传递给 SetupRouter
-	ginRouter := router.SetupRouter(logger, &cfg, postController, hotPostController, postAdminController)
+	ginRouter := router.SetupRouter(logger, &cfg, postController, hotPostController, postAdminController, reportController, tagSubscriptionController, badgeController, coverExperimentController, postSnapshotController, readDepthController, conversionController)
+	// 暴露任务运行指标，供 Prometheus 抓取并配置“热榜超过 N 分钟未刷新”等告警
+	ginRouter.GET("/metrics", gin.WrapH(metricsReporter))
+	// K8s 探针：/healthz 只反映进程存活，/readyz 探测 MySQL、Redis、Kafka（已配置时）与 COS
+	healthDependencies := []health.Dependency{
+		health.MySQLDependency(db),
+		health.RedisDependency(rdb),
+		health.COSDependency(cos),
+	}
+	if kafkaProducer != nil {
+		healthDependencies = append(healthDependencies, health.KafkaDependency(kafkaProducer))
+	}
+	healthChecker := health.NewHealthChecker(constant.HealthCheckTimeout, logger, healthDependencies...)
+	controller.NewHealthController(healthChecker).RegisterRoutes(ginRouter)
 	logger.Info("Gin 路由器已设置")
 
 	// --- 11. 启动 HTTP 服务器 ---
@@ -312,6 +496,14 @@ func main() {
 		logger.Info("HTTP 服务器已成功关闭")
 	}
 
+	// a.1 HTTP 服务关闭后不再有新的浏览，写出聚合器中剩余的浏览增量
+	select {
+	case <-viewCountAggregator.Stop().Done():
+		logger.Info("浏览增量聚合器已停止")
+	case <-shutdownCtx.Done():
+		logger.Error("等待浏览增量聚合器停止超时", zap.Error(shutdownCtx.Err()))
+	}
+
 	// b . 关闭 Kafka 消费者
 	if consumerCancel != nil {
 		logger.Info("正在发送停止信号给 Kafka 消费者...")
@@ -320,7 +512,7 @@ func main() {
 	logger.Info("等待 Kafka 消费者停止...")
 	consumerWg.Wait() // <--- **关键**：阻塞在这里，直到所有 goroutine 都调用了 Done()
 
-	// 现在可以安全地关闭每个 consumer 的 reader (可选，但推荐)
+	// 关闭每个 consumer 的 reader，同时刷出 worker 处理完成后尚未发送的 offset 提交
 	for _, c := range consumers {
 		if err := c.Close(); err != nil {
 			logger.Error("关闭某个 Kafka 消费者时出错", zap.Error(err))
@@ -330,31 +522,38 @@ func main() {
 
 	// c. 停止定时任务调度器 (等待任务结束)
 	logger.Info("正在停止定时任务...")
-	syncStopCtx := syncTask.Stop()
-	cacheStopCtx := cacheTask.Stop()
+	// 先统一发出停止信号，再逐个等待，所有任务共享同一个关停超时
+	taskStopCtxs := map[string]context.Context{
+		"浏览量同步任务":             syncTask.Stop(),
+		"热帖缓存任务":              cacheTask.Stop(),
+		"浏览量冷数据归档任务":          archiveTask.Stop(),
+		"浏览量白名单刷新任务":          whitelistTask.Stop(),
+		"浏览量一致性校验任务":          consistencyTask.Stop(),
+		"点赞数同步任务":             likeSyncTask.Stop(),
+		"Bloom Filter 扩容检查任务": bloomCapacityTask.Stop(),
+		"占位帖子回收任务":            uploadCleanupTask.Stop(),
+		"COS 延迟删除任务":          cosDeleteTask.Stop(),
+	}
+	if reportTask != nil {
+		taskStopCtxs["帖子数据报表任务"] = reportTask.Stop()
+	}
+	if snapshotTask != nil {
+		taskStopCtxs["帖子指标快照任务"] = snapshotTask.Stop()
+	}
+	if reconcileTask != nil {
+		taskStopCtxs["帖子对账快照导出任务"] = reconcileTask.Stop()
+	}
+	if outboxRelayTask != nil {
+		taskStopCtxs["发件箱补偿投递任务"] = outboxRelayTask.Stop()
+	}
 
-	// 使用 select 和 定时器来等待任务结束，避免无限阻塞
-	tasksStopped := 0
-	for tasksStopped < 2 { // 等待两个任务结束
+	// 使用 select 等待任务结束，避免无限阻塞
+	for name, stopCtx := range taskStopCtxs {
 		select {
-		case <-syncStopCtx.Done():
-			logger.Info("浏览量同步任务已停止")
-			syncStopCtx = nil // 防止重复 select 到
-			tasksStopped++
-		case <-cacheStopCtx.Done():
-			logger.Info("热帖缓存任务已停止")
-			cacheStopCtx = nil // 防止重复 select 到
-			tasksStopped++
+		case <-stopCtx.Done():
+			logger.Info("定时任务已停止", zap.String("task", name))
 		case <-shutdownCtx.Done(): // 检查总的关停超时
-			logger.Error("等待定时任务停止超时", zap.Error(shutdownCtx.Err()))
-			tasksStopped = 2 // 超时则强制退出等待
-		}
-		// 如果一个 context 已经是 nil，则短暂 sleep 避免空转 CPU
-		if syncStopCtx == nil && cacheStopCtx == nil {
-			break // 都完成了
-		} else if (syncStopCtx == nil && cacheStopCtx != nil) || (syncStopCtx != nil && cacheStopCtx == nil) {
-			// 如果一个完成一个没完成，短暂 sleep 等待另一个或超时
-			time.Sleep(100 * time.Millisecond)
+			logger.Error("等待定时任务停止超时", zap.String("task", name), zap.Error(shutdownCtx.Err()))
 		}
 	}
 	logger.Info("所有定时任务已停止")
@@ -0,0 +1,229 @@
+// Package metrics 提供定时任务等后台流程的运行指标上报。
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsReporter 定义后台任务步骤的指标上报接口。
+// - 每个步骤执行完成后调用一次 ObserveTaskStep，err 为 nil 表示成功。
+// - 任务内部的事件计数（如失败批次数）通过 AddTaskEvents 累加，任务观测到的当前值（如内存占用）通过 SetTaskGauge 设置。
+// - 调用方持有的 MetricsReporter 可以为 nil，此时应跳过上报（参考 ObserveTaskStep、AddTaskEvents、SetTaskGauge 包级函数）。
+type MetricsReporter interface {
+	ObserveTaskStep(task, step string, duration time.Duration, err error)
+	AddTaskEvents(task, event string, delta int)
+	SetTaskGauge(task, name string, value float64)
+}
+
+// ObserveTaskStep 在 reporter 不为 nil 时上报一次步骤结果，方便调用方无需判空。
+func ObserveTaskStep(reporter MetricsReporter, task, step string, duration time.Duration, err error) {
+	if reporter == nil {
+		return
+	}
+	reporter.ObserveTaskStep(task, step, duration, err)
+}
+
+// AddTaskEvents 在 reporter 不为 nil 且 delta 为正时累加一次事件计数，方便调用方无需判空。
+func AddTaskEvents(reporter MetricsReporter, task, event string, delta int) {
+	if reporter == nil || delta <= 0 {
+		return
+	}
+	reporter.AddTaskEvents(task, event, delta)
+}
+
+// SetTaskGauge 在 reporter 不为 nil 时设置一次任务观测值，方便调用方无需判空。
+func SetTaskGauge(reporter MetricsReporter, task, name string, value float64) {
+	if reporter == nil {
+		return
+	}
+	reporter.SetTaskGauge(task, name, value)
+}
+
+// 指标名称，遵循 Prometheus 命名规范
+const (
+	metricStepTotal       = "post_service_task_step_total"                          // counter: 步骤执行次数，按 result 区分成功/失败
+	metricStepDuration    = "post_service_task_step_duration_seconds"               // histogram: 步骤耗时
+	metricStepLastSuccess = "post_service_task_step_last_success_timestamp_seconds" // gauge: 最后一次成功的 Unix 时间，用于配置“超过 N 分钟未刷新”告警
+	metricTaskEvents      = "post_service_task_events_total"                        // counter: 任务内部事件的累计次数，按 event 区分
+	metricTaskGauge       = "post_service_task_gauge"                               // gauge: 任务最近一次观测到的值，按 name 区分
+)
+
+// stepDurationBuckets 是步骤耗时 histogram 的桶上界（秒），覆盖从毫秒级 Redis 操作到数分钟的批量回源。
+var stepDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// stepKey 标识一个任务步骤
+type stepKey struct {
+	task string
+	step string
+}
+
+// stepStats 是单个任务步骤的累计指标
+type stepStats struct {
+	success      uint64
+	failure      uint64
+	bucketCounts []uint64 // 与 stepDurationBuckets 一一对应，非累计
+	durationSum  float64
+	lastSuccess  time.Time
+}
+
+// eventKey 标识一个任务事件或观测值
+type eventKey struct {
+	task  string
+	event string
+}
+
+// TextReporter 是 MetricsReporter 的内存实现，同时实现 http.Handler，以 Prometheus 文本格式输出指标。
+// - 不依赖 Prometheus 客户端库，指标保存在进程内存中，服务重启后清零（counter 重置可由 Prometheus 的 rate/increase 正确处理）。
+type TextReporter struct {
+	mu     sync.Mutex
+	steps  map[stepKey]*stepStats
+	events map[eventKey]uint64
+	gauges map[eventKey]float64
+}
+
+// NewTextReporter 创建一个空的 TextReporter。
+func NewTextReporter() *TextReporter {
+	return &TextReporter{steps: make(map[stepKey]*stepStats), events: make(map[eventKey]uint64), gauges: make(map[eventKey]float64)}
+}
+
+// ObserveTaskStep 记录一次步骤执行的结果与耗时。
+func (r *TextReporter) ObserveTaskStep(task, step string, duration time.Duration, err error) {
+	seconds := duration.Seconds()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := stepKey{task: task, step: step}
+	stats, ok := r.steps[key]
+	if !ok {
+		stats = &stepStats{bucketCounts: make([]uint64, len(stepDurationBuckets))}
+		r.steps[key] = stats
+	}
+	if err != nil {
+		stats.failure++
+	} else {
+		stats.success++
+		stats.lastSuccess = time.Now()
+	}
+	stats.durationSum += seconds
+	for i, upper := range stepDurationBuckets {
+		if seconds <= upper {
+			stats.bucketCounts[i]++
+			break
+		}
+	}
+}
+
+// AddTaskEvents 累加任务事件计数。
+func (r *TextReporter) AddTaskEvents(task, event string, delta int) {
+	if delta <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[eventKey{task: task, event: event}] += uint64(delta)
+}
+
+// SetTaskGauge 设置任务观测值，覆盖上一次的值。
+func (r *TextReporter) SetTaskGauge(task, name string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gauges[eventKey{task: task, event: name}] = value
+}
+
+// ServeHTTP 以 Prometheus 文本格式 (text/plain; version=0.0.4) 输出全部指标。
+func (r *TextReporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.writeTo(w)
+}
+
+// writeTo 按任务、步骤排序输出，保证每次抓取的顺序稳定。
+func (r *TextReporter) writeTo(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]stepKey, 0, len(r.steps))
+	for k := range r.steps {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].task != keys[j].task {
+			return keys[i].task < keys[j].task
+		}
+		return keys[i].step < keys[j].step
+	})
+
+	fmt.Fprintf(w, "# HELP %s Total number of task step executions by result.\n# TYPE %s counter\n", metricStepTotal, metricStepTotal)
+	for _, k := range keys {
+		s := r.steps[k]
+		fmt.Fprintf(w, "%s{%s,result=\"success\"} %d\n", metricStepTotal, k.labels(), s.success)
+		fmt.Fprintf(w, "%s{%s,result=\"failure\"} %d\n", metricStepTotal, k.labels(), s.failure)
+	}
+
+	fmt.Fprintf(w, "# HELP %s Duration of task step executions in seconds.\n# TYPE %s histogram\n", metricStepDuration, metricStepDuration)
+	for _, k := range keys {
+		s := r.steps[k]
+		var cumulative uint64
+		for i, upper := range stepDurationBuckets {
+			cumulative += s.bucketCounts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", metricStepDuration, k.labels(), strconv.FormatFloat(upper, 'g', -1, 64), cumulative)
+		}
+		total := s.success + s.failure
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", metricStepDuration, k.labels(), total)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", metricStepDuration, k.labels(), strconv.FormatFloat(s.durationSum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", metricStepDuration, k.labels(), total)
+	}
+
+	fmt.Fprintf(w, "# HELP %s Unix time of the last successful task step execution.\n# TYPE %s gauge\n", metricStepLastSuccess, metricStepLastSuccess)
+	for _, k := range keys {
+		s := r.steps[k]
+		if s.lastSuccess.IsZero() {
+			// 从未成功过时不输出该序列，告警规则可用 absent() 覆盖“启动后一直失败”的情况
+			continue
+		}
+		fmt.Fprintf(w, "%s{%s} %d\n", metricStepLastSuccess, k.labels(), s.lastSuccess.Unix())
+	}
+
+	fmt.Fprintf(w, "# HELP %s Total number of task events by event.\n# TYPE %s counter\n", metricTaskEvents, metricTaskEvents)
+	for _, k := range sortedEventKeys(r.events) {
+		fmt.Fprintf(w, "%s{task=%s,event=%s} %d\n", metricTaskEvents, quoteLabel(k.task), quoteLabel(k.event), r.events[k])
+	}
+
+	fmt.Fprintf(w, "# HELP %s Last value observed by a task, by name.\n# TYPE %s gauge\n", metricTaskGauge, metricTaskGauge)
+	for _, k := range sortedEventKeys(r.gauges) {
+		fmt.Fprintf(w, "%s{task=%s,name=%s} %s\n", metricTaskGauge, quoteLabel(k.task), quoteLabel(k.event), strconv.FormatFloat(r.gauges[k], 'g', -1, 64))
+	}
+}
+
+// sortedEventKeys 按任务、名称排序返回 m 的 Key。
+func sortedEventKeys[V any](m map[eventKey]V) []eventKey {
+	keys := make([]eventKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].task != keys[j].task {
+			return keys[i].task < keys[j].task
+		}
+		return keys[i].event < keys[j].event
+	})
+	return keys
+}
+
+// labels 输出 task、step 两个标签
+func (k stepKey) labels() string {
+	return "task=" + quoteLabel(k.task) + ",step=" + quoteLabel(k.step)
+}
+
+// labelEscaper 按 Prometheus 文本格式转义标签值中的反斜杠、双引号与换行
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
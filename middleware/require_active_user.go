@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Xushengqwer/go-common/constants"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/go-common/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequireActiveUser 拒绝被拉黑用户的写请求。
+// - 用户状态来自 UserContextMiddleware 写入上下文的 constants.StatusKey（网关透传的 X-User-Status），必须注册在其之后。
+// - 状态为 blacklisted 时，写请求返回 403；GET / HEAD / OPTIONS 请求不做拦截，被拉黑用户仍可浏览。
+// - 状态缺失或无法识别时放行，是否登录由各接口自行校验。
+func RequireActiveUser(logger *core.ZapLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		status, err := enums.StatusFromString(c.GetString(string(constants.StatusKey)))
+		if err != nil || status != enums.StatusBlacklisted {
+			c.Next()
+			return
+		}
+
+		logger.Warn("被拉黑用户的写请求已拒绝",
+			zap.String("userID", c.GetString(string(constants.UserIDKey))),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.FullPath()),
+		)
+		response.RespondError(c, http.StatusForbidden, response.ErrCodeClientForbidden, "账号已被限制，无法进行该操作")
+		c.Abort()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	commonMiddleware "github.com/Xushengqwer/go-common/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireActiveUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// 与 router/global.go 一致：用户状态由 UserContextMiddleware 从网关透传的请求头写入上下文
+	router.Use(commonMiddleware.UserContextMiddleware(), RequireActiveUser(newTestLogger(t)))
+	handled := 0
+	handler := func(c *gin.Context) {
+		handled++
+		c.Status(http.StatusOK)
+	}
+	router.GET("/posts/:id", handler)
+	router.POST("/posts", handler)
+	router.DELETE("/posts/:id", handler)
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		status     string
+		wantStatus int
+	}{
+		{name: "被拉黑用户发帖", method: http.MethodPost, path: "/posts", status: "blacklisted", wantStatus: http.StatusForbidden},
+		{name: "被拉黑用户删除帖子", method: http.MethodDelete, path: "/posts/1", status: "blacklisted", wantStatus: http.StatusForbidden},
+		{name: "状态大小写不敏感", method: http.MethodPost, path: "/posts", status: "Blacklisted", wantStatus: http.StatusForbidden},
+		{name: "被拉黑用户浏览不受影响", method: http.MethodGet, path: "/posts/1", status: "blacklisted", wantStatus: http.StatusOK},
+		{name: "正常用户发帖", method: http.MethodPost, path: "/posts", status: "active", wantStatus: http.StatusOK},
+		{name: "缺少状态时放行", method: http.MethodPost, path: "/posts", wantStatus: http.StatusOK},
+		{name: "无法识别的状态放行", method: http.MethodPost, path: "/posts", status: "unknown", wantStatus: http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handled = 0
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("X-User-ID", "user-1")
+			if tc.status != "" {
+				req.Header.Set("X-User-Status", tc.status)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tc.wantStatus, w.Body.String())
+			}
+			if wantHandled := map[bool]int{true: 1, false: 0}[tc.wantStatus == http.StatusOK]; handled != wantHandled {
+				t.Fatalf("handler ran %d times, want %d", handled, wantHandled)
+			}
+		})
+	}
+}
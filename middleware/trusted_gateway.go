@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// TrustedGatewayMiddleware 识别请求是否来自可信网关，并移除非网关请求中的用户画像请求头。
+//   - 携带与 cfg.SharedSecret 一致的 X-Gateway-Token，或 TCP 对端地址落在 cfg.TrustedCIDRs 内的请求视为来自网关。
+//   - 非网关请求中的 constant.GatewayProfileHeaders 一律删除，后续的投放定向与送审优先级按匿名画像处理，客户端无法自行伪造。
+//   - 识别结果写入上下文的 constant.GatewayTrustedContextKey；X-Gateway-Token 在识别后同样删除，不向后续处理透传。
+//   - TrustedCIDRs 中存在无法解析的网段时返回错误，由调用方拒绝启动。
+func TrustedGatewayMiddleware(cfg appConfig.GatewayConfig, logger *core.ZapLogger) (gin.HandlerFunc, error) {
+	prefixes := make([]netip.Prefix, 0, len(cfg.TrustedCIDRs))
+	for _, cidr := range cfg.TrustedCIDRs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("解析可信网关网段 %q 失败: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	secret := []byte(cfg.SharedSecret)
+	if len(secret) == 0 && len(prefixes) == 0 {
+		logger.Warn("未配置可信网关，所有请求中的用户画像请求头都将被忽略")
+	}
+
+	return func(c *gin.Context) {
+		trusted := false
+		if token := c.GetHeader(constant.HeaderGatewayToken); len(secret) > 0 && token != "" {
+			trusted = subtle.ConstantTimeCompare([]byte(token), secret) == 1
+		}
+		if !trusted && len(prefixes) > 0 {
+			trusted = remoteAddrInPrefixes(c.Request.RemoteAddr, prefixes)
+		}
+		c.Request.Header.Del(constant.HeaderGatewayToken)
+
+		if !trusted {
+			for _, header := range constant.GatewayProfileHeaders {
+				if c.Request.Header.Get(header) != "" {
+					logger.Debug("忽略非网关请求中的用户画像请求头",
+						zap.String("header", header),
+						zap.String("remoteAddr", c.Request.RemoteAddr),
+					)
+				}
+				c.Request.Header.Del(header)
+			}
+		}
+		c.Set(constant.GatewayTrustedContextKey, trusted)
+		c.Next()
+	}, nil
+}
+
+// remoteAddrInPrefixes 判断 TCP 对端地址是否落在任一可信网段内。
+func remoteAddrInPrefixes(remoteAddr string, prefixes []netip.Prefix) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/gin-gonic/gin"
+
+	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+func newTestLogger(t *testing.T) *core.ZapLogger {
+	t.Helper()
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	return logger
+}
+
+func TestTrustedGatewayMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := appConfig.GatewayConfig{SharedSecret: "s3cret", TrustedCIDRs: []string{"10.0.0.0/8"}}
+
+	cases := []struct {
+		name        string
+		remoteAddr  string
+		token       string
+		wantTrusted bool
+	}{
+		{name: "公网直连且无密钥", remoteAddr: "203.0.113.5:4321", wantTrusted: false},
+		{name: "密钥错误", remoteAddr: "203.0.113.5:4321", token: "guess", wantTrusted: false},
+		{name: "密钥正确", remoteAddr: "203.0.113.5:4321", token: "s3cret", wantTrusted: true},
+		{name: "来自可信网段", remoteAddr: "10.1.2.3:4321", wantTrusted: true},
+		{name: "IPv4 映射的 IPv6 地址", remoteAddr: "[::ffff:10.1.2.3]:4321", wantTrusted: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := TrustedGatewayMiddleware(cfg, newTestLogger(t))
+			if err != nil {
+				t.Fatalf("TrustedGatewayMiddleware: %v", err)
+			}
+			var (
+				gotTrusted bool
+				gotRegion  string
+				gotToken   string
+			)
+			router := gin.New()
+			router.Use(handler)
+			router.GET("/", func(c *gin.Context) {
+				gotTrusted = c.GetBool(constant.GatewayTrustedContextKey)
+				gotRegion = c.GetHeader(constant.HeaderUserRegion)
+				gotToken = c.GetHeader(constant.HeaderGatewayToken)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			// 客户端伪造的转发头不能让请求变成可信请求
+			req.Header.Set("X-Forwarded-For", "10.0.0.1")
+			req.Header.Set(constant.HeaderUserRegion, "guangdong")
+			req.Header.Set(constant.HeaderUserLevel, "99")
+			if tc.token != "" {
+				req.Header.Set(constant.HeaderGatewayToken, tc.token)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if gotTrusted != tc.wantTrusted {
+				t.Fatalf("trusted = %v, want %v", gotTrusted, tc.wantTrusted)
+			}
+			if wantRegion := map[bool]string{true: "guangdong", false: ""}[tc.wantTrusted]; gotRegion != wantRegion {
+				t.Fatalf("%s = %q, want %q", constant.HeaderUserRegion, gotRegion, wantRegion)
+			}
+			if gotToken != "" {
+				t.Fatalf("%s was passed through to the handler", constant.HeaderGatewayToken)
+			}
+		})
+	}
+}
+
+func TestTrustedGatewayMiddlewareWithoutConfigTrustsNothing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, err := TrustedGatewayMiddleware(appConfig.GatewayConfig{}, newTestLogger(t))
+	if err != nil {
+		t.Fatalf("TrustedGatewayMiddleware: %v", err)
+	}
+	var gotLevel string
+	router := gin.New()
+	router.Use(handler)
+	router.GET("/", func(c *gin.Context) { gotLevel = c.GetHeader(constant.HeaderUserLevel) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	req.Header.Set(constant.HeaderUserLevel, "99")
+	req.Header.Set(constant.HeaderGatewayToken, "")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotLevel != "" {
+		t.Fatalf("%s = %q, want it stripped", constant.HeaderUserLevel, gotLevel)
+	}
+}
+
+func TestTrustedGatewayMiddlewareRejectsInvalidCIDR(t *testing.T) {
+	if _, err := TrustedGatewayMiddleware(appConfig.GatewayConfig{TrustedCIDRs: []string{"10.0.0.0/33"}}, newTestLogger(t)); err == nil {
+		t.Fatal("TrustedGatewayMiddleware accepted an invalid CIDR")
+	}
+}
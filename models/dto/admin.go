@@ -1,22 +1,112 @@
 package dto
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/Xushengqwer/go-common/models/enums"
+
+	"github.com/Xushengqwer/post_service/constant"
 )
 
-// ListPostsByConditionRequest 定义管理员分页条件查询帖子的请求数据结构
-type ListPostsByConditionRequest struct {
+// PostConditionFilter 是管理员按条件查询帖子的筛选条件，由列表查询与 CSV 导出共用
+type PostConditionFilter struct {
 	ID             *uint64            `form:"id" json:"id,omitempty"`                                            // 帖子ID，若存在则按主键查询，可选
 	Title          *string            `form:"title" json:"title,omitempty"`                                      // 标题模糊查询，可选
 	AuthorUsername *string            `form:"author_username" json:"author_username,omitempty"`                  // 作者用户名模糊查询，可选
 	Status         *enums.Status      `form:"status" json:"status,omitempty" swaggertype:"integer"`              // 状态筛选，可选（0=待审核, 1=已审核, 2=拒绝）
-	OfficialTag    *enums.OfficialTag `form:"official_tag" json:"official_tag,omitempty" swaggertype:"integer" ` // 官方标签筛选，可选
+	OfficialTag    *enums.OfficialTag `form:"official_tag" json:"official_tag,omitempty" swaggertype:"integer" ` // 官方标签筛选，拥有该标签即匹配，可选
 	ViewCountMin   *int64             `form:"view_count_min" json:"view_count_min,omitempty"`                    // 浏览量下限，可选
 	ViewCountMax   *int64             `form:"view_count_max" json:"view_count_max,omitempty"`                    // 浏览量上限，可选
-	OrderBy        string             `form:"order_by" json:"order_by"`                                          // 排序字段（created_at 或 updated_at），默认 created_at
-	OrderDesc      bool               `form:"order_desc" json:"order_desc"`                                      // 是否降序，true 为降序
-	Page           int                `form:"page" json:"page" binding:"required,gt=0"`                          // 页码，从 1 开始，必填
-	PageSize       int                `form:"page_size" json:"page_size" binding:"required,gt=0"`                // 每页大小，必填
+
+	// 创建时间范围（RFC3339，必须带时区偏移，如 2025-06-10T00:00:00+08:00），可只传一端
+	// - 带偏移的时间表示确定的时刻，与服务器和数据库连接的时区设置无关
+	CreatedAtStart *time.Time `form:"created_at_start" json:"created_at_start,omitempty" time_format:"2006-01-02T15:04:05Z07:00"` // 创建时间下限（包含），可选
+	CreatedAtEnd   *time.Time `form:"created_at_end" json:"created_at_end,omitempty" time_format:"2006-01-02T15:04:05Z07:00"`     // 创建时间上限（包含），可选
+}
+
+// ListPostsByConditionRequest 定义管理员分页条件查询帖子的请求数据结构
+type ListPostsByConditionRequest struct {
+	PostConditionFilter
+
+	OrderBy   string `form:"order_by" json:"order_by"`                                   // 排序条件，支持组合：view_count:desc,created_at:desc；省略方向时取 order_desc，默认 created_at
+	OrderDesc bool   `form:"order_desc" json:"order_desc"`                               // 未指定方向的排序字段是否降序，true 为降序
+	Page      int    `form:"page" json:"page" binding:"required_without=CursorID,gte=0"` // 页码，从 1 开始；未携带游标时必填
+	PageSize  int    `form:"page_size" json:"page_size" binding:"required,gt=0"`         // 每页大小，必填
+
+	// CursorID 游标分页参数，取上一页响应中的 next_cursor，可选
+	// - 携带时走游标分页：按 ID 排序（与创建时间顺序一致），忽略 Page，且不统计总数，避免深分页时 OFFSET 扫描大量行
+	// - 不携带时走 offset 分页并返回总数，适合首屏
+	CursorID *uint64 `form:"cursor_id" json:"cursor_id,omitempty"`
+
+	// Sorts 由 ParseOrderBy 从 OrderBy 解析得到的排序条件，按优先级排列，不参与绑定
+	Sorts []SortField `form:"-" json:"-"`
+}
+
+// ExportPostsByConditionRequest 定义管理员按条件导出帖子 CSV 的请求数据结构
+// - 筛选条件与 ListPostsByConditionRequest 相同，不分页，导出全部匹配的帖子。
+// - 按 ID 顺序导出（与创建时间顺序一致），OrderDesc 为 true 时从最新的帖子开始。
+type ExportPostsByConditionRequest struct {
+	PostConditionFilter
+
+	OrderDesc bool `form:"order_desc" json:"order_desc"` // 是否按 ID 降序导出
+}
+
+// SortField 描述一个排序条件
+type SortField struct {
+	Field string // 排序字段，必须在 constant.AdminPostSortFields 白名单中
+	Desc  bool   // 是否降序
+}
+
+// ParseOrderBy 解析组合排序语法，结果写入 Sorts。
+// - 语法: 逗号分隔的 "字段[:asc|desc]"，如 view_count:desc,created_at:desc；省略方向时按 OrderDesc 决定。
+// - 字段不在白名单、方向非法、字段重复或超过 constant.AdminPostSortMaxFields 个时返回错误。
+// - OrderDesc 同步为首个排序字段的方向，游标分页按该方向翻页。
+func (r *ListPostsByConditionRequest) ParseOrderBy() error {
+	orderBy := strings.TrimSpace(r.OrderBy)
+	if orderBy == "" {
+		orderBy = constant.AdminPostSortDefaultField
+	}
+	parts := strings.Split(orderBy, ",")
+	if len(parts) > constant.AdminPostSortMaxFields {
+		return fmt.Errorf("order_by 最多支持 %d 个排序字段", constant.AdminPostSortMaxFields)
+	}
+
+	sorts := make([]SortField, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		field, direction, hasDirection := strings.Cut(strings.TrimSpace(part), ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !constant.AdminPostSortFields[field] {
+			return fmt.Errorf("不支持的排序字段: %q", field)
+		}
+		if seen[field] {
+			return fmt.Errorf("排序字段重复: %q", field)
+		}
+		seen[field] = true
+
+		desc := r.OrderDesc
+		if hasDirection {
+			switch strings.ToLower(strings.TrimSpace(direction)) {
+			case "asc":
+				desc = false
+			case "desc":
+				desc = true
+			default:
+				return fmt.Errorf("排序字段 %q 的方向无效: %q（应为 asc 或 desc）", field, direction)
+			}
+		}
+		sorts = append(sorts, SortField{Field: field, Desc: desc})
+	}
+	r.Sorts = sorts
+	r.OrderDesc = sorts[0].Desc
+	return nil
+}
+
+// SortsByCreatedAtOnly 判断是否只按创建时间排序，此时顺序与 ID 顺序一致，可以切换到游标分页。
+func (r *ListPostsByConditionRequest) SortsByCreatedAtOnly() bool {
+	return len(r.Sorts) == 0 || (len(r.Sorts) == 1 && r.Sorts[0].Field == "created_at")
 }
 
 // AuditPostRequest 定义审核帖子的请求数据结构
@@ -28,6 +118,13 @@ type AuditPostRequest struct {
 	// 2: 拒绝 (Rejected)
 	Status enums.Status `json:"status" binding:"min=0,max=2" swaggertype:"integer" `
 	Reason string       `json:"reason" binding:"omitempty,max=255" example:"内容符合规范"`
+	// EventTime 审核结果事件的产生时间，仅由审核服务的 Kafka 消费者设置。
+	// - 不为 nil 时只在帖子审核状态最后变更时间早于该时间时更新状态，防止重投的旧消息覆盖之后的人工审核结果。
+	EventTime *time.Time `json:"-"`
+	// RejectedSegments 审核服务按段落回传的未通过段落序号（从 1 开始，见 constant.KafkaHeaderAuditRejectedSegments），仅由 Kafka 消费者设置。
+	RejectedSegments []int `json:"-"`
+	// MatchedContent 审核拒绝详情中的命中内容，未按段落回传时用于定位未通过的段落，仅由 Kafka 消费者设置。
+	MatchedContent []string `json:"-"`
 }
 
 // UpdateOfficialTagRequest 定义更新帖子官方标签的请求数据结构
@@ -35,3 +132,48 @@ type UpdateOfficialTagRequest struct {
 	PostID      uint64            `json:"post_id" binding:"required"`                                        // 帖子ID，必填
 	OfficialTag enums.OfficialTag `json:"official_tag" swaggertype:"integer" binding:"required,min=0,max=3"` // 新的官方标签值，必填，并限制范围 (假设最大值为 3)
 }
+
+// BatchUpdateOfficialTagRequest 定义管理员批量更新帖子官方标签的请求数据结构
+// - 所有帖子打上同一个标签，单次最多 500 个帖子；official_tag 为 0 表示批量移除标签
+type BatchUpdateOfficialTagRequest struct {
+	PostIDs     []uint64          `json:"post_ids" binding:"required,min=1,max=500,dive,gte=1"`     // 帖子ID列表，必填
+	OfficialTag enums.OfficialTag `json:"official_tag" swaggertype:"integer" binding:"min=0,max=3"` // 新的官方标签值
+}
+
+// BatchAuditRequest 定义管理员批量审核帖子的请求数据结构
+// - 每个条目的含义与 AuditPostRequest 相同，单次最多 100 条
+type BatchAuditRequest struct {
+	Items []AuditPostRequest `json:"items" binding:"required,min=1,max=100,dive"` // 待审核的帖子列表，必填
+}
+
+// ListAdminAuditLogsRequest 定义管理员查询操作审计日志的请求参数
+// - 所有过滤条件可选，结果按操作时间倒序
+type ListAdminAuditLogsRequest struct {
+	AdminUserID string     `form:"admin_user_id" json:"admin_user_id,omitempty"`                                                                                                                                                                // 按操作人过滤，可选
+	Action      string     `form:"action" json:"action,omitempty" binding:"omitempty,oneof=audit_post batch_audit_posts update_official_tag batch_official_tag add_official_tag remove_official_tag delete_post restore_post reconcile_resync"` // 按操作类型过滤，可选
+	TargetID    string     `form:"target_id" json:"target_id,omitempty"`                                                                                                                                                                        // 按目标 ID（帖子 ID）过滤，可选
+	Result      string     `form:"result" json:"result,omitempty" binding:"omitempty,oneof=success failure partial"`                                                                                                                            // 按操作结果过滤，可选
+	StartTime   *time.Time `form:"start_time" json:"start_time,omitempty" time_format:"2006-01-02T15:04:05Z07:00"`                                                                                                                              // 操作时间下限（包含，RFC3339），可选
+	EndTime     *time.Time `form:"end_time" json:"end_time,omitempty" time_format:"2006-01-02T15:04:05Z07:00"`                                                                                                                                  // 操作时间上限（不包含，RFC3339），可选
+	Page        int        `form:"page" json:"page" binding:"required,gte=1"`                                                                                                                                                                   // 页码，从 1 开始，必填
+	PageSize    int        `form:"page_size" json:"page_size" binding:"required,gte=1,lte=100"`                                                                                                                                                 // 每页数量，必填
+}
+
+// ListPostAuditLogsRequest 定义分页查询单个帖子审计历史的请求参数（帖子 ID 在路径中）
+type ListPostAuditLogsRequest struct {
+	Page     int `form:"page" json:"page" binding:"required,gte=1"`                   // 页码，从 1 开始，必填
+	PageSize int `form:"page_size" json:"page_size" binding:"required,gte=1,lte=100"` // 每页数量，必填
+}
+
+// ListReportedPostsRequest 定义分页查询被举报帖子的请求参数
+type ListReportedPostsRequest struct {
+	Page     int `form:"page" json:"page" binding:"required,gte=1"`                   // 页码，从 1 开始，必填
+	PageSize int `form:"page_size" json:"page_size" binding:"required,gte=1,lte=100"` // 每页数量，必填
+}
+
+// ReconcileResyncRequest 定义对账补偿请求，由下游服务比对快照后提交缺失或过期的帖子
+type ReconcileResyncRequest struct {
+	Source  string   `json:"source" binding:"required,max=50"`          // 差异来源（下游服务名，如 search、recommend），必填
+	PostIDs []uint64 `json:"post_ids" binding:"required,min=1,max=500"` // 需要重新同步的帖子 ID 列表，必填，最多 500 个
+	Reason  string   `json:"reason" binding:"omitempty,max=255"`        // 差异说明，可选（如 missing、stale）
+}
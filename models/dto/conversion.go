@@ -0,0 +1,11 @@
+package dto
+
+// ReportConversionRequest 定义了上报帖子转化事件的请求体。
+type ReportConversionRequest struct {
+	// EventType 转化阶段：view=浏览详情, contact=联系作者, deal=成交。
+	EventType string `json:"event_type" binding:"required,oneof=view contact deal"`
+
+	// Source 浏览来源，只对 view 事件生效：timeline/hot/search/profile/share/other，省略时按 other 处理。
+	// - 联系与成交不需要传来源，服务端按该用户最近一次浏览的来源归因。
+	Source string `json:"source" binding:"omitempty,oneof=timeline hot search profile share other"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// SetCoverCandidatesRequest 定义了设置帖子 A/B 候选封面的请求体。
+type SetCoverCandidatesRequest struct {
+	// ImageIDs 候选封面的图片ID列表，必须是该帖子已上传的图片。
+	// - 传空数组表示关闭 A/B 封面实验；非空时数量需在 constant.CoverCandidatesMin 与 constant.CoverCandidatesMax 之间。
+	ImageIDs []uint64 `json:"image_ids" binding:"max=4,dive,gt=0"`
+}
+
+// RecordCoverClickRequest 定义了上报 A/B 封面点击的请求体。
+type RecordCoverClickRequest struct {
+	// ImageID 用户点击时看到的封面图片ID（即列表响应中的 cover_image_id）。
+	ImageID uint64 `json:"image_id" binding:"required,gt=0"`
+}
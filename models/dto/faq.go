@@ -0,0 +1,13 @@
+package dto
+
+// PostFAQItem 定义了单条帖子 FAQ（问题-答案）的请求数据结构
+type PostFAQItem struct {
+	Question string `json:"question" binding:"required,max=255"` // 问题，必填，最大255字符
+	Answer   string `json:"answer" binding:"required,max=1000"`  // 答案，必填，最大1000字符
+}
+
+// UpdatePostFAQsRequest 定义了整体替换帖子 FAQ 列表的请求数据结构
+// - 列表顺序即展示顺序；传入空列表表示清空全部 FAQ
+type UpdatePostFAQsRequest struct {
+	FAQs []PostFAQItem `json:"faqs" binding:"max=20,dive"` // FAQ 列表，最多20条
+}
@@ -1,16 +1,62 @@
 package dto
 
+import "github.com/Xushengqwer/go-common/models/enums"
+
 // CreatePostRequest 定义了创建帖子的请求数据结构
 // - 添加了 binding 标签用于输入验证
 type CreatePostRequest struct {
-	Title          string  `json:"title" form:"title" binding:"required,max=100"`                    // 帖子标题，必填，最大100字符
-	Content        string  `json:"content" form:"content" binding:"required,max=1000"`               // 帖子内容，必填，最大1000字符
+	Title          string  `json:"title" form:"title" binding:"required,maxchars=100"`               // 帖子标题，必填，最多100个字符
+	Content        string  `json:"content" form:"content" binding:"required,maxchars=1000"`          // 帖子内容，必填，最多1000个字符
 	PricePerUnit   float64 `json:"price_per_unit" form:"price_per_unit" binding:"omitempty,gte=0"`   // 单价，可选，大于等于0
 	ContactInfo    string  `json:"contact_info" form:"contact_info" binding:"omitempty"`             // 联系方式，可选
 	AuthorID       string  `json:"author_id" form:"author_id" binding:"required"`                    // 作者ID，必填
 	AuthorAvatar   string  `json:"author_avatar" form:"author_avatar" binding:"omitempty,url|uri"`   // 作者头像 URL，可选
 	AuthorUsername string  `json:"author_username" form:"author_username" binding:"required,max=50"` // 作者用户名，必填，最大50字符
 
+	// 版权声明，转载帖 (copyright_type=1) 必须填写来源地址
+	CopyrightType int    `json:"copyright_type" form:"copyright_type" binding:"omitempty,min=0,max=2"`                     // 版权声明类型，可选，0=原创(默认), 1=转载, 2=禁止转载
+	SourceURL     string `json:"source_url" form:"source_url" binding:"required_if=CopyrightType 1,omitempty,url,max=512"` // 转载来源地址，转载时必填
+
+	// 详情访问策略（可选），按位组合: 0=公开(默认), 1=需登录, 2=需付费, 4=需关注作者
+	AccessPolicy int `json:"access_policy" form:"access_policy" binding:"omitempty,min=0,max=7"`
+
+	// 转发的原帖ID（可选）。设置后该帖为转发帖，Content 即为转发语
+	QuotedPostID *uint64 `json:"quoted_post_id" form:"quoted_post_id" binding:"omitempty,gte=1"`
+
+	// 审核加急标记（可选），加急帖按高优先级送审
+	Urgent bool `json:"urgent" form:"urgent"`
+	// 保存为草稿（可选）。草稿不送审、不出现在任何公开列表，之后通过发布草稿接口提交审核
+	SaveAsDraft bool `json:"save_as_draft" form:"save_as_draft"`
+	// 忽略查重提示（可选）。发布前查重命中相似帖子时返回 409，作者确认后带上 ignore_similar=true 重新提交即可继续发布
+	IgnoreSimilar bool `json:"ignore_similar" form:"ignore_similar"`
+
+	// KeepOriginalImages 为 true 时，被压缩过的图片额外保存一份原图，供下载原图使用；未被压缩的图片本身即为原图。
+	KeepOriginalImages bool `json:"keep_original_images" form:"keep_original_images"`
+	// AuthorLevel 作者等级，由控制器根据网关注入的 X-User-Level 请求头填充，不接受客户端表单传值
+	AuthorLevel int `json:"-" form:"-"`
+	// IdempotencyKey 幂等键，由控制器从 Idempotency-Key 请求头填充，为空表示不做幂等处理
+	IdempotencyKey string `json:"-" form:"-"`
+
+	// FAQ 列表（可选）。multipart 表单中以 JSON 数组字符串形式通过 faqs 字段提交，由控制器解析后填入 FAQs
+	FAQsJSON string        `json:"-" form:"faqs"`
+	FAQs     []PostFAQItem `json:"faqs" form:"-" binding:"omitempty,max=20,dive"`
+
+	// 投放定向条件（可选），均不填写时帖子对所有用户可见
+	TargetRegions  []string `json:"target_regions" form:"target_regions" binding:"omitempty,max=50,dive,max=50"` // 投放地区编码列表，可选
+	TargetMinLevel int      `json:"target_min_level" form:"target_min_level" binding:"omitempty,gte=0"`          // 最低用户等级，可选，0 表示不限
+	TargetTags     []string `json:"target_tags" form:"target_tags" binding:"omitempty,max=50,dive,max=50"`       // 投放用户标签列表，可选，命中任意一个即可见
+
+	// 从 URL 导入的图片（可选），服务端下载后与上传的文件一起校验、压缩并上传，数量合计计入图片上限
+	// - max 与默认图片上限 constant.DefaultPostImageMaxCount 一致；按配置的实际上限在下载前由服务层校验合计数量
+	ImageURLs []string `json:"image_urls" form:"image_urls" binding:"omitempty,max=9,dive,required,url,max=2048"`
+	// ImageURLPositions 指定每个 URL 图片在最终图片列表中的位置 (从 0 开始)，与 ImageURLs 一一对应；
+	// 省略时 URL 图片按顺序排在上传的文件之后。
+	ImageURLPositions []int `json:"image_url_positions" form:"image_url_positions" binding:"omitempty,dive,gte=0"`
+
+	// ImageObjectKeys 客户端凭预签名 URL 直传到 COS 的图片对象键，可选；按给定顺序排在上传的文件与 URL 导入的图片之后。
+	// - 对象键必须由 PrepareImageUpload 为当前作者生成，且对象已上传完成。
+	ImageObjectKeys []string `json:"image_object_keys" form:"image_object_keys" binding:"omitempty,max=9,dive,required,max=512"`
+
 	// 注意：这里没有 Images 字段，因为图片文件是作为 multipart/form-data 的一部分直接上传的。
 	// 如果需要前端传递图片顺序或其他元数据，可以考虑其他方式：
 	// 1. 文件命名约定：后端根据文件名解析顺序。
@@ -18,10 +64,40 @@ type CreatePostRequest struct {
 	// 通常，如果文件是按顺序附加到 FormData 中的，后端按接收顺序处理是最简单的。
 }
 
+// CheckSimilarPostsRequest 定义了发布前查重（预览）的请求体，字段限制与 CreatePostRequest 一致。
+// - 作者ID从请求上下文获取，不接受客户端传值。
+type CheckSimilarPostsRequest struct {
+	Title   string `json:"title" binding:"required,maxchars=100"`    // 待发布的帖子标题
+	Content string `json:"content" binding:"required,maxchars=1000"` // 待发布的帖子内容
+}
+
+// UpdatePostRequest 定义了作者编辑帖子的请求体（帖子 ID 在路径中），字段限制与 CreatePostRequest 一致。
+// - 整体替换标题、正文、单价与联系方式；图片、投放定向与官方标签不能通过编辑修改。
+// - 作者ID从请求上下文获取，不接受客户端传值。
+type UpdatePostRequest struct {
+	Title        string  `json:"title" binding:"required,maxchars=100"`    // 帖子标题，必填，最多100个字符
+	Content      string  `json:"content" binding:"required,maxchars=1000"` // 帖子内容，必填，最多1000个字符；按行分段，只有变更的段落会重新送审
+	PricePerUnit float64 `json:"price_per_unit" binding:"omitempty,gte=0"` // 单价，可选，大于等于0
+	ContactInfo  string  `json:"contact_info" binding:"omitempty"`         // 联系方式，可选
+}
+
 // ListPostsByUserIDRequest 定义分页查询用户帖子的请求数据结构（游标加载）
 // - 添加了 form 和 binding 标签
 type ListPostsByUserIDRequest struct {
 	UserID   string  `json:"user_id" form:"user_id" binding:"required"`          // 用户ID，必填 (form tag 用于 query 参数绑定)
 	Cursor   *uint64 `json:"cursor" form:"cursor"`                               // 游标（上次加载的最后一条帖子的 ID），可选
 	PageSize int     `json:"page_size" form:"page_size" binding:"required,gt=0"` // 每页数量，必填，大于0
+
+	// Status 状态筛选 (0=待审核, 1=已审核, 2=已拒绝)，可选；只在查询者是作者本人时生效，省略表示全部状态。
+	// - 查看他人主页时忽略该参数，始终只返回已审核通过的帖子。
+	Status *enums.Status `json:"status" form:"status" binding:"omitempty,oneof=0 1 2"`
+
+	// ViewerID 当前登录用户的ID，由控制器从请求上下文填充，不从查询参数绑定；为空表示匿名访问。
+	ViewerID string `json:"-" form:"-"`
+}
+
+// PresignImageUploadRequest 申请图片直传 COS 的预签名上传 URL 的请求
+type PresignImageUploadRequest struct {
+	// Filename 待上传图片的原始文件名，只取其扩展名生成对象键，扩展名必须是支持的图片格式。
+	Filename string `json:"filename" binding:"required,max=255" example:"photo.jpg"`
 }
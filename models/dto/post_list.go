@@ -1,8 +1,13 @@
 package dto
 
 import (
-	"github.com/Xushengqwer/go-common/models/enums"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+
+	"github.com/Xushengqwer/post_service/constant"
 )
 
 // GetUserPostsRequestDTO 定义了用户获取自己帖子列表的API请求参数。
@@ -18,7 +23,7 @@ type GetUserPostsRequestDTO struct {
 	// - binding:"required,gte=1,lte=100"`: 必填，值必须在1到100之间。
 	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
 
-	// OfficialTag 官方标签筛选条件。
+	// OfficialTag 官方标签筛选条件，帖子拥有该标签即匹配（帖子可同时拥有多个标签），0 只匹配没有标签的帖子。
 	// - 从URL查询参数 "officialTag" 获取。
 	// - binding:"omitempty,min=0"`: 可选，如果提供，值必须大于等于0。
 	OfficialTag *enums.OfficialTag `form:"officialTag" binding:"omitempty,min=0"`
@@ -32,6 +37,11 @@ type GetUserPostsRequestDTO struct {
 	// - 从URL查询参数 "status" 获取。
 	// - binding:"omitempty,oneof=0 1 2"`: 可选，如果提供，必须是 0 (待审核), 1 (通过), 或 2 (拒绝) 之一。
 	Status *enums.Status `form:"status" binding:"omitempty,oneof=0 1 2"`
+
+	// IsDraft 草稿筛选条件。
+	// - 从URL查询参数 "isDraft" 获取。
+	// - 可选：true 只返回草稿，false 只返回已提交的帖子，不传返回全部。
+	IsDraft *bool `form:"isDraft"`
 }
 
 // GetOffset 计算分页偏移量。
@@ -52,12 +62,13 @@ func (dto *GetUserPostsRequestDTO) GetLimit() int {
 // - 用于控制器层接收和验证来自客户端的HTTP请求。
 // - 标签如 `form` 用于从URL查询参数绑定，`binding` 用于参数验证。
 type GetPostsTimelineRequestDTO struct {
-	// LastCreatedAt 上一页最后一条记录的创建时间，用于游标分页。
+	// LastCreatedAt 上一页最后一条记录的创建时间。
 	// - 从URL查询参数 "lastCreatedAt" 获取。
+	// - 已废弃：翻页只依赖 LastPostID，保留该参数仅为兼容旧客户端，传入后会被忽略。
 	// - binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`: 可选，如果提供，必须是 RFC3339 格式的时间字符串。
 	LastCreatedAt *time.Time `form:"lastCreatedAt" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 
-	// LastPostID 上一页最后一条记录的 ID，用于游标分页（辅助排序）。
+	// LastPostID 上一页最后一条记录的 ID，用于游标分页。
 	// - 从URL查询参数 "lastPostId" 获取。
 	// - binding:"omitempty,gte=1"`: 可选，如果提供，必须大于等于1。
 	LastPostID *uint64 `form:"lastPostId" binding:"omitempty,gte=1"`
@@ -67,7 +78,7 @@ type GetPostsTimelineRequestDTO struct {
 	// - binding:"required,gte=1,lte=100"`: 必填，值必须在1到100之间。
 	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
 
-	// OfficialTag 官方标签筛选条件。
+	// OfficialTag 官方标签筛选条件，帖子拥有该标签即匹配（帖子可同时拥有多个标签），0 只匹配没有标签的帖子。
 	// - 从URL查询参数 "officialTag" 获取。
 	// - binding:"omitempty,min=0"`: 可选，如果提供，值必须大于等于0 (假设枚举的底层类型是int，且0是有效值如 "无标签")。
 	//   请根据你的 enums.OfficialTag 的实际有效值范围调整 `min` 或使用 `oneof`。
@@ -82,23 +93,56 @@ type GetPostsTimelineRequestDTO struct {
 	// - 从URL查询参数 "authorUsername" 获取。
 	// - binding:"omitempty,max=50"`: 可选，如果提供，最大长度为50个字符。
 	AuthorUsername *string `form:"authorUsername" binding:"omitempty,max=50"`
+
+	// AuthorIDs 只看这些作者的帖子（关注流），可选。
+	// - 从URL查询参数 "author_id" 获取，支持重复参数 (author_id=a&author_id=b) 或逗号分隔 (author_id=a,b)，两种写法可混用。
+	// - 规整后的数量上限为 constant.TimelineMaxAuthorIDs，使用 NormalizeAuthorIDs 取得规整后的列表。
+	AuthorIDs []string `form:"author_id"`
+
+	// CollapseSimilar 是否将当前页内容高度相似的帖子折叠为一组，只展示代表帖。
+	// - 从URL查询参数 "collapseSimilar" 获取，默认不折叠。
+	CollapseSimilar bool `form:"collapseSimilar"`
+}
+
+// NormalizeAuthorIDs 展开逗号分隔的作者 ID，去除空白与重复项。
+// - 规整后超过 constant.TimelineMaxAuthorIDs 个时返回错误；未携带时返回 nil，表示不按作者过滤。
+func (dto *GetPostsTimelineRequestDTO) NormalizeAuthorIDs() ([]string, error) {
+	var authorIDs []string
+	seen := make(map[string]struct{})
+	for _, value := range dto.AuthorIDs {
+		for _, authorID := range strings.Split(value, ",") {
+			authorID = strings.TrimSpace(authorID)
+			if authorID == "" {
+				continue
+			}
+			if _, ok := seen[authorID]; ok {
+				continue
+			}
+			seen[authorID] = struct{}{}
+			authorIDs = append(authorIDs, authorID)
+		}
+	}
+	if len(authorIDs) > constant.TimelineMaxAuthorIDs {
+		return nil, fmt.Errorf("author_id 最多支持 %d 个作者", constant.TimelineMaxAuthorIDs)
+	}
+	return authorIDs, nil
 }
 
 // TimelineQueryDTO 封装了按时间线获取帖子列表的查询参数。
 // - 用于在 Service 层和 Repo 层之间传递结构化的查询条件。
 type TimelineQueryDTO struct {
-	// LastCreatedAt 上一页最后一条记录的创建时间，用于游标分页。
-	// - 类型为 *time.Time，允许为 nil，表示首次查询。
+	// LastCreatedAt 上一页最后一条记录的创建时间。
+	// - 已废弃：仓库层只按 LastPostID 翻页，保留字段仅用于日志排查。
 	LastCreatedAt *time.Time `json:"lastCreatedAt"`
 
-	// LastPostID 上一页最后一条记录的 ID，用于游标分页（辅助排序）。
+	// LastPostID 上一页最后一条记录的 ID，用于游标分页。
 	// - 类型为 *uint64，允许为 nil，表示首次查询。
 	LastPostID *uint64 `json:"lastPostID"`
 
 	// PageSize 每页期望返回的记录数。
 	PageSize int `json:"pageSize"`
 
-	// OfficialTag 官方标签筛选条件。
+	// OfficialTag 官方标签筛选条件，帖子拥有该标签即匹配。
 	// - 类型为 *enums.OfficialTag，允许为 nil，表示不按官方标签筛选。
 	OfficialTag *enums.OfficialTag `json:"officialTag"`
 
@@ -109,4 +153,85 @@ type TimelineQueryDTO struct {
 	// AuthorUsername 作者用户名模糊搜索关键词。
 	// - 类型为 *string，允许为 nil，表示不按作者用户名筛选。
 	AuthorUsername *string `json:"authorUsername"`
+
+	// AuthorIDs 只看这些作者的帖子（关注流）。
+	// - 为空表示不按作者过滤；调用方需保证已去重且不超过 constant.TimelineMaxAuthorIDs 个。
+	AuthorIDs []string `json:"authorIds"`
+
+	// CollapseSimilar 是否在当前页内折叠内容相似的帖子（基于 SimHash 指纹）。
+	CollapseSimilar bool `json:"collapseSimilar"`
+
+	// Viewer 当前访问用户的画像属性，用于投放定向过滤。
+	// - 为 nil 时按匿名用户处理（只能看到未限制地区/等级/标签的帖子）。
+	Viewer *ViewerAttributes `json:"viewer"`
+}
+
+// PostTimelineCursor 是按时间线 (id DESC) 排序的游标。
+// - 与 TimelineQueryDTO 中的 LastPostID 含义一致；CreatedAt 仅为兼容旧客户端保留，不参与翻页。
+type PostTimelineCursor struct {
+	CreatedAt *time.Time `json:"createdAt"` // 上一页最后一条记录的创建时间（已废弃，可省略）
+	PostID    uint64     `json:"postId"`    // 上一页最后一条记录的帖子ID
+}
+
+// GetPostReferencesRequest 定义了查询帖子被引用（转发）列表的API请求参数。
+type GetPostReferencesRequest struct {
+	// LastPostID 上一页最后一条记录的 ID，首页省略。
+	LastPostID *uint64 `form:"lastPostId" binding:"omitempty,gte=1"`
+
+	// PageSize 每页期望返回的记录数。
+	// - binding:"required,gte=1,lte=100"`: 必填，值必须在1到100之间。
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
+}
+
+// ListPostsByAuthorsRequest 定义了按多个作者查询帖子时间线的API请求参数（推荐/关注流）。
+// - 作者列表可能较长，因此使用 JSON 请求体而不是查询参数。
+type ListPostsByAuthorsRequest struct {
+	// AuthorIDs 作者ID列表。
+	// - binding:"required,min=1,max=2000"`: 必填，最多 constant.MaxFeedAuthors 个。
+	AuthorIDs []string `json:"authorIds" binding:"required,min=1,max=2000"`
+
+	// LastCreatedAt 上一页最后一条记录的创建时间。
+	// - 已废弃：翻页只依赖 LastPostID，保留该字段仅为兼容旧客户端，传入后会被忽略。
+	LastCreatedAt *time.Time `json:"lastCreatedAt"`
+
+	// LastPostID 上一页最后一条记录的 ID，首页省略。
+	LastPostID *uint64 `json:"lastPostId" binding:"omitempty,gte=1"`
+
+	// PageSize 每页期望返回的记录数。
+	// - binding:"required,gte=1,lte=100"`: 必填，值必须在1到100之间。
+	PageSize int `json:"pageSize" binding:"required,gte=1,lte=100"`
+}
+
+// PostViewCountCursor 是按浏览量倒序 (view_count DESC, id DESC) 排序的复合游标。
+// - 浏览量相同的帖子按 ID 倒序，保证游标在浏览量并列时依然稳定。
+type PostViewCountCursor struct {
+	ViewCount int64  `json:"viewCount"` // 上一页最后一条记录的浏览量
+	PostID    uint64 `json:"postId"`    // 上一页最后一条记录的帖子ID
+}
+
+// ListPopularPostsRequestDTO 定义了按浏览量倒序获取公开帖子列表的API请求参数。
+type ListPopularPostsRequestDTO struct {
+	// LastViewCount 上一页响应的 nextViewCount，首次加载不传；必须与 LastPostID 同时提供。
+	LastViewCount *int64 `form:"lastViewCount" binding:"omitempty,gte=0"`
+
+	// LastPostID 上一页响应的 nextPostId，首次加载不传；必须与 LastViewCount 同时提供。
+	LastPostID *uint64 `form:"lastPostId" binding:"omitempty,gte=1"`
+
+	// PageSize 每页数量。
+	// - binding:"required,gte=1,lte=50"`: 必填，值必须在1到50之间。
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=50"`
+}
+
+// SearchPostsRequestDTO 定义了帖子全文搜索的API请求参数。
+type SearchPostsRequestDTO struct {
+	// Keyword 搜索关键词，多个词以空白分隔，每个词都必须在标题或正文中出现。
+	// - binding:"required,max=100"`: 必填，最大长度为100个字符；只包含空白或运算符的关键词由服务层拒绝。
+	Keyword string `form:"keyword" binding:"required,max=100"`
+
+	// Cursor 上一页响应的 next_cursor，首次加载不传。
+	Cursor *uint64 `form:"cursor"`
+
+	// PageSize 每页数量。
+	// - binding:"required,gte=1,lte=50"`: 必填，值必须在1到50之间。
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=50"`
 }
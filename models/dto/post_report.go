@@ -0,0 +1,11 @@
+package dto
+
+// ReportPostRequest 定义用户举报帖子的请求数据结构（帖子 ID 在路径中）
+type ReportPostRequest struct {
+	Reason string `json:"reason" binding:"required,max=255" example:"虚假广告"` // 举报原因，必填，最多 255 个字符
+}
+
+// AppealPostRequest 定义作者申诉被拒帖子的请求数据结构（帖子 ID 在路径中）
+type AppealPostRequest struct {
+	Reason string `json:"reason" binding:"required,max=255" example:"帖子内容为原创，不涉及违规"` // 申诉理由，必填，最多 255 个字符
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// ListPostSnapshotsRequest 定义了管理员查询单个帖子历史指标快照的请求参数。
+type ListPostSnapshotsRequest struct {
+	// Granularity 快照粒度，可选值 hourly / daily，不传默认为 hourly。
+	Granularity string `form:"granularity" binding:"omitempty,oneof=hourly daily"`
+
+	// StartTime 快照时间下限（包含，RFC3339），不传默认为 EndTime 前 7 天。
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+
+	// EndTime 快照时间上限（不包含，RFC3339），不传默认为当前时间。
+	EndTime *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+}
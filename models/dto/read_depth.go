@@ -0,0 +1,10 @@
+package dto
+
+// ReportReadDepthRequest 定义了上报阅读深度的请求体。
+type ReportReadDepthRequest struct {
+	// ReadID 客户端为每次打开帖子生成的阅读标识（如 UUID），同一次阅读的多次上报只保留最大深度。
+	ReadID string `json:"read_id" binding:"required,max=64"`
+
+	// Depth 用户滚动到的内容百分比，0-100。
+	Depth *int `json:"depth" binding:"required,min=0,max=100"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// ListDataReportsRequest 定义了管理员查询历史数据报表的请求参数。
+type ListDataReportsRequest struct {
+	// Period 按统计周期过滤，可选值 daily / weekly，不传则返回全部。
+	Period string `form:"period" binding:"omitempty,oneof=daily weekly"`
+
+	// Page 页码，从 1 开始。
+	Page int `form:"page" binding:"required,gte=1"`
+
+	// PageSize 每页数量。
+	PageSize int `form:"page_size" binding:"required,gte=1,lte=100"`
+}
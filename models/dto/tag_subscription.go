@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/Xushengqwer/go-common/models/enums"
+
+// SubscribeTagRequest 定义用户订阅标签的请求数据结构
+type SubscribeTagRequest struct {
+	Tag enums.OfficialTag `json:"tag" swaggertype:"integer" binding:"required,min=1,max=3"` // 要订阅的官方标签 (1=官方认证, 2=预付保证金, 3=急速响应)，必填
+}
@@ -0,0 +1,23 @@
+package dto
+
+// ViewerAttributes 封装了当前访问用户的画像属性，用于帖子投放定向过滤。
+// - 由控制器层从网关透传的请求头中解析得到，向下传递到 Service / Repo 层。
+// - 未登录或网关未提供属性时各字段为零值，此时用户只能看到未设置定向条件的帖子以及不限制对应维度的帖子。
+type ViewerAttributes struct {
+	// Region 用户所在地区编码。
+	Region string `json:"region"`
+
+	// Level 用户等级。
+	Level int `json:"level"`
+
+	// Tags 用户标签列表。
+	Tags []string `json:"tags"`
+
+	// UserID 当前登录用户的ID，未登录时为空。
+	// - 不参与投放定向过滤，仅用于 A/B 封面实验的稳定分桶。
+	UserID string `json:"userId"`
+
+	// ClientID 匿名访客的客户端标识（"device:{设备ID}" 或 "ip:{客户端IP}"），只用于匿名浏览的去重计数。
+	// - 不序列化，避免客户端 IP 随查询参数出现在日志中。
+	ClientID string `json:"-"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxCharsTag 是按字符数（Unicode 码点）校验字符串长度上限的自定义 binding 标签，用法: binding:"maxchars=1000"。
+// - 中文、英文与 emoji 都按一个字符计数，与客户端输入框的计数方式一致，不受 UTF-8 编码字节数影响。
+// - 组合字符序列（如带肤色修饰的 emoji、国旗）按其包含的码点数计数，会多于用户看到的字形数。
+const MaxCharsTag = "maxchars"
+
+// RegisterValidators 把自定义校验标签注册到 gin 的默认校验器，需在处理任何请求之前调用一次。
+func RegisterValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("gin 的校验引擎不是 *validator.Validate，无法注册自定义标签")
+	}
+	if err := v.RegisterValidation(MaxCharsTag, validateMaxChars); err != nil {
+		return fmt.Errorf("注册 %s 校验标签失败: %w", MaxCharsTag, err)
+	}
+	return nil
+}
+
+// validateMaxChars 校验字符串字段的字符数不超过标签参数，非字符串字段或参数不是非负整数时校验失败。
+func validateMaxChars(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil || limit < 0 {
+		return false
+	}
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	return utf8.RuneCountInString(field.String()) <= limit
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestValidateMaxChars(t *testing.T) {
+	v := validator.New()
+	if err := v.RegisterValidation(MaxCharsTag, validateMaxChars); err != nil {
+		t.Fatalf("RegisterValidation: %v", err)
+	}
+	type sample struct {
+		Text string `validate:"maxchars=5"`
+	}
+
+	cases := []struct {
+		name  string
+		text  string
+		valid bool
+	}{
+		{name: "英文", text: "hello", valid: true},
+		{name: "英文超限", text: "hello!", valid: false},
+		{name: "中文按字符计数", text: "你好世界啊", valid: true}, // 15 字节
+		{name: "中文超限", text: "你好世界啊！", valid: false},
+		{name: "emoji 按字符计数", text: "😀😀😀😀😀", valid: true}, // 20 字节
+		{name: "emoji 超限", text: "😀😀😀😀😀😀", valid: false},
+		{name: "中英文与 emoji 混合", text: "hi你好😀", valid: true},
+		{name: "带肤色修饰的 emoji 按码点计数", text: "👍🏽👍🏽👍🏽", valid: false}, // 3 个字形、6 个码点
+		{name: "空字符串", text: "", valid: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.Struct(sample{Text: tc.text})
+			if tc.valid != (err == nil) {
+				t.Fatalf("maxchars=5 on %q: err = %v, want valid %v", tc.text, err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestValidateMaxCharsRejectsBadUsage(t *testing.T) {
+	v := validator.New()
+	if err := v.RegisterValidation(MaxCharsTag, validateMaxChars); err != nil {
+		t.Fatalf("RegisterValidation: %v", err)
+	}
+	type badParam struct {
+		Text string `validate:"maxchars=abc"`
+	}
+	type nonString struct {
+		Count int `validate:"maxchars=5"`
+	}
+	if err := v.Struct(badParam{Text: "a"}); err == nil {
+		t.Fatal("maxchars with a non-integer parameter passed validation")
+	}
+	if err := v.Struct(nonString{Count: 1}); err == nil {
+		t.Fatal("maxchars on a non-string field passed validation")
+	}
+}
+
+// 标题上限 100 个字符：100 个汉字（300 字节）可以通过，101 个不行。
+func TestPostRequestLimitsCountCharacters(t *testing.T) {
+	if err := RegisterValidators(); err != nil {
+		t.Fatalf("RegisterValidators: %v", err)
+	}
+	ok := CheckSimilarPostsRequest{Title: strings.Repeat("帖", 100), Content: strings.Repeat("🎉", 1000)}
+	if err := binding.Validator.ValidateStruct(&ok); err != nil {
+		t.Fatalf("100 CJK characters / 1000 emoji rejected: %v", err)
+	}
+	tooLong := CheckSimilarPostsRequest{Title: strings.Repeat("帖", 101), Content: "正文"}
+	if err := binding.Validator.ValidateStruct(&tooLong); err == nil {
+		t.Fatal("101 CJK characters passed the 100-character title limit")
+	}
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "github.com/Xushengqwer/go-common/models/entities"
+
+// AdminAuditLog 管理员操作审计日志实体
+// - 使用场景: 统一记录管理员对帖子的审核、删除、改标签、恢复以及批量操作，供管理后台追溯“谁在什么时候做了什么”
+// - 表名: admin_audit_logs (GORM 默认使用结构体名复数形式)
+// - 日志只追加不修改，CreatedAt 即操作时间
+type AdminAuditLog struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt
+
+	// 操作人（管理员用户 ID）
+	AdminUserID string `gorm:"type:varchar(64);not null;index"`
+
+	// 操作类型，参考 constant.AdminAction* 常量
+	Action string `gorm:"type:varchar(32);not null;index"`
+
+	// 目标类型，参考 constant.AdminAuditTarget* 常量
+	TargetType string `gorm:"type:varchar(32);not null"`
+
+	// 目标 ID，单个帖子时为帖子 ID；批量操作为空，目标列表见 Params
+	TargetID string `gorm:"type:varchar(64);not null;default:'';index"`
+
+	// 操作参数（JSON），如审核状态与原因、新标签值、批量审核的条目列表
+	Params string `gorm:"type:text"`
+
+	// 操作结果，参考 constant.AdminAuditResult* 常量
+	Result string `gorm:"type:varchar(16);not null;index"`
+
+	// 失败原因或批量操作的结果摘要
+	ErrorMessage string `gorm:"type:varchar(512);not null;default:''"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"github.com/Xushengqwer/go-common/models/entities"
+)
+
+// AuthorBadge 作者徽章实体
+// - 使用场景: 帖子浏览量首次达到里程碑 (constant.ViewMilestones) 时为作者颁发的徽章，激励创作
+// - 表名: author_badges (GORM 默认使用结构体名复数形式)
+// - 颁发的幂等性依靠 (post_id, milestone) 联合唯一索引保证，同一帖子的同一里程碑只会有一条记录
+type AuthorBadge struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt，CreatedAt 即颁发时间
+
+	// 获得徽章的作者ID（颁发时帖子的作者）
+	// - GORM 标签: index 加速查询作者的徽章列表
+	AuthorID string `gorm:"type:char(36);not null;index"`
+
+	// 达成里程碑的帖子ID
+	PostID uint64 `gorm:"type:bigint;not null;uniqueIndex:idx_post_milestone,priority:1"`
+
+	// 达成的浏览量里程碑，参考 constant.ViewMilestones
+	Milestone int64 `gorm:"type:bigint;not null;uniqueIndex:idx_post_milestone,priority:2"`
+}
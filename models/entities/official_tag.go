@@ -0,0 +1,53 @@
+package entities
+
+import "github.com/Xushengqwer/go-common/models/enums"
+
+// DefinedOfficialTags 是当前定义的全部官方标签（不含“无标签”），按标签值升序。
+var DefinedOfficialTags = []enums.OfficialTag{
+	enums.OfficialTagCertified,
+	enums.OfficialTagDeposit,
+	enums.OfficialTagRapid,
+}
+
+// OfficialTagMask 是帖子官方标签的位掩码。
+// - 标签 enums.OfficialTag(n) (n >= 1) 对应第 n-1 位：官方认证=1、预付保证金=2、急速响应=4；0 表示无标签。
+type OfficialTagMask int
+
+// OfficialTagFlag 返回官方标签对应的位；无标签或非法值返回 0。
+func OfficialTagFlag(tag enums.OfficialTag) OfficialTagMask {
+	if tag <= enums.OfficialTagNone {
+		return 0
+	}
+	return 1 << (tag - 1)
+}
+
+// Has 判断掩码是否包含指定标签；tag 为无标签时判断掩码是否为空。
+func (m OfficialTagMask) Has(tag enums.OfficialTag) bool {
+	if tag == enums.OfficialTagNone {
+		return m == 0
+	}
+	flag := OfficialTagFlag(tag)
+	return flag != 0 && m&flag != 0
+}
+
+// Tags 将掩码解析为标签列表，按标签值升序；无标签时返回空切片，以便 JSON 序列化为 []。
+func (m OfficialTagMask) Tags() []enums.OfficialTag {
+	tags := make([]enums.OfficialTag, 0, len(DefinedOfficialTags))
+	for _, tag := range DefinedOfficialTags {
+		if m.Has(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Primary 返回掩码中标签值最小的标签，无标签时返回 enums.OfficialTagNone。
+// - 用于仍只认识单个官方标签的字段（如 VO 中的 official_tag、报表分组）。
+func (m OfficialTagMask) Primary() enums.OfficialTag {
+	for _, tag := range DefinedOfficialTags {
+		if m.Has(tag) {
+			return tag
+		}
+	}
+	return enums.OfficialTagNone
+}
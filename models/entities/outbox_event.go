@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/entities"
+)
+
+// OutboxEvent 事务性发件箱中待投递的 Kafka 事件
+// - 使用场景: 与帖子数据在同一事务内写入，事务提交后投递到 Kafka，投递成功后物理删除；失败的记录由发件箱补偿任务重试
+// - 表名: outbox_events (GORM 默认使用结构体名复数形式)
+// - Payload 保存序列化后的完整事件（含 EventID），重试时原样发送，下游据此去重
+type OutboxEvent struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt
+
+	// 目标 Kafka 主题
+	Topic string `gorm:"type:varchar(255);not null"`
+
+	// 序列化后的事件内容
+	Payload []byte `gorm:"type:mediumblob;not null"`
+
+	// 消息头，JSON 编码的 map[string]string，没有消息头时为空串
+	Headers string `gorm:"type:varchar(1024);not null;default:''"`
+
+	// 已失败的投递次数（不含发送时的本地重试）
+	Attempts int `gorm:"type:int;not null;default:0"`
+
+	// 下次允许补偿任务投递的时间
+	// - GORM 标签: index 加速补偿任务扫描到期记录
+	NextAttemptAt time.Time `gorm:"not null;index"`
+
+	// 最近一次投递失败的错误信息
+	LastError string `gorm:"type:varchar(512);not null;default:''"`
+}
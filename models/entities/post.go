@@ -2,6 +2,8 @@ package entities
 
 import (
 	"database/sql"
+	"time"
+
 	"github.com/Xushengqwer/go-common/models/entities"
 	"github.com/Xushengqwer/go-common/models/enums"
 )
@@ -19,8 +21,8 @@ type Post struct {
 
 	// 作者ID，关联用户表，外键
 	// - 类型: char(36)，用户ID为UUID格式（36个字符）
-	// - GORM 标签: type:char(36) 指定固定长度字符，not null 表示非空
-	AuthorID string `gorm:"type:char(36);not null"`
+	// - GORM 标签: type:char(36) 指定固定长度字符，not null 表示非空；index 便于按作者查询与同步作者冗余信息
+	AuthorID string `gorm:"type:char(36);not null;index"`
 
 	// 作者头像，存储作者头像的URL或路径
 	// - 类型: varchar(255)，限制长度为 255 个字符，适合存储URL（例如“https://example.com/avatar.jpg”）
@@ -43,18 +45,74 @@ type Post struct {
 	// - GORM 标签: type:int 指定整数类型，default:0 设置默认值为待审核
 	Status enums.Status `gorm:"type:int;default:0"`
 
+	// 审核状态最后一次变更的时间，只有改变 status 的写入（送审、审核、申诉、恢复）才会更新
+	// - 类型: *time.Time，可以为 NULL；早于该列上线的帖子为 NULL，比较时以 created_at 代替
+	// - 设计意图: 作为审核结果事件的乐观条件，updated_at 会被改标签等无关写入刷新，不能用来判断事件是否过期
+	StatusUpdatedAt *time.Time `gorm:"comment:审核状态最后变更时间"`
+
+	// 是否为草稿：草稿保存为待审核状态但不送审，只有作者本人可见，发布后置为 false 并进入审核流程
+	// - 类型: bool，default:false 表示普通帖子
+	IsDraft bool `gorm:"default:false;comment:是否为草稿"`
+
+	// 图片是否仍在上传中：带图帖子先写库占位、再上传图片，全部上传并回填 URL 后置为 false
+	// - 为 true 的帖子对所有人（包括作者）不可见，也不会送审
+	// - 长时间停留在 true 的占位记录由上传清理任务回收（参考 constant.PostUploadStaleAfter）
+	ImageUploadPending bool `gorm:"default:false;index;comment:图片是否上传中"`
+
 	// 浏览量，统计帖子的浏览次数
 	// - 类型: int64，记录浏览次数，默认值为0
 	// - GORM 标签: type:int 指定整数类型，default:0 设置默认值
 	ViewCount int64 `gorm:"type:int;default:0"`
 
-	// 官方标签，标识帖子的官方认证状态
-	// - 类型: int，使用枚举值表示官方标签（参考 enums.OfficialTag）
-	// - GORM 标签: type:int 指定整数类型，default:0 设置默认值为无标签
-	OfficialTag enums.OfficialTag `gorm:"type:int;default:0"`
+	// 点赞数，由 Redis 计数器 (constant.PostLikeCountPrefix) 定时同步写回
+	// - 类型: int64，默认值为0
+	LikeCount int64 `gorm:"type:int;default:0"`
+
+	// 官方标签位掩码，一个帖子可以同时拥有多个官方标签（参考 OfficialTagMask）
+	// - 类型: int，default:0 表示无标签；按标签筛选使用 official_tags & ? > 0 的包含匹配
+	// - 取代早期的单值列 official_tag，该列保留在表中不再写入，迁移逻辑见 dependencies.InitMySQL
+	OfficialTags OfficialTagMask `gorm:"column:official_tags;type:int;not null;default:0;comment:官方标签位掩码"`
 
 	// 审核原因，记录帖子审核（特别是拒绝时）的原因
 	// - 类型: sql.NullString，可以为 NULL 的字符串，用于存储可能不存在的原因
 	// - GORM 标签: type:varchar(255) 指定数据库类型；comment:审核原因 添加数据库列注释
 	AuditReason sql.NullString `gorm:"type:varchar(255);comment:审核原因"`
+
+	// 作者对被拒帖子的申诉次数，每次申诉成功（帖子重新送审）加 1，达到 constant.PostAppealMaxCount 后不能再申诉
+	AppealCount int `gorm:"type:int;not null;default:0;comment:申诉次数"`
+
+	// 版权声明类型：0=原创, 1=转载, 2=禁止转载（参考 constant.CopyrightType*）
+	// - 类型: tinyint，default:0 表示默认按原创处理
+	CopyrightType int `gorm:"type:tinyint;default:0;comment:版权声明类型"`
+
+	// 转载来源地址，仅当 CopyrightType 为转载时有值
+	// - 类型: varchar(512)，存储来源 URL，可为空
+	SourceURL string `gorm:"type:varchar(512);comment:转载来源"`
+
+	// 审核优先级：0=普通, 1=高（热门作者或付费加急，参考 constant.AuditPriority*）
+	// - 创建时确定，用于审核完成后统计加急帖子的处理时延
+	AuditPriority int `gorm:"type:tinyint;default:0;comment:审核优先级"`
+
+	// 详情访问策略，按位组合：0=公开, 1=需登录, 2=需付费, 4=需关注作者（参考 constant.AccessPolicy*）
+	// - 只限制详情访问，帖子仍会出现在列表与热榜中
+	AccessPolicy int `gorm:"type:tinyint;default:0;comment:详情访问策略"`
+
+	// 转发的原帖ID，为 NULL 表示普通帖子（非转发帖）
+	// - GORM 标签: index 便于统计、查询某个帖子的转发
+	// - 转发帖的正文 (PostDetail.Content) 即为转发语
+	QuotedPostID *uint64 `gorm:"index;comment:被转发的原帖ID"`
+
+	// 被转发原帖的快照（转发时刻的标题与作者），用于在详情中渲染嵌套的原帖卡片
+	// - 原帖之后被修改不影响快照；原帖被删除时详情中不再展示快照，改为提示“原帖已删除”
+	QuotedTitle          string `gorm:"type:varchar(255);comment:原帖标题快照"`
+	QuotedAuthorID       string `gorm:"type:varchar(36);comment:原帖作者ID快照"`
+	QuotedAuthorUsername string `gorm:"type:varchar(50);comment:原帖作者用户名快照"`
+
+	// 标题与正文纯文本的 SimHash 指纹，创建时计算，用于时间线折叠内容高度相似的帖子
+	// - 0 表示未计算（历史帖子）或没有有效文本，不参与折叠
+	ContentSimHash uint64 `gorm:"default:0;comment:内容SimHash指纹"`
+
+	// 转发数，统计该帖子被转发的次数
+	// - 转发帖创建/删除/恢复时在同一事务内维护
+	RepostCount int64 `gorm:"type:int;default:0;comment:转发数"`
 }
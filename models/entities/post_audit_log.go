@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"github.com/Xushengqwer/go-common/models/entities"
+	"github.com/Xushengqwer/go-common/models/enums"
+)
+
+// PostAuditLog 帖子状态流转审计日志实体
+// - 使用场景: 记录管理员对单个帖子的审核、改标签、删除、恢复等操作前后的状态，供运营按帖子追溯“谁在什么时候把它拒绝了”
+// - 表名: post_audit_logs (GORM 默认使用结构体名复数形式)
+// - 只记录成功的操作；操作失败（含失败原因）的记录见 AdminAuditLog
+type PostAuditLog struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt，CreatedAt 即操作时间
+
+	// 帖子ID，关联 Post 表
+	// - GORM 标签: index 加速按帖子查询审计历史
+	PostID uint64 `gorm:"type:bigint;not null;index"`
+
+	// 操作人（管理员用户 ID，审核服务回传时为 constant.AdminAuditOperatorAuditService）
+	AdminUserID string `gorm:"type:varchar(64);not null"`
+
+	// 操作类型，参考 constant.AdminAction* 常量
+	Action string `gorm:"type:varchar(32);not null"`
+
+	// 操作前的帖子状态
+	OldStatus enums.Status `gorm:"type:int;not null"`
+
+	// 操作后的帖子状态；改标签、删除等不改变审核状态的操作与 OldStatus 相同
+	NewStatus enums.Status `gorm:"type:int;not null"`
+
+	// 操作原因或说明，如拒绝原因、标签变更 (官方标签 0 -> 1)
+	Reason string `gorm:"type:varchar(255);not null;default:''"`
+}
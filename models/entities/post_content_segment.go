@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"github.com/Xushengqwer/go-common/models/entities"
+	"github.com/Xushengqwer/go-common/models/enums"
+)
+
+// PostContentSegment 帖子正文段落的审核状态
+// - 使用场景: 作者编辑帖子重新送审时，与上次送审的段落逐段比对，只把变更的段落送审，未变更的段落沿用已有的审核结果
+// - 表名: post_content_segments (GORM 默认使用结构体名复数形式)
+// - 关系: 与 Post 表一对多关系，通过 PostID 关联；每次送审整体替换，行数与当前正文的段落数一致
+// - 正文按行切分、忽略空行后即为段落，只保存段落指纹，比对时的段落文本取自帖子详情
+type PostContentSegment struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt
+
+	// 帖子ID，关联 Post 表
+	// - GORM 标签: 与 SegmentIndex 组成联合索引，按帖子读取全部段落
+	PostID uint64 `gorm:"type:bigint;not null;index:idx_post_segment,priority:1"`
+
+	// 段落在正文中的位置，从 0 开始
+	SegmentIndex int `gorm:"type:int;not null;index:idx_post_segment,priority:2"`
+
+	// 段落文本 (去除首尾空白) 的 SHA-256 前 8 字节的十六进制表示
+	ContentHash string `gorm:"type:char(16);not null"`
+
+	// 段落的审核状态：待审核 (已送审未回传)、通过或拒绝
+	Status enums.Status `gorm:"type:int;not null"`
+}
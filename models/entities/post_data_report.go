@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/entities"
+)
+
+// PostDataReport 帖子数据报表记录实体
+// - 使用场景: 记录定时任务生成并上传到 COS 的历史报表，供管理后台列表展示与下载
+// - 表名: post_data_reports (GORM 默认使用结构体名复数形式)
+type PostDataReport struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt，支持软删除
+
+	// 统计周期，"daily" 或 "weekly"（参考 constant.ReportPeriodDaily / ReportPeriodWeekly）
+	Period string `gorm:"type:varchar(16);not null;index"`
+
+	// 分组维度，"none" 或 "official_tag"（参考 constant.ReportGroupByNone / ReportGroupByOfficialTag）
+	GroupBy string `gorm:"type:varchar(32);not null"`
+
+	// 统计区间起始时间（包含）
+	PeriodStart time.Time `gorm:"not null"`
+
+	// 统计区间结束时间（不包含）
+	PeriodEnd time.Time `gorm:"not null"`
+
+	// 报表文件在 COS 中的对象键，用于后续清理
+	ObjectKey string `gorm:"type:varchar(255);not null"`
+
+	// 报表文件的公开访问 URL，管理后台通过它下载报表
+	FileURL string `gorm:"type:varchar(512);not null"`
+
+	// 报表中包含的帖子行数
+	RowCount int `gorm:"type:int;not null;default:0"`
+}
@@ -33,6 +33,15 @@ type PostDetailImage struct {
 	// 图片在COS中的ObjectKey
 	ObjectKey string `gorm:"type:varchar(255);not null;index"`
 
+	// 原图在COS中的ObjectKey与URL
+	// - 仅当上传时压缩了图片且作者选择保留原图时才有值，用于下载原图；ImageURL/ObjectKey 指向压缩后的图片。
+	OriginalObjectKey string `gorm:"type:varchar(255);not null;default:'';index"`
+	OriginalImageURL  string `gorm:"type:varchar(1023);not null;default:''"`
+
+	// 是否为 A/B 测试的候选封面
+	// - 同一帖子有 constant.CoverCandidatesMin 张及以上候选封面时，列表按用户分桶展示其中一张。
+	IsCoverCandidate bool `gorm:"default:false;comment:是否为A/B测试候选封面"`
+
 	// 你还可以根据需要添加其他元数据字段，例如:
 	// AltText string `gorm:"type:varchar(255)"` // 图片的SEO友好替代文本
 	// MimeType string `gorm:"type:varchar(50)"`  // 图片的MIME类型, 如 "image/jpeg"
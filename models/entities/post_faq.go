@@ -0,0 +1,24 @@
+package entities
+
+import "github.com/Xushengqwer/go-common/models/entities"
+
+// PostFAQ 帖子常见问题（问题-答案）实体
+// - 使用场景: 服务类帖子附带的结构化 FAQ，在详情页展示，也可用于生成 SEO 结构化数据
+// - 表名: post_faqs (GORM 默认使用结构体名复数形式)
+// - 关系: 与 Post 表一对多关系，通过 PostID 关联
+type PostFAQ struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt，支持软删除
+
+	// 帖子ID，关联 Post 表
+	// - GORM 标签: index 加速按帖子查询 FAQ 列表
+	PostID uint64 `gorm:"type:bigint;index;not null"`
+
+	// 问题，必填，最大长度 255 个字符
+	Question string `gorm:"type:varchar(255);not null"`
+
+	// 答案，必填，最大长度 1000 个字符
+	Answer string `gorm:"type:varchar(1000);not null"`
+
+	// 展示顺序，从 0 开始，数值越小越靠前
+	DisplayOrder int `gorm:"type:int;not null;default:0"`
+}
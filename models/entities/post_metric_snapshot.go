@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/entities"
+	"github.com/Xushengqwer/go-common/models/enums"
+)
+
+// PostMetricSnapshot 帖子指标快照实体
+// - 使用场景: 定时记录帖子在某一时刻的浏览量、点赞数、状态与排行榜排名，供数据分析回溯历史
+// - 表名: post_metric_snapshots (GORM 默认使用结构体名复数形式)
+// - 只对活跃帖子生成快照：小时级快照覆盖最近一小时有浏览的帖子，日级快照覆盖前一天有浏览的帖子，过期快照由任务定期清理
+type PostMetricSnapshot struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt
+
+	// 帖子ID，与快照时间组成联合索引，支撑按帖子查询时间序列
+	PostID uint64 `gorm:"not null;index:idx_snapshot_post_time,priority:1"`
+
+	// 快照粒度，"hourly" 或 "daily"（参考 constant.SnapshotGranularity*），清理过期快照时按粒度与时间删除
+	Granularity string `gorm:"type:varchar(16);not null;index:idx_snapshot_granularity_time,priority:1"`
+
+	// 快照时刻（同一轮快照内的所有帖子相同）
+	SnapshotAt time.Time `gorm:"not null;index:idx_snapshot_post_time,priority:2;index:idx_snapshot_granularity_time,priority:2"`
+
+	// 快照时刻的浏览量（取 Redis 实时计数，计数器不存在时取 MySQL 持久化值）
+	ViewCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// 快照时刻的点赞数（MySQL 中定时同步的持久化值）
+	LikeCount int64 `gorm:"type:bigint;not null;default:0"`
+
+	// 快照时刻的审核状态
+	Status enums.Status `gorm:"type:int;not null"`
+
+	// 快照时刻在全站排行榜中的排名（0 开始），不在排行榜中时为 NULL
+	Rank *int64 `gorm:"type:bigint"`
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "github.com/Xushengqwer/go-common/models/entities"
+
+// PostReport 用户举报帖子实体
+// - 使用场景: 用户举报违规帖子；管理员按举报数查看被举报的帖子，举报数达到阈值时触发自动下架审查
+// - 表名: post_reports (GORM 默认使用结构体名复数形式)
+// - CreatedAt 即举报时间
+type PostReport struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt
+
+	// 被举报的帖子ID
+	// - GORM 标签: 与 ReporterID 组成联合唯一索引，同一用户对同一帖子只能举报一次；帖子在前，按帖子聚合举报数时可直接使用该索引
+	PostID uint64 `gorm:"type:bigint;not null;uniqueIndex:idx_post_reporter,priority:1"`
+
+	// 举报人用户ID
+	ReporterID string `gorm:"type:char(36);not null;uniqueIndex:idx_post_reporter,priority:2"`
+
+	// 举报原因
+	Reason string `gorm:"type:varchar(255);not null;default:''"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "github.com/Xushengqwer/go-common/models/entities"
+
+// PostTargeting 帖子投放定向条件实体
+// - 使用场景: 运营帖只投放给特定人群（如某地区、某等级、带某标签的用户），列表与详情查询时据此过滤
+// - 表名: post_targetings (GORM 默认使用结构体名复数形式)
+// - 关系: 与 Post 表一对一关系，通过 PostID 关联；没有定向记录的帖子对所有用户可见
+type PostTargeting struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt，支持软删除
+
+	// 帖子ID，关联 Post 表
+	// - GORM 标签: uniqueIndex 确保一个帖子最多只有一条定向记录，同时加速按帖子查询定向条件
+	PostID uint64 `gorm:"type:bigint;uniqueIndex;not null"`
+
+	// 投放地区列表，逗号分隔的地区编码（例如 "guangdong,shanghai"）
+	// - 空字符串表示不限地区
+	// - 使用逗号分隔存储，便于在 SQL 中通过 FIND_IN_SET 直接过滤
+	Regions string `gorm:"type:varchar(512);not null;default:''"`
+
+	// 最低用户等级，用户等级需大于等于该值才可见
+	// - 0 表示不限等级
+	MinUserLevel int `gorm:"type:int;not null;default:0"`
+
+	// 投放用户标签列表，逗号分隔（例如 "vip,merchant"）
+	// - 空字符串表示不限标签；非空时用户只需命中其中任意一个标签即可见
+	Tags string `gorm:"type:varchar(512);not null;default:''"`
+}
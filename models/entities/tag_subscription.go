@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"github.com/Xushengqwer/go-common/models/entities"
+	"github.com/Xushengqwer/go-common/models/enums"
+)
+
+// TagSubscription 用户标签订阅实体
+// - 使用场景: 用户订阅某个官方标签，帖子审核通过且带有该标签时向订阅者推送新帖事件
+// - 表名: tag_subscriptions (GORM 默认使用结构体名复数形式)
+// - 取消订阅为物理删除，避免软删除记录占用唯一索引导致无法重新订阅
+type TagSubscription struct {
+	entities.BaseModel // 嵌入自定义的 BaseModel , 包含 ID, CreatedAt, UpdatedAt, DeletedAt
+
+	// 订阅的标签，参考 enums.OfficialTag
+	// - GORM 标签: 与 UserID 组成联合唯一索引，标签在前，推送时按标签 + 用户ID游标分批扫描订阅者
+	Tag enums.OfficialTag `gorm:"type:int;not null;uniqueIndex:idx_tag_user,priority:1"`
+
+	// 订阅用户ID
+	// - GORM 标签: 单独建立普通索引，加速查询用户自己的订阅列表
+	UserID string `gorm:"type:char(36);not null;uniqueIndex:idx_tag_user,priority:2;index"`
+}
@@ -0,0 +1,171 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+)
+
+// 批量审核单条结果的错误类型
+const (
+	BatchAuditErrorNotFound         = "not_found"         // 帖子不存在
+	BatchAuditErrorInvalidCopyright = "invalid_copyright" // 版权声明不合理，无法审核通过
+	BatchAuditErrorIsDraft          = "is_draft"          // 帖子仍是草稿，作者尚未提交审核
+	BatchAuditErrorInternal         = "internal_error"    // 其他内部错误
+)
+
+// BatchAuditItemResultVO 定义批量审核中单个帖子的处理结果
+type BatchAuditItemResultVO struct {
+	PostID    uint64 `json:"post_id"`              // 帖子ID
+	Success   bool   `json:"success"`              // 是否处理成功
+	ErrorType string `json:"error_type,omitempty"` // 失败类型 (not_found / invalid_copyright / is_draft / internal_error)，成功时为空
+	Message   string `json:"message,omitempty"`    // 失败详情，成功时为空
+}
+
+// BatchAuditResultVO 定义管理员批量审核帖子的响应结构
+type BatchAuditResultVO struct {
+	Results      []*BatchAuditItemResultVO `json:"results"`       // 每个帖子的处理结果，顺序与请求一致
+	SuccessCount int                       `json:"success_count"` // 成功数量
+	FailureCount int                       `json:"failure_count"` // 失败数量
+}
+
+// BatchResultVO 定义批量更新类管理操作的响应结构
+type BatchResultVO struct {
+	Total         int      `json:"total"`          // 请求中的帖子数量（去重后）
+	AffectedCount int64    `json:"affected_count"` // 实际更新的帖子数量
+	NotFoundIDs   []uint64 `json:"not_found_ids"`  // 不存在或已删除、未被更新的帖子ID
+}
+
+// RecentViewsVO 定义最近 N 分钟全站浏览量的响应结构
+type RecentViewsVO struct {
+	Minutes   int   `json:"minutes"`    // 统计窗口（分钟），包含当前未结束的分钟
+	ViewCount int64 `json:"view_count"` // 窗口内的全站浏览量
+}
+
+// PostStatusCountVO 定义单个审核状态的帖子数量
+type PostStatusCountVO struct {
+	Status enums.Status `json:"status"` // 审核状态 (0=待审核, 1=已审核, 2=已拒绝)
+	Count  int64        `json:"count"`  // 帖子数量
+}
+
+// PostTagCountVO 定义单个官方标签的帖子数量
+type PostTagCountVO struct {
+	OfficialTag enums.OfficialTag `json:"official_tag"` // 官方标签 (0=无标签)
+	Count       int64             `json:"count"`        // 帖子数量
+}
+
+// PostStatsVO 定义帖子数量统计的响应结构，供运营看板展示
+// - 统计不含已删除的帖子与图片上传中的占位帖子；结果会被短期缓存，存在最多一分钟左右的延迟。
+type PostStatsVO struct {
+	Total         int64               `json:"total"`           // 帖子总数（含草稿）
+	Drafts        int64               `json:"drafts"`          // 草稿数量，不计入 by_status
+	ByStatus      []PostStatusCountVO `json:"by_status"`       // 各审核状态的帖子数量（不含草稿），每个状态都会返回
+	ByOfficialTag []PostTagCountVO    `json:"by_official_tag"` // 各官方标签的帖子数量（含草稿），只返回数量大于 0 的标签
+	GeneratedAt   time.Time           `json:"generated_at"`    // 统计生成时间
+}
+
+// AdminAuditLogVO 定义一条管理员操作审计日志的视图对象
+type AdminAuditLogVO struct {
+	ID           uint64    `json:"id"`                      // 日志ID
+	AdminUserID  string    `json:"admin_user_id"`           // 操作人
+	Action       string    `json:"action"`                  // 操作类型
+	TargetType   string    `json:"target_type"`             // 目标类型 (post / post_batch)
+	TargetID     string    `json:"target_id,omitempty"`     // 目标 ID，批量操作为空
+	Params       string    `json:"params,omitempty"`        // 操作参数 (JSON 字符串)
+	Result       string    `json:"result"`                  // 操作结果 (success / failure / partial)
+	ErrorMessage string    `json:"error_message,omitempty"` // 失败原因或批量结果摘要
+	CreatedAt    time.Time `json:"created_at"`              // 操作时间
+}
+
+// ListAdminAuditLogsVO 定义管理员操作审计日志分页查询的响应结构
+type ListAdminAuditLogsVO struct {
+	Logs  []*AdminAuditLogVO `json:"logs"`  // 当前页的日志列表
+	Total int64              `json:"total"` // 符合条件的总记录数
+}
+
+// PostAuditLogVO 定义一条帖子状态流转审计日志的视图对象
+type PostAuditLogVO struct {
+	ID          uint64       `json:"id"`                               // 日志ID
+	PostID      uint64       `json:"post_id"`                          // 帖子ID
+	AdminUserID string       `json:"admin_user_id"`                    // 操作人
+	Action      string       `json:"action"`                           // 操作类型 (audit_post / update_official_tag / delete_post / restore_post)
+	OldStatus   enums.Status `json:"old_status" swaggertype:"integer"` // 操作前状态 (0=待审核, 1=已审核, 2=已拒绝)
+	NewStatus   enums.Status `json:"new_status" swaggertype:"integer"` // 操作后状态
+	Reason      string       `json:"reason,omitempty"`                 // 操作原因或说明
+	CreatedAt   time.Time    `json:"created_at"`                       // 操作时间
+}
+
+// ListPostAuditLogsVO 定义帖子审计历史分页查询的响应结构
+type ListPostAuditLogsVO struct {
+	Logs  []*PostAuditLogVO `json:"logs"`  // 当前页的日志列表
+	Total int64             `json:"total"` // 该帖子的日志总数
+}
+
+// ReportedPostVO 定义一个被举报帖子的举报统计
+type ReportedPostVO struct {
+	PostID         uint64       `json:"post_id"`                      // 帖子ID
+	Title          string       `json:"title"`                        // 帖子标题
+	AuthorID       string       `json:"author_id"`                    // 作者ID
+	AuthorUsername string       `json:"author_username"`              // 作者用户名
+	Status         enums.Status `json:"status" swaggertype:"integer"` // 帖子当前状态 (0=待审核, 1=已审核, 2=已拒绝)
+	ReportCount    int64        `json:"report_count"`                 // 举报数
+	LastReportedAt time.Time    `json:"last_reported_at"`             // 最近一次被举报的时间
+}
+
+// ListReportedPostsVO 定义被举报帖子分页查询的响应结构
+type ListReportedPostsVO struct {
+	Posts []*ReportedPostVO `json:"posts"` // 当前页的帖子，按举报数倒序
+	Total int64             `json:"total"` // 被举报的帖子总数
+}
+
+// DeleteConfirmRequiredVO 是删除高影响帖子需要二次确认时返回的数据，展示删除的影响面。
+type DeleteConfirmRequiredVO struct {
+	PostID             uint64 `json:"post_id"`              // 帖子ID
+	ViewCount          int64  `json:"view_count"`           // 帖子浏览量（MySQL 中的持久化值，可能略低于实时值）
+	ViewCountThreshold int64  `json:"view_count_threshold"` // 触发二次确认的浏览量阈值
+	InHotList          bool   `json:"in_hot_list"`          // 帖子当前是否在热榜中
+	HotRank            *int64 `json:"hot_rank,omitempty"`   // 热榜排名（0 开始），不在热榜时为空
+}
+
+// ReconcileResyncResultVO 定义对账补偿的处理结果
+type ReconcileResyncResultVO struct {
+	UpsertedPostIDs []uint64 `json:"upserted_post_ids"` // 重新发送审核通过事件的帖子
+	RemovedPostIDs  []uint64 `json:"removed_post_ids"`  // 重新发送删除事件的帖子（已删除、不存在或未审核通过）
+	FailedPostIDs   []uint64 `json:"failed_post_ids"`   // 事件发送失败的帖子，可稍后重试
+}
+
+// AdminPostDetailVO 定义管理员查看单个帖子完整详情的视图对象。
+// - 区别于面向普通用户的 PostDetailVO：包含审核状态、审核原因、审核优先级与删除信息，且已删除的帖子同样返回。
+type AdminPostDetailVO struct {
+	// --- 来自 Post 实体 ---
+	ID             uint64              `json:"id"`                                        // 帖子ID
+	CreatedAt      time.Time           `json:"created_at"`                                // 创建时间
+	UpdatedAt      time.Time           `json:"updated_at"`                                // 更新时间
+	Title          string              `json:"title"`                                     // 帖子标题
+	AuthorID       string              `json:"author_id"`                                 // 作者ID
+	AuthorAvatar   string              `json:"author_avatar"`                             // 作者头像URL
+	AuthorUsername string              `json:"author_username"`                           // 作者用户名
+	Status         enums.Status        `json:"status" swaggertype:"integer"`              // 审核状态 (0=待审核, 1=已审核, 2=已拒绝)
+	AuditReason    string              `json:"audit_reason,omitempty"`                    // 审核原因（拒绝原因等），未填写时为空
+	AuditPriority  int                 `json:"audit_priority"`                            // 审核优先级 (0=普通, 1=高)
+	IsDraft        bool                `json:"is_draft"`                                  // 是否为草稿
+	IsDeleted      bool                `json:"is_deleted"`                                // 是否已被软删除
+	DeletedAt      *time.Time          `json:"deleted_at,omitempty"`                      // 删除时间，未删除时为空
+	ViewCount      int64               `json:"view_count"`                                // 浏览量（MySQL 中的持久化值）
+	LikeCount      int64               `json:"like_count"`                                // 点赞数（MySQL 中的持久化值）
+	RepostCount    int64               `json:"repost_count"`                              // 被转发次数
+	OfficialTag    enums.OfficialTag   `json:"official_tag" swaggertype:"integer"`        // 主官方标签（帖子拥有的标签值最小者）
+	OfficialTags   []enums.OfficialTag `json:"official_tags" swaggertype:"array,integer"` // 帖子拥有的全部官方标签，按标签值升序
+	CopyrightType  int                 `json:"copyright_type"`                            // 版权声明类型 (0=原创, 1=转载, 2=禁止转载)
+	SourceURL      string              `json:"source_url"`                                // 转载来源地址
+	AccessPolicy   int                 `json:"access_policy"`                             // 详情访问策略 (按位组合)
+	QuotedPostID   *uint64             `json:"quoted_post_id,omitempty"`                  // 转发的原帖ID，非转发帖为空
+
+	// --- 来自 PostDetail 实体，详情缺失时为零值 ---
+	Content      string  `json:"content"`        // 帖子详细HTML内容
+	PricePerUnit float64 `json:"price_per_unit"` // 单价 (单位：元)
+	ContactInfo  string  `json:"contact_info"`   // 联系方式
+
+	// --- 来自 PostDetailImage 实体列表，按 DisplayOrder 排序 ---
+	Images []PostImageVO `json:"images"` // 详情图片列表
+}
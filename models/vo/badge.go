@@ -0,0 +1,28 @@
+package vo
+
+import "time"
+
+// AuthorBadgeVO 作者获得的一枚浏览量里程碑徽章
+type AuthorBadgeVO struct {
+	PostID    uint64    `json:"post_id"`    // 达成里程碑的帖子ID
+	Milestone int64     `json:"milestone"`  // 浏览量里程碑
+	BadgeName string    `json:"badge_name"` // 徽章名称
+	AwardedAt time.Time `json:"awarded_at"` // 颁发时间
+}
+
+// AuthorBadgesVO 作者的徽章列表
+type AuthorBadgesVO struct {
+	AuthorID string           `json:"author_id"` // 作者ID
+	Badges   []*AuthorBadgeVO `json:"badges"`    // 徽章列表，按颁发时间倒序
+}
+
+// PostMilestoneProgressVO 帖子浏览量里程碑进度
+// - ViewCount 为已同步到 MySQL 的浏览量，与徽章颁发使用同一数据源，比实时浏览量略有延迟
+type PostMilestoneProgressVO struct {
+	PostID        uint64           `json:"post_id"`                   // 帖子ID
+	ViewCount     int64            `json:"view_count"`                // 已同步的浏览量
+	Achieved      []*AuthorBadgeVO `json:"achieved"`                  // 已颁发的里程碑徽章，按里程碑升序
+	NextMilestone *int64           `json:"next_milestone,omitempty"`  // 下一个里程碑，已全部达成时为空
+	NextBadgeName string           `json:"next_badge_name,omitempty"` // 下一个里程碑的徽章名称
+	Progress      float64          `json:"progress"`                  // 距下一个里程碑的进度 (0~1)，已全部达成时为 1
+}
@@ -0,0 +1,33 @@
+package vo
+
+// ConversionFunnelVO 浏览 → 联系 → 成交的转化漏斗
+type ConversionFunnelVO struct {
+	Views       int64   `json:"views"`        // 浏览人数（同一用户每天只计一次）
+	Contacts    int64   `json:"contacts"`     // 联系人数
+	Deals       int64   `json:"deals"`        // 成交人数
+	ContactRate float64 `json:"contact_rate"` // 联系率，Contacts / Views，Views 为 0 时为 0
+	DealRate    float64 `json:"deal_rate"`    // 成交率，Deals / Contacts，Contacts 为 0 时为 0
+	OverallRate float64 `json:"overall_rate"` // 整体转化率，Deals / Views，Views 为 0 时为 0
+}
+
+// DailyConversionVO 某一天的转化漏斗
+type DailyConversionVO struct {
+	Date string `json:"date"` // 日期 (YYYY-MM-DD，服务端时区)
+	ConversionFunnelVO
+}
+
+// SourceConversionVO 某个浏览来源带来的转化漏斗
+type SourceConversionVO struct {
+	Source string `json:"source"` // 浏览来源 (timeline/hot/search/profile/share/other)，direct 表示归因窗口内没有浏览记录
+	ConversionFunnelVO
+}
+
+// PostConversionVO 帖子的转化归因分析
+type PostConversionVO struct {
+	PostID     uint64                `json:"post_id"`     // 帖子ID
+	Days       int                   `json:"days"`        // 统计的最近天数（含当天）
+	TotalViews int64                 `json:"total_views"` // 帖子累计浏览量（浏览计数器的实时值，包含未上报转化事件的浏览）
+	Funnel     ConversionFunnelVO    `json:"funnel"`      // 统计期内的汇总漏斗
+	Daily      []*DailyConversionVO  `json:"daily"`       // 按天的漏斗，按日期升序
+	Sources    []*SourceConversionVO `json:"sources"`     // 按浏览来源拆分的漏斗，按浏览人数降序
+}
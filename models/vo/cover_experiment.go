@@ -0,0 +1,17 @@
+package vo
+
+// PostCoverVariantStatsVO 单张候选封面的实验表现
+type PostCoverVariantStatsVO struct {
+	ImageID          uint64  `json:"image_id"`           // 候选封面图片ID
+	ImageURL         string  `json:"image_url"`          // 候选封面图片URL
+	Impressions      int64   `json:"impressions"`        // 曝光数（登录用户在公开列表中看到该封面的次数）
+	Clicks           int64   `json:"clicks"`             // 点击数
+	ClickThroughRate float64 `json:"click_through_rate"` // 点击率 (clicks / impressions)，无曝光时为 0
+}
+
+// PostCoverStatsVO 帖子 A/B 封面实验的表现统计
+type PostCoverStatsVO struct {
+	PostID   uint64                     `json:"post_id"`  // 帖子ID
+	Active   bool                       `json:"active"`   // 实验是否生效（候选封面达到最少数量）
+	Variants []*PostCoverVariantStatsVO `json:"variants"` // 各候选封面的表现，按展示顺序排列
+}
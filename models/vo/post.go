@@ -8,33 +8,81 @@ import (
 
 // PostResponse 定义了帖子基础信息的响应数据结构
 type PostResponse struct {
-	ID             uint64            `json:"id"`              // 帖子ID
-	Title          string            `json:"title"`           // 帖子标题
-	Status         enums.Status      `json:"status" `         // 帖子状态，0=待审核, 1=已审核, 2=拒绝
-	ViewCount      int64             `json:"view_count"`      // 浏览量
-	AuthorID       string            `json:"author_id"`       // 作者ID
-	AuthorAvatar   string            `json:"author_avatar"`   // 作者头像
-	AuthorUsername string            `json:"author_username"` // 作者用户名
-	AuditReason    *string           `json:"audit_reason"`    // 审核原因 (如果 Status 为拒绝，则可能包含原因)
-	OfficialTag    enums.OfficialTag `json:"official_tag" `   // 官方标签 (0=无, 1=官方认证, ...)
-	CreatedAt      time.Time         `json:"created_at"`      // 创建时间
-	UpdatedAt      time.Time         `json:"updated_at"`      // 更新时间
+	ID             uint64              `json:"id"`                                        // 帖子ID
+	Title          string              `json:"title"`                                     // 帖子标题
+	Status         enums.Status        `json:"status" `                                   // 帖子状态，0=待审核, 1=已审核, 2=拒绝
+	IsDraft        bool                `json:"is_draft"`                                  // 是否为草稿（草稿的状态为待审核，但尚未送审）
+	ViewCount      int64               `json:"view_count"`                                // 浏览量
+	LikeCount      int64               `json:"like_count"`                                // 点赞数（MySQL 中的持久化值，定时同步，可能略低于实时值）
+	AuthorID       string              `json:"author_id"`                                 // 作者ID
+	AuthorAvatar   string              `json:"author_avatar"`                             // 作者头像
+	AuthorUsername string              `json:"author_username"`                           // 作者用户名
+	AuditReason    *string             `json:"audit_reason"`                              // 审核原因 (如果 Status 为拒绝，则可能包含原因)
+	AppealCount    int                 `json:"appeal_count"`                              // 作者对被拒帖子的累计申诉次数
+	OfficialTag    enums.OfficialTag   `json:"official_tag" `                             // 主官方标签（帖子拥有的标签值最小者，0=无），兼容只认识单个标签的客户端
+	OfficialTags   []enums.OfficialTag `json:"official_tags" swaggertype:"array,integer"` // 帖子拥有的全部官方标签，按标签值升序
+	CopyrightType  int                 `json:"copyright_type"`                            // 版权声明类型 (0=原创, 1=转载, 2=禁止转载)
+	RepostCount    int64               `json:"repost_count"`                              // 被转发次数
+	QuotedPostID   *uint64             `json:"quoted_post_id"`                            // 转发的原帖ID，非转发帖为 null
+	CoverImageID   *uint64             `json:"cover_image_id,omitempty"`                  // A/B 封面实验中分配给当前用户的封面图片ID，未开启实验时省略
+	CoverImageURL  string              `json:"cover_image_url,omitempty"`                 // 列表封面图片URL：A/B 封面实验中分配给当前用户的封面，未开启实验时为首图，无图帖子省略
+	CreatedAt      time.Time           `json:"created_at"`                                // 创建时间
+	UpdatedAt      time.Time           `json:"updated_at"`                                // 更新时间
+}
+
+// PostCardVO 定义了公开信息流中的帖子卡片，是 PostResponse 的精简版本。
+// - 不包含审核状态与审核原因等仅作者或管理员可见的字段，同时减小移动端的响应体积。
+// - 作者本人的列表 (/mine) 与管理员列表仍使用 PostResponse。
+type PostCardVO struct {
+	ID             uint64              `json:"id"`                                        // 帖子ID
+	Title          string              `json:"title"`                                     // 帖子标题
+	AuthorID       string              `json:"author_id"`                                 // 作者ID
+	AuthorAvatar   string              `json:"author_avatar"`                             // 作者头像
+	AuthorUsername string              `json:"author_username"`                           // 作者用户名
+	ViewCount      int64               `json:"view_count"`                                // 浏览量
+	OfficialTag    enums.OfficialTag   `json:"official_tag"`                              // 主官方标签（帖子拥有的标签值最小者，0=无），兼容只认识单个标签的客户端
+	OfficialTags   []enums.OfficialTag `json:"official_tags" swaggertype:"array,integer"` // 帖子拥有的全部官方标签，按标签值升序
+	CreatedAt      time.Time           `json:"created_at"`                                // 创建时间
+	CoverImageID   *uint64             `json:"cover_image_id,omitempty"`                  // A/B 封面实验中分配给当前用户的封面图片ID，未开启实验时省略
+	CoverImageURL  string              `json:"cover_image_url,omitempty"`                 // 列表封面图片URL：A/B 封面实验中分配给当前用户的封面，未开启实验时为首图，无图帖子省略
+
+	// SimilarPosts 时间线开启相似折叠时，被折叠到该代表帖下的内容相似帖子；未折叠时省略
+	SimilarPosts []*PostCardVO `json:"similar_posts,omitempty"`
 }
 
 // ListHotPostsByCursorResponse 查看热门帖子列表（基础信息）游标加载
 type ListHotPostsByCursorResponse struct {
 	Posts      []*PostResponse `json:"posts"`       // 帖子列表
 	NextCursor *uint64         `json:"next_cursor"` // 下一个游标，nil 表示无更多数据
+	Degraded   bool            `json:"degraded"`    // 是否为 Redis 不可用时从数据库查询的近似热榜（排序可能与实时热榜不同）
+	// CursorReset 为 true 表示请求的游标帖子已不在当前热榜中（热榜已重建），本页从估算位置或榜首重新开始，
+	// 可能与已加载的帖子重复，客户端应按帖子 ID 去重。
+	CursorReset bool `json:"cursor_reset"`
 }
 
 // PostTimelinePageVO 定义了帖子时间线分页查询的响应结构。
 // - 包含当前页的帖子列表和下一页的游标信息。
 type PostTimelinePageVO struct {
 	Posts         []*PostResponse `json:"posts"`         // 当前页的帖子摘要列表
-	NextCreatedAt *time.Time      `json:"nextCreatedAt"` // 下一页游标：创建时间，如果为nil表示没有下一页
+	NextCreatedAt *time.Time      `json:"nextCreatedAt"` // 下一页最后一条记录的创建时间（已废弃，仅为兼容保留，翻页只需 nextPostId）
 	NextPostID    *uint64         `json:"nextPostId"`    // 下一页游标：帖子ID，如果为nil表示没有下一页
 }
 
+// PostTimelineCardPageVO 定义了公开帖子时间线的分页响应结构，帖子以精简的卡片形式返回。
+type PostTimelineCardPageVO struct {
+	Posts         []*PostCardVO `json:"posts"`         // 当前页的帖子卡片列表
+	NextCreatedAt *time.Time    `json:"nextCreatedAt"` // 下一页最后一条记录的创建时间（已废弃，仅为兼容保留，翻页只需 nextPostId）
+	NextPostID    *uint64       `json:"nextPostId"`    // 下一页游标：帖子ID，如果为nil表示没有下一页
+}
+
+// PopularPostsPageVO 定义了按浏览量倒序的公开帖子列表的分页响应结构。
+// - 下一页游标由浏览量与帖子ID组成，两者均为 nil 表示没有下一页。
+type PopularPostsPageVO struct {
+	Posts         []*PostResponse `json:"posts"`         // 当前页的帖子摘要列表
+	NextViewCount *int64          `json:"nextViewCount"` // 下一页游标：最后一条记录的浏览量
+	NextPostID    *uint64         `json:"nextPostId"`    // 下一页游标：最后一条记录的帖子ID
+}
+
 // ListUserPostPageVO 定义了自己的发帖的分页的查询响应结构。
 // - 包含当前页的帖子列表和总记录数。
 type ListUserPostPageVO struct {
@@ -45,7 +93,9 @@ type ListUserPostPageVO struct {
 // ListPostsAdminByConditionResponse 定义管理员按条件查询帖子基础信息的响应结构体
 type ListPostsAdminByConditionResponse struct {
 	Posts []*PostResponse `json:"posts"` // 帖子列表
-	Total int64           `json:"total"` // 帖子总数
+	Total int64           `json:"total"` // 帖子总数；游标分页模式下不统计，固定为 -1
+	// NextCursor 下一页的游标，作为 cursor_id 传入即可继续翻页；没有更多数据或按更新时间排序时为空
+	NextCursor *uint64 `json:"next_cursor,omitempty"`
 }
 
 // MapPostsToPostResponsesVO 是一个辅助函数，用于将帖子实体列表转换为帖子响应VO列表。
@@ -64,14 +114,62 @@ func MapPostsToPostResponsesVO(posts []*entities.Post) []*PostResponse {
 			ID:             post.ID,
 			Title:          post.Title,
 			Status:         post.Status,
+			IsDraft:        post.IsDraft,
 			ViewCount:      post.ViewCount,
+			LikeCount:      post.LikeCount,
 			AuthorID:       post.AuthorID,
 			AuthorAvatar:   post.AuthorAvatar,
 			AuthorUsername: post.AuthorUsername,
-			OfficialTag:    post.OfficialTag,
+			OfficialTag:    post.OfficialTags.Primary(),
+			OfficialTags:   post.OfficialTags.Tags(),
+			CopyrightType:  post.CopyrightType,
+			RepostCount:    post.RepostCount,
+			QuotedPostID:   post.QuotedPostID,
+			AppealCount:    post.AppealCount,
 			CreatedAt:      post.CreatedAt,
 			UpdatedAt:      post.UpdatedAt,
 		})
 	}
 	return responses
 }
+
+// MapPostsToCardVO 将帖子实体列表转换为公开信息流使用的卡片列表，空列表返回空切片而不是 nil。
+func MapPostsToCardVO(posts []*entities.Post) []*PostCardVO {
+	cards := make([]*PostCardVO, 0, len(posts))
+	for _, post := range posts {
+		if post == nil {
+			continue
+		}
+		cards = append(cards, &PostCardVO{
+			ID:             post.ID,
+			Title:          post.Title,
+			AuthorID:       post.AuthorID,
+			AuthorAvatar:   post.AuthorAvatar,
+			AuthorUsername: post.AuthorUsername,
+			ViewCount:      post.ViewCount,
+			OfficialTag:    post.OfficialTags.Primary(),
+			OfficialTags:   post.OfficialTags.Tags(),
+			CreatedAt:      post.CreatedAt,
+		})
+	}
+	return cards
+}
+
+// PostLikeVO 点赞/取消点赞后的帖子点赞状态
+type PostLikeVO struct {
+	PostID    uint64 `json:"post_id"`    // 帖子ID
+	Liked     bool   `json:"liked"`      // 当前用户操作后是否处于已点赞状态
+	LikeCount int64  `json:"like_count"` // 操作后的实时点赞数
+}
+
+// MergeAnonymousViewsVO 登录后归并设备匿名浏览记录的结果
+type MergeAnonymousViewsVO struct {
+	MergedPosts int `json:"merged_posts"` // 本次补入用户去重记录的帖子数量（浏览量不变）
+}
+
+// PresignedImageUploadVO 图片直传 COS 的预签名上传信息
+type PresignedImageUploadVO struct {
+	ObjectKey string    `json:"object_key"` // 对象键，上传完成后在创建帖子时通过 image_object_keys 提交
+	UploadURL string    `json:"upload_url"` // 预签名上传 URL，客户端以 PUT 方法上传图片内容，并设置图片的 Content-Type
+	ExpiresAt time.Time `json:"expires_at"` // 上传 URL 的过期时间
+}
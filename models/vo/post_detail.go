@@ -11,15 +11,27 @@ import (
 // 它聚合了 Post 实体、PostDetail 实体以及 PostDetailImage 实体列表的信息。
 type PostDetailVO struct {
 	// --- 来自 Post 实体 ---
-	ID             uint64            `json:"id"`              // 帖子ID
-	CreatedAt      time.Time         `json:"created_at"`      // 创建时间
-	UpdatedAt      time.Time         `json:"updated_at"`      // 更新时间
-	Title          string            `json:"title"`           // 帖子标题
-	AuthorID       string            `json:"author_id"`       // 作者ID
-	AuthorAvatar   string            `json:"author_avatar"`   // 作者头像URL
-	AuthorUsername string            `json:"author_username"` // 作者用户名
-	ViewCount      int64             `json:"view_count"`      // 浏览量
-	OfficialTag    enums.OfficialTag `json:"official_tag"`    // 官方标签 (参考 enums.OfficialTag)
+	ID             uint64              `json:"id"`                                        // 帖子ID
+	CreatedAt      time.Time           `json:"created_at"`                                // 创建时间
+	UpdatedAt      time.Time           `json:"updated_at"`                                // 更新时间
+	Title          string              `json:"title"`                                     // 帖子标题
+	AuthorID       string              `json:"author_id"`                                 // 作者ID
+	AuthorAvatar   string              `json:"author_avatar"`                             // 作者头像URL
+	AuthorUsername string              `json:"author_username"`                           // 作者用户名
+	ViewCount      int64               `json:"view_count"`                                // 浏览量
+	LikeCount      int64               `json:"like_count"`                                // 点赞数（MySQL 中的持久化值，定时同步，可能略低于实时值）
+	Status         enums.Status        `json:"status"`                                    // 审核状态 (0=待审核, 1=已审核, 2=已拒绝)，新建帖子为待审核；前端可据此二次校验
+	AuditReason    *string             `json:"audit_reason,omitempty"`                    // 审核原因（通常为拒绝原因），仅作者本人查看时返回
+	IsDraft        bool                `json:"is_draft"`                                  // 是否为草稿，只有作者本人能看到草稿
+	OfficialTag    enums.OfficialTag   `json:"official_tag"`                              // 主官方标签（帖子拥有的标签值最小者，参考 enums.OfficialTag），兼容只认识单个标签的客户端
+	OfficialTags   []enums.OfficialTag `json:"official_tags" swaggertype:"array,integer"` // 帖子拥有的全部官方标签，按标签值升序
+	CopyrightType  int                 `json:"copyright_type"`                            // 版权声明类型 (0=原创, 1=转载, 2=禁止转载)
+	SourceURL      string              `json:"source_url"`                                // 转载来源地址，非转载帖为空
+	RepostCount    int64               `json:"repost_count"`                              // 被转发次数
+	AccessPolicy   int                 `json:"access_policy"`                             // 详情访问策略 (按位组合: 1=需登录, 2=需付费, 4=需关注作者, 0=公开)
+
+	// --- 转发帖引用的原帖卡片，非转发帖为 nil ---
+	QuotedPost *QuotedPostVO `json:"quoted_post,omitempty"`
 
 	// --- 来自 PostDetail 实体 ---
 	Content      string  `json:"content"`        // 帖子详细HTML内容
@@ -29,6 +41,10 @@ type PostDetailVO struct {
 	// --- 来自 PostDetailImage 实体列表 ---
 	// Images 字段存储了帖子的所有详情图片，并已按 DisplayOrder 排序。
 	Images []PostImageVO `json:"images"` // 详情图片列表
+
+	// --- 来自 PostFAQ 实体列表 ---
+	// FAQs 字段存储了帖子的常见问题解答，已按 DisplayOrder 排序。
+	FAQs []PostFAQVO `json:"faqs"` // FAQ 列表
 }
 
 // PostImageVO 定义了帖子详情中单张图片的视图对象。
@@ -37,6 +53,8 @@ type PostImageVO struct {
 	ImageURL     string `json:"image_url"`     // 图片URL
 	DisplayOrder int    `json:"display_order"` // 图片展示顺序
 	ObjectKey    string `json:"object_key"`    // 图片在COS中的ObjectKey
+
+	OriginalImageURL string `json:"original_image_url,omitempty"` // 原图URL，仅在上传时保留了原图才有值，用于下载原图
 }
 
 // NewPostImageVOFromEntity 将单个 PostDetailImage 实体转换为 PostImageVO。
@@ -51,6 +69,8 @@ func NewPostImageVOFromEntity(entity *entities.PostDetailImage) PostImageVO { //
 		ImageURL:     entity.ImageURL,
 		DisplayOrder: entity.DisplayOrder,
 		ObjectKey:    entity.ObjectKey,
+
+		OriginalImageURL: entity.OriginalImageURL,
 	}
 }
 
@@ -69,3 +89,98 @@ func NewPostImageVOsFromEntities(entities []*entities.PostDetailImage) []PostIma
 	}
 	return vos
 }
+
+// PostFAQVO 定义了帖子详情中单条 FAQ 的视图对象。
+type PostFAQVO struct {
+	Question     string `json:"question"`      // 问题
+	Answer       string `json:"answer"`        // 答案
+	DisplayOrder int    `json:"display_order"` // 展示顺序
+}
+
+// NewPostFAQVOsFromEntities 将 PostFAQ 实体切片转换为 PostFAQVO 切片。
+// 与图片列表一致，返回非 nil 的切片，以便 JSON 序列化为 [] 而不是 null。
+func NewPostFAQVOsFromEntities(faqs []*entities.PostFAQ) []PostFAQVO {
+	vos := make([]PostFAQVO, 0, len(faqs))
+	for _, faq := range faqs {
+		if faq != nil {
+			vos = append(vos, PostFAQVO{
+				Question:     faq.Question,
+				Answer:       faq.Answer,
+				DisplayOrder: faq.DisplayOrder,
+			})
+		}
+	}
+	return vos
+}
+
+// QuotedPostDeletedNotice 是原帖被删除后，转发帖中原帖卡片展示的提示语。
+const QuotedPostDeletedNotice = "原帖已删除"
+
+// QuotedPostVO 定义了转发帖详情中嵌套展示的原帖摘要卡片。
+// - 内容来自转发时保存的快照；原帖被删除后不再返回快照，只返回 Deleted 与提示语。
+type QuotedPostVO struct {
+	ID             uint64 `json:"id"`              // 原帖ID
+	Title          string `json:"title"`           // 原帖标题（快照）
+	AuthorID       string `json:"author_id"`       // 原帖作者ID（快照）
+	AuthorUsername string `json:"author_username"` // 原帖作者用户名（快照）
+	Deleted        bool   `json:"deleted"`         // 原帖是否已被删除
+	Notice         string `json:"notice"`          // 原帖不可见时的提示语，例如“原帖已删除”
+}
+
+// NewQuotedPostVO 根据转发帖实体中的引用快照构造原帖卡片。
+// - post 不是转发帖时返回 nil。
+// - quotedDeleted 为 true 时隐藏快照内容，只展示提示语。
+func NewQuotedPostVO(post *entities.Post, quotedDeleted bool) *QuotedPostVO {
+	if post == nil || post.QuotedPostID == nil {
+		return nil
+	}
+	if quotedDeleted {
+		return &QuotedPostVO{
+			ID:      *post.QuotedPostID,
+			Deleted: true,
+			Notice:  QuotedPostDeletedNotice,
+		}
+	}
+	return &QuotedPostVO{
+		ID:             *post.QuotedPostID,
+		Title:          post.QuotedTitle,
+		AuthorID:       post.QuotedAuthorID,
+		AuthorUsername: post.QuotedAuthorUsername,
+	}
+}
+
+// PostAccessDeniedVO 是帖子详情访问鉴权不通过时返回给前端的数据，用于引导用户操作。
+type PostAccessDeniedVO struct {
+	PostID           uint64   `json:"post_id"`           // 帖子ID
+	AccessPolicy     int      `json:"access_policy"`     // 帖子的访问策略 (按位组合)
+	RequiredPolicies []string `json:"required_policies"` // 帖子要求的全部策略名称，如 ["login", "paid"]
+	DeniedPolicy     string   `json:"denied_policy"`     // 本次未通过的策略名称: login (引导登录) / paid (引导付费) / follower (引导关注作者)
+}
+
+// PostSEOVO 定义了帖子 SEO 元数据的视图对象。
+// - Title、Description、Keywords 为未转义的纯文本，供前端按自身模板渲染。
+// - MetaTags 为已完成 HTML 转义的 <title>/<meta> 标签片段，可直接嵌入页面 <head>。
+type PostSEOVO struct {
+	PostID      uint64   `json:"post_id"`     // 帖子 ID
+	Title       string   `json:"title"`       // 页面标题
+	Description string   `json:"description"` // meta description，最长 constant.SEODescriptionMaxRunes 个字符
+	Keywords    []string `json:"keywords"`    // meta keywords
+	MetaTags    string   `json:"meta_tags"`   // 已转义的 HTML 标签片段
+}
+
+// SimilarPostVO 是发布前查重命中的已有帖子，供作者选择引用（转发）该帖或忽略提示继续发布。
+type SimilarPostVO struct {
+	PostID         uint64       `json:"post_id"`         // 已有帖子ID，引用时作为 quoted_post_id 提交
+	Title          string       `json:"title"`           // 已有帖子标题
+	AuthorID       string       `json:"author_id"`       // 已有帖子作者ID
+	AuthorUsername string       `json:"author_username"` // 已有帖子作者用户名
+	Status         enums.Status `json:"status"`          // 审核状态，作者本人的帖子可能仍在待审核
+	CreatedAt      time.Time    `json:"created_at"`      // 已有帖子创建时间
+	IsOwnPost      bool         `json:"is_own_post"`     // 是否为作者本人的帖子
+	Distance       int          `json:"distance"`        // 与待发布内容 SimHash 指纹的汉明距离，越小越相似
+}
+
+// SimilarPostsVO 是发布前查重的结果；Similar 为空表示没有高度相似的帖子。
+type SimilarPostsVO struct {
+	Similar []*SimilarPostVO `json:"similar"` // 按相似度从高到低排列
+}
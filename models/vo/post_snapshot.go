@@ -0,0 +1,26 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+)
+
+// PostMetricSnapshotVO 定义单条帖子指标快照的视图对象
+type PostMetricSnapshotVO struct {
+	SnapshotAt time.Time    `json:"snapshot_at"`                  // 快照时刻
+	ViewCount  int64        `json:"view_count"`                   // 浏览量
+	LikeCount  int64        `json:"like_count"`                   // 点赞数（定时同步的持久化值）
+	Status     enums.Status `json:"status" swaggertype:"integer"` // 审核状态 (0=待审核, 1=已审核, 2=已拒绝)
+	Rank       *int64       `json:"rank,omitempty"`               // 全站排行榜排名（0 开始），不在排行榜中时为空
+}
+
+// ListPostSnapshotsVO 定义单个帖子历史指标快照查询的响应结构
+type ListPostSnapshotsVO struct {
+	PostID      uint64                  `json:"post_id"`     // 帖子ID
+	Granularity string                  `json:"granularity"` // 快照粒度 (hourly / daily)
+	StartTime   time.Time               `json:"start_time"`  // 实际查询的时间下限（包含）
+	EndTime     time.Time               `json:"end_time"`    // 实际查询的时间上限（不包含）
+	Snapshots   []*PostMetricSnapshotVO `json:"snapshots"`   // 快照列表，按快照时间升序
+	Truncated   bool                    `json:"truncated"`   // 是否因超过 constant.SnapshotQueryMaxRows 被截断
+}
@@ -0,0 +1,18 @@
+package vo
+
+// PostReadDepthVO 帖子的阅读深度统计
+type PostReadDepthVO struct {
+	PostID         uint64               `json:"post_id"`         // 帖子ID
+	Reads          int64                `json:"reads"`           // 阅读次数（同一用户的同一次阅读只计一次）
+	AverageDepth   float64              `json:"average_depth"`   // 平均阅读深度（百分比，0-100）
+	CompletedReads int64                `json:"completed_reads"` // 读完（深度 100）的阅读次数
+	CompletionRate float64              `json:"completion_rate"` // 读完率，CompletedReads / Reads
+	Distribution   []*ReadDepthBucketVO `json:"distribution"`    // 深度分布，按深度升序
+}
+
+// ReadDepthBucketVO 阅读深度分布中的一个区间
+type ReadDepthBucketVO struct {
+	MinDepth int   `json:"min_depth"` // 区间下限（包含）
+	MaxDepth int   `json:"max_depth"` // 区间上限（包含）
+	Reads    int64 `json:"reads"`     // 最大深度落在该区间的阅读次数
+}
@@ -0,0 +1,42 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// DataReportVO 定义了帖子数据报表记录的视图对象，供管理后台展示历史报表。
+type DataReportVO struct {
+	ID          uint64    `json:"id"`           // 报表记录ID
+	Period      string    `json:"period"`       // 统计周期 (daily / weekly)
+	GroupBy     string    `json:"group_by"`     // 分组维度 (none / official_tag)
+	PeriodStart time.Time `json:"period_start"` // 统计区间起始时间
+	PeriodEnd   time.Time `json:"period_end"`   // 统计区间结束时间
+	FileURL     string    `json:"file_url"`     // 报表文件下载地址
+	RowCount    int       `json:"row_count"`    // 报表行数
+	CreatedAt   time.Time `json:"created_at"`   // 生成时间
+}
+
+// ListDataReportsVO 定义了历史报表分页查询的响应结构。
+type ListDataReportsVO struct {
+	Reports []*DataReportVO `json:"reports"` // 当前页的报表列表
+	Total   int64           `json:"total"`   // 符合条件的总记录数
+}
+
+// NewDataReportVOFromEntity 将报表实体转换为 VO。
+func NewDataReportVOFromEntity(report *entities.PostDataReport) *DataReportVO {
+	if report == nil {
+		return nil
+	}
+	return &DataReportVO{
+		ID:          report.ID,
+		Period:      report.Period,
+		GroupBy:     report.GroupBy,
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		FileURL:     report.FileURL,
+		RowCount:    report.RowCount,
+		CreatedAt:   report.CreatedAt,
+	}
+}
@@ -16,7 +16,7 @@ type PostResponseWrapper struct {
 	Data    PostResponse `json:"data"` // 使用具体的 vo.PostResponse
 }
 
-// PostDetailResponseWrapper 对应 response.APIResponse[vo.PostDetailResponse]
+// PostDetailResponseWrapper 对应 response.APIResponse[vo.PostDetailVO]
 type PostDetailResponseWrapper struct {
 	Code    int          `json:"code" example:"0"`
 	Message string       `json:"message,omitempty" example:"success"`
@@ -30,6 +30,84 @@ type ListPostsAdminResponseWrapper struct {
 	Data    ListPostsAdminByConditionResponse `json:"data"` // 使用具体的 vo.ListPostsAdminByConditionResponse
 }
 
+// BatchAuditResponseWrapper 对应 response.APIResponse[vo.BatchAuditResultVO]
+type BatchAuditResponseWrapper struct {
+	Code    int                `json:"code" example:"0"`
+	Message string             `json:"message,omitempty" example:"success"`
+	Data    BatchAuditResultVO `json:"data"`
+}
+
+// BatchResultResponseWrapper 对应 response.APIResponse[vo.BatchResultVO]
+type BatchResultResponseWrapper struct {
+	Code    int           `json:"code" example:"0"`
+	Message string        `json:"message,omitempty" example:"success"`
+	Data    BatchResultVO `json:"data"`
+}
+
+// RecentViewsResponseWrapper 对应 response.APIResponse[vo.RecentViewsVO]
+type RecentViewsResponseWrapper struct {
+	Code    int           `json:"code" example:"0"`
+	Message string        `json:"message,omitempty" example:"success"`
+	Data    RecentViewsVO `json:"data"`
+}
+
+// PostStatsResponseWrapper 对应 response.APIResponse[*vo.PostStatsVO]
+type PostStatsResponseWrapper struct {
+	Code    int         `json:"code" example:"0"`
+	Message string      `json:"message,omitempty" example:"success"`
+	Data    PostStatsVO `json:"data"`
+}
+
+// ListAdminAuditLogsResponseWrapper 对应 response.APIResponse[vo.ListAdminAuditLogsVO]
+type ListAdminAuditLogsResponseWrapper struct {
+	Code    int                  `json:"code" example:"0"`
+	Message string               `json:"message,omitempty" example:"success"`
+	Data    ListAdminAuditLogsVO `json:"data"`
+}
+
+// ListPostAuditLogsResponseWrapper 对应 response.APIResponse[vo.ListPostAuditLogsVO]
+type ListPostAuditLogsResponseWrapper struct {
+	Code    int                 `json:"code" example:"0"`
+	Message string              `json:"message,omitempty" example:"success"`
+	Data    ListPostAuditLogsVO `json:"data"`
+}
+
+// ListReportedPostsResponseWrapper 对应 response.APIResponse[vo.ListReportedPostsVO]
+type ListReportedPostsResponseWrapper struct {
+	Code    int                 `json:"code" example:"0"`
+	Message string              `json:"message,omitempty" example:"success"`
+	Data    ListReportedPostsVO `json:"data"`
+}
+
+// DeleteConfirmRequiredResponseWrapper 对应 response.APIResponse[*vo.DeleteConfirmRequiredVO]
+// 用于删除高浏览量帖子未带 confirm=true 时的响应，data 中携带删除的影响面。
+type DeleteConfirmRequiredResponseWrapper struct {
+	Code    int                     `json:"code" example:"40001"`
+	Message string                  `json:"message,omitempty" example:"该帖子浏览量较高，请确认后带 confirm=true 重新提交删除"`
+	Data    DeleteConfirmRequiredVO `json:"data"`
+}
+
+// PostLikeResponseWrapper 对应 response.APIResponse[vo.PostLikeVO]
+type PostLikeResponseWrapper struct {
+	Code    int        `json:"code" example:"0"`
+	Message string     `json:"message,omitempty" example:"success"`
+	Data    PostLikeVO `json:"data"`
+}
+
+// PostSEOResponseWrapper 对应 response.APIResponse[vo.PostSEOVO]
+type PostSEOResponseWrapper struct {
+	Code    int       `json:"code" example:"0"`
+	Message string    `json:"message,omitempty" example:"success"`
+	Data    PostSEOVO `json:"data"`
+}
+
+// PostFAQsResponseWrapper 对应 response.APIResponse[[]vo.PostFAQVO]
+type PostFAQsResponseWrapper struct {
+	Code    int         `json:"code" example:"0"`
+	Message string      `json:"message,omitempty" example:"success"`
+	Data    []PostFAQVO `json:"data"`
+}
+
 // --- 用于错误响应 或 简单成功响应（只有 Code 和 Message） ---
 
 // BaseResponseWrapper 代表一个只包含 Code 和 Message 的响应。
@@ -49,6 +127,14 @@ type PostTimelinePageResponseWrapper struct {
 	Data    PostTimelinePageVO `json:"data"`                                // 实际的帖子时间线分页数据
 }
 
+// PostTimelineCardPageResponseWrapper 对应 response.APIResponse[vo.PostTimelineCardPageVO]
+// 用于 GetPostsTimeline (公开时间线) 接口的成功响应。
+type PostTimelineCardPageResponseWrapper struct {
+	Code    int                    `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string                 `json:"message,omitempty" example:"success"` // 响应消息
+	Data    PostTimelineCardPageVO `json:"data"`                                // 实际的帖子卡片分页数据
+}
+
 // ListUserPostPageResponseWrapper 对应 response.APIResponse[vo.ListUserPostPageVO]
 // 用于 GetUserPosts (用户获取自己的帖子列表) 接口的成功响应。
 type ListUserPostPageResponseWrapper struct {
@@ -56,3 +142,120 @@ type ListUserPostPageResponseWrapper struct {
 	Message string             `json:"message,omitempty" example:"success"` // 响应消息
 	Data    ListUserPostPageVO `json:"data"`                                // 实际的用户帖子列表分页数据
 }
+
+// ListDataReportsResponseWrapper 对应 response.APIResponse[vo.ListDataReportsVO]
+// 用于管理员查询历史数据报表接口的成功响应。
+type ListDataReportsResponseWrapper struct {
+	Code    int               `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string            `json:"message,omitempty" example:"success"` // 响应消息
+	Data    ListDataReportsVO `json:"data"`                                // 报表列表分页数据
+}
+
+// PostAccessDeniedResponseWrapper 对应 response.APIResponse[*vo.PostAccessDeniedVO]
+// 用于帖子详情访问鉴权不通过（401/402/403）时的响应，data 中携带需要引导用户完成的策略。
+type PostAccessDeniedResponseWrapper struct {
+	Code    int                `json:"code" example:"40301"`                    // 错误码
+	Message string             `json:"message,omitempty" example:"需要关注作者后才能查看"` // 错误消息
+	Data    PostAccessDeniedVO `json:"data"`                                    // 访问策略与未通过的策略
+}
+
+// TagSubscriptionsResponseWrapper 对应 response.APIResponse[*vo.TagSubscriptionsVO]
+// 用于用户查询自己订阅的标签接口的成功响应。
+type TagSubscriptionsResponseWrapper struct {
+	Code    int                `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string             `json:"message,omitempty" example:"success"` // 响应消息
+	Data    TagSubscriptionsVO `json:"data"`                                // 已订阅的标签列表
+}
+
+// ReconcileResyncResponseWrapper 对应 response.APIResponse[*vo.ReconcileResyncResultVO]
+// 用于对账补偿接口的成功响应。
+type ReconcileResyncResponseWrapper struct {
+	Code    int                     `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string                  `json:"message,omitempty" example:"success"` // 响应消息
+	Data    ReconcileResyncResultVO `json:"data"`                                // 各帖子的处理结果
+}
+
+// AuthorBadgesResponseWrapper 对应 response.APIResponse[*vo.AuthorBadgesVO]
+// 用于查询作者徽章接口的成功响应。
+type AuthorBadgesResponseWrapper struct {
+	Code    int            `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string         `json:"message,omitempty" example:"success"` // 响应消息
+	Data    AuthorBadgesVO `json:"data"`                                // 作者徽章列表
+}
+
+// PostMilestoneProgressResponseWrapper 对应 response.APIResponse[*vo.PostMilestoneProgressVO]
+// 用于查询帖子里程碑进度接口的成功响应。
+type PostMilestoneProgressResponseWrapper struct {
+	Code    int                     `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string                  `json:"message,omitempty" example:"success"` // 响应消息
+	Data    PostMilestoneProgressVO `json:"data"`                                // 里程碑进度
+}
+
+// PostCoverStatsResponseWrapper 对应 response.APIResponse[*vo.PostCoverStatsVO]
+// 用于查询 A/B 封面表现统计接口的成功响应。
+type PostCoverStatsResponseWrapper struct {
+	Code    int              `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string           `json:"message,omitempty" example:"success"` // 响应消息
+	Data    PostCoverStatsVO `json:"data"`                                // 各候选封面的表现统计
+}
+
+// AdminPostDetailResponseWrapper 对应 response.APIResponse[vo.AdminPostDetailVO]
+type AdminPostDetailResponseWrapper struct {
+	Code    int               `json:"code" example:"0"`
+	Message string            `json:"message,omitempty" example:"success"`
+	Data    AdminPostDetailVO `json:"data"`
+}
+
+// PopularPostsPageResponseWrapper 对应 response.APIResponse[vo.PopularPostsPageVO]
+type PopularPostsPageResponseWrapper struct {
+	Code    int                `json:"code" example:"0"`
+	Message string             `json:"message,omitempty" example:"success"`
+	Data    PopularPostsPageVO `json:"data"`
+}
+
+// ListPostSnapshotsResponseWrapper 对应 response.APIResponse[vo.ListPostSnapshotsVO]
+type ListPostSnapshotsResponseWrapper struct {
+	Code    int                 `json:"code" example:"0"`
+	Message string              `json:"message,omitempty" example:"success"`
+	Data    ListPostSnapshotsVO `json:"data"`
+}
+
+// PostReadDepthResponseWrapper 对应 response.APIResponse[*vo.PostReadDepthVO]
+// 用于查询帖子阅读深度统计接口的成功响应。
+type PostReadDepthResponseWrapper struct {
+	Code    int             `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string          `json:"message,omitempty" example:"success"` // 响应消息
+	Data    PostReadDepthVO `json:"data"`                                // 阅读深度统计
+}
+
+// PostConversionResponseWrapper 对应 response.APIResponse[*vo.PostConversionVO]
+// 用于查询帖子转化归因分析接口的成功响应。
+type PostConversionResponseWrapper struct {
+	Code    int              `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string           `json:"message,omitempty" example:"success"` // 响应消息
+	Data    PostConversionVO `json:"data"`                                // 转化归因分析
+}
+
+// SimilarPostsResponseWrapper 对应 response.APIResponse[*vo.SimilarPostsVO]
+// 用于发布前查重预览的成功响应，以及创建帖子查重命中（409）时的响应。
+type SimilarPostsResponseWrapper struct {
+	Code    int            `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string         `json:"message,omitempty" example:"success"` // 响应消息
+	Data    SimilarPostsVO `json:"data"`                                // 相似帖子列表
+}
+
+// MergeAnonymousViewsResponseWrapper 对应 response.APIResponse[*vo.MergeAnonymousViewsVO]
+// 用于登录后归并设备匿名浏览记录接口的成功响应。
+type MergeAnonymousViewsResponseWrapper struct {
+	Code    int                   `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string                `json:"message,omitempty" example:"success"` // 响应消息
+	Data    MergeAnonymousViewsVO `json:"data"`                                // 归并结果
+}
+
+// PresignedImageUploadResponseWrapper 对应 response.APIResponse[*vo.PresignedImageUploadVO]
+// 用于申请图片直传 URL 接口的成功响应。
+type PresignedImageUploadResponseWrapper struct {
+	Code    int                    `json:"code" example:"0"`                    // 响应码，0 表示成功
+	Message string                 `json:"message,omitempty" example:"success"` // 响应消息
+	Data    PresignedImageUploadVO `json:"data"`                                // 对象键与上传 URL
+}
@@ -0,0 +1,8 @@
+package vo
+
+import "github.com/Xushengqwer/go-common/models/enums"
+
+// TagSubscriptionsVO 用户已订阅的标签列表
+type TagSubscriptionsVO struct {
+	Tags []enums.OfficialTag `json:"tags" swaggertype:"array,integer"` // 已订阅的官方标签，按标签值升序
+}
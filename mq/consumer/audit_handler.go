@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,7 +17,9 @@ import (
 	"github.com/Xushengqwer/go-common/models/enums"       // 假设 enums 在这里
 	"github.com/Xushengqwer/go-common/models/kafkaevents" // 导入统一的事件结构
 
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/myErrors"
 	"github.com/Xushengqwer/post_service/service"
 )
 
@@ -44,6 +47,12 @@ func NewApprovedAuditHandler(logger *core.ZapLogger, postAdminService service.Po
 func (h *ApprovedAuditHandler) Handle(ctx context.Context, msg kafka.Message) error {
 	h.logger.Debug("ApprovedAuditHandler: 开始处理 Kafka 消息", zap.String("topic", msg.Topic))
 
+	// 本服务自己发出的审核通过事件（批量审核、对账补偿）只用于通知下游，状态已在发送前写入，不再回写
+	if isSelfPublished(msg) {
+		h.logger.Debug("ApprovedAuditHandler: 跳过本服务发送的审核通过事件", zap.Int64("offset", msg.Offset))
+		return nil
+	}
+
 	// 2. 使用从 common 包导入的 kafkaevents.PostApprovedEvent
 	var event kafkaevents.PostApprovedEvent
 	if err := json.Unmarshal(msg.Value, &event); err != nil {
@@ -58,21 +67,32 @@ func (h *ApprovedAuditHandler) Handle(ctx context.Context, msg kafka.Message) er
 		zap.Uint64("post_id", postID))
 
 	auditRequest := &dto.AuditPostRequest{
-		PostID: postID,
-		Status: enums.Approved, // 使用 common/enums 中的 Approved
-		Reason: "",
+		PostID:    postID,
+		Status:    enums.Approved, // 使用 common/enums 中的 Approved
+		Reason:    "",
+		EventTime: eventTimeOf(event.Timestamp),
 	}
 
 	updateCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := h.postAdminService.AuditPost(updateCtx, auditRequest)
+	err := h.postAdminService.AuditPost(updateCtx, auditRequest, constant.AdminAuditOperatorAuditService)
 	if err != nil {
 		h.logger.Error("ApprovedAuditHandler: 更新帖子状态为已通过失败", zap.Error(err), zap.Uint64("post_id", postID))
 		if errors.Is(err, commonerrors.ErrRepoNotFound) {
 			h.logger.Warn("ApprovedAuditHandler: 尝试更新不存在或已删除的帖子状态", zap.Uint64("post_id", postID))
 			return nil // 不再重试
 		}
+		if errors.Is(err, myErrors.ErrStaleAuditEvent) {
+			// 帖子状态在事件产生之后又变更过（如管理员改判、作者申诉重新送审），旧消息不能覆盖当前状态
+			h.logger.Warn("ApprovedAuditHandler: 审核事件已过期，忽略", zap.Uint64("post_id", postID), zap.Time("event_time", event.Timestamp))
+			return nil
+		}
+		if errors.Is(err, myErrors.ErrInvalidCopyright) {
+			// 版权声明不合理属于业务校验失败，重试也不会成功，帖子保持待审核状态等待人工处理
+			h.logger.Warn("ApprovedAuditHandler: 帖子版权声明不合理，保持待审核状态", zap.Uint64("post_id", postID))
+			return nil
+		}
 		return fmt.Errorf("ApprovedAuditHandler: 调用 AuditPost 失败: %w", err)
 	}
 
@@ -145,15 +165,19 @@ func (h *RejectedAuditHandler) Handle(ctx context.Context, msg kafka.Message) er
 		zap.String("generated_reason", auditReason))
 
 	auditRequest := &dto.AuditPostRequest{
-		PostID: postID,
-		Status: enums.Rejected, // 使用 common/enums 中的 Rejected
-		Reason: auditReason,
+		PostID:    postID,
+		Status:    enums.Rejected, // 使用 common/enums 中的 Rejected
+		Reason:    auditReason,
+		EventTime: eventTimeOf(event.Timestamp),
+		// 编辑后分段送审的帖子按段落合并结果：优先使用审核服务回传的段落序号，否则按命中内容定位段落
+		RejectedSegments: rejectedSegmentsOf(msg),
+		MatchedContent:   matchedContentOf(&event),
 	}
 
 	updateCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := h.postAdminService.AuditPost(updateCtx, auditRequest)
+	err := h.postAdminService.AuditPost(updateCtx, auditRequest, constant.AdminAuditOperatorAuditService)
 	if err != nil {
 		h.logger.Error("RejectedAuditHandler: 更新帖子状态为已拒绝失败",
 			zap.Error(err),
@@ -163,9 +187,85 @@ func (h *RejectedAuditHandler) Handle(ctx context.Context, msg kafka.Message) er
 			h.logger.Warn("RejectedAuditHandler: 尝试更新不存在或已删除的帖子状态", zap.Uint64("post_id", postID))
 			return nil // 不再重试
 		}
+		if errors.Is(err, myErrors.ErrStaleAuditEvent) {
+			h.logger.Warn("RejectedAuditHandler: 审核事件已过期，忽略", zap.Uint64("post_id", postID), zap.Time("event_time", event.Timestamp))
+			return nil
+		}
 		return fmt.Errorf("RejectedAuditHandler: 调用 AuditPost 失败: %w", err)
 	}
 
 	h.logger.Info("RejectedAuditHandler: 成功更新帖子状态为已拒绝", zap.Uint64("post_id", postID))
 	return nil
 }
+
+// isSelfPublished 判断消息是否由本服务发送（见 constant.KafkaHeaderEventSource）。
+func isSelfPublished(msg kafka.Message) bool {
+	source, ok := headerValue(msg, constant.KafkaHeaderEventSource)
+	return ok && source == constant.EventSourcePostService
+}
+
+// headerValue 返回消息中第一个键为 key 的消息头取值。
+func headerValue(msg kafka.Message, key string) (string, bool) {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
+}
+
+// rejectedSegmentsOf 解析审核服务回传的未通过段落序号（见 constant.KafkaHeaderAuditRejectedSegments），无法解析的序号被忽略。
+func rejectedSegmentsOf(msg kafka.Message) []int {
+	value, ok := headerValue(msg, constant.KafkaHeaderAuditRejectedSegments)
+	if !ok {
+		return nil
+	}
+	var numbers []int
+	for _, part := range strings.Split(value, ",") {
+		if number, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && number > 0 {
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// matchedContentOf 汇总拒绝详情中的命中内容。
+func matchedContentOf(event *kafkaevents.PostRejectedEvent) []string {
+	var matched []string
+	for _, detail := range event.Details {
+		matched = append(matched, detail.MatchedContent...)
+	}
+	return matched
+}
+
+// eventTimeOf 返回用于乐观更新的事件时间；未携带时间戳的旧版本事件返回 nil，按无条件更新处理。
+func eventTimeOf(timestamp time.Time) *time.Time {
+	if timestamp.IsZero() {
+		return nil
+	}
+	return &timestamp
+}
+
+// PostIDOf 解析审核通过消息中的帖子 ID，用于将同一帖子的消息分发到同一个 worker。
+func (h *ApprovedAuditHandler) PostIDOf(msg kafka.Message) (uint64, bool) {
+	var event struct {
+		Post struct {
+			ID uint64 `json:"id"`
+		} `json:"post"`
+	}
+	if err := json.Unmarshal(msg.Value, &event); err != nil || event.Post.ID == 0 {
+		return 0, false
+	}
+	return event.Post.ID, true
+}
+
+// PostIDOf 解析审核拒绝消息中的帖子 ID，用于将同一帖子的消息分发到同一个 worker。
+func (h *RejectedAuditHandler) PostIDOf(msg kafka.Message) (uint64, bool) {
+	var event struct {
+		PostID uint64 `json:"post_id"`
+	}
+	if err := json.Unmarshal(msg.Value, &event); err != nil || event.PostID == 0 {
+		return 0, false
+	}
+	return event.PostID, true
+}
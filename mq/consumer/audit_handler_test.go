@@ -0,0 +1,114 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/go-common/models/kafkaevents"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/service"
+	"github.com/segmentio/kafka-go"
+)
+
+// recordingAdminService 记录 AuditPost 收到的请求。
+type recordingAdminService struct {
+	service.PostAdminService
+	audited []*dto.AuditPostRequest
+}
+
+func (s *recordingAdminService) AuditPost(ctx context.Context, req *dto.AuditPostRequest, adminUserID string) error {
+	s.audited = append(s.audited, req)
+	return nil
+}
+
+func TestApprovedAuditHandlerSkipsSelfPublishedEvents(t *testing.T) {
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	value, err := json.Marshal(kafkaevents.PostApprovedEvent{
+		EventID:   "event-1",
+		Timestamp: time.Now(),
+		Post:      kafkaevents.PostData{ID: 42, Status: enums.Approved},
+	})
+	if err != nil {
+		t.Fatalf("序列化事件失败: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		headers     []kafka.Header
+		wantAudited bool
+	}{
+		{name: "审核服务发送的事件", wantAudited: true},
+		{name: "其他来源标记", headers: []kafka.Header{{Key: constant.KafkaHeaderEventSource, Value: []byte("audit-service")}}, wantAudited: true},
+		{name: "本服务发送的事件", headers: []kafka.Header{{Key: constant.KafkaHeaderEventSource, Value: []byte(constant.EventSourcePostService)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminSvc := &recordingAdminService{}
+			handler := NewApprovedAuditHandler(logger, adminSvc)
+			if err := handler.Handle(context.Background(), kafka.Message{Value: value, Headers: tt.headers}); err != nil {
+				t.Fatalf("Handle 返回错误: %v", err)
+			}
+			if got := len(adminSvc.audited) > 0; got != tt.wantAudited {
+				t.Fatalf("是否调用 AuditPost = %v, 期望 %v", got, tt.wantAudited)
+			}
+		})
+	}
+}
+
+func TestRejectedAuditHandlerCollectsSegmentResults(t *testing.T) {
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	value, err := json.Marshal(kafkaevents.PostRejectedEvent{
+		EventID:   "event-2",
+		Timestamp: time.Now(),
+		PostID:    42,
+		Details: []kafkaevents.RejectionDetail{
+			{Label: "ad", MatchedContent: []string{"加微信"}},
+			{Label: "abuse", MatchedContent: []string{"骂人"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("序列化事件失败: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		headers  []kafka.Header
+		wantSegs []int
+	}{
+		{name: "未回传段落序号"},
+		{name: "回传段落序号", headers: []kafka.Header{{Key: constant.KafkaHeaderAuditRejectedSegments, Value: []byte("2, 5")}}, wantSegs: []int{2, 5}},
+		{name: "忽略无法解析的序号", headers: []kafka.Header{{Key: constant.KafkaHeaderAuditRejectedSegments, Value: []byte("x,0,3,")}}, wantSegs: []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminSvc := &recordingAdminService{}
+			handler := NewRejectedAuditHandler(logger, adminSvc)
+			if err := handler.Handle(context.Background(), kafka.Message{Value: value, Headers: tt.headers}); err != nil {
+				t.Fatalf("Handle 返回错误: %v", err)
+			}
+			if len(adminSvc.audited) != 1 {
+				t.Fatalf("AuditPost 调用次数 = %d, 期望 1", len(adminSvc.audited))
+			}
+			req := adminSvc.audited[0]
+			if !slices.Equal(req.RejectedSegments, tt.wantSegs) {
+				t.Errorf("RejectedSegments = %v, 期望 %v", req.RejectedSegments, tt.wantSegs)
+			}
+			if want := []string{"加微信", "骂人"}; !slices.Equal(req.MatchedContent, want) {
+				t.Errorf("MatchedContent = %v, 期望 %v", req.MatchedContent, want)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package consumer
 import (
 	"context"
 	"errors"
+	"hash/fnv"
 	"io"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Xushengqwer/go-common/core"
@@ -12,15 +15,26 @@ import (
 	"go.uber.org/zap"
 
 	appConfig "github.com/Xushengqwer/post_service/config"
-	// 不再需要 "github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/constant"
 )
 
-// Consumer 定义 Kafka 消费者结构 (保持不变)
+// Consumer 定义 Kafka 消费者结构
+// - 读取循环只负责拉取消息并分发，消息由 concurrency 个 worker 并发处理。
+// - handler 实现 PostIDExtractor 时按帖子 ID 分发，否则按消息 Key（为空时按分区）分发，保证同一帖子的消息有序处理。
+// - offset 通过 offsets 手动提交：同一分区内更早的消息全部处理完成后才提交，不会跳过未处理的消息。
 type Consumer struct {
-	reader  *kafka.Reader
-	handler MessageHandler
-	logger  *core.ZapLogger
-	topic   string
+	reader      *kafka.Reader
+	handler     MessageHandler
+	logger      *core.ZapLogger
+	topic       string
+	concurrency int
+	offsets     *offsetTracker
+}
+
+// PostIDExtractor 是 MessageHandler 的可选接口，用于从消息中解析帖子 ID，作为分发到 worker 的依据。
+// - 无法解析时返回 false，消息按 Key 或分区分发。
+type PostIDExtractor interface {
+	PostIDOf(msg kafka.Message) (uint64, bool)
 }
 
 // NewConsumer 创建 Kafka Consumer 实例 (修改为直接接收 topicName)
@@ -34,13 +48,19 @@ func NewConsumer(cfg *appConfig.KafkaConfig, groupID string, topicName string, h
 	if len(cfg.Brokers) == 0 {
 		return nil, errors.New("kafka brokers 配置不能为空")
 	}
+	concurrency := cfg.ConsumerConcurrency
+	if concurrency <= 0 {
+		concurrency = constant.KafkaConsumerDefaultConcurrency
+	}
 
 	logger.Info("初始化 Kafka 消费者",
 		zap.Strings("brokers", cfg.Brokers),
 		zap.String("topic", topicName),
-		zap.String("group_id", groupID))
+		zap.String("group_id", groupID),
+		zap.Int("concurrency", concurrency))
 
 	// 使用 segmentio/kafka-go 的 NewReader
+	// offset 由 Consumer 在消息处理完成后通过 CommitMessages 提交，CommitInterval 内的提交会合并后异步发送，Reader 关闭时刷出最后一批
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:        cfg.Brokers,
 		Topic:          topicName, // <--- 直接使用传入的 topicName
@@ -52,33 +72,49 @@ func NewConsumer(cfg *appConfig.KafkaConfig, groupID string, topicName string, h
 	})
 
 	return &Consumer{
-		reader:  reader,
-		handler: handler,
-		logger:  logger,
-		topic:   topicName,
+		reader:      reader,
+		handler:     handler,
+		logger:      logger,
+		topic:       topicName,
+		concurrency: concurrency,
+		offsets:     newOffsetTracker(),
 	}, nil
 }
 
-// Start 启动消费者循环来读取和处理消息 (保持不变)
+// Start 启动消费者循环来读取和分发消息，阻塞直到 ctx 取消且所有 worker 退出。
+// - ctx 取消后不再读取新消息；worker 处理完手上的消息后退出，队列中尚未开始处理的消息不提交 offset，重启后重新投递。
+// - 处理失败的消息只记录日志并照常提交 offset（与此前的自动提交行为一致），避免阻塞同分区后续消息。
 func (c *Consumer) Start(ctx context.Context) {
-	c.logger.Info("Kafka 消费者已启动", zap.String("topic", c.topic))
+	c.logger.Info("Kafka 消费者已启动", zap.String("topic", c.topic), zap.Int("concurrency", c.concurrency))
 	defer c.logger.Info("Kafka 消费者已停止", zap.String("topic", c.topic))
 
-	for {
-		// 检查 context 是否已取消
-		select {
-		case <-ctx.Done():
-			c.logger.Warn("消费者上下文已取消，正在退出...", zap.String("topic", c.topic))
-			return
-		default:
-			// 继续执行
-		}
+	workers := make([]chan kafka.Message, c.concurrency)
+	var wg sync.WaitGroup
+	for i := range workers {
+		workers[i] = make(chan kafka.Message, constant.KafkaConsumerWorkerQueueSize)
+		wg.Add(1)
+		go func(queue <-chan kafka.Message) {
+			defer wg.Done()
+			c.runWorker(ctx, queue)
+		}(workers[i])
+	}
 
-		msg, err := c.reader.ReadMessage(ctx)
+	c.dispatch(ctx, workers)
 
+	for _, queue := range workers {
+		close(queue)
+	}
+	c.logger.Info("等待 Kafka 消费者 worker 处理完在途消息...", zap.String("topic", c.topic))
+	wg.Wait()
+}
+
+// dispatch 循环读取消息并分发到 worker，ctx 取消或 Reader 关闭时返回。
+func (c *Consumer) dispatch(ctx context.Context, workers []chan kafka.Message) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
 		if err != nil {
 			// 如果 context 被取消或 Reader 关闭，正常退出
-			if errors.Is(err, context.Canceled) || errors.Is(err, io.EOF) || errors.Is(err, os.ErrClosed) {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) || errors.Is(err, os.ErrClosed) {
 				c.logger.Warn("消费者读取循环退出", zap.String("topic", c.topic), zap.Error(err))
 				return
 			}
@@ -87,17 +123,67 @@ func (c *Consumer) Start(ctx context.Context) {
 			continue
 		}
 
-		handleCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		c.offsets.track(msg)
+		select {
+		case workers[c.workerIndex(msg)] <- msg:
+		case <-ctx.Done():
+			c.logger.Warn("消费者上下文已取消，正在退出...", zap.String("topic", c.topic))
+			return
+		}
+	}
+}
+
+// runWorker 顺序处理分配给该 worker 的消息，并在可以推进时提交所在分区的 offset。
+func (c *Consumer) runWorker(ctx context.Context, queue <-chan kafka.Message) {
+	for msg := range queue {
+		if ctx.Err() != nil {
+			continue // 关停中：排队的消息不再处理，offset 不提交
+		}
+
+		// 使用独立的 context，关停时在途消息可以处理完成
+		handleCtx, cancel := context.WithTimeout(context.Background(), constant.KafkaConsumerHandleTimeout)
 		handleErr := c.handler.Handle(handleCtx, msg)
 		cancel()
-
 		if handleErr != nil {
 			c.logger.Error("处理 Kafka 消息时发生错误",
 				zap.Error(handleErr),
 				zap.String("topic", msg.Topic),
+				zap.Int("partition", msg.Partition),
 				zap.Int64("offset", msg.Offset))
 		}
+
+		commitOffset, ok := c.offsets.complete(msg)
+		if !ok {
+			continue
+		}
+		commitMsg := kafka.Message{Topic: msg.Topic, Partition: msg.Partition, Offset: commitOffset}
+		if err := c.reader.CommitMessages(context.Background(), commitMsg); err != nil {
+			c.logger.Error("提交 Kafka offset 失败，重启后可能重复消费",
+				zap.Error(err),
+				zap.String("topic", msg.Topic),
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", commitOffset))
+		}
+	}
+}
+
+// workerIndex 计算消息应分发到的 worker：优先按帖子 ID，其次按消息 Key，都没有时按分区。
+func (c *Consumer) workerIndex(msg kafka.Message) int {
+	if c.concurrency == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	if extractor, ok := c.handler.(PostIDExtractor); ok {
+		if postID, ok := extractor.PostIDOf(msg); ok {
+			h.Write([]byte(strconv.FormatUint(postID, 10)))
+			return int(h.Sum32() % uint32(c.concurrency))
+		}
+	}
+	if len(msg.Key) > 0 {
+		h.Write(msg.Key)
+		return int(h.Sum32() % uint32(c.concurrency))
 	}
+	return msg.Partition % c.concurrency
 }
 
 // Close 关闭 Kafka Reader (保持不变)
@@ -0,0 +1,57 @@
+package consumer
+
+import (
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// offsetTracker 记录每个分区已读取但未提交的消息，计算可以安全提交的 offset。
+// - 消息被并发处理，完成顺序与读取顺序不同；只有分区内更早的消息全部完成后，offset 才能向前推进。
+// - 同一分区读取到不大于已记录最大值的 offset 时（再均衡后重新投递），丢弃该分区的旧记录重新开始。
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+// partitionOffsets 是单个分区按读取顺序排列的未提交 offset。
+type partitionOffsets struct {
+	pending []int64
+	done    map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[int]*partitionOffsets)}
+}
+
+// track 在消息分发给 worker 之前记录其 offset。
+func (t *offsetTracker) track(msg kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.partitions[msg.Partition]
+	if !ok || (len(p.pending) > 0 && msg.Offset <= p.pending[len(p.pending)-1]) {
+		p = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[msg.Partition] = p
+	}
+	p.pending = append(p.pending, msg.Offset)
+}
+
+// complete 标记消息处理完成，返回分区内连续完成的最大 offset。
+// - 返回 false 表示分区内仍有更早的消息未完成，暂时不能提交。
+func (t *offsetTracker) complete(msg kafka.Message) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.partitions[msg.Partition]
+	if !ok || len(p.pending) == 0 || msg.Offset < p.pending[0] {
+		return 0, false // 分区记录已被重置，该消息会被重新投递
+	}
+	p.done[msg.Offset] = true
+
+	committable := int64(-1)
+	for len(p.pending) > 0 && p.done[p.pending[0]] {
+		committable = p.pending[0]
+		delete(p.done, committable)
+		p.pending = p.pending[1:]
+	}
+	return committable, committable >= 0
+}
@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// UserInfoUpdatedEvent 是用户服务发布的用户信息变更事件。
+// - 只携带发生变更的字段，未变更的字段省略（反序列化为 nil）。
+type UserInfoUpdatedEvent struct {
+	EventID   string    `json:"event_id"`
+	Timestamp time.Time `json:"timestamp"`
+	UserID    string    `json:"user_id"`
+	Username  *string   `json:"username,omitempty"`   // 新昵称
+	AvatarURL *string   `json:"avatar_url,omitempty"` // 新头像 URL
+}
+
+// --- UserInfoUpdatedHandler ---
+
+// UserInfoUpdatedHandler 消费用户信息变更事件，同步该用户所有帖子中的作者头像与昵称。
+// - 用户服务以用户 ID 作为消息 Key，同一用户的事件由同一个 worker 按顺序处理，不会被旧事件覆盖。
+type UserInfoUpdatedHandler struct {
+	logger        *core.ZapLogger
+	authorInfoSvc service.AuthorInfoSyncService
+}
+
+func NewUserInfoUpdatedHandler(logger *core.ZapLogger, authorInfoSvc service.AuthorInfoSyncService) *UserInfoUpdatedHandler {
+	return &UserInfoUpdatedHandler{
+		logger:        logger,
+		authorInfoSvc: authorInfoSvc,
+	}
+}
+
+func (h *UserInfoUpdatedHandler) Handle(ctx context.Context, msg kafka.Message) error {
+	h.logger.Debug("UserInfoUpdatedHandler: 开始处理 Kafka 消息", zap.String("topic", msg.Topic))
+
+	var event UserInfoUpdatedEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		h.logger.Error("UserInfoUpdatedHandler: 反序列化 Kafka 消息失败", zap.Error(err), zap.ByteString("value", msg.Value))
+		return nil // 不重试无法解析的消息
+	}
+	if event.UserID == "" {
+		h.logger.Warn("UserInfoUpdatedHandler: 消息缺少 user_id，忽略", zap.String("event_id", event.EventID))
+		return nil
+	}
+	if event.Username == nil && event.AvatarURL == nil {
+		h.logger.Debug("UserInfoUpdatedHandler: 头像与昵称均未变更，忽略", zap.String("event_id", event.EventID), zap.String("user_id", event.UserID))
+		return nil
+	}
+
+	// 作者帖子很多时需要执行多批更新，单条消息的默认处理超时不够用
+	syncCtx, cancel := context.WithTimeout(context.Background(), constant.AuthorInfoSyncTimeout)
+	defer cancel()
+
+	updated, err := h.authorInfoSvc.SyncAuthorInfo(syncCtx, event.UserID, event.AvatarURL, event.Username)
+	if err != nil {
+		h.logger.Error("UserInfoUpdatedHandler: 同步作者信息失败",
+			zap.Error(err),
+			zap.String("event_id", event.EventID),
+			zap.String("user_id", event.UserID),
+			zap.Int64("updated_posts", updated))
+		return fmt.Errorf("UserInfoUpdatedHandler: 调用 SyncAuthorInfo 失败: %w", err)
+	}
+
+	h.logger.Info("UserInfoUpdatedHandler: 成功同步作者信息",
+		zap.String("event_id", event.EventID),
+		zap.String("user_id", event.UserID),
+		zap.Int64("updated_posts", updated))
+	return nil
+}
@@ -3,6 +3,10 @@ package producer
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time" // 引入 time 包
 
 	"github.com/Xushengqwer/go-common/core"
@@ -10,32 +14,73 @@ import (
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 
+	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/go-common/models/kafkaevents"
 	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
 )
 
 // KafkaProducer Kafka 消息生产者 (保持不变)
 type KafkaProducer struct {
-	writer *kafka.Writer
-	logger *core.ZapLogger
-	topics config.Topics
+	writer       *kafka.Writer
+	brokers      []string
+	logger       *core.ZapLogger
+	topics       config.Topics
+	maxRetries   int           // 发送失败后的本地重试次数
+	retryBackoff time.Duration // 首次重试前的等待时间，之后每次翻倍
 }
 
-// NewKafkaProducer 创建一个新的 Kafka 生产者实例 (保持不变)
+// NewKafkaProducer 创建一个新的 Kafka 生产者实例
+// - config 中未配置（为 0）的重试参数退回 constant.KafkaSend* 默认值
 func NewKafkaProducer(config config.KafkaConfig, logger *core.ZapLogger) *KafkaProducer {
 	writer := &kafka.Writer{
 		Addr:     kafka.TCP(config.Brokers...),
 		Balancer: &kafka.LeastBytes{},
 	}
+	maxRetries := config.SendMaxRetries
+	if maxRetries <= 0 {
+		maxRetries = constant.KafkaSendMaxRetries
+	}
+	retryBackoff := config.SendRetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = constant.KafkaSendRetryBackoff
+	}
 	return &KafkaProducer{
-		writer: writer,
-		logger: logger,
-		topics: config.Topics,
+		writer:       writer,
+		brokers:      config.Brokers,
+		logger:       logger,
+		topics:       config.Topics,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+	}
+}
+
+// Ping 依次尝试连接配置的 broker，任一 broker 可连接即返回 nil，用于就绪探针。
+func (p *KafkaProducer) Ping(ctx context.Context) error {
+	if len(p.brokers) == 0 {
+		return errors.New("未配置 Kafka brokers")
+	}
+	var lastErr error
+	for _, broker := range p.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_ = conn.Close()
+		return nil
 	}
+	return fmt.Errorf("所有 Kafka broker 均无法连接: %w", lastErr)
 }
 
-// SendEvent 发送事件到指定 Kafka 主题 (保持不变，但现在会处理统一的事件结构)
+// SendEvent 发送事件到指定 Kafka 主题，失败时按指数退避在本地重试
 func (p *KafkaProducer) SendEvent(ctx context.Context, topic string, event interface{}) error {
+	return p.sendEventWithHeaders(ctx, topic, event, nil)
+}
+
+// sendEventWithHeaders 发送事件到指定 Kafka 主题，并附带消息头
+func (p *KafkaProducer) sendEventWithHeaders(ctx context.Context, topic string, event interface{}, headers []kafka.Header) error {
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
 		p.logger.Error("Failed to marshal event", zap.Error(err), zap.String("topic", topic))
@@ -46,9 +91,10 @@ func (p *KafkaProducer) SendEvent(ctx context.Context, topic string, event inter
 		zap.String("topic", topic),
 		zap.ByteString("payload", eventBytes))
 
-	err = p.writer.WriteMessages(ctx, kafka.Message{
-		Topic: topic,
-		Value: eventBytes,
+	err = p.writeMessages(ctx, kafka.Message{
+		Topic:   topic,
+		Value:   eventBytes,
+		Headers: headers,
 	})
 
 	if err != nil {
@@ -59,11 +105,125 @@ func (p *KafkaProducer) SendEvent(ctx context.Context, topic string, event inter
 	return err
 }
 
+// writeMessages 写入消息，失败时按指数退避重试至多 maxRetries 次
+// - ctx 被取消或超时时立即放弃，返回最后一次的写入错误
+// - 批量写入的部分失败同样整体重试，下游需按 EventID 幂等处理
+func (p *KafkaProducer) writeMessages(ctx context.Context, msgs ...kafka.Message) error {
+	backoff := p.retryBackoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = p.writer.WriteMessages(ctx, msgs...); err == nil {
+			return nil
+		}
+		if attempt >= p.maxRetries {
+			return err
+		}
+		p.logger.Warn("Kafka message write failed, retrying",
+			zap.Error(err),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// NewPostPendingAuditOutboxEvent 构建帖子待审核事件的发件箱记录，供调用方在业务事务内写入
+// - 主题与消息头的选择与 SendPostPendingAuditEvent 一致
+// - 记录的 NextAttemptAt 为当前时间加 constant.OutboxRelayDelay，期间由调用方负责即时投递
+func (p *KafkaProducer) NewPostPendingAuditOutboxEvent(postData kafkaevents.PostData, meta PendingAuditMeta) (*entities.OutboxEvent, error) {
+	topic, event, headers := p.pendingAuditEvent(postData, meta)
+	return newOutboxEvent(topic, event, headers)
+}
+
+// NewPostDeleteOutboxEvent 构建帖子删除事件的发件箱记录，供调用方在业务事务内写入
+func (p *KafkaProducer) NewPostDeleteOutboxEvent(postID uint64, meta PostDeleteMeta) (*entities.OutboxEvent, error) {
+	return newOutboxEvent(p.topics.PostDeleted, newPostDeletedEvent(postID, meta), nil)
+}
+
+// newOutboxEvent 序列化事件与消息头，生成发件箱记录
+func newOutboxEvent(topic string, event interface{}, headers []kafka.Header) (*entities.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("序列化发件箱事件失败: %w", err)
+	}
+	outboxEvent := &entities.OutboxEvent{
+		Topic:         topic,
+		Payload:       payload,
+		NextAttemptAt: time.Now().Add(constant.OutboxRelayDelay),
+	}
+	if len(headers) > 0 {
+		headerMap := make(map[string]string, len(headers))
+		for _, h := range headers {
+			headerMap[h.Key] = string(h.Value)
+		}
+		headerBytes, err := json.Marshal(headerMap)
+		if err != nil {
+			return nil, fmt.Errorf("序列化发件箱消息头失败: %w", err)
+		}
+		outboxEvent.Headers = string(headerBytes)
+	}
+	return outboxEvent, nil
+}
+
+// SendOutboxEvent 投递一条发件箱记录中的事件（包含本地重试）
+// - 事件内容原样发送，重复投递时 EventID 不变
+func (p *KafkaProducer) SendOutboxEvent(ctx context.Context, event *entities.OutboxEvent) error {
+	var headers []kafka.Header
+	if event.Headers != "" {
+		var headerMap map[string]string
+		if err := json.Unmarshal([]byte(event.Headers), &headerMap); err != nil {
+			return fmt.Errorf("解析发件箱消息头失败 (ID: %d): %w", event.ID, err)
+		}
+		for k, v := range headerMap {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+	}
+
+	err := p.writeMessages(ctx, kafka.Message{
+		Topic:   event.Topic,
+		Value:   event.Payload,
+		Headers: headers,
+	})
+	if err != nil {
+		p.logger.Error("Failed to write outbox event", zap.Error(err), zap.String("topic", event.Topic), zap.Uint64("outbox_id", event.ID))
+		return err
+	}
+	p.logger.Info("Successfully sent outbox event", zap.String("topic", event.Topic), zap.Uint64("outbox_id", event.ID))
+	return nil
+}
+
 // SendPostPendingAuditEvent 发送帖子待审核事件到 Kafka (重构)
 // - 意图: 将新创建或更新的帖子发送到 Kafka 供审核服务消费
-// - 输入: ctx context.Context 上下文, postData kafkaevents.PostData 帖子核心数据
+// - 输入: ctx context.Context 上下文, postData kafkaevents.PostData 帖子核心数据, meta 审核优先级与地区合规信息
 // - 输出: error 错误信息
-func (p *KafkaProducer) SendPostPendingAuditEvent(ctx context.Context, postData kafkaevents.PostData) error {
+// - 优先级: 消息头 audit-priority 标记优先级；高优先级且配置了 PostPendingAuditHigh 时发往高优先级主题
+func (p *KafkaProducer) SendPostPendingAuditEvent(ctx context.Context, postData kafkaevents.PostData, meta PendingAuditMeta) error {
+	topic, event, headers := p.pendingAuditEvent(postData, meta)
+	return p.sendEventWithHeaders(ctx, topic, event, headers)
+}
+
+// PendingAuditMeta 是随待审核事件通过消息头携带的附加信息
+type PendingAuditMeta struct {
+	Priority     int      // 审核优先级 (constant.AuditPriority*)
+	Regions      []string // 帖子的目标地区，审核服务据此选择地区规则
+	FlaggedWords []string // 合规预检命中的待复审敏感词
+	ManualReview bool     // 目标地区的规则是否要求人工复审
+	AppealReason string   // 作者申诉被拒帖子时填写的理由，非申诉送审为空
+	AppealCount  int      // 包含本次在内的累计申诉次数，非申诉送审为 0
+	Incremental  bool     // 作者编辑后的增量送审，事件 Content 只包含变更的段落
+	Segments     []int    // 增量送审时 Content 中各段落的序号（从 1 开始）
+	Added        []int    // 增量送审时其中新增段落的序号，其余为修改的段落
+}
+
+// pendingAuditEvent 创建帖子待审核事件，并根据优先级选择主题与消息头
+func (p *KafkaProducer) pendingAuditEvent(postData kafkaevents.PostData, meta PendingAuditMeta) (string, kafkaevents.PostPendingAuditEvent, []kafka.Header) {
 	// 1. 创建统一的 PostPendingAuditEvent 事件
 	event := kafkaevents.PostPendingAuditEvent{
 		EventID:   uuid.New().String(), // 生成唯一的 EventID
@@ -71,24 +231,251 @@ func (p *KafkaProducer) SendPostPendingAuditEvent(ctx context.Context, postData
 		Post:      postData,            // 嵌入 PostData
 	}
 
-	// 2. 发送事件到 PostPendingAudit 主题
+	// 2. 根据优先级选择主题与消息头
 	//    注意：我们现在从 p.topics.PostPendingAudit 获取主题名称
-	return p.SendEvent(ctx, p.topics.PostPendingAudit, event)
+	topic := p.topics.PostPendingAudit
+	priorityValue := "normal"
+	if meta.Priority == constant.AuditPriorityHigh {
+		priorityValue = "high"
+		if p.topics.PostPendingAuditHigh != "" {
+			topic = p.topics.PostPendingAuditHigh
+		}
+	}
+	headers := []kafka.Header{{Key: constant.KafkaHeaderAuditPriority, Value: []byte(priorityValue)}}
+
+	// 3. 地区合规信息只在有值时携带，未升级的审核服务可忽略这些消息头
+	if len(meta.Regions) > 0 {
+		headers = append(headers, kafka.Header{Key: constant.KafkaHeaderAuditRegions, Value: []byte(strings.Join(meta.Regions, ","))})
+	}
+	if len(meta.FlaggedWords) > 0 {
+		headers = append(headers, kafka.Header{Key: constant.KafkaHeaderAuditFlaggedWords, Value: []byte(strings.Join(meta.FlaggedWords, ","))})
+	}
+	if meta.ManualReview {
+		headers = append(headers, kafka.Header{Key: constant.KafkaHeaderAuditManualReview, Value: []byte("true")})
+	}
+	if meta.AppealCount > 0 {
+		headers = append(headers,
+			kafka.Header{Key: constant.KafkaHeaderAuditAppealReason, Value: []byte(meta.AppealReason)},
+			kafka.Header{Key: constant.KafkaHeaderAuditAppealCount, Value: []byte(strconv.Itoa(meta.AppealCount))})
+	}
+	if meta.Incremental {
+		headers = append(headers, kafka.Header{Key: constant.KafkaHeaderAuditIncremental, Value: []byte("true")})
+		if len(meta.Segments) > 0 {
+			headers = append(headers, kafka.Header{Key: constant.KafkaHeaderAuditSegments, Value: []byte(joinInts(meta.Segments))})
+		}
+		if len(meta.Added) > 0 {
+			headers = append(headers, kafka.Header{Key: constant.KafkaHeaderAuditAddedSegments, Value: []byte(joinInts(meta.Added))})
+		}
+	}
+	return topic, event, headers
+}
+
+// joinInts 将整数列表拼接为逗号分隔的消息头取值
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// PostDeletedEvent 帖子删除事件，在公共库 kafkaevents.PostDeletedEvent 的基础上携带删除上下文
+// - 公共事件以匿名嵌入的方式展开在同一层级，event_id、timestamp、post_id 字段不变，只读 post_id 的下游无需修改
+// - 下游（如搜索服务）可直接按 author_id 清理作者维度的索引，无需回查本服务
+type PostDeletedEvent struct {
+	kafkaevents.PostDeletedEvent
+	AuthorID  string     `json:"author_id,omitempty"`  // 帖子作者ID，对账补偿等无法确定作者时为空
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // 删除时间，对账补偿重发时为空
+	Source    string     `json:"source"`               // 删除来源 (constant.PostDeleteSource*)
+}
+
+// PostDeleteMeta 是随删除事件携带的删除上下文
+type PostDeleteMeta struct {
+	AuthorID  string
+	DeletedAt time.Time // 为零值时事件不携带 deleted_at
+	Source    string    // constant.PostDeleteSource*
 }
 
 // SendPostDeleteEvent 发送帖子删除事件到 Kafka (重构)
 // - 意图: 将帖子删除事件发送到 PostDeleted 主题
-// - 输入: ctx context.Context 上下文, postID uint64 帖子ID
+// - 输入: ctx context.Context 上下文, postID uint64 帖子ID, meta PostDeleteMeta 删除上下文
 // - 输出: error 错误信息
-func (p *KafkaProducer) SendPostDeleteEvent(ctx context.Context, postID uint64) error {
-	// 1. 创建统一的 PostDeletedEvent 事件
-	event := kafkaevents.PostDeletedEvent{
-		EventID:   uuid.New().String(), // 生成唯一的 EventID
-		Timestamp: time.Now(),          // 设置当前时间戳
-		PostID:    postID,              // 设置 PostID
+func (p *KafkaProducer) SendPostDeleteEvent(ctx context.Context, postID uint64, meta PostDeleteMeta) error {
+	// 发送事件到 PostDeleted 主题
+	// 注意：我们现在从 p.topics.PostDeleted 获取主题名称
+	return p.SendEvent(ctx, p.topics.PostDeleted, newPostDeletedEvent(postID, meta))
+}
+
+// newPostDeletedEvent 创建携带删除上下文的 PostDeletedEvent 事件
+func newPostDeletedEvent(postID uint64, meta PostDeleteMeta) PostDeletedEvent {
+	event := PostDeletedEvent{
+		PostDeletedEvent: kafkaevents.PostDeletedEvent{
+			EventID:   uuid.New().String(), // 生成唯一的 EventID
+			Timestamp: time.Now(),          // 设置当前时间戳
+			PostID:    postID,              // 设置 PostID
+		},
+		AuthorID: meta.AuthorID,
+		Source:   meta.Source,
+	}
+	if !meta.DeletedAt.IsZero() {
+		deletedAt := meta.DeletedAt
+		event.DeletedAt = &deletedAt
+	}
+	return event
+}
+
+// SendPostApprovedEvents 批量发送帖子审核通过事件到 Kafka
+// - 意图: 管理员批量审核通过后，通知下游（如搜索服务）同步帖子数据
+// - 输入: ctx context.Context 上下文, posts []kafkaevents.PostData 审核通过的帖子数据
+// - 输出: error 错误信息
+// - 注意: 所有消息通过一次 WriteMessages 调用写入；消息头标记来源为本服务，本服务自身的 ApprovedAuditHandler 会跳过这些消息
+func (p *KafkaProducer) SendPostApprovedEvents(ctx context.Context, posts []kafkaevents.PostData) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	messages := make([]kafka.Message, 0, len(posts))
+	for _, postData := range posts {
+		event := kafkaevents.PostApprovedEvent{
+			EventID:   uuid.New().String(),
+			Timestamp: time.Now(),
+			Post:      postData,
+		}
+		eventBytes, err := json.Marshal(event)
+		if err != nil {
+			p.logger.Error("Failed to marshal approved event", zap.Error(err), zap.Uint64("post_id", postData.ID))
+			return err
+		}
+		messages = append(messages, kafka.Message{
+			Topic:   p.topics.PostAuditApproved,
+			Value:   eventBytes,
+			Headers: []kafka.Header{{Key: constant.KafkaHeaderEventSource, Value: []byte(constant.EventSourcePostService)}},
+		})
+	}
+
+	if err := p.writeMessages(ctx, messages...); err != nil {
+		p.logger.Error("Failed to write approved events", zap.Error(err), zap.Int("count", len(messages)))
+		return err
+	}
+	p.logger.Info("Successfully sent approved events", zap.String("topic", p.topics.PostAuditApproved), zap.Int("count", len(messages)))
+	return nil
+}
+
+// TagNewPostEvent 标签新帖推送事件，由推送服务消费并向 SubscriberIDs 中的用户下发通知
+// - 订阅者较多时同一帖子会拆分为多条事件，每条携带至多 constant.TagNewPostBatchSize 个订阅者
+type TagNewPostEvent struct {
+	EventID        string            `json:"event_id"`
+	Timestamp      time.Time         `json:"timestamp"`
+	Tag            enums.OfficialTag `json:"tag"`
+	PostID         uint64            `json:"post_id"`
+	Title          string            `json:"title"`
+	AuthorID       string            `json:"author_id"`
+	AuthorUsername string            `json:"author_username"`
+	SubscriberIDs  []string          `json:"subscriber_ids"`
+}
+
+// TagNewPostEnabled 返回是否配置了标签新帖推送主题。
+func (p *KafkaProducer) TagNewPostEnabled() bool {
+	return p.topics.TagNewPost != ""
+}
+
+// SendTagNewPostEvents 批量发送标签新帖推送事件到 Kafka
+// - 意图: 帖子审核通过且带有官方标签后，按订阅者分批通知推送服务
+// - 输入: ctx context.Context 上下文, events []TagNewPostEvent 同一批订阅者对应的事件
+// - 输出: error 错误信息
+// - 注意: 所有消息通过一次 WriteMessages 调用写入；未配置 TagNewPost 主题时直接返回
+func (p *KafkaProducer) SendTagNewPostEvents(ctx context.Context, events []TagNewPostEvent) error {
+	if len(events) == 0 || !p.TagNewPostEnabled() {
+		return nil
 	}
 
-	// 2. 发送事件到 PostDeleted 主题
-	//    注意：我们现在从 p.topics.PostDeleted 获取主题名称
-	return p.SendEvent(ctx, p.topics.PostDeleted, event)
+	messages := make([]kafka.Message, 0, len(events))
+	for i := range events {
+		eventBytes, err := json.Marshal(&events[i])
+		if err != nil {
+			p.logger.Error("Failed to marshal tag new post event", zap.Error(err), zap.Uint64("post_id", events[i].PostID))
+			return err
+		}
+		messages = append(messages, kafka.Message{
+			Topic: p.topics.TagNewPost,
+			Value: eventBytes,
+		})
+	}
+
+	if err := p.writeMessages(ctx, messages...); err != nil {
+		p.logger.Error("Failed to write tag new post events", zap.Error(err), zap.Int("count", len(messages)))
+		return err
+	}
+	p.logger.Info("Successfully sent tag new post events", zap.String("topic", p.topics.TagNewPost), zap.Int("count", len(messages)))
+	return nil
+}
+
+// PostSnapshot 对账快照中单个帖子的状态
+type PostSnapshot struct {
+	ID        uint64       `json:"id"`
+	Status    enums.Status `json:"status"`
+	Deleted   bool         `json:"deleted"`    // 帖子已被删除，下游应移除该帖子
+	UpdatedAt int64        `json:"updated_at"` // 帖子最后更新时间（Unix 毫秒），下游据此判断自身数据是否过期
+}
+
+// PostReconcileSnapshotEvent 帖子状态增量快照事件，供搜索、推荐等下游服务对账
+// - 一个对账窗口 [WindowStart, WindowEnd) 内发生变更的帖子会拆分为多条事件，每条至多 constant.PostReconcilePageSize 个帖子
+// - 下游发现缺失或过期的帖子后调用管理员补偿接口，由本服务重新发送审核通过/删除事件
+type PostReconcileSnapshotEvent struct {
+	EventID     string         `json:"event_id"`
+	Timestamp   time.Time      `json:"timestamp"`
+	WindowStart time.Time      `json:"window_start"`
+	WindowEnd   time.Time      `json:"window_end"`
+	Posts       []PostSnapshot `json:"posts"`
+}
+
+// ReconcileSnapshotEnabled 返回是否配置了对账快照主题。
+func (p *KafkaProducer) ReconcileSnapshotEnabled() bool {
+	return p.topics.PostReconcileSnapshot != ""
+}
+
+// SendPostReconcileSnapshotEvent 发送一条帖子状态快照事件到 Kafka
+// - 意图: 对账任务增量导出帖子状态，供下游服务比对
+// - 输入: ctx context.Context 上下文, event *PostReconcileSnapshotEvent 快照事件（EventID、Timestamp 为空时自动填充）
+// - 输出: error 错误信息；未配置 PostReconcileSnapshot 主题时直接返回
+func (p *KafkaProducer) SendPostReconcileSnapshotEvent(ctx context.Context, event *PostReconcileSnapshotEvent) error {
+	if !p.ReconcileSnapshotEnabled() {
+		return nil
+	}
+	if event.EventID == "" {
+		event.EventID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	return p.SendEvent(ctx, p.topics.PostReconcileSnapshot, event)
+}
+
+// PostReportThresholdEvent 帖子举报数达到阈值事件，供审核服务对帖子做自动下架审查
+// - 每个帖子只在举报数恰好达到阈值时发送一次
+type PostReportThresholdEvent struct {
+	EventID     string    `json:"event_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	PostID      uint64    `json:"post_id"`
+	Title       string    `json:"title"`
+	AuthorID    string    `json:"author_id"`
+	ReportCount int64     `json:"report_count"` // 触发时的举报数
+	Threshold   int64     `json:"threshold"`    // 触发审查的举报数阈值
+}
+
+// ReportThresholdEnabled 返回是否配置了举报阈值审查主题。
+func (p *KafkaProducer) ReportThresholdEnabled() bool {
+	return p.topics.PostReportThreshold != ""
+}
+
+// NewPostReportThresholdOutboxEvent 构建举报数达到阈值事件的发件箱记录，供调用方在写入举报的事务内写入
+// - EventID、Timestamp 为空时自动填充
+func (p *KafkaProducer) NewPostReportThresholdOutboxEvent(event *PostReportThresholdEvent) (*entities.OutboxEvent, error) {
+	if event.EventID == "" {
+		event.EventID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	return newOutboxEvent(p.topics.PostReportThreshold, event, nil)
 }
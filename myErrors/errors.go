@@ -4,3 +4,90 @@ import "errors"
 
 // ErrCacheMiss 表示在缓存层未找到对应的键值
 var ErrCacheMiss = errors.New("cache: key not found (miss)")
+
+// ErrInvalidCopyright 表示帖子的版权声明不合理（例如转载帖未填写来源地址）
+var ErrInvalidCopyright = errors.New("post: invalid copyright declaration")
+
+// ErrPermissionDenied 表示当前用户无权操作该资源（例如非作者修改帖子）
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrPostNotDeleted 表示帖子当前未处于删除状态，无需恢复
+var ErrPostNotDeleted = errors.New("post: post is not deleted")
+
+// ErrQuotedPostUnavailable 表示被转发的原帖不存在、已删除或尚未审核通过
+var ErrQuotedPostUnavailable = errors.New("post: quoted post is unavailable")
+
+// ErrAccessLoginRequired 表示帖子详情需要登录后才能访问
+var ErrAccessLoginRequired = errors.New("post access: login required")
+
+// ErrAccessPaymentRequired 表示帖子详情需要付费后才能访问
+var ErrAccessPaymentRequired = errors.New("post access: payment required")
+
+// ErrAccessForbidden 表示当前用户不满足帖子详情的访问策略（例如未关注作者）
+var ErrAccessForbidden = errors.New("post access: forbidden")
+
+// ErrUnsupportedAccessPolicy 表示帖子设置的访问策略在当前部署中没有可用的鉴权钩子
+var ErrUnsupportedAccessPolicy = errors.New("post access: unsupported access policy")
+
+// ErrPostContentEmpty 表示帖子正文经过 HTML 清洗后没有剩余的有效内容
+var ErrPostContentEmpty = errors.New("post: content is empty after sanitization")
+
+// ErrDeleteConfirmRequired 表示删除的帖子影响面较大（如浏览量超过阈值），需要管理员二次确认
+var ErrDeleteConfirmRequired = errors.New("post: delete requires confirmation")
+
+// ErrRepostNotAllowed 表示原帖声明了禁止转载，不允许转发
+var ErrRepostNotAllowed = errors.New("post: repost is not allowed by the original post")
+
+// ErrInvalidPostImage 表示上传的帖子图片不合法（数量、大小超限或不是图片文件）
+var ErrInvalidPostImage = errors.New("post: invalid post image")
+
+// ErrPostNotDraft 表示帖子不是草稿（或已被发布），无法执行草稿发布
+var ErrPostNotDraft = errors.New("post: post is not a draft")
+
+// ErrPostIsDraft 表示帖子仍是草稿，作者发布前不能被审核
+var ErrPostIsDraft = errors.New("post: post is still a draft")
+
+// ErrEventPublishingUnavailable 表示当前部署未配置 Kafka，无法发送同步事件
+var ErrEventPublishingUnavailable = errors.New("post: event publishing is unavailable")
+
+// ErrInvalidSearchKeyword 表示搜索关键词为空，或剔除全文检索运算符后没有剩余的有效内容
+var ErrInvalidSearchKeyword = errors.New("post search: invalid keyword")
+
+// ErrInvalidCoverCandidates 表示候选封面设置不合法（数量不在允许范围内，或图片不属于该帖子）
+var ErrInvalidCoverCandidates = errors.New("post: invalid cover candidates")
+
+// ErrInvalidTimeRange 表示查询的时间范围不合法（起始时间不早于结束时间，或跨度超过上限）
+var ErrInvalidTimeRange = errors.New("post: invalid time range")
+
+// ErrPostAlreadyReported 表示用户已经举报过该帖子，不能重复举报
+var ErrPostAlreadyReported = errors.New("post report: already reported")
+
+// ErrReadDepthTooFrequent 表示同一次阅读的阅读深度上报过于频繁
+var ErrReadDepthTooFrequent = errors.New("post read depth: report too frequent")
+
+// ErrInvalidOfficialTag 表示官方标签不在合法的枚举范围内
+var ErrInvalidOfficialTag = errors.New("post: invalid official tag")
+
+// ErrContentNotCompliant 表示帖子内容命中了目标地区规则集中的禁止词，不允许创建或发布
+var ErrContentNotCompliant = errors.New("post: content is not compliant with the target regions")
+
+// ErrSimilarPostsFound 表示平台上已有与待发布内容高度相似的帖子，作者确认后可以忽略提示继续发布
+var ErrSimilarPostsFound = errors.New("post: similar posts already exist")
+
+// ErrDeviceIDRequired 表示请求没有携带设备标识 (X-Device-ID)，无法定位该设备的匿名浏览记录
+var ErrDeviceIDRequired = errors.New("post view: device id is required")
+
+// ErrIdempotentRequestInProgress 表示携带相同幂等键的请求仍在处理中，客户端应稍后重试
+var ErrIdempotentRequestInProgress = errors.New("post: request with the same idempotency key is in progress")
+
+// ErrStaleAuditEvent 表示审核结果事件早于帖子审核状态的最后一次变更，是乱序或重投的过期消息，不应覆盖帖子当前状态
+var ErrStaleAuditEvent = errors.New("post audit: audit event is older than the last post status change")
+
+// ErrPostNotRejected 表示帖子当前不是审核拒绝状态（或已被并发申诉），无法申诉
+var ErrPostNotRejected = errors.New("post appeal: post is not rejected")
+
+// ErrPostNotEditable 表示帖子当前状态不允许编辑（草稿尚未发布、或正在审核中）
+var ErrPostNotEditable = errors.New("post edit: post is not editable in its current status")
+
+// ErrAppealLimitExceeded 表示帖子的申诉次数已达上限
+var ErrAppealLimitExceeded = errors.New("post appeal: appeal limit exceeded")
@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// AdminAuditLogRepository 定义了管理员操作审计日志的持久化操作接口。
+type AdminAuditLogRepository interface {
+	// CreateAuditLog 追加一条审计日志。
+	CreateAuditLog(ctx context.Context, log *entities.AdminAuditLog) error
+
+	// ListAuditLogs 按条件分页查询审计日志，按操作时间倒序。
+	// - 查询走从库 (dbresolver.Read)，不影响线上写库。
+	ListAuditLogs(ctx context.Context, req *dto.ListAdminAuditLogsRequest, offset, limit int) ([]*entities.AdminAuditLog, int64, error)
+}
+
+// adminAuditLogRepository 是 AdminAuditLogRepository 接口针对 MySQL 的具体实现。
+type adminAuditLogRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewAdminAuditLogRepository 是 adminAuditLogRepository 的构造函数。
+func NewAdminAuditLogRepository(db *gorm.DB, logger *core.ZapLogger) AdminAuditLogRepository {
+	return &adminAuditLogRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateAuditLog 实现审计日志的插入。
+func (r *adminAuditLogRepository) CreateAuditLog(ctx context.Context, log *entities.AdminAuditLog) error {
+	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+		r.logger.Error("保存管理员审计日志失败", zap.Error(err),
+			zap.String("adminUserID", log.AdminUserID),
+			zap.String("action", log.Action),
+			zap.String("targetID", log.TargetID))
+		return err
+	}
+	return nil
+}
+
+// ListAuditLogs 实现审计日志的条件分页查询。
+func (r *adminAuditLogRepository) ListAuditLogs(ctx context.Context, req *dto.ListAdminAuditLogsRequest, offset, limit int) ([]*entities.AdminAuditLog, int64, error) {
+	var logs []*entities.AdminAuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Clauses(dbresolver.Read).Model(&entities.AdminAuditLog{})
+	if req.AdminUserID != "" {
+		query = query.Where("admin_user_id = ?", req.AdminUserID)
+	}
+	if req.Action != "" {
+		query = query.Where("action = ?", req.Action)
+	}
+	if req.TargetID != "" {
+		query = query.Where("target_id = ?", req.TargetID)
+	}
+	if req.Result != "" {
+		query = query.Where("result = ?", req.Result)
+	}
+	if req.StartTime != nil {
+		query = query.Where("created_at >= ?", *req.StartTime)
+	}
+	if req.EndTime != nil {
+		query = query.Where("created_at < ?", *req.EndTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("统计管理员审计日志数量失败", zap.Error(err), zap.Any("request", req))
+		return nil, 0, fmt.Errorf("统计审计日志失败: %w", err)
+	}
+	if total == 0 {
+		return logs, 0, nil
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
+		r.logger.Error("分页查询管理员审计日志失败", zap.Error(err), zap.Any("request", req))
+		return nil, 0, fmt.Errorf("查询审计日志失败: %w", err)
+	}
+	return logs, total, nil
+}
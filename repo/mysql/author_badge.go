@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// AuthorBadgeRepository 定义了作者徽章的持久化操作接口。
+type AuthorBadgeRepository interface {
+	// CreateBadges 批量颁发徽章，(post_id, milestone) 已存在的记录依靠联合唯一索引忽略，保证重复颁发幂等。
+	// - 返回实际新增的徽章数量。
+	CreateBadges(ctx context.Context, badges []*entities.AuthorBadge) (int64, error)
+
+	// GetAwardedMilestones 查询一批帖子已颁发过徽章的里程碑，返回 map[postID]map[milestone]bool。
+	// - 读取走主库：颁发前的判重不能受主从延迟影响。
+	GetAwardedMilestones(ctx context.Context, postIDs []uint64) (map[uint64]map[int64]bool, error)
+
+	// ListBadgesByAuthor 查询作者获得的全部徽章，按颁发时间倒序，走从库 (dbresolver.Read)。
+	ListBadgesByAuthor(ctx context.Context, authorID string) ([]*entities.AuthorBadge, error)
+
+	// ListBadgesByPost 查询帖子已达成的里程碑徽章，按里程碑升序，走从库 (dbresolver.Read)。
+	ListBadgesByPost(ctx context.Context, postID uint64) ([]*entities.AuthorBadge, error)
+}
+
+// authorBadgeRepository 是 AuthorBadgeRepository 接口针对 MySQL 的具体实现。
+type authorBadgeRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewAuthorBadgeRepository 是 authorBadgeRepository 的构造函数。
+func NewAuthorBadgeRepository(db *gorm.DB, logger *core.ZapLogger) AuthorBadgeRepository {
+	return &authorBadgeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateBadges 实现徽章的批量颁发。
+func (r *authorBadgeRepository) CreateBadges(ctx context.Context, badges []*entities.AuthorBadge) (int64, error) {
+	if len(badges) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&badges)
+	if result.Error != nil {
+		r.logger.Error("批量颁发作者徽章失败", zap.Error(result.Error), zap.Int("count", len(badges)))
+		return 0, fmt.Errorf("批量颁发作者徽章失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetAwardedMilestones 实现已颁发里程碑的批量查询。
+func (r *authorBadgeRepository) GetAwardedMilestones(ctx context.Context, postIDs []uint64) (map[uint64]map[int64]bool, error) {
+	awarded := make(map[uint64]map[int64]bool)
+	if len(postIDs) == 0 {
+		return awarded, nil
+	}
+	var badges []*entities.AuthorBadge
+	err := r.db.WithContext(ctx).
+		Select("post_id", "milestone").
+		Where("post_id IN ?", postIDs).
+		Find(&badges).Error
+	if err != nil {
+		r.logger.Error("查询帖子已颁发的里程碑失败", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return nil, fmt.Errorf("查询帖子已颁发的里程碑失败: %w", err)
+	}
+	for _, b := range badges {
+		if awarded[b.PostID] == nil {
+			awarded[b.PostID] = make(map[int64]bool)
+		}
+		awarded[b.PostID][b.Milestone] = true
+	}
+	return awarded, nil
+}
+
+// ListBadgesByAuthor 实现作者徽章列表的查询。
+func (r *authorBadgeRepository) ListBadgesByAuthor(ctx context.Context, authorID string) ([]*entities.AuthorBadge, error) {
+	var badges []*entities.AuthorBadge
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Read).
+		Where("author_id = ?", authorID).
+		Order("created_at DESC, id DESC").
+		Find(&badges).Error
+	if err != nil {
+		r.logger.Error("查询作者徽章失败", zap.Error(err), zap.String("authorID", authorID))
+		return nil, fmt.Errorf("查询作者徽章失败: %w", err)
+	}
+	return badges, nil
+}
+
+// ListBadgesByPost 实现帖子里程碑徽章的查询。
+func (r *authorBadgeRepository) ListBadgesByPost(ctx context.Context, postID uint64) ([]*entities.AuthorBadge, error) {
+	var badges []*entities.AuthorBadge
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Read).
+		Where("post_id = ?", postID).
+		Order("milestone ASC").
+		Find(&badges).Error
+	if err != nil {
+		r.logger.Error("查询帖子里程碑徽章失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("查询帖子里程碑徽章失败: %w", err)
+	}
+	return badges, nil
+}
@@ -3,6 +3,7 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Xushengqwer/go-common/core"
 	"strings"
@@ -10,10 +11,12 @@ import (
 	"time"
 
 	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/entities"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PostBatchOperationsRepository defines the interface for batch database operations,
@@ -21,8 +24,37 @@ import (
 type PostBatchOperationsRepository interface {
 	// BatchUpdatePostViewCounts 异步、并发地将 Redis 中的浏览量批量同步到 MySQL。
 	// 设计目标是高吞吐量和容错性，允许在单个任务中处理大量更新，并记录但不中断因部分批次失败。
+	// - 部分批次失败时返回 *ViewCountBatchError，其中携带未写入的浏览量，调用方可据此持久化后重试。
 	BatchUpdatePostViewCounts(ctx context.Context, viewCounts map[uint64]int64) error
 
+	// GetPostViewCounts 批量获取帖子在 MySQL 中持久化的浏览量（不含已删除帖子）。
+	// - 用于 Redis 中已归档（被删除）的浏览量计数器回源；不存在的帖子不会出现在返回的映射中。
+	GetPostViewCounts(ctx context.Context, postIDs []uint64) (map[uint64]int64, error)
+
+	// GetPostLikeCounts 批量获取帖子在 MySQL 中持久化的点赞数（不含已删除帖子），用于 Redis 点赞计数器回源。
+	GetPostLikeCounts(ctx context.Context, postIDs []uint64) (map[uint64]int64, error)
+
+	// BatchUpdatePostLikeCounts 将 Redis 中的点赞数分批写回 MySQL（只更新 like_count 一列）。
+	// - 按 viewSyncCfg.BatchSize 分批顺序执行，单批失败不中断其余批次，最终返回汇总错误。
+	BatchUpdatePostLikeCounts(ctx context.Context, likeCounts map[uint64]int64) error
+
+	// ListChangedPostSnapshots 按 (column, id) 游标读取 column 落在 [since, until) 内的帖子，用于增量对账导出状态快照。
+	// - column 取 constant.PostReconcileColumnUpdatedAt 或 constant.PostReconcileColumnDeletedAt。
+	// - 包含已软删除的帖子，只读取 ID、状态、更新时间与删除时间。
+	// - 游标为上一页最后一条记录的 (afterTime, afterID)，首页传入零值 afterTime。
+	ListChangedPostSnapshots(ctx context.Context, column string, since, until, afterTime time.Time, afterID uint64, limit int) ([]*entities.Post, error)
+
+	// GetMaxPostID 返回未删除帖子中最大的 ID，没有帖子时返回 0，用于浏览量一致性校验随机选取抽样起点。
+	GetMaxPostID(ctx context.Context) (uint64, error)
+
+	// ListPostViewCountsAfter 按主键顺序读取 ID 大于 afterID 的至多 limit 个未删除帖子（只包含 ID 与 ViewCount）。
+	// - 用于浏览量一致性校验分批抽样，按主键范围扫描，不产生全表扫描。
+	ListPostViewCountsAfter(ctx context.Context, afterID uint64, limit int) ([]*entities.Post, error)
+
+	// ArchivePostViewCounts 将冷数据归档时读取到的 Redis 浏览量写入 MySQL。
+	// - 只更新 view_count 一列，且只增不减 (GREATEST)，避免覆盖更新的值。
+	ArchivePostViewCounts(ctx context.Context, viewCounts map[uint64]int64) error
+
 	// GetPostDetailsByPostIDs 批量获取帖子详情。
 	// 主要用于为热门帖子缓存等场景提供数据源，通过单次查询减少数据库负载。
 	GetPostDetailsByPostIDs(ctx context.Context, postIDs []uint64) ([]*entities.PostDetail, error)
@@ -37,6 +69,10 @@ type PostBatchOperationsRepository interface {
 	// 其中键是 postDetailID，值是 *entities.PostDetailImage 的切片。
 	// 如果某个 postDetailID 没有图片，它仍然会存在于映射中，对应的值是一个空切片。
 	BatchGetPostDetailImages(ctx context.Context, postDetailIDs []uint64) (map[uint64][]*entities.PostDetailImage, error)
+
+	// BatchGetPostFAQs 批量获取多个帖子的 FAQ 列表。
+	// 返回 map[postID][]*entities.PostFAQ，每个帖子的 FAQ 按 DisplayOrder 升序排列；没有 FAQ 的帖子不会出现在映射中。
+	BatchGetPostFAQs(ctx context.Context, postIDs []uint64) (map[uint64][]*entities.PostFAQ, error)
 }
 
 type postBatchOperationsRepository struct {
@@ -50,10 +86,39 @@ func NewPostBatchOperationsRepository(db *gorm.DB, logger *core.ZapLogger, viewS
 	return &postBatchOperationsRepository{db: db, logger: logger, viewSyncCfg: viewSyncCfg}
 }
 
-// updateItem 是一个内部结构体，用于在并发处理通道中传递 ID 和对应的浏览量。
+// updateItem 是一个内部结构体，用于在并发处理通道中传递 ID 和对应的计数值（浏览量或点赞数）。
 type updateItem struct {
-	ID        uint64
-	ViewCount int64
+	ID    uint64
+	Count int64
+}
+
+// ViewCountBatchError 是 BatchUpdatePostViewCounts 部分批次失败时返回的错误。
+// - Failed 包含写入失败以及因上下文取消未执行的批次中的全部浏览量，调用方可用 errors.As 取出后重试。
+type ViewCountBatchError struct {
+	Failed        map[uint64]int64 // 未写入 MySQL 的帖子浏览量
+	FailedBatches int              // 未成功的批次数
+	TotalBatches  int              // 总批次数
+	Errs          []error          // 各失败批次的错误
+}
+
+// Error 汇总各失败批次的错误信息。
+func (e *ViewCountBatchError) Error() string {
+	errorStrings := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		errorStrings = append(errorStrings, err.Error())
+	}
+	return fmt.Sprintf("并发批量更新过程中发生错误 (%d / %d 个批次失败): %s", e.FailedBatches, e.TotalBatches, strings.Join(errorStrings, "; "))
+}
+
+// Unwrap 返回各失败批次的错误，便于调用方用 errors.Is 判断上下文取消等原因。
+func (e *ViewCountBatchError) Unwrap() []error {
+	return e.Errs
+}
+
+// viewCountBatchResult 是单个批次的处理结果。
+type viewCountBatchResult struct {
+	batch []updateItem
+	err   error
 }
 
 // BatchUpdatePostViewCounts 实现了浏览量批量同步的核心逻辑。
@@ -65,11 +130,11 @@ type updateItem struct {
 // 核心机制:
 // 1. 数据分批: 根据配置 `viewSyncCfg.BatchSize` 将大量更新分割成小批次。
 // 2. 并发处理: 根据配置 `viewSyncCfg.ConcurrencyLevel` 启动 worker goroutine 池处理这些批次。
-// 3. 数据库更新: 每个 worker 对其批次内的数据，通过 `processBatch` 方法构建单条 SQL (通常使用 CASE WHEN) 更新数据库。
+// 3. 数据库更新: 每个 worker 对其批次内的数据，通过 `processBatch` 方法以单条批量 SQL 更新数据库 (默认 INSERT ... ON DUPLICATE KEY UPDATE，可通过 `viewSyncCfg.UpdateStrategy` 切换为 CASE WHEN)。
 //
 // 设计目标:
 // 高效同步数据，同时通过分批和并发控制数据库负载，保证服务稳定性。
-// 允许部分批次失败（记录错误并聚合返回），以实现最终一致性。
+// 允许部分批次失败（记录错误并以 *ViewCountBatchError 聚合返回），以实现最终一致性。
 func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Context, viewCounts map[uint64]int64) error {
 	totalUpdates := len(viewCounts)
 	if totalUpdates == 0 {
@@ -93,7 +158,7 @@ func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Co
 	// --- 2. 数据准备与日志记录 ---
 	itemsToUpdate := make([]updateItem, 0, totalUpdates)
 	for id, count := range viewCounts {
-		itemsToUpdate = append(itemsToUpdate, updateItem{ID: id, ViewCount: count})
+		itemsToUpdate = append(itemsToUpdate, updateItem{ID: id, Count: count})
 	}
 
 	totalBatches := (totalUpdates + batchSize - 1) / batchSize
@@ -107,7 +172,7 @@ func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Co
 	// --- 3. 设置并发工作池 ---
 	var wg sync.WaitGroup
 	jobs := make(chan []updateItem, concurrencyLevel)
-	results := make(chan error, totalBatches)
+	results := make(chan viewCountBatchResult, totalBatches)
 	overallStartTime := time.Now()
 
 	// --- 4. 启动 Worker Goroutines ---
@@ -121,13 +186,13 @@ func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Co
 				select {
 				case <-ctx.Done():
 					r.logger.Warn("上下文取消，Worker 停止处理", zap.Int("workerID", workerID), zap.Error(ctx.Err()))
-					results <- fmt.Errorf("worker %d: context cancelled: %w", workerID, ctx.Err())
+					results <- viewCountBatchResult{batch: batch, err: fmt.Errorf("worker %d: context cancelled: %w", workerID, ctx.Err())}
 					continue
 				default:
 				}
 
 				err := r.processBatch(ctx, batch, workerID)
-				results <- err
+				results <- viewCountBatchResult{batch: batch, err: err}
 			}
 			r.logger.Debug("Worker 正常退出", zap.Int("workerID", workerID))
 		}(i)
@@ -166,17 +231,25 @@ func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Co
 	}()
 
 	// --- 7. 收集并聚合结果 ---
+	// 分发因上下文取消提前结束时，未分发的批次不会出现在结果中，因此以成功写入的帖子反推未写入的浏览量
+	succeeded := make(map[uint64]struct{}, totalUpdates)
+	succeededBatches := 0
 	r.logger.Info("开始收集处理结果...")
-	for err := range results {
-		if err != nil {
-			aggregatedErrors = append(aggregatedErrors, err)
+	for result := range results {
+		if result.err != nil {
+			aggregatedErrors = append(aggregatedErrors, result.err)
+			continue
+		}
+		succeededBatches++
+		for _, item := range result.batch {
+			succeeded[item.ID] = struct{}{}
 		}
 	}
 	r.logger.Info("结果收集完毕。")
 
 	// --- 8. 最终日志记录与返回 ---
 	totalDuration := time.Since(overallStartTime)
-	failedCount := len(aggregatedErrors)
+	failedCount := totalBatches - succeededBatches
 	r.logger.Info("完成所有批次的帖子浏览量并发更新处理。",
 		zap.Duration("总耗时", totalDuration),
 		zap.Int("总批次数", totalBatches),
@@ -184,12 +257,22 @@ func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Co
 	)
 
 	if failedCount > 0 {
-		var errorStrings []string
-		for _, e := range aggregatedErrors {
-			errorStrings = append(errorStrings, e.Error())
+		failed := make(map[uint64]int64, totalUpdates-len(succeeded))
+		for id, count := range viewCounts {
+			if _, ok := succeeded[id]; !ok {
+				failed[id] = count
+			}
+		}
+		if len(aggregatedErrors) < failedCount {
+			aggregatedErrors = append(aggregatedErrors, fmt.Errorf("%d 个批次因上下文取消未分发: %w", failedCount-len(aggregatedErrors), ctx.Err()))
 		}
-		finalError := fmt.Errorf("并发批量更新过程中发生错误 (%d / %d 个批次失败): %s", failedCount, totalBatches, strings.Join(errorStrings, "; "))
-		r.logger.Error("并发批量更新最终结果：失败", zap.Error(finalError))
+		finalError := &ViewCountBatchError{
+			Failed:        failed,
+			FailedBatches: failedCount,
+			TotalBatches:  totalBatches,
+			Errs:          aggregatedErrors,
+		}
+		r.logger.Error("并发批量更新最终结果：失败", zap.Error(finalError), zap.Int("未写入帖子数", len(failed)))
 		return finalError
 	}
 
@@ -198,31 +281,27 @@ func (r *postBatchOperationsRepository) BatchUpdatePostViewCounts(ctx context.Co
 }
 
 // processBatch 负责处理单个批次的数据库更新。
+// - 按 viewSyncCfg.UpdateStrategy 选择 upsertViewCounts（默认）或 caseWhenUpdateViewCounts。
 func (r *postBatchOperationsRepository) processBatch(ctx context.Context, batch []updateItem, workerID int) error {
 	currentBatchSize := len(batch)
-
-	var (
-		ids          []uint64
-		sqlCase      strings.Builder
-		updateParams []interface{}
-	)
-	sqlCase.WriteString("CASE id ")
-	for _, item := range batch {
-		ids = append(ids, item.ID)
-		sqlCase.WriteString("WHEN ? THEN ? ")
-		updateParams = append(updateParams, item.ID, item.ViewCount)
+	strategy := r.viewSyncCfg.UpdateStrategy
+	if strategy != constant.ViewSyncStrategyCaseWhen {
+		strategy = constant.ViewSyncStrategyUpsert
 	}
-	sqlCase.WriteString("END")
 
 	dbOperationStart := time.Now()
-	err := r.db.WithContext(ctx).Model(&entities.Post{}).
-		Where("id IN ?", ids).
-		Update("view_count", gorm.Expr(sqlCase.String(), updateParams...)).Error
+	var err error
+	if strategy == constant.ViewSyncStrategyCaseWhen {
+		err = r.caseWhenUpdateViewCounts(ctx, batch)
+	} else {
+		err = r.upsertViewCounts(ctx, batch)
+	}
 	dbDuration := time.Since(dbOperationStart)
 
 	if err != nil {
 		r.logger.Error("processBatch: 数据库更新批次失败",
 			zap.Int("workerID", workerID),
+			zap.String("strategy", strategy),
 			zap.Int("batchSize", currentBatchSize),
 			zap.Duration("db耗时", dbDuration),
 			zap.Error(err),
@@ -232,12 +311,232 @@ func (r *postBatchOperationsRepository) processBatch(ctx context.Context, batch
 
 	r.logger.Debug("processBatch: 数据库更新批次成功",
 		zap.Int("workerID", workerID),
+		zap.String("strategy", strategy),
 		zap.Int("batchSize", currentBatchSize),
 		zap.Duration("db耗时", dbDuration),
 	)
 	return nil
 }
 
+// upsertViewCounts 使用 INSERT ... ON DUPLICATE KEY UPDATE 批量写回浏览量。
+//   - 冲突时只更新 view_count 一列，不会改动 updated_at 等其他列。
+//   - ON DUPLICATE KEY 在主键不存在时会插入新行，因此先在同一事务内用 SELECT ... FOR UPDATE 锁定
+//     仍存在（未软删除）的帖子，只对这些行写入；锁定期间行无法被删除，保证写入必然命中冲突分支、不会插入新行。
+//   - 插入分支的其余列由 GORM 按零值填充，只为满足 NOT NULL 约束，实际不会落库。
+func (r *postBatchOperationsRepository) upsertViewCounts(ctx context.Context, batch []updateItem) error {
+	ids := make([]uint64, 0, len(batch))
+	for _, item := range batch {
+		ids = append(ids, item.ID)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingIDs []uint64
+		if err := tx.Model(&entities.Post{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", ids).
+			Pluck("id", &existingIDs).Error; err != nil {
+			return fmt.Errorf("锁定待更新帖子失败: %w", err)
+		}
+		if len(existingIDs) == 0 {
+			return nil
+		}
+
+		existing := make(map[uint64]struct{}, len(existingIDs))
+		for _, id := range existingIDs {
+			existing[id] = struct{}{}
+		}
+		rows := make([]*entities.Post, 0, len(existingIDs))
+		for _, item := range batch {
+			if _, ok := existing[item.ID]; !ok {
+				continue
+			}
+			row := &entities.Post{ViewCount: item.Count}
+			row.ID = item.ID
+			rows = append(rows, row)
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"view_count"}),
+		}).Create(&rows).Error
+	})
+}
+
+// caseWhenUpdateViewCounts 使用单条 UPDATE ... CASE id WHEN ? THEN ? ... END 写回浏览量。
+// - 使用 UpdateColumn，只更新 view_count，不会刷新 updated_at。
+func (r *postBatchOperationsRepository) caseWhenUpdateViewCounts(ctx context.Context, batch []updateItem) error {
+	return r.caseWhenUpdateColumn(ctx, "view_count", batch)
+}
+
+// caseWhenUpdateColumn 使用单条 UPDATE ... CASE id WHEN ? THEN ? ... END 把一批计数写入指定列。
+func (r *postBatchOperationsRepository) caseWhenUpdateColumn(ctx context.Context, column string, batch []updateItem) error {
+	var (
+		ids          []uint64
+		sqlCase      strings.Builder
+		updateParams []interface{}
+	)
+	sqlCase.WriteString("CASE id ")
+	for _, item := range batch {
+		ids = append(ids, item.ID)
+		sqlCase.WriteString("WHEN ? THEN ? ")
+		updateParams = append(updateParams, item.ID, item.Count)
+	}
+	sqlCase.WriteString("END")
+
+	return r.db.WithContext(ctx).Model(&entities.Post{}).
+		Where("id IN ?", ids).
+		UpdateColumn(column, gorm.Expr(sqlCase.String(), updateParams...)).Error
+}
+
+// GetPostViewCounts 实现浏览量回源查询。
+func (r *postBatchOperationsRepository) GetPostViewCounts(ctx context.Context, postIDs []uint64) (map[uint64]int64, error) {
+	viewCounts := make(map[uint64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return viewCounts, nil
+	}
+
+	var rows []struct {
+		ID        uint64
+		ViewCount int64
+	}
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).
+		Select("id", "view_count").
+		Where("id IN ?", postIDs).
+		Find(&rows).Error; err != nil {
+		r.logger.Error("GetPostViewCounts: 查询帖子浏览量失败。", zap.Error(err), zap.Int("id数量", len(postIDs)))
+		return nil, fmt.Errorf("查询帖子浏览量失败: %w", err)
+	}
+	for _, row := range rows {
+		viewCounts[row.ID] = row.ViewCount
+	}
+	return viewCounts, nil
+}
+
+// GetPostLikeCounts 实现点赞数回源查询。
+func (r *postBatchOperationsRepository) GetPostLikeCounts(ctx context.Context, postIDs []uint64) (map[uint64]int64, error) {
+	likeCounts := make(map[uint64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return likeCounts, nil
+	}
+
+	var rows []struct {
+		ID        uint64
+		LikeCount int64
+	}
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).
+		Select("id", "like_count").
+		Where("id IN ?", postIDs).
+		Find(&rows).Error; err != nil {
+		r.logger.Error("GetPostLikeCounts: 查询帖子点赞数失败。", zap.Error(err), zap.Int("id数量", len(postIDs)))
+		return nil, fmt.Errorf("查询帖子点赞数失败: %w", err)
+	}
+	for _, row := range rows {
+		likeCounts[row.ID] = row.LikeCount
+	}
+	return likeCounts, nil
+}
+
+// BatchUpdatePostLikeCounts 实现点赞数的分批写回。
+// - 使用 UPDATE ... CASE id WHEN ? THEN ? ... END，已删除的帖子不会被更新，也不会插入新行。
+func (r *postBatchOperationsRepository) BatchUpdatePostLikeCounts(ctx context.Context, likeCounts map[uint64]int64) error {
+	if len(likeCounts) == 0 {
+		return nil
+	}
+	batchSize := r.viewSyncCfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	items := make([]updateItem, 0, len(likeCounts))
+	for id, count := range likeCounts {
+		items = append(items, updateItem{ID: id, Count: count})
+	}
+
+	var errs []error
+	for start := 0; start < len(items); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		batch := items[start:min(start+batchSize, len(items))]
+		if err := r.caseWhenUpdateColumn(ctx, "like_count", batch); err != nil {
+			r.logger.Error("BatchUpdatePostLikeCounts: 批次写回点赞数失败。", zap.Error(err), zap.Int("batchSize", len(batch)))
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("批量写回点赞数失败 (%d 个批次): %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+// ListChangedPostSnapshots 实现增量对账的分页读取。
+func (r *postBatchOperationsRepository) ListChangedPostSnapshots(ctx context.Context, column string, since, until, afterTime time.Time, afterID uint64, limit int) ([]*entities.Post, error) {
+	if column != constant.PostReconcileColumnUpdatedAt && column != constant.PostReconcileColumnDeletedAt {
+		return nil, fmt.Errorf("不支持的对账时间列: %s", column)
+	}
+
+	query := r.db.WithContext(ctx).Unscoped().
+		Select("id", "status", "updated_at", "deleted_at").
+		Where(column+" >= ? AND "+column+" < ?", since, until)
+	if !afterTime.IsZero() {
+		query = query.Where("("+column+" > ? OR ("+column+" = ? AND id > ?))", afterTime, afterTime, afterID)
+	}
+	var posts []*entities.Post
+	if err := query.Order(column + " ASC, id ASC").Limit(limit).Find(&posts).Error; err != nil {
+		r.logger.Error("ListChangedPostSnapshots: 读取变更帖子失败。", zap.Error(err), zap.String("column", column), zap.Time("since", since), zap.Time("until", until))
+		return nil, fmt.Errorf("读取变更帖子失败: %w", err)
+	}
+	return posts, nil
+}
+
+// GetMaxPostID 实现最大帖子 ID 的查询。
+func (r *postBatchOperationsRepository) GetMaxPostID(ctx context.Context) (uint64, error) {
+	var maxID *uint64
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).Select("MAX(id)").Scan(&maxID).Error; err != nil {
+		r.logger.Error("GetMaxPostID: 查询最大帖子 ID 失败。", zap.Error(err))
+		return 0, fmt.Errorf("查询最大帖子 ID 失败: %w", err)
+	}
+	if maxID == nil {
+		return 0, nil
+	}
+	return *maxID, nil
+}
+
+// ListPostViewCountsAfter 实现按主键游标分批读取帖子浏览量。
+func (r *postBatchOperationsRepository) ListPostViewCountsAfter(ctx context.Context, afterID uint64, limit int) ([]*entities.Post, error) {
+	var posts []*entities.Post
+	if err := r.db.WithContext(ctx).
+		Select("id", "view_count").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		r.logger.Error("ListPostViewCountsAfter: 分批读取帖子浏览量失败。", zap.Error(err), zap.Uint64("afterID", afterID))
+		return nil, fmt.Errorf("分批读取帖子浏览量失败: %w", err)
+	}
+	return posts, nil
+}
+
+// ArchivePostViewCounts 实现冷数据浏览量的归档写入。
+// - 归档批次较小，在一个事务内逐行 UPDATE，任一行失败整体回滚，调用方据此不删除 Redis 计数器。
+func (r *postBatchOperationsRepository) ArchivePostViewCounts(ctx context.Context, viewCounts map[uint64]int64) error {
+	if len(viewCounts) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for postID, count := range viewCounts {
+			if err := tx.Model(&entities.Post{}).
+				Where("id = ?", postID).
+				UpdateColumn("view_count", gorm.Expr("GREATEST(view_count, ?)", count)).Error; err != nil {
+				r.logger.Error("ArchivePostViewCounts: 归档帖子浏览量失败。", zap.Error(err), zap.Uint64("postID", postID), zap.Int64("viewCount", count))
+				return fmt.Errorf("归档帖子 %d 的浏览量失败: %w", postID, err)
+			}
+		}
+		return nil
+	})
+}
+
 // GetPostDetailsByPostIDs 批量获取帖子详情
 func (r *postBatchOperationsRepository) GetPostDetailsByPostIDs(ctx context.Context, postIDs []uint64) ([]*entities.PostDetail, error) {
 	var postDetails []*entities.PostDetail
@@ -330,3 +629,24 @@ func (r *postBatchOperationsRepository) BatchGetPostDetailImages(ctx context.Con
 	// 返回构建好的图片映射和nil错误（表示操作成功）。
 	return imagesMap, nil
 }
+
+// BatchGetPostFAQs 实现批量获取帖子 FAQ。
+func (r *postBatchOperationsRepository) BatchGetPostFAQs(ctx context.Context, postIDs []uint64) (map[uint64][]*entities.PostFAQ, error) {
+	faqsMap := make(map[uint64][]*entities.PostFAQ, len(postIDs))
+	if len(postIDs) == 0 {
+		return faqsMap, nil
+	}
+
+	var faqs []*entities.PostFAQ
+	if err := r.db.WithContext(ctx).
+		Where("post_id IN ?", postIDs).
+		Order("post_id ASC, display_order ASC, id ASC").
+		Find(&faqs).Error; err != nil {
+		r.logger.Error("批量获取帖子 FAQ 失败", zap.Int("idCount", len(postIDs)), zap.Error(err))
+		return nil, err
+	}
+	for _, faq := range faqs {
+		faqsMap[faq.PostID] = append(faqsMap[faq.PostID], faq)
+	}
+	return faqsMap, nil
+}
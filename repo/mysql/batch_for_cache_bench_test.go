@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// benchViewCountPosts 是浏览量写回基准测试预置的帖子数量。
+const benchViewCountPosts = 20000
+
+// seedBenchPosts 保证 posts 表中至少有 n 条未删除的帖子，返回它们的 ID。
+func seedBenchPosts(b *testing.B, r *postBatchOperationsRepository, n int) []uint64 {
+	b.Helper()
+	ctx := context.Background()
+	var ids []uint64
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).Order("id ASC").Limit(n).Pluck("id", &ids).Error; err != nil {
+		b.Fatalf("读取已有帖子失败: %v", err)
+	}
+	if missing := n - len(ids); missing > 0 {
+		rows := make([]*entities.Post, 0, missing)
+		for i := 0; i < missing; i++ {
+			rows = append(rows, &entities.Post{
+				Title:    fmt.Sprintf("bench post %d", i),
+				AuthorID: "bench-author",
+			})
+		}
+		if err := r.db.WithContext(ctx).CreateInBatches(rows, 1000).Error; err != nil {
+			b.Fatalf("预置帖子失败: %v", err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+	}
+	return ids[:n]
+}
+
+// BenchmarkViewCountWriteBack 对比浏览量写回的两种单语句批量写法：
+// INSERT ... ON DUPLICATE KEY UPDATE (upsert) 与 UPDATE ... CASE id WHEN ... END (case_when)。
+// 需要设置 POST_SERVICE_BENCH_MYSQL_DSN，否则跳过。
+func BenchmarkViewCountWriteBack(b *testing.B) {
+	db := newBenchMySQL(b, &entities.Post{})
+	r := &postBatchOperationsRepository{db: db, logger: nil, viewSyncCfg: config.ViewSyncConfig{}}
+	ids := seedBenchPosts(b, r, benchViewCountPosts)
+
+	strategies := []struct {
+		name  string
+		write func(context.Context, []updateItem) error
+	}{
+		{name: "upsert", write: r.upsertViewCounts},
+		{name: "case_when", write: r.caseWhenUpdateViewCounts},
+	}
+	for _, batchSize := range []int{100, 500, 2000} {
+		for _, strategy := range strategies {
+			b.Run(fmt.Sprintf("%s/batch=%d", strategy.name, batchSize), func(b *testing.B) {
+				ctx := context.Background()
+				batch := make([]updateItem, batchSize)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					// 每轮写入不同的一段帖子与计数，避免 MySQL 因值未变化而跳过行更新
+					offset := (i * batchSize) % (len(ids) - batchSize + 1)
+					for j := range batch {
+						batch[j] = updateItem{ID: ids[offset+j], Count: int64(i + j)}
+					}
+					if err := strategy.write(ctx, batch); err != nil {
+						b.Fatalf("%s 写回失败: %v", strategy.name, err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+func TestViewCountWriteBackOnlyUpdatesExistingPosts(t *testing.T) {
+	strategies := []struct {
+		name  string
+		write func(*postBatchOperationsRepository, context.Context, []updateItem) error
+	}{
+		{name: "upsert", write: (*postBatchOperationsRepository).upsertViewCounts},
+		{name: "case_when", write: (*postBatchOperationsRepository).caseWhenUpdateViewCounts},
+	}
+	for _, strategy := range strategies {
+		t.Run(strategy.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDB(t, &entities.Post{})
+			r := &postBatchOperationsRepository{db: db, logger: newTestLogger(t), viewSyncCfg: config.ViewSyncConfig{}}
+
+			live := &entities.Post{Title: "正常帖子", AuthorID: "author-1", ViewCount: 1}
+			deleted := &entities.Post{Title: "已删除帖子", AuthorID: "author-1", ViewCount: 2}
+			if err := db.Create([]*entities.Post{live, deleted}).Error; err != nil {
+				t.Fatalf("创建测试帖子失败: %v", err)
+			}
+			if err := db.Delete(deleted).Error; err != nil {
+				t.Fatalf("软删除测试帖子失败: %v", err)
+			}
+			const unknownID = 999
+
+			batch := []updateItem{{ID: live.ID, Count: 10}, {ID: deleted.ID, Count: 20}, {ID: unknownID, Count: 30}}
+			if err := strategy.write(r, ctx, batch); err != nil {
+				t.Fatalf("写回浏览量失败: %v", err)
+			}
+
+			viewCountOf := func(id uint64) int64 {
+				t.Helper()
+				var post entities.Post
+				if err := db.Unscoped().First(&post, id).Error; err != nil {
+					t.Fatalf("查询帖子 %d 失败: %v", id, err)
+				}
+				return post.ViewCount
+			}
+			if got := viewCountOf(live.ID); got != 10 {
+				t.Errorf("正常帖子的浏览量 = %d, 期望 10", got)
+			}
+			if got := viewCountOf(deleted.ID); got != 2 {
+				t.Errorf("已删除帖子的浏览量 = %d, 期望保持 2", got)
+			}
+			var rows int64
+			if err := db.Unscoped().Model(&entities.Post{}).Count(&rows).Error; err != nil {
+				t.Fatalf("统计帖子数失败: %v", err)
+			}
+			if rows != 2 {
+				t.Errorf("写回后帖子总数 = %d, 期望 2（不存在的帖子 %d 不应被插入）", rows, unknownID)
+			}
+		})
+	}
+}
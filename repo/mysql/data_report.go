@@ -0,0 +1,115 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// DataReportRepository 定义了帖子数据报表相关的持久化操作接口。
+// - 报表数据的读取全部强制走从库 (dbresolver.Read)，避免大批量扫描影响线上写库。
+type DataReportRepository interface {
+	// CreateReport 记录一份已生成的报表元信息。
+	CreateReport(ctx context.Context, report *entities.PostDataReport) error
+
+	// GetReportByID 根据 ID 获取报表记录。
+	// - 未找到时返回 commonerrors.ErrRepoNotFound。
+	GetReportByID(ctx context.Context, id uint64) (*entities.PostDataReport, error)
+
+	// ListReports 分页获取历史报表记录，按创建时间倒序。
+	// - period 为空字符串时不按周期过滤。
+	ListReports(ctx context.Context, period string, offset, limit int) ([]*entities.PostDataReport, int64, error)
+
+	// ListApprovedPostsForReport 从从库分页读取统计区间 [periodStart, periodEnd) 内创建、已审核通过的帖子，按浏览量降序、ID 降序排列。
+	// - 排序即为报表中的排名顺序。
+	ListApprovedPostsForReport(ctx context.Context, periodStart, periodEnd time.Time, offset, limit int) ([]*entities.Post, error)
+}
+
+// dataReportRepository 是 DataReportRepository 接口针对 MySQL 的具体实现。
+type dataReportRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewDataReportRepository 是 dataReportRepository 的构造函数。
+func NewDataReportRepository(db *gorm.DB, logger *core.ZapLogger) DataReportRepository {
+	return &dataReportRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateReport 实现报表记录的插入。
+func (r *dataReportRepository) CreateReport(ctx context.Context, report *entities.PostDataReport) error {
+	if err := r.db.WithContext(ctx).Create(report).Error; err != nil {
+		r.logger.Error("保存报表记录失败", zap.Error(err), zap.String("objectKey", report.ObjectKey))
+		return err
+	}
+	return nil
+}
+
+// GetReportByID 实现按 ID 查询报表记录。
+func (r *dataReportRepository) GetReportByID(ctx context.Context, id uint64) (*entities.PostDataReport, error) {
+	var report entities.PostDataReport
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Read).First(&report, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, commonerrors.ErrRepoNotFound
+		}
+		r.logger.Error("根据 ID 获取报表记录失败", zap.Uint64("reportID", id), zap.Error(err))
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListReports 实现历史报表的分页查询。
+func (r *dataReportRepository) ListReports(ctx context.Context, period string, offset, limit int) ([]*entities.PostDataReport, int64, error) {
+	var reports []*entities.PostDataReport
+	var total int64
+
+	query := r.db.WithContext(ctx).Clauses(dbresolver.Read).Model(&entities.PostDataReport{})
+	if period != "" {
+		query = query.Where("period = ?", period)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("统计报表记录数量失败", zap.Error(err), zap.String("period", period))
+		return nil, 0, fmt.Errorf("统计报表记录失败: %w", err)
+	}
+	if total == 0 {
+		return reports, 0, nil
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Offset(offset).Limit(limit).Find(&reports).Error; err != nil {
+		r.logger.Error("分页查询报表记录失败", zap.Error(err), zap.String("period", period))
+		return nil, 0, fmt.Errorf("查询报表记录失败: %w", err)
+	}
+	return reports, total, nil
+}
+
+// ListApprovedPostsForReport 实现报表数据源的分页读取（强制从库）。
+func (r *dataReportRepository) ListApprovedPostsForReport(ctx context.Context, periodStart, periodEnd time.Time, offset, limit int) ([]*entities.Post, error) {
+	var posts []*entities.Post
+	err := r.db.WithContext(ctx).
+		Clauses(dbresolver.Read).
+		Where("status = ?", enums.Approved).
+		Where("created_at >= ? AND created_at < ?", periodStart, periodEnd).
+		Order("view_count DESC").Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		r.logger.Error("从从库读取报表帖子数据失败", zap.Error(err), zap.Time("periodStart", periodStart), zap.Int("offset", offset), zap.Int("limit", limit))
+		return nil, err
+	}
+	return posts, nil
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+func TestListApprovedPostsForReportFiltersByPeriod(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, &entities.Post{})
+	repo := NewDataReportRepository(db, newTestLogger(t))
+
+	periodStart := time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)
+	periodEnd := periodStart.AddDate(0, 0, 1)
+	seed := []struct {
+		title     string
+		createdAt time.Time
+		status    enums.Status
+		viewCount int64
+	}{
+		{title: "区间开始前", createdAt: periodStart.Add(-time.Second), status: enums.Approved, viewCount: 100},
+		{title: "区间开始", createdAt: periodStart, status: enums.Approved, viewCount: 10},
+		{title: "区间内浏览最多", createdAt: periodStart.Add(12 * time.Hour), status: enums.Approved, viewCount: 50},
+		{title: "区间内未审核", createdAt: periodStart.Add(time.Hour), status: enums.Pending, viewCount: 80},
+		{title: "区间结束", createdAt: periodEnd, status: enums.Approved, viewCount: 90},
+	}
+	for _, p := range seed {
+		post := &entities.Post{Title: p.title, AuthorID: "author-1", Status: p.status, ViewCount: p.viewCount}
+		post.CreatedAt = p.createdAt
+		if err := db.Create(post).Error; err != nil {
+			t.Fatalf("创建测试帖子失败: %v", err)
+		}
+	}
+
+	posts, err := repo.ListApprovedPostsForReport(ctx, periodStart, periodEnd, 0, 10)
+	if err != nil {
+		t.Fatalf("ListApprovedPostsForReport 返回错误: %v", err)
+	}
+	want := []string{"区间内浏览最多", "区间开始"}
+	if len(posts) != len(want) {
+		t.Fatalf("返回 %d 条帖子, 期望 %d 条", len(posts), len(want))
+	}
+	for i, post := range posts {
+		if post.Title != want[i] {
+			t.Fatalf("第 %d 条帖子 = %q, 期望 %q", i, post.Title, want[i])
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/core"
+	sqlitedriver "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// 为 SQLite 注册查询中用到的 MySQL 函数，使投放定向过滤等条件可以在内存数据库上执行。
+func init() {
+	sqlitedriver.MustRegisterDeterministicScalarFunction("FIND_IN_SET", 2, func(_ *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+		needle, list := sqlTextValue(args[0]), sqlTextValue(args[1])
+		if list == "" {
+			return int64(0), nil
+		}
+		for i, item := range strings.Split(list, ",") {
+			if item == needle {
+				return int64(i + 1), nil
+			}
+		}
+		return int64(0), nil
+	})
+}
+
+// sqlTextValue 将 SQLite 传入的文本参数统一转换为 string。
+func sqlTextValue(v driver.Value) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+// newTestDB 创建一个内存 SQLite 数据库并迁移给定的实体，测试结束时自动关闭。
+// - 只用于验证与方言无关的查询条件，MySQL 特有的语法（ON DUPLICATE KEY、FOR UPDATE 等）需要在真实 MySQL 上验证。
+func newTestDB(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatalf("打开 SQLite 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取底层连接失败: %v", err)
+	}
+	// 内存数据库按连接隔离，限制为单连接保证所有查询看到同一份数据
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("迁移测试表失败: %v", err)
+	}
+	return db
+}
+
+// newTestLogger 返回只输出错误日志的 logger，避免测试输出被调试日志淹没。
+func newTestLogger(t testing.TB) *core.ZapLogger {
+	t.Helper()
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	return logger
+}
+
+// benchMySQLDSNEnv 是基准测试使用的 MySQL DSN 环境变量，未设置时跳过需要真实 MySQL 的基准测试。
+// - 基准测试会迁移并写入 posts 等表，必须指向独立的测试库，DSN 需带 parseTime=true。
+// - 运行方式: POST_SERVICE_BENCH_MYSQL_DSN="user:pass@tcp(127.0.0.1:3306)/post_bench?parseTime=true" go test -run='^$' -bench=. ./repo/mysql
+const benchMySQLDSNEnv = "POST_SERVICE_BENCH_MYSQL_DSN"
+
+// newBenchMySQL 连接环境变量指定的 MySQL 并迁移给定的实体，未设置 DSN 时跳过基准测试。
+func newBenchMySQL(b *testing.B, models ...interface{}) *gorm.DB {
+	b.Helper()
+	dsn := os.Getenv(benchMySQLDSNEnv)
+	if dsn == "" {
+		b.Skipf("未设置 %s，跳过需要 MySQL 的基准测试", benchMySQLDSNEnv)
+	}
+	db, err := gorm.Open(gormmysql.Open(dsn), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		b.Fatalf("连接 MySQL 失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		b.Fatalf("获取底层连接失败: %v", err)
+	}
+	b.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(models...); err != nil {
+		b.Fatalf("迁移基准测试表失败: %v", err)
+	}
+	return db
+}
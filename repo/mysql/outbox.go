@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// OutboxRepository 定义了事务性发件箱 (outbox_events) 的持久化操作接口。
+type OutboxRepository interface {
+	// CreateEvent 写入一条待投递事件。
+	// - db 传入业务事务，保证事件与业务数据同时提交或回滚。
+	CreateEvent(ctx context.Context, db *gorm.DB, event *entities.OutboxEvent) error
+
+	// DeleteEvent 投递成功后物理删除事件记录。
+	DeleteEvent(ctx context.Context, id uint64) error
+
+	// ListDueEvents 按写入顺序读取到期（NextAttemptAt 不晚于 now）的事件，至多 limit 条。
+	// - 读取走主库：刚写入或刚更新的记录不能因主从延迟被漏掉或重复投递。
+	ListDueEvents(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error)
+
+	// MarkEventFailed 记录一次投递失败：失败次数加 1，并推迟到 nextAttemptAt 再重试。
+	MarkEventFailed(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error
+}
+
+// outboxRepository 是 OutboxRepository 接口针对 MySQL 的具体实现。
+type outboxRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewOutboxRepository 是 outboxRepository 的构造函数。
+func NewOutboxRepository(db *gorm.DB, logger *core.ZapLogger) OutboxRepository {
+	return &outboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateEvent 实现待投递事件的写入。
+func (r *outboxRepository) CreateEvent(ctx context.Context, db *gorm.DB, event *entities.OutboxEvent) error {
+	if err := db.WithContext(ctx).Create(event).Error; err != nil {
+		r.logger.Error("写入发件箱事件失败", zap.Error(err), zap.String("topic", event.Topic))
+		return fmt.Errorf("写入发件箱事件失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteEvent 实现事件记录的物理删除；发件箱记录没有保留价值，不走软删除。
+func (r *outboxRepository) DeleteEvent(ctx context.Context, id uint64) error {
+	if err := r.db.WithContext(ctx).Unscoped().Delete(&entities.OutboxEvent{}, id).Error; err != nil {
+		r.logger.Error("删除发件箱事件失败", zap.Error(err), zap.Uint64("outboxID", id))
+		return fmt.Errorf("删除发件箱事件 (ID: %d) 失败: %w", id, err)
+	}
+	return nil
+}
+
+// ListDueEvents 实现到期事件的查询。
+func (r *outboxRepository) ListDueEvents(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	var events []*entities.OutboxEvent
+	err := r.db.WithContext(ctx).
+		Where("next_attempt_at <= ?", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		r.logger.Error("查询到期的发件箱事件失败", zap.Error(err))
+		return nil, fmt.Errorf("查询到期的发件箱事件失败: %w", err)
+	}
+	return events, nil
+}
+
+// MarkEventFailed 实现投递失败的记录。
+func (r *outboxRepository) MarkEventFailed(ctx context.Context, id uint64, nextAttemptAt time.Time, lastError string) error {
+	err := r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+		}).Error
+	if err != nil {
+		r.logger.Error("更新发件箱事件失败状态失败", zap.Error(err), zap.Uint64("outboxID", id))
+		return fmt.Errorf("更新发件箱事件 (ID: %d) 失败状态失败: %w", id, err)
+	}
+	return nil
+}
@@ -9,10 +9,13 @@ import (
 	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/post_service/models/dto"
 	"go.uber.org/zap"
+	"sort"
 	"time" // 用于更新时间戳
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/entities" // 引入数据库实体定义
 	// 建议: 如果使用自定义错误，在这里导入
 	// "github.com/Xushengqwer/go-common/commonerrors"
@@ -35,22 +38,55 @@ type PostRepository interface {
 	// - 设计为降序（ID越大越新），适用于“用户个人主页”等场景展示最新帖子。
 	// - cursor (*uint64): 使用指针类型是为了区分“首次加载”（nil）和“从某个ID之后加载”。
 	// - 返回 nextCursor (*uint64): 下一页的起始ID，如果为 nil 表示没有更多数据。
-	GetPostsByUserIDCursor(ctx context.Context, userID string, cursor *uint64, pageSize int) ([]*entities.Post, *uint64, error)
+	// - statusFilter 为 nil 时返回全部状态（包括草稿，供作者本人查看），否则只返回该状态；图片上传中的占位帖子始终不返回。
+	//   是否允许传 nil 由 service 层根据查询者身份决定，查看他人主页时必须传 Approved。
+	GetPostsByUserIDCursor(ctx context.Context, userID string, statusFilter *enums.Status, cursor *uint64, pageSize int) ([]*entities.Post, *uint64, error)
 
 	// GetPostsByTimeline 实现按时间线、条件筛选和游标分页查询帖子列表。
-	// - 使用 TimelineQueryDTO 封装所有查询参数。
+	// - 使用 TimelineQueryDTO 封装所有查询参数；AuthorIDs 非空时只返回这些作者的帖子（关注流）。
+	// - 按 id DESC 单键排序并以 LastPostID 作为游标：id 自增且唯一，同一时间戳下批量插入的帖子也不会在翻页时重复或遗漏。
 	// - 返回 ([]*entities.Post, *time.Time, *uint64, error): 帖子列表, 下一页游标时间, 下一页游标ID, 错误。
 	GetPostsByTimeline(ctx context.Context, params *dto.TimelineQueryDTO) ([]*entities.Post, *time.Time, *uint64, error)
 
+	// GetPostsByAuthorsTimeline 查询多个作者已审核通过的帖子，按时间线 (id DESC) 混合排序并游标分页。
+	// - 作者数量超过 constant.AuthorsQueryBatchSize 时分批执行 author_id IN (...) 查询，
+	//   每批最多取 pageSize+1 条，再在内存中归并取前 pageSize+1 条（全局前 N 条必然落在各批次的前 N 条之中）。
+	// - cursor 为 nil 表示首次加载。
+	// - 返回值与 GetPostsByTimeline 一致。
+	GetPostsByAuthorsTimeline(ctx context.Context, authorIDs []string, cursor *dto.PostTimelineCursor, pageSize int) ([]*entities.Post, *time.Time, *uint64, error)
+
+	// GetQuotingPostsTimeline 查询转发/引用了指定帖子的已审核通过帖子，按时间线 (id DESC) 游标分页。
+	// - 反向索引即 posts.quoted_post_id 上的二级索引：InnoDB 二级索引隐含主键，
+	//   quoted_post_id = ? AND id < ? ORDER BY id DESC 可直接按索引顺序扫描，无需额外维护关系表。
+	// - 转发帖被删除后由软删除条件自动排除，原帖的转发数由 DeletePost 同步扣减。
+	// - cursor 为 nil 表示首次加载。
+	// - 返回当前页帖子以及下一页游标 (nextCreatedAt, nextPostID)，没有更多数据时游标为 nil。
+	GetQuotingPostsTimeline(ctx context.Context, quotedPostID uint64, cursor *dto.PostTimelineCursor, pageSize int) ([]*entities.Post, *time.Time, *uint64, error)
+
+	// SearchPostsFullText 使用 MySQL 全文索引检索标题或正文命中 booleanQuery 的已审核通过帖子，按相关性降序分页。
+	// - booleanQuery 必须是调用方已清洗好的 BOOLEAN MODE 表达式，仓库层不再做转义。
+	// - 标题相关性乘以 constant.SearchTitleWeight 后与正文相关性相加作为排序分，分数相同时按 id 降序。
+	// - 按 offset/limit 分页，调用方通过多取一条判断是否还有下一页。
+	SearchPostsFullText(ctx context.Context, booleanQuery string, viewer *dto.ViewerAttributes, offset, limit int) ([]*entities.Post, error)
+
+	// GetPostsByViewCountCursor 按浏览量倒序查询已审核通过的帖子，以 (view_count, id) 复合游标分页。
+	// - 排序为 view_count DESC, id DESC，浏览量相同时以 id 作为决胜键，游标在并列时依然稳定。
+	// - 依赖 posts (status, view_count, id) 联合索引 (constant.PostStatusViewCountIndexName)。
+	// - cursor 为 nil 表示首次加载；viewer 用于投放定向过滤，与时间线一致。
+	// - 返回当前页帖子与下一页游标，没有更多数据时游标为 nil。
+	// - 注意: 浏览量由定时任务从 Redis 同步写回，翻页期间同步导致的分数变化可能使少量帖子重复或遗漏。
+	GetPostsByViewCountCursor(ctx context.Context, cursor *dto.PostViewCountCursor, viewer *dto.ViewerAttributes, pageSize int) ([]*entities.Post, *dto.PostViewCountCursor, error)
+
 	// GetUserPostsByConditions 分页查询指定用户发布的帖子列表，支持多种条件筛选。
 	// - authorID: 必需，指定用户ID。
 	// - officialTag (*enums.OfficialTag): 可选，按官方标签筛选。
 	// - title (*string): 可选，按标题模糊搜索。
 	// - status (*enums.Status): 可选，按帖子审核状态筛选。
+	// - isDraft (*bool): 可选，按是否为草稿筛选。
 	// - offset (int): 分页偏移量。
 	// - limit (int): 每页数量。
 	// - 返回: 帖子列表 ([]*entities.Post), 符合条件的总记录数 (int64), 错误 (error)。
-	GetUserPostsByConditions(ctx context.Context, authorID string, officialTag *enums.OfficialTag, title *string, status *enums.Status, offset, limit int) ([]*entities.Post, int64, error)
+	GetUserPostsByConditions(ctx context.Context, authorID string, officialTag *enums.OfficialTag, title *string, status *enums.Status, isDraft *bool, offset, limit int) ([]*entities.Post, int64, error)
 
 	// GetPostByID 根据单个 ID 检索帖子信息。
 	// - 用于需要获取指定帖子基础信息的场景。
@@ -61,6 +97,52 @@ type PostRepository interface {
 	// - 软删除是通过 GORM 的约定（填充 deleted_at 字段）实现的，数据本身仍在数据库中。
 	// - 适用于用户下架或管理员删除帖子的场景，保留数据可追溯。
 	DeletePost(ctx context.Context, db *gorm.DB, id uint64) error
+
+	// PublishDraft 将草稿转为待审核状态（is_draft=false, status=Pending）。
+	// - 只对未删除且仍为草稿的帖子生效，否则返回 commonerrors.ErrRepoNotFound（例如并发发布时后到的请求）。
+	// - db 传入事务，与待审核事件的发件箱记录一起提交。
+	PublishDraft(ctx context.Context, db *gorm.DB, postID uint64) error
+
+	// AppealRejectedPost 将审核被拒的帖子重新置为待审核，申诉次数加 1，并清空审核原因（拒绝原因保留在帖子审计日志中）。
+	// - 只对未删除、状态为拒绝且申诉次数小于 maxAppeals 的帖子生效，否则返回 commonerrors.ErrRepoNotFound（例如并发申诉时后到的请求）。
+	// - 同时刷新 updated_at，申诉前产生的审核结果事件因此被视为过期，不会覆盖重审结果。
+	// - db 传入事务，与待审核事件的发件箱记录一起提交。
+	AppealRejectedPost(ctx context.Context, db *gorm.DB, postID uint64, maxAppeals int) error
+
+	// ResubmitEditedPost 将作者编辑后的帖子重新置为待审核：更新标题与 SimHash 指纹，并清空审核原因（拒绝原因保留在帖子审计日志中）。
+	// - 只对未删除、非草稿且状态为 fromStatus 的帖子生效，否则返回 commonerrors.ErrRepoNotFound（例如编辑期间被管理员改判或并发编辑）。
+	// - 同时刷新 status_updated_at，编辑前产生的审核结果事件因此被视为过期，不会覆盖重审结果。
+	// - db 传入事务，与正文更新、段落记录和待审核事件的发件箱记录一起提交。
+	ResubmitEditedPost(ctx context.Context, db *gorm.DB, postID uint64, fromStatus enums.Status, title string, contentSimHash uint64) error
+
+	// IncrementRepostCount 在事务中将指定帖子的转发数加 1（仅对未删除的帖子生效）。
+	// - 帖子不存在或已被删除时返回 commonerrors.ErrRepoNotFound。
+	IncrementRepostCount(ctx context.Context, db *gorm.DB, postID uint64) error
+
+	// CompleteImageUpload 将图片上传中的占位帖子标记为上传完成（image_upload_pending=false）。
+	// - 只对仍处于上传中的帖子生效，否则返回 commonerrors.ErrRepoNotFound（例如占位记录已被清理任务回收）。
+	// - db 传入事务，与图片 URL 回填、待审核事件的发件箱记录一起提交。
+	CompleteImageUpload(ctx context.Context, db *gorm.DB, postID uint64) error
+
+	// PurgeUploadPendingPost 在一个事务内物理删除仍处于图片上传中的帖子及其详情、图片、投放定向与 FAQ 记录。
+	// - 帖子已上传完成或不存在时不做任何修改并返回 commonerrors.ErrRepoNotFound，防止误删正常帖子。
+	// - 占位记录从未对外可见，直接物理删除，不保留软删除痕迹。
+	PurgeUploadPendingPost(ctx context.Context, postID uint64) error
+
+	// ListStaleUploadPendingPosts 查询创建时间早于 before、仍处于图片上传中的帖子，供上传清理任务回收。
+	// - 返回 map[postID][]objectKey，值为该帖子占位图片记录的 COS 对象键，含保留的原图（可能尚未真正上传）。
+	ListStaleUploadPendingPosts(ctx context.Context, before time.Time, limit int) (map[uint64][]string, error)
+
+	// ListDuplicateCandidates 查询发布查重的候选帖子，按 id DESC 最多返回 limit 条，只读取查重与提示所需的列。
+	// - authorID 不为 nil 时返回该作者 since 之后待审核或已审核通过的非草稿帖子；为 nil 时返回全站 since 之后已审核通过、对匿名用户可见的帖子（投放定向按匿名用户过滤）。
+	// - 没有 SimHash 指纹的帖子（历史帖子或正文过短）不参与查重，直接在查询中排除。
+	ListDuplicateCandidates(ctx context.Context, authorID *string, since time.Time, limit int) ([]*entities.Post, error)
+
+	// UpdateAuthorInfoBatch 将作者帖子中与新值不一致的冗余头像、昵称更新为新值，单次最多修改 limit 条，返回实际修改的行数。
+	// - avatar、username 为 nil 表示该字段不更新，两者都为 nil 时直接返回 0。
+	// - 已修改的行不再满足条件，调用方重复调用直到返回值小于 limit 即可完成全部帖子；每次调用是一条独立提交的语句。
+	// - 包含已软删除的帖子（恢复后同样展示新值）；不修改 updated_at，避免影响基于更新时间的审核事件过期判断。
+	UpdateAuthorInfoBatch(ctx context.Context, authorID string, avatar, username *string, limit int) (int64, error)
 }
 
 // postRepository 是 PostRepository 接口针对 MySQL 的具体实现。
@@ -145,15 +227,19 @@ func (r *postRepository) UpdatePost(ctx context.Context, postID uint64, title *s
 }
 
 // GetPostsByUserIDCursor 实现游标方式获取用户帖子。
-func (r *postRepository) GetPostsByUserIDCursor(ctx context.Context, userID string, cursor *uint64, pageSize int) ([]*entities.Post, *uint64, error) {
+func (r *postRepository) GetPostsByUserIDCursor(ctx context.Context, userID string, statusFilter *enums.Status, cursor *uint64, pageSize int) ([]*entities.Post, *uint64, error) {
 	var posts []*entities.Post // 用于存储查询结果
 
-	// 构建基础查询：指定用户、只看已通过审核 (Approved) 的帖子、按 ID 降序排序。
+	// 构建基础查询：指定用户、排除图片上传中的占位帖子、按 ID 降序排序。
 	query := r.db.WithContext(ctx).
-		Where("author_id = ?", userID).
-		Where("status = ?", enums.Approved).
+		Where("author_id = ? AND image_upload_pending = ?", userID, false).
 		Order("id DESC")
 
+	// 指定了状态时只看该状态的帖子；nil 表示全部状态（作者本人视角）。
+	if statusFilter != nil {
+		query = query.Where("status = ?", *statusFilter)
+	}
+
 	// 如果提供了 cursor (非首次加载)，则只查询 ID 小于 cursor 的记录。
 	// 使用指针判断 cursor 是否被提供。
 	if cursor != nil {
@@ -204,7 +290,7 @@ func (r *postRepository) GetPostsByTimeline(ctx context.Context, params *dto.Tim
 
 	// 应用筛选条件 (检查指针是否为 nil)
 	if params.OfficialTag != nil {
-		query = query.Where("official_tag = ?", *params.OfficialTag)
+		query = whereOfficialTag(query, *params.OfficialTag)
 	}
 	if params.Title != nil {
 		// 只有当 Title 不为 nil 时才添加 WHERE 条件
@@ -214,14 +300,22 @@ func (r *postRepository) GetPostsByTimeline(ctx context.Context, params *dto.Tim
 		// 只有当 AuthorUsername 不为 nil 时才添加 WHERE 条件
 		query = query.Where("author_username LIKE ?", "%"+*params.AuthorUsername+"%")
 	}
+	if len(params.AuthorIDs) > 0 {
+		// 关注流：只看指定作者的帖子，与其他筛选条件和游标同时生效
+		query = query.Where("author_id IN ?", params.AuthorIDs)
+	}
+
+	// 应用投放定向过滤：不满足定向条件的帖子对当前用户完全隐藏
+	query = applyTargetingFilter(query, params.Viewer)
 
 	// 应用游标分页条件 (检查指针是否为 nil)
-	if params.LastCreatedAt != nil && params.LastPostID != nil {
-		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", *params.LastCreatedAt, *params.LastCreatedAt, *params.LastPostID)
+	// - 只使用 id 作为游标：客户端回传的 LastCreatedAt 可能被截断到秒，与列中的毫秒值比较会跳过或重复记录
+	if params.LastPostID != nil {
+		query = query.Where("id < ?", *params.LastPostID)
 	}
 
-	// 定义排序：首先按创建时间降序，然后按 ID 降序
-	query = query.Order("created_at DESC").Order("id DESC")
+	// 定义排序：id 自增，按 ID 降序即按发布先后倒序
+	query = query.Order("id DESC")
 
 	// 查询 pageSize + 1 条记录
 	err := query.Limit(pageSize + 1).Find(&posts).Error
@@ -233,36 +327,188 @@ func (r *postRepository) GetPostsByTimeline(ctx context.Context, params *dto.Tim
 		return nil, nil, nil, err
 	}
 
-	// 准备下一页的游标
-	var nextCreatedAt *time.Time
-	var nextPostID *uint64
+	// 截断结果并计算下一页游标
+	posts, nextCreatedAt, nextPostID := cutTimelinePage(posts, pageSize)
+	return posts, nextCreatedAt, nextPostID, nil
+}
 
-	// 检查实际返回的帖子数量是否超过请求的 pageSize。
-	if len(posts) > pageSize {
-		lastPostInPage := posts[pageSize-1]
-		nextCreatedAt = &lastPostInPage.CreatedAt
-		nextPostID = &lastPostInPage.ID
-		posts = posts[:pageSize] // 截断结果
+// GetPostsByAuthorsTimeline 实现多作者帖子时间线的游标分页查询。
+func (r *postRepository) GetPostsByAuthorsTimeline(ctx context.Context, authorIDs []string, cursor *dto.PostTimelineCursor, pageSize int) ([]*entities.Post, *time.Time, *uint64, error) {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if len(authorIDs) == 0 {
+		return []*entities.Post{}, nil, nil, nil
+	}
+
+	var merged []*entities.Post
+	for start := 0; start < len(authorIDs); start += constant.AuthorsQueryBatchSize {
+		end := start + constant.AuthorsQueryBatchSize
+		if end > len(authorIDs) {
+			end = len(authorIDs)
+		}
+
+		query := r.db.WithContext(ctx).
+			Model(&entities.Post{}).
+			Where("author_id IN ?", authorIDs[start:end]).
+			Where("status = ?", enums.Approved)
+		if cursor != nil {
+			query = query.Where("id < ?", cursor.PostID)
+		}
+
+		var batch []*entities.Post
+		if err := query.Order("id DESC").Limit(pageSize + 1).Find(&batch).Error; err != nil {
+			r.logger.Error("按多作者查询帖子时间线失败",
+				zap.Error(err),
+				zap.Int("authorCount", len(authorIDs)),
+				zap.Int("batchStart", start),
+			)
+			return nil, nil, nil, err
+		}
+		merged = append(merged, batch...)
 	}
 
-	// 返回当前页数据和下一页游标
+	// 多个批次时需要在内存中重新按时间线排序，并只保留 pageSize+1 条
+	if len(authorIDs) > constant.AuthorsQueryBatchSize {
+		sort.Slice(merged, func(i, j int) bool {
+			return merged[i].ID > merged[j].ID
+		})
+		if len(merged) > pageSize+1 {
+			merged = merged[:pageSize+1]
+		}
+	}
+
+	posts, nextCreatedAt, nextPostID := cutTimelinePage(merged, pageSize)
 	return posts, nextCreatedAt, nextPostID, nil
 }
 
+// GetQuotingPostsTimeline 实现引用了指定帖子的帖子列表查询。
+func (r *postRepository) GetQuotingPostsTimeline(ctx context.Context, quotedPostID uint64, cursor *dto.PostTimelineCursor, pageSize int) ([]*entities.Post, *time.Time, *uint64, error) {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("quoted_post_id = ?", quotedPostID).
+		Where("status = ?", enums.Approved)
+	if cursor != nil {
+		query = query.Where("id < ?", cursor.PostID)
+	}
+
+	var posts []*entities.Post
+	if err := query.Order("id DESC").Limit(pageSize + 1).Find(&posts).Error; err != nil {
+		r.logger.Error("查询引用帖子的转发帖列表失败", zap.Error(err), zap.Uint64("quotedPostID", quotedPostID))
+		return nil, nil, nil, err
+	}
+
+	page, nextCreatedAt, nextPostID := cutTimelinePage(posts, pageSize)
+	return page, nextCreatedAt, nextPostID, nil
+}
+
+// SearchPostsFullText 实现帖子全文检索。
+// - 正文在 post_details 表中，使用子查询而不是 JOIN，避免与 posts 表的同名列产生歧义，也便于复用投放定向过滤。
+func (r *postRepository) SearchPostsFullText(ctx context.Context, booleanQuery string, viewer *dto.ViewerAttributes, offset, limit int) ([]*entities.Post, error) {
+	const (
+		titleMatch   = "MATCH(posts.title) AGAINST(? IN BOOLEAN MODE)"
+		contentMatch = "MATCH(pd.content) AGAINST(? IN BOOLEAN MODE)"
+	)
+	contentHit := "posts.id IN (SELECT pd.post_id FROM post_details pd WHERE pd.deleted_at IS NULL AND " + contentMatch + ")"
+	contentScore := "COALESCE((SELECT " + contentMatch + " FROM post_details pd WHERE pd.post_id = posts.id AND pd.deleted_at IS NULL), 0)"
+
+	query := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("status = ?", enums.Approved).
+		Where("is_draft = ?", false).
+		Where("("+titleMatch+" OR "+contentHit+")", booleanQuery, booleanQuery)
+	query = applyTargetingFilter(query, viewer)
+
+	var posts []*entities.Post
+	err := query.
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  fmt.Sprintf("%s * %d + %s DESC, posts.id DESC", titleMatch, constant.SearchTitleWeight, contentScore),
+			Vars: []interface{}{booleanQuery, booleanQuery},
+		}}).
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		r.logger.Error("全文检索帖子失败",
+			zap.Error(err),
+			zap.String("booleanQuery", booleanQuery),
+			zap.Int("offset", offset),
+			zap.Int("limit", limit),
+		)
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetPostsByViewCountCursor 实现按浏览量倒序的复合游标分页查询。
+func (r *postRepository) GetPostsByViewCountCursor(ctx context.Context, cursor *dto.PostViewCountCursor, viewer *dto.ViewerAttributes, pageSize int) ([]*entities.Post, *dto.PostViewCountCursor, error) {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("status = ?", enums.Approved)
+	query = applyTargetingFilter(query, viewer)
+	if cursor != nil {
+		// 展开为 OR 形式而不是行构造器比较，确保能够使用联合索引做范围扫描
+		query = query.Where("(view_count < ? OR (view_count = ? AND id < ?))", cursor.ViewCount, cursor.ViewCount, cursor.PostID)
+	}
+
+	var posts []*entities.Post
+	if err := query.Order("view_count DESC").Order("id DESC").Limit(pageSize + 1).Find(&posts).Error; err != nil {
+		r.logger.Error("按浏览量获取帖子列表数据库查询失败", zap.Error(err), zap.Any("cursor", cursor), zap.Int("pageSize", pageSize))
+		return nil, nil, err
+	}
+
+	var nextCursor *dto.PostViewCountCursor
+	if len(posts) > pageSize {
+		posts = posts[:pageSize]
+		last := posts[pageSize-1]
+		nextCursor = &dto.PostViewCountCursor{ViewCount: last.ViewCount, PostID: last.ID}
+	}
+	return posts, nextCursor, nil
+}
+
+// whereOfficialTag 按官方标签筛选帖子：包含该标签即匹配（official_tags & flag > 0），帖子可能同时拥有其他标签。
+// - tag 为无标签时只匹配没有任何标签的帖子。
+func whereOfficialTag(query *gorm.DB, tag enums.OfficialTag) *gorm.DB {
+	if tag == enums.OfficialTagNone {
+		return query.Where("official_tags = 0")
+	}
+	return query.Where("official_tags & ? > 0", int(entities.OfficialTagFlag(tag)))
+}
+
+// cutTimelinePage 将按时间线排序、最多 pageSize+1 条的查询结果截断为一页，并计算下一页游标。
+// - 结果数量不超过 pageSize 时说明没有下一页，游标均为 nil。
+// - 下一页的创建时间仅为兼容旧客户端返回，翻页只依赖帖子 ID。
+func cutTimelinePage(posts []*entities.Post, pageSize int) ([]*entities.Post, *time.Time, *uint64) {
+	if len(posts) <= pageSize {
+		return posts, nil, nil
+	}
+	lastPostInPage := posts[pageSize-1]
+	return posts[:pageSize], &lastPostInPage.CreatedAt, &lastPostInPage.ID
+}
+
 // GetUserPostsByConditions 分页查询指定用户发布的帖子列表，支持多种条件筛选。
-func (r *postRepository) GetUserPostsByConditions(ctx context.Context, authorID string, officialTag *enums.OfficialTag, title *string, status *enums.Status, offset, limit int) ([]*entities.Post, int64, error) {
+func (r *postRepository) GetUserPostsByConditions(ctx context.Context, authorID string, officialTag *enums.OfficialTag, title *string, status *enums.Status, isDraft *bool, offset, limit int) ([]*entities.Post, int64, error) {
 	var posts []*entities.Post // 用于存储查询结果
 	var totalCount int64       // 用于存储符合条件的总记录数
 
 	// --- 构建基础查询 ---
 	// 始终基于当前用户ID进行查询
-	query := r.db.WithContext(ctx).Model(&entities.Post{}).Where("author_id = ?", authorID)
-	countQuery := r.db.WithContext(ctx).Model(&entities.Post{}).Where("author_id = ?", authorID) // 用于计数的查询
+	// 图片仍在上传中的占位帖子尚未创建完成，不出现在作者的列表中
+	query := r.db.WithContext(ctx).Model(&entities.Post{}).Where("author_id = ? AND image_upload_pending = ?", authorID, false)
+	countQuery := r.db.WithContext(ctx).Model(&entities.Post{}).Where("author_id = ? AND image_upload_pending = ?", authorID, false) // 用于计数的查询
 
 	// --- 应用筛选条件 ---
 	if officialTag != nil {
-		query = query.Where("official_tag = ?", *officialTag)
-		countQuery = countQuery.Where("official_tag = ?", *officialTag)
+		query = whereOfficialTag(query, *officialTag)
+		countQuery = whereOfficialTag(countQuery, *officialTag)
 	}
 	if title != nil && *title != "" { // 确保指针不为nil且字符串非空
 		query = query.Where("title LIKE ?", "%"+*title+"%")
@@ -272,6 +518,10 @@ func (r *postRepository) GetUserPostsByConditions(ctx context.Context, authorID
 		query = query.Where("status = ?", *status)
 		countQuery = countQuery.Where("status = ?", *status)
 	}
+	if isDraft != nil {
+		query = query.Where("is_draft = ?", *isDraft)
+		countQuery = countQuery.Where("is_draft = ?", *isDraft)
+	}
 
 	// --- 执行计数查询 ---
 	// 在应用所有筛选条件后，但在应用分页和排序之前执行计数
@@ -341,6 +591,12 @@ func (r *postRepository) GetPostByID(ctx context.Context, id uint64) (*entities.
 // DeletePost 实现帖子的软删除
 // db 参数是执行此操作的数据库句柄 (可以是普通连接，也可以是事务 tx)
 func (r *postRepository) DeletePost(ctx context.Context, db *gorm.DB, id uint64) error {
+	// 转发帖被删除时需要同步减少原帖的转发数，先读取引用关系
+	var quoted entities.Post
+	if err := db.WithContext(ctx).Select("id", "quoted_post_id").Where("id = ?", id).Limit(1).Find(&quoted).Error; err != nil {
+		return err
+	}
+
 	// 确保 entities.Post 结构体中嵌入了 gorm.DeletedAt 以支持软删除
 	// 使用传入的 db 对象执行数据库操作
 	result := db.WithContext(ctx).Delete(&entities.Post{}, id)
@@ -348,6 +604,15 @@ func (r *postRepository) DeletePost(ctx context.Context, db *gorm.DB, id uint64)
 		return result.Error
 	}
 
+	if result.RowsAffected > 0 && quoted.QuotedPostID != nil {
+		if err := db.WithContext(ctx).Model(&entities.Post{}).
+			Where("id = ? AND repost_count > 0", *quoted.QuotedPostID).
+			UpdateColumn("repost_count", gorm.Expr("repost_count - 1")).Error; err != nil {
+			r.logger.Error("删除转发帖时减少原帖转发数失败", zap.Error(err), zap.Uint64("postID", id), zap.Uint64("quotedPostID", *quoted.QuotedPostID))
+			return fmt.Errorf("减少原帖转发数失败: %w", err)
+		}
+	}
+
 	// 可选：如果业务逻辑要求“删除不存在的记录”是一个需要特殊处理的错误，
 	// 而不是静默成功 (GORM 默认行为)，可以在这里检查 RowsAffected。
 	// if result.RowsAffected == 0 {
@@ -355,3 +620,219 @@ func (r *postRepository) DeletePost(ctx context.Context, db *gorm.DB, id uint64)
 	// }
 	return nil
 }
+
+// ResubmitEditedPost 实现编辑后重新送审，以编辑前读取到的状态作为条件保证并发的状态变更不会被覆盖。
+func (r *postRepository) ResubmitEditedPost(ctx context.Context, db *gorm.DB, postID uint64, fromStatus enums.Status, title string, contentSimHash uint64) error {
+	result := db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND status = ? AND is_draft = ?", postID, fromStatus, false).
+		Updates(map[string]interface{}{
+			"title":             title,
+			"content_sim_hash":  contentSimHash,
+			"status":            enums.Pending,
+			"status_updated_at": time.Now(),
+			"audit_reason":      gorm.Expr("NULL"),
+		})
+	if result.Error != nil {
+		r.logger.Error("编辑帖子后重新送审失败", zap.Error(result.Error), zap.Uint64("postID", postID))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// IncrementRepostCount 实现原帖转发数的原子递增。
+func (r *postRepository) IncrementRepostCount(ctx context.Context, db *gorm.DB, postID uint64) error {
+	result := db.WithContext(ctx).Model(&entities.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("repost_count", gorm.Expr("repost_count + 1"))
+	if result.Error != nil {
+		r.logger.Error("增加帖子转发数失败", zap.Error(result.Error), zap.Uint64("postID", postID))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// PublishDraft 实现草稿发布，以 is_draft = true 作为条件保证同一草稿只会被发布一次。
+func (r *postRepository) PublishDraft(ctx context.Context, db *gorm.DB, postID uint64) error {
+	result := db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND is_draft = ?", postID, true).
+		Updates(map[string]interface{}{
+			"is_draft":          false,
+			"status":            enums.Pending,
+			"status_updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Error("发布草稿失败", zap.Error(result.Error), zap.Uint64("postID", postID))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// AppealRejectedPost 实现被拒帖子的申诉重审，以状态与申诉次数作为条件保证并发申诉只有一次生效。
+func (r *postRepository) AppealRejectedPost(ctx context.Context, db *gorm.DB, postID uint64, maxAppeals int) error {
+	result := db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND status = ? AND appeal_count < ?", postID, enums.Rejected, maxAppeals).
+		Updates(map[string]interface{}{
+			"status":            enums.Pending,
+			"status_updated_at": time.Now(),
+			"audit_reason":      gorm.Expr("NULL"),
+			"appeal_count":      gorm.Expr("appeal_count + 1"),
+		})
+	if result.Error != nil {
+		r.logger.Error("申诉重审帖子失败", zap.Error(result.Error), zap.Uint64("postID", postID))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// CompleteImageUpload 实现占位帖子的上传完成标记，以 image_upload_pending = true 作为条件避免复活已回收的记录。
+func (r *postRepository) CompleteImageUpload(ctx context.Context, db *gorm.DB, postID uint64) error {
+	result := db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND image_upload_pending = ?", postID, true).
+		UpdateColumn("image_upload_pending", false)
+	if result.Error != nil {
+		r.logger.Error("标记帖子图片上传完成失败", zap.Error(result.Error), zap.Uint64("postID", postID))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// PurgeUploadPendingPost 实现占位帖子及其关联记录的物理删除。
+// - 先以 image_upload_pending = true 为条件删除帖子本身，删除成功才继续删除关联记录，保证不会波及已完成创建的帖子。
+func (r *postRepository) PurgeUploadPendingPost(ctx context.Context, postID uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("id = ? AND image_upload_pending = ?", postID, true).
+			Delete(&entities.Post{})
+		if result.Error != nil {
+			return fmt.Errorf("删除占位帖子失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return commonerrors.ErrRepoNotFound
+		}
+
+		detailIDs := tx.Unscoped().Model(&entities.PostDetail{}).Select("id").Where("post_id = ?", postID)
+		if err := tx.Unscoped().Where("post_detail_id IN (?)", detailIDs).Delete(&entities.PostDetailImage{}).Error; err != nil {
+			return fmt.Errorf("删除占位帖子图片记录失败: %w", err)
+		}
+		if err := tx.Unscoped().Where("post_id = ?", postID).Delete(&entities.PostDetail{}).Error; err != nil {
+			return fmt.Errorf("删除占位帖子详情失败: %w", err)
+		}
+		if err := tx.Unscoped().Where("post_id = ?", postID).Delete(&entities.PostTargeting{}).Error; err != nil {
+			return fmt.Errorf("删除占位帖子投放定向失败: %w", err)
+		}
+		if err := tx.Unscoped().Where("post_id = ?", postID).Delete(&entities.PostFAQ{}).Error; err != nil {
+			return fmt.Errorf("删除占位帖子FAQ失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListStaleUploadPendingPosts 实现遗留占位帖子的查询：先取帖子 ID，再一次性读取这些帖子的图片对象键。
+func (r *postRepository) ListStaleUploadPendingPosts(ctx context.Context, before time.Time, limit int) (map[uint64][]string, error) {
+	var postIDs []uint64
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).
+		Where("image_upload_pending = ? AND created_at < ?", true, before).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &postIDs).Error; err != nil {
+		r.logger.Error("查询遗留的图片上传中帖子失败", zap.Error(err), zap.Time("before", before))
+		return nil, fmt.Errorf("查询遗留的图片上传中帖子失败: %w", err)
+	}
+	result := make(map[uint64][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+	for _, postID := range postIDs {
+		result[postID] = nil
+	}
+
+	var rows []struct {
+		PostID            uint64
+		ObjectKey         string
+		OriginalObjectKey string
+	}
+	if err := r.db.WithContext(ctx).Table("post_detail_images AS i").
+		Select("d.post_id, i.object_key, i.original_object_key").
+		Joins("JOIN post_details AS d ON d.id = i.post_detail_id").
+		Where("d.post_id IN ?", postIDs).
+		Scan(&rows).Error; err != nil {
+		r.logger.Error("查询遗留帖子的图片对象键失败", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return nil, fmt.Errorf("查询遗留帖子的图片对象键失败: %w", err)
+	}
+	for _, row := range rows {
+		result[row.PostID] = append(result[row.PostID], row.ObjectKey)
+		if row.OriginalObjectKey != "" {
+			result[row.PostID] = append(result[row.PostID], row.OriginalObjectKey)
+		}
+	}
+	return result, nil
+}
+
+// ListDuplicateCandidates 实现发布查重候选帖子的查询。
+func (r *postRepository) ListDuplicateCandidates(ctx context.Context, authorID *string, since time.Time, limit int) ([]*entities.Post, error) {
+	query := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Select("id", "created_at", "title", "author_id", "author_username", "status", "content_sim_hash").
+		Where("content_sim_hash <> 0 AND created_at >= ?", since)
+	if authorID != nil {
+		query = query.Where("author_id = ? AND status IN ? AND is_draft = ? AND image_upload_pending = ?",
+			*authorID, []enums.Status{enums.Pending, enums.Approved}, false, false)
+	} else {
+		query = applyTargetingFilter(query.Where("status = ?", enums.Approved), nil)
+	}
+
+	var posts []*entities.Post
+	if err := query.Order("id DESC").Limit(limit).Find(&posts).Error; err != nil {
+		r.logger.Error("查询发布查重候选帖子失败", zap.Error(err), zap.Stringp("authorID", authorID), zap.Time("since", since))
+		return nil, fmt.Errorf("查询发布查重候选帖子失败: %w", err)
+	}
+	return posts, nil
+}
+
+// UpdateAuthorInfoBatch 实现作者冗余信息的分批同步。
+func (r *postRepository) UpdateAuthorInfoBatch(ctx context.Context, authorID string, avatar, username *string, limit int) (int64, error) {
+	columns := make(map[string]interface{}, 2)
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.Post{}).Where("author_id = ?", authorID)
+	switch {
+	case avatar != nil && username != nil:
+		query = query.Where("(author_avatar <> ? OR author_username <> ?)", *avatar, *username)
+	case avatar != nil:
+		query = query.Where("author_avatar <> ?", *avatar)
+	case username != nil:
+		query = query.Where("author_username <> ?", *username)
+	default:
+		return 0, nil
+	}
+	if avatar != nil {
+		columns["author_avatar"] = *avatar
+	}
+	if username != nil {
+		columns["author_username"] = *username
+	}
+
+	result := query.Limit(limit).UpdateColumns(columns)
+	if result.Error != nil {
+		r.logger.Error("同步作者冗余信息失败", zap.Error(result.Error), zap.String("authorID", authorID))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
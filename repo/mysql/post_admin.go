@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt" // 需要导入 fmt
 	"github.com/Xushengqwer/go-common/models/enums"
+	"strings"
 	"time"
 
 	"github.com/Xushengqwer/go-common/commonerrors"
 	"github.com/Xushengqwer/go-common/core" // 导入日志库
 	"go.uber.org/zap"                       // 导入 zap
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/dto"
 	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
 )
 
 // PostAdminRepository 定义了帖子管理员相关的数据库操作接口。
@@ -29,7 +33,10 @@ type PostAdminRepository interface {
 	// - 用于管理员审核帖子（通过/拒绝）或系统自动更新状态。
 	// - reason (sql.NullString): 使用 sql.NullString 以区分 NULL 和空字符串。
 	// - 注意: 如果记录未找到或已被软删除，应返回明确的错误。
-	UpdatePostStatus(ctx context.Context, postID uint64, status enums.Status, reason sql.NullString) error
+	// - notModifiedSince 不为 nil 时为乐观条件：只有帖子的审核状态最后变更时间 (status_updated_at) 早于该时间才更新，
+	//   否则返回 myErrors.ErrStaleAuditEvent，用于丢弃乱序到达的旧审核事件；为 nil 时无条件更新。
+	// - 改标签等不涉及状态的写入只刷新 updated_at，不影响该条件。
+	UpdatePostStatus(ctx context.Context, postID uint64, status enums.Status, reason sql.NullString, notModifiedSince *time.Time) error
 
 	// ListPostsByCondition 根据多种可选条件分页查询帖子列表。
 	// - 服务于管理员后台的复杂查询和筛选需求。
@@ -37,10 +44,54 @@ type PostAdminRepository interface {
 	// - 输出: 返回帖子列表和满足条件的总记录数，用于分页展示。
 	ListPostsByCondition(ctx context.Context, req *dto.ListPostsByConditionRequest) ([]*entities.Post, int64, error)
 
-	// UpdateOfficialTag 更新指定帖子的官方标签。
-	// - 允许管理员为帖子添加或修改官方认证等标签。
+	// ListPostsByConditionCursor 按与 ListPostsByCondition 相同的筛选条件进行游标分页查询。
+	// - 以 req.CursorID 为游标按 ID 排序（req.OrderDesc 决定方向），忽略 Page 与 OrderBy，不统计总数。
+	// - 查询耗时与翻到第几页无关，用于替代深分页时的 OFFSET。
+	// - 输出: 帖子列表与下一页游标；没有更多数据时游标为 nil。
+	ListPostsByConditionCursor(ctx context.Context, req *dto.ListPostsByConditionRequest) ([]*entities.Post, *uint64, error)
+
+	// UpdateOfficialTag 将指定帖子的官方标签整体替换为 tag 一个标签（tag 为无标签时清空全部标签）。
+	// - 允许管理员为帖子添加或修改官方认证等标签；需要保留其他标签时使用 AddOfficialTagFlag / RemoveOfficialTagFlag。
 	// - 注意: 如果记录未找到或已被软删除，应返回明确的错误。
 	UpdateOfficialTag(ctx context.Context, postID uint64, tag enums.OfficialTag) error
+
+	// AddOfficialTagFlag 用 official_tags = official_tags | flag 为帖子追加一个官方标签，不影响已有的其他标签。
+	// - 帖子不存在或已删除时返回 commonerrors.ErrRepoNotFound。
+	AddOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag) error
+
+	// RemoveOfficialTagFlag 用 official_tags = official_tags & ~flag 移除帖子的一个官方标签，不影响其他标签。
+	// - 帖子不存在或已删除时返回 commonerrors.ErrRepoNotFound。
+	RemoveOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag) error
+
+	// BatchUpdateOfficialTag 用一条 UPDATE ... WHERE id IN (?) 将一批帖子的官方标签整体替换为同一个标签。
+	// - 已软删除的帖子不会被更新。
+	// - 输出: 实际更新的行数，以及不存在或已删除、未被更新的帖子 ID（按入参顺序）。
+	BatchUpdateOfficialTag(ctx context.Context, postIDs []uint64, tag enums.OfficialTag) (int64, []uint64, error)
+
+	// RestorePost 恢复一个已被软删除的帖子（撤销删除）。
+	// - 清空 posts、post_details、post_detail_images、post_faqs 中对应记录的 deleted_at。
+	// - 恢复的是转发帖时，原帖的转发数同步加 1。
+	// - 帖子状态重置为 Pending、清空审核原因，恢复后需要重新走审核。
+	// - db 参数用于支持事务，由服务层传入事务句柄。
+	// - 帖子不存在（包括已被物理删除）时返回 commonerrors.ErrRepoNotFound；帖子未被删除时返回 myErrors.ErrPostNotDeleted。
+	RestorePost(ctx context.Context, db *gorm.DB, postID uint64) error
+
+	// GetPostFullDetail 获取帖子主记录、详情与详情图片，供管理员查看完整详情。
+	// - 使用 Unscoped，已软删除的帖子同样可查；此时只返回随帖子一起删除的详情与图片，
+	//   在此之前就被单独删除的图片（如作者编辑时移除的）不返回，判定方式与 RestorePost 一致。
+	// - 帖子不存在（包括已被物理删除）时返回 commonerrors.ErrRepoNotFound；详情缺失时 detail 为 nil。
+	// - 图片按 DisplayOrder 升序返回。
+	GetPostFullDetail(ctx context.Context, postID uint64) (*entities.Post, *entities.PostDetail, []*entities.PostDetailImage, error)
+
+	// CountPostsByStatus 用 GROUP BY status 统计各审核状态的帖子数量，草稿单独计数（草稿同为待审核状态，但尚未送审）。
+	// - 已软删除的帖子与图片上传中的占位帖子不计入。
+	// - 没有帖子的状态不会出现在返回的映射中。
+	CountPostsByStatus(ctx context.Context) (map[enums.Status]int64, int64, error)
+
+	// CountPostsByOfficialTag 统计各官方标签的帖子数量（含无标签），统计范围与 CountPostsByStatus 一致（含草稿）。
+	// - 拥有多个标签的帖子在每个标签下各计一次，各标签数量之和可能大于帖子总数。
+	// - 数量为 0 的标签不会出现在返回的映射中。
+	CountPostsByOfficialTag(ctx context.Context) (map[enums.OfficialTag]int64, error)
 }
 
 // postAdminRepository 是 PostAdminRepository 接口的 MySQL 实现。
@@ -78,27 +129,47 @@ func (r *postAdminRepository) GetPostByID(ctx context.Context, id uint64) (*enti
 }
 
 // UpdatePostStatus 实现更新帖子状态和原因的逻辑。
-func (r *postAdminRepository) UpdatePostStatus(ctx context.Context, postID uint64, status enums.Status, reason sql.NullString) error {
+func (r *postAdminRepository) UpdatePostStatus(ctx context.Context, postID uint64, status enums.Status, reason sql.NullString, notModifiedSince *time.Time) error {
 	// 准备需要更新的字段 map。
 	// 使用 map 可以确保只更新指定的字段。
+	now := time.Now()
 	updateData := map[string]interface{}{
-		"status":       status,
-		"updated_at":   time.Now(), // 总是更新修改时间
-		"audit_reason": reason,     // 更新审核原因 (可以是 NULL)
+		"status":            status,
+		"status_updated_at": now,    // 记录状态变更时间，供后续审核事件判断是否过期
+		"updated_at":        now,    // 总是更新修改时间
+		"audit_reason":      reason, // 更新审核原因 (可以是 NULL)
 	}
 
 	// 执行更新操作，限制条件为 ID 匹配且未被软删除。
-	result := r.db.WithContext(ctx).
+	// 带乐观条件时，状态在事件时间之后变更过的帖子不会被更新；status_updated_at 为 NULL 的旧帖子以 created_at 代替。
+	query := r.db.WithContext(ctx).
 		Model(&entities.Post{}).
-		Where("id = ? AND deleted_at IS NULL", postID).
-		Updates(updateData)
+		Where("id = ? AND deleted_at IS NULL", postID)
+	if notModifiedSince != nil {
+		query = query.Where("COALESCE(status_updated_at, created_at) < ?", *notModifiedSince)
+	}
+	result := query.Updates(updateData)
 
 	// 处理 GORM 操作本身的错误。
 	if result.Error != nil {
 		r.logger.Error("更新帖子状态数据库出错", zap.Error(result.Error), zap.Uint64("postID", postID), zap.Any("status", status))
 		return result.Error
 	}
-	// 检查是否有行受到影响。如果没有，说明帖子未找到或已被删除。
+	// 检查是否有行受到影响。如果没有，说明帖子未找到或已被删除；带乐观条件时还可能是帖子状态在事件之后变更过。
+	if result.RowsAffected == 0 && notModifiedSince != nil {
+		var exists int64
+		if err := r.db.WithContext(ctx).Model(&entities.Post{}).Where("id = ? AND deleted_at IS NULL", postID).Count(&exists).Error; err != nil {
+			r.logger.Error("判断帖子是否存在失败", zap.Error(err), zap.Uint64("postID", postID))
+			return err
+		}
+		if exists > 0 {
+			r.logger.Warn("帖子状态在审核事件之后已变更，忽略过期的状态更新",
+				zap.Uint64("postID", postID),
+				zap.Any("status", status),
+				zap.Time("notModifiedSince", *notModifiedSince))
+			return myErrors.ErrStaleAuditEvent
+		}
+	}
 	if result.RowsAffected == 0 {
 		r.logger.Warn("尝试更新不存在或已删除帖子的状态", zap.Uint64("postID", postID), zap.Any("status", status))
 		return commonerrors.ErrRepoNotFound
@@ -137,43 +208,10 @@ func (r *postAdminRepository) ListPostsByCondition(ctx context.Context, req *dto
 		return posts, 1, nil // 返回单条记录及总数 1
 	}
 
-	// --- 动态构建查询条件 ---
-	// 使用 Where 方法链式添加条件。
-	// 对于可选条件，先判断 DTO 中的字段是否为 nil。
-	if req.Title != nil {
-		dbQuery = dbQuery.Where("title LIKE ?", "%"+*req.Title+"%")
-	}
-	if req.AuthorUsername != nil {
-		dbQuery = dbQuery.Where("author_username LIKE ?", "%"+*req.AuthorUsername+"%")
-	}
-	if req.Status != nil {
-		dbQuery = dbQuery.Where("status = ?", *req.Status)
-	}
-	if req.OfficialTag != nil {
-		dbQuery = dbQuery.Where("official_tag = ?", *req.OfficialTag)
-	}
-	// 处理范围查询
-	if req.ViewCountMin != nil || req.ViewCountMax != nil {
-		// 这里可以简化逻辑，因为 GORM 的 Where 能处理 nil 值（虽然显式检查更清晰）
-		if req.ViewCountMin != nil {
-			dbQuery = dbQuery.Where("view_count >= ?", *req.ViewCountMin)
-		}
-		if req.ViewCountMax != nil {
-			dbQuery = dbQuery.Where("view_count <= ?", *req.ViewCountMax)
-		}
-	}
+	dbQuery = applyListPostsConditions(dbQuery, req)
 
 	// --- 处理排序 ---
-	orderField := "created_at" // 默认排序字段
-	if req.OrderBy == "updated_at" {
-		orderField = "updated_at"
-	}
-	orderDirection := "ASC" // 默认升序
-	if req.OrderDesc {
-		orderDirection = "DESC" // 如果 DTO 要求降序
-	}
-	// 构建完整的 ORDER BY 子句
-	orderClause := fmt.Sprintf("%s %s", orderField, orderDirection)
+	orderClause := buildListPostsOrder(req)
 
 	// --- 执行 Count 查询 ---
 	// 先计算总数，此时不应用 Limit 和 Offset，但应用 Where 条件。
@@ -203,11 +241,108 @@ func (r *postAdminRepository) ListPostsByCondition(ctx context.Context, req *dto
 	return posts, total, nil // 返回查询结果和总数
 }
 
+// buildListPostsOrder 把请求中的组合排序条件转换为 ORDER BY 子句。
+// - 字段再次按白名单过滤，并以列名引用的方式写入 SQL，不存在注入风险；没有有效字段时按创建时间排序。
+// - 末尾以 id 作为最终排序（方向与最后一个字段一致），保证排序值相同时顺序稳定，也使首屏与游标分页的顺序一致。
+func buildListPostsOrder(req *dto.ListPostsByConditionRequest) clause.OrderBy {
+	columns := make([]clause.OrderByColumn, 0, len(req.Sorts)+1)
+	for _, sort := range req.Sorts {
+		if !constant.AdminPostSortFields[sort.Field] {
+			continue
+		}
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: sort.Field}, Desc: sort.Desc})
+	}
+	if len(columns) == 0 {
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: constant.AdminPostSortDefaultField}, Desc: req.OrderDesc})
+	}
+	columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: columns[len(columns)-1].Desc})
+	return clause.OrderBy{Columns: columns}
+}
+
+// ListPostsByConditionCursor 实现按条件游标分页查询帖子。
+func (r *postAdminRepository) ListPostsByConditionCursor(ctx context.Context, req *dto.ListPostsByConditionRequest) ([]*entities.Post, *uint64, error) {
+	var posts []*entities.Post
+	dbQuery := applyListPostsConditions(r.db.WithContext(ctx).Model(&entities.Post{}).Where("deleted_at IS NULL"), req)
+	if req.ID != nil {
+		dbQuery = dbQuery.Where("id = ?", *req.ID)
+	}
+
+	// 游标条件与排序方向一致：降序取更小的 ID，升序取更大的 ID
+	if req.OrderDesc {
+		dbQuery = dbQuery.Order("id DESC")
+		if req.CursorID != nil {
+			dbQuery = dbQuery.Where("id < ?", *req.CursorID)
+		}
+	} else {
+		dbQuery = dbQuery.Order("id ASC")
+		if req.CursorID != nil {
+			dbQuery = dbQuery.Where("id > ?", *req.CursorID)
+		}
+	}
+
+	// 多查一条用于判断是否还有下一页
+	if err := dbQuery.Limit(req.PageSize + 1).Find(&posts).Error; err != nil {
+		r.logger.Error("按条件游标查询帖子失败", zap.Error(err), zap.Uint64p("cursorID", req.CursorID))
+		return nil, nil, err
+	}
+
+	var nextCursor *uint64
+	if len(posts) > req.PageSize {
+		posts = posts[:req.PageSize]
+		nextCursor = &posts[req.PageSize-1].ID
+	}
+	r.logger.Debug("按条件游标查询帖子成功", zap.Uint64p("cursorID", req.CursorID), zap.Int("count", len(posts)))
+	return posts, nextCursor, nil
+}
+
+// applyListPostsConditions 为管理员帖子列表查询追加筛选条件（不含精确 ID、排序与分页）。
+func applyListPostsConditions(dbQuery *gorm.DB, req *dto.ListPostsByConditionRequest) *gorm.DB {
+	// 草稿尚未提交审核，不出现在管理员的帖子列表中
+	dbQuery = dbQuery.Where("is_draft = ?", false)
+	// 图片仍在上传中的占位帖子尚未创建完成，同样不出现
+	dbQuery = dbQuery.Where("image_upload_pending = ?", false)
+
+	// --- 动态构建查询条件 ---
+	// 使用 Where 方法链式添加条件。
+	// 对于可选条件，先判断 DTO 中的字段是否为 nil。
+	if req.Title != nil {
+		dbQuery = dbQuery.Where("title LIKE ?", "%"+*req.Title+"%")
+	}
+	if req.AuthorUsername != nil {
+		dbQuery = dbQuery.Where("author_username LIKE ?", "%"+*req.AuthorUsername+"%")
+	}
+	if req.Status != nil {
+		dbQuery = dbQuery.Where("status = ?", *req.Status)
+	}
+	if req.OfficialTag != nil {
+		dbQuery = whereOfficialTag(dbQuery, *req.OfficialTag)
+	}
+	// 处理范围查询
+	if req.ViewCountMin != nil || req.ViewCountMax != nil {
+		// 这里可以简化逻辑，因为 GORM 的 Where 能处理 nil 值（虽然显式检查更清晰）
+		if req.ViewCountMin != nil {
+			dbQuery = dbQuery.Where("view_count >= ?", *req.ViewCountMin)
+		}
+		if req.ViewCountMax != nil {
+			dbQuery = dbQuery.Where("view_count <= ?", *req.ViewCountMax)
+		}
+	}
+	// 创建时间范围：两端独立生效，只传一端即为开区间查询。
+	// 时间参数带时区偏移，MySQL 驱动会按 DSN 的 loc 转换后再比较，无需在此换算时区。
+	if req.CreatedAtStart != nil {
+		dbQuery = dbQuery.Where("created_at >= ?", *req.CreatedAtStart)
+	}
+	if req.CreatedAtEnd != nil {
+		dbQuery = dbQuery.Where("created_at <= ?", *req.CreatedAtEnd)
+	}
+	return dbQuery
+}
+
 // UpdateOfficialTag 实现更新帖子官方标签的逻辑。
 func (r *postAdminRepository) UpdateOfficialTag(ctx context.Context, postID uint64, tag enums.OfficialTag) error {
 	updateData := map[string]interface{}{
-		"official_tag": tag,
-		"updated_at":   time.Now(),
+		"official_tags": entities.OfficialTagFlag(tag),
+		"updated_at":    time.Now(),
 	}
 
 	result := r.db.WithContext(ctx).
@@ -226,3 +361,251 @@ func (r *postAdminRepository) UpdateOfficialTag(ctx context.Context, postID uint
 	r.logger.Debug("成功更新帖子官方标签", zap.Uint64("postID", postID), zap.Any("tag", tag))
 	return nil
 }
+
+// AddOfficialTagFlag 实现追加帖子官方标签的逻辑。
+func (r *postAdminRepository) AddOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag) error {
+	return r.updateOfficialTagFlags(ctx, postID, tag, gorm.Expr("official_tags | ?", int(entities.OfficialTagFlag(tag))))
+}
+
+// RemoveOfficialTagFlag 实现移除帖子官方标签的逻辑。
+func (r *postAdminRepository) RemoveOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag) error {
+	return r.updateOfficialTagFlags(ctx, postID, tag, gorm.Expr("official_tags & ~?", int(entities.OfficialTagFlag(tag))))
+}
+
+// updateOfficialTagFlags 用位运算表达式更新帖子的官方标签位掩码。
+// - 同时更新 updated_at，标签位未变化时 RowsAffected 仍为 1，RowsAffected 为 0 只表示帖子不存在或已删除。
+func (r *postAdminRepository) updateOfficialTagFlags(ctx context.Context, postID uint64, tag enums.OfficialTag, expr clause.Expr) error {
+	result := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND deleted_at IS NULL", postID).
+		Updates(map[string]interface{}{
+			"official_tags": expr,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Error("按位更新官方标签数据库出错", zap.Error(result.Error), zap.Uint64("postID", postID), zap.Any("tag", tag))
+		return fmt.Errorf("按位更新帖子 %d 的官方标签失败: %w", postID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		r.logger.Warn("尝试更新不存在或已删除帖子的官方标签", zap.Uint64("postID", postID), zap.Any("tag", tag))
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// BatchUpdateOfficialTag 实现批量更新帖子官方标签的逻辑。
+// - 在同一事务内先查出存在的帖子 ID 再更新，得到准确的未找到列表。
+// - MySQL 的 RowsAffected 只统计值真正变化的行，同时更新 updated_at 保证存在的行都会计入。
+func (r *postAdminRepository) BatchUpdateOfficialTag(ctx context.Context, postIDs []uint64, tag enums.OfficialTag) (int64, []uint64, error) {
+	var (
+		existingIDs []uint64
+		affected    int64
+	)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.Post{}).Where("id IN ?", postIDs).Pluck("id", &existingIDs).Error; err != nil {
+			return fmt.Errorf("查询待更新标签的帖子失败: %w", err)
+		}
+		if len(existingIDs) == 0 {
+			return nil
+		}
+		result := tx.Model(&entities.Post{}).
+			Where("id IN ?", existingIDs).
+			Updates(map[string]interface{}{
+				"official_tags": entities.OfficialTagFlag(tag),
+				"updated_at":    time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("批量更新官方标签失败: %w", result.Error)
+		}
+		affected = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("批量更新官方标签数据库出错", zap.Error(err), zap.Int("count", len(postIDs)), zap.Any("tag", tag))
+		return 0, nil, err
+	}
+
+	existing := make(map[uint64]struct{}, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = struct{}{}
+	}
+	notFoundIDs := make([]uint64, 0)
+	for _, id := range postIDs {
+		if _, ok := existing[id]; !ok {
+			notFoundIDs = append(notFoundIDs, id)
+		}
+	}
+	r.logger.Debug("批量更新帖子官方标签完成", zap.Int64("affected", affected), zap.Int("notFound", len(notFoundIDs)), zap.Any("tag", tag))
+	return affected, notFoundIDs, nil
+}
+
+// RestorePost 实现软删除帖子的恢复。
+func (r *postAdminRepository) RestorePost(ctx context.Context, db *gorm.DB, postID uint64) error {
+	tx := db.WithContext(ctx)
+
+	// 1. 确认帖子存在且处于删除状态（需要 Unscoped 才能查到已软删除的记录）
+	var post entities.Post
+	if err := tx.Unscoped().Where("id = ?", postID).First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Warn("尝试恢复不存在的帖子", zap.Uint64("postID", postID))
+			return commonerrors.ErrRepoNotFound
+		}
+		r.logger.Error("恢复帖子时查询帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return err
+	}
+	if !post.DeletedAt.Valid {
+		return myErrors.ErrPostNotDeleted
+	}
+
+	// 2. 恢复帖子主记录，并重置为待审核
+	err := tx.Unscoped().Model(&entities.Post{}).
+		Where("id = ?", postID).
+		Updates(map[string]interface{}{
+			"deleted_at":        nil,
+			"status":            enums.Pending,
+			"status_updated_at": time.Now(),
+			"audit_reason":      sql.NullString{},
+			"updated_at":        time.Now(),
+		}).Error
+	if err != nil {
+		r.logger.Error("恢复帖子主记录失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("恢复帖子主记录失败: %w", err)
+	}
+
+	// 关联记录只恢复随帖子一起删除的那部分：删除帖子时关联记录先于主记录删除，时间略早于主记录，
+	// 而在此之前就被单独删除的记录（如被替换掉的旧 FAQ、被移除的图片）不应复活。
+	cascadeSince := post.DeletedAt.Time.Add(-constant.PostRestoreCascadeWindow)
+
+	// 3. 恢复帖子详情与详情图片（图片通过详情 ID 关联）
+	if err := tx.Unscoped().Model(&entities.PostDetail{}).Where("post_id = ? AND deleted_at >= ?", postID, cascadeSince).Update("deleted_at", nil).Error; err != nil {
+		r.logger.Error("恢复帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("恢复帖子详情失败: %w", err)
+	}
+	detailIDs := tx.Unscoped().Model(&entities.PostDetail{}).Select("id").Where("post_id = ?", postID)
+	if err := tx.Unscoped().Model(&entities.PostDetailImage{}).Where("post_detail_id IN (?) AND deleted_at >= ?", detailIDs, cascadeSince).Update("deleted_at", nil).Error; err != nil {
+		r.logger.Error("恢复帖子详情图片失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("恢复帖子详情图片失败: %w", err)
+	}
+
+	// 3.1 转发帖恢复后，原帖转发数同步加回（原帖已删除时不处理）
+	if post.QuotedPostID != nil {
+		if err := tx.Model(&entities.Post{}).Where("id = ?", *post.QuotedPostID).UpdateColumn("repost_count", gorm.Expr("repost_count + 1")).Error; err != nil {
+			r.logger.Error("恢复转发帖时增加原帖转发数失败", zap.Error(err), zap.Uint64("postID", postID), zap.Uint64("quotedPostID", *post.QuotedPostID))
+			return fmt.Errorf("增加原帖转发数失败: %w", err)
+		}
+	}
+
+	// 4. 恢复帖子 FAQ
+	if err := tx.Unscoped().Model(&entities.PostFAQ{}).Where("post_id = ? AND deleted_at >= ?", postID, cascadeSince).Update("deleted_at", nil).Error; err != nil {
+		r.logger.Error("恢复帖子 FAQ 失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("恢复帖子 FAQ 失败: %w", err)
+	}
+
+	r.logger.Info("帖子及其关联数据已恢复", zap.Uint64("postID", postID))
+	return nil
+}
+
+// GetPostFullDetail 实现管理员完整详情的查询。
+func (r *postAdminRepository) GetPostFullDetail(ctx context.Context, postID uint64) (*entities.Post, *entities.PostDetail, []*entities.PostDetailImage, error) {
+	db := r.db.WithContext(ctx)
+
+	var post entities.Post
+	if err := db.Unscoped().Where("id = ?", postID).First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, commonerrors.ErrRepoNotFound
+		}
+		r.logger.Error("查询帖子完整详情时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, nil, nil, fmt.Errorf("获取帖子失败: %w", err)
+	}
+
+	// 关联记录的可见范围：帖子未删除时只看未删除的记录；帖子已删除时额外包含随帖子一起删除的记录
+	visible := func(query *gorm.DB) *gorm.DB {
+		if !post.DeletedAt.Valid {
+			return query.Where("deleted_at IS NULL")
+		}
+		cascadeSince := post.DeletedAt.Time.Add(-constant.PostRestoreCascadeWindow)
+		return query.Where("deleted_at IS NULL OR deleted_at >= ?", cascadeSince)
+	}
+
+	var detail entities.PostDetail
+	err := visible(db.Unscoped().Where("post_id = ?", postID)).Order("id DESC").First(&detail).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &post, nil, []*entities.PostDetailImage{}, nil
+	}
+	if err != nil {
+		r.logger.Error("查询帖子完整详情时获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, nil, nil, fmt.Errorf("获取帖子详情失败: %w", err)
+	}
+
+	var images []*entities.PostDetailImage
+	err = visible(db.Unscoped().Where("post_detail_id = ?", detail.ID)).
+		Order("display_order ASC").Order("id ASC").
+		Find(&images).Error
+	if err != nil {
+		r.logger.Error("查询帖子完整详情时获取详情图片失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, nil, nil, fmt.Errorf("获取帖子详情图片失败: %w", err)
+	}
+	return &post, &detail, images, nil
+}
+
+// CountPostsByStatus 实现按审核状态的帖子数量统计。
+func (r *postAdminRepository) CountPostsByStatus(ctx context.Context) (map[enums.Status]int64, int64, error) {
+	var rows []struct {
+		Status  enums.Status
+		IsDraft bool
+		Count   int64
+	}
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).
+		Select("status, is_draft, COUNT(*) AS count").
+		Where("image_upload_pending = ?", false).
+		Group("status, is_draft").
+		Scan(&rows).Error; err != nil {
+		r.logger.Error("按审核状态统计帖子数量失败", zap.Error(err))
+		return nil, 0, fmt.Errorf("按审核状态统计帖子数量失败: %w", err)
+	}
+
+	counts := make(map[enums.Status]int64, len(rows))
+	var drafts int64
+	for _, row := range rows {
+		if row.IsDraft {
+			drafts += row.Count
+			continue
+		}
+		counts[row.Status] += row.Count
+	}
+	return counts, drafts, nil
+}
+
+// CountPostsByOfficialTag 实现按官方标签的帖子数量统计。
+// - 位掩码无法直接 GROUP BY，改为一次扫描中按每个标签位分别求和。
+func (r *postAdminRepository) CountPostsByOfficialTag(ctx context.Context) (map[enums.OfficialTag]int64, error) {
+	tags := append([]enums.OfficialTag{enums.OfficialTagNone}, entities.DefinedOfficialTags...)
+	columns := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if tag == enums.OfficialTagNone {
+			columns = append(columns, fmt.Sprintf("COALESCE(SUM(official_tags = 0), 0) AS c%d", i))
+			continue
+		}
+		columns = append(columns, fmt.Sprintf("COALESCE(SUM(official_tags & %d > 0), 0) AS c%d", entities.OfficialTagFlag(tag), i))
+	}
+	values := make([]int64, len(tags))
+	dest := make([]interface{}, len(tags))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.db.WithContext(ctx).Model(&entities.Post{}).
+		Select(strings.Join(columns, ", ")).
+		Where("image_upload_pending = ?", false).
+		Row().Scan(dest...); err != nil {
+		r.logger.Error("按官方标签统计帖子数量失败", zap.Error(err))
+		return nil, fmt.Errorf("按官方标签统计帖子数量失败: %w", err)
+	}
+
+	counts := make(map[enums.OfficialTag]int64, len(tags))
+	for i, tag := range tags {
+		if values[i] > 0 {
+			counts[tag] = values[i]
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// benchAdminListPosts 是管理员列表基准测试预置的帖子数量，需覆盖最深的测试页。
+const benchAdminListPosts = 60000
+
+// BenchmarkAdminListDeepPage 对比管理员帖子列表在不同深度下 offset 分页与游标分页的耗时。
+// - offset 分页每页都统计总数并扫描跳过前面的全部行，耗时随页码线性增长；游标分页按主键定位，与深度无关。
+// - 需要设置 POST_SERVICE_BENCH_MYSQL_DSN，否则跳过。
+func BenchmarkAdminListDeepPage(b *testing.B) {
+	db := newBenchMySQL(b, &entities.Post{})
+	batchRepo := &postBatchOperationsRepository{db: db, viewSyncCfg: config.ViewSyncConfig{}}
+	seedBenchPosts(b, batchRepo, benchAdminListPosts)
+	r := NewPostAdminRepository(db, newTestLogger(b))
+	ctx := context.Background()
+
+	const pageSize = 20
+	for _, page := range []int{1, 100, 1000, 2500} {
+		offset := (page - 1) * pageSize
+
+		// 游标取 offset 分页上一页最后一条的 ID
+		var cursor *uint64
+		if offset > 0 {
+			var ids []uint64
+			if err := db.Model(&entities.Post{}).Where("image_upload_pending = ?", false).
+				Order("id DESC").Offset(offset-1).Limit(1).Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+				b.Fatalf("定位第 %d 页的游标失败: %v", page, err)
+			}
+			cursor = &ids[0]
+		}
+
+		b.Run(fmt.Sprintf("offset/page=%d", page), func(b *testing.B) {
+			// 默认按 created_at、id 倒序，预置数据的创建时间与 ID 同序，两种方式取到的是同一深度的数据
+			req := &dto.ListPostsByConditionRequest{OrderDesc: true, Page: page, PageSize: pageSize}
+			for i := 0; i < b.N; i++ {
+				if _, _, err := r.ListPostsByCondition(ctx, req); err != nil {
+					b.Fatalf("offset 分页查询失败: %v", err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("cursor/page=%d", page), func(b *testing.B) {
+			req := &dto.ListPostsByConditionRequest{OrderDesc: true, PageSize: pageSize, CursorID: cursor}
+			for i := 0; i < b.N; i++ {
+				if _, _, err := r.ListPostsByConditionCursor(ctx, req); err != nil {
+					b.Fatalf("游标分页查询失败: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"gorm.io/gorm"
+)
+
+// newStatusTestPost 创建一条待审核帖子，送审时间为 submittedAt。
+func newStatusTestPost(t *testing.T, db *gorm.DB, submittedAt time.Time) *entities.Post {
+	t.Helper()
+	post := &entities.Post{Title: "测试帖子", AuthorID: "author-1", Status: enums.Pending, StatusUpdatedAt: &submittedAt}
+	post.CreatedAt = submittedAt
+	if err := db.Create(post).Error; err != nil {
+		t.Fatalf("创建测试帖子失败: %v", err)
+	}
+	return post
+}
+
+func postStatusOf(t *testing.T, db *gorm.DB, postID uint64) enums.Status {
+	t.Helper()
+	var post entities.Post
+	if err := db.First(&post, postID).Error; err != nil {
+		t.Fatalf("查询测试帖子失败: %v", err)
+	}
+	return post.Status
+}
+
+func TestUpdatePostStatusDropsOutOfOrderAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, &entities.Post{})
+	repo := NewPostAdminRepository(db, newTestLogger(t))
+	postRepo := NewPostRepository(db, newTestLogger(t))
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	eventTime := func(offset time.Duration) *time.Time {
+		ts := base.Add(offset)
+		return &ts
+	}
+
+	t.Run("审核结果晚于送审时生效", func(t *testing.T) {
+		post := newStatusTestPost(t, db, base)
+		if err := repo.UpdatePostStatus(ctx, post.ID, enums.Approved, sql.NullString{}, eventTime(time.Minute)); err != nil {
+			t.Fatalf("UpdatePostStatus 返回错误: %v", err)
+		}
+		if got := postStatusOf(t, db, post.ID); got != enums.Approved {
+			t.Fatalf("帖子状态 = %v, 期望 Approved", got)
+		}
+	})
+
+	t.Run("管理员改判后重投的旧审核通过消息被丢弃", func(t *testing.T) {
+		post := newStatusTestPost(t, db, base)
+		if err := repo.UpdatePostStatus(ctx, post.ID, enums.Approved, sql.NullString{}, eventTime(time.Minute)); err != nil {
+			t.Fatalf("UpdatePostStatus 返回错误: %v", err)
+		}
+		// 管理员手动驳回，不带乐观条件
+		if err := repo.UpdatePostStatus(ctx, post.ID, enums.Rejected, sql.NullString{String: "违规", Valid: true}, nil); err != nil {
+			t.Fatalf("管理员驳回返回错误: %v", err)
+		}
+		err := repo.UpdatePostStatus(ctx, post.ID, enums.Approved, sql.NullString{}, eventTime(time.Minute))
+		if !errors.Is(err, myErrors.ErrStaleAuditEvent) {
+			t.Fatalf("重投的旧消息错误 = %v, 期望 ErrStaleAuditEvent", err)
+		}
+		if got := postStatusOf(t, db, post.ID); got != enums.Rejected {
+			t.Fatalf("帖子状态 = %v, 期望保持 Rejected", got)
+		}
+	})
+
+	t.Run("申诉重新送审后到达的旧驳回消息被丢弃", func(t *testing.T) {
+		post := newStatusTestPost(t, db, base)
+		if err := repo.UpdatePostStatus(ctx, post.ID, enums.Rejected, sql.NullString{String: "违规", Valid: true}, eventTime(time.Minute)); err != nil {
+			t.Fatalf("UpdatePostStatus 返回错误: %v", err)
+		}
+		if err := postRepo.AppealRejectedPost(ctx, db, post.ID, 3); err != nil {
+			t.Fatalf("AppealRejectedPost 返回错误: %v", err)
+		}
+		err := repo.UpdatePostStatus(ctx, post.ID, enums.Rejected, sql.NullString{String: "违规", Valid: true}, eventTime(time.Minute))
+		if !errors.Is(err, myErrors.ErrStaleAuditEvent) {
+			t.Fatalf("重投的旧消息错误 = %v, 期望 ErrStaleAuditEvent", err)
+		}
+		if got := postStatusOf(t, db, post.ID); got != enums.Pending {
+			t.Fatalf("帖子状态 = %v, 期望保持 Pending", got)
+		}
+	})
+
+	t.Run("改官方标签刷新 updated_at 不影响审核结果", func(t *testing.T) {
+		post := newStatusTestPost(t, db, base)
+		if err := repo.UpdateOfficialTag(ctx, post.ID, enums.OfficialTag(1)); err != nil {
+			t.Fatalf("UpdateOfficialTag 返回错误: %v", err)
+		}
+		// updated_at 已晚于事件时间，但状态自送审后没有变化，结果仍应生效
+		if err := repo.UpdatePostStatus(ctx, post.ID, enums.Approved, sql.NullString{}, eventTime(time.Minute)); err != nil {
+			t.Fatalf("UpdatePostStatus 返回错误: %v", err)
+		}
+		if got := postStatusOf(t, db, post.ID); got != enums.Approved {
+			t.Fatalf("帖子状态 = %v, 期望 Approved", got)
+		}
+	})
+
+	t.Run("没有状态变更时间的旧帖子以创建时间比较", func(t *testing.T) {
+		post := newStatusTestPost(t, db, base)
+		if err := db.Model(&entities.Post{}).Where("id = ?", post.ID).Update("status_updated_at", nil).Error; err != nil {
+			t.Fatalf("清空 status_updated_at 失败: %v", err)
+		}
+		err := repo.UpdatePostStatus(ctx, post.ID, enums.Approved, sql.NullString{}, eventTime(-time.Minute))
+		if !errors.Is(err, myErrors.ErrStaleAuditEvent) {
+			t.Fatalf("早于创建时间的消息错误 = %v, 期望 ErrStaleAuditEvent", err)
+		}
+		if err := repo.UpdatePostStatus(ctx, post.ID, enums.Approved, sql.NullString{}, eventTime(time.Minute)); err != nil {
+			t.Fatalf("UpdatePostStatus 返回错误: %v", err)
+		}
+	})
+
+	t.Run("帖子不存在", func(t *testing.T) {
+		err := repo.UpdatePostStatus(ctx, 999999, enums.Approved, sql.NullString{}, eventTime(time.Minute))
+		if !errors.Is(err, commonerrors.ErrRepoNotFound) {
+			t.Fatalf("错误 = %v, 期望 ErrRepoNotFound", err)
+		}
+	})
+}
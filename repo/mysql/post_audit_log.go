@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// PostAuditLogRepository 定义了帖子状态流转审计日志的持久化操作接口。
+type PostAuditLogRepository interface {
+	// CreatePostAuditLog 追加一条帖子审计日志。
+	CreatePostAuditLog(ctx context.Context, log *entities.PostAuditLog) error
+
+	// BatchCreatePostAuditLogs 批量追加帖子审计日志，用于批量管理操作。
+	BatchCreatePostAuditLogs(ctx context.Context, logs []*entities.PostAuditLog) error
+
+	// ListPostAuditLogs 分页查询指定帖子的审计历史，按操作时间倒序。
+	// - 查询走从库 (dbresolver.Read)；已删除帖子的历史同样可以查询。
+	ListPostAuditLogs(ctx context.Context, postID uint64, offset, limit int) ([]*entities.PostAuditLog, int64, error)
+}
+
+// postAuditLogRepository 是 PostAuditLogRepository 接口针对 MySQL 的具体实现。
+type postAuditLogRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewPostAuditLogRepository 是 postAuditLogRepository 的构造函数。
+func NewPostAuditLogRepository(db *gorm.DB, logger *core.ZapLogger) PostAuditLogRepository {
+	return &postAuditLogRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreatePostAuditLog 实现帖子审计日志的插入。
+func (r *postAuditLogRepository) CreatePostAuditLog(ctx context.Context, log *entities.PostAuditLog) error {
+	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+		r.logger.Error("保存帖子审计日志失败", zap.Error(err), zap.Uint64("postID", log.PostID), zap.String("action", log.Action))
+		return err
+	}
+	return nil
+}
+
+// BatchCreatePostAuditLogs 实现帖子审计日志的批量插入。
+func (r *postAuditLogRepository) BatchCreatePostAuditLogs(ctx context.Context, logs []*entities.PostAuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(logs, 100).Error; err != nil {
+		r.logger.Error("批量保存帖子审计日志失败", zap.Error(err), zap.Int("count", len(logs)), zap.String("action", logs[0].Action))
+		return err
+	}
+	return nil
+}
+
+// ListPostAuditLogs 实现帖子审计历史的分页查询。
+func (r *postAuditLogRepository) ListPostAuditLogs(ctx context.Context, postID uint64, offset, limit int) ([]*entities.PostAuditLog, int64, error) {
+	var logs []*entities.PostAuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Clauses(dbresolver.Read).Model(&entities.PostAuditLog{}).Where("post_id = ?", postID)
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("统计帖子审计日志数量失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, 0, fmt.Errorf("统计帖子审计日志失败: %w", err)
+	}
+	if total == 0 {
+		return logs, 0, nil
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
+		r.logger.Error("分页查询帖子审计日志失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, 0, fmt.Errorf("查询帖子审计日志失败: %w", err)
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// PostContentSegmentRepository 定义了帖子正文段落审核状态的持久化操作接口。
+// - 段落记录随每次送审整体替换，旧记录物理删除，不使用软删除。
+type PostContentSegmentRepository interface {
+	// GetSegmentsByPostID 获取指定帖子的全部段落记录，按 SegmentIndex 升序排列。
+	// - 帖子没有段落记录（编辑功能上线前创建、从未编辑过）时返回空切片，不返回错误。
+	GetSegmentsByPostID(ctx context.Context, postID uint64) ([]*entities.PostContentSegment, error)
+
+	// ReplaceSegments 物理删除帖子已有的段落记录并写入新的段落记录。
+	// - db 参数允许在外部事务中执行（与帖子正文更新、待审核事件的发件箱记录一起提交）。
+	ReplaceSegments(ctx context.Context, db *gorm.DB, postID uint64, segments []*entities.PostContentSegment) error
+
+	// ResolvePendingSegments 按审核结果在一个事务内更新帖子待审核段落的状态。
+	// - rejected 中的段落 (SegmentIndex) 置为拒绝，其余待审核段落置为 rest；已通过或已拒绝的段落不受影响。
+	ResolvePendingSegments(ctx context.Context, postID uint64, rejected []int, rest enums.Status) error
+}
+
+// postContentSegmentRepository 是 PostContentSegmentRepository 接口的 MySQL 实现。
+type postContentSegmentRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewPostContentSegmentRepository 是 postContentSegmentRepository 的构造函数。
+func NewPostContentSegmentRepository(db *gorm.DB, logger *core.ZapLogger) PostContentSegmentRepository {
+	return &postContentSegmentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetSegmentsByPostID 实现按帖子 ID 查询段落记录。
+func (r *postContentSegmentRepository) GetSegmentsByPostID(ctx context.Context, postID uint64) ([]*entities.PostContentSegment, error) {
+	var segments []*entities.PostContentSegment
+	err := r.db.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Order("segment_index ASC").
+		Find(&segments).Error
+	if err != nil {
+		r.logger.Error("根据帖子 ID 获取正文段落失败", zap.Uint64("postID", postID), zap.Error(err))
+		return nil, err
+	}
+	return segments, nil
+}
+
+// ReplaceSegments 实现段落记录的整体替换。
+func (r *postContentSegmentRepository) ReplaceSegments(ctx context.Context, db *gorm.DB, postID uint64, segments []*entities.PostContentSegment) error {
+	if err := db.WithContext(ctx).Unscoped().Where("post_id = ?", postID).Delete(&entities.PostContentSegment{}).Error; err != nil {
+		return fmt.Errorf("删除旧的正文段落失败: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	if err := db.WithContext(ctx).Create(&segments).Error; err != nil {
+		return fmt.Errorf("写入正文段落失败: %w", err)
+	}
+	return nil
+}
+
+// ResolvePendingSegments 实现审核结果的按段落合并，先拒绝指定段落，再处理剩余的待审核段落。
+func (r *postContentSegmentRepository) ResolvePendingSegments(ctx context.Context, postID uint64, rejected []int, rest enums.Status) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(rejected) > 0 {
+			if err := tx.Model(&entities.PostContentSegment{}).
+				Where("post_id = ? AND status = ? AND segment_index IN ?", postID, enums.Pending, rejected).
+				Update("status", enums.Rejected).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&entities.PostContentSegment{}).
+			Where("post_id = ? AND status = ?", postID, enums.Pending).
+			Update("status", rest).Error
+	})
+	if err != nil {
+		r.logger.Error("合并正文段落审核结果失败", zap.Uint64("postID", postID), zap.Ints("rejected", rejected), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+func TestPostContentSegmentRepositoryMergesAuditResult(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, &entities.PostContentSegment{})
+	repo := NewPostContentSegmentRepository(db, newTestLogger(t))
+
+	newSegments := func(postID uint64, statuses ...enums.Status) []*entities.PostContentSegment {
+		segments := make([]*entities.PostContentSegment, len(statuses))
+		for i, status := range statuses {
+			segments[i] = &entities.PostContentSegment{PostID: postID, SegmentIndex: i, ContentHash: "0123456789abcdef", Status: status}
+		}
+		return segments
+	}
+	statusesOf := func(postID uint64) []enums.Status {
+		t.Helper()
+		segments, err := repo.GetSegmentsByPostID(ctx, postID)
+		if err != nil {
+			t.Fatalf("GetSegmentsByPostID 返回错误: %v", err)
+		}
+		statuses := make([]enums.Status, len(segments))
+		for i, segment := range segments {
+			if segment.SegmentIndex != i {
+				t.Fatalf("段落记录未按 SegmentIndex 排序: 第 %d 条为 %d", i, segment.SegmentIndex)
+			}
+			statuses[i] = segment.Status
+		}
+		return statuses
+	}
+
+	// 旧记录被物理删除，其他帖子的记录不受影响
+	if err := repo.ReplaceSegments(ctx, db, 1, newSegments(1, enums.Approved, enums.Approved, enums.Approved)); err != nil {
+		t.Fatalf("ReplaceSegments 返回错误: %v", err)
+	}
+	if err := repo.ReplaceSegments(ctx, db, 2, newSegments(2, enums.Pending)); err != nil {
+		t.Fatalf("ReplaceSegments 返回错误: %v", err)
+	}
+	if err := repo.ReplaceSegments(ctx, db, 1, newSegments(1, enums.Approved, enums.Pending, enums.Pending, enums.Pending)); err != nil {
+		t.Fatalf("ReplaceSegments 返回错误: %v", err)
+	}
+	var total int64
+	if err := db.Unscoped().Model(&entities.PostContentSegment{}).Where("post_id = ?", 1).Count(&total).Error; err != nil {
+		t.Fatalf("统计段落记录失败: %v", err)
+	}
+	if total != 4 {
+		t.Fatalf("帖子 1 的段落记录（含软删除）数 = %d, 期望 4", total)
+	}
+
+	// 第 3 段被拒，其余待审核段落视为通过；已通过的段落即使出现在 rejected 中也不受影响
+	if err := repo.ResolvePendingSegments(ctx, 1, []int{0, 2}, enums.Approved); err != nil {
+		t.Fatalf("ResolvePendingSegments 返回错误: %v", err)
+	}
+	if got, want := statusesOf(1), []enums.Status{enums.Approved, enums.Approved, enums.Rejected, enums.Approved}; !slices.Equal(got, want) {
+		t.Errorf("帖子 1 的段落状态 = %v, 期望 %v", got, want)
+	}
+	if got, want := statusesOf(2), []enums.Status{enums.Pending}; !slices.Equal(got, want) {
+		t.Errorf("帖子 2 的段落状态 = %v, 期望 %v", got, want)
+	}
+
+	// 无法定位段落时本次送审的段落全部置为拒绝
+	if err := repo.ResolvePendingSegments(ctx, 2, nil, enums.Rejected); err != nil {
+		t.Fatalf("ResolvePendingSegments 返回错误: %v", err)
+	}
+	if got, want := statusesOf(2), []enums.Status{enums.Rejected}; !slices.Equal(got, want) {
+		t.Errorf("帖子 2 的段落状态 = %v, 期望 %v", got, want)
+	}
+}
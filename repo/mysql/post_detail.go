@@ -25,10 +25,10 @@ type PostDetailRepository interface {
 
 	// UpdatePostDetail 更新帖子详情信息
 	// - 意图: 更新数据库中指定帖子详情的内容、单价和联系方式，用于修改帖子详细信息
-	// - 输入: ctx context.Context, postDetail *entities.PostDetail
+	// - 输入: ctx context.Context, db *gorm.DB 执行更新的数据库句柄（可为事务）, postDetail *entities.PostDetail
 	// - 输出: error
-	// - 注意事项: 仅更新 content、price_per_unit 和 contact_qr_code 字段，避免修改无关字段
-	UpdatePostDetail(ctx context.Context, postDetail *entities.PostDetail) error
+	// - 注意事项: 仅更新 content、price_per_unit 和 contact_info 字段，避免修改无关字段
+	UpdatePostDetail(ctx context.Context, db *gorm.DB, postDetail *entities.PostDetail) error
 
 	// DeletePostDetailByPostID 根据 PostID 软删除帖子详情
 	// - 意图: 将指定 PostID 的帖子详情标记为已删除，用于逻辑删除帖子详情
@@ -74,9 +74,10 @@ func (r *postDetailRepository) GetPostDetailByPostID(ctx context.Context, postID
 }
 
 // UpdatePostDetail 更新帖子详情信息
-func (r *postDetailRepository) UpdatePostDetail(ctx context.Context, postDetail *entities.PostDetail) error {
+// db 参数是执行此操作的数据库句柄
+func (r *postDetailRepository) UpdatePostDetail(ctx context.Context, db *gorm.DB, postDetail *entities.PostDetail) error {
 	// Step 1: 使用 GORM 的 Updates 方法更新指定字段
-	if err := r.db.WithContext(ctx).Model(postDetail).Updates(map[string]interface{}{
+	if err := db.WithContext(ctx).Model(postDetail).Updates(map[string]interface{}{
 		"content":        postDetail.Content,
 		"price_per_unit": postDetail.PricePerUnit,
 		"contact_info":   postDetail.ContactInfo,
@@ -46,6 +46,12 @@ type PostDetailImageRepository interface {
 	// - 注意: 每个 image 对象都应包含其 ID 和需要更新的 DisplayOrder。
 	BatchUpdateImages(ctx context.Context, db *gorm.DB, images []*entities.PostDetailImage) error
 
+	// BackfillImageURLs 为先写库占位、后上传的图片记录回填 COS 访问 URL。
+	// - 意图: 带图帖子创建时图片记录先以空 URL 写入，上传成功后在同一事务内回填。
+	// - 输入: ctx, db (用于事务操作), images (每个对象需包含 ID 与待写入的 ImageURL)
+	// - 输出: error
+	BackfillImageURLs(ctx context.Context, db *gorm.DB, images []*entities.PostDetailImage) error
+
 	// DeleteImageByID 根据图片自身的ID删除帖子详情图片。
 	// - 意图: 从数据库中移除指定的单张图片记录。
 	// - 输入: ctx context.Context, db *gorm.DB (用于事务操作), imageID uint (假设BaseModel中的ID类型为uint)
@@ -59,6 +65,29 @@ type PostDetailImageRepository interface {
 	// - 输出: error
 	// - 原生 SQL (概念): DELETE FROM post_detail_images WHERE post_id = ?
 	DeleteImagesByPostDetailID(ctx context.Context, db *gorm.DB, postDetailID uint64) error
+
+	// SetCoverCandidates 将帖子详情下的候选封面整体替换为 imageIDs。
+	// - 意图: 先清除该帖子详情所有图片的候选标记，再标记 imageIDs；imageIDs 为空表示关闭 A/B 封面实验。
+	// - 输入: ctx, db (用于事务操作), postDetailID, imageIDs (调用方需确保均属于该帖子详情)
+	// - 输出: error
+	SetCoverCandidates(ctx context.Context, db *gorm.DB, postDetailID uint64, imageIDs []uint64) error
+
+	// GetCoverCandidatesByPostIDs 批量查询多个帖子的候选封面。
+	// - 意图: 列表展示时一次性取出当前页所有帖子的候选封面，避免 N+1 查询。
+	// - 输出: map[postID][]*entities.PostDetailImage，每个帖子的候选按 DisplayOrder、ID 升序；没有候选封面的帖子不出现在 map 中。
+	GetCoverCandidatesByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64][]*entities.PostDetailImage, error)
+
+	// GetFirstImagesByPostIDs 批量查询多个帖子的首图（DisplayOrder 最小的已上传图片）。
+	// - 意图: 列表展示时一次性取出当前页所有帖子的默认封面，避免 N+1 查询。
+	// - 输出: map[postID]*entities.PostDetailImage；没有图片的帖子不出现在 map 中。
+	// - 原生 SQL (概念): SELECT pdi.*, pd.post_id FROM post_detail_images pdi JOIN post_details pd ON ... WHERE pd.post_id IN (?) AND pdi.display_order = (SELECT MIN(display_order) ...)
+	GetFirstImagesByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]*entities.PostDetailImage, error)
+
+	// FilterReferencedObjectKeys 找出仍被未删除帖子引用的对象键。
+	// - 意图: COS 延迟删除任务真正删除对象前的兜底检查，帖子已被恢复或对象键被其他帖子复用时不应删除。
+	// - 图片、帖子详情与帖子三者均未被软删除才视为被引用。
+	// - 输出: 被引用的对象键集合；未出现在集合中的对象键可以安全删除。
+	FilterReferencedObjectKeys(ctx context.Context, objectKeys []string) (map[string]bool, error)
 }
 
 type postDetailImageRepository struct {
@@ -145,6 +174,21 @@ func (r *postDetailImageRepository) BatchUpdateImages(ctx context.Context, db *g
 	return nil
 }
 
+// BackfillImageURLs 逐条回填图片 URL（含保留的原图 URL），调用方传入事务保证整批要么全部回填、要么全部回滚。
+func (r *postDetailImageRepository) BackfillImageURLs(ctx context.Context, db *gorm.DB, images []*entities.PostDetailImage) error {
+	tx := db.WithContext(ctx)
+	for _, img := range images {
+		if err := tx.Model(&entities.PostDetailImage{}).Where("id = ?", img.ID).
+			UpdateColumns(map[string]interface{}{
+				"image_url":          img.ImageURL,
+				"original_image_url": img.OriginalImageURL,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // DeleteImageByID 根据图片自身的ID删除帖子详情图片。
 func (r *postDetailImageRepository) DeleteImageByID(ctx context.Context, db *gorm.DB, imageID uint) error {
 	tx := db.WithContext(ctx)
@@ -170,3 +214,116 @@ func (r *postDetailImageRepository) DeleteImagesByPostDetailID(ctx context.Conte
 	}
 	return nil
 }
+
+// SetCoverCandidates 整体替换帖子详情下的候选封面。
+func (r *postDetailImageRepository) SetCoverCandidates(ctx context.Context, db *gorm.DB, postDetailID uint64, imageIDs []uint64) error {
+	tx := db.WithContext(ctx)
+	if err := tx.Model(&entities.PostDetailImage{}).
+		Where("post_detail_id = ? AND is_cover_candidate = ?", postDetailID, true).
+		Update("is_cover_candidate", false).Error; err != nil {
+		return err
+	}
+	if len(imageIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&entities.PostDetailImage{}).
+		Where("post_detail_id = ? AND id IN ?", postDetailID, imageIDs).
+		Update("is_cover_candidate", true).Error
+}
+
+// coverCandidateRow 是批量查询候选封面时的结果行，附带图片所属的帖子 ID。
+type coverCandidateRow struct {
+	entities.PostDetailImage
+	PostID uint64
+}
+
+// GetCoverCandidatesByPostIDs 批量查询多个帖子的候选封面。
+func (r *postDetailImageRepository) GetCoverCandidatesByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64][]*entities.PostDetailImage, error) {
+	result := make(map[uint64][]*entities.PostDetailImage)
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+	var rows []*coverCandidateRow
+	err := r.db.WithContext(ctx).
+		Table("post_detail_images pdi").
+		Select("pdi.*, pd.post_id").
+		Joins("JOIN post_details pd ON pd.id = pdi.post_detail_id AND pd.deleted_at IS NULL").
+		Where("pd.post_id IN ? AND pdi.is_cover_candidate = ? AND pdi.deleted_at IS NULL", postIDs, true).
+		Order("pdi.display_order ASC, pdi.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		image := row.PostDetailImage
+		result[row.PostID] = append(result[row.PostID], &image)
+	}
+	return result, nil
+}
+
+// GetFirstImagesByPostIDs 批量查询多个帖子的首图。
+// - 占位中（URL 尚未回填）的图片不参与；DisplayOrder 相同时取 ID 最小的一张。
+func (r *postDetailImageRepository) GetFirstImagesByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]*entities.PostDetailImage, error) {
+	result := make(map[uint64]*entities.PostDetailImage)
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+	var rows []*coverCandidateRow
+	err := r.db.WithContext(ctx).
+		Table("post_detail_images pdi").
+		Select("pdi.*, pd.post_id").
+		Joins("JOIN post_details pd ON pd.id = pdi.post_detail_id AND pd.deleted_at IS NULL").
+		Where("pd.post_id IN ? AND pdi.deleted_at IS NULL AND pdi.image_url <> ''", postIDs).
+		Where("pdi.display_order = (?)", r.db.
+			Table("post_detail_images pdi_first").
+			Select("MIN(pdi_first.display_order)").
+			Where("pdi_first.post_detail_id = pdi.post_detail_id AND pdi_first.deleted_at IS NULL AND pdi_first.image_url <> ''")).
+		Order("pdi.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if _, ok := result[row.PostID]; ok {
+			continue
+		}
+		image := row.PostDetailImage
+		result[row.PostID] = &image
+	}
+	return result, nil
+}
+
+// FilterReferencedObjectKeys 找出仍被未删除帖子引用的对象键。
+func (r *postDetailImageRepository) FilterReferencedObjectKeys(ctx context.Context, objectKeys []string) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	if len(objectKeys) == 0 {
+		return referenced, nil
+	}
+	// 对象键既可能是展示用的图片，也可能是保留的原图
+	var rows []struct {
+		ObjectKey         string
+		OriginalObjectKey string
+	}
+	err := r.db.WithContext(ctx).
+		Table("post_detail_images pdi").
+		Select("pdi.object_key, pdi.original_object_key").
+		Joins("JOIN post_details pd ON pd.id = pdi.post_detail_id AND pd.deleted_at IS NULL").
+		Joins("JOIN posts p ON p.id = pd.post_id AND p.deleted_at IS NULL").
+		Where("(pdi.object_key IN ? OR pdi.original_object_key IN ?) AND pdi.deleted_at IS NULL", objectKeys, objectKeys).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(objectKeys))
+	for _, key := range objectKeys {
+		wanted[key] = true
+	}
+	for _, row := range rows {
+		for _, key := range []string{row.ObjectKey, row.OriginalObjectKey} {
+			if wanted[key] {
+				referenced[key] = true
+			}
+		}
+	}
+	return referenced, nil
+}
@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// PostFAQRepository 定义了帖子 FAQ 在 MySQL 中的持久化操作接口。
+type PostFAQRepository interface {
+	// BatchCreateFAQs 批量创建帖子 FAQ。
+	// - db 参数允许在外部事务中执行（与帖子创建保持原子性）。
+	BatchCreateFAQs(ctx context.Context, db *gorm.DB, faqs []*entities.PostFAQ) error
+
+	// GetFAQsByPostID 获取指定帖子的 FAQ 列表，按 DisplayOrder 升序排列。
+	// - 帖子没有 FAQ 时返回空切片，不返回错误。
+	GetFAQsByPostID(ctx context.Context, postID uint64) ([]*entities.PostFAQ, error)
+
+	// DeleteFAQsByPostID (软)删除指定帖子的全部 FAQ。
+	// - db 参数允许在外部事务中执行。
+	DeleteFAQsByPostID(ctx context.Context, db *gorm.DB, postID uint64) error
+}
+
+// postFAQRepository 是 PostFAQRepository 接口针对 MySQL 的具体实现。
+type postFAQRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewPostFAQRepository 是 postFAQRepository 的构造函数。
+func NewPostFAQRepository(db *gorm.DB, logger *core.ZapLogger) PostFAQRepository {
+	return &postFAQRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// BatchCreateFAQs 实现 FAQ 的批量插入。
+func (r *postFAQRepository) BatchCreateFAQs(ctx context.Context, db *gorm.DB, faqs []*entities.PostFAQ) error {
+	if len(faqs) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Create(&faqs).Error
+}
+
+// GetFAQsByPostID 实现按帖子 ID 查询 FAQ 列表。
+func (r *postFAQRepository) GetFAQsByPostID(ctx context.Context, postID uint64) ([]*entities.PostFAQ, error) {
+	var faqs []*entities.PostFAQ
+	err := r.db.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Order("display_order ASC").Order("id ASC").
+		Find(&faqs).Error
+	if err != nil {
+		r.logger.Error("根据帖子 ID 获取 FAQ 列表失败", zap.Uint64("postID", postID), zap.Error(err))
+		return nil, err
+	}
+	return faqs, nil
+}
+
+// DeleteFAQsByPostID 实现按帖子 ID (软)删除 FAQ。
+func (r *postFAQRepository) DeleteFAQsByPostID(ctx context.Context, db *gorm.DB, postID uint64) error {
+	return db.WithContext(ctx).Where("post_id = ?", postID).Delete(&entities.PostFAQ{}).Error
+}
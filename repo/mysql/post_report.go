@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// ReportedPost 是按帖子聚合的举报统计，附带帖子的基础信息。
+type ReportedPost struct {
+	PostID         uint64
+	Title          string
+	AuthorID       string
+	AuthorUsername string
+	Status         enums.Status
+	ReportCount    int64
+	LastReportedAt time.Time
+}
+
+// PostReportRepository 定义了用户举报帖子的持久化操作接口。
+type PostReportRepository interface {
+	// CreateReport 在事务内写入一条举报，并返回写入后该帖子的举报总数。
+	// - 写入前对帖子行加排他锁，同一帖子的举报串行执行，保证只有一次举报观察到举报数恰好达到阈值。
+	// - 用户已举报过该帖子时依靠 (post_id, reporter_id) 唯一索引忽略写入，返回 myErrors.ErrPostAlreadyReported。
+	// - db 传入事务，与阈值事件的发件箱记录一起提交。
+	CreateReport(ctx context.Context, db *gorm.DB, report *entities.PostReport) (int64, error)
+
+	// ListReportedPosts 按举报数倒序分页列出被举报的帖子（举报数相同时按帖子 ID 倒序），返回当前页与被举报帖子总数。
+	// - 按 post_id 聚合 post_reports 并关联 posts 取基础信息，已删除的帖子不再列出。
+	// - 查询走从库 (dbresolver.Read)。
+	ListReportedPosts(ctx context.Context, offset, limit int) ([]*ReportedPost, int64, error)
+}
+
+// postReportRepository 是 PostReportRepository 接口针对 MySQL 的具体实现。
+type postReportRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewPostReportRepository 是 postReportRepository 的构造函数。
+func NewPostReportRepository(db *gorm.DB, logger *core.ZapLogger) PostReportRepository {
+	return &postReportRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateReport 实现举报写入与举报数统计。
+func (r *postReportRepository) CreateReport(ctx context.Context, db *gorm.DB, report *entities.PostReport) (int64, error) {
+	tx := db.WithContext(ctx)
+
+	var locked []uint64
+	if err := tx.Model(&entities.Post{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", report.PostID).
+		Pluck("id", &locked).Error; err != nil {
+		r.logger.Error("举报时锁定帖子失败", zap.Error(err), zap.Uint64("postID", report.PostID))
+		return 0, fmt.Errorf("锁定帖子失败: %w", err)
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		r.logger.Error("保存帖子举报失败", zap.Error(result.Error), zap.Uint64("postID", report.PostID), zap.String("reporterID", report.ReporterID))
+		return 0, fmt.Errorf("保存帖子举报失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return 0, myErrors.ErrPostAlreadyReported
+	}
+
+	var reportCount int64
+	if err := tx.Model(&entities.PostReport{}).Where("post_id = ?", report.PostID).Count(&reportCount).Error; err != nil {
+		r.logger.Error("统计帖子举报数失败", zap.Error(err), zap.Uint64("postID", report.PostID))
+		return 0, fmt.Errorf("统计帖子举报数失败: %w", err)
+	}
+	return reportCount, nil
+}
+
+// ListReportedPosts 实现被举报帖子的聚合分页查询。
+// - 按 posts.id 分组，标题、作者等列函数依赖于主键，满足 ONLY_FULL_GROUP_BY。
+func (r *postReportRepository) ListReportedPosts(ctx context.Context, offset, limit int) ([]*ReportedPost, int64, error) {
+	base := r.db.WithContext(ctx).Clauses(dbresolver.Read).
+		Table("post_reports AS r").
+		Joins("JOIN posts AS p ON p.id = r.post_id AND p.deleted_at IS NULL").
+		Where("r.deleted_at IS NULL")
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Distinct("r.post_id").Count(&total).Error; err != nil {
+		r.logger.Error("统计被举报帖子数量失败", zap.Error(err))
+		return nil, 0, fmt.Errorf("统计被举报帖子数量失败: %w", err)
+	}
+	posts := make([]*ReportedPost, 0, limit)
+	if total == 0 {
+		return posts, 0, nil
+	}
+
+	err := base.Session(&gorm.Session{}).
+		Select("p.id AS post_id, p.title, p.author_id, p.author_username, p.status, " +
+			"COUNT(*) AS report_count, MAX(r.created_at) AS last_reported_at").
+		Group("p.id").
+		Order("report_count DESC").
+		Order("p.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&posts).Error
+	if err != nil {
+		r.logger.Error("查询被举报帖子列表失败", zap.Error(err), zap.Int("offset", offset), zap.Int("limit", limit))
+		return nil, 0, fmt.Errorf("查询被举报帖子列表失败: %w", err)
+	}
+	return posts, total, nil
+}
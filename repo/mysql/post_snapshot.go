@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// PostSnapshotRepository 定义了帖子指标快照的持久化操作接口。
+// - 快照只追加写入，过期后物理删除，不使用软删除。
+type PostSnapshotRepository interface {
+	// BatchCreateSnapshots 批量写入一轮快照，按 constant.SnapshotBatchSize 分批插入。
+	BatchCreateSnapshots(ctx context.Context, snapshots []*entities.PostMetricSnapshot) error
+
+	// ListPostSnapshots 查询单个帖子在 [start, end) 内指定粒度的快照，按快照时间升序，最多 limit 条。
+	// - 读取走从库 (dbresolver.Read)。
+	ListPostSnapshots(ctx context.Context, postID uint64, granularity string, start, end time.Time, limit int) ([]*entities.PostMetricSnapshot, error)
+
+	// PurgeSnapshotsBefore 物理删除指定粒度、快照时间早于 before 的快照。
+	// - 每条 DELETE 最多删除 constant.SnapshotPurgeBatchSize 行，循环直到删完，避免长事务。
+	// - 输出: 删除的总行数。
+	PurgeSnapshotsBefore(ctx context.Context, granularity string, before time.Time) (int64, error)
+}
+
+// postSnapshotRepository 是 PostSnapshotRepository 接口的 MySQL 实现。
+type postSnapshotRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewPostSnapshotRepository 是 postSnapshotRepository 的构造函数。
+func NewPostSnapshotRepository(db *gorm.DB, logger *core.ZapLogger) PostSnapshotRepository {
+	return &postSnapshotRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// BatchCreateSnapshots 实现快照的批量写入。
+func (r *postSnapshotRepository) BatchCreateSnapshots(ctx context.Context, snapshots []*entities.PostMetricSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(snapshots, constant.SnapshotBatchSize).Error; err != nil {
+		r.logger.Error("批量写入帖子指标快照失败", zap.Error(err), zap.Int("count", len(snapshots)))
+		return fmt.Errorf("批量写入帖子指标快照失败: %w", err)
+	}
+	return nil
+}
+
+// ListPostSnapshots 实现单个帖子快照的时间范围查询。
+func (r *postSnapshotRepository) ListPostSnapshots(ctx context.Context, postID uint64, granularity string, start, end time.Time, limit int) ([]*entities.PostMetricSnapshot, error) {
+	var snapshots []*entities.PostMetricSnapshot
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Read).
+		Where("post_id = ? AND granularity = ? AND snapshot_at >= ? AND snapshot_at < ?", postID, granularity, start, end).
+		Order("snapshot_at ASC").
+		Limit(limit).
+		Find(&snapshots).Error
+	if err != nil {
+		r.logger.Error("查询帖子指标快照失败", zap.Error(err), zap.Uint64("postID", postID), zap.String("granularity", granularity))
+		return nil, fmt.Errorf("查询帖子指标快照失败: %w", err)
+	}
+	return snapshots, nil
+}
+
+// PurgeSnapshotsBefore 实现过期快照的分批物理删除。
+func (r *postSnapshotRepository) PurgeSnapshotsBefore(ctx context.Context, granularity string, before time.Time) (int64, error) {
+	var total int64
+	for {
+		result := r.db.WithContext(ctx).Exec(
+			"DELETE FROM post_metric_snapshots WHERE granularity = ? AND snapshot_at < ? LIMIT ?",
+			granularity, before, constant.SnapshotPurgeBatchSize,
+		)
+		if result.Error != nil {
+			r.logger.Error("清理过期帖子指标快照失败", zap.Error(result.Error), zap.String("granularity", granularity), zap.Int64("deletedSoFar", total))
+			return total, fmt.Errorf("清理过期帖子指标快照失败: %w", result.Error)
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < constant.SnapshotPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
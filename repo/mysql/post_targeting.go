@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// PostTargetingRepository 定义了帖子投放定向条件在 MySQL 中的持久化操作接口。
+type PostTargetingRepository interface {
+	// CreateTargeting 为帖子创建投放定向记录。
+	// - db 参数允许在外部事务中执行（与帖子创建保持原子性）。
+	CreateTargeting(ctx context.Context, db *gorm.DB, targeting *entities.PostTargeting) error
+
+	// GetTargetingByPostID 获取指定帖子的定向条件。
+	// - 如果帖子未配置定向条件，返回 commonerrors.ErrRepoNotFound。
+	GetTargetingByPostID(ctx context.Context, postID uint64) (*entities.PostTargeting, error)
+
+	// GetTargetingsByPostIDs 批量获取帖子定向条件。
+	// - 返回 map[postID]*entities.PostTargeting，未配置定向的帖子不会出现在 map 中。
+	GetTargetingsByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]*entities.PostTargeting, error)
+}
+
+// postTargetingRepository 是 PostTargetingRepository 接口针对 MySQL 的具体实现。
+type postTargetingRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewPostTargetingRepository 是 postTargetingRepository 的构造函数。
+func NewPostTargetingRepository(db *gorm.DB, logger *core.ZapLogger) PostTargetingRepository {
+	return &postTargetingRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateTargeting 实现定向记录的插入。
+func (r *postTargetingRepository) CreateTargeting(ctx context.Context, db *gorm.DB, targeting *entities.PostTargeting) error {
+	if err := db.WithContext(ctx).Create(targeting).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTargetingByPostID 实现按帖子 ID 查询定向条件。
+func (r *postTargetingRepository) GetTargetingByPostID(ctx context.Context, postID uint64) (*entities.PostTargeting, error) {
+	var targeting entities.PostTargeting
+	err := r.db.WithContext(ctx).Where("post_id = ?", postID).First(&targeting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, commonerrors.ErrRepoNotFound
+		}
+		r.logger.Error("根据帖子 ID 获取定向条件失败", zap.Uint64("postID", postID), zap.Error(err))
+		return nil, err
+	}
+	return &targeting, nil
+}
+
+// GetTargetingsByPostIDs 实现批量查询定向条件。
+func (r *postTargetingRepository) GetTargetingsByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]*entities.PostTargeting, error) {
+	result := make(map[uint64]*entities.PostTargeting, len(postIDs))
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+
+	var targetings []*entities.PostTargeting
+	if err := r.db.WithContext(ctx).Where("post_id IN ?", postIDs).Find(&targetings).Error; err != nil {
+		r.logger.Error("批量获取帖子定向条件失败", zap.Int("idCount", len(postIDs)), zap.Error(err))
+		return nil, err
+	}
+	for _, t := range targetings {
+		result[t.PostID] = t
+	}
+	return result, nil
+}
+
+// applyTargetingFilter 在帖子列表查询上追加投放定向过滤条件。
+// - 没有定向记录的帖子对所有人可见；有定向记录的帖子必须同时满足地区、等级、标签三个维度才可见。
+// - 使用 NOT EXISTS 子查询而不是 JOIN，避免与 posts 表的同名列 (id, created_at 等) 产生歧义。
+// - viewer 为 nil 时按匿名用户（零值属性）处理。
+func applyTargetingFilter(query *gorm.DB, viewer *dto.ViewerAttributes) *gorm.DB {
+	if viewer == nil {
+		viewer = &dto.ViewerAttributes{}
+	}
+
+	// 标签维度：定向未限制标签，或命中用户任意一个标签
+	tagCond := "pt.tags = ''"
+	tagArgs := make([]interface{}, 0, len(viewer.Tags))
+	for _, tag := range viewer.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		tagCond += " OR FIND_IN_SET(?, pt.tags) > 0"
+		tagArgs = append(tagArgs, tag)
+	}
+
+	sql := "NOT EXISTS (SELECT 1 FROM post_targetings pt WHERE pt.post_id = posts.id AND pt.deleted_at IS NULL AND NOT (" +
+		"(pt.regions = '' OR FIND_IN_SET(?, pt.regions) > 0) AND pt.min_user_level <= ? AND (" + tagCond + ")))"
+	args := append([]interface{}{strings.TrimSpace(viewer.Region), viewer.Level}, tagArgs...)
+	return query.Where(sql, args...)
+}
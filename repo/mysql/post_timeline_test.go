@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// seedSameSecondPosts 批量插入 n 条创建时间完全相同的已审核帖子，作者按 authors 轮流分配。
+func seedSameSecondPosts(t *testing.T, db *gorm.DB, createdAt time.Time, n int, authors []string) map[uint64]bool {
+	t.Helper()
+	posts := make([]*entities.Post, n)
+	for i := range posts {
+		posts[i] = &entities.Post{Title: fmt.Sprintf("post %d", i), AuthorID: authors[i%len(authors)], Status: enums.Approved}
+		posts[i].CreatedAt = createdAt
+	}
+	if err := db.CreateInBatches(posts, 10).Error; err != nil {
+		t.Fatalf("批量插入测试帖子失败: %v", err)
+	}
+	ids := make(map[uint64]bool, n)
+	for _, p := range posts {
+		ids[p.ID] = true
+	}
+	return ids
+}
+
+// assertTimelinePages 校验逐页拉取的结果按 ID 严格递减、不重复且恰好覆盖 want。
+func assertTimelinePages(t *testing.T, want map[uint64]bool, fetch func(cursor *uint64) ([]*entities.Post, *uint64)) {
+	t.Helper()
+	seen := make(map[uint64]bool, len(want))
+	var (
+		cursor *uint64
+		lastID uint64
+	)
+	for page := 0; page <= len(want); page++ {
+		posts, next := fetch(cursor)
+		for _, p := range posts {
+			if seen[p.ID] {
+				t.Fatalf("post %d returned twice (page %d)", p.ID, page)
+			}
+			if lastID != 0 && p.ID >= lastID {
+				t.Fatalf("post %d follows %d, want strictly descending ids", p.ID, lastID)
+			}
+			seen[p.ID], lastID = true, p.ID
+		}
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	if len(seen) != len(want) {
+		t.Fatalf("paged %d posts, want %d", len(seen), len(want))
+	}
+	for id := range want {
+		if !seen[id] {
+			t.Fatalf("post %d was skipped while paging", id)
+		}
+	}
+}
+
+// 同一时间戳下批量插入的帖子，只按 ID 翻页时既不重复也不遗漏；客户端回传截断到秒的 lastCreatedAt 不影响结果。
+func TestGetPostsByTimelinePagesSameTimestampByID(t *testing.T) {
+	db := newTestDB(t, &entities.Post{}, &entities.PostTargeting{})
+	repo := NewPostRepository(db, newTestLogger(t))
+	createdAt := time.Now().Truncate(time.Second).Add(345 * time.Millisecond)
+	want := seedSameSecondPosts(t, db, createdAt, 23, []string{"author-1"})
+	// 未审核通过的帖子不出现在时间线中
+	pending := &entities.Post{Title: "pending", AuthorID: "author-1", Status: enums.Pending}
+	pending.CreatedAt = createdAt
+	if err := db.Create(pending).Error; err != nil {
+		t.Fatalf("插入待审核帖子失败: %v", err)
+	}
+
+	truncated := createdAt.Truncate(time.Second)
+	assertTimelinePages(t, want, func(cursor *uint64) ([]*entities.Post, *uint64) {
+		params := &dto.TimelineQueryDTO{PageSize: 5, LastPostID: cursor}
+		if cursor != nil {
+			params.LastCreatedAt = &truncated
+		}
+		posts, _, next, err := repo.GetPostsByTimeline(context.Background(), params)
+		if err != nil {
+			t.Fatalf("GetPostsByTimeline: %v", err)
+		}
+		return posts, next
+	})
+}
+
+// 作者数超过单批查询上限时各批结果在内存中合并，同一时间戳下依然按 ID 翻页不重复。
+func TestGetPostsByAuthorsTimelinePagesSameTimestampByID(t *testing.T) {
+	db := newTestDB(t, &entities.Post{})
+	repo := NewPostRepository(db, newTestLogger(t))
+	authors := make([]string, 250)
+	for i := range authors {
+		authors[i] = fmt.Sprintf("author-%03d", i)
+	}
+	want := seedSameSecondPosts(t, db, time.Now().Truncate(time.Second), 40, authors[180:220])
+
+	assertTimelinePages(t, want, func(cursor *uint64) ([]*entities.Post, *uint64) {
+		var c *dto.PostTimelineCursor
+		if cursor != nil {
+			c = &dto.PostTimelineCursor{PostID: *cursor}
+		}
+		posts, _, next, err := repo.GetPostsByAuthorsTimeline(context.Background(), authors, c, 6)
+		if err != nil {
+			t.Fatalf("GetPostsByAuthorsTimeline: %v", err)
+		}
+		return posts, next
+	})
+}
@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// TagSubscriptionRepository 定义了用户标签订阅的持久化操作接口。
+type TagSubscriptionRepository interface {
+	// Subscribe 为用户订阅标签，重复订阅是幂等的。
+	Subscribe(ctx context.Context, userID string, tag enums.OfficialTag) error
+
+	// Unsubscribe 取消用户对标签的订阅（物理删除），未订阅时同样返回 nil。
+	Unsubscribe(ctx context.Context, userID string, tag enums.OfficialTag) error
+
+	// ListTagsByUser 查询用户已订阅的标签，按标签值升序。
+	ListTagsByUser(ctx context.Context, userID string) ([]enums.OfficialTag, error)
+
+	// ListSubscriberIDsAfter 按用户 ID 游标分批查询订阅了指定标签的用户。
+	// - 返回 user_id 大于 afterUserID 的至多 limit 个用户 ID，按 user_id 升序，调用方以最后一个 ID 作为下一批的游标（首批传空字符串）。
+	// - 查询条件与排序都落在 (tag, user_id) 联合索引上，只扫描索引即可完成，走从库 (dbresolver.Read)。
+	ListSubscriberIDsAfter(ctx context.Context, tag enums.OfficialTag, afterUserID string, limit int) ([]string, error)
+}
+
+// tagSubscriptionRepository 是 TagSubscriptionRepository 接口针对 MySQL 的具体实现。
+type tagSubscriptionRepository struct {
+	db     *gorm.DB
+	logger *core.ZapLogger
+}
+
+// NewTagSubscriptionRepository 是 tagSubscriptionRepository 的构造函数。
+func NewTagSubscriptionRepository(db *gorm.DB, logger *core.ZapLogger) TagSubscriptionRepository {
+	return &tagSubscriptionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Subscribe 实现标签订阅，已存在的订阅依靠联合唯一索引忽略。
+func (r *tagSubscriptionRepository) Subscribe(ctx context.Context, userID string, tag enums.OfficialTag) error {
+	sub := &entities.TagSubscription{UserID: userID, Tag: tag}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(sub).Error; err != nil {
+		r.logger.Error("保存标签订阅失败", zap.Error(err), zap.String("userID", userID), zap.Int("tag", int(tag)))
+		return fmt.Errorf("保存标签订阅失败: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe 实现取消标签订阅。
+func (r *tagSubscriptionRepository) Unsubscribe(ctx context.Context, userID string, tag enums.OfficialTag) error {
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("tag = ? AND user_id = ?", tag, userID).
+		Delete(&entities.TagSubscription{}).Error
+	if err != nil {
+		r.logger.Error("删除标签订阅失败", zap.Error(err), zap.String("userID", userID), zap.Int("tag", int(tag)))
+		return fmt.Errorf("删除标签订阅失败: %w", err)
+	}
+	return nil
+}
+
+// ListTagsByUser 实现用户订阅列表的查询。
+func (r *tagSubscriptionRepository) ListTagsByUser(ctx context.Context, userID string) ([]enums.OfficialTag, error) {
+	var tags []enums.OfficialTag
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Read).Model(&entities.TagSubscription{}).
+		Where("user_id = ?", userID).
+		Order("tag ASC").
+		Pluck("tag", &tags).Error
+	if err != nil {
+		r.logger.Error("查询用户标签订阅失败", zap.Error(err), zap.String("userID", userID))
+		return nil, fmt.Errorf("查询用户标签订阅失败: %w", err)
+	}
+	return tags, nil
+}
+
+// ListSubscriberIDsAfter 实现订阅者的游标分批查询。
+func (r *tagSubscriptionRepository) ListSubscriberIDsAfter(ctx context.Context, tag enums.OfficialTag, afterUserID string, limit int) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Clauses(dbresolver.Read).Model(&entities.TagSubscription{}).
+		Where("tag = ? AND user_id > ?", tag, afterUserID).
+		Order("user_id ASC").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		r.logger.Error("分批查询标签订阅者失败", zap.Error(err), zap.Int("tag", int(tag)), zap.String("afterUserID", afterUserID))
+		return nil, fmt.Errorf("查询标签订阅者失败: %w", err)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// PendingCOSDelete 是延迟删除队列中的一个条目。
+type PendingCOSDelete struct {
+	PostID    uint64 // 图片所属帖子
+	ObjectKey string // 待删除的 COS 对象键
+	member    string // ZSet 成员原文，确认删除时使用
+}
+
+// COSDeleteQueue 定义了 COS 图片延迟删除队列的 Redis 操作接口。
+// - 队列为一个 ZSet (constant.COSPendingDeleteKey)，成员为 "{postID}:{objectKey}"，分数为计划删除时间 (Unix 秒)。
+// - 同一对象键重复入队只会更新计划删除时间，不会产生重复条目。
+type COSDeleteQueue interface {
+	// Enqueue 将帖子的图片对象键写入队列，计划在 deleteAt 之后删除。
+	Enqueue(ctx context.Context, postID uint64, objectKeys []string, deleteAt time.Time) error
+
+	// Cancel 从队列中移除帖子的图片对象键，帖子恢复时调用；不在队列中的对象键忽略。
+	Cancel(ctx context.Context, postID uint64, objectKeys []string) error
+
+	// ListDue 按计划删除时间升序读取至多 limit 个已到期（分数不大于 now）的条目。
+	// - 只读取不移除，删除成功后调用 Ack；无法解析的成员直接移除并跳过。
+	ListDue(ctx context.Context, now time.Time, limit int) ([]PendingCOSDelete, error)
+
+	// Ack 从队列中移除已处理完毕的条目。
+	Ack(ctx context.Context, items []PendingCOSDelete) error
+
+	// Retry 将删除失败的条目改期到 retryAt，避免失败条目一直堆在队首。
+	Retry(ctx context.Context, items []PendingCOSDelete, retryAt time.Time) error
+}
+
+// cosDeleteQueue 是 COSDeleteQueue 接口的 Redis 实现。
+type cosDeleteQueue struct {
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewCOSDeleteQueue 创建 COSDeleteQueue 实例。
+func NewCOSDeleteQueue(redisClient *redis.Client, logger *core.ZapLogger) COSDeleteQueue {
+	return &cosDeleteQueue{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// cosDeleteMember 生成队列成员，postID 在前，对象键中的冒号不影响解析。
+func cosDeleteMember(postID uint64, objectKey string) string {
+	return strconv.FormatUint(postID, 10) + ":" + objectKey
+}
+
+// Enqueue 实现对象键入队。
+func (q *cosDeleteQueue) Enqueue(ctx context.Context, postID uint64, objectKeys []string, deleteAt time.Time) error {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, 0, len(objectKeys))
+	for _, objectKey := range objectKeys {
+		members = append(members, redis.Z{Score: float64(deleteAt.Unix()), Member: cosDeleteMember(postID, objectKey)})
+	}
+	if err := q.redisClient.ZAdd(ctx, constant.COSPendingDeleteKey, members...).Err(); err != nil {
+		q.logger.Error("写入 COS 延迟删除队列失败", zap.Error(err), zap.Uint64("postID", postID), zap.Strings("objectKeys", objectKeys))
+		return fmt.Errorf("写入 COS 延迟删除队列失败: %w", err)
+	}
+	return nil
+}
+
+// Cancel 实现帖子对象键出队。
+func (q *cosDeleteQueue) Cancel(ctx context.Context, postID uint64, objectKeys []string) error {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	members := make([]interface{}, 0, len(objectKeys))
+	for _, objectKey := range objectKeys {
+		members = append(members, cosDeleteMember(postID, objectKey))
+	}
+	if err := q.redisClient.ZRem(ctx, constant.COSPendingDeleteKey, members...).Err(); err != nil {
+		q.logger.Error("从 COS 延迟删除队列移除对象键失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("从 COS 延迟删除队列移除对象键失败: %w", err)
+	}
+	return nil
+}
+
+// ListDue 实现到期条目的读取。
+func (q *cosDeleteQueue) ListDue(ctx context.Context, now time.Time, limit int) ([]PendingCOSDelete, error) {
+	members, err := q.redisClient.ZRangeByScore(ctx, constant.COSPendingDeleteKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		q.logger.Error("读取 COS 延迟删除队列失败", zap.Error(err))
+		return nil, fmt.Errorf("读取 COS 延迟删除队列失败: %w", err)
+	}
+
+	items := make([]PendingCOSDelete, 0, len(members))
+	var malformed []interface{}
+	for _, member := range members {
+		postIDStr, objectKey, found := strings.Cut(member, ":")
+		postID, parseErr := strconv.ParseUint(postIDStr, 10, 64)
+		if !found || parseErr != nil || objectKey == "" {
+			q.logger.Error("COS 延迟删除队列中的成员格式错误，已移除", zap.String("member", member))
+			malformed = append(malformed, member)
+			continue
+		}
+		items = append(items, PendingCOSDelete{PostID: postID, ObjectKey: objectKey, member: member})
+	}
+	if len(malformed) > 0 {
+		_ = q.redisClient.ZRem(ctx, constant.COSPendingDeleteKey, malformed...).Err()
+	}
+	return items, nil
+}
+
+// Ack 实现已处理条目的移除。
+func (q *cosDeleteQueue) Ack(ctx context.Context, items []PendingCOSDelete) error {
+	if len(items) == 0 {
+		return nil
+	}
+	members := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		members = append(members, item.member)
+	}
+	if err := q.redisClient.ZRem(ctx, constant.COSPendingDeleteKey, members...).Err(); err != nil {
+		q.logger.Error("确认 COS 延迟删除队列条目失败", zap.Error(err), zap.Int("items", len(items)))
+		return fmt.Errorf("确认 COS 延迟删除队列条目失败: %w", err)
+	}
+	return nil
+}
+
+// Retry 实现失败条目的改期，使用 XX 只更新仍在队列中的成员（期间被恢复取消的条目不会被重新加入）。
+func (q *cosDeleteQueue) Retry(ctx context.Context, items []PendingCOSDelete, retryAt time.Time) error {
+	if len(items) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, 0, len(items))
+	for _, item := range items {
+		members = append(members, redis.Z{Score: float64(retryAt.Unix()), Member: item.member})
+	}
+	if err := q.redisClient.ZAddXX(ctx, constant.COSPendingDeleteKey, members...).Err(); err != nil {
+		q.logger.Error("COS 延迟删除队列条目改期失败", zap.Error(err), zap.Int("items", len(items)))
+		return fmt.Errorf("COS 延迟删除队列条目改期失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// CoverExperimentRepository 定义了帖子 A/B 封面曝光/点击计数的 Redis 操作接口。
+// - 每个帖子一个 Hash (constant.PostCoverStatsPrefix)，按候选封面的 imageID 分别记录曝光数与点击数。
+type CoverExperimentRepository interface {
+	// RecordImpressions 为一批帖子各自展示的封面增加一次曝光。
+	// - impressions: map[postID]imageID，在一次管道往返内完成。
+	RecordImpressions(ctx context.Context, impressions map[uint64]uint64) error
+
+	// RecordClick 为帖子的指定封面增加一次点击。
+	RecordClick(ctx context.Context, postID, imageID uint64) error
+
+	// GetCoverStats 读取帖子各封面的曝光数与点击数。
+	// - 输出: impressions / clicks 均为 map[imageID]计数，没有记录的封面不出现在 map 中。
+	GetCoverStats(ctx context.Context, postID uint64) (impressions, clicks map[uint64]int64, err error)
+
+	// ResetCoverStats 删除帖子的封面统计，在候选封面变更后开始新一轮实验时调用。
+	ResetCoverStats(ctx context.Context, postID uint64) error
+}
+
+// coverExperimentRepository 是 CoverExperimentRepository 接口的 Redis 实现。
+type coverExperimentRepository struct {
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewCoverExperimentRepository 创建 CoverExperimentRepository 实例。
+func NewCoverExperimentRepository(redisClient *redis.Client, logger *core.ZapLogger) CoverExperimentRepository {
+	return &coverExperimentRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// coverStatsKey 返回帖子封面统计 Hash 的 Key。
+func coverStatsKey(postID uint64) string {
+	return constant.PostCoverStatsPrefix + strconv.FormatUint(postID, 10)
+}
+
+// RecordImpressions 实现批量曝光计数。
+func (r *coverExperimentRepository) RecordImpressions(ctx context.Context, impressions map[uint64]uint64) error {
+	if len(impressions) == 0 {
+		return nil
+	}
+	pipe := r.redisClient.Pipeline()
+	for postID, imageID := range impressions {
+		pipe.HIncrBy(ctx, coverStatsKey(postID), constant.CoverStatsImpressionField+strconv.FormatUint(imageID, 10), 1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Error("记录封面曝光失败", zap.Error(err), zap.Int("posts", len(impressions)))
+		return fmt.Errorf("记录封面曝光失败: %w", err)
+	}
+	return nil
+}
+
+// RecordClick 实现点击计数。
+func (r *coverExperimentRepository) RecordClick(ctx context.Context, postID, imageID uint64) error {
+	field := constant.CoverStatsClickField + strconv.FormatUint(imageID, 10)
+	if err := r.redisClient.HIncrBy(ctx, coverStatsKey(postID), field, 1).Err(); err != nil {
+		r.logger.Error("记录封面点击失败", zap.Error(err), zap.Uint64("postID", postID), zap.Uint64("imageID", imageID))
+		return fmt.Errorf("记录封面点击失败 (PostID: %d): %w", postID, err)
+	}
+	return nil
+}
+
+// GetCoverStats 实现封面统计读取，无法解析的字段被跳过。
+func (r *coverExperimentRepository) GetCoverStats(ctx context.Context, postID uint64) (map[uint64]int64, map[uint64]int64, error) {
+	fields, err := r.redisClient.HGetAll(ctx, coverStatsKey(postID)).Result()
+	if err != nil {
+		r.logger.Error("读取封面统计失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, nil, fmt.Errorf("读取封面统计失败 (PostID: %d): %w", postID, err)
+	}
+	impressions := make(map[uint64]int64)
+	clicks := make(map[uint64]int64)
+	for field, value := range fields {
+		target := impressions
+		imageIDStr, ok := strings.CutPrefix(field, constant.CoverStatsImpressionField)
+		if !ok {
+			if imageIDStr, ok = strings.CutPrefix(field, constant.CoverStatsClickField); !ok {
+				continue
+			}
+			target = clicks
+		}
+		imageID, idErr := strconv.ParseUint(imageIDStr, 10, 64)
+		count, countErr := strconv.ParseInt(value, 10, 64)
+		if idErr != nil || countErr != nil {
+			r.logger.Warn("封面统计字段格式错误，已跳过", zap.Uint64("postID", postID), zap.String("field", field), zap.String("value", value))
+			continue
+		}
+		target[imageID] = count
+	}
+	return impressions, clicks, nil
+}
+
+// ResetCoverStats 实现封面统计清理。
+func (r *coverExperimentRepository) ResetCoverStats(ctx context.Context, postID uint64) error {
+	if err := r.redisClient.Del(ctx, coverStatsKey(postID)).Err(); err != nil {
+		r.logger.Error("删除封面统计失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("删除封面统计失败 (PostID: %d): %w", postID, err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedis 启动一个 miniredis 实例并返回连接到它的客户端，测试结束时自动关闭。
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+// newTestLogger 返回只输出错误日志的 logger，避免测试输出被调试日志淹没。
+func newTestLogger(t *testing.T) *core.ZapLogger {
+	t.Helper()
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	return logger
+}
+
+// registerFakeBloom 为 miniredis 注册用 Set 模拟的 BF.INSERT / BF.EXISTS / BF.ADD 命令，没有误判。
+// - 过滤器以普通 Set 存放，TTL、EXPIRE 与 FastForward 的行为与真实的 Bloom Filter Key 一致。
+// - 命令通过再次分发 SADD / SISMEMBER 实现：Lua 脚本内调用时沿用脚本已持有的锁，不会死锁。
+func registerFakeBloom(t *testing.T, mr *miniredis.Miniredis) {
+	t.Helper()
+	srv := mr.Server()
+	// dispatch 以调用方的连接上下文执行一条整数回复的命令，返回回复是否为 1
+	dispatch := func(c *server.Peer, args ...string) bool {
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		peer := server.NewPeer(w)
+		peer.Ctx = c.Ctx
+		srv.Dispatch(peer, args)
+		_ = w.Flush()
+		reply, err := server.ParseReply(bufio.NewReader(&buf))
+		return err == nil && reply == 1
+	}
+	writeBool := func(c *server.Peer, ok bool) {
+		if ok {
+			c.WriteInt(1)
+		} else {
+			c.WriteInt(0)
+		}
+	}
+	commands := map[string]server.Cmd{
+		// BF.INSERT key [CAPACITY n] [ERROR e] [EXPANSION x] ITEMS item...
+		"BF.INSERT": func(c *server.Peer, _ string, args []string) {
+			items := 0
+			for i, arg := range args {
+				if strings.EqualFold(arg, "ITEMS") {
+					items = i + 1
+					break
+				}
+			}
+			if len(args) == 0 || items == 0 || items >= len(args) {
+				c.WriteError("ERR wrong number of arguments for 'BF.INSERT' command")
+				return
+			}
+			added := make([]bool, 0, len(args)-items)
+			for _, item := range args[items:] {
+				added = append(added, dispatch(c, "SADD", args[0], item))
+			}
+			c.WriteLen(len(added))
+			for _, ok := range added {
+				writeBool(c, ok)
+			}
+		},
+		"BF.ADD": func(c *server.Peer, _ string, args []string) {
+			if len(args) != 2 {
+				c.WriteError("ERR wrong number of arguments for 'BF.ADD' command")
+				return
+			}
+			writeBool(c, dispatch(c, "SADD", args[0], args[1]))
+		},
+		"BF.EXISTS": func(c *server.Peer, _ string, args []string) {
+			if len(args) != 2 {
+				c.WriteError("ERR wrong number of arguments for 'BF.EXISTS' command")
+				return
+			}
+			writeBool(c, dispatch(c, "SISMEMBER", args[0], args[1]))
+		},
+	}
+	for name, cmd := range commands {
+		if err := srv.Register(name, cmd); err != nil {
+			t.Fatalf("注册 %s 失败: %v", name, err)
+		}
+	}
+}
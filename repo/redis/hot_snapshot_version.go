@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/vo"
+)
+
+// hotPostDetailCacheEntry 是热门详情 Key (`PostDetailCacheKeyPrefix:{id}`) 中存储的结构。
+// - 在 vo.PostDetailVO 的 JSON 字段之外附加写入时所基于的热榜快照版本，字段平铺在同一层。
+// - 升级前写入的旧数据没有版本字段，按版本 0 处理。
+type hotPostDetailCacheEntry struct {
+	*vo.PostDetailVO
+	SnapshotVersion int64 `json:"snapshot_version,omitempty"`
+}
+
+// parseSnapshotVersion 解析 Redis 返回的快照版本号。
+// - Key 或字段不存在 (nil) 时返回 0，表示尚未生成过快照。
+// - 值无法解析为整数时 ok 为 false，调用方应将依赖版本的缓存视为不可信。
+func parseSnapshotVersion(value interface{}) (version int64, ok bool) {
+	if value == nil {
+		return 0, true
+	}
+	str, isString := value.(string)
+	if !isString {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// snapshotVersionFromCmd 读取 GET HotPostsSnapshotVersionKey 命令的结果，Key 不存在时返回 0。
+func snapshotVersionFromCmd(cmd *redis.StringCmd) (int64, error) {
+	version, err := cmd.Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("读取热榜快照版本 (key: %s) 失败: %w", constant.HotPostsSnapshotVersionKey, err)
+	}
+	return version, nil
+}
+
+// GetHotSnapshotVersion 实现读取当前热榜快照版本号。
+func (c *postReadCacheImpl) GetHotSnapshotVersion(ctx context.Context) (int64, error) {
+	return snapshotVersionFromCmd(c.redisClient.Get(ctx, constant.HotPostsSnapshotVersionKey))
+}
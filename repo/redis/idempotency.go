@@ -0,0 +1,152 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// 幂等键记录的状态
+const (
+	IdempotencyStateProcessing = "processing" // 首次请求仍在处理中
+	IdempotencyStateCompleted  = "completed"  // 首次请求已成功完成，Result 为其响应结果
+)
+
+// IdempotencyRecord 是幂等键在 Redis 中记录的内容。
+type IdempotencyRecord struct {
+	State  string          `json:"state"`            // IdempotencyStateProcessing / IdempotencyStateCompleted
+	Token  string          `json:"token,omitempty"`  // 处理中时占用该键的请求令牌，用于完成或释放时确认仍由自己持有
+	Result json.RawMessage `json:"result,omitempty"` // 完成后记录的首次请求结果
+}
+
+// IdempotencyStore 定义了基于 Redis 的幂等键操作接口。
+// - 首次请求通过 SET NX 占用幂等键并标记为处理中，成功后写入结果，失败后释放，允许客户端用相同的键重试。
+// - 完成与释放都只在键仍由同一令牌持有时生效，避免过期后被其他请求重新占用的键被误改。
+type IdempotencyStore interface {
+	// Acquire 尝试以 token 占用幂等键。
+	// - 占用成功时返回 (nil, nil)；键已存在时返回其当前记录。
+	Acquire(ctx context.Context, key, token string, ttl time.Duration) (*IdempotencyRecord, error)
+
+	// Complete 将仍由 token 持有的幂等键标记为已完成并记录结果，过期时间重新计算。
+	Complete(ctx context.Context, key, token string, result []byte, ttl time.Duration) error
+
+	// Release 删除仍由 token 持有的幂等键，首次请求失败后调用。
+	Release(ctx context.Context, key, token string) error
+}
+
+// completeIdempotencyScript 在键仍为 ARGV[1] 持有的处理中记录时写入完成记录。
+//   - KEYS: [1] 幂等键
+//   - ARGV: [1] 处理中记录, [2] 完成记录, [3] 过期时间(毫秒)
+//   - 返回: 1 已写入，0 键已不由该令牌持有
+var completeIdempotencyScript = redis.NewScript(`
+    if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+        return 0
+    end
+    redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+    return 1
+`)
+
+// releaseIdempotencyScript 在键仍为 ARGV[1] 持有的处理中记录时删除该键。
+//   - KEYS: [1] 幂等键
+//   - ARGV: [1] 处理中记录
+//   - 返回: 1 已删除，0 键已不由该令牌持有
+var releaseIdempotencyScript = redis.NewScript(`
+    if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+        return 0
+    end
+    return redis.call("DEL", KEYS[1])
+`)
+
+// idempotencyStore 是 IdempotencyStore 接口的 Redis 实现。
+type idempotencyStore struct {
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewIdempotencyStore 创建 IdempotencyStore 实例。
+func NewIdempotencyStore(redisClient *redis.Client, logger *core.ZapLogger) IdempotencyStore {
+	return &idempotencyStore{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// processingRecord 返回 token 对应的处理中记录的序列化结果，Acquire、Complete 与 Release 以此比较持有者。
+func processingRecord(token string) (string, error) {
+	data, err := json.Marshal(IdempotencyRecord{State: IdempotencyStateProcessing, Token: token})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Acquire 实现幂等键占用。
+// - 键在 SET NX 与 GET 之间过期时重新尝试占用一次，仍失败则按处理中返回。
+func (s *idempotencyStore) Acquire(ctx context.Context, key, token string, ttl time.Duration) (*IdempotencyRecord, error) {
+	processing, err := processingRecord(token)
+	if err != nil {
+		return nil, fmt.Errorf("序列化幂等键记录失败: %w", err)
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		acquired, err := s.redisClient.SetNX(ctx, key, processing, ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("占用幂等键 (key: %s) 失败: %w", key, err)
+		}
+		if acquired {
+			return nil, nil
+		}
+
+		raw, err := s.redisClient.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("读取幂等键 (key: %s) 失败: %w", key, err)
+		}
+		var record IdempotencyRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			s.logger.Warn("幂等键记录格式错误，按处理中处理", zap.String("key", key), zap.Error(err))
+			return &IdempotencyRecord{State: IdempotencyStateProcessing}, nil
+		}
+		return &record, nil
+	}
+	return &IdempotencyRecord{State: IdempotencyStateProcessing}, nil
+}
+
+// Complete 实现幂等键完成标记。
+func (s *idempotencyStore) Complete(ctx context.Context, key, token string, result []byte, ttl time.Duration) error {
+	processing, err := processingRecord(token)
+	if err != nil {
+		return fmt.Errorf("序列化幂等键记录失败: %w", err)
+	}
+	completed, err := json.Marshal(IdempotencyRecord{State: IdempotencyStateCompleted, Result: result})
+	if err != nil {
+		return fmt.Errorf("序列化幂等键结果失败: %w", err)
+	}
+	written, err := completeIdempotencyScript.Run(ctx, s.redisClient, []string{key}, processing, string(completed), ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("写入幂等键结果 (key: %s) 失败: %w", key, err)
+	}
+	if written == 0 {
+		s.logger.Warn("幂等键已过期或被其他请求占用，未记录本次结果", zap.String("key", key))
+	}
+	return nil
+}
+
+// Release 实现幂等键释放。
+func (s *idempotencyStore) Release(ctx context.Context, key, token string) error {
+	processing, err := processingRecord(token)
+	if err != nil {
+		return fmt.Errorf("序列化幂等键记录失败: %w", err)
+	}
+	if err := releaseIdempotencyScript.Run(ctx, s.redisClient, []string{key}, processing).Err(); err != nil {
+		return fmt.Errorf("释放幂等键 (key: %s) 失败: %w", key, err)
+	}
+	return nil
+}
@@ -6,21 +6,24 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/post_service/models/vo"
 	"github.com/Xushengqwer/post_service/myErrors"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"strconv"
+	"time"
 
 	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/entities"
 	"github.com/Xushengqwer/post_service/repo/mysql"
 )
 
-// Cache 定义了帖子相关的缓存操作接口。
+// PostReadCache 定义了请求链路使用的帖子缓存操作接口。
 // - 目标: 提供 Redis 缓存层，加速热点数据的访问，减轻数据库压力。
-// - 包括: 热榜帖子列表缓存、帖子详情缓存、排名查询等。
-type Cache interface {
+// - 包括: 热榜与排名查询、帖子 Hash 与详情读取，以及请求链路上的回源写回和主动失效。
+// - 热榜快照、帖子 Hash 与热门详情的生成由后台任务通过 PostTaskCache 完成，本接口只读取这些快照。
+type PostReadCache interface {
 	// GetPostRank 获取指定帖子在热榜 ZSet (`HotPostsRankKey`) 中的排名（0-based, 降序）。
 	// - 返回 -1 表示帖子不在榜单中。
 	GetPostRank(ctx context.Context, postID uint64) (int64, error)
@@ -30,47 +33,96 @@ type Cache interface {
 	// - start, stop 是基于 0 的排名索引。
 	GetPostsByRange(ctx context.Context, start, stop int64) ([]uint64, error)
 
+	// GetTagPostRank 获取指定帖子在官方标签热榜 ZSet (`HotPostsTagRankKeyPrefix:{tag}`) 中的排名（0-based, 降序）。
+	// - 返回 -1 表示帖子不在该标签的榜单中。
+	GetTagPostRank(ctx context.Context, tag enums.OfficialTag, postID uint64) (int64, error)
+
+	// GetTagPostsByRange 从官方标签热榜 ZSet (`HotPostsTagRankKeyPrefix:{tag}`) 获取指定排名范围内的帖子 ID 列表。
+	// - 标签热榜由热帖缓存任务根据总热榜快照按 OfficialTag 拆分生成。
+	GetTagPostsByRange(ctx context.Context, tag enums.OfficialTag, start, stop int64) ([]uint64, error)
+
 	// GetPosts 从 Redis Hash (`PostsHashKey`) 中批量获取帖子实体。
 	// - 根据帖子 ID 列表，高效获取缓存的帖子信息，用于信息流等场景。
 	// - 返回的帖子实体中 ViewCount 反映的是缓存刷新时的快照值。
+	// - Hash 记录的快照版本与当前热榜快照版本不一致时（热榜已更新、Hash 尚未刷新），整批回源 MySQL，此时 ViewCount 为数据库中已同步的值。
 	GetPosts(ctx context.Context, postIDs []uint64) ([]*entities.Post, error)
 
-	// GetPostDetail 从 Redis (`PostDetailCacheKeyPrefix:{id}` key) 获取单个帖子详情。
-	// - 用于访问热点帖子的详情页。
-	// - 如果缓存未命中，返回 myerrors.ErrCacheMiss，上层服务需要处理回源。
+	// GetHotSnapshotVersion 获取当前热榜快照版本号 (`HotPostsSnapshotVersionKey`)，尚未生成过快照时返回 0。
+	GetHotSnapshotVersion(ctx context.Context) (int64, error)
+
+	// GetPostDetail 从 Redis 获取单个帖子详情。
+	// - 优先读取热门详情 Key (`PostDetailCacheKeyPrefix:{id}`)，其次读取普通详情 Key (`PostDetailNormalCacheKeyPrefix:{id}`)，一次 MGET 完成。
+	// - 热门详情记录的快照版本与当前热榜快照版本不一致时跳过该 Key，视为未命中。
+	// - 如果两个 Key 都未命中，返回 myerrors.ErrCacheMiss，上层服务需要处理回源。
+	// - 反序列化失败的 Key 视为未命中并被删除（同一帖子每 constant.PostDetailCacheRepairInterval 最多删除一次），上层回源后重建。
 	GetPostDetail(ctx context.Context, postID uint64) (*vo.PostDetailVO, error)
+
+	// SetHotPostDetail 将帖子详情写入热门详情 Key (`PostDetailCacheKeyPrefix:{id}`)。
+	// - 用于热门详情缓存未命中、回源数据库后的写回。
+	// - ttl 为 0 表示不过期（与定时任务写入的行为一致），回源写回时应传入较短的 TTL。
+	// - 写入时记录当前热榜快照版本，热榜更新后该数据会在读取时被视为过期。
+	// - 热门详情对所有访问者共享：只写入审核通过的非草稿帖子，其余帖子直接跳过并返回 nil；审核原因只对作者可见，写入前清空。
+	SetHotPostDetail(ctx context.Context, postID uint64, detail *vo.PostDetailVO, ttl time.Duration) error
+
+	// SetPostDetail 将普通（非热门）帖子详情写入独立的 Key (`PostDetailNormalCacheKeyPrefix:{id}`)。
+	// - 用于普通帖子详情查库后的缓存，应传入较短的 TTL。
+	SetPostDetail(ctx context.Context, postID uint64, detail *vo.PostDetailVO, ttl time.Duration) error
+
+	// DeletePostDetail 删除帖子的详情缓存（热门与普通两个 Key）。
+	// - 用于帖子删除等需要立即失效缓存的场景。
+	DeletePostDetail(ctx context.Context, postID uint64) error
+
+	// GetPostStats 读取缓存的帖子数量统计 (`PostStatsCacheKey`)，未命中时返回 myErrors.ErrCacheMiss。
+	GetPostStats(ctx context.Context) (*vo.PostStatsVO, error)
+
+	// SetPostStats 写入帖子数量统计缓存，应传入较短的 TTL (constant.PostStatsCacheTTL)。
+	SetPostStats(ctx context.Context, stats *vo.PostStatsVO, ttl time.Duration) error
+
+	// GetTimelineHomePage 读取时间线首页缓存 (`TimelineHomeCacheKeyPrefix` + variant)，未命中时返回 myErrors.ErrCacheMiss。
+	GetTimelineHomePage(ctx context.Context, variant string) (*vo.PostTimelineCardPageVO, error)
+
+	// SetTimelineHomePage 写入时间线首页缓存，并把 Key 记入索引集合 (`TimelineHomeCacheIndexKey`)，两者在同一事务内写入。
+	SetTimelineHomePage(ctx context.Context, variant string, page *vo.PostTimelineCardPageVO, ttl time.Duration) error
+
+	// DeleteTimelineHomePages 删除索引集合中记录的全部时间线首页缓存，用于帖子公开状态变化后的主动失效。
+	DeleteTimelineHomePages(ctx context.Context) error
 }
 
-// cacheImpl 是 Cache 接口的 Redis 实现。
-type cacheImpl struct {
-	postViewRepo PostViewRepository                  // 依赖 PostView 仓库获取排名/ID
-	postBatch    mysql.PostBatchOperationsRepository // 依赖postBatch仓库
-	redisClient  *redis.Client                       // Redis 客户端实例
-	logger       *core.ZapLogger                     // 日志记录器实例
+// postReadCacheImpl 是 PostReadCache 接口的 Redis 实现。
+type postReadCacheImpl struct {
+	postBatch   mysql.PostBatchOperationsRepository // 帖子 Hash 版本过期时回源 MySQL
+	redisClient *redis.Client                       // Redis 客户端实例
+	logger      *core.ZapLogger                     // 日志记录器实例
 }
 
-// NewCache 是 cacheImpl 的构造函数。
+// NewPostReadCache 是 postReadCacheImpl 的构造函数。
 // - 通过依赖注入初始化所有必需的组件。
-func NewCache(
-	postViewRepo PostViewRepository,
+func NewPostReadCache(
 	postBatch mysql.PostBatchOperationsRepository,
 	redisClient *redis.Client,
-	logger *core.ZapLogger, // 添加 logger 参数
-) Cache {
-	return &cacheImpl{
-		postViewRepo: postViewRepo,
-		postBatch:    postBatch,
-		redisClient:  redisClient,
-		logger:       logger, // 初始化 logger
+	logger *core.ZapLogger,
+) PostReadCache {
+	return &postReadCacheImpl{
+		postBatch:   postBatch,
+		redisClient: redisClient,
+		logger:      logger,
 	}
 }
 
 // GetPostRank 实现获取帖子排名。
 // 排名是 0-based，分数越高，排名越靠前 (即 ZREVRANK 的结果)。
-func (c *cacheImpl) GetPostRank(ctx context.Context, postID uint64) (int64, error) {
-	// 1. 确定要操作的 Redis Key 和 成员 (Member)
-	// 使用 constant.HotPostsRankKey 作为热榜的 Sorted Set Key。
-	key := constant.HotPostsRankKey
+func (c *postReadCacheImpl) GetPostRank(ctx context.Context, postID uint64) (int64, error) {
+	return c.getRankInKey(ctx, constant.HotPostsRankKey, postID)
+}
+
+// GetTagPostRank 实现获取帖子在指定官方标签热榜中的排名。
+func (c *postReadCacheImpl) GetTagPostRank(ctx context.Context, tag enums.OfficialTag, postID uint64) (int64, error) {
+	return c.getRankInKey(ctx, HotPostsTagRankKey(tag), postID)
+}
+
+// getRankInKey 获取帖子在指定热榜 ZSet 中的排名 (ZREVRANK)，不在榜单中时返回 -1。
+func (c *postReadCacheImpl) getRankInKey(ctx context.Context, key string, postID uint64) (int64, error) {
+	// 1. 确定要操作的成员 (Member)
 	// Sorted Set 中的成员通常存储为字符串。
 	member := fmt.Sprintf("%d", postID)
 
@@ -116,10 +168,17 @@ func (c *cacheImpl) GetPostRank(ctx context.Context, postID uint64) (int64, erro
 
 // GetPostsByRange 实现按排名范围获取帖子 ID。
 // start 和 stop 是 0-based 的排名索引，按分数从高到低排列。
-func (c *cacheImpl) GetPostsByRange(ctx context.Context, start, stop int64) ([]uint64, error) {
-	// 1. 确定要操作的 Redis Key。
-	key := constant.HotPostsRankKey // 使用热榜 Key。
+func (c *postReadCacheImpl) GetPostsByRange(ctx context.Context, start, stop int64) ([]uint64, error) {
+	return c.getRangeInKey(ctx, constant.HotPostsRankKey, start, stop)
+}
+
+// GetTagPostsByRange 实现按排名范围获取指定官方标签热榜中的帖子 ID。
+func (c *postReadCacheImpl) GetTagPostsByRange(ctx context.Context, tag enums.OfficialTag, start, stop int64) ([]uint64, error) {
+	return c.getRangeInKey(ctx, HotPostsTagRankKey(tag), start, stop)
+}
 
+// getRangeInKey 从指定热榜 ZSet 中按排名范围 (ZREVRANGE) 获取帖子 ID 列表。
+func (c *postReadCacheImpl) getRangeInKey(ctx context.Context, key string, start, stop int64) ([]uint64, error) {
 	c.logger.Debug("开始从 Redis 按排名范围获取帖子 ID",
 		zap.String("key", key),
 		zap.Int64("start_rank", start),
@@ -226,7 +285,8 @@ func (c *cacheImpl) GetPostsByRange(ctx context.Context, start, stop int64) ([]u
 // GetPosts 从 Redis Hash (`PostsHashKey`) 中批量获取帖子实体。
 // - 根据帖子 ID 列表，高效获取缓存的帖子信息。
 // - 返回的帖子实体中 ViewCount 反映的是 CacheHotPostsToRedis 任务缓存刷新时的快照值。
-func (c *cacheImpl) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities.Post, error) {
+// - HMGET 与读取当前快照版本在同一个 MULTI/EXEC 中执行；Hash 的版本字段与当前快照版本不一致时整批回源 MySQL。
+func (c *postReadCacheImpl) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities.Post, error) {
 	// 1. 处理边界情况：如果请求的 ID 列表为空，则直接返回空列表。
 	if len(postIDs) == 0 {
 		c.logger.Debug("GetPosts: 请求的 postIDs 列表为空，返回空帖子列表。")
@@ -237,7 +297,7 @@ func (c *cacheImpl) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities
 	//    - hashKey: 存储帖子缓存的 Redis Hash 的键名。
 	//    - fields: 需要从 Hash 中获取的字段列表，即字符串形式的 postID。
 	hashKey := constant.PostsHashKey // 与 CacheHotPostsToRedis 中使用的键一致
-	fields := make([]string, len(postIDs))
+	fields := make([]string, len(postIDs), len(postIDs)+1)
 	for i, id := range postIDs {
 		fields[i] = fmt.Sprintf("%d", id)
 	}
@@ -248,10 +308,25 @@ func (c *cacheImpl) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities
 		// zap.Strings("fields_to_get", fields), // 记录 fields 可能会很长，酌情开启
 	)
 
-	// 3. 执行 HMGET 命令批量获取数据。
-	// HMGET 返回一个 []interface{}，其顺序与请求的 fields 顺序一致。
+	// 3. 执行 HMGET 命令批量获取数据，同时读取 Hash 的版本字段与当前快照版本。
+	// HMGET 返回一个 []interface{}，其顺序与请求的 fields 顺序一致，最后一个元素是版本字段。
 	// 如果某个 field 在 Hash 中不存在，则结果列表中对应位置的值为 nil。
-	values, err := c.redisClient.HMGet(ctx, hashKey, fields...).Result()
+	var hmgetCmd *redis.SliceCmd
+	var versionCmd *redis.StringCmd
+	_, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		hmgetCmd = pipe.HMGet(ctx, hashKey, append(fields, constant.HotPostsHashVersionField)...)
+		versionCmd = pipe.Get(ctx, constant.HotPostsSnapshotVersionKey)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) { // 版本 Key 不存在时 EXEC 返回 redis.Nil
+		c.logger.Error("从 Redis Hash 批量获取帖子失败 (MULTI/EXEC 执行错误)",
+			zap.Error(err),
+			zap.String("hashKey", hashKey),
+			zap.Int("idCount", len(postIDs)),
+		)
+		return nil, fmt.Errorf("批量获取帖子缓存 (key: %s) 失败: %w", hashKey, err)
+	}
+	values, err := hmgetCmd.Result()
 	if err != nil {
 		// 如果 HMGET 命令本身失败（例如 Redis 连接问题），则记录错误并返回。
 		c.logger.Error("从 Redis Hash 批量获取帖子失败 (HMGET 执行错误)",
@@ -261,6 +336,21 @@ func (c *cacheImpl) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities
 		)
 		return nil, fmt.Errorf("批量获取帖子缓存 (key: %s) 失败: %w", hashKey, err)
 	}
+	currentVersion, err := snapshotVersionFromCmd(versionCmd)
+	if err != nil {
+		c.logger.Error("读取热榜快照版本失败", zap.Error(err))
+		return nil, err
+	}
+	hashVersion, ok := parseSnapshotVersion(values[len(values)-1])
+	if !ok || hashVersion != currentVersion {
+		c.logger.Info("帖子 Hash 缓存的快照版本与当前热榜不一致，回源数据库",
+			zap.Int64("hashVersion", hashVersion),
+			zap.Int64("currentVersion", currentVersion),
+			zap.Int("idCount", len(postIDs)),
+		)
+		return c.getPostsFromDB(ctx, postIDs)
+	}
+	values = values[:len(values)-1]
 
 	// 4. 处理 HMGET 返回的结果，反序列化 JSON 数据。
 	posts := make([]*entities.Post, 0, len(postIDs)) // 预估容量，最多为请求的 ID 数量
@@ -326,55 +416,253 @@ func (c *cacheImpl) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities
 	return posts, nil
 }
 
-// GetPostDetail 从 Redis 获取单个帖子详情 (vo.PostDetailResponse)。
+// getPostsFromDB 在帖子 Hash 缓存版本过期时从 MySQL 批量读取帖子，按 postIDs 的顺序返回，数据库中不存在的帖子被跳过。
+func (c *postReadCacheImpl) getPostsFromDB(ctx context.Context, postIDs []uint64) ([]*entities.Post, error) {
+	dbPosts, err := c.postBatch.GetPostsByIDs(ctx, postIDs)
+	if err != nil {
+		c.logger.Error("帖子 Hash 缓存版本过期后回源数据库失败", zap.Error(err), zap.Int("idCount", len(postIDs)))
+		return nil, fmt.Errorf("回源数据库批量获取帖子失败: %w", err)
+	}
+	postMap := make(map[uint64]*entities.Post, len(dbPosts))
+	for _, post := range dbPosts {
+		postMap[post.ID] = post
+	}
+	posts := make([]*entities.Post, 0, len(postIDs))
+	for _, id := range postIDs {
+		if post, ok := postMap[id]; ok {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+// GetPostDetail 从 Redis 获取单个帖子详情 (vo.PostDetailVO)。
+// - 热门详情 Key 优先，普通详情 Key 兜底。
+// - 热门详情与当前快照版本在同一次 MGET 中读取，版本不一致的热门详情被跳过（不删除，由下一轮热帖缓存任务覆盖或清理）。
 // - 如果缓存未命中，返回 myerrors.ErrCacheMiss，上层服务应处理回源。
 // - 如果缓存数据损坏或发生其他 Redis 错误，则返回相应的错误。
-func (c *cacheImpl) GetPostDetail(ctx context.Context, postID uint64) (*vo.PostDetailVO, error) {
+func (c *postReadCacheImpl) GetPostDetail(ctx context.Context, postID uint64) (*vo.PostDetailVO, error) {
 	// 1. 构造缓存 Key。
-	//    Key 的格式应与 CacheHotPostDetailsToRedis 方法中写入时使用的最终 Key 格式一致。
-	//    例如："post_detail:<postID>"
-	key := fmt.Sprintf("%s%d", constant.PostDetailCacheKeyPrefix, postID) // 使用 Sprintf 更安全
-	c.logger.Debug("尝试从 Redis 获取帖子详情 VO", zap.String("key", key), zap.Uint64("postID", postID))
-
-	// 2. 执行 GET 命令从 Redis 获取序列化后的数据 (应为 JSON 字符串)。
-	jsonData, err := c.redisClient.Get(ctx, key).Result()
+	//    热门 Key 的格式应与 CacheHotPostDetailsToRedis 方法中写入时使用的最终 Key 格式一致。
+	hotKey := fmt.Sprintf("%s%d", constant.PostDetailCacheKeyPrefix, postID)
+	normalKey := fmt.Sprintf("%s%d", constant.PostDetailNormalCacheKeyPrefix, postID)
+	c.logger.Debug("尝试从 Redis 获取帖子详情 VO", zap.String("hotKey", hotKey), zap.String("normalKey", normalKey))
 
-	// 3. 处理 Redis GET 命令的执行结果。
+	// 2. 一次 MGET 同时读取两个 Key 与当前快照版本。
+	values, err := c.redisClient.MGet(ctx, hotKey, normalKey, constant.HotPostsSnapshotVersionKey).Result()
 	if err != nil {
-		// 3a. 如果错误是 redis.Nil，表示 Key 不存在，即缓存未命中。
-		if errors.Is(err, redis.Nil) {
-			c.logger.Info("帖子详情 VO 缓存未命中", zap.String("key", key), zap.Uint64("postID", postID))
-			// 返回应用层定义的缓存未命中错误，上层服务应处理回源逻辑。
-			return nil, myErrors.ErrCacheMiss
-		}
-		// 3b. 如果是其他类型的 Redis 错误 (例如连接问题、服务器错误等)。
-		c.logger.Error("从 Redis 获取帖子详情 VO 失败 (GET 命令执行错误)",
+		c.logger.Error("从 Redis 获取帖子详情 VO 失败 (MGET 命令执行错误)",
 			zap.Error(err),
-			zap.String("key", key),
 			zap.Uint64("postID", postID),
 		)
-		return nil, fmt.Errorf("获取帖子(ID: %d)详情缓存 (key: %s) 失败: %w", postID, key, err)
+		return nil, fmt.Errorf("获取帖子(ID: %d)详情缓存失败: %w", postID, err)
 	}
 
-	// 4. 反序列化 JSON 数据到 *vo.PostDetailVO 结构体。
-	//    此操作依赖于 CacheHotPostDetailsToRedis 方法缓存的是 vo.PostDetailVO 的 JSON 序列化形式。
-	var postDetailVO vo.PostDetailVO
-	if jsonErr := json.Unmarshal([]byte(jsonData), &postDetailVO); jsonErr != nil {
-		// 如果 JSON 反序列化失败，表明缓存数据可能已损坏。
-		c.logger.Error("反序列化帖子详情 VO 缓存数据失败",
-			zap.Error(jsonErr),
-			zap.String("key", key),
+	currentVersion, versionOK := parseSnapshotVersion(values[2])
+	if !versionOK {
+		c.logger.Warn("热榜快照版本无法解析，跳过热门详情缓存", zap.Any("value", values[2]), zap.Uint64("postID", postID))
+	}
+
+	// 3. 按优先级选出第一个命中的值。
+	keys := []string{hotKey, normalKey}
+	for i, v := range values[:len(keys)] {
+		jsonData, ok := v.(string)
+		if !ok || jsonData == "" {
+			continue
+		}
+		// 4. 反序列化 JSON 数据到 *vo.PostDetailVO 结构体，热门详情需同时校验快照版本。
+		entry := hotPostDetailCacheEntry{PostDetailVO: &vo.PostDetailVO{}}
+		if jsonErr := json.Unmarshal([]byte(jsonData), &entry); jsonErr != nil {
+			// 缓存数据已损坏：删除后按未命中处理，继续尝试下一个 Key，都不可用时由上层回源重建
+			c.repairCorruptPostDetail(ctx, postID, keys[i], jsonData, jsonErr)
+			continue
+		}
+		if keys[i] == hotKey && (!versionOK || entry.SnapshotVersion != currentVersion) {
+			c.logger.Debug("热门帖子详情缓存的快照版本与当前热榜不一致，跳过",
+				zap.Uint64("postID", postID),
+				zap.Int64("entryVersion", entry.SnapshotVersion),
+				zap.Int64("currentVersion", currentVersion),
+			)
+			continue
+		}
+		c.logger.Debug("成功从 Redis 获取并解析帖子详情 VO", zap.String("key", keys[i]), zap.Uint64("postID", postID))
+		return entry.PostDetailVO, nil
+	}
+
+	c.logger.Info("帖子详情 VO 缓存未命中", zap.Uint64("postID", postID))
+	// 返回应用层定义的缓存未命中错误，上层服务应处理回源逻辑。
+	return nil, myErrors.ErrCacheMiss
+}
+
+// repairCorruptPostDetail 记录详情缓存损坏事件，并在频率限制内删除损坏的 Key。
+// - 同一帖子每 constant.PostDetailCacheRepairInterval 最多删除一次，避免写入端持续写入坏数据时反复删除、回源。
+// - 删除失败只记录日志，本次请求依然按未命中回源。
+func (c *postReadCacheImpl) repairCorruptPostDetail(ctx context.Context, postID uint64, key string, data string, decodeErr error) {
+	sample := data
+	if len(sample) > constant.PostDetailCorruptSampleBytes {
+		sample = sample[:constant.PostDetailCorruptSampleBytes]
+	}
+	c.logger.Error("帖子详情缓存数据损坏，按未命中处理",
+		zap.Error(decodeErr),
+		zap.String("key", key),
+		zap.Uint64("postID", postID),
+		zap.Int("size", len(data)),
+		zap.String("sample", sample),
+	)
+
+	lockKey := constant.PostDetailCacheRepairLockPrefix + strconv.FormatUint(postID, 10)
+	acquired, err := c.redisClient.SetNX(ctx, lockKey, key, constant.PostDetailCacheRepairInterval).Result()
+	if err != nil {
+		c.logger.Warn("获取损坏详情缓存的删除许可失败，跳过删除", zap.Error(err), zap.String("key", key))
+		return
+	}
+	if !acquired {
+		c.logger.Debug("损坏的详情缓存近期已删除过，跳过本次删除", zap.String("key", key))
+		return
+	}
+	if err := c.redisClient.Del(ctx, key).Err(); err != nil {
+		c.logger.Warn("删除损坏的帖子详情缓存失败", zap.Error(err), zap.String("key", key))
+		return
+	}
+	c.logger.Warn("已删除损坏的帖子详情缓存，等待回源重建", zap.String("key", key), zap.Uint64("postID", postID))
+}
+
+// SetHotPostDetail 实现热门帖子详情的写入，数据中记录当前热榜快照版本。
+// - 读取版本与写入之间热榜恰好更新时，写入的数据在读取时被视为过期并回源，直到 TTL 到期或被下一轮任务覆盖。
+func (c *postReadCacheImpl) SetHotPostDetail(ctx context.Context, postID uint64, detail *vo.PostDetailVO, ttl time.Duration) error {
+	if detail.Status != enums.Approved || detail.IsDraft {
+		c.logger.Warn("帖子未审核通过，跳过写入热门详情缓存",
 			zap.Uint64("postID", postID),
-			// zap.String("jsonData_preview", jsonData[:min(len(jsonData), 200)]), // 记录部分JSON预览
-		)
-		// 考虑：主动删除这个损坏的 Key 以避免后续请求也读到坏数据。
-		// if delErr := c.redisClient.Del(context.Background(), key).Err(); delErr != nil {
-		//    c.logger.Error("删除损坏的帖子详情缓存失败", zap.Error(delErr), zap.String("key", key))
-		// }
-		return nil, fmt.Errorf("解析帖子(ID: %d)详情缓存 (key: %s) 数据失败: %w", postID, key, jsonErr)
+			zap.Int("status", int(detail.Status)),
+			zap.Bool("isDraft", detail.IsDraft))
+		return nil
+	}
+	publicDetail := *detail
+	publicDetail.AuditReason = nil
+	detail = &publicDetail
+
+	version, err := c.GetHotSnapshotVersion(ctx)
+	if err != nil {
+		c.logger.Error("写入热门帖子详情前读取快照版本失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("写入帖子(ID: %d)热门详情缓存失败: %w", postID, err)
+	}
+	return c.setPostDetail(ctx, constant.PostDetailCacheKeyPrefix, postID, hotPostDetailCacheEntry{PostDetailVO: detail, SnapshotVersion: version}, ttl)
+}
+
+// SetPostDetail 实现普通帖子详情的写入。
+func (c *postReadCacheImpl) SetPostDetail(ctx context.Context, postID uint64, detail *vo.PostDetailVO, ttl time.Duration) error {
+	return c.setPostDetail(ctx, constant.PostDetailNormalCacheKeyPrefix, postID, detail, ttl)
+}
+
+// DeletePostDetail 实现帖子详情缓存的删除。
+func (c *postReadCacheImpl) DeletePostDetail(ctx context.Context, postID uint64) error {
+	hotKey := fmt.Sprintf("%s%d", constant.PostDetailCacheKeyPrefix, postID)
+	normalKey := fmt.Sprintf("%s%d", constant.PostDetailNormalCacheKeyPrefix, postID)
+	if err := c.redisClient.Del(ctx, hotKey, normalKey).Err(); err != nil {
+		c.logger.Error("删除帖子详情缓存失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("删除帖子(ID: %d)详情缓存失败: %w", postID, err)
+	}
+	return nil
+}
+
+// HotPostsTagRankKey 返回指定官方标签热榜 ZSet 的 Key。
+func HotPostsTagRankKey(tag enums.OfficialTag) string {
+	return constant.HotPostsTagRankKeyPrefix + strconv.Itoa(int(tag))
+}
+
+// setPostDetail 将帖子详情（*vo.PostDetailVO 或 hotPostDetailCacheEntry）序列化为 JSON 后写入指定前缀的 Key。
+func (c *postReadCacheImpl) setPostDetail(ctx context.Context, prefix string, postID uint64, detail interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("%s%d", prefix, postID)
+	jsonData, err := json.Marshal(detail)
+	if err != nil {
+		c.logger.Error("序列化帖子详情 VO 失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("序列化帖子(ID: %d)详情失败: %w", postID, err)
+	}
+	if err := c.redisClient.Set(ctx, key, jsonData, ttl).Err(); err != nil {
+		c.logger.Error("写入帖子详情缓存失败", zap.Error(err), zap.String("key", key))
+		return fmt.Errorf("写入帖子(ID: %d)详情缓存 (key: %s) 失败: %w", postID, key, err)
+	}
+	c.logger.Debug("成功写入帖子详情缓存", zap.String("key", key), zap.Duration("ttl", ttl))
+	return nil
+}
+
+// GetPostStats 实现帖子数量统计缓存的读取。
+func (c *postReadCacheImpl) GetPostStats(ctx context.Context) (*vo.PostStatsVO, error) {
+	data, err := c.redisClient.Get(ctx, constant.PostStatsCacheKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, myErrors.ErrCacheMiss
+		}
+		return nil, fmt.Errorf("读取帖子数量统计缓存失败: %w", err)
+	}
+	var stats vo.PostStatsVO
+	if err := json.Unmarshal(data, &stats); err != nil {
+		// 结构不兼容的旧数据按未命中处理，重新统计后会被覆盖
+		c.logger.Warn("反序列化帖子数量统计缓存失败，按未命中处理", zap.Error(err))
+		return nil, myErrors.ErrCacheMiss
+	}
+	return &stats, nil
+}
+
+// SetPostStats 实现帖子数量统计缓存的写入。
+func (c *postReadCacheImpl) SetPostStats(ctx context.Context, stats *vo.PostStatsVO, ttl time.Duration) error {
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("序列化帖子数量统计失败: %w", err)
+	}
+	if err := c.redisClient.Set(ctx, constant.PostStatsCacheKey, jsonData, ttl).Err(); err != nil {
+		return fmt.Errorf("写入帖子数量统计缓存失败: %w", err)
+	}
+	return nil
+}
+
+// GetTimelineHomePage 实现时间线首页缓存的读取。
+func (c *postReadCacheImpl) GetTimelineHomePage(ctx context.Context, variant string) (*vo.PostTimelineCardPageVO, error) {
+	key := constant.TimelineHomeCacheKeyPrefix + variant
+	data, err := c.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, myErrors.ErrCacheMiss
+		}
+		return nil, fmt.Errorf("读取时间线首页缓存 (key: %s) 失败: %w", key, err)
+	}
+	var page vo.PostTimelineCardPageVO
+	if err := json.Unmarshal(data, &page); err != nil {
+		// 结构不兼容的旧数据按未命中处理，回源后会被覆盖
+		c.logger.Warn("反序列化时间线首页缓存失败，按未命中处理", zap.Error(err), zap.String("key", key))
+		return nil, myErrors.ErrCacheMiss
+	}
+	return &page, nil
+}
+
+// SetTimelineHomePage 实现时间线首页缓存的写入。
+func (c *postReadCacheImpl) SetTimelineHomePage(ctx context.Context, variant string, page *vo.PostTimelineCardPageVO, ttl time.Duration) error {
+	key := constant.TimelineHomeCacheKeyPrefix + variant
+	jsonData, err := json.Marshal(page)
+	if err != nil {
+		return fmt.Errorf("序列化时间线首页失败: %w", err)
 	}
+	pipe := c.redisClient.TxPipeline()
+	pipe.Set(ctx, key, jsonData, ttl)
+	pipe.SAdd(ctx, constant.TimelineHomeCacheIndexKey, key)
+	pipe.Expire(ctx, constant.TimelineHomeCacheIndexKey, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("写入时间线首页缓存 (key: %s) 失败: %w", key, err)
+	}
+	return nil
+}
 
-	// 5. 成功获取并反序列化，返回帖子详情 VO。
-	c.logger.Debug("成功从 Redis 获取并解析帖子详情 VO", zap.String("key", key), zap.Uint64("postID", postID))
-	return &postDetailVO, nil
+// DeleteTimelineHomePages 实现时间线首页缓存的批量删除。
+// - 索引集合中已过期的 Key 一并删除，DEL 对不存在的 Key 无副作用。
+func (c *postReadCacheImpl) DeleteTimelineHomePages(ctx context.Context) error {
+	keys, err := c.redisClient.SMembers(ctx, constant.TimelineHomeCacheIndexKey).Result()
+	if err != nil {
+		return fmt.Errorf("读取时间线首页缓存索引失败: %w", err)
+	}
+	keys = append(keys, constant.TimelineHomeCacheIndexKey)
+	if err := c.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("删除时间线首页缓存失败 (%d keys): %w", len(keys), err)
+	}
+	c.logger.Debug("已清除时间线首页缓存", zap.Int("keys", len(keys)-1))
+	return nil
 }
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+func TestSetHotPostDetailOnlyCachesApprovedPublicPosts(t *testing.T) {
+	reason := "涉嫌广告"
+	tests := []struct {
+		name       string
+		detail     vo.PostDetailVO
+		wantCached bool
+	}{
+		{name: "审核通过", detail: vo.PostDetailVO{ID: 1, Status: enums.Approved, AuditReason: &reason}, wantCached: true},
+		{name: "待审核", detail: vo.PostDetailVO{ID: 2, Status: enums.Pending}},
+		{name: "已拒绝", detail: vo.PostDetailVO{ID: 3, Status: enums.Rejected, AuditReason: &reason}},
+		{name: "草稿", detail: vo.PostDetailVO{ID: 4, Status: enums.Approved, IsDraft: true}},
+	}
+
+	ctx := context.Background()
+	mr, client := newTestRedis(t)
+	cache := NewPostReadCache(nil, client, newTestLogger(t))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := tt.detail
+			if err := cache.SetHotPostDetail(ctx, detail.ID, &detail, time.Minute); err != nil {
+				t.Fatalf("SetHotPostDetail 返回错误: %v", err)
+			}
+			if detail.AuditReason != tt.detail.AuditReason {
+				t.Fatalf("SetHotPostDetail 不应修改调用方传入的详情")
+			}
+
+			key := constant.PostDetailCacheKeyPrefix + strconv.FormatUint(detail.ID, 10)
+			if got := mr.Exists(key); got != tt.wantCached {
+				t.Fatalf("热门详情 Key 是否存在 = %v, 期望 %v", got, tt.wantCached)
+			}
+
+			cached, err := cache.GetPostDetail(ctx, detail.ID)
+			if !tt.wantCached {
+				if !errors.Is(err, myErrors.ErrCacheMiss) {
+					t.Fatalf("GetPostDetail 错误 = %v, 期望 ErrCacheMiss", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPostDetail 返回错误: %v", err)
+			}
+			if cached.AuditReason != nil {
+				t.Fatalf("共享缓存中不应包含审核原因, 实际为 %q", *cached.AuditReason)
+			}
+		})
+	}
+}
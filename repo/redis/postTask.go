@@ -3,6 +3,7 @@ package redis
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/entities"
 	"github.com/Xushengqwer/post_service/models/vo" // 确保 vo 包已导入
@@ -21,15 +23,18 @@ import (
 )
 
 // PostTaskCache 定义了后台任务管理和维护帖子相关缓存的操作接口。
+// - 负责生成热榜快照并写入帖子 Hash 与热门详情，请求链路的读取与失效由 PostReadCache 负责。
 type PostTaskCache interface {
 	// CreateHotList 原子性地从总排行榜 (`PostsRankKey`) 截取前 N 条记录，生成/覆盖热榜 (`HotPostsRankKey`)。
-	// 此方法负责生成后续缓存方法所依赖的热榜快照。
+	// 此方法负责生成后续缓存方法所依赖的热榜快照，并在同一个脚本内递增快照版本号 (`HotPostsSnapshotVersionKey`)。
 	CreateHotList(ctx context.Context, n int) error
 
 	// CacheHotPostsToRedis 将MySQL中的帖子基础信息加载到redis中
+	// - Hash 中记录所基于的快照版本，读取方据此判断 Hash 是否与当前热榜一致。
 	CacheHotPostsToRedis(ctx context.Context) error
 
 	// CacheHotPostDetailsToRedis  将MySQL中的帖子详情信息加载到redis中
+	// - 每条详情记录所基于的快照版本，读取方据此判断详情是否与当前热榜一致。
 	CacheHotPostDetailsToRedis(ctx context.Context) error
 }
 
@@ -40,8 +45,8 @@ type postTaskCacheImpl struct {
 	postBatch   mysql.PostBatchOperationsRepository
 }
 
-// NewPostTaskCacheImpl 创建 PostTaskCache 的新实例。
-func NewPostTaskCacheImpl(
+// NewPostTaskCache 创建 PostTaskCache 的新实例。
+func NewPostTaskCache(
 	redisClient *redis.Client,
 	logger *core.ZapLogger,
 	postBatch mysql.PostBatchOperationsRepository,
@@ -54,6 +59,8 @@ func NewPostTaskCacheImpl(
 }
 
 // CreateHotList 原子性地从总排行榜截取前 N 条记录，生成或覆盖热榜。
+// - 快照版本号与热榜在同一个脚本内更新，读取方不会看到新热榜搭配旧版本号的中间状态。
+// - 版本号更新后、Hash 与详情缓存刷新完成前，读取方会因版本不一致回源 MySQL。
 func (c *postTaskCacheImpl) CreateHotList(ctx context.Context, n int) error {
 	if n <= 0 {
 		c.logger.Info("CreateHotList: 请求创建的热榜大小 n 小于或等于 0，操作取消。", zap.Int("n", n))
@@ -69,31 +76,37 @@ func (c *postTaskCacheImpl) CreateHotList(ctx context.Context, n int) error {
 		zap.Int("size_n", n),
 	)
 
-	// 修正后的 Lua 脚本：
-	// ZREVRANGE WITHSCORES 返回 {member1, score1, member2, score2, ...}
-	// ZADD 需要 {score1, member1, score2, member2, ...}
-	// 因此，我们需要在 Lua 中重新构造参数列表或迭代添加。
+	// ZREVRANGE WITHSCORES 返回 {member1, score1, member2, score2, ...}，ZADD 需要 {score1, member1, ...}。
+	// 按 ARGV[2] 个成员一批调用 ZADD，不再 unpack 全部成员，避免大 N 时超出 Lua 栈/参数数量上限。
+	// DEL 与全部 ZADD 在同一个脚本内执行，仍然保证“先清空再填充”的原子语义。
 	luaScript := redis.NewScript(`
 		-- KEYS[1]: source ZSet (total rank: constant.PostsRankKey)
 		-- KEYS[2]: destination ZSet (hot list: constant.HotPostsRankKey)
 		-- ARGV[1]: number of items to copy (n)
+		-- KEYS[3]: snapshot version counter (constant.HotPostsSnapshotVersionKey)
+		-- ARGV[2]: members per ZADD batch
 
 		local items_with_scores = redis.call("ZREVRANGE", KEYS[1], 0, tonumber(ARGV[1]) - 1, "WITHSCORES")
 		redis.call("DEL", KEYS[2])
 
-		if #items_with_scores > 0 then
-			local args_for_zadd = { KEYS[2] } -- Start with the key for ZADD
-			for i = 1, #items_with_scores, 2 do
-				-- items_with_scores[i] is member, items_with_scores[i+1] is score
-				table.insert(args_for_zadd, items_with_scores[i+1]) -- Add score
-				table.insert(args_for_zadd, items_with_scores[i])   -- Add member
+		local batch_size = tonumber(ARGV[2])
+		local batch = {}
+		for i = 1, #items_with_scores, 2 do
+			-- items_with_scores[i] is member, items_with_scores[i+1] is score
+			batch[#batch + 1] = items_with_scores[i + 1]
+			batch[#batch + 1] = items_with_scores[i]
+			if #batch >= batch_size * 2 then
+				redis.call("ZADD", KEYS[2], unpack(batch))
+				batch = {}
 			end
-			redis.call("ZADD", unpack(args_for_zadd))
 		end
-		return #items_with_scores / 2 -- Returns the number of members processed
+		if #batch > 0 then
+			redis.call("ZADD", KEYS[2], unpack(batch))
+		end
+		return redis.call("INCR", KEYS[3]) -- Returns the new snapshot version
 	`)
 
-	_, err := luaScript.Run(ctx, c.redisClient, []string{fullRankKey, hotListKey}, n).Result()
+	version, err := luaScript.Run(ctx, c.redisClient, []string{fullRankKey, hotListKey, constant.HotPostsSnapshotVersionKey}, n, constant.HotListZAddBatchSize).Int64()
 	if err != nil {
 		c.logger.Error("执行 Lua 脚本创建热榜快照失败",
 			zap.Error(err),
@@ -107,6 +120,7 @@ func (c *postTaskCacheImpl) CreateHotList(ctx context.Context, n int) error {
 	c.logger.Info("成功创建/更新热榜快照",
 		zap.String("key", hotListKey),
 		zap.Int("requested_size_n", n),
+		zap.Int64("snapshotVersion", version),
 	)
 	return nil
 }
@@ -120,7 +134,7 @@ func (c *postTaskCacheImpl) CacheHotPostsToRedis(ctx context.Context) error {
 	finalHashKey := constant.PostsHashKey
 	tempHashKey := finalHashKey + "_temp_" + strconv.FormatInt(time.Now().UnixNano(), 10)
 
-	postScores, err := c.redisClient.ZRevRangeWithScores(ctx, hotListKey, 0, int64(constant.HotPostsCacheSize-1)).Result()
+	postScores, snapshotVersion, err := c.getHotListSnapshot(ctx)
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			c.logger.Info("热榜 ZSet (快照) 为空，将清空帖子 Hash 缓存", zap.String("hashKeyToClear", finalHashKey))
@@ -183,7 +197,16 @@ func (c *postTaskCacheImpl) CacheHotPostsToRedis(ctx context.Context) error {
 			c.logger.Warn("热榜中的 PostID 在数据库中未找到，无法缓存该帖子", zap.Uint64("postID", hotID))
 			continue
 		}
+		// Hash 中的帖子会出现在公开热榜上：快照生成后被驳回或转回草稿的帖子不写入，审核原因只对作者可见，一并清空。
+		if post.Status != enums.Approved || post.IsDraft {
+			c.logger.Warn("热榜中的帖子未审核通过，跳过列表缓存",
+				zap.Uint64("postID", hotID),
+				zap.Int("status", int(post.Status)),
+				zap.Bool("isDraft", post.IsDraft))
+			continue
+		}
 		postToCache := *post
+		postToCache.AuditReason = sql.NullString{}
 		if score, scoreExists := currentScoreMap[idStr]; scoreExists {
 			postToCache.ViewCount = int64(score) // 使用 ZSet 快照中的分数作为浏览量
 		} else {
@@ -209,6 +232,8 @@ func (c *postTaskCacheImpl) CacheHotPostsToRedis(ctx context.Context) error {
 		return errors.New("未能准备有效的帖子数据进行缓存，操作中止")
 	}
 
+	dataToCache[constant.HotPostsHashVersionField] = strconv.FormatInt(snapshotVersion, 10)
+
 	pipe := c.redisClient.Pipeline()
 	pipe.Del(ctx, tempHashKey)
 	if hmSetCmdErr := pipe.HMSet(ctx, tempHashKey, dataToCache).Err(); hmSetCmdErr != nil {
@@ -236,15 +261,84 @@ func (c *postTaskCacheImpl) CacheHotPostsToRedis(ctx context.Context) error {
 
 	c.logger.Info("成功将热门帖子同步到 Redis Hash (采用临时Key+RENAME策略)",
 		zap.String("finalHashKey", finalHashKey),
-		zap.Int("cachedCount", len(dataToCache)),
+		zap.Int("cachedCount", len(dataToCache)-1),
+		zap.Int64("snapshotVersion", snapshotVersion),
 		zap.Int("marshalErrors", marshalErrors),
 	)
 
+	// 按官方标签拆分热榜快照，供按标签查询热门帖子使用。失败只记录日志，不影响总热榜。
+	if tagErr := c.rebuildTagHotLists(ctx, currentHotPostIDs, dbPostsMap, currentScoreMap); tagErr != nil {
+		c.logger.Error("按官方标签拆分热榜失败，标签热榜可能是旧数据", zap.Error(tagErr))
+	}
+
 	duration := time.Since(startTime)
 	c.logger.Info("完成同步热门帖子到 Redis Hash 任务", zap.Duration("duration", duration))
 	return nil
 }
 
+// getHotListSnapshot 在同一个 MULTI/EXEC 中读取热榜快照 (带分数) 与其版本号，保证两者对应同一次 CreateHotList。
+func (c *postTaskCacheImpl) getHotListSnapshot(ctx context.Context) ([]redis.Z, int64, error) {
+	var rangeCmd *redis.ZSliceCmd
+	var versionCmd *redis.StringCmd
+	_, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		rangeCmd = pipe.ZRevRangeWithScores(ctx, constant.HotPostsRankKey, 0, int64(constant.HotPostsCacheSize-1))
+		versionCmd = pipe.Get(ctx, constant.HotPostsSnapshotVersionKey)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) { // 版本 Key 不存在时 EXEC 返回 redis.Nil
+		return nil, 0, err
+	}
+	postScores, err := rangeCmd.Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	version, err := snapshotVersionFromCmd(versionCmd)
+	if err != nil {
+		return nil, 0, err
+	}
+	return postScores, version, nil
+}
+
+// hotListOfficialTags 是需要维护独立热榜的官方标签列表（含“无标签”）。
+var hotListOfficialTags = append([]enums.OfficialTag{enums.OfficialTagNone}, entities.DefinedOfficialTags...)
+
+// rebuildTagHotLists 根据热榜快照中帖子的官方标签，重建每个官方标签的热榜 ZSet。
+// - 拥有多个标签的帖子会同时进入每个标签的热榜；没有标签的帖子进入“无标签”热榜。
+// - 所有标签的 DEL + ZADD 放在同一个 MULTI/EXEC 事务中，读取方不会看到清空后尚未写入的中间状态。
+// - 快照中没有某个标签的帖子时，该标签的热榜会被删除。
+func (c *postTaskCacheImpl) rebuildTagHotLists(ctx context.Context, hotPostIDs []uint64, posts map[uint64]*entities.Post, scores map[string]float64) error {
+	tagMembers := make(map[enums.OfficialTag][]redis.Z, len(hotListOfficialTags))
+	for _, id := range hotPostIDs {
+		post, ok := posts[id]
+		if !ok {
+			continue
+		}
+		idStr := strconv.FormatUint(id, 10)
+		for _, tag := range hotListOfficialTags {
+			if post.OfficialTags.Has(tag) {
+				tagMembers[tag] = append(tagMembers[tag], redis.Z{Score: scores[idStr], Member: idStr})
+			}
+		}
+	}
+
+	_, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range hotListOfficialTags {
+			key := HotPostsTagRankKey(tag)
+			pipe.Del(ctx, key)
+			if members := tagMembers[tag]; len(members) > 0 {
+				pipe.ZAdd(ctx, key, members...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("重建官方标签热榜失败: %w", err)
+	}
+
+	c.logger.Debug("成功重建官方标签热榜", zap.Int("tagCount", len(tagMembers)))
+	return nil
+}
+
 // CacheHotPostDetailsToRedis 实现缓存热门帖子详情的逻辑。
 // 此方法依赖于外部调用者已通过 CreateHotList (现在是 PostTaskCache 的一部分) 更新了 constant.HotPostsRankKey (热榜快照)。
 func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) error {
@@ -253,7 +347,7 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 
 	// 1. 从热榜 ZSet (`constant.HotPostsRankKey`) 获取当前热门帖子ID和分数(浏览量)
 	hotListKey := constant.HotPostsRankKey
-	postScores, err := c.redisClient.ZRevRangeWithScores(ctx, hotListKey, 0, int64(constant.HotPostsCacheSize-1)).Result()
+	postScores, snapshotVersion, err := c.getHotListSnapshot(ctx)
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			c.logger.Info("热榜 ZSet (快照) 为空，无需同步详情缓存。将清理所有旧详情缓存。")
@@ -391,6 +485,7 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 
 	// 4. 阶段一：获取、聚合新详情并写入临时缓存区
 	var marshalErrorCountInStage1 int = 0
+	var skippedUnapprovedCount int = 0
 	tempKeyToFinalKeyMap := make(map[string]string)
 
 	if len(idsToFetchAndAggregate) > 0 {
@@ -433,6 +528,35 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 			}
 		}
 
+		// 4.4 批量获取帖子 FAQ (post_faqs 表)
+		postFAQsMap, dbErrFAQs := c.postBatch.BatchGetPostFAQs(ctx, idsToFetchAndAggregate)
+		if dbErrFAQs != nil {
+			c.logger.Error("从MySQL批量获取帖子FAQ失败，将不带FAQ信息继续聚合，但不中止操作。", zap.Error(dbErrFAQs))
+			postFAQsMap = make(map[uint64][]*entities.PostFAQ)
+		}
+
+		// 4.5 批量确认转发帖引用的原帖是否仍存在（已删除的原帖在详情中展示“原帖已删除”）
+		quotedPostExists := make(map[uint64]bool)
+		var quotedPostIDs []uint64
+		for _, p := range postsData {
+			if p.QuotedPostID != nil {
+				quotedPostIDs = append(quotedPostIDs, *p.QuotedPostID)
+			}
+		}
+		if len(quotedPostIDs) > 0 {
+			quotedPosts, dbErrQuoted := c.postBatch.GetPostsByIDs(ctx, quotedPostIDs)
+			if dbErrQuoted != nil {
+				c.logger.Error("从MySQL批量获取被转发的原帖失败，将按原帖未删除继续聚合。", zap.Error(dbErrQuoted))
+				for _, id := range quotedPostIDs {
+					quotedPostExists[id] = true
+				}
+			} else {
+				for _, qp := range quotedPosts {
+					quotedPostExists[qp.ID] = true
+				}
+			}
+		}
+
 		if len(postsData) > 0 || len(detailsData) > 0 {
 			pipe := c.redisClient.Pipeline()
 			tempKeyWritesAttempted := 0
@@ -446,6 +570,20 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 					continue
 				}
 
+				// 热榜快照生成后帖子可能被驳回或转回草稿，这类帖子不能进入公开的热门详情缓存；
+				// 已缓存的旧详情一并删除，避免继续对外提供。
+				if post.Status != enums.Approved || post.IsDraft {
+					c.logger.Warn("热榜中的帖子未审核通过，跳过详情缓存",
+						zap.Uint64("postID", postIDToProcess),
+						zap.Int("status", int(post.Status)),
+						zap.Bool("isDraft", post.IsDraft))
+					if finalKey, cached := cachedDetailIDsMap[postIDToProcess]; cached {
+						finalKeysToDelete = append(finalKeysToDelete, finalKey)
+					}
+					skippedUnapprovedCount++
+					continue
+				}
+
 				viewCountFromSnapshot := post.ViewCount // 默认使用DB中的值
 				if score, ok := currentHotPostScoresMap[postIDToProcess]; ok {
 					viewCountFromSnapshot = int64(score)
@@ -457,11 +595,7 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 				var imageVOs []vo.PostImageVO
 				if images, imagesFound := detailImagesMap[detail.ID]; imagesFound { // 使用 detail.ID (post_details 表的主键) 作为 key
 					for _, imgEntity := range images {
-						imageVOs = append(imageVOs, vo.PostImageVO{
-							ImageURL:     imgEntity.ImageURL,
-							DisplayOrder: imgEntity.DisplayOrder,
-							ObjectKey:    imgEntity.ObjectKey,
-						})
+						imageVOs = append(imageVOs, vo.NewPostImageVOFromEntity(imgEntity))
 					}
 				}
 
@@ -473,9 +607,18 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 					AuthorAvatar:   post.AuthorAvatar,
 					AuthorUsername: post.AuthorUsername,
 					ViewCount:      viewCountFromSnapshot, // 使用来自热榜快照的浏览量
-					OfficialTag:    post.OfficialTag,
+					Status:         post.Status,
+					OfficialTag:    post.OfficialTags.Primary(),
+					OfficialTags:   post.OfficialTags.Tags(),
+					CopyrightType:  post.CopyrightType,
+					SourceURL:      post.SourceURL,
 					CreatedAt:      post.CreatedAt,
 					UpdatedAt:      post.UpdatedAt,
+					RepostCount:    post.RepostCount,
+					AccessPolicy:   post.AccessPolicy,
+
+					// 转发帖引用的原帖卡片
+					QuotedPost: vo.NewQuotedPostVO(post, post.QuotedPostID != nil && !quotedPostExists[*post.QuotedPostID]),
 
 					// post_detail实体的部分
 					Content:      detail.Content,
@@ -484,10 +627,13 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 
 					// 详情图的部分
 					Images: imageVOs,
+
+					// FAQ 的部分
+					FAQs: vo.NewPostFAQVOsFromEntities(postFAQsMap[postIDToProcess]),
 				}
 
 				idStr := strconv.FormatUint(postDetailVO.ID, 10)
-				jsonData, jsonErr := json.Marshal(postDetailVO)
+				jsonData, jsonErr := json.Marshal(hotPostDetailCacheEntry{PostDetailVO: &postDetailVO, SnapshotVersion: snapshotVersion})
 				if jsonErr != nil {
 					c.logger.Error("序列化聚合后的帖子详情VO失败，跳过", zap.Error(jsonErr), zap.Uint64("postID", postDetailVO.ID))
 					marshalErrorCountInStage1++
@@ -515,10 +661,10 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 					}
 					return fmt.Errorf("写入新详情到临时缓存失败: %w", execErr)
 				}
-				c.logger.Info("成功将聚合帖子详情写入临时Key区域", zap.Int("count", tempKeyWritesAttempted), zap.Int("marshalErrors", marshalErrorCountInStage1))
+				c.logger.Info("成功将聚合帖子详情写入临时Key区域", zap.Int("count", tempKeyWritesAttempted), zap.Int("marshalErrors", marshalErrorCountInStage1), zap.Int("skippedUnapproved", skippedUnapprovedCount))
 			} else if len(idsToFetchAndAggregate) > 0 {
 				c.logger.Warn("有待缓存的帖子ID，但未能成功准备任何详情数据写入临时缓存（可能DB无数据或全部序列化失败）。",
-					zap.Int("idsToFetchCount", len(idsToFetchAndAggregate)))
+					zap.Int("idsToFetchCount", len(idsToFetchAndAggregate)), zap.Int("skippedUnapproved", skippedUnapprovedCount))
 			}
 		} else {
 			c.logger.Info("从数据库未获取到任何需要聚合的新帖子详情数据。", zap.Int("requestedCount", len(idsToFetchAndAggregate)))
@@ -527,38 +673,73 @@ func (c *postTaskCacheImpl) CacheHotPostDetailsToRedis(ctx context.Context) erro
 		c.logger.Info("没有新的帖子详情需要获取、聚合和缓存。")
 	}
 
-	// 5. 阶段二：删除不再热门的帖子详情缓存 (final keys)
-	if len(finalKeysToDelete) > 0 {
-		c.logger.Info("开始删除不再热门的帖子详情缓存", zap.Int("count", len(finalKeysToDelete)))
-		pipe := c.redisClient.Pipeline()
-		for _, keyToDel := range finalKeysToDelete {
-			pipe.Del(ctx, keyToDel)
-		}
-		if _, execErr := pipe.Exec(ctx); execErr != nil {
-			c.logger.Warn("Pipeline执行失败：删除不再热门的帖子详情时出错，部分旧缓存可能残留。",
-				zap.Error(execErr), zap.Int("deleteKeyCount", len(finalKeysToDelete)))
-		} else {
-			c.logger.Info("成功删除不再热门的帖子详情缓存", zap.Int("count", len(finalKeysToDelete)))
-		}
-	}
-
-	// 6. 阶段三：激活新的热门帖子详情缓存 (RENAME temp keys to final keys)
-	if len(tempKeyToFinalKeyMap) > 0 {
-		c.logger.Info("开始激活新的帖子详情缓存 (RENAME操作)", zap.Int("count", len(tempKeyToFinalKeyMap)))
-		renamePipe := c.redisClient.Pipeline()
-		for tempKey, finalKeyToRenameTo := range tempKeyToFinalKeyMap {
-			renamePipe.Rename(ctx, tempKey, finalKeyToRenameTo)
-		}
-		_, execErr := renamePipe.Exec(ctx)
-		if execErr != nil {
-			c.logger.Error("Pipeline执行严重失败：RENAME临时Key到最终Key时出错。缓存状态可能不一致，部分新数据可能仍在临时区。",
-				zap.Error(execErr), zap.Int("renameCount", len(tempKeyToFinalKeyMap)))
-			return fmt.Errorf("RENAME临时缓存失败: %w", execErr)
+	// 5. 阶段二：原子切换——删除不再热门的详情并把临时 Key 激活为最终 Key，同一个 Lua 脚本内完成
+	if len(finalKeysToDelete) > 0 || len(tempKeyToFinalKeyMap) > 0 {
+		c.logger.Info("开始原子切换帖子详情缓存",
+			zap.Int("deleteCount", len(finalKeysToDelete)),
+			zap.Int("renameCount", len(tempKeyToFinalKeyMap)),
+		)
+		if err := c.activateHotPostDetails(ctx, finalKeysToDelete, tempKeyToFinalKeyMap); err != nil {
+			c.logger.Error("原子切换帖子详情缓存失败，现有缓存保持不变，已清理临时Key。",
+				zap.Error(err), zap.Int("renameCount", len(tempKeyToFinalKeyMap)))
+			if len(tempKeyToFinalKeyMap) > 0 {
+				keysToClean := make([]string, 0, len(tempKeyToFinalKeyMap))
+				for tKey := range tempKeyToFinalKeyMap {
+					keysToClean = append(keysToClean, tKey)
+				}
+				c.redisClient.Del(ctx, keysToClean...)
+			}
+			return fmt.Errorf("切换帖子详情缓存失败: %w", err)
 		}
-		c.logger.Info("成功激活新的帖子详情缓存", zap.Int("count", len(tempKeyToFinalKeyMap)))
+		c.logger.Info("成功原子切换帖子详情缓存",
+			zap.Int("deleteCount", len(finalKeysToDelete)),
+			zap.Int("renameCount", len(tempKeyToFinalKeyMap)),
+		)
 	}
 
 	duration := time.Since(startTime)
 	c.logger.Info("完成同步热门帖子详情到 Redis 任务", zap.Duration("duration", duration))
 	return nil
 }
+
+// activateHotPostDetailsScript 原子地删除不再热门的详情 Key，并把全部临时 Key RENAME 为最终 Key。
+//   - 先检查全部临时 Key 都存在，任何一个缺失时直接返回 -1 且不做任何修改；Lua 脚本中途报错不会回滚已执行的命令，
+//     所以先校验再写入，保证读取方要么看到完整的旧缓存，要么看到完整的新缓存。
+//   - 选择 Lua 而不是“整批详情存入一个大 Hash + 一次 RENAME”：详情仍按帖子独立存储，读取端一次 MGET 即可与普通详情 Key
+//     一起读取，回源写回也能为单个帖子设置 TTL；代价是切换前临时 Key 与旧 Key 并存，短时间内约占两倍内存，
+//     且脚本执行期间（最多 constant.HotPostsCacheSize 次 RENAME）会短暂阻塞 Redis。
+//   - 依赖单节点 Redis：集群模式下这些 Key 不在同一个 slot，无法在一个脚本中操作。
+//   - KEYS: [1..ARGV[1]] 要删除的旧最终 Key，之后依次为 (临时 Key, 最终 Key) 对
+//   - ARGV: [1] 要删除的 Key 数量
+var activateHotPostDetailsScript = redis.NewScript(`
+    local delete_count = tonumber(ARGV[1])
+    for i = delete_count + 1, #KEYS, 2 do
+        if redis.call("EXISTS", KEYS[i]) == 0 then
+            return -1
+        end
+    end
+    for i = 1, delete_count do
+        redis.call("DEL", KEYS[i])
+    end
+    for i = delete_count + 1, #KEYS, 2 do
+        redis.call("RENAME", KEYS[i], KEYS[i + 1])
+    end
+    return (#KEYS - delete_count) / 2
+`)
+
+// activateHotPostDetails 执行 activateHotPostDetailsScript，临时 Key 缺失时返回错误且缓存保持不变。
+func (c *postTaskCacheImpl) activateHotPostDetails(ctx context.Context, keysToDelete []string, tempToFinal map[string]string) error {
+	keys := make([]string, 0, len(keysToDelete)+len(tempToFinal)*2)
+	keys = append(keys, keysToDelete...)
+	for tempKey, finalKey := range tempToFinal {
+		keys = append(keys, tempKey, finalKey)
+	}
+	renamed, err := activateHotPostDetailsScript.Run(ctx, c.redisClient, keys, len(keysToDelete)).Int64()
+	if err != nil {
+		return fmt.Errorf("执行详情缓存切换脚本失败: %w", err)
+	}
+	if renamed < 0 {
+		return errors.New("部分临时详情 Key 已不存在，放弃本次切换")
+	}
+	return nil
+}
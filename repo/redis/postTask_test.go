@@ -0,0 +1,212 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+func TestCreateHotListCopiesTopNInBatches(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+
+	// 总榜成员数多于 N 且 N 远大于单批 ZADD 大小，覆盖多批与末尾不满一批的情况
+	const total, n = 12345, 10000
+	members := make([]redis.Z, total)
+	for i := range members {
+		members[i] = redis.Z{Score: float64(i), Member: strconv.Itoa(i)}
+	}
+	if err := client.ZAdd(ctx, constant.PostsRankKey, members...).Err(); err != nil {
+		t.Fatalf("seed rank: %v", err)
+	}
+	// 旧热榜中的成员必须被清空，不能残留到新快照
+	if _, err := mr.ZAdd(constant.HotPostsRankKey, 1e9, "stale"); err != nil {
+		t.Fatalf("seed stale hot list: %v", err)
+	}
+
+	cache := NewPostTaskCache(client, newTestLogger(t), nil)
+	if err := cache.CreateHotList(ctx, n); err != nil {
+		t.Fatalf("CreateHotList(%d): %v", n, err)
+	}
+
+	hot, err := client.ZRevRangeWithScores(ctx, constant.HotPostsRankKey, 0, -1).Result()
+	if err != nil {
+		t.Fatalf("read hot list: %v", err)
+	}
+	if len(hot) != n {
+		t.Fatalf("hot list size = %d, want %d", len(hot), n)
+	}
+	for i, z := range hot {
+		want := total - 1 - i
+		if z.Member != strconv.Itoa(want) || z.Score != float64(want) {
+			t.Fatalf("hot[%d] = %v/%v, want %d with the same score", i, z.Member, z.Score, want)
+		}
+	}
+	if v, _ := mr.Get(constant.HotPostsSnapshotVersionKey); v != "1" {
+		t.Fatalf("snapshot version = %q, want 1", v)
+	}
+}
+
+func TestCreateHotListShorterThanBatch(t *testing.T) {
+	_, client := newTestRedis(t)
+	ctx := context.Background()
+	if err := client.ZAdd(ctx, constant.PostsRankKey, redis.Z{Score: 2, Member: "a"}, redis.Z{Score: 5, Member: "b"}).Err(); err != nil {
+		t.Fatalf("seed rank: %v", err)
+	}
+
+	if err := NewPostTaskCache(client, newTestLogger(t), nil).CreateHotList(ctx, constant.HotListZAddBatchSize); err != nil {
+		t.Fatalf("CreateHotList: %v", err)
+	}
+	hot, err := client.ZRevRange(ctx, constant.HotPostsRankKey, 0, -1).Result()
+	if err != nil || len(hot) != 2 || hot[0] != "b" || hot[1] != "a" {
+		t.Fatalf("hot list = %v, %v, want [b a]", hot, err)
+	}
+}
+
+// detailBatchRepo 是只返回帖子、详情的内存批量查询仓库，图片与 FAQ 为空。
+type detailBatchRepo struct {
+	mysql.PostBatchOperationsRepository
+	posts map[uint64]*entities.Post
+}
+
+func (r *detailBatchRepo) GetPostsByIDs(_ context.Context, ids []uint64) ([]*entities.Post, error) {
+	var result []*entities.Post
+	for _, id := range ids {
+		if p, ok := r.posts[id]; ok {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (r *detailBatchRepo) GetPostDetailsByPostIDs(_ context.Context, postIDs []uint64) ([]*entities.PostDetail, error) {
+	var result []*entities.PostDetail
+	for _, id := range postIDs {
+		if p, ok := r.posts[id]; ok {
+			result = append(result, &entities.PostDetail{PostID: id, Content: "content of " + p.Title})
+		}
+	}
+	return result, nil
+}
+
+func (r *detailBatchRepo) BatchGetPostDetailImages(context.Context, []uint64) (map[uint64][]*entities.PostDetailImage, error) {
+	return map[uint64][]*entities.PostDetailImage{}, nil
+}
+
+func (r *detailBatchRepo) BatchGetPostFAQs(context.Context, []uint64) (map[uint64][]*entities.PostFAQ, error) {
+	return map[uint64][]*entities.PostFAQ{}, nil
+}
+
+func detailKey(id int) string { return constant.PostDetailCacheKeyPrefix + strconv.Itoa(id) }
+func tempDetailKey(id int) string {
+	return constant.PostDetailCacheKeyPrefix + "temp:" + strconv.Itoa(id)
+}
+
+// 任一临时 Key 缺失时切换脚本不做任何修改：旧详情全部保留，其余临时 Key 也不会被提前激活。
+func TestActivateHotPostDetailsIsAllOrNothing(t *testing.T) {
+	mr, client := newTestRedis(t)
+	c := NewPostTaskCache(client, newTestLogger(t), nil).(*postTaskCacheImpl)
+	ctx := context.Background()
+
+	mr.Set(detailKey(1), "old-1")
+	mr.Set(detailKey(2), "stale-2")
+	mr.Set(tempDetailKey(1), "new-1")
+	mr.Set(tempDetailKey(3), "new-3")
+	toDelete := []string{detailKey(2)}
+
+	err := c.activateHotPostDetails(ctx, toDelete, map[string]string{
+		tempDetailKey(1): detailKey(1),
+		tempDetailKey(3): detailKey(3),
+		tempDetailKey(4): detailKey(4), // 已过期或被淘汰
+	})
+	if err == nil {
+		t.Fatal("activateHotPostDetails succeeded with a missing temp key")
+	}
+	for key, want := range map[string]string{detailKey(1): "old-1", detailKey(2): "stale-2", tempDetailKey(1): "new-1", tempDetailKey(3): "new-3"} {
+		if got, _ := mr.Get(key); got != want {
+			t.Fatalf("%s = %q after a failed switch, want %q", key, got, want)
+		}
+	}
+	if mr.Exists(detailKey(3)) {
+		t.Fatalf("%s was activated by a failed switch", detailKey(3))
+	}
+
+	if err := c.activateHotPostDetails(ctx, toDelete, map[string]string{tempDetailKey(1): detailKey(1), tempDetailKey(3): detailKey(3)}); err != nil {
+		t.Fatalf("activateHotPostDetails: %v", err)
+	}
+	for key, want := range map[string]string{detailKey(1): "new-1", detailKey(3): "new-3"} {
+		if got, _ := mr.Get(key); got != want {
+			t.Fatalf("%s = %q, want %q", key, got, want)
+		}
+	}
+	for _, key := range []string{detailKey(2), tempDetailKey(1), tempDetailKey(3)} {
+		if mr.Exists(key) {
+			t.Fatalf("%s still exists after the switch", key)
+		}
+	}
+}
+
+// 写入临时 Key 之后、切换之前临时 Key 丢失时，任务失败并清理临时 Key，对外的详情缓存保持切换前的状态；下一轮任务正常完成切换。
+func TestCacheHotPostDetailsKeepsCacheWhenActivationFails(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+	repo := &detailBatchRepo{posts: map[uint64]*entities.Post{}}
+	for _, id := range []uint64{1, 2} {
+		p := &entities.Post{Title: "post " + strconv.FormatUint(id, 10), AuthorID: "author-1", Status: enums.Approved}
+		p.ID = id
+		repo.posts[id] = p
+	}
+	c := NewPostTaskCache(client, newTestLogger(t), repo)
+
+	if err := client.ZAdd(ctx, constant.HotPostsRankKey, redis.Z{Score: 20, Member: "1"}, redis.Z{Score: 10, Member: "2"}).Err(); err != nil {
+		t.Fatalf("seed hot list: %v", err)
+	}
+	mr.Set(constant.HotPostsSnapshotVersionKey, "1")
+	mr.Set(detailKey(1), "old-1")
+	mr.Set(detailKey(9), "old-9") // 已跌出热榜，切换成功时才删除
+
+	// 切换脚本执行前删除帖子 2 的临时 Key，模拟其被淘汰
+	mr.Server().SetPreHook(func(_ *server.Peer, cmd string, _ ...string) bool {
+		if strings.HasPrefix(cmd, "EVAL") {
+			mr.Del(tempDetailKey(2))
+		}
+		return false
+	})
+	if err := c.CacheHotPostDetailsToRedis(ctx); err == nil {
+		t.Fatal("CacheHotPostDetailsToRedis succeeded although a temp key was lost")
+	}
+	for key, want := range map[string]string{detailKey(1): "old-1", detailKey(9): "old-9"} {
+		if got, _ := mr.Get(key); got != want {
+			t.Fatalf("%s = %q after a failed switch, want %q", key, got, want)
+		}
+	}
+	if mr.Exists(detailKey(2)) {
+		t.Fatalf("%s was activated by a failed switch", detailKey(2))
+	}
+	if keys := mr.Keys(); strings.Contains(strings.Join(keys, ","), ":temp:") {
+		t.Fatalf("temp keys left behind: %v", keys)
+	}
+
+	mr.Server().SetPreHook(nil)
+	if err := c.CacheHotPostDetailsToRedis(ctx); err != nil {
+		t.Fatalf("CacheHotPostDetailsToRedis (retry): %v", err)
+	}
+	for _, id := range []int{1, 2} {
+		raw, _ := mr.Get(detailKey(id))
+		if !strings.Contains(raw, "content of post "+strconv.Itoa(id)) {
+			t.Fatalf("%s = %q, want the refreshed detail", detailKey(id), raw)
+		}
+	}
+	if mr.Exists(detailKey(9)) {
+		t.Fatalf("%s still cached after it left the hot list", detailKey(9))
+	}
+}
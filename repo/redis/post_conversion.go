@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// ConversionDayStats 是帖子某一天的转化统计。
+// - Counts 的 key 为 Hash 字段："{阶段}" 为当天该阶段的人数，"{阶段}:{来源}" 为按归因来源拆分的人数。
+type ConversionDayStats struct {
+	Date   time.Time
+	Counts map[string]int64
+}
+
+// PostConversionRepository 定义了帖子转化漏斗统计的 Redis 操作接口。
+// - 每个帖子每天一个统计 Hash (constant.PostConversionStatsPrefix)。
+// - 每个用户对每个帖子记录最近一次浏览的来源 (constant.PostConversionTouchPrefix)，联系与成交按它归因。
+type PostConversionRepository interface {
+	// RecordEvent 记录用户对帖子的一次转化事件，返回计入的来源；同一用户同一阶段在去重窗口内重复上报时返回空字符串。
+	// - eventType 为 constant.ConversionEvent*；浏览事件按 source 计数并刷新触点，其余阶段忽略 source，按触点归因。
+	RecordEvent(ctx context.Context, postID uint64, userID, eventType, source string, at time.Time) (string, error)
+
+	// GetDailyStats 批量读取帖子在指定日期的统计，结果与 days 一一对应，没有数据的日期返回空 Counts。
+	GetDailyStats(ctx context.Context, postID uint64, days []time.Time) ([]*ConversionDayStats, error)
+}
+
+// recordConversionScript 在一次 Redis 往返内完成“去重 + 归因 + 计数”。
+//   - KEYS: [1] 去重 Key, [2] 当天统计 Hash, [3] 触点 Key
+//   - ARGV: [1] 阶段, [2] 来源, [3] 是否为浏览事件 ("1"/"0"), [4] 去重窗口(毫秒), [5] 统计保留时长(毫秒),
+//     [6] 归因窗口(毫秒), [7] 无触点时的来源, [8] 来源字段分隔符
+//   - 返回: 计入的来源，重复上报时返回空字符串
+var recordConversionScript = redis.NewScript(`
+    if not redis.call("SET", KEYS[1], "1", "NX", "PX", ARGV[4]) then
+        return ""
+    end
+    local source = ARGV[2]
+    if ARGV[3] == "1" then
+        redis.call("SET", KEYS[3], source, "PX", ARGV[6])
+    else
+        source = redis.call("GET", KEYS[3]) or ARGV[7]
+    end
+    redis.call("HINCRBY", KEYS[2], ARGV[1], 1)
+    redis.call("HINCRBY", KEYS[2], ARGV[1] .. ARGV[8] .. source, 1)
+    redis.call("PEXPIRE", KEYS[2], ARGV[5])
+    return source
+`)
+
+// postConversionRepository 是 PostConversionRepository 接口的 Redis 实现。
+type postConversionRepository struct {
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewPostConversionRepository 创建 PostConversionRepository 实例。
+func NewPostConversionRepository(redisClient *redis.Client, logger *core.ZapLogger) PostConversionRepository {
+	return &postConversionRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// conversionStatsKey 返回帖子某一天转化统计 Hash 的 Key。
+func conversionStatsKey(postID uint64, day time.Time) string {
+	return constant.PostConversionStatsPrefix + strconv.FormatUint(postID, 10) + ":" + day.Format(constant.ConversionStatsDateLayout)
+}
+
+// RecordEvent 实现转化事件记录。
+func (r *postConversionRepository) RecordEvent(ctx context.Context, postID uint64, userID, eventType, source string, at time.Time) (string, error) {
+	postIDStr := strconv.FormatUint(postID, 10)
+	dedupKey := constant.PostConversionDedupPrefix + postIDStr + ":" + eventType + ":" + userID + ":" + at.Format(constant.ConversionStatsDateLayout)
+	touchKey := constant.PostConversionTouchPrefix + postIDStr + ":" + userID
+	isView := "0"
+	if eventType == constant.ConversionEventView {
+		isView = "1"
+	}
+
+	counted, err := recordConversionScript.Run(ctx, r.redisClient,
+		[]string{dedupKey, conversionStatsKey(postID, at), touchKey},
+		eventType,
+		source,
+		isView,
+		constant.ConversionDedupWindow.Milliseconds(),
+		constant.ConversionStatsRetention.Milliseconds(),
+		constant.ConversionAttributionWindow.Milliseconds(),
+		constant.ConversionSourceDirect,
+		constant.ConversionStatsSourceSeparator,
+	).Text()
+	if err != nil {
+		r.logger.Error("Lua 脚本执行失败：记录转化事件", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID), zap.String("eventType", eventType))
+		return "", fmt.Errorf("记录转化事件失败 (PostID: %d): %w", postID, err)
+	}
+	return counted, nil
+}
+
+// GetDailyStats 实现每日转化统计读取，使用 Pipeline 一次读取全部日期；无法解析的字段被跳过。
+func (r *postConversionRepository) GetDailyStats(ctx context.Context, postID uint64, days []time.Time) ([]*ConversionDayStats, error) {
+	pipe := r.redisClient.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(days))
+	for i, day := range days {
+		cmds[i] = pipe.HGetAll(ctx, conversionStatsKey(postID, day))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		r.logger.Error("读取转化统计失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("读取转化统计失败 (PostID: %d): %w", postID, err)
+	}
+
+	result := make([]*ConversionDayStats, len(days))
+	for i, cmd := range cmds {
+		stats := &ConversionDayStats{Date: days[i], Counts: make(map[string]int64)}
+		for field, value := range cmd.Val() {
+			count, parseErr := strconv.ParseInt(value, 10, 64)
+			if parseErr != nil {
+				r.logger.Warn("转化统计字段格式错误，已跳过", zap.Uint64("postID", postID), zap.String("field", field), zap.String("value", value))
+				continue
+			}
+			stats.Counts[field] = count
+		}
+		result[i] = stats
+	}
+	return result, nil
+}
@@ -0,0 +1,233 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// PostLikeRepository 定义了帖子点赞相关的 Redis 操作接口。
+// - 点赞用户记录在 Set (constant.PostLikeUsersPrefix)，点赞数维护在单独的计数器 (constant.PostLikeCountPrefix)。
+// - 点赞数发生变化的帖子写入脏集合，由点赞数同步任务定时写回 MySQL。
+type PostLikeRepository interface {
+	// AddLike 原子地记录用户对帖子的点赞并增加点赞数。
+	// - 用户已点过赞时不做任何修改，changed 返回 false，保证重复点赞幂等。
+	// - 计数器不存在（新帖或 Redis 数据丢失）时先从 MySQL 回源初始化。
+	// - 输出: changed 本次是否真正新增了点赞, likeCount 操作后的点赞数。
+	AddLike(ctx context.Context, postID uint64, userID string) (changed bool, likeCount int64, err error)
+
+	// RemoveLike 原子地取消用户对帖子的点赞并减少点赞数。
+	// - 用户未点过赞时不做任何修改，changed 返回 false；点赞数不会被减到负数。
+	RemoveLike(ctx context.Context, postID uint64, userID string) (changed bool, likeCount int64, err error)
+
+	// GetDirtyLikeCounts 获取上次同步以来点赞数发生过变化的帖子的当前点赞数，作为同步到 MySQL 的数据源。
+	// - 认领语义同 PostViewRepository.GetDirtyViewCounts：写入 MySQL 成功后必须调用 AckDirtyLikeCounts。
+	GetDirtyLikeCounts(ctx context.Context) (map[uint64]int64, error)
+
+	// AckDirtyLikeCounts 确认同步中集合已成功写入 MySQL，删除同步中集合。
+	AckDirtyLikeCounts(ctx context.Context) error
+}
+
+// likeStatusNeedSeed 是点赞脚本在计数器不存在时返回的状态，调用方回源初始化后重试。
+const likeStatusNeedSeed = -2
+
+// addLikeScript 在一次 Redis 往返内完成“幂等判断 + 计数 + 标记脏数据”。
+// - KEYS: [1] 点赞用户 Set, [2] 点赞数计数器, [3] 脏集合 Set
+// - ARGV: [1] userID, [2] postID
+// - 返回: {状态, 点赞数}；状态 1 表示新增点赞，0 表示已点过赞（点赞数为当前值），-2 表示计数器需要回源
+var addLikeScript = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[2]) == 0 then
+        return {-2, 0}
+    end
+    if redis.call("SADD", KEYS[1], ARGV[1]) == 0 then
+        return {0, tonumber(redis.call("GET", KEYS[2]))}
+    end
+    local likeCount = redis.call("INCR", KEYS[2])
+    redis.call("SADD", KEYS[3], ARGV[2])
+    return {1, likeCount}
+`)
+
+// removeLikeScript 与 addLikeScript 对称：只有用户确实点过赞时才减少点赞数，且不会减到负数。
+// - KEYS / ARGV / 返回值同 addLikeScript；状态 0 表示用户未点过赞。
+var removeLikeScript = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[2]) == 0 then
+        return {-2, 0}
+    end
+    if redis.call("SREM", KEYS[1], ARGV[1]) == 0 then
+        return {0, tonumber(redis.call("GET", KEYS[2]))}
+    end
+    local likeCount = redis.call("DECR", KEYS[2])
+    if likeCount < 0 then
+        redis.call("SET", KEYS[2], 0)
+        likeCount = 0
+    end
+    redis.call("SADD", KEYS[3], ARGV[2])
+    return {1, likeCount}
+`)
+
+// postLikeRepository 是 PostLikeRepository 接口的 Redis 实现。
+type postLikeRepository struct {
+	redisClient *redis.Client                       // Redis 客户端实例
+	postBatch   mysql.PostBatchOperationsRepository // MySQL 批量操作仓库，用于点赞数计数器的回源
+	logger      *core.ZapLogger                     // 日志记录器实例
+}
+
+// NewPostLikeRepository 创建 PostLikeRepository 实例。
+func NewPostLikeRepository(redisClient *redis.Client, postBatch mysql.PostBatchOperationsRepository, logger *core.ZapLogger) PostLikeRepository {
+	return &postLikeRepository{
+		redisClient: redisClient,
+		postBatch:   postBatch,
+		logger:      logger,
+	}
+}
+
+// AddLike 实现点赞。
+func (r *postLikeRepository) AddLike(ctx context.Context, postID uint64, userID string) (bool, int64, error) {
+	return r.runLikeScript(ctx, addLikeScript, postID, userID)
+}
+
+// RemoveLike 实现取消点赞。
+func (r *postLikeRepository) RemoveLike(ctx context.Context, postID uint64, userID string) (bool, int64, error) {
+	return r.runLikeScript(ctx, removeLikeScript, postID, userID)
+}
+
+// runLikeScript 执行点赞/取消点赞脚本，计数器不存在时从 MySQL 回源初始化后重试一次。
+func (r *postLikeRepository) runLikeScript(ctx context.Context, script *redis.Script, postID uint64, userID string) (bool, int64, error) {
+	member := strconv.FormatUint(postID, 10)
+	likeUsersKey := constant.PostLikeUsersPrefix + member
+	likeCountKey := constant.PostLikeCountPrefix + member
+
+	run := func() ([]int64, error) {
+		return script.Run(ctx, r.redisClient,
+			[]string{likeUsersKey, likeCountKey, constant.DirtyLikeCountsKey},
+			userID, member,
+		).Int64Slice()
+	}
+	result, err := run()
+	if err == nil && len(result) == 2 && result[0] == likeStatusNeedSeed {
+		if err = r.seedLikeCount(ctx, postID, likeCountKey); err == nil {
+			result, err = run()
+		}
+		if err == nil && len(result) == 2 && result[0] == likeStatusNeedSeed {
+			err = fmt.Errorf("回源初始化后点赞数计数器仍不存在")
+		}
+	}
+	if err == nil && len(result) != 2 {
+		err = fmt.Errorf("点赞脚本返回值格式错误: %v", result)
+	}
+	if err != nil {
+		r.logger.Error("Lua 脚本执行失败：点赞/取消点赞", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID))
+		return false, 0, fmt.Errorf("更新点赞状态失败 (PostID: %d): %w", postID, err)
+	}
+	return result[0] == 1, result[1], nil
+}
+
+// seedLikeCount 从 MySQL 读取帖子已持久化的点赞数，初始化 Redis 计数器。
+// - 使用 SETNX，并发回源时只有第一个写入生效。
+func (r *postLikeRepository) seedLikeCount(ctx context.Context, postID uint64, likeCountKey string) error {
+	likeCounts, err := r.postBatch.GetPostLikeCounts(ctx, []uint64{postID})
+	if err != nil {
+		return fmt.Errorf("回源获取帖子点赞数失败: %w", err)
+	}
+	if err := r.redisClient.SetNX(ctx, likeCountKey, likeCounts[postID], 0).Err(); err != nil {
+		return fmt.Errorf("初始化点赞数计数器失败: %w", err)
+	}
+	r.logger.Debug("点赞数计数器已从 MySQL 回源初始化", zap.Uint64("postID", postID), zap.Int64("likeCount", likeCounts[postID]))
+	return nil
+}
+
+// GetDirtyLikeCounts 实现点赞数增量同步的数据获取：认领脏集合后用 SSCAN 分批读取成员，并 MGET 其点赞数。
+func (r *postLikeRepository) GetDirtyLikeCounts(ctx context.Context) (map[uint64]int64, error) {
+	// 认领脚本只依赖 KEYS，与浏览量脏集合共用
+	total, err := claimDirtyViewCountsScript.Run(ctx, r.redisClient,
+		[]string{constant.DirtyLikeCountsKey, constant.DirtyLikeCountsSyncingKey}).Int64()
+	if err != nil {
+		r.logger.Error("认领点赞数脏集合失败", zap.Error(err))
+		return nil, fmt.Errorf("认领点赞数脏集合失败: %w", err)
+	}
+	likeCounts := make(map[uint64]int64, total)
+	if total == 0 {
+		return likeCounts, nil
+	}
+	startTime := time.Now()
+
+	var cursor uint64
+	for {
+		members, nextCursor, err := r.redisClient.SScan(ctx, constant.DirtyLikeCountsSyncingKey, cursor, "", constant.LikeSyncScanBatchSize).Result()
+		if err != nil {
+			r.logger.Error("执行 Redis SSCAN 读取点赞数脏集合失败", zap.Error(err), zap.Uint64("cursor", cursor))
+			return nil, fmt.Errorf("读取点赞数脏集合失败: %w", err)
+		}
+		if err := r.collectLikeCounts(ctx, members, likeCounts); err != nil {
+			return nil, err
+		}
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	r.logger.Info("完成读取点赞数脏集合",
+		zap.Int64("dirty_posts", total),
+		zap.Int("posts_to_sync", len(likeCounts)),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return likeCounts, nil
+}
+
+// collectLikeCounts 批量读取一批帖子的点赞数计数器并写入 likeCounts，计数器不存在或无法解析的帖子跳过。
+func (r *postLikeRepository) collectLikeCounts(ctx context.Context, members []string, likeCounts map[uint64]int64) error {
+	if len(members) == 0 {
+		return nil
+	}
+	postIDs := make([]uint64, 0, len(members))
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		postID, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			r.logger.Error("点赞数脏集合中的成员不是合法的 PostID，已跳过", zap.String("member", member))
+			continue
+		}
+		postIDs = append(postIDs, postID)
+		keys = append(keys, constant.PostLikeCountPrefix+member)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Error("执行 Redis MGET 批量获取帖子点赞数失败", zap.Error(err), zap.Int("keys", len(keys)))
+		return fmt.Errorf("批量获取点赞数失败 (%d keys): %w", len(keys), err)
+	}
+	for i, value := range values {
+		valueStr, ok := value.(string)
+		if !ok || valueStr == "" {
+			continue
+		}
+		likeCount, parseErr := strconv.ParseInt(valueStr, 10, 64)
+		if parseErr != nil {
+			r.logger.Error("解析 Redis 中的点赞数失败，已跳过", zap.Error(parseErr), zap.String("key", keys[i]), zap.String("value_str", valueStr))
+			continue
+		}
+		likeCounts[postIDs[i]] = likeCount
+	}
+	return nil
+}
+
+// AckDirtyLikeCounts 实现同步成功后的脏标记清理。
+func (r *postLikeRepository) AckDirtyLikeCounts(ctx context.Context) error {
+	if err := r.redisClient.Del(ctx, constant.DirtyLikeCountsSyncingKey).Err(); err != nil {
+		r.logger.Error("删除点赞数同步中集合失败，下一轮将重复同步这些帖子", zap.Error(err))
+		return fmt.Errorf("删除点赞数同步中集合失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// ReadDepthRecordResult 是一次阅读深度上报的处理结果。
+type ReadDepthRecordResult int
+
+const (
+	ReadDepthThrottled ReadDepthRecordResult = -1 // 距离同一次阅读的上一次上报不足 constant.ReadDepthReportInterval，未处理
+	ReadDepthUnchanged ReadDepthRecordResult = 0  // 深度未超过该次阅读已记录的最大深度，统计不变
+	ReadDepthRecorded  ReadDepthRecordResult = 1  // 新的一次阅读或深度提升，统计已更新
+)
+
+// ReadDepthStats 是帖子阅读深度的聚合统计。
+type ReadDepthStats struct {
+	Reads    int64         // 阅读次数（同一用户的同一次阅读只计一次）
+	SumDepth int64         // 各次阅读最大深度之和
+	Buckets  map[int]int64 // 各深度区间的阅读次数，key 为桶序号 (深度 / constant.ReadDepthBucketSize)
+}
+
+// PostReadDepthRepository 定义了帖子阅读深度统计的 Redis 操作接口。
+// - 每个帖子一个统计 Hash (constant.PostReadDepthStatsPrefix)，记录阅读次数、深度总和与深度分布。
+// - 每次阅读一个会话 Hash (constant.PostReadSessionPrefix)，记录已计入统计的最大深度，用于去重与频率控制。
+type PostReadDepthRepository interface {
+	// RecordDepth 记录用户某次阅读 (readID) 滚动到的深度 (0-100)。
+	// - 同一次阅读只保留最大深度：深度提升时把该次阅读从旧区间移到新区间，并补上深度差；深度未提升时不改动统计。
+	RecordDepth(ctx context.Context, postID uint64, userID, readID string, depth int) (ReadDepthRecordResult, error)
+
+	// GetDepthStats 读取帖子的阅读深度统计，没有任何阅读时返回零值统计。
+	GetDepthStats(ctx context.Context, postID uint64) (*ReadDepthStats, error)
+}
+
+// recordReadDepthScript 在一次 Redis 往返内完成“频率控制 + 去重取最大值 + 更新分布”。
+//   - KEYS: [1] 阅读会话 Hash, [2] 帖子统计 Hash
+//   - ARGV: [1] 深度, [2] 当前时间(毫秒), [3] 最小上报间隔(毫秒), [4] 会话过期时间(毫秒), [5] 桶宽,
+//     [6] 阅读次数字段, [7] 深度总和字段, [8] 分布桶字段前缀
+//   - 返回: -1 上报过于频繁，0 深度未提升，1 统计已更新
+var recordReadDepthScript = redis.NewScript(`
+    local now = tonumber(ARGV[2])
+    local last = redis.call("HGET", KEYS[1], "ts")
+    if last and now - tonumber(last) < tonumber(ARGV[3]) then
+        return -1
+    end
+    local depth = tonumber(ARGV[1])
+    local bucketSize = tonumber(ARGV[5])
+    local status = 1
+    local old = redis.call("HGET", KEYS[1], "depth")
+    if old then
+        old = tonumber(old)
+        if depth > old then
+            redis.call("HINCRBY", KEYS[2], ARGV[8] .. math.floor(old / bucketSize), -1)
+            redis.call("HINCRBY", KEYS[2], ARGV[8] .. math.floor(depth / bucketSize), 1)
+            redis.call("HINCRBY", KEYS[2], ARGV[7], depth - old)
+            redis.call("HSET", KEYS[1], "depth", depth)
+        else
+            status = 0
+        end
+    else
+        redis.call("HINCRBY", KEYS[2], ARGV[6], 1)
+        redis.call("HINCRBY", KEYS[2], ARGV[7], depth)
+        redis.call("HINCRBY", KEYS[2], ARGV[8] .. math.floor(depth / bucketSize), 1)
+        redis.call("HSET", KEYS[1], "depth", depth)
+    end
+    redis.call("HSET", KEYS[1], "ts", now)
+    redis.call("PEXPIRE", KEYS[1], ARGV[4])
+    return status
+`)
+
+// postReadDepthRepository 是 PostReadDepthRepository 接口的 Redis 实现。
+type postReadDepthRepository struct {
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewPostReadDepthRepository 创建 PostReadDepthRepository 实例。
+func NewPostReadDepthRepository(redisClient *redis.Client, logger *core.ZapLogger) PostReadDepthRepository {
+	return &postReadDepthRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// readDepthStatsKey 返回帖子阅读深度统计 Hash 的 Key。
+func readDepthStatsKey(postID uint64) string {
+	return constant.PostReadDepthStatsPrefix + strconv.FormatUint(postID, 10)
+}
+
+// RecordDepth 实现阅读深度上报。
+func (r *postReadDepthRepository) RecordDepth(ctx context.Context, postID uint64, userID, readID string, depth int) (ReadDepthRecordResult, error) {
+	sessionKey := constant.PostReadSessionPrefix + strconv.FormatUint(postID, 10) + ":" + userID + ":" + readID
+	status, err := recordReadDepthScript.Run(ctx, r.redisClient,
+		[]string{sessionKey, readDepthStatsKey(postID)},
+		depth,
+		time.Now().UnixMilli(),
+		constant.ReadDepthReportInterval.Milliseconds(),
+		constant.ReadDepthSessionTTL.Milliseconds(),
+		constant.ReadDepthBucketSize,
+		constant.ReadDepthStatsReadsField,
+		constant.ReadDepthStatsSumField,
+		constant.ReadDepthStatsBucketField,
+	).Int64()
+	if err != nil {
+		r.logger.Error("Lua 脚本执行失败：记录阅读深度", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID))
+		return 0, fmt.Errorf("记录阅读深度失败 (PostID: %d): %w", postID, err)
+	}
+	return ReadDepthRecordResult(status), nil
+}
+
+// GetDepthStats 实现阅读深度统计读取，无法解析的字段被跳过。
+func (r *postReadDepthRepository) GetDepthStats(ctx context.Context, postID uint64) (*ReadDepthStats, error) {
+	fields, err := r.redisClient.HGetAll(ctx, readDepthStatsKey(postID)).Result()
+	if err != nil {
+		r.logger.Error("读取阅读深度统计失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("读取阅读深度统计失败 (PostID: %d): %w", postID, err)
+	}
+	stats := &ReadDepthStats{Buckets: make(map[int]int64)}
+	for field, value := range fields {
+		count, countErr := strconv.ParseInt(value, 10, 64)
+		if countErr != nil {
+			r.logger.Warn("阅读深度统计字段格式错误，已跳过", zap.Uint64("postID", postID), zap.String("field", field), zap.String("value", value))
+			continue
+		}
+		switch field {
+		case constant.ReadDepthStatsReadsField:
+			stats.Reads = count
+		case constant.ReadDepthStatsSumField:
+			stats.SumDepth = count
+		default:
+			bucketStr, ok := strings.CutPrefix(field, constant.ReadDepthStatsBucketField)
+			if !ok {
+				continue
+			}
+			bucket, idErr := strconv.Atoi(bucketStr)
+			if idErr != nil {
+				r.logger.Warn("阅读深度统计字段格式错误，已跳过", zap.Uint64("postID", postID), zap.String("field", field))
+				continue
+			}
+			stats.Buckets[bucket] = count
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,175 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// cachedPostTargetingRepository 在 MySQL 定向条件仓库外包一层 Redis 读穿缓存，
+// 使帖子详情、热榜等读路径不必每次请求都查询 post_targetings 表。
+//   - 定向条件随帖子创建写入后不再修改，缓存无需在更新时失效；未配置定向的帖子同样缓存占位值。
+//   - 回源未命中时用 SETNX 写入占位值，CreateTargeting 则无条件覆盖：即使并发的读请求在事务提交前回源，
+//     也不会用“无定向”覆盖刚创建的定向条件。
+//   - Redis 不可用或缓存值损坏时直接回源 MySQL，不影响请求结果。
+type cachedPostTargetingRepository struct {
+	mysql.PostTargetingRepository
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewCachedPostTargetingRepository 创建带 Redis 缓存的定向条件仓库，接口与被包装的 MySQL 仓库一致。
+func NewCachedPostTargetingRepository(repo mysql.PostTargetingRepository, redisClient *redis.Client, logger *core.ZapLogger) mysql.PostTargetingRepository {
+	return &cachedPostTargetingRepository{
+		PostTargetingRepository: repo,
+		redisClient:             redisClient,
+		logger:                  logger,
+	}
+}
+
+func postTargetingCacheKey(postID uint64) string {
+	return constant.PostTargetingCacheKeyPrefix + strconv.FormatUint(postID, 10)
+}
+
+// CreateTargeting 写入 MySQL 后立即覆盖缓存，保证新建的定向条件不会被之前缓存的占位值遮住。
+func (r *cachedPostTargetingRepository) CreateTargeting(ctx context.Context, db *gorm.DB, targeting *entities.PostTargeting) error {
+	if err := r.PostTargetingRepository.CreateTargeting(ctx, db, targeting); err != nil {
+		return err
+	}
+	data, err := json.Marshal(targeting)
+	if err != nil {
+		r.logger.Error("序列化帖子定向条件失败", zap.Uint64("postID", targeting.PostID), zap.Error(err))
+		return nil
+	}
+	if err := r.redisClient.Set(ctx, postTargetingCacheKey(targeting.PostID), data, constant.PostTargetingCacheTTL).Err(); err != nil {
+		// 写缓存失败时删除旧值，删除也失败则只能等待占位值过期
+		r.logger.Warn("写入帖子定向条件缓存失败", zap.Uint64("postID", targeting.PostID), zap.Error(err))
+		_ = r.redisClient.Del(ctx, postTargetingCacheKey(targeting.PostID)).Err()
+	}
+	return nil
+}
+
+// GetTargetingByPostID 优先读取缓存，未命中时回源 MySQL 并写回。
+func (r *cachedPostTargetingRepository) GetTargetingByPostID(ctx context.Context, postID uint64) (*entities.PostTargeting, error) {
+	raw, err := r.redisClient.Get(ctx, postTargetingCacheKey(postID)).Result()
+	if err == nil {
+		if targeting, ok := r.decode(ctx, postID, raw); ok {
+			if targeting == nil {
+				return nil, commonerrors.ErrRepoNotFound
+			}
+			return targeting, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		r.logger.Warn("读取帖子定向条件缓存失败，回源 MySQL", zap.Uint64("postID", postID), zap.Error(err))
+	}
+
+	targeting, err := r.PostTargetingRepository.GetTargetingByPostID(ctx, postID)
+	switch {
+	case err == nil:
+		r.fill(ctx, map[uint64]*entities.PostTargeting{postID: targeting}, nil)
+	case errors.Is(err, commonerrors.ErrRepoNotFound):
+		r.fill(ctx, nil, []uint64{postID})
+	}
+	return targeting, err
+}
+
+// GetTargetingsByPostIDs 一次 MGET 读取缓存，只对未命中的帖子批量回源 MySQL。
+func (r *cachedPostTargetingRepository) GetTargetingsByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]*entities.PostTargeting, error) {
+	if len(postIDs) == 0 {
+		return make(map[uint64]*entities.PostTargeting), nil
+	}
+
+	keys := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		keys[i] = postTargetingCacheKey(id)
+	}
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Warn("批量读取帖子定向条件缓存失败，整批回源 MySQL", zap.Int("idCount", len(postIDs)), zap.Error(err))
+		values = make([]interface{}, len(postIDs))
+	}
+
+	result := make(map[uint64]*entities.PostTargeting, len(postIDs))
+	missed := make([]uint64, 0, len(postIDs))
+	for i, id := range postIDs {
+		raw, ok := values[i].(string)
+		if !ok {
+			missed = append(missed, id)
+			continue
+		}
+		targeting, ok := r.decode(ctx, id, raw)
+		if !ok {
+			missed = append(missed, id)
+			continue
+		}
+		if targeting != nil {
+			result[id] = targeting
+		}
+	}
+	if len(missed) == 0 {
+		return result, nil
+	}
+
+	loaded, err := r.PostTargetingRepository.GetTargetingsByPostIDs(ctx, missed)
+	if err != nil {
+		return nil, err
+	}
+	var none []uint64
+	for _, id := range missed {
+		if targeting, ok := loaded[id]; ok {
+			result[id] = targeting
+		} else {
+			none = append(none, id)
+		}
+	}
+	r.fill(ctx, loaded, none)
+	return result, nil
+}
+
+// decode 解析缓存值，返回 (nil, true) 表示帖子未配置定向条件。
+// - 值损坏时删除该 Key 并返回 false，由调用方回源后重新写入（占位值用 SETNX 写入，不删除就无法覆盖）。
+func (r *cachedPostTargetingRepository) decode(ctx context.Context, postID uint64, raw string) (*entities.PostTargeting, bool) {
+	if raw == constant.PostTargetingCacheNone {
+		return nil, true
+	}
+	var targeting entities.PostTargeting
+	if err := json.Unmarshal([]byte(raw), &targeting); err != nil {
+		r.logger.Warn("帖子定向条件缓存值损坏，回源 MySQL", zap.Uint64("postID", postID), zap.Error(err))
+		_ = r.redisClient.Del(ctx, postTargetingCacheKey(postID)).Err()
+		return nil, false
+	}
+	return &targeting, true
+}
+
+// fill 将回源结果写回缓存：有定向条件的帖子直接写入，未配置定向的帖子用 SETNX 写入占位值。
+func (r *cachedPostTargetingRepository) fill(ctx context.Context, targetings map[uint64]*entities.PostTargeting, none []uint64) {
+	if len(targetings) == 0 && len(none) == 0 {
+		return
+	}
+	pipe := r.redisClient.Pipeline()
+	for id, targeting := range targetings {
+		data, err := json.Marshal(targeting)
+		if err != nil {
+			r.logger.Error("序列化帖子定向条件失败", zap.Uint64("postID", id), zap.Error(err))
+			continue
+		}
+		pipe.Set(ctx, postTargetingCacheKey(id), data, constant.PostTargetingCacheTTL)
+	}
+	for _, id := range none {
+		pipe.SetNX(ctx, postTargetingCacheKey(id), constant.PostTargetingCacheNone, constant.PostTargetingCacheTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Warn("写回帖子定向条件缓存失败", zap.Int("count", len(targetings)+len(none)), zap.Error(err))
+	}
+}
@@ -0,0 +1,144 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// countingTargetingRepo 是记录回源次数的内存定向条件仓库。
+type countingTargetingRepo struct {
+	mysql.PostTargetingRepository
+	targetings  map[uint64]*entities.PostTargeting
+	singleCalls int
+	batchIDs    []uint64
+}
+
+func (r *countingTargetingRepo) CreateTargeting(_ context.Context, _ *gorm.DB, targeting *entities.PostTargeting) error {
+	r.targetings[targeting.PostID] = targeting
+	return nil
+}
+
+func (r *countingTargetingRepo) GetTargetingByPostID(_ context.Context, postID uint64) (*entities.PostTargeting, error) {
+	r.singleCalls++
+	if t, ok := r.targetings[postID]; ok {
+		return t, nil
+	}
+	return nil, commonerrors.ErrRepoNotFound
+}
+
+func (r *countingTargetingRepo) GetTargetingsByPostIDs(_ context.Context, postIDs []uint64) (map[uint64]*entities.PostTargeting, error) {
+	r.batchIDs = append(r.batchIDs, postIDs...)
+	result := make(map[uint64]*entities.PostTargeting)
+	for _, id := range postIDs {
+		if t, ok := r.targetings[id]; ok {
+			result[id] = t
+		}
+	}
+	return result, nil
+}
+
+func TestCachedPostTargetingRepositoryReadsThrough(t *testing.T) {
+	_, client := newTestRedis(t)
+	inner := &countingTargetingRepo{targetings: map[uint64]*entities.PostTargeting{
+		1: {PostID: 1, Regions: "guangdong", MinUserLevel: 3},
+	}}
+	repo := NewCachedPostTargetingRepository(inner, client, newTestLogger(t))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		got, err := repo.GetTargetingByPostID(ctx, 1)
+		if err != nil || got.Regions != "guangdong" || got.MinUserLevel != 3 {
+			t.Fatalf("GetTargetingByPostID(1) = %+v, %v", got, err)
+		}
+		if _, err := repo.GetTargetingByPostID(ctx, 2); !errors.Is(err, commonerrors.ErrRepoNotFound) {
+			t.Fatalf("GetTargetingByPostID(2) error = %v, want ErrRepoNotFound", err)
+		}
+	}
+	if inner.singleCalls != 2 {
+		t.Fatalf("MySQL lookups = %d, want 2 (one per post, later reads served from cache)", inner.singleCalls)
+	}
+}
+
+func TestCachedPostTargetingRepositoryCreateOverridesNoneMarker(t *testing.T) {
+	mr, client := newTestRedis(t)
+	inner := &countingTargetingRepo{targetings: map[uint64]*entities.PostTargeting{}}
+	repo := NewCachedPostTargetingRepository(inner, client, newTestLogger(t))
+	ctx := context.Background()
+
+	// 帖子创建前被探测到，缓存了“无定向”占位值
+	if _, err := repo.GetTargetingByPostID(ctx, 7); !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		t.Fatalf("GetTargetingByPostID(7) error = %v, want ErrRepoNotFound", err)
+	}
+	if v, _ := mr.Get(postTargetingCacheKey(7)); v != constant.PostTargetingCacheNone {
+		t.Fatalf("cached value = %q, want the none marker", v)
+	}
+
+	if err := repo.CreateTargeting(ctx, nil, &entities.PostTargeting{PostID: 7, Tags: "vip"}); err != nil {
+		t.Fatalf("CreateTargeting: %v", err)
+	}
+	got, err := repo.GetTargetingByPostID(ctx, 7)
+	if err != nil || got.Tags != "vip" {
+		t.Fatalf("GetTargetingByPostID(7) after create = %+v, %v, want the new targeting", got, err)
+	}
+}
+
+func TestCachedPostTargetingRepositoryBatchOnlyLoadsMisses(t *testing.T) {
+	mr, client := newTestRedis(t)
+	inner := &countingTargetingRepo{targetings: map[uint64]*entities.PostTargeting{
+		1: {PostID: 1, Regions: "shanghai"},
+		3: {PostID: 3, Tags: "merchant"},
+	}}
+	repo := NewCachedPostTargetingRepository(inner, client, newTestLogger(t))
+	ctx := context.Background()
+
+	if _, err := repo.GetTargetingByPostID(ctx, 1); err != nil {
+		t.Fatalf("warm cache: %v", err)
+	}
+	// 损坏的缓存值视为未命中
+	mr.Set(postTargetingCacheKey(2), "{broken")
+
+	got, err := repo.GetTargetingsByPostIDs(ctx, []uint64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("GetTargetingsByPostIDs: %v", err)
+	}
+	if len(got) != 2 || got[1].Regions != "shanghai" || got[3].Tags != "merchant" {
+		t.Fatalf("GetTargetingsByPostIDs = %+v, want posts 1 and 3", got)
+	}
+	if len(inner.batchIDs) != 2 || inner.batchIDs[0] != 2 || inner.batchIDs[1] != 3 {
+		t.Fatalf("MySQL batch lookup ids = %v, want [2 3]", inner.batchIDs)
+	}
+
+	inner.batchIDs = nil
+	if _, err := repo.GetTargetingsByPostIDs(ctx, []uint64{1, 2, 3}); err != nil {
+		t.Fatalf("GetTargetingsByPostIDs (cached): %v", err)
+	}
+	if len(inner.batchIDs) != 0 {
+		t.Fatalf("MySQL batch lookup ids = %v, want none once every post is cached", inner.batchIDs)
+	}
+}
+
+func TestCachedPostTargetingRepositoryFallsBackWhenRedisDown(t *testing.T) {
+	mr, client := newTestRedis(t)
+	inner := &countingTargetingRepo{targetings: map[uint64]*entities.PostTargeting{
+		1: {PostID: 1, MinUserLevel: 5},
+	}}
+	repo := NewCachedPostTargetingRepository(inner, client, newTestLogger(t))
+	mr.Close()
+
+	got, err := repo.GetTargetingByPostID(context.Background(), 1)
+	if err != nil || got.MinUserLevel != 5 {
+		t.Fatalf("GetTargetingByPostID with Redis down = %+v, %v, want the MySQL value", got, err)
+	}
+	batch, err := repo.GetTargetingsByPostIDs(context.Background(), []uint64{1})
+	if err != nil || batch[1] == nil {
+		t.Fatalf("GetTargetingsByPostIDs with Redis down = %+v, %v, want the MySQL value", batch, err)
+	}
+}
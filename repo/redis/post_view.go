@@ -2,10 +2,12 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Xushengqwer/post_service/config"
 	"strconv" // 需要导入 strconv 包
 	"strings" // 需要导入 strings 包
+	"sync/atomic"
 	"time"
 
 	"github.com/Xushengqwer/go-common/core" // 导入日志库
@@ -13,6 +15,7 @@ import (
 	"go.uber.org/zap" // 导入 zap
 
 	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/repo/mysql"
 )
 
 // PostViewRepository 定义了与帖子浏览、排名相关的 Redis 操作接口。
@@ -21,9 +24,44 @@ type PostViewRepository interface {
 	// IncrementViewCount 原子性地增加指定帖子的浏览量，并更新其在热榜中的分数。
 	// - 使用 Bloom Filter (`bloomKey`) 防止同一用户在短时间 (TTL) 内重复计数。
 	// - 使用 Lua 脚本 (`luaScript`) 保证 Redis 中计数器 (`viewCountKey`) 和 ZSet (`hotPostsKey`) 的原子性更新。
-	// - 输入: postID (帖子ID), userID (用于Bloom Filter的用户标识)。
+	// - 输入: postID (帖子ID), viewer (访客标识，登录用户以 userID 为主键去重，见 ViewerIdentity)。
+	// - 配置关闭匿名浏览统计 (ViewCountConfig.CountAnonymousViews) 时，匿名访客的浏览直接忽略。
+	// - 匿名设备计入的浏览会记录到 constant.AnonymousDeviceViewsPrefix，供登录后 MergeAnonymousViews 归并。
 	// - 输出: error 操作错误。如果用户已在 Bloom Filter 中，则返回 nil 且不执行计数增加。
-	IncrementViewCount(ctx context.Context, postID uint64, userID string) error
+	IncrementViewCount(ctx context.Context, postID uint64, viewer ViewerIdentity) error
+
+	// IncrementViewCountWithTTL 与 IncrementViewCount 相同，但允许为特殊帖子（如热门活动帖）覆盖默认的防刷窗口。
+	// - ttl 为 0（或负数）时退回到配置的默认去重窗口。
+	IncrementViewCountWithTTL(ctx context.Context, postID uint64, viewer ViewerIdentity, ttl time.Duration) error
+
+	// MarkViewed 只执行 IncrementViewCount 中必须逐次浏览完成的去重部分，不修改浏览量计数器。
+	// - 白名单、匿名访客与设备成员的处理与 IncrementViewCount 一致；ttl 为 0（或负数）时退回配置的默认去重窗口。
+	// - 输出: 为 true 表示本次是窗口内的新访客，调用方应随后通过 IncrementViewCountBy 计入浏览量（可跨请求聚合）。
+	MarkViewed(ctx context.Context, postID uint64, viewer ViewerIdentity, ttl time.Duration) (bool, error)
+
+	// IncrementViewCountBy 把已通过 MarkViewed 去重的 delta 次浏览一次性计入帖子。
+	// - 在一个 Lua 脚本内完成计数器 INCRBY、排行榜分数、最近活跃时间、脏集合与全站分钟桶的更新，语义与 IncrementViewCount 计数部分一致。
+	// - 计数器不存在（新帖或已归档）时从 MySQL 回源初始化后重试一次。delta 不大于 0 时直接返回。
+	IncrementViewCountBy(ctx context.Context, postID uint64, delta int64) error
+
+	// MergeAnonymousViews 将设备在去重窗口内的匿名浏览记录归并到登录用户。
+	// - 对记录中的每个帖子，把 userID 补入其去重过滤器（不增加浏览量），之后用户在任何设备上浏览这些帖子都不会重复计数。
+	// - 过滤器已过期的帖子无需归并；归并完成后删除该设备的浏览记录。
+	// - deviceMember 为 ViewerIdentity.DeviceMember 格式的设备成员。
+	// - 输出: 本次补入用户的帖子数量。
+	MergeAnonymousViews(ctx context.Context, userID string, deviceMember string) (int, error)
+
+	// RefreshViewWhitelist 从 Redis Set (constant.ViewWhitelistKey) 重新加载浏览量防刷白名单，并与配置中的白名单合并。
+	// - 白名单保存在本地内存中，计数时的白名单检查不产生额外的 Redis 往返。
+	// - 加载失败时保留上一次的白名单，返回 error。
+	// - 输出: 当前生效的白名单用户数量。
+	RefreshViewWhitelist(ctx context.Context) (int, error)
+
+	// GetViewsInWindow 聚合最近 minutes 分钟的全站浏览量（滑动窗口指标，供运营大屏使用）。
+	// - 窗口包含当前（未结束的）分钟桶以及之前的 minutes-1 个完整分钟桶。
+	// - minutes 会被限制在 [1, constant.ViewBucketRetentionMinutes] 范围内。
+	// - 以 Redis 服务器时间为准确定桶边界，与写入侧保持一致。
+	GetViewsInWindow(ctx context.Context, minutes int) (int64, error)
 
 	// GetAllViewCounts 使用 SCAN 命令分批获取 Redis 中所有帖子的浏览量计数。
 	// - 目的是安全、高效地获取全量浏览量数据，作为同步到 MySQL 的数据源。
@@ -31,102 +69,572 @@ type PostViewRepository interface {
 	// - 输入: ctx (上下文)。
 	// - 输出: map[uint64]int64 (帖子 ID -> 浏览量), error 操作错误。
 	GetAllViewCounts(ctx context.Context) (map[uint64]int64, error)
+
+	// GetDirtyViewCounts 获取上次同步以来浏览量发生过变化的帖子的当前浏览量，作为增量同步到 MySQL 的数据源。
+	// - 先将脏集合 (constant.DirtyViewCountsKey) 原子地转入同步中集合 (constant.DirtyViewCountsSyncingKey)，
+	//   上一轮同步失败遗留的同步中集合会与之合并，之后产生的新浏览写入新的脏集合，互不干扰。
+	// - 计数器已被归档删除的帖子会被跳过（归档时已写入 MySQL）。
+	// - 调用方写入 MySQL 成功后必须调用 AckDirtyViewCounts；失败时不调用，脏标记保留到下一轮重试。
+	GetDirtyViewCounts(ctx context.Context) (map[uint64]int64, error)
+
+	// AckDirtyViewCounts 确认同步中集合已成功写入 MySQL，删除同步中集合。
+	AckDirtyViewCounts(ctx context.Context) error
+
+	// GetViewCountsByIDs 使用 MGET 批量获取指定帖子在 Redis 中的浏览量计数。
+	// - 计数器不存在（未被浏览过或已归档删除）的帖子不会出现在返回的映射中。
+	GetViewCountsByIDs(ctx context.Context, postIDs []uint64) (map[uint64]int64, error)
+
+	// GetViewCount 读取单个帖子在 Redis 中的实时浏览量。
+	// - 计数器不存在（未被浏览过或已归档删除）时返回 0 和 nil，调用方应与 MySQL 中的值取较大者。
+	GetViewCount(ctx context.Context, postID uint64) (int64, error)
+
+	// GetPendingSyncPostIDs 返回 postIDs 中尚未同步到 MySQL 的帖子（位于脏集合或同步中集合）。
+	// - 这些帖子在 Redis 与 MySQL 之间的差值属于正常的同步延迟。
+	GetPendingSyncPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]bool, error)
+
+	// ArchiveColdViewCounts 将冷帖子的浏览量计数器归档到 MySQL 后从 Redis 删除。
+	// - 冷热判定: 最近一次计入浏览的时间 (constant.ViewLastActiveKey) 早于 coldThreshold 之前，且当前不在热榜 (constant.HotPostsRankKey) 中。
+	// - 归档顺序为“读取计数 -> 写入 MySQL -> 计数未变化时才删除”，删除前如有新浏览则保留计数器，保证新浏览不丢失。
+	// - 计数器删除后再次被浏览时，由 IncrementViewCount 从 MySQL 回源初始化后继续计数。
+	// - 输出: 本次归档（删除）的计数器数量。
+	ArchiveColdViewCounts(ctx context.Context, coldThreshold time.Duration, batchSize int) (int, error)
+
+	// GetBloomInfo 用 Pipeline 批量执行 BF.INFO，读取帖子去重 Bloom Filter 的已插入数量、容量与内存占用。
+	// - 过滤器不存在（已过期或尚未有人浏览）或单个读取失败的帖子不出现在返回的映射中。
+	GetBloomInfo(ctx context.Context, postIDs []uint64) (map[uint64]*ViewBloomInfo, error)
+
+	// RebuildBloom 以更大的容量重建帖子的去重 Bloom Filter，info 为重建前通过 GetBloomInfo 读取的信息。
+	// - 新容量为 max(当前容量, 已插入数量, 默认容量) 乘以扩容倍数。
+	// - 迁移: 原过滤器改名为 constant.PostViewBloomPrevPrefix 并保留剩余过期时间，计数脚本同时检查新旧两个过滤器，
+	//   旧过滤器在去重窗口结束后自然过期，期间已浏览过的用户不会被重复计数。
+	// - 上一次扩容的旧过滤器尚未过期，或过滤器已过期时不重建，返回 (nil, nil)。
+	RebuildBloom(ctx context.Context, postID uint64, info *ViewBloomInfo) (*ViewBloomExpansion, error)
+
+	// BatchIncrementRank 批量增加多个帖子在全站排行榜 (constant.PostsRankKey) 中的分数，供批量浏览上报后一次性刷新热榜分数。
+	// - 使用 Lua 脚本执行 ZINCRBY，每个脚本最多处理 constant.BatchIncrementRankMaxMembers 个帖子；
+	//   超出时按帖子 ID 升序拆分为多个脚本，单个脚本内的更新是原子的，脚本之间不保证原子性。
+	// - 增量为 0 的帖子被忽略。只更新排行榜分数，不修改浏览量计数器。
+	// - 某个脚本执行失败时立即返回错误，此前已执行的脚本不会回滚。
+	BatchIncrementRank(ctx context.Context, increments map[uint64]int64) error
+
+	// GetActivePostIDs 返回 since 之后被计入过浏览的帖子 ID（来自 constant.ViewLastActiveKey），按最近活跃时间倒序，最多 limit 个。
+	GetActivePostIDs(ctx context.Context, since time.Time, limit int) ([]uint64, error)
+
+	// GetPostRanks 使用 Pipeline 批量获取帖子在全站排行榜 (constant.PostsRankKey) 中的排名（0 开始，按分数降序）。
+	// - 不在排行榜中的帖子不会出现在返回的映射中。
+	GetPostRanks(ctx context.Context, postIDs []uint64) (map[uint64]int64, error)
+}
+
+// incrementViewScript 在一次 Redis 往返内完成“去重判断 + 按需创建 Bloom Filter + 加入 + 计数”。
+// - 计数器不存在（新帖或已被归档）时直接返回 -2，由调用方从 MySQL 回源初始化后重试；此时不写入 Bloom Filter，重试不会被误判为重复浏览。
+// - BF.INSERT 在过滤器不存在时按 CAPACITY/ERROR 参数自动创建，并原子地判断用户是否已存在、不存在则加入。
+// - 扩容迁移期间同时检查旧过滤器 (KEYS[6])，用户已在旧过滤器中时同样视为重复浏览；旧过滤器不存在时 BF.EXISTS 返回 0。
+// - 只有新用户才会增加帖子浏览量、更新排行榜与最近活跃时间，并累加当前分钟的全站浏览桶。
+// - Bloom Filter 过期时间：滑动模式 (ARGV[9] 为 1) 下每位新用户都会续期；固定模式下只在过滤器尚无过期时间（刚创建）时设置一次。
+// - 分钟桶使用 Redis 服务器时间 (TIME) 计算，避免多个服务实例之间的时钟偏差导致计入不同的桶。
+// - 同时把帖子加入脏集合，供增量同步任务只同步发生过变化的帖子。
+// - 登录用户携带设备成员 (ARGV[10]) 时：设备成员已在过滤器中（登录前匿名浏览过）则只补入用户、不计数；计数时同时加入设备成员，退出登录后匿名浏览不再计数。
+// - 匿名设备（设备成员即为 ARGV[1]）计数后把帖子记入设备浏览记录 (KEYS[7])，保留最新的 ARGV[11] 条，过期时间与去重窗口一致。
+// - KEYS: [1] Bloom Filter, [2] 帖子浏览量计数器, [3] 全站排行榜 ZSet, [4] 最近活跃时间 ZSet, [5] 脏集合 Set, [6] 扩容前的旧 Bloom Filter, [7] 设备浏览记录 ZSet
+// - ARGV: [1] 访客成员, [2] postID, [3] Bloom 容量, [4] Bloom 误判率, [5] Bloom 过期秒数, [6] 分钟桶 Key 前缀, [7] 分钟桶过期秒数, [8] Bloom 扩展倍数, [9] 是否滑动续期 (1/0), [10] 设备成员（可为空）, [11] 设备浏览记录上限
+// - 返回: 新的浏览量；用户已在窗口内浏览过时返回 -1；计数器需要回源时返回 -2
+// - 注意: 分钟桶 Key 在脚本内动态拼接，依赖单节点 Redis（当前使用 *redis.Client）。
+var incrementViewScript = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[2]) == 0 then
+        return -2
+    end
+    if redis.call("BF.EXISTS", KEYS[6], ARGV[1]) == 1 then
+        return -1
+    end
+    local device = ARGV[10]
+    local linkDevice = device ~= "" and device ~= ARGV[1]
+    local function insert(member)
+        local added = redis.call("BF.INSERT", KEYS[1], "CAPACITY", ARGV[3], "ERROR", ARGV[4], "EXPANSION", ARGV[8], "ITEMS", member)
+        if (ARGV[9] == "1" and tonumber(added[1]) == 1) or redis.call("TTL", KEYS[1]) < 0 then
+            redis.call("EXPIRE", KEYS[1], ARGV[5])
+        end
+        return tonumber(added[1])
+    end
+    if linkDevice and (redis.call("BF.EXISTS", KEYS[1], device) == 1 or redis.call("BF.EXISTS", KEYS[6], device) == 1) then
+        insert(ARGV[1])
+        return -1
+    end
+    if insert(ARGV[1]) == 0 then
+        return -1
+    end
+    if linkDevice then
+        insert(device)
+    end
+    local viewCount = redis.call("INCR", KEYS[2])
+    redis.call("ZADD", KEYS[3], viewCount, ARGV[2])
+    local now = redis.call("TIME")
+    redis.call("ZADD", KEYS[4], now[1], ARGV[2])
+    if device ~= "" and not linkDevice then
+        redis.call("ZADD", KEYS[7], now[1], ARGV[2])
+        redis.call("ZREMRANGEBYRANK", KEYS[7], 0, -tonumber(ARGV[11]) - 1)
+        redis.call("EXPIRE", KEYS[7], ARGV[5])
+    end
+    redis.call("SADD", KEYS[5], ARGV[2])
+    local bucketKey = ARGV[6] .. math.floor(tonumber(now[1]) / 60)
+    redis.call("INCR", bucketKey)
+    redis.call("EXPIRE", bucketKey, ARGV[7])
+    return viewCount
+`)
+
+// markViewScript 是 incrementViewScript 的去重部分：判断访客是否为窗口内的新访客并写入 Bloom Filter，不修改计数器。
+// - 去重、设备成员关联与 Bloom Filter 过期时间的处理与 incrementViewScript 完全一致。
+// - 匿名设备被判定为新访客时同样记入设备浏览记录，供登录后归并。
+// - KEYS: [1] Bloom Filter, [2] 扩容前的旧 Bloom Filter, [3] 设备浏览记录 ZSet
+// - ARGV: [1] 访客成员, [2] postID, [3] Bloom 容量, [4] Bloom 误判率, [5] Bloom 过期秒数, [6] Bloom 扩展倍数, [7] 是否滑动续期 (1/0), [8] 设备成员（可为空）, [9] 设备浏览记录上限
+// - 返回: 1 新访客，应计入浏览量；0 窗口内已浏览过
+var markViewScript = redis.NewScript(`
+    if redis.call("BF.EXISTS", KEYS[2], ARGV[1]) == 1 then
+        return 0
+    end
+    local device = ARGV[8]
+    local linkDevice = device ~= "" and device ~= ARGV[1]
+    local function insert(member)
+        local added = redis.call("BF.INSERT", KEYS[1], "CAPACITY", ARGV[3], "ERROR", ARGV[4], "EXPANSION", ARGV[6], "ITEMS", member)
+        if (ARGV[7] == "1" and tonumber(added[1]) == 1) or redis.call("TTL", KEYS[1]) < 0 then
+            redis.call("EXPIRE", KEYS[1], ARGV[5])
+        end
+        return tonumber(added[1])
+    end
+    if linkDevice and (redis.call("BF.EXISTS", KEYS[1], device) == 1 or redis.call("BF.EXISTS", KEYS[2], device) == 1) then
+        insert(ARGV[1])
+        return 0
+    end
+    if insert(ARGV[1]) == 0 then
+        return 0
+    end
+    if linkDevice then
+        insert(device)
+    end
+    if device ~= "" and not linkDevice then
+        local now = redis.call("TIME")
+        redis.call("ZADD", KEYS[3], now[1], ARGV[2])
+        redis.call("ZREMRANGEBYRANK", KEYS[3], 0, -tonumber(ARGV[9]) - 1)
+        redis.call("EXPIRE", KEYS[3], ARGV[5])
+    end
+    return 1
+`)
+
+// incrementViewByScript 是 incrementViewScript 的计数部分，一次计入 ARGV[2] 次已去重的浏览。
+// - 计数器不存在时返回 -2，由调用方从 MySQL 回源初始化后重试。
+// - 排行榜分数与 incrementViewScript 一致，直接设为计数器的新值。
+// - KEYS: [1] 帖子浏览量计数器, [2] 全站排行榜 ZSet, [3] 最近活跃时间 ZSet, [4] 脏集合 Set
+// - ARGV: [1] postID, [2] 浏览增量, [3] 分钟桶 Key 前缀, [4] 分钟桶过期秒数
+// - 返回: 新的浏览量；计数器需要回源时返回 -2
+// - 注意: 分钟桶 Key 在脚本内动态拼接，依赖单节点 Redis（当前使用 *redis.Client）。
+var incrementViewByScript = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return -2
+    end
+    local viewCount = redis.call("INCRBY", KEYS[1], ARGV[2])
+    redis.call("ZADD", KEYS[2], viewCount, ARGV[1])
+    local now = redis.call("TIME")
+    redis.call("ZADD", KEYS[3], now[1], ARGV[1])
+    redis.call("SADD", KEYS[4], ARGV[1])
+    local bucketKey = ARGV[3] .. math.floor(tonumber(now[1]) / 60)
+    redis.call("INCRBY", bucketKey, ARGV[2])
+    redis.call("EXPIRE", bucketKey, ARGV[4])
+    return viewCount
+`)
+
+// mergeAnonymousViewsScript 将设备浏览记录中的帖子逐个补入登录用户的去重成员，不增加浏览量。
+// - 先丢弃早于去重窗口的记录；过滤器已过期（不存在）的帖子跳过，BF.ADD 不会为其创建新的过滤器。
+// - 归并完成后删除设备浏览记录。
+// - KEYS: [1] 设备浏览记录 ZSet
+// - ARGV: [1] userID, [2] Bloom Filter Key 前缀, [3] 记录的最早有效时间 (Unix 秒)
+// - 返回: 新补入用户的帖子数量
+// - 注意: Bloom Filter Key 在脚本内动态拼接，依赖单节点 Redis（当前使用 *redis.Client）。
+var mergeAnonymousViewsScript = redis.NewScript(`
+    redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", "(" .. ARGV[3])
+    local postIDs = redis.call("ZRANGE", KEYS[1], 0, -1)
+    local merged = 0
+    for _, postID in ipairs(postIDs) do
+        local bloomKey = ARGV[2] .. postID
+        if redis.call("EXISTS", bloomKey) == 1 and redis.call("BF.ADD", bloomKey, ARGV[1]) == 1 then
+            merged = merged + 1
+        end
+    end
+    redis.call("DEL", KEYS[1])
+    return merged
+`)
+
+// archiveViewCountScript 在计数器未发生变化、且期间没有新浏览时删除计数器并移除其活跃记录。
+// - KEYS: [1] 帖子浏览量计数器, [2] 最近活跃时间 ZSet
+// - ARGV: [1] 归档时读取到的浏览量, [2] postID, [3] 冷数据截止时间 (Unix 秒)
+// - 返回: 1 表示已删除；0 表示归档期间有新浏览，计数器被保留
+var archiveViewCountScript = redis.NewScript(`
+    if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+        return 0
+    end
+    local lastActive = redis.call("ZSCORE", KEYS[2], ARGV[2])
+    if lastActive and tonumber(lastActive) > tonumber(ARGV[3]) then
+        return 0
+    end
+    redis.call("DEL", KEYS[1])
+    redis.call("ZREM", KEYS[2], ARGV[2])
+    return 1
+`)
+
+// claimDirtyViewCountsScript 把脏集合转入同步中集合，返回同步中集合的成员数量。
+// - 同步中集合不存在时直接 RENAME；存在（上一轮同步失败）时合并后删除脏集合。
+// - KEYS: [1] 脏集合, [2] 同步中集合
+var claimDirtyViewCountsScript = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 1 then
+        if redis.call("EXISTS", KEYS[2]) == 1 then
+            redis.call("SUNIONSTORE", KEYS[2], KEYS[2], KEYS[1])
+            redis.call("DEL", KEYS[1])
+        else
+            redis.call("RENAME", KEYS[1], KEYS[2])
+        end
+    end
+    return redis.call("SCARD", KEYS[2])
+`)
+
+// ViewerIdentity 是浏览去重使用的访客标识。
+// - 登录用户以 userID 为主键去重，同一用户在不同设备上的浏览只计一次。
+// - 未登录时使用稳定的设备标识，没有设备标识时退回客户端 IP。
+type ViewerIdentity struct {
+	// Member 去重过滤器的主成员：登录用户为 userID，匿名访客为 constant.AnonymousViewerPrefix 开头的客户端标识。
+	Member string
+	// DeviceMember 当前设备的匿名成员 ("anon:device:{设备ID}")，请求没有设备标识时为空。
+	// - 登录用户携带设备标识时与 Member 一起参与去重，登录前后在同一设备上的浏览只计一次。
+	DeviceMember string
 }
 
 // postViewRepository 是 PostViewRepository 接口的 Redis 实现。
 type postViewRepository struct {
-	redisClient       *redis.Client         // Redis 客户端实例
-	logger            *core.ZapLogger       // 日志记录器实例
-	viewSyncCfg       config.ViewSyncConfig // 新增：用于存储浏览量同步相关的配置，包括 ScanBatchSize
-	bloomFilterSize   int64                 // Bloom Filter 配置: 预期容量
-	bloomFilterHashes uint                  // Bloom Filter 配置: 哈希函数数量 (影响精度和空间)
-	bloomErrorRate    float64               // Bloom Filter 配置: 可接受的误判率
+	redisClient       *redis.Client                       // Redis 客户端实例
+	postBatch         mysql.PostBatchOperationsRepository // MySQL 批量操作仓库，用于浏览量计数器的回源与归档
+	logger            *core.ZapLogger                     // 日志记录器实例
+	viewSyncCfg       config.ViewSyncConfig               // 新增：用于存储浏览量同步相关的配置，包括 ScanBatchSize
+	dedupWindow       time.Duration                       // 默认的浏览去重窗口 (Bloom Filter 过期时间)
+	slidingExpire     bool                                // 是否每位新访客都续期 Bloom Filter 过期时间 (滑动窗口)
+	countAnonymous    bool                                // 是否统计匿名访客 (constant.AnonymousViewerPrefix 开头的成员) 的浏览
+	bloomFilterSize   int64                               // Bloom Filter 配置: 预期容量
+	bloomFilterHashes uint                                // Bloom Filter 配置: 哈希函数数量 (影响精度和空间)
+	bloomErrorRate    float64                             // Bloom Filter 配置: 可接受的误判率
+	bloomExpansion    int                                 // Bloom Filter 配置: 扩容倍数 (RedisBloom EXPANSION)
+
+	staticWhitelist []string                            // 配置文件中的浏览量白名单
+	whitelist       atomic.Pointer[map[string]struct{}] // 当前生效的白名单（配置 + Redis），整体替换以支持热更新
 }
 
 // NewPostViewRepository 创建 PostViewRepository 实例。
 // - 通过依赖注入传入 redisClient 和 logger。
 // - Bloom Filter 相关参数也在此设置。
-func NewPostViewRepository(redisClient *redis.Client, logger *core.ZapLogger, bloomFilterSize int64, bloomFilterHashes uint, bloomErrorRate float64, viewSyncCfg config.ViewSyncConfig) PostViewRepository { // 添加 logger 参数
-	return &postViewRepository{
+// - viewCountCfg.DedupWindow 未配置时使用 constant.BloomViewTTL 作为默认去重窗口。
+// - viewCountCfg.BloomExpansion 未配置时退回 constant.BloomExpansionFactor。
+// - postBatch 用于已归档计数器的回源与冷数据归档写入。
+func NewPostViewRepository(redisClient *redis.Client, postBatch mysql.PostBatchOperationsRepository, logger *core.ZapLogger, bloomFilterSize int64, bloomFilterHashes uint, bloomErrorRate float64, viewSyncCfg config.ViewSyncConfig, viewCountCfg config.ViewCountConfig) PostViewRepository { // 添加 logger 参数
+	dedupWindow := viewCountCfg.DedupWindow
+	if dedupWindow <= 0 {
+		dedupWindow = constant.BloomViewTTL
+	}
+	bloomExpansion := viewCountCfg.BloomExpansion
+	if bloomExpansion <= 0 {
+		bloomExpansion = constant.BloomExpansionFactor
+	}
+	repo := &postViewRepository{
 		redisClient:       redisClient,
+		postBatch:         postBatch,
 		logger:            logger,      // 初始化 logger
 		viewSyncCfg:       viewSyncCfg, // 存储配置
+		dedupWindow:       dedupWindow,
+		slidingExpire:     viewCountCfg.SlidingExpire,
+		countAnonymous:    viewCountCfg.CountAnonymousViews,
 		bloomFilterSize:   bloomFilterSize,
 		bloomFilterHashes: bloomFilterHashes,
 		bloomErrorRate:    bloomErrorRate,
+		bloomExpansion:    bloomExpansion,
+		staticWhitelist:   viewCountCfg.Whitelist,
+	}
+	// 在首次从 Redis 加载之前，先让配置中的白名单生效
+	repo.storeWhitelist(nil)
+	return repo
+}
+
+// storeWhitelist 合并配置白名单与 Redis 白名单，并原子地替换当前生效的白名单。
+func (r *postViewRepository) storeWhitelist(redisMembers []string) int {
+	whitelist := make(map[string]struct{}, len(r.staticWhitelist)+len(redisMembers))
+	for _, lists := range [][]string{r.staticWhitelist, redisMembers} {
+		for _, userID := range lists {
+			if userID = strings.TrimSpace(userID); userID != "" {
+				whitelist[userID] = struct{}{}
+			}
+		}
+	}
+	r.whitelist.Store(&whitelist)
+	return len(whitelist)
+}
+
+// isWhitelisted 判断用户是否在浏览量防刷白名单中（只读本地内存）。
+func (r *postViewRepository) isWhitelisted(userID string) bool {
+	whitelist := r.whitelist.Load()
+	if whitelist == nil || len(*whitelist) == 0 {
+		return false
+	}
+	_, ok := (*whitelist)[userID]
+	return ok
+}
+
+// RefreshViewWhitelist 实现白名单的热加载。
+func (r *postViewRepository) RefreshViewWhitelist(ctx context.Context) (int, error) {
+	members, err := r.redisClient.SMembers(ctx, constant.ViewWhitelistKey).Result()
+	if err != nil {
+		r.logger.Error("从 Redis 加载浏览量白名单失败，继续使用旧白名单", zap.Error(err), zap.String("key", constant.ViewWhitelistKey))
+		return 0, fmt.Errorf("加载浏览量白名单失败: %w", err)
 	}
+	count := r.storeWhitelist(members)
+	r.logger.Debug("浏览量白名单已刷新", zap.Int("count", count))
+	return count, nil
 }
 
-// IncrementViewCount 实现增加帖子浏览量的逻辑。
+// IncrementViewCount 实现增加帖子浏览量的逻辑，使用配置的默认去重窗口。
+func (r *postViewRepository) IncrementViewCount(ctx context.Context, postID uint64, viewer ViewerIdentity) error {
+	return r.IncrementViewCountWithTTL(ctx, postID, viewer, 0)
+}
+
+// IncrementViewCountWithTTL 实现增加帖子浏览量的逻辑。
 // 核心功能：使用 Bloom Filter 防止用户短时间内重复刷量，并原子性地增加帖子浏览数及更新其在排行榜中的分数。
-func (r *postViewRepository) IncrementViewCount(ctx context.Context, postID uint64, userID string) error {
+func (r *postViewRepository) IncrementViewCountWithTTL(ctx context.Context, postID uint64, viewer ViewerIdentity, ttl time.Duration) error {
+	userID := viewer.Member
+	if r.skipViewer(ctx, postID, userID) {
+		return nil
+	}
+
+	if ttl <= 0 {
+		ttl = r.dedupWindow
+	}
+
 	// 1. 构造 Redis Key
 	bloomKey := fmt.Sprintf("%s%d", constant.PostViewBloomPrefix, postID)
+	prevBloomKey := fmt.Sprintf("%s%d", constant.PostViewBloomPrevPrefix, postID)
 	viewCountKey := fmt.Sprintf("%s%d", constant.PostViewCountPrefix, postID)
 	postsRankKey := constant.PostsRankKey
+	deviceViewsKey := anonymousDeviceViewsKey(viewer.DeviceMember)
 
-	// 2. 确保 Bloom Filter 已按需创建
-	// 直接调用 BF.RESERVE。
-	// 如果过滤器已存在，BF.RESERVE 可能会返回 "ERR item exists"，我们将其视为正常情况。
-	if err := r.redisClient.BFReserve(ctx, bloomKey, r.bloomErrorRate, r.bloomFilterSize).Err(); err != nil {
-		// 检查错误消息是否明确指示 "item exists"。
-		if err != nil && strings.Contains(err.Error(), "ERR item exists") {
-			r.logger.Debug("尝试创建 Bloom Filter 时发现其已存在 (此为正常情况)",
-				zap.String("bloomKey", bloomKey),
-				zap.String("originalError", err.Error()),
-			)
-		} else {
-			// 对于其他类型的 BF.RESERVE 错误，则认为是真正的失败。
-			r.logger.Error("创建或调整 Bloom Filter 失败", zap.Error(err), zap.String("bloomKey", bloomKey))
-			return fmt.Errorf("创建或调整 Bloom Filter '%s' 失败: %w", bloomKey, err)
+	// 2. 单次 Lua 脚本完成去重与计数（每次浏览只有一次 Redis 往返）
+	//    Bloom Filter 的创建由 BF.INSERT 按需完成，不再每次调用 BF.RESERVE。
+	slidingExpireArg := 0
+	if r.slidingExpire {
+		slidingExpireArg = 1
+	}
+	runScript := func() (int64, error) {
+		return incrementViewScript.Run(ctx, r.redisClient,
+			[]string{bloomKey, viewCountKey, postsRankKey, constant.ViewLastActiveKey, constant.DirtyViewCountsKey, prevBloomKey, deviceViewsKey},
+			userID,
+			postID,
+			r.bloomFilterSize,
+			r.bloomErrorRate,
+			int64(ttl/time.Second),
+			constant.GlobalViewBucketPrefix,
+			int64(constant.ViewBucketTTL/time.Second),
+			r.bloomExpansion,
+			slidingExpireArg,
+			viewer.DeviceMember,
+			constant.AnonymousDeviceViewsMaxPosts,
+		).Int64()
+	}
+	result, err := runScript()
+	// 2.1 计数器不存在（新帖或冷数据已归档）：从 MySQL 回源初始化后重试一次
+	if err == nil && result == -2 {
+		if err = r.seedViewCount(ctx, postID, viewCountKey); err == nil {
+			result, err = runScript()
+		}
+		if err == nil && result == -2 {
+			err = fmt.Errorf("回源初始化后浏览量计数器仍不存在")
 		}
-	} else {
-		r.logger.Info("Bloom Filter 已确保存在/已创建", zap.String("bloomKey", bloomKey))
 	}
-
-	// 3. 使用 Bloom Filter 判断用户是否已浏览 (防刷核心)
-	userExists, err := r.redisClient.BFExists(ctx, bloomKey, userID).Result()
 	if err != nil {
-		r.logger.Error("检查用户是否在 Bloom Filter 中时出错", zap.Error(err), zap.String("bloomKey", bloomKey), zap.String("userID", userID))
-		return fmt.Errorf("检查 Bloom Filter 出错 ('%s', '%s'): %w", bloomKey, userID, err)
+		r.logger.Error("Lua 脚本执行失败：浏览去重与计数", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID))
+		return fmt.Errorf("原子性增加浏览量失败 (PostID: %d): %w", postID, err)
 	}
-	if userExists {
+
+	if result < 0 {
 		r.logger.Debug("用户已在 Bloom Filter 中，跳过计数", zap.String("bloomKey", bloomKey), zap.String("userID", userID), zap.Uint64("postID", postID))
 		return nil
 	}
 
-	// 4. 将用户添加到 Bloom Filter 并设置/刷新过期时间
-	_, err = r.redisClient.BFAdd(ctx, bloomKey, userID).Result()
+	r.logger.Debug("成功增加浏览量并更新排名", zap.Uint64("postID", postID), zap.Int64("viewCount", result))
+	return nil
+}
+
+// skipViewer 判断访客的浏览是否不参与计数：未开启匿名统计时的匿名访客，以及白名单用户。
+// - 白名单用户（内部测试、运营账号）不计入真实浏览量，只单独记录被跳过的次数。
+func (r *postViewRepository) skipViewer(ctx context.Context, postID uint64, userID string) bool {
+	if !r.countAnonymous && strings.HasPrefix(userID, constant.AnonymousViewerPrefix) {
+		return true
+	}
+	if !r.isWhitelisted(userID) {
+		return false
+	}
+	if err := r.redisClient.HIncrBy(ctx, constant.ViewWhitelistSkippedKey, strconv.FormatUint(postID, 10), 1).Err(); err != nil {
+		r.logger.Warn("记录白名单跳过的浏览量失败", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID))
+	}
+	r.logger.Debug("白名单用户浏览，跳过计数", zap.Uint64("postID", postID), zap.String("userID", userID))
+	return true
+}
+
+// MarkViewed 实现浏览去重判断，不修改浏览量计数器。
+func (r *postViewRepository) MarkViewed(ctx context.Context, postID uint64, viewer ViewerIdentity, ttl time.Duration) (bool, error) {
+	userID := viewer.Member
+	if r.skipViewer(ctx, postID, userID) {
+		return false, nil
+	}
+	if ttl <= 0 {
+		ttl = r.dedupWindow
+	}
+	slidingExpireArg := 0
+	if r.slidingExpire {
+		slidingExpireArg = 1
+	}
+	bloomKey := fmt.Sprintf("%s%d", constant.PostViewBloomPrefix, postID)
+	prevBloomKey := fmt.Sprintf("%s%d", constant.PostViewBloomPrevPrefix, postID)
+	counted, err := markViewScript.Run(ctx, r.redisClient,
+		[]string{bloomKey, prevBloomKey, anonymousDeviceViewsKey(viewer.DeviceMember)},
+		userID,
+		postID,
+		r.bloomFilterSize,
+		r.bloomErrorRate,
+		int64(ttl/time.Second),
+		r.bloomExpansion,
+		slidingExpireArg,
+		viewer.DeviceMember,
+		constant.AnonymousDeviceViewsMaxPosts,
+	).Int64()
 	if err != nil {
-		r.logger.Error("添加用户到 Bloom Filter 失败", zap.Error(err), zap.String("bloomKey", bloomKey), zap.String("userID", userID))
-		return fmt.Errorf("添加用户到 Bloom Filter '%s' 失败: %w", bloomKey, err)
+		r.logger.Error("Lua 脚本执行失败：浏览去重", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID))
+		return false, fmt.Errorf("浏览去重失败 (PostID: %d): %w", postID, err)
 	}
+	return counted == 1, nil
+}
 
-	// 确保 Bloom Filter 有过期时间，定义防刷窗口，并刷新它。
-	// 直接使用 constant.BloomViewTTL。
-	// 确保 constant.BloomViewTTL 在您的 constant 包中已定义为 time.Duration 类型。
-	// 例如: const BloomViewTTL time.Duration = 12 * time.Hour
-	if err := r.redisClient.Expire(ctx, bloomKey, constant.BloomViewTTL).Err(); err != nil { // 直接使用常量
-		r.logger.Warn("设置 Bloom Filter 过期时间失败，但不中断计数", zap.Error(err), zap.String("bloomKey", bloomKey))
+// IncrementViewCountBy 实现批量计入已去重的浏览。
+func (r *postViewRepository) IncrementViewCountBy(ctx context.Context, postID uint64, delta int64) error {
+	if delta <= 0 {
+		return nil
+	}
+	viewCountKey := fmt.Sprintf("%s%d", constant.PostViewCountPrefix, postID)
+	runScript := func() (int64, error) {
+		return incrementViewByScript.Run(ctx, r.redisClient,
+			[]string{viewCountKey, constant.PostsRankKey, constant.ViewLastActiveKey, constant.DirtyViewCountsKey},
+			postID,
+			delta,
+			constant.GlobalViewBucketPrefix,
+			int64(constant.ViewBucketTTL/time.Second),
+		).Int64()
 	}
+	result, err := runScript()
+	// 计数器不存在（新帖或冷数据已归档）：从 MySQL 回源初始化后重试一次
+	if err == nil && result == -2 {
+		if err = r.seedViewCount(ctx, postID, viewCountKey); err == nil {
+			result, err = runScript()
+		}
+		if err == nil && result == -2 {
+			err = fmt.Errorf("回源初始化后浏览量计数器仍不存在")
+		}
+	}
+	if err != nil {
+		r.logger.Error("Lua 脚本执行失败：批量计入浏览量", zap.Error(err), zap.Uint64("postID", postID), zap.Int64("delta", delta))
+		return fmt.Errorf("批量增加浏览量失败 (PostID: %d): %w", postID, err)
+	}
+	r.logger.Debug("成功批量增加浏览量并更新排名", zap.Uint64("postID", postID), zap.Int64("delta", delta), zap.Int64("viewCount", result))
+	return nil
+}
 
-	// 5. 原子性增加浏览量并更新排行榜 (Lua 脚本)
-	luaScript := redis.NewScript(`
-        local viewCount = redis.call("INCR", KEYS[1])
-        redis.call("ZADD", KEYS[2], viewCount, ARGV[1])
-        return viewCount
-    `)
+// anonymousDeviceViewsKey 返回设备浏览记录的 Key，设备成员为空时返回前缀本身（脚本不会写入）。
+func anonymousDeviceViewsKey(deviceMember string) string {
+	return constant.AnonymousDeviceViewsPrefix + strings.TrimPrefix(deviceMember, constant.AnonymousViewerPrefix+constant.AnonymousDeviceClientPrefix)
+}
 
-	_, err = luaScript.Run(ctx, r.redisClient, []string{viewCountKey, postsRankKey}, postID).Result()
+// MergeAnonymousViews 实现设备匿名浏览记录到登录用户的归并。
+func (r *postViewRepository) MergeAnonymousViews(ctx context.Context, userID string, deviceMember string) (int, error) {
+	if userID == "" || deviceMember == "" {
+		return 0, nil
+	}
+	now, err := r.redisClient.Time(ctx).Result()
 	if err != nil {
-		r.logger.Error("Lua 脚本执行失败：增加浏览量和更新排名", zap.Error(err), zap.Uint64("postID", postID))
-		return fmt.Errorf("原子性增加浏览量失败 (PostID: %d): %w", postID, err)
+		return 0, fmt.Errorf("获取 Redis 服务器时间失败: %w", err)
 	}
+	deviceViewsKey := anonymousDeviceViewsKey(deviceMember)
+	merged, err := mergeAnonymousViewsScript.Run(ctx, r.redisClient,
+		[]string{deviceViewsKey},
+		userID,
+		constant.PostViewBloomPrefix,
+		now.Add(-r.dedupWindow).Unix(),
+	).Int()
+	if err != nil {
+		r.logger.Error("Lua 脚本执行失败：归并设备匿名浏览记录", zap.Error(err), zap.String("userID", userID), zap.String("key", deviceViewsKey))
+		return 0, fmt.Errorf("归并设备匿名浏览记录失败: %w", err)
+	}
+	r.logger.Debug("设备匿名浏览记录已归并到用户", zap.String("userID", userID), zap.String("key", deviceViewsKey), zap.Int("merged", merged))
+	return merged, nil
+}
 
-	r.logger.Debug("成功增加浏览量并更新排名", zap.Uint64("postID", postID))
+// seedViewCount 从 MySQL 读取帖子已持久化的浏览量，初始化 Redis 计数器。
+// - 使用 SETNX，并发回源时只有第一个写入生效，不会覆盖其间已经产生的计数。
+// - MySQL 中不存在的帖子（例如刚创建尚未可见）按 0 初始化。
+func (r *postViewRepository) seedViewCount(ctx context.Context, postID uint64, viewCountKey string) error {
+	viewCounts, err := r.postBatch.GetPostViewCounts(ctx, []uint64{postID})
+	if err != nil {
+		return fmt.Errorf("回源获取帖子浏览量失败: %w", err)
+	}
+	if err := r.redisClient.SetNX(ctx, viewCountKey, viewCounts[postID], 0).Err(); err != nil {
+		return fmt.Errorf("初始化浏览量计数器失败: %w", err)
+	}
+	r.logger.Debug("浏览量计数器已从 MySQL 回源初始化", zap.Uint64("postID", postID), zap.Int64("viewCount", viewCounts[postID]))
 	return nil
 }
 
+// GetViewsInWindow 实现最近 N 分钟全站浏览量的聚合。
+func (r *postViewRepository) GetViewsInWindow(ctx context.Context, minutes int) (int64, error) {
+	if minutes <= 0 {
+		minutes = 1
+	}
+	if minutes > constant.ViewBucketRetentionMinutes {
+		minutes = constant.ViewBucketRetentionMinutes
+	}
+
+	// 1. 使用 Redis 服务器时间确定当前分钟，避免本机与 Redis 之间的时钟偏差
+	now, err := r.redisClient.Time(ctx).Result()
+	if err != nil {
+		r.logger.Error("获取 Redis 服务器时间失败", zap.Error(err))
+		return 0, fmt.Errorf("获取 Redis 服务器时间失败: %w", err)
+	}
+	currentMinute := now.Unix() / 60
+
+	// 2. 拼接窗口内所有分钟桶的 Key，MGET 批量读取
+	keys := make([]string, 0, minutes)
+	for i := 0; i < minutes; i++ {
+		keys = append(keys, constant.GlobalViewBucketPrefix+strconv.FormatInt(currentMinute-int64(i), 10))
+	}
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Error("批量获取分钟浏览桶失败", zap.Error(err), zap.Int("minutes", minutes))
+		return 0, fmt.Errorf("批量获取分钟浏览桶失败: %w", err)
+	}
+
+	// 3. 累加，不存在的桶（该分钟无浏览或已过期）视为 0
+	var total int64
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok || str == "" {
+			continue
+		}
+		count, parseErr := strconv.ParseInt(str, 10, 64)
+		if parseErr != nil {
+			r.logger.Warn("解析分钟浏览桶的值失败，按 0 处理", zap.String("key", keys[i]), zap.String("value", str))
+			continue
+		}
+		total += count
+	}
+	return total, nil
+}
+
 // GetAllViewCounts 使用 SCAN 命令安全地迭代并获取所有帖子的浏览量。
 // 此方法主要用于定时任务，将 Redis 中的全量浏览数据同步到持久化存储（如 MySQL）。
 func (r *postViewRepository) GetAllViewCounts(ctx context.Context) (map[uint64]int64, error) {
@@ -229,3 +737,306 @@ func (r *postViewRepository) GetAllViewCounts(ctx context.Context) (map[uint64]i
 	)
 	return viewCounts, nil
 }
+
+// GetDirtyViewCounts 实现增量同步的数据获取：认领脏集合后用 SSCAN 分批读取成员，并 MGET 其浏览量。
+func (r *postViewRepository) GetDirtyViewCounts(ctx context.Context) (map[uint64]int64, error) {
+	// 1. 原子地认领脏集合；此后的新浏览写入新的脏集合，留到下一轮同步
+	total, err := claimDirtyViewCountsScript.Run(ctx, r.redisClient,
+		[]string{constant.DirtyViewCountsKey, constant.DirtyViewCountsSyncingKey}).Int64()
+	if err != nil {
+		r.logger.Error("认领浏览量脏集合失败", zap.Error(err))
+		return nil, fmt.Errorf("认领浏览量脏集合失败: %w", err)
+	}
+	viewCounts := make(map[uint64]int64, total)
+	if total == 0 {
+		return viewCounts, nil
+	}
+
+	scanCount := r.viewSyncCfg.ScanBatchSize
+	if scanCount <= 0 {
+		scanCount = 1000
+	}
+	r.logger.Info("开始读取浏览量脏集合", zap.Int64("dirty_posts", total), zap.Int64("scan_batch_size", scanCount))
+	startTime := time.Now()
+
+	// 2. SSCAN 分批读取同步中集合，逐批 MGET 当前浏览量
+	var cursor uint64
+	for {
+		members, nextCursor, err := r.redisClient.SScan(ctx, constant.DirtyViewCountsSyncingKey, cursor, "", scanCount).Result()
+		if err != nil {
+			r.logger.Error("执行 Redis SSCAN 读取脏集合失败", zap.Error(err), zap.Uint64("cursor", cursor))
+			return nil, fmt.Errorf("读取浏览量脏集合失败: %w", err)
+		}
+		if err := r.collectViewCounts(ctx, members, viewCounts); err != nil {
+			return nil, err
+		}
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	r.logger.Info("完成读取浏览量脏集合",
+		zap.Int64("dirty_posts", total),
+		zap.Int("posts_to_sync", len(viewCounts)),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return viewCounts, nil
+}
+
+// collectViewCounts 批量读取一批帖子的浏览量计数器并写入 viewCounts。
+// - 计数器不存在（已被归档删除）的帖子跳过，无法解析的成员或值记录日志后跳过。
+func (r *postViewRepository) collectViewCounts(ctx context.Context, members []string, viewCounts map[uint64]int64) error {
+	if len(members) == 0 {
+		return nil
+	}
+	postIDs := make([]uint64, 0, len(members))
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		postID, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			r.logger.Error("脏集合中的成员不是合法的 PostID，已跳过", zap.String("member", member))
+			continue
+		}
+		postIDs = append(postIDs, postID)
+		keys = append(keys, constant.PostViewCountPrefix+member)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Error("执行 Redis MGET 批量获取帖子浏览量失败", zap.Error(err), zap.Int("keys", len(keys)))
+		return fmt.Errorf("批量获取浏览量值失败 (%d keys): %w", len(keys), err)
+	}
+	for i, value := range values {
+		valueStr, ok := value.(string)
+		if !ok || valueStr == "" {
+			// 计数器已被归档到 MySQL 并删除，无需再同步
+			continue
+		}
+		viewCount, parseErr := strconv.ParseInt(valueStr, 10, 64)
+		if parseErr != nil {
+			r.logger.Error("解析 Redis 中的浏览量值失败，已跳过", zap.Error(parseErr), zap.String("key", keys[i]), zap.String("value_str", valueStr))
+			continue
+		}
+		viewCounts[postIDs[i]] = viewCount
+	}
+	return nil
+}
+
+// AckDirtyViewCounts 实现同步成功后的脏标记清理。
+func (r *postViewRepository) AckDirtyViewCounts(ctx context.Context) error {
+	if err := r.redisClient.Del(ctx, constant.DirtyViewCountsSyncingKey).Err(); err != nil {
+		r.logger.Error("删除浏览量同步中集合失败，下一轮将重复同步这些帖子", zap.Error(err))
+		return fmt.Errorf("删除浏览量同步中集合失败: %w", err)
+	}
+	return nil
+}
+
+// GetViewCountsByIDs 实现指定帖子浏览量的批量读取。
+func (r *postViewRepository) GetViewCountsByIDs(ctx context.Context, postIDs []uint64) (map[uint64]int64, error) {
+	viewCounts := make(map[uint64]int64, len(postIDs))
+	members := make([]string, 0, len(postIDs))
+	for _, postID := range postIDs {
+		members = append(members, strconv.FormatUint(postID, 10))
+	}
+	if err := r.collectViewCounts(ctx, members, viewCounts); err != nil {
+		return nil, err
+	}
+	return viewCounts, nil
+}
+
+// GetViewCount 实现单个帖子实时浏览量的读取。
+func (r *postViewRepository) GetViewCount(ctx context.Context, postID uint64) (int64, error) {
+	viewCountKey := fmt.Sprintf("%s%d", constant.PostViewCountPrefix, postID)
+	viewCount, err := r.redisClient.Get(ctx, viewCountKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		r.logger.Warn("读取帖子实时浏览量失败", zap.Error(err), zap.Uint64("postID", postID))
+		return 0, fmt.Errorf("读取帖子(ID: %d)实时浏览量失败: %w", postID, err)
+	}
+	return viewCount, nil
+}
+
+// GetPendingSyncPostIDs 实现待同步帖子的判断，两个集合的 SMISMEMBER 在一次管道往返内完成。
+func (r *postViewRepository) GetPendingSyncPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]bool, error) {
+	pending := make(map[uint64]bool)
+	if len(postIDs) == 0 {
+		return pending, nil
+	}
+	members := make([]interface{}, 0, len(postIDs))
+	for _, postID := range postIDs {
+		members = append(members, strconv.FormatUint(postID, 10))
+	}
+
+	pipe := r.redisClient.Pipeline()
+	dirtyCmd := pipe.SMIsMember(ctx, constant.DirtyViewCountsKey, members...)
+	syncingCmd := pipe.SMIsMember(ctx, constant.DirtyViewCountsSyncingKey, members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Error("批量判断帖子是否待同步失败", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return nil, fmt.Errorf("批量判断帖子是否待同步失败: %w", err)
+	}
+	dirty, syncing := dirtyCmd.Val(), syncingCmd.Val()
+	for i, postID := range postIDs {
+		if dirty[i] || syncing[i] {
+			pending[postID] = true
+		}
+	}
+	return pending, nil
+}
+
+// ArchiveColdViewCounts 实现冷数据浏览量计数器的归档。
+// - 按最近活跃时间从旧到新分页扫描冷帖子；热榜中的帖子跳过保留，游标越过它们继续向后扫描。
+// - 已归档的帖子会从活跃 ZSet 中移除，因此下一页的起点只需要跳过本页保留下来的帖子。
+func (r *postViewRepository) ArchiveColdViewCounts(ctx context.Context, coldThreshold time.Duration, batchSize int) (int, error) {
+	if coldThreshold <= 0 {
+		coldThreshold = constant.ViewColdThreshold
+	}
+	if batchSize <= 0 {
+		batchSize = constant.ViewArchiveBatchSize
+	}
+
+	// 1. 以 Redis 服务器时间计算冷数据截止时间，与写入活跃时间的 Lua 脚本保持一致
+	now, err := r.redisClient.Time(ctx).Result()
+	if err != nil {
+		return 0, fmt.Errorf("获取 Redis 服务器时间失败: %w", err)
+	}
+	cutoff := strconv.FormatInt(now.Add(-coldThreshold).Unix(), 10)
+
+	archived := 0
+	var offset int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+
+		// 2. 取一页冷帖子
+		members, err := r.redisClient.ZRangeByScore(ctx, constant.ViewLastActiveKey, &redis.ZRangeBy{
+			Min:    "-inf",
+			Max:    cutoff,
+			Offset: offset,
+			Count:  int64(batchSize),
+		}).Result()
+		if err != nil {
+			return archived, fmt.Errorf("获取冷数据帖子列表失败: %w", err)
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		// 3. 归档本页，保留下来的成员仍在 ZSet 中，下一页需要越过它们
+		n, kept, err := r.archiveViewCountPage(ctx, members, cutoff)
+		archived += n
+		if err != nil {
+			return archived, err
+		}
+		offset += int64(kept)
+		if len(members) < batchSize {
+			break
+		}
+	}
+
+	r.logger.Info("浏览量冷数据归档完成", zap.Int("archived", archived), zap.Duration("coldThreshold", coldThreshold))
+	return archived, nil
+}
+
+// archiveViewCountPage 归档一页冷帖子的浏览量计数器。
+// - 返回删除的计数器数量，以及仍保留在活跃 ZSet 中的成员数量（热榜帖子、归档期间有新浏览的帖子等）。
+func (r *postViewRepository) archiveViewCountPage(ctx context.Context, members []string, cutoff string) (int, int, error) {
+	// 1. 跳过热榜中的帖子（ZMSCORE 对不存在的成员返回 0）
+	hotScores, err := r.redisClient.ZMScore(ctx, constant.HotPostsRankKey, members...).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("检查帖子是否在热榜中失败: %w", err)
+	}
+	kept := 0
+	candidates := make([]string, 0, len(members))
+	for i, member := range members {
+		if i < len(hotScores) && hotScores[i] != 0 {
+			kept++
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+	if len(candidates) == 0 {
+		return 0, kept, nil
+	}
+
+	// 2. 读取计数器当前值
+	keys := make([]string, len(candidates))
+	for i, member := range candidates {
+		keys[i] = constant.PostViewCountPrefix + member
+	}
+	values, err := r.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, kept, fmt.Errorf("批量获取冷数据浏览量失败: %w", err)
+	}
+
+	viewCounts := make(map[uint64]int64, len(candidates))
+	rawValues := make(map[uint64]string, len(candidates))
+	var orphans []interface{}
+	for i, member := range candidates {
+		postID, parseErr := strconv.ParseUint(member, 10, 64)
+		str, ok := values[i].(string)
+		if parseErr != nil || !ok {
+			// 成员无法解析或计数器已不存在，活跃记录已无意义，直接移除
+			orphans = append(orphans, member)
+			continue
+		}
+		count, parseErr := strconv.ParseInt(str, 10, 64)
+		if parseErr != nil {
+			r.logger.Warn("冷数据浏览量值无法解析，跳过归档", zap.String("key", keys[i]), zap.String("value", str))
+			kept++
+			continue
+		}
+		viewCounts[postID] = count
+		rawValues[postID] = str
+	}
+	if len(orphans) > 0 {
+		if err := r.redisClient.ZRem(ctx, constant.ViewLastActiveKey, orphans...).Err(); err != nil {
+			r.logger.Warn("移除无效的浏览活跃记录失败", zap.Error(err), zap.Int("count", len(orphans)))
+			kept += len(orphans)
+		}
+	}
+	if len(viewCounts) == 0 {
+		return 0, kept, nil
+	}
+
+	// 3. 先写入 MySQL，写入失败时不删除任何计数器
+	if err := r.postBatch.ArchivePostViewCounts(ctx, viewCounts); err != nil {
+		return 0, kept, fmt.Errorf("归档浏览量到 MySQL 失败: %w", err)
+	}
+
+	// 4. 计数器未变化时才删除；期间有新浏览的计数器保留，等待下次归档
+	//    流水线中的 EVALSHA 遇到 NOSCRIPT 时无法回退到 EVAL，先确保脚本已加载
+	if err := archiveViewCountScript.Load(ctx, r.redisClient).Err(); err != nil {
+		return 0, kept + len(viewCounts), fmt.Errorf("加载浏览量归档脚本失败: %w", err)
+	}
+	pipe := r.redisClient.Pipeline()
+	cmds := make(map[uint64]*redis.Cmd, len(viewCounts))
+	for postID := range viewCounts {
+		member := strconv.FormatUint(postID, 10)
+		cmds[postID] = archiveViewCountScript.EvalSha(ctx, pipe,
+			[]string{constant.PostViewCountPrefix + member, constant.ViewLastActiveKey},
+			rawValues[postID], member, cutoff)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Warn("删除已归档的浏览量计数器时部分失败，将在下次归档时重试", zap.Error(err))
+	}
+	archived := 0
+	for postID, cmd := range cmds {
+		deleted, err := cmd.Int()
+		if err != nil || deleted != 1 {
+			kept++
+			if err != nil {
+				r.logger.Warn("删除已归档的浏览量计数器失败", zap.Error(err), zap.Uint64("postID", postID))
+			}
+			continue
+		}
+		archived++
+	}
+	return archived, kept, nil
+}
@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// ViewBloomInfo 是单个帖子去重 Bloom Filter 的 BF.INFO 信息。
+type ViewBloomInfo struct {
+	Items     int64 // 已插入的用户数
+	Capacity  int64 // 总容量（含 RedisBloom 自动扩展出的子过滤器）
+	Filters   int64 // 子过滤器数量，大于 1 说明 RedisBloom 已自动扩展过，查询需要逐层检查
+	SizeBytes int64 // 占用的内存字节数
+}
+
+// ViewBloomExpansion 描述一次浏览去重 Bloom Filter 的扩容事件。
+type ViewBloomExpansion struct {
+	PostID      uint64
+	Items       int64 // 扩容前已插入的用户数
+	Capacity    int64 // 扩容前的总容量（含 RedisBloom 自动扩展出的子过滤器）
+	Filters     int64 // 扩容前的子过滤器数量，大于 1 说明 RedisBloom 已自动扩展过，查询需要逐层检查
+	NewCapacity int64 // 新过滤器的容量
+}
+
+// rebuildBloomStatus* 是 rebuildViewBloomScript 的返回值。
+const (
+	rebuildBloomStatusDone      = 1  // 已重建
+	rebuildBloomStatusMissing   = 0  // 过滤器已不存在（已过期），无需重建
+	rebuildBloomStatusMigrating = -1 // 上一次扩容的旧过滤器尚未过期
+)
+
+// rebuildViewBloomScript 原子地把当前过滤器改名为旧过滤器，并按新容量创建空的过滤器。
+// - 改名与创建在同一脚本内完成，期间的浏览不会抢先以默认容量创建过滤器。
+// - 旧过滤器通过 RENAME 保留原有的剩余过期时间；新过滤器沿用同样的过期时间，滑动续期模式下之后由计数脚本在每次新浏览时刷新。
+// - KEYS: [1] 当前 Bloom Filter, [2] 旧 Bloom Filter
+// - ARGV: [1] 新容量, [2] 误判率, [3] 扩展倍数, [4] 当前过滤器没有过期时间时使用的默认过期毫秒数
+var rebuildViewBloomScript = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[2]) == 1 then
+        return -1
+    end
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return 0
+    end
+    local ttl = redis.call("PTTL", KEYS[1])
+    if ttl <= 0 then
+        ttl = tonumber(ARGV[4])
+    end
+    redis.call("RENAME", KEYS[1], KEYS[2])
+    redis.call("BF.RESERVE", KEYS[1], ARGV[2], ARGV[1], "EXPANSION", ARGV[3])
+    redis.call("PEXPIRE", KEYS[1], ttl)
+    return 1
+`)
+
+// GetBloomInfo 实现去重 Bloom Filter 信息的批量读取。
+func (r *postViewRepository) GetBloomInfo(ctx context.Context, postIDs []uint64) (map[uint64]*ViewBloomInfo, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	// 单个过滤器不存在（已过期）或读取失败不影响其余帖子，只有上下文结束时整体返回错误
+	pipe := r.redisClient.Pipeline()
+	infoCmds := make([]*redis.BFInfoCmd, len(postIDs))
+	for i, postID := range postIDs {
+		infoCmds[i] = pipe.BFInfo(ctx, constant.PostViewBloomPrefix+strconv.FormatUint(postID, 10))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("批量获取 Bloom Filter 信息失败: %w", err)
+	}
+
+	infos := make(map[uint64]*ViewBloomInfo, len(postIDs))
+	for i, postID := range postIDs {
+		info, err := infoCmds[i].Result()
+		if err != nil {
+			if !strings.Contains(err.Error(), "not found") {
+				r.logger.Warn("获取 Bloom Filter 信息失败，跳过该帖子", zap.Error(err), zap.Uint64("postID", postID))
+			}
+			continue
+		}
+		infos[postID] = &ViewBloomInfo{
+			Items:     info.ItemsInserted,
+			Capacity:  info.Capacity,
+			Filters:   info.Filters,
+			SizeBytes: info.Size,
+		}
+	}
+	return infos, nil
+}
+
+// RebuildBloom 实现单个帖子去重 Bloom Filter 的扩容重建。
+func (r *postViewRepository) RebuildBloom(ctx context.Context, postID uint64, info *ViewBloomInfo) (*ViewBloomExpansion, error) {
+	expansion := &ViewBloomExpansion{
+		PostID:      postID,
+		Items:       info.Items,
+		Capacity:    info.Capacity,
+		Filters:     info.Filters,
+		NewCapacity: max(info.Capacity, info.Items, r.bloomFilterSize) * int64(r.bloomExpansion),
+	}
+	done, err := r.rebuildViewBloom(ctx, strconv.FormatUint(postID, 10), expansion.NewCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("重建帖子 %d 的 Bloom Filter 失败: %w", postID, err)
+	}
+	if !done {
+		return nil, nil
+	}
+	return expansion, nil
+}
+
+// rebuildViewBloom 执行单个帖子的过滤器重建，返回是否完成了重建。
+func (r *postViewRepository) rebuildViewBloom(ctx context.Context, member string, newCapacity int64) (bool, error) {
+	status, err := rebuildViewBloomScript.Run(ctx, r.redisClient,
+		[]string{constant.PostViewBloomPrefix + member, constant.PostViewBloomPrevPrefix + member},
+		newCapacity,
+		r.bloomErrorRate,
+		r.bloomExpansion,
+		r.dedupWindow.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return false, err
+	}
+	switch status {
+	case rebuildBloomStatusMigrating:
+		r.logger.Warn("Bloom Filter 已接近容量，但上一次扩容的旧过滤器尚未过期，本轮跳过", zap.String("postID", member))
+	case rebuildBloomStatusMissing:
+		r.logger.Debug("Bloom Filter 已过期，无需扩容", zap.String("postID", member))
+	}
+	return status == rebuildBloomStatusDone, nil
+}
@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// batchIncrementRankScript 在一次 Redis 往返内对多个帖子执行 ZINCRBY。
+// - KEYS: [1] 全站排行榜 ZSet
+// - ARGV: 依次为 postID, 增量 成对出现
+// - 返回: 更新的帖子数量
+var batchIncrementRankScript = redis.NewScript(`
+    local updated = 0
+    for i = 1, #ARGV, 2 do
+        redis.call("ZINCRBY", KEYS[1], ARGV[i + 1], ARGV[i])
+        updated = updated + 1
+    end
+    return updated
+`)
+
+// BatchIncrementRank 实现排行榜分数的批量增加。
+func (r *postViewRepository) BatchIncrementRank(ctx context.Context, increments map[uint64]int64) error {
+	postIDs := make([]uint64, 0, len(increments))
+	for postID, delta := range increments {
+		if delta != 0 {
+			postIDs = append(postIDs, postID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+	// 固定顺序拆分，便于按日志定位失败的批次
+	slices.Sort(postIDs)
+
+	for start := 0; start < len(postIDs); start += constant.BatchIncrementRankMaxMembers {
+		chunk := postIDs[start:min(start+constant.BatchIncrementRankMaxMembers, len(postIDs))]
+		args := make([]interface{}, 0, len(chunk)*2)
+		for _, postID := range chunk {
+			args = append(args, strconv.FormatUint(postID, 10), increments[postID])
+		}
+		if err := batchIncrementRankScript.Run(ctx, r.redisClient, []string{constant.PostsRankKey}, args...).Err(); err != nil {
+			r.logger.Error("Lua 脚本执行失败：批量增加排行榜分数",
+				zap.Error(err),
+				zap.Int("chunkStart", start),
+				zap.Int("chunkSize", len(chunk)),
+				zap.Int("totalPosts", len(postIDs)))
+			return fmt.Errorf("批量增加排行榜分数失败 (已完成 %d/%d 个帖子): %w", start, len(postIDs), err)
+		}
+	}
+	r.logger.Debug("批量增加排行榜分数完成", zap.Int("posts", len(postIDs)))
+	return nil
+}
+
+// GetActivePostIDs 实现活跃帖子的查询。
+func (r *postViewRepository) GetActivePostIDs(ctx context.Context, since time.Time, limit int) ([]uint64, error) {
+	if limit <= 0 {
+		return []uint64{}, nil
+	}
+	members, err := r.redisClient.ZRevRangeByScore(ctx, constant.ViewLastActiveKey, &redis.ZRangeBy{
+		Min:   strconv.FormatInt(since.Unix(), 10),
+		Max:   "+inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		r.logger.Error("查询最近活跃帖子失败", zap.Error(err), zap.Time("since", since))
+		return nil, fmt.Errorf("查询最近活跃帖子失败: %w", err)
+	}
+
+	postIDs := make([]uint64, 0, len(members))
+	for _, member := range members {
+		postID, parseErr := strconv.ParseUint(member, 10, 64)
+		if parseErr != nil {
+			r.logger.Error("最近活跃时间集合中的成员不是合法的 PostID，已跳过", zap.String("member", member))
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+	return postIDs, nil
+}
+
+// GetPostRanks 实现排行榜排名的批量查询。
+func (r *postViewRepository) GetPostRanks(ctx context.Context, postIDs []uint64) (map[uint64]int64, error) {
+	ranks := make(map[uint64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return ranks, nil
+	}
+
+	pipe := r.redisClient.Pipeline()
+	cmds := make([]*redis.IntCmd, len(postIDs))
+	for i, postID := range postIDs {
+		cmds[i] = pipe.ZRevRank(ctx, constant.PostsRankKey, strconv.FormatUint(postID, 10))
+	}
+	// 不在排行榜中的成员返回 redis.Nil，Exec 会把它作为错误返回，逐条判断即可
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		r.logger.Error("执行 Redis Pipeline 批量获取排行榜排名失败", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return nil, fmt.Errorf("批量获取排行榜排名失败: %w", err)
+	}
+	for i, cmd := range cmds {
+		rank, err := cmd.Result()
+		if err != nil {
+			continue
+		}
+		ranks[postIDs[i]] = rank
+	}
+	return ranks, nil
+}
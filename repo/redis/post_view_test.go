@@ -0,0 +1,196 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// newBloomExpiryRepo 创建去重窗口为 1 分钟的浏览计数仓库，并为帖子 postID 预置计数器，避免回源 MySQL。
+func newBloomExpiryRepo(t *testing.T, sliding bool, postID uint64) (*miniredis.Miniredis, PostViewRepository) {
+	t.Helper()
+	mr, client := newTestRedis(t)
+	registerFakeBloom(t, mr)
+	mr.Set(constant.PostViewCountPrefix+strconv.FormatUint(postID, 10), "0")
+	repo := NewPostViewRepository(client, nil, newTestLogger(t), 1000, 0, 0.01, config.ViewSyncConfig{},
+		config.ViewCountConfig{DedupWindow: time.Minute, SlidingExpire: sliding})
+	return mr, repo
+}
+
+// viewAndCount 以 member 浏览帖子，返回之后的浏览量。
+func viewAndCount(t *testing.T, mr *miniredis.Miniredis, repo PostViewRepository, postID uint64, member string) int {
+	t.Helper()
+	if err := repo.IncrementViewCount(context.Background(), postID, ViewerIdentity{Member: member}); err != nil {
+		t.Fatalf("IncrementViewCount(%s): %v", member, err)
+	}
+	v, err := mr.Get(constant.PostViewCountPrefix + strconv.FormatUint(postID, 10))
+	if err != nil {
+		t.Fatalf("read view count: %v", err)
+	}
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+// 固定窗口：过期时间只在过滤器创建时设置，持续有新访客也会按时清空，老访客在下个窗口重新计数。
+func TestIncrementViewCountFixedBloomExpiry(t *testing.T) {
+	const postID = 1
+	mr, repo := newBloomExpiryRepo(t, false, postID)
+	bloomKey := constant.PostViewBloomPrefix + strconv.Itoa(postID)
+
+	if got := viewAndCount(t, mr, repo, postID, "user-a"); got != 1 {
+		t.Fatalf("first view count = %d, want 1", got)
+	}
+	if got := viewAndCount(t, mr, repo, postID, "user-a"); got != 1 {
+		t.Fatalf("repeat view count = %d, want 1 (deduplicated)", got)
+	}
+
+	mr.FastForward(40 * time.Second)
+	if got := viewAndCount(t, mr, repo, postID, "user-b"); got != 2 {
+		t.Fatalf("new visitor count = %d, want 2", got)
+	}
+	if ttl := mr.TTL(bloomKey); ttl > 20*time.Second {
+		t.Fatalf("bloom TTL after a new visitor = %v, want it left at the remaining ~20s", ttl)
+	}
+
+	mr.FastForward(25 * time.Second)
+	if mr.Exists(bloomKey) {
+		t.Fatal("bloom filter still exists after its fixed window ended")
+	}
+	if got := viewAndCount(t, mr, repo, postID, "user-a"); got != 3 {
+		t.Fatalf("view count after the window = %d, want 3 (user-a counted again)", got)
+	}
+	if ttl := mr.TTL(bloomKey); ttl != time.Minute {
+		t.Fatalf("TTL of the recreated filter = %v, want a fresh 1m window", ttl)
+	}
+}
+
+// 滑动窗口：每位新访客都续期整个过滤器，老访客在最后一位新访客之后的整个窗口内都不会重新计数。
+func TestIncrementViewCountSlidingBloomExpiry(t *testing.T) {
+	const postID = 2
+	mr, repo := newBloomExpiryRepo(t, true, postID)
+	bloomKey := constant.PostViewBloomPrefix + strconv.Itoa(postID)
+
+	viewAndCount(t, mr, repo, postID, "user-a")
+	mr.FastForward(40 * time.Second)
+	// 重复浏览不续期
+	viewAndCount(t, mr, repo, postID, "user-a")
+	if ttl := mr.TTL(bloomKey); ttl > 20*time.Second {
+		t.Fatalf("bloom TTL after a repeat view = %v, want it unchanged", ttl)
+	}
+	if got := viewAndCount(t, mr, repo, postID, "user-b"); got != 2 {
+		t.Fatalf("new visitor count = %d, want 2", got)
+	}
+	if ttl := mr.TTL(bloomKey); ttl != time.Minute {
+		t.Fatalf("bloom TTL after a new visitor = %v, want it renewed to 1m", ttl)
+	}
+
+	mr.FastForward(25 * time.Second)
+	if got := viewAndCount(t, mr, repo, postID, "user-a"); got != 2 {
+		t.Fatalf("view count = %d, want 2 (user-a still deduplicated by the renewed window)", got)
+	}
+}
+
+// MarkViewed 与 IncrementViewCount 共用同样的过期策略。
+func TestMarkViewedFixedBloomExpiry(t *testing.T) {
+	const postID = 3
+	mr, repo := newBloomExpiryRepo(t, false, postID)
+	ctx := context.Background()
+	bloomKey := constant.PostViewBloomPrefix + strconv.Itoa(postID)
+
+	if counted, err := repo.MarkViewed(ctx, postID, ViewerIdentity{Member: "user-a"}, 0); err != nil || !counted {
+		t.Fatalf("MarkViewed(user-a) = %v, %v, want counted", counted, err)
+	}
+	mr.FastForward(40 * time.Second)
+	if counted, err := repo.MarkViewed(ctx, postID, ViewerIdentity{Member: "user-b"}, 0); err != nil || !counted {
+		t.Fatalf("MarkViewed(user-b) = %v, %v, want counted", counted, err)
+	}
+	if ttl := mr.TTL(bloomKey); ttl > 20*time.Second {
+		t.Fatalf("bloom TTL = %v, want the fixed window kept", ttl)
+	}
+	mr.FastForward(25 * time.Second)
+	if counted, err := repo.MarkViewed(ctx, postID, ViewerIdentity{Member: "user-a"}, 0); err != nil || !counted {
+		t.Fatalf("MarkViewed(user-a) after the window = %v, %v, want counted again", counted, err)
+	}
+}
+
+// archiveBatchRepo 记录归档写入 MySQL 的浏览量，onArchive 模拟写入期间发生的新浏览。
+type archiveBatchRepo struct {
+	mysql.PostBatchOperationsRepository
+	archived  map[uint64]int64
+	onArchive func()
+}
+
+func (r *archiveBatchRepo) ArchivePostViewCounts(_ context.Context, viewCounts map[uint64]int64) error {
+	for postID, count := range viewCounts {
+		r.archived[postID] = count
+	}
+	if r.onArchive != nil {
+		r.onArchive()
+	}
+	return nil
+}
+
+func TestArchiveColdViewCountsDeletesOnlyUnchangedCounters(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+
+	// 1: 冷帖子，计数器不变；2: 冷帖子，归档期间有新浏览；3: 冷帖子但在热榜中
+	cold := float64(time.Now().Add(-2 * time.Hour).Unix())
+	for postID, count := range map[string]string{"1": "5", "2": "7", "3": "9"} {
+		mr.Set(constant.PostViewCountPrefix+postID, count)
+		if _, err := mr.ZAdd(constant.ViewLastActiveKey, cold, postID); err != nil {
+			t.Fatalf("ZAdd: %v", err)
+		}
+	}
+	if _, err := mr.ZAdd(constant.HotPostsRankKey, 100, "3"); err != nil {
+		t.Fatalf("ZAdd: %v", err)
+	}
+
+	batch := &archiveBatchRepo{archived: map[uint64]int64{}}
+	batch.onArchive = func() {
+		if _, err := mr.Incr(constant.PostViewCountPrefix+"2", 1); err != nil {
+			t.Errorf("Incr: %v", err)
+		}
+	}
+	repo := NewPostViewRepository(client, batch, newTestLogger(t), 1000, 0, 0.01, config.ViewSyncConfig{}, config.ViewCountConfig{})
+
+	archived, err := repo.ArchiveColdViewCounts(ctx, time.Hour, 10)
+	if err != nil {
+		t.Fatalf("ArchiveColdViewCounts: %v", err)
+	}
+	if archived != 1 {
+		t.Fatalf("archived = %d, want 1", archived)
+	}
+	if batch.archived[1] != 5 || batch.archived[2] != 7 {
+		t.Fatalf("counts written to MySQL = %v, want 1:5 and 2:7", batch.archived)
+	}
+	if _, ok := batch.archived[3]; ok {
+		t.Fatal("hot post 3 was archived")
+	}
+
+	// 未变化的计数器被删除并移出活跃 ZSet
+	if mr.Exists(constant.PostViewCountPrefix + "1") {
+		t.Fatal("counter of post 1 still exists after archiving")
+	}
+	if _, err := client.ZScore(ctx, constant.ViewLastActiveKey, "1").Result(); err != redis.Nil {
+		t.Fatalf("post 1 still in %s (err = %v)", constant.ViewLastActiveKey, err)
+	}
+
+	// 归档期间变化的计数器与热榜帖子保留
+	for postID, want := range map[string]string{"2": "8", "3": "9"} {
+		if got, err := mr.Get(constant.PostViewCountPrefix + postID); err != nil || got != want {
+			t.Fatalf("counter of post %s = %q, %v, want %q kept", postID, got, err, want)
+		}
+		if _, err := client.ZScore(ctx, constant.ViewLastActiveKey, postID).Result(); err != nil {
+			t.Fatalf("post %s removed from %s: %v", postID, constant.ViewLastActiveKey, err)
+		}
+	}
+}
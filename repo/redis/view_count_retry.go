@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// ViewCountRetryBatch 是一条浏览量同步失败批次的重试记录。
+type ViewCountRetryBatch struct {
+	Counts        map[uint64]int64 `json:"counts"`          // 未写入 MySQL 的帖子浏览量 (postID -> 浏览量)
+	Attempts      int              `json:"attempts"`        // 已重试次数，首次失败时为 0
+	FirstFailedAt int64            `json:"first_failed_at"` // 首次失败时间 (Unix 秒)
+	LastFailedAt  int64            `json:"last_failed_at"`  // 最近一次失败时间 (Unix 秒)
+}
+
+// ViewCountRetryStore 定义了浏览量同步失败批次的持久化接口。
+// - 记录保存在 List (constant.ViewCountRetryBatchesKey) 中，失败时追加到队尾，重试时从队首读取。
+// - 读取与移除分两步：处理完成（重新失败的记录已再次追加）后再 Ack，进程中途退出时记录不会丢失，最多被重复重试一次。
+// - 依赖同步任务的分布式锁保证同一时间只有一个读取方。
+type ViewCountRetryStore interface {
+	// Push 将重试记录追加到队尾。
+	Push(ctx context.Context, batches []*ViewCountRetryBatch) error
+
+	// List 读取队首至多 limit 条记录，只读取不移除。
+	// - 返回的 read 为实际读取的元素数量（包括无法解析而被跳过的元素），处理完成后以此调用 Ack。
+	List(ctx context.Context, limit int64) (batches []*ViewCountRetryBatch, read int64, err error)
+
+	// Ack 移除队首 n 条已处理的记录。
+	Ack(ctx context.Context, n int64) error
+}
+
+// viewCountRetryStore 是 ViewCountRetryStore 接口的 Redis 实现。
+type viewCountRetryStore struct {
+	redisClient *redis.Client
+	logger      *core.ZapLogger
+}
+
+// NewViewCountRetryStore 创建 ViewCountRetryStore 实例。
+func NewViewCountRetryStore(redisClient *redis.Client, logger *core.ZapLogger) ViewCountRetryStore {
+	return &viewCountRetryStore{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// Push 实现重试记录的追加。
+func (s *viewCountRetryStore) Push(ctx context.Context, batches []*ViewCountRetryBatch) error {
+	if len(batches) == 0 {
+		return nil
+	}
+	values := make([]interface{}, 0, len(batches))
+	for _, batch := range batches {
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return fmt.Errorf("序列化浏览量重试记录失败: %w", err)
+		}
+		values = append(values, data)
+	}
+	if err := s.redisClient.RPush(ctx, constant.ViewCountRetryBatchesKey, values...).Err(); err != nil {
+		return fmt.Errorf("写入浏览量重试记录失败: %w", err)
+	}
+	return nil
+}
+
+// List 实现重试记录的读取，无法解析的元素记录日志后跳过，随 Ack 一并移除。
+func (s *viewCountRetryStore) List(ctx context.Context, limit int64) ([]*ViewCountRetryBatch, int64, error) {
+	if limit <= 0 {
+		return nil, 0, nil
+	}
+	raws, err := s.redisClient.LRange(ctx, constant.ViewCountRetryBatchesKey, 0, limit-1).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("读取浏览量重试记录失败: %w", err)
+	}
+	batches := make([]*ViewCountRetryBatch, 0, len(raws))
+	for _, raw := range raws {
+		var batch ViewCountRetryBatch
+		if err := json.Unmarshal([]byte(raw), &batch); err != nil {
+			s.logger.Error("浏览量重试记录格式错误，已跳过", zap.Error(err), zap.String("raw", raw))
+			continue
+		}
+		batches = append(batches, &batch)
+	}
+	return batches, int64(len(raws)), nil
+}
+
+// Ack 实现已处理记录的移除。
+func (s *viewCountRetryStore) Ack(ctx context.Context, n int64) error {
+	if n <= 0 {
+		return nil
+	}
+	if err := s.redisClient.LTrim(ctx, constant.ViewCountRetryBatchesKey, n, -1).Err(); err != nil {
+		return fmt.Errorf("移除已处理的浏览量重试记录失败: %w", err)
+	}
+	return nil
+}
@@ -11,7 +11,10 @@ import (
 	appConfig "github.com/Xushengqwer/post_service/config"
 	"github.com/Xushengqwer/post_service/constant" // 需要导入常量包获取 ServiceName
 	"github.com/Xushengqwer/post_service/controller"
+	"github.com/Xushengqwer/post_service/middleware"
+	"github.com/Xushengqwer/post_service/models/dto"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	// 导入 OTel Gin 中间件
 	otelgin "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"net/http"
@@ -24,9 +27,21 @@ func SetupRouter(
 	postController *controller.PostController,
 	hotPostController *controller.HotPostController,
 	postAdminController *controller.PostAdminController,
+	reportController *controller.ReportController,
+	tagSubscriptionController *controller.TagSubscriptionController,
+	badgeController *controller.BadgeController,
+	coverExperimentController *controller.CoverExperimentController,
+	postSnapshotController *controller.PostSnapshotController,
+	readDepthController *controller.ReadDepthController,
+	conversionController *controller.ConversionController,
 ) *gin.Engine {
 	logger.Info("开始设置 Gin 路由...")
 
+	// 自定义校验标签（如按字符数校验长度的 maxchars）需在任何请求绑定之前注册
+	if err := dto.RegisterValidators(); err != nil {
+		logger.Fatal("注册自定义请求校验标签失败", zap.Error(err))
+	}
+
 	// 使用 gin.New() 而不是 gin.Default()，因为我们要自定义 Recovery 和 Logger
 	router := gin.New()
 
@@ -51,10 +66,20 @@ func SetupRouter(
 	requestTimeout := time.Duration(cfg.ServerConfig.RequestTimeout) * time.Second
 	router.Use(commonMiddleware.RequestTimeoutMiddleware(logger, requestTimeout))
 
-	// 5. User Context (提取用户信息)
+	// 5. Trusted Gateway (识别可信网关，移除非网关请求中的用户画像请求头)
+	trustedGateway, err := middleware.TrustedGatewayMiddleware(cfg.Gateway, logger)
+	if err != nil {
+		logger.Fatal("初始化可信网关中间件失败", zap.Error(err))
+	}
+	router.Use(trustedGateway)
+
+	// 6. User Context (提取用户信息)
 	router.Use(commonMiddleware.UserContextMiddleware())
 
-	// 6. (可选) RequestIDMiddleware - 如果决定需要它，放在 OTel 之后，Logger 之前或之后都可以
+	// 7. Request Body Limit (限制写接口请求体大小，超限返回 413)
+	router.Use(middleware.RequestBodyLimitMiddleware(cfg.BodyLimit, logger))
+
+	// 8. (可选) RequestIDMiddleware - 如果决定需要它，放在 OTel 之后，Logger 之前或之后都可以
 	// router.Use(middleware.RequestIDMiddleware()) // 根据你的决定选择是否添加
 
 	logger.Debug("已注册全局中间件")
@@ -63,10 +88,20 @@ func SetupRouter(
 	v1 := router.Group("/api/v1/post")
 	logger.Debug("已创建 API/v1/post 分组")
 
+	// 创建/删除/编辑帖子的路由拒绝被拉黑用户的写请求，读请求不受影响
+	activeUserOnly := v1.Group("", middleware.RequireActiveUser(logger))
+
 	// --- 注册控制器路由 ---
-	postController.RegisterRoutes(v1)
+	postController.RegisterRoutes(activeUserOnly)
 	hotPostController.RegisterRoutes(v1)
 	postAdminController.RegisterRoutes(v1)
+	reportController.RegisterRoutes(v1)
+	tagSubscriptionController.RegisterRoutes(v1)
+	badgeController.RegisterRoutes(v1)
+	coverExperimentController.RegisterRoutes(activeUserOnly)
+	postSnapshotController.RegisterRoutes(v1)
+	readDepthController.RegisterRoutes(v1)
+	conversionController.RegisterRoutes(v1)
 	logger.Info("所有控制器路由已注册到 /api/v1/post 分组")
 
 	// --- 新增：注册 Swagger UI 路由 ---
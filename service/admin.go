@@ -8,34 +8,134 @@ import (
 	"github.com/Xushengqwer/go-common/commonerrors"
 	"github.com/Xushengqwer/go-common/core" // 导入日志库
 	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/go-common/models/kafkaevents"
 	"github.com/Xushengqwer/post_service/mq/producer"
 	"go.uber.org/zap" // 导入 zap
 	"gorm.io/gorm"
+	"io"
+	"sort"
+	"time"
 
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
 	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
 	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
 )
 
 // PostAdminService 定义帖子管理员服务的接口。
 // - 封装管理员对帖子的管理操作，如审核、查询、设置标签和删除。
+// - 所有写操作（无论成功或失败）都会写入一条管理员操作审计日志，adminUserID 为操作人。
 type PostAdminService interface {
 	// AuditPost 处理管理员审核帖子的请求。
+	// - 审核通过前会校验版权声明，不合理时返回 myErrors.ErrInvalidCopyright。
 	// - 内部调用仓库层更新状态和可选的原因。
-	AuditPost(ctx context.Context, req *dto.AuditPostRequest) error
+	// - 帖子由其他状态变为审核通过且带有官方标签时，异步推送给订阅了该标签的用户。
+	AuditPost(ctx context.Context, req *dto.AuditPostRequest, adminUserID string) error
+
+	// BatchAuditPosts 处理管理员批量审核帖子的请求。
+	// - 逐条复用 AuditPost 的校验与更新逻辑，单条失败不影响其他帖子。
+	// - 返回每个帖子的处理结果，区分帖子不存在、版权声明不合理和其他错误。
+	// - 对审核通过的帖子批量发送审核通过事件。
+	// - 除每个帖子各自的审核日志外，另记录一条批量操作的汇总审计日志。
+	BatchAuditPosts(ctx context.Context, req *dto.BatchAuditRequest, adminUserID string) (*vo.BatchAuditResultVO, error)
+
+	// GetRecentViews 获取最近 minutes 分钟的全站浏览量，供运营大屏展示。
+	GetRecentViews(ctx context.Context, minutes int) (*vo.RecentViewsVO, error)
+
+	// GetPostStats 统计各审核状态与各官方标签的帖子数量，供运营看板展示。
+	// - 不含已删除的帖子与图片上传中的占位帖子，草稿单独计数。
+	// - 结果在 Redis 中缓存 constant.PostStatsCacheTTL，缓存读写失败时直接查库，不影响结果。
+	GetPostStats(ctx context.Context) (*vo.PostStatsVO, error)
 
 	// ListPostsByCondition 按条件分页查询帖子列表。
 	// - 供管理后台使用，直接将 DTO 传递给仓库层。
 	ListPostsByCondition(ctx context.Context, req *dto.ListPostsByConditionRequest) (*vo.ListPostsAdminByConditionResponse, error)
 
-	// UpdateOfficialTag 处理管理员更新帖子官方标签的请求。
+	// ExportPostsByCondition 按与 ListPostsByCondition 相同的筛选条件导出全部匹配的帖子，以 CSV 格式写入 writer。
+	// - 按 ID 游标分批读取并逐批写出，内存占用与结果集大小无关。
+	// - 已写出部分数据后出错时返回错误，writer 中的内容不完整。
+	ExportPostsByCondition(ctx context.Context, req *dto.ExportPostsByConditionRequest, writer io.Writer) error
+
+	// UpdateOfficialTag 处理管理员更新帖子官方标签的请求，帖子的全部标签被替换为请求中的一个标签。
 	// - 调用仓库层执行实际的数据库更新。
-	UpdateOfficialTag(ctx context.Context, req *dto.UpdateOfficialTagRequest) error
+	// - 已审核通过的帖子换上新标签时，异步推送给订阅了该标签的用户。
+	UpdateOfficialTag(ctx context.Context, req *dto.UpdateOfficialTagRequest, adminUserID string) error
+
+	// AddOfficialTagFlag 为帖子追加一个官方标签，保留已有的其他标签。
+	// - tag 为无标签或不在合法枚举范围内时返回 myErrors.ErrInvalidOfficialTag；帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	// - 已审核通过的帖子新增标签时，异步推送给订阅了该标签的用户。
+	AddOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag, adminUserID string) error
+
+	// RemoveOfficialTagFlag 移除帖子的一个官方标签，保留其他标签；帖子本就没有该标签时同样成功。
+	// - tag 为无标签或不在合法枚举范围内时返回 myErrors.ErrInvalidOfficialTag；帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	RemoveOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag, adminUserID string) error
+
+	// BatchUpdateOfficialTag 为一批帖子打上同一个官方标签（tag 为 OfficialTagNone 时批量移除标签）。
+	// - tag 不在合法枚举范围内时返回 myErrors.ErrInvalidOfficialTag。
+	// - 用单条 UPDATE 完成更新，返回实际更新的数量与不存在或已删除的帖子 ID。
+	// - 为每个更新的帖子写入状态流转日志，另记录一条批量操作的汇总审计日志；已审核通过且标签变化的帖子推送给标签订阅者。
+	BatchUpdateOfficialTag(ctx context.Context, postIDs []uint64, tag enums.OfficialTag, adminUserID string) (*vo.BatchResultVO, error)
 
 	// DeletePostByAdmin 处理管理员删除帖子的请求。
 	// - 执行软删除操作。
-	// - 记录管理员操作日志。
-	DeletePostByAdmin(ctx context.Context, postID uint64, adminUserID string) error
+	// - 浏览量超过配置阈值的帖子必须 confirm 为 true，否则返回 *DeleteConfirmRequiredError（可 errors.Is myErrors.ErrDeleteConfirmRequired），其中携带浏览量与热榜信息。
+	// - 记录管理员操作审计日志。
+	DeletePostByAdmin(ctx context.Context, postID uint64, adminUserID string, confirm bool) error
+
+	// ListPostAuditLogs 分页查询单个帖子的状态流转审计历史（审核、改标签、删除），按操作时间倒序。
+	ListPostAuditLogs(ctx context.Context, postID uint64, page, pageSize int) (*vo.ListPostAuditLogsVO, error)
+
+	// RestorePost 恢复被软删除的帖子（撤销删除）。
+	// - 在事务中恢复帖子、详情、详情图片及 FAQ，帖子状态置为 Pending。
+	// - 待审核事件通过发件箱与恢复操作在同一事务内提交，帖子需重新走审核流程才会再次公开。
+	// - 帖子不存在时返回 commonerrors.ErrRepoNotFound；帖子未被删除时返回 myErrors.ErrPostNotDeleted。
+	RestorePost(ctx context.Context, postID uint64, adminUserID string) error
+
+	// ResyncPosts 对账补偿：为下游服务报告缺失或过期的帖子重新发送同步事件。
+	// - 已审核通过的帖子重新发送审核通过事件（携带完整帖子数据）；已删除、不存在或未审核通过的帖子发送删除事件。
+	// - 记录管理员操作审计日志，Params 中保存差异来源与帖子列表，作为对账差异记录。
+	// - 未配置 Kafka 时返回 myErrors.ErrEventPublishingUnavailable。
+	ResyncPosts(ctx context.Context, req *dto.ReconcileResyncRequest, adminUserID string) (*vo.ReconcileResyncResultVO, error)
+
+	// GetPostFullDetail 获取单个帖子的完整详情（帖子、正文、图片及审核信息），供管理员审核时查看。
+	// - 已软删除的帖子同样可查；帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	GetPostFullDetail(ctx context.Context, postID uint64) (*vo.AdminPostDetailVO, error)
+
+	// ListReportedPosts 按举报数倒序分页列出被举报的帖子（不含已删除的帖子），供管理员处理用户举报。
+	ListReportedPosts(ctx context.Context, page, pageSize int) (*vo.ListReportedPostsVO, error)
+}
+
+// DeleteConfirmRequiredError 表示删除的帖子影响面较大，需要管理员带 confirm=true 二次确认。
+type DeleteConfirmRequiredError struct {
+	PostID    uint64
+	ViewCount int64
+	Threshold int64
+	HotRank   int64 // 热榜排名（0 开始），-1 表示不在热榜
+}
+
+func (e *DeleteConfirmRequiredError) Error() string {
+	return fmt.Sprintf("帖子(ID: %d)浏览量 %d 超过阈值 %d，删除需要二次确认", e.PostID, e.ViewCount, e.Threshold)
+}
+
+func (e *DeleteConfirmRequiredError) Unwrap() error { return myErrors.ErrDeleteConfirmRequired }
+
+// VO 返回给前端展示的删除影响面信息。
+func (e *DeleteConfirmRequiredError) VO() *vo.DeleteConfirmRequiredVO {
+	result := &vo.DeleteConfirmRequiredVO{
+		PostID:             e.PostID,
+		ViewCount:          e.ViewCount,
+		ViewCountThreshold: e.Threshold,
+		InHotList:          e.HotRank >= 0,
+	}
+	if result.InHotList {
+		rank := e.HotRank
+		result.HotRank = &rank
+	}
+	return result
 }
 
 // postAdminService 是 PostAdminService 接口的实现。
@@ -43,33 +143,119 @@ type postAdminService struct {
 	postAdminRepo  mysql.PostAdminRepository
 	postRepo       mysql.PostRepository
 	postDetailRepo mysql.PostDetailRepository
+	postBatchRepo  mysql.PostBatchOperationsRepository // 批量读取帖子数据，用于组装审核通过事件
+	postViewRepo   redis.PostViewRepository            // 浏览量相关的 Redis 操作，用于滑动窗口指标
+	postCache      redis.PostReadCache                 // 帖子详情缓存，删除帖子时主动清除
 	logger         *core.ZapLogger
 	db             *gorm.DB
-	kafkaSvc       *producer.KafkaProducer // Kafka 生产者，用于发送异步消息
+	kafkaSvc       *producer.KafkaProducer            // Kafka 生产者，用于发送异步消息
+	auditLogSvc    AdminAuditLogService               // 管理员操作审计日志
+	postAuditRepo  mysql.PostAuditLogRepository       // 帖子状态流转审计日志
+	segmentRepo    mysql.PostContentSegmentRepository // 正文段落审核状态，审核结果按段落合并
+	outboxRepo     mysql.OutboxRepository             // 事务性发件箱，恢复帖子时与恢复操作一起提交待审核事件
+	deleteCfg      config.AdminDeleteConfig           // 删除帖子的二次确认阈值
+	tagSubSvc      TagSubscriptionService             // 标签订阅，带标签的帖子公开后推送新帖事件
+	postReportRepo mysql.PostReportRepository         // 用户举报，按帖子聚合举报数
+	cosDeleteQueue redis.COSDeleteQueue               // COS 图片延迟删除队列，删除帖子时投递、恢复帖子时移除
+	targetingRepo  mysql.PostTargetingRepository      // 帖子投放定向，恢复帖子送审时读取目标地区
+	compliance     ContentComplianceChecker           // 按目标地区的内容合规预检，恢复帖子送审时标记命中的敏感词
+	timelineCfg    config.TimelineCacheConfig         // 时间线首页缓存，帖子公开状态变化时按配置主动失效
+}
+
+// PostAdminServiceDeps 是 NewPostAdminService 的依赖集合，各字段含义见 postAdminService 的同名字段。
+type PostAdminServiceDeps struct {
+	PostAdminRepo  mysql.PostAdminRepository
+	PostRepo       mysql.PostRepository
+	PostDetailRepo mysql.PostDetailRepository
+	PostBatchRepo  mysql.PostBatchOperationsRepository
+	PostViewRepo   redis.PostViewRepository
+	PostCache      redis.PostReadCache
+	Logger         *core.ZapLogger
+	DB             *gorm.DB
+	KafkaSvc       *producer.KafkaProducer
+	AuditLogSvc    AdminAuditLogService
+	PostAuditRepo  mysql.PostAuditLogRepository
+	SegmentRepo    mysql.PostContentSegmentRepository
+	OutboxRepo     mysql.OutboxRepository
+	DeleteCfg      config.AdminDeleteConfig
+	TagSubSvc      TagSubscriptionService
+	PostReportRepo mysql.PostReportRepository
+	COSDeleteQueue redis.COSDeleteQueue
+	TargetingRepo  mysql.PostTargetingRepository
+	Compliance     ContentComplianceChecker
+	TimelineCfg    config.TimelineCacheConfig
 }
 
 // NewPostAdminService 初始化帖子管理员服务。
-func NewPostAdminService(
-	postAdminRepo mysql.PostAdminRepository,
-	postRepo mysql.PostRepository,
-	postDetailRepo mysql.PostDetailRepository,
-	logger *core.ZapLogger,
-	db *gorm.DB,
-	kafkaSvc *producer.KafkaProducer,
-) PostAdminService {
+func NewPostAdminService(deps PostAdminServiceDeps) PostAdminService {
 	return &postAdminService{
-		postAdminRepo:  postAdminRepo,
-		postRepo:       postRepo,
-		postDetailRepo: postDetailRepo,
-		logger:         logger,
-		db:             db,
-		kafkaSvc:       kafkaSvc,
+		postAdminRepo:  deps.PostAdminRepo,
+		postRepo:       deps.PostRepo,
+		postDetailRepo: deps.PostDetailRepo,
+		postBatchRepo:  deps.PostBatchRepo,
+		postViewRepo:   deps.PostViewRepo,
+		postCache:      deps.PostCache,
+		logger:         deps.Logger,
+		db:             deps.DB,
+		kafkaSvc:       deps.KafkaSvc,
+		auditLogSvc:    deps.AuditLogSvc,
+		postAuditRepo:  deps.PostAuditRepo,
+		segmentRepo:    deps.SegmentRepo,
+		outboxRepo:     deps.OutboxRepo,
+		deleteCfg:      deps.DeleteCfg,
+		tagSubSvc:      deps.TagSubSvc,
+		postReportRepo: deps.PostReportRepo,
+		cosDeleteQueue: deps.COSDeleteQueue,
+		targetingRepo:  deps.TargetingRepo,
+		compliance:     deps.Compliance,
+		timelineCfg:    deps.TimelineCfg,
+	}
+}
+
+// recordPostTransition 在帖子管理操作成功后写入一条帖子状态流转审计日志。
+// - 使用脱离请求取消的上下文写入，失败只记录日志，不影响主操作。
+func (s *postAdminService) recordPostTransition(ctx context.Context, log *entities.PostAuditLog) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.AdminAuditWriteTimeout)
+	defer cancel()
+	if err := s.postAuditRepo.CreatePostAuditLog(writeCtx, log); err != nil {
+		s.logger.Error("写入帖子审计日志失败（不影响主操作）", zap.Error(err),
+			zap.Uint64("postID", log.PostID),
+			zap.String("action", log.Action),
+			zap.String("adminUserID", log.AdminUserID))
 	}
 }
 
+// notifyTagSubscribers 在后台向订阅了 tag 的用户推送新帖事件，推送失败只记录日志，不影响管理操作。
+func (s *postAdminService) notifyTagSubscribers(post *entities.Post, tag enums.OfficialTag) {
+	if tag == enums.OfficialTagNone {
+		return
+	}
+	go func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), constant.TagNewPostPushTimeout)
+		defer cancel()
+		if err := s.tagSubSvc.NotifyNewPost(bgCtx, post, tag); err != nil {
+			s.logger.Error("标签新帖推送失败", zap.Error(err), zap.Uint64("postID", post.ID), zap.Int("tag", int(tag)))
+		}
+	}()
+}
+
+// recordPostAudit 为单个帖子的管理操作记录审计日志，在各写操作中通过 defer 统一调用。
+func (s *postAdminService) recordPostAudit(ctx context.Context, adminUserID, action string, postID uint64, params any, err error) {
+	s.auditLogSvc.Record(ctx, &AdminAuditEntry{
+		AdminUserID: adminUserID,
+		Action:      action,
+		TargetType:  constant.AdminAuditTargetPost,
+		TargetID:    postAuditTarget(postID),
+		Params:      params,
+		Err:         err,
+	})
+}
+
 // AuditPost 实现审核帖子的逻辑。
 // - 将 DTO 中的 Reason 转换为 sql.NullString 再传递给仓库层。
-func (s *postAdminService) AuditPost(ctx context.Context, req *dto.AuditPostRequest) error {
+func (s *postAdminService) AuditPost(ctx context.Context, req *dto.AuditPostRequest, adminUserID string) (err error) {
+	defer func() { s.recordPostAudit(ctx, adminUserID, constant.AdminActionAuditPost, req.PostID, req, err) }()
+
 	var auditReason sql.NullString
 	// 只有当状态是“拒绝”且 DTO 中提供了非空原因时，才设置 Reason。
 	if req.Status == enums.Rejected && req.Reason != "" {
@@ -78,8 +264,41 @@ func (s *postAdminService) AuditPost(ctx context.Context, req *dto.AuditPostRequ
 		auditReason = sql.NullString{Valid: false} // 其他情况，数据库存 NULL
 	}
 
+	// 先获取帖子：用于审核通过前的版权校验，以及加急帖子的处理时延统计。
+	post, err := s.postRepo.GetPostByID(ctx, req.PostID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return fmt.Errorf("帖子(ID: %d)未找到: %w", req.PostID, err)
+		}
+		s.logger.Error("审核帖子时获取帖子信息失败", zap.Error(err), zap.Uint64("postID", req.PostID))
+		return fmt.Errorf("获取帖子(ID: %d)信息失败: %w", req.PostID, err)
+	}
+
+	// 草稿尚未由作者提交，不允许审核
+	if post.IsDraft {
+		return myErrors.ErrPostIsDraft
+	}
+
+	// 审核通过前校验版权声明的合理性（如转载帖必须注明来源）。
+	if req.Status == enums.Approved {
+		if err := validateCopyright(post); err != nil {
+			s.logger.Warn("帖子版权声明不合理，拒绝审核通过", zap.Error(err), zap.Uint64("postID", req.PostID))
+			return err
+		}
+	}
+
+	// 经过编辑送审的帖子按段落合并审核结果，任一段落未通过则整帖被拒，拒绝原因注明未通过的段落
+	verdict := s.loadSegmentVerdict(ctx, req)
+	if req.Status == enums.Rejected && len(verdict.rejected) > 0 {
+		auditReason = sql.NullString{String: rejectedSegmentsReason(verdict.rejected, req.Reason), Valid: true}
+	}
+
 	// 调用仓库层更新状态和原因。
-	err := s.postAdminRepo.UpdatePostStatus(ctx, req.PostID, req.Status, auditReason)
+	err = s.postAdminRepo.UpdatePostStatus(ctx, req.PostID, req.Status, auditReason, req.EventTime)
+	if errors.Is(err, myErrors.ErrStaleAuditEvent) {
+		s.logger.Info("审核事件早于帖子状态的最后一次变更，已忽略", zap.Uint64("postID", req.PostID), zap.Any("status", req.Status), zap.Timep("eventTime", req.EventTime))
+		return err
+	}
 	if err != nil {
 		// 记录具体的错误日志
 		logFields := []zap.Field{
@@ -99,14 +318,274 @@ func (s *postAdminService) AuditPost(ctx context.Context, req *dto.AuditPostRequ
 		return fmt.Errorf("审核帖子(ID: %d)失败: %w", req.PostID, err)
 	}
 	s.logger.Info("管理员审核帖子成功", zap.Uint64("postID", req.PostID), zap.Any("status", req.Status))
+	s.invalidatePostDetailCache(ctx, req.PostID)
+	s.applySegmentVerdict(ctx, req, verdict)
+	s.recordPostTransition(ctx, &entities.PostAuditLog{
+		PostID:      req.PostID,
+		AdminUserID: adminUserID,
+		Action:      constant.AdminActionAuditPost,
+		OldStatus:   post.Status,
+		NewStatus:   req.Status,
+		Reason:      auditReason.String,
+	})
+
+	// 帖子公开状态发生变化时清除时间线首页缓存，让新通过的帖子尽快出现在首页、被驳回的帖子尽快消失
+	if (req.Status == enums.Approved) != (post.Status == enums.Approved) {
+		s.invalidateTimelineHomeCache(ctx, req.PostID)
+	}
+
+	// 帖子首次变为审核通过且已带官方标签时，推送给订阅了该标签的用户；重复的审核通过（如审核服务回传）不会重复推送
+	if req.Status == enums.Approved && post.Status != enums.Approved {
+		for _, tag := range post.OfficialTags.Tags() {
+			s.notifyTagSubscribers(post, tag)
+		}
+	}
+
+	// 记录加急帖子从创建到审核完成的处理时延，便于评估高优先级通道的效果。
+	if post.AuditPriority == constant.AuditPriorityHigh && req.Status != enums.Pending {
+		s.logger.Info("高优先级帖子审核完成",
+			zap.Uint64("postID", req.PostID),
+			zap.Any("status", req.Status),
+			zap.Duration("auditLatency", time.Since(post.CreatedAt)),
+		)
+	}
 	return nil
 }
 
+// invalidateTimelineHomeCache 按配置清除时间线首页缓存，失败只记录日志，缓存会在 TTL 后自然过期。
+func (s *postAdminService) invalidateTimelineHomeCache(ctx context.Context, postID uint64) {
+	if !s.timelineCfg.Enabled || !s.timelineCfg.InvalidateOnAudit {
+		return
+	}
+	if err := s.postCache.DeleteTimelineHomePages(ctx); err != nil {
+		s.logger.Warn("帖子公开状态变化后清除时间线首页缓存失败", zap.Error(err), zap.Uint64("postID", postID))
+	}
+}
+
+// invalidatePostDetailCache 帖子数据变更后清除详情缓存，失败只记录日志，缓存会在 TTL 后自然过期。
+func (s *postAdminService) invalidatePostDetailCache(ctx context.Context, postIDs ...uint64) {
+	for _, postID := range postIDs {
+		if err := s.postCache.DeletePostDetail(ctx, postID); err != nil {
+			s.logger.Warn("帖子变更后清除详情缓存失败", zap.Error(err), zap.Uint64("postID", postID))
+		}
+	}
+}
+
+// BatchAuditPosts 实现批量审核帖子的逻辑。
+func (s *postAdminService) BatchAuditPosts(ctx context.Context, req *dto.BatchAuditRequest, adminUserID string) (*vo.BatchAuditResultVO, error) {
+	result := &vo.BatchAuditResultVO{
+		Results: make([]*vo.BatchAuditItemResultVO, 0, len(req.Items)),
+	}
+	approvedIDs := make([]uint64, 0, len(req.Items))
+
+	// 1. 逐条审核，单条失败只记录结果，不中断整个批次
+	for i := range req.Items {
+		item := &req.Items[i]
+		itemResult := &vo.BatchAuditItemResultVO{PostID: item.PostID, Success: true}
+		if err := s.AuditPost(ctx, item, adminUserID); err != nil {
+			itemResult.Success = false
+			itemResult.Message = err.Error()
+			switch {
+			case errors.Is(err, commonerrors.ErrRepoNotFound):
+				itemResult.ErrorType = vo.BatchAuditErrorNotFound
+			case errors.Is(err, myErrors.ErrInvalidCopyright):
+				itemResult.ErrorType = vo.BatchAuditErrorInvalidCopyright
+			case errors.Is(err, myErrors.ErrPostIsDraft):
+				itemResult.ErrorType = vo.BatchAuditErrorIsDraft
+			default:
+				itemResult.ErrorType = vo.BatchAuditErrorInternal
+			}
+			result.FailureCount++
+		} else {
+			result.SuccessCount++
+			if item.Status == enums.Approved {
+				approvedIDs = append(approvedIDs, item.PostID)
+			}
+		}
+		result.Results = append(result.Results, itemResult)
+	}
+
+	s.logger.Info("管理员批量审核帖子完成",
+		zap.Int("total", len(req.Items)),
+		zap.Int("success", result.SuccessCount),
+		zap.Int("failure", result.FailureCount))
+
+	// 1.1 记录批量操作的汇总审计日志（逐条结果已由 AuditPost 各自记录）
+	batchResult := constant.AdminAuditResultSuccess
+	switch {
+	case result.SuccessCount == 0:
+		batchResult = constant.AdminAuditResultFailure
+	case result.FailureCount > 0:
+		batchResult = constant.AdminAuditResultPartial
+	}
+	s.auditLogSvc.Record(ctx, &AdminAuditEntry{
+		AdminUserID: adminUserID,
+		Action:      constant.AdminActionBatchAuditPosts,
+		TargetType:  constant.AdminAuditTargetPostBatch,
+		Params:      req,
+		Result:      batchResult,
+		Summary:     fmt.Sprintf("成功 %d 条，失败 %d 条", result.SuccessCount, result.FailureCount),
+	})
+
+	// 2. 对审核通过的帖子异步批量发送审核通过事件通知下游，失败只记录日志，不影响审核结果
+	// - 状态已由 AuditPost 直接写入；事件带本服务的来源标记，本服务的审核结果消费者不会再回写状态
+	// - 发送前按帖子当前状态过滤，期间被改判的帖子不再通知下游
+	if len(approvedIDs) > 0 && s.kafkaSvc != nil {
+		go func(postIDs []uint64) {
+			bgCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			built, err := s.buildPostEventData(bgCtx, postIDs)
+			if err != nil {
+				s.logger.Error("组装批量审核通过事件数据失败", zap.Error(err), zap.Int("count", len(postIDs)))
+				return
+			}
+			postsData := make([]kafkaevents.PostData, 0, len(built))
+			for _, data := range built {
+				if data.Status != enums.Approved {
+					s.logger.Info("帖子在批量审核后已被改判，不发送审核通过事件", zap.Uint64("postID", data.ID), zap.Any("status", data.Status))
+					continue
+				}
+				postsData = append(postsData, data)
+			}
+			if err := s.kafkaSvc.SendPostApprovedEvents(bgCtx, postsData); err != nil {
+				s.logger.Error("批量发送审核通过事件失败", zap.Error(err), zap.Int("count", len(postsData)))
+			}
+		}(approvedIDs)
+	}
+
+	return result, nil
+}
+
+// buildPostEventData 批量读取帖子、详情与图片，组装 Kafka 事件所需的 PostData（审核通过、恢复后重新送审等）。
+// - 缺少详情的帖子会被跳过并记录警告。
+func (s *postAdminService) buildPostEventData(ctx context.Context, postIDs []uint64) ([]kafkaevents.PostData, error) {
+	posts, err := s.postBatchRepo.GetPostsByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取帖子失败: %w", err)
+	}
+	details, err := s.postBatchRepo.GetPostDetailsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取帖子详情失败: %w", err)
+	}
+	detailMap := make(map[uint64]*entities.PostDetail, len(details))
+	detailIDs := make([]uint64, 0, len(details))
+	for _, d := range details {
+		detailMap[d.PostID] = d
+		detailIDs = append(detailIDs, d.ID)
+	}
+	imagesMap, err := s.postBatchRepo.BatchGetPostDetailImages(ctx, detailIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取帖子详情图失败: %w", err)
+	}
+
+	postsData := make([]kafkaevents.PostData, 0, len(posts))
+	for _, post := range posts {
+		detail, ok := detailMap[post.ID]
+		if !ok {
+			s.logger.Warn("帖子缺少详情记录，跳过事件发送", zap.Uint64("postID", post.ID))
+			continue
+		}
+		images := make([]kafkaevents.ImageEventData, 0, len(imagesMap[detail.ID]))
+		for _, img := range imagesMap[detail.ID] {
+			images = append(images, kafkaevents.ImageEventData{
+				ImageURL:     img.ImageURL,
+				ObjectKey:    img.ObjectKey,
+				DisplayOrder: img.DisplayOrder,
+			})
+		}
+		postsData = append(postsData, kafkaevents.PostData{
+			ID:             post.ID,
+			Title:          post.Title,
+			Content:        detail.Content,
+			AuthorID:       post.AuthorID,
+			AuthorAvatar:   post.AuthorAvatar,
+			AuthorUsername: post.AuthorUsername,
+			Status:         post.Status,
+			ViewCount:      post.ViewCount,
+			OfficialTag:    post.OfficialTags.Primary(), // 事件结构只有单个标签字段，取主标签
+			PricePerUnit:   detail.PricePerUnit,
+			ContactInfo:    detail.ContactInfo,
+			CreatedAt:      post.CreatedAt.UnixMilli(),
+			UpdatedAt:      post.UpdatedAt.UnixMilli(),
+			Images:         images,
+		})
+	}
+	return postsData, nil
+}
+
+// GetRecentViews 实现最近 N 分钟全站浏览量的查询。
+func (s *postAdminService) GetRecentViews(ctx context.Context, minutes int) (*vo.RecentViewsVO, error) {
+	total, err := s.postViewRepo.GetViewsInWindow(ctx, minutes)
+	if err != nil {
+		s.logger.Error("获取最近浏览量失败", zap.Error(err), zap.Int("minutes", minutes))
+		return nil, fmt.Errorf("获取最近 %d 分钟浏览量失败: %w", minutes, err)
+	}
+	return &vo.RecentViewsVO{Minutes: minutes, ViewCount: total}, nil
+}
+
+// GetPostStats 实现帖子数量统计，优先读取短期缓存。
+func (s *postAdminService) GetPostStats(ctx context.Context) (*vo.PostStatsVO, error) {
+	stats, err := s.postCache.GetPostStats(ctx)
+	if err == nil {
+		return stats, nil
+	}
+	if !errors.Is(err, myErrors.ErrCacheMiss) {
+		s.logger.Warn("读取帖子数量统计缓存失败，直接查询数据库", zap.Error(err))
+	}
+
+	statusCounts, drafts, err := s.postAdminRepo.CountPostsByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("按审核状态统计帖子数量失败: %w", err)
+	}
+	tagCounts, err := s.postAdminRepo.CountPostsByOfficialTag(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("按官方标签统计帖子数量失败: %w", err)
+	}
+
+	stats = &vo.PostStatsVO{
+		Total:         drafts,
+		Drafts:        drafts,
+		ByStatus:      make([]vo.PostStatusCountVO, 0, 3),
+		ByOfficialTag: make([]vo.PostTagCountVO, 0, len(tagCounts)),
+		GeneratedAt:   time.Now(),
+	}
+	// 每个状态都返回（没有帖子时为 0），看板无需补齐
+	for _, status := range []enums.Status{enums.Pending, enums.Approved, enums.Rejected} {
+		stats.ByStatus = append(stats.ByStatus, vo.PostStatusCountVO{Status: status, Count: statusCounts[status]})
+		stats.Total += statusCounts[status]
+	}
+	for tag, count := range tagCounts {
+		stats.ByOfficialTag = append(stats.ByOfficialTag, vo.PostTagCountVO{OfficialTag: tag, Count: count})
+	}
+	sort.Slice(stats.ByOfficialTag, func(i, j int) bool {
+		return stats.ByOfficialTag[i].OfficialTag < stats.ByOfficialTag[j].OfficialTag
+	})
+
+	if cacheErr := s.postCache.SetPostStats(ctx, stats, constant.PostStatsCacheTTL); cacheErr != nil {
+		s.logger.Warn("写入帖子数量统计缓存失败", zap.Error(cacheErr))
+	}
+	return stats, nil
+}
+
 // ListPostsByCondition 实现按条件查询帖子。
-// - 业务逻辑简单，主要依赖仓库层查询和结果转换。
+// - 携带游标时走游标分页（不统计总数，Total 为 -1），否则走 offset 分页。
+// - offset 模式只按创建时间排序且还有下一页时同样返回 NextCursor，首屏之后即可切换到游标分页。
 func (s *postAdminService) ListPostsByCondition(ctx context.Context, req *dto.ListPostsByConditionRequest) (*vo.ListPostsAdminByConditionResponse, error) {
-	// 直接调用仓库层进行查询。
-	posts, total, err := s.postAdminRepo.ListPostsByCondition(ctx, req)
+	var (
+		posts      []*entities.Post
+		total      int64
+		nextCursor *uint64
+		err        error
+	)
+	if req.CursorID != nil {
+		posts, nextCursor, err = s.postAdminRepo.ListPostsByConditionCursor(ctx, req)
+		total = -1
+	} else {
+		posts, total, err = s.postAdminRepo.ListPostsByCondition(ctx, req)
+		if err == nil && req.SortsByCreatedAtOnly() && len(posts) > 0 && int64((req.Page-1)*req.PageSize+len(posts)) < total {
+			nextCursor = &posts[len(posts)-1].ID
+		}
+	}
 	if err != nil {
 		s.logger.Error("管理员按条件查询帖子列表失败", zap.Error(err), zap.Any("request", req)) // 注意不要泄露敏感信息
 		return nil, fmt.Errorf("查询帖子列表失败: %w", err)                               // 对上层隐藏具体数据库错误细节
@@ -123,7 +602,11 @@ func (s *postAdminService) ListPostsByCondition(ctx context.Context, req *dto.Li
 			AuthorAvatar:   post.AuthorAvatar,
 			Status:         post.Status,
 			ViewCount:      post.ViewCount,
-			OfficialTag:    post.OfficialTag,
+			OfficialTag:    post.OfficialTags.Primary(),
+			OfficialTags:   post.OfficialTags.Tags(),
+			CopyrightType:  post.CopyrightType,
+			RepostCount:    post.RepostCount,
+			QuotedPostID:   post.QuotedPostID,
 			CreatedAt:      post.CreatedAt,
 			UpdatedAt:      post.UpdatedAt,
 		})
@@ -131,17 +614,32 @@ func (s *postAdminService) ListPostsByCondition(ctx context.Context, req *dto.Li
 
 	// 构造响应。
 	response := &vo.ListPostsAdminByConditionResponse{
-		Posts: postResponses,
-		Total: total,
+		Posts:      postResponses,
+		Total:      total,
+		NextCursor: nextCursor,
 	}
 	s.logger.Debug("管理员按条件查询帖子列表成功", zap.Int("count", len(posts)), zap.Int64("total", total))
 	return response, nil
 }
 
 // UpdateOfficialTag 实现更新官方标签的逻辑。
-func (s *postAdminService) UpdateOfficialTag(ctx context.Context, req *dto.UpdateOfficialTagRequest) error {
-	// 直接调用仓库层执行更新。
-	err := s.postAdminRepo.UpdateOfficialTag(ctx, req.PostID, req.OfficialTag)
+func (s *postAdminService) UpdateOfficialTag(ctx context.Context, req *dto.UpdateOfficialTagRequest, adminUserID string) (err error) {
+	defer func() {
+		s.recordPostAudit(ctx, adminUserID, constant.AdminActionUpdateOfficialTag, req.PostID, req, err)
+	}()
+
+	// 先获取帖子，记录变更前的标签与状态用于审计
+	post, err := s.postRepo.GetPostByID(ctx, req.PostID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return fmt.Errorf("帖子(ID: %d)未找到: %w", req.PostID, err)
+		}
+		s.logger.Error("更新官方标签时获取帖子信息失败", zap.Error(err), zap.Uint64("postID", req.PostID))
+		return fmt.Errorf("获取帖子(ID: %d)信息失败: %w", req.PostID, err)
+	}
+
+	// 调用仓库层执行更新。
+	err = s.postAdminRepo.UpdateOfficialTag(ctx, req.PostID, req.OfficialTag)
 	if err != nil {
 		// 记录日志并根据错误类型返回。
 		logFields := []zap.Field{
@@ -156,16 +654,237 @@ func (s *postAdminService) UpdateOfficialTag(ctx context.Context, req *dto.Updat
 		return fmt.Errorf("更新帖子(ID: %d)官方标签失败: %w", req.PostID, err)
 	}
 	s.logger.Info("管理员更新官方标签成功", zap.Uint64("postID", req.PostID), zap.Any("tag", req.OfficialTag))
+	s.invalidatePostDetailCache(ctx, req.PostID)
+	s.recordPostTransition(ctx, &entities.PostAuditLog{
+		PostID:      req.PostID,
+		AdminUserID: adminUserID,
+		Action:      constant.AdminActionUpdateOfficialTag,
+		OldStatus:   post.Status,
+		NewStatus:   post.Status,
+		Reason:      officialTagChangeReason(post.OfficialTags, entities.OfficialTagFlag(req.OfficialTag)),
+	})
+
+	// 已公开的帖子被打上新的官方标签时，推送给订阅了新标签的用户；待审核帖子在审核通过时再推送
+	if post.Status == enums.Approved && !post.OfficialTags.Has(req.OfficialTag) {
+		s.notifyTagSubscribers(post, req.OfficialTag)
+	}
 	return nil
 }
 
+// AddOfficialTagFlag 实现追加官方标签的逻辑。
+func (s *postAdminService) AddOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag, adminUserID string) (err error) {
+	defer func() {
+		s.recordPostAudit(ctx, adminUserID, constant.AdminActionAddOfficialTag, postID, map[string]any{"official_tag": tag}, err)
+	}()
+	post, err := s.updateOfficialTagFlag(ctx, postID, tag, adminUserID, constant.AdminActionAddOfficialTag, s.postAdminRepo.AddOfficialTagFlag,
+		func(mask entities.OfficialTagMask) entities.OfficialTagMask {
+			return mask | entities.OfficialTagFlag(tag)
+		})
+	if err != nil {
+		return err
+	}
+	// 已公开的帖子新增标签时推送给该标签的订阅者；待审核帖子在审核通过时再推送
+	if post.Status == enums.Approved && !post.OfficialTags.Has(tag) {
+		s.notifyTagSubscribers(post, tag)
+	}
+	return nil
+}
+
+// RemoveOfficialTagFlag 实现移除官方标签的逻辑。
+func (s *postAdminService) RemoveOfficialTagFlag(ctx context.Context, postID uint64, tag enums.OfficialTag, adminUserID string) (err error) {
+	defer func() {
+		s.recordPostAudit(ctx, adminUserID, constant.AdminActionRemoveOfficialTag, postID, map[string]any{"official_tag": tag}, err)
+	}()
+	_, err = s.updateOfficialTagFlag(ctx, postID, tag, adminUserID, constant.AdminActionRemoveOfficialTag, s.postAdminRepo.RemoveOfficialTagFlag,
+		func(mask entities.OfficialTagMask) entities.OfficialTagMask {
+			return mask &^ entities.OfficialTagFlag(tag)
+		})
+	return err
+}
+
+// updateOfficialTagFlag 是追加与移除单个官方标签的公共流程：校验标签、读取变更前的帖子、按位更新并写入状态流转日志。
+// - 返回变更前的帖子，调用方据此判断是否需要推送标签订阅。
+// - apply 只用于计算日志中变更后的标签，实际更新由 update 在数据库中按位完成，不会覆盖并发写入的其他标签。
+func (s *postAdminService) updateOfficialTagFlag(
+	ctx context.Context,
+	postID uint64,
+	tag enums.OfficialTag,
+	adminUserID string,
+	action string,
+	update func(ctx context.Context, postID uint64, tag enums.OfficialTag) error,
+	apply func(mask entities.OfficialTagMask) entities.OfficialTagMask,
+) (*entities.Post, error) {
+	if tag == enums.OfficialTagNone || !isValidOfficialTag(tag) {
+		return nil, myErrors.ErrInvalidOfficialTag
+	}
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, fmt.Errorf("帖子(ID: %d)未找到: %w", postID, err)
+		}
+		return nil, fmt.Errorf("获取帖子(ID: %d)信息失败: %w", postID, err)
+	}
+	if err := update(ctx, postID, tag); err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, fmt.Errorf("帖子(ID: %d)未找到: %w", postID, err)
+		}
+		return nil, fmt.Errorf("更新帖子(ID: %d)官方标签失败: %w", postID, err)
+	}
+	s.logger.Info("管理员按位更新官方标签成功", zap.Uint64("postID", postID), zap.String("action", action), zap.Int("tag", int(tag)))
+	s.invalidatePostDetailCache(ctx, postID)
+	s.recordPostTransition(ctx, &entities.PostAuditLog{
+		PostID:      postID,
+		AdminUserID: adminUserID,
+		Action:      action,
+		OldStatus:   post.Status,
+		NewStatus:   post.Status,
+		Reason:      officialTagChangeReason(post.OfficialTags, apply(post.OfficialTags)),
+	})
+	return post, nil
+}
+
+// officialTagChangeReason 生成官方标签变更的状态流转日志说明，例如 "官方标签 [1] -> [1 3]"。
+func officialTagChangeReason(before, after entities.OfficialTagMask) string {
+	return fmt.Sprintf("官方标签 %v -> %v", before.Tags(), after.Tags())
+}
+
+// isValidOfficialTag 判断官方标签是否在合法的枚举范围内（无标签或已定义展示名称的标签）。
+func isValidOfficialTag(tag enums.OfficialTag) bool {
+	if tag == enums.OfficialTagNone {
+		return true
+	}
+	_, ok := constant.OfficialTagLabels[tag]
+	return ok
+}
+
+// BatchUpdateOfficialTag 实现批量更新官方标签的逻辑。
+func (s *postAdminService) BatchUpdateOfficialTag(ctx context.Context, postIDs []uint64, tag enums.OfficialTag, adminUserID string) (result *vo.BatchResultVO, err error) {
+	if !isValidOfficialTag(tag) {
+		return nil, myErrors.ErrInvalidOfficialTag
+	}
+
+	// 1. 去重，保持请求顺序
+	seen := make(map[uint64]struct{}, len(postIDs))
+	uniqueIDs := make([]uint64, 0, len(postIDs))
+	for _, id := range postIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniqueIDs = append(uniqueIDs, id)
+	}
+
+	defer func() {
+		entry := &AdminAuditEntry{
+			AdminUserID: adminUserID,
+			Action:      constant.AdminActionBatchOfficialTag,
+			TargetType:  constant.AdminAuditTargetPostBatch,
+			Params:      map[string]any{"post_ids": uniqueIDs, "official_tag": tag},
+			Err:         err,
+		}
+		if result != nil {
+			entry.Summary = fmt.Sprintf("更新 %d 条，未找到 %d 条", result.AffectedCount, len(result.NotFoundIDs))
+			switch {
+			case result.AffectedCount == 0:
+				entry.Result = constant.AdminAuditResultFailure
+			case len(result.NotFoundIDs) > 0:
+				entry.Result = constant.AdminAuditResultPartial
+			}
+		}
+		s.auditLogSvc.Record(ctx, entry)
+	}()
+
+	// 2. 先读取变更前的标签与状态，用于状态流转日志与标签订阅推送
+	posts, err := s.postBatchRepo.GetPostsByIDs(ctx, uniqueIDs)
+	if err != nil {
+		s.logger.Error("批量更新官方标签时获取帖子失败", zap.Error(err), zap.Int("count", len(uniqueIDs)))
+		return nil, fmt.Errorf("获取帖子信息失败: %w", err)
+	}
+
+	// 3. 单条 UPDATE 完成批量更新
+	affected, notFoundIDs, err := s.postAdminRepo.BatchUpdateOfficialTag(ctx, uniqueIDs, tag)
+	if err != nil {
+		return nil, fmt.Errorf("批量更新官方标签失败: %w", err)
+	}
+	result = &vo.BatchResultVO{
+		Total:         len(uniqueIDs),
+		AffectedCount: affected,
+		NotFoundIDs:   notFoundIDs,
+	}
+	s.logger.Info("管理员批量更新官方标签完成",
+		zap.Int("total", result.Total),
+		zap.Int64("affected", affected),
+		zap.Int("notFound", len(notFoundIDs)),
+		zap.Any("tag", tag),
+		zap.String("adminUserID", adminUserID))
+
+	// 4. 为实际更新的帖子写入状态流转日志并推送标签订阅（读取之后被并发删除的帖子已计入 notFoundIDs）
+	missing := make(map[uint64]struct{}, len(notFoundIDs))
+	for _, id := range notFoundIDs {
+		missing[id] = struct{}{}
+	}
+	logs := make([]*entities.PostAuditLog, 0, len(posts))
+	for _, post := range posts {
+		if _, ok := missing[post.ID]; ok {
+			continue
+		}
+		s.invalidatePostDetailCache(ctx, post.ID)
+		logs = append(logs, &entities.PostAuditLog{
+			PostID:      post.ID,
+			AdminUserID: adminUserID,
+			Action:      constant.AdminActionBatchOfficialTag,
+			OldStatus:   post.Status,
+			NewStatus:   post.Status,
+			Reason:      officialTagChangeReason(post.OfficialTags, entities.OfficialTagFlag(tag)),
+		})
+		if post.Status == enums.Approved && !post.OfficialTags.Has(tag) {
+			s.notifyTagSubscribers(post, tag)
+		}
+	}
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.AdminAuditWriteTimeout)
+	defer cancel()
+	if logErr := s.postAuditRepo.BatchCreatePostAuditLogs(writeCtx, logs); logErr != nil {
+		s.logger.Error("批量写入帖子审计日志失败（不影响主操作）", zap.Error(logErr), zap.Int("count", len(logs)), zap.String("adminUserID", adminUserID))
+	}
+	return result, nil
+}
+
 // DeletePostByAdmin 实现管理员删除帖子的逻辑（包含事务和详情删除）。
-func (s *postAdminService) DeletePostByAdmin(ctx context.Context, postID uint64, adminUserID string) error {
+func (s *postAdminService) DeletePostByAdmin(ctx context.Context, postID uint64, adminUserID string, confirm bool) (err error) {
+	defer func() {
+		s.recordPostAudit(ctx, adminUserID, constant.AdminActionDeletePost, postID, map[string]bool{"confirm": confirm}, err)
+	}()
+
 	// 1. 记录管理员操作开始日志
 	s.logger.Info("管理员开始删除帖子", zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
 
+	// 1.1 获取删除前的帖子状态用于审计；帖子不存在时与删除失败的处理保持一致
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return fmt.Errorf("管理员尝试删除的帖子(ID: %d)未找到: %w", postID, err)
+		}
+		s.logger.Error("管理员删除帖子时获取帖子信息失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子(ID: %d)信息失败: %w", postID, err)
+	}
+
+	// 1.2 高浏览量帖子需要二次确认，未确认时返回影响面信息
+	if !confirm {
+		if confirmErr := s.checkDeleteConfirm(ctx, post); confirmErr != nil {
+			s.logger.Info("删除高浏览量帖子需要二次确认", zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID), zap.Int64("viewCount", post.ViewCount))
+			return confirmErr
+		}
+	}
+
+	// 1.3 读取详情图片，删除成功后投递到 COS 延迟删除队列
+	_, _, images, err := s.postAdminRepo.GetPostFullDetail(ctx, postID)
+	if err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		s.logger.Error("管理员删除帖子时获取帖子图片失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子(ID: %d)图片失败: %w", postID, err)
+	}
+
 	// 2. 使用事务确保 Post 和 PostDetail 的删除是原子的
-	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 2.1. 软删除 Post 记录
 		//     调用 PostRepository 的 DeletePost 方法
 		if repoErr := s.postRepo.DeletePost(ctx, tx, postID); repoErr != nil {
@@ -210,17 +929,224 @@ func (s *postAdminService) DeletePostByAdmin(ctx context.Context, postID uint64,
 		return fmt.Errorf("管理员删除帖子(ID: %d)时发生错误: %w", postID, err)
 	}
 
-	// 4. 记录操作成功日志
+	// 4. 主动清除详情缓存，失败只记录日志
+	s.invalidatePostDetailCache(ctx, postID)
+
+	// 4.1 图片保留一段时间以便恢复，超过保留期后由 COS 延迟删除任务删除
+	enqueuePostImageDeletion(ctx, s.cosDeleteQueue, s.logger, postID, images)
+
+	// 4.2 记录操作成功日志与帖子审计日志
 	s.logger.Info("管理员删除帖子成功", zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
+	s.recordPostTransition(ctx, &entities.PostAuditLog{
+		PostID:      postID,
+		AdminUserID: adminUserID,
+		Action:      constant.AdminActionDeletePost,
+		OldStatus:   post.Status,
+		NewStatus:   post.Status,
+	})
 
 	//
 	// 5. 触发管理员删除帖子的特定事件，如果需要的话
+	deleteMeta := producer.PostDeleteMeta{
+		AuthorID:  post.AuthorID,
+		DeletedAt: time.Now(),
+		Source:    constant.PostDeleteSourceAdmin,
+	}
 	go func(postID uint64) {
 		bgCtx := context.Background()
-		if kafkaErr := s.kafkaSvc.SendPostDeleteEvent(bgCtx, postID); kafkaErr != nil {
+		if kafkaErr := s.kafkaSvc.SendPostDeleteEvent(bgCtx, postID, deleteMeta); kafkaErr != nil {
 			s.logger.Error("发送 Kafka 删除事件失败", zap.Error(kafkaErr), zap.Uint64("post_id", postID))
 		}
 	}(postID)
 
 	return nil
 }
+
+// checkDeleteConfirm 判断删除该帖子是否需要二次确认，需要时返回 *DeleteConfirmRequiredError。
+// - 浏览量取 MySQL 中的持久化值；热榜排名查询失败只记录日志，按不在热榜处理，不阻塞判断。
+func (s *postAdminService) checkDeleteConfirm(ctx context.Context, post *entities.Post) error {
+	threshold := s.deleteCfg.ConfirmViewThreshold
+	if threshold == 0 {
+		threshold = constant.DefaultDeleteConfirmViewThreshold
+	}
+	if threshold < 0 || post.ViewCount <= threshold {
+		return nil
+	}
+
+	hotRank, err := s.postCache.GetPostRank(ctx, post.ID)
+	if err != nil {
+		s.logger.Warn("查询帖子热榜排名失败，按不在热榜处理", zap.Error(err), zap.Uint64("postID", post.ID))
+		hotRank = -1
+	}
+	return &DeleteConfirmRequiredError{
+		PostID:    post.ID,
+		ViewCount: post.ViewCount,
+		Threshold: threshold,
+		HotRank:   hotRank,
+	}
+}
+
+// ListReportedPosts 实现被举报帖子的分页查询。
+func (s *postAdminService) ListReportedPosts(ctx context.Context, page, pageSize int) (*vo.ListReportedPostsVO, error) {
+	posts, total, err := s.postReportRepo.ListReportedPosts(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		s.logger.Error("查询被举报帖子列表失败", zap.Error(err), zap.Int("page", page), zap.Int("pageSize", pageSize))
+		return nil, fmt.Errorf("查询被举报帖子列表失败: %w", err)
+	}
+
+	result := &vo.ListReportedPostsVO{
+		Posts: make([]*vo.ReportedPostVO, 0, len(posts)),
+		Total: total,
+	}
+	for _, p := range posts {
+		result.Posts = append(result.Posts, &vo.ReportedPostVO{
+			PostID:         p.PostID,
+			Title:          p.Title,
+			AuthorID:       p.AuthorID,
+			AuthorUsername: p.AuthorUsername,
+			Status:         p.Status,
+			ReportCount:    p.ReportCount,
+			LastReportedAt: p.LastReportedAt,
+		})
+	}
+	return result, nil
+}
+
+// ListPostAuditLogs 实现单个帖子审计历史的分页查询。
+func (s *postAdminService) ListPostAuditLogs(ctx context.Context, postID uint64, page, pageSize int) (*vo.ListPostAuditLogsVO, error) {
+	logs, total, err := s.postAuditRepo.ListPostAuditLogs(ctx, postID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		s.logger.Error("查询帖子审计历史失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("查询帖子(ID: %d)审计历史失败: %w", postID, err)
+	}
+
+	result := &vo.ListPostAuditLogsVO{
+		Logs:  make([]*vo.PostAuditLogVO, 0, len(logs)),
+		Total: total,
+	}
+	for _, l := range logs {
+		result.Logs = append(result.Logs, &vo.PostAuditLogVO{
+			ID:          l.ID,
+			PostID:      l.PostID,
+			AdminUserID: l.AdminUserID,
+			Action:      l.Action,
+			OldStatus:   l.OldStatus,
+			NewStatus:   l.NewStatus,
+			Reason:      l.Reason,
+			CreatedAt:   l.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RestorePost 实现管理员恢复已删除帖子的逻辑。
+func (s *postAdminService) RestorePost(ctx context.Context, postID uint64, adminUserID string) (err error) {
+	defer func() { s.recordPostAudit(ctx, adminUserID, constant.AdminActionRestorePost, postID, nil, err) }()
+
+	s.logger.Info("管理员开始恢复帖子", zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
+
+	// 1. 读取将随帖子一起恢复的详情与图片（与 RestorePost 的恢复范围一致），组装待审核事件
+	post, detail, images, err := s.postAdminRepo.GetPostFullDetail(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			s.logger.Warn("管理员恢复帖子失败", zap.Error(err), zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
+			return err
+		}
+		s.logger.Error("恢复帖子时获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("管理员恢复帖子(ID: %d)时发生错误: %w", postID, err)
+	}
+	if !post.DeletedAt.Valid {
+		return myErrors.ErrPostNotDeleted
+	}
+	if detail == nil {
+		// 缺少详情记录时仍以空正文送审，避免帖子停留在待审核状态
+		s.logger.Warn("恢复的帖子缺少详情记录，以空正文送审", zap.Uint64("postID", postID))
+		detail = &entities.PostDetail{PostID: postID}
+	}
+	// 恢复由管理员发起，合规预检只用于标记目标地区与命中的敏感词，不拦截送审
+	regions, regionErr := loadTargetRegions(ctx, s.targetingRepo, postID)
+	if regionErr != nil {
+		s.logger.Error("获取恢复帖子的目标地区失败，按无地区定向送审", zap.Error(regionErr), zap.Uint64("post_id", postID))
+	}
+	compliance := s.compliance.Check(regions, post.Title, detail.Content)
+	post.Status = enums.Pending
+
+	// 2. 在事务中恢复帖子及其关联数据，并写入待审核事件的发件箱记录
+	var auditEvent *entities.OutboxEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if repoErr := s.postAdminRepo.RestorePost(ctx, tx, postID); repoErr != nil {
+			return repoErr
+		}
+		event, outboxErr := s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+			// 沿用帖子创建时确定的审核优先级
+			return s.kafkaSvc.NewPostPendingAuditOutboxEvent(newPendingAuditPostData(post, detail, images), compliance.PendingAuditMeta(post.AuditPriority))
+		})
+		if outboxErr != nil {
+			return outboxErr
+		}
+		auditEvent = event
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) || errors.Is(err, myErrors.ErrPostNotDeleted) {
+			s.logger.Warn("管理员恢复帖子失败", zap.Error(err), zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
+			return err
+		}
+		s.logger.Error("管理员恢复帖子事务失败", zap.Error(err), zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
+		return fmt.Errorf("管理员恢复帖子(ID: %d)时发生错误: %w", postID, err)
+	}
+	s.logger.Info("管理员恢复帖子成功，等待重新审核", zap.Uint64("postID", postID), zap.String("adminUserID", adminUserID))
+	s.relayOutboxEventAsync(auditEvent, postID)
+	s.invalidatePostDetailCache(ctx, postID)
+
+	// 3. 恢复的图片不再需要删除，从 COS 延迟删除队列中移除
+	cancelPostImageDeletion(ctx, s.cosDeleteQueue, s.logger, postID, images)
+	return nil
+}
+
+// GetPostFullDetail 实现管理员查询帖子完整详情。
+func (s *postAdminService) GetPostFullDetail(ctx context.Context, postID uint64) (*vo.AdminPostDetailVO, error) {
+	post, detail, images, err := s.postAdminRepo.GetPostFullDetail(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("管理员查询帖子完整详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("查询帖子(ID: %d)完整详情失败: %w", postID, err)
+	}
+
+	result := &vo.AdminPostDetailVO{
+		ID:             post.ID,
+		CreatedAt:      post.CreatedAt,
+		UpdatedAt:      post.UpdatedAt,
+		Title:          post.Title,
+		AuthorID:       post.AuthorID,
+		AuthorAvatar:   post.AuthorAvatar,
+		AuthorUsername: post.AuthorUsername,
+		Status:         post.Status,
+		AuditReason:    post.AuditReason.String,
+		AuditPriority:  post.AuditPriority,
+		IsDraft:        post.IsDraft,
+		IsDeleted:      post.DeletedAt.Valid,
+		ViewCount:      post.ViewCount,
+		LikeCount:      post.LikeCount,
+		RepostCount:    post.RepostCount,
+		OfficialTag:    post.OfficialTags.Primary(),
+		OfficialTags:   post.OfficialTags.Tags(),
+		CopyrightType:  post.CopyrightType,
+		SourceURL:      post.SourceURL,
+		AccessPolicy:   post.AccessPolicy,
+		QuotedPostID:   post.QuotedPostID,
+		Images:         vo.NewPostImageVOsFromEntities(images),
+	}
+	if post.DeletedAt.Valid {
+		deletedAt := post.DeletedAt.Time
+		result.DeletedAt = &deletedAt
+	}
+	if detail != nil {
+		result.Content = detail.Content
+		result.PricePerUnit = detail.PricePerUnit
+		result.ContactInfo = detail.ContactInfo
+	}
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// AdminAuditEntry 描述一次待记录的管理员操作。
+type AdminAuditEntry struct {
+	AdminUserID string // 操作人
+	Action      string // 操作类型，参考 constant.AdminAction*
+	TargetType  string // 目标类型，参考 constant.AdminAuditTarget*
+	TargetID    string // 目标 ID，批量操作为空
+	Params      any    // 操作参数，序列化为 JSON 保存
+	Err         error  // 操作返回的错误，nil 表示成功
+	Result      string // 显式指定的结果（如批量操作的 partial），为空时按 Err 推断
+	Summary     string // 结果摘要，Err 为 nil 时写入 ErrorMessage 列（如批量操作的成功/失败数量）
+}
+
+// AdminAuditLogService 定义管理员操作审计日志的记录与查询。
+type AdminAuditLogService interface {
+	// Record 记录一条管理员操作审计日志。
+	// - 写入与主操作解耦：使用脱离请求取消的上下文，失败只记录 zap 日志，不返回错误，不影响主操作。
+	Record(ctx context.Context, entry *AdminAuditEntry)
+
+	// ListAuditLogs 按条件分页查询审计日志，按操作时间倒序。
+	ListAuditLogs(ctx context.Context, req *dto.ListAdminAuditLogsRequest) (*vo.ListAdminAuditLogsVO, error)
+}
+
+// adminAuditLogService 是 AdminAuditLogService 接口的实现。
+type adminAuditLogService struct {
+	auditLogRepo mysql.AdminAuditLogRepository
+	logger       *core.ZapLogger
+}
+
+// NewAdminAuditLogService 初始化管理员操作审计日志服务。
+func NewAdminAuditLogService(auditLogRepo mysql.AdminAuditLogRepository, logger *core.ZapLogger) AdminAuditLogService {
+	return &adminAuditLogService{
+		auditLogRepo: auditLogRepo,
+		logger:       logger,
+	}
+}
+
+// Record 实现审计日志的写入。
+func (s *adminAuditLogService) Record(ctx context.Context, entry *AdminAuditEntry) {
+	log := &entities.AdminAuditLog{
+		AdminUserID:  entry.AdminUserID,
+		Action:       entry.Action,
+		TargetType:   entry.TargetType,
+		TargetID:     entry.TargetID,
+		Result:       entry.Result,
+		ErrorMessage: entry.Summary,
+	}
+	if entry.Err != nil {
+		log.ErrorMessage = entry.Err.Error()
+		if log.Result == "" {
+			log.Result = constant.AdminAuditResultFailure
+		}
+	}
+	if log.Result == "" {
+		log.Result = constant.AdminAuditResultSuccess
+	}
+	if runes := []rune(log.ErrorMessage); len(runes) > constant.AdminAuditErrorMessageMaxLen {
+		log.ErrorMessage = string(runes[:constant.AdminAuditErrorMessageMaxLen])
+	}
+	if entry.Params != nil {
+		if params, err := json.Marshal(entry.Params); err != nil {
+			s.logger.Warn("序列化审计日志参数失败，参数将不被记录", zap.Error(err), zap.String("action", entry.Action))
+		} else {
+			log.Params = string(params)
+		}
+	}
+
+	// 请求可能已结束或被取消，审计日志仍需落库
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.AdminAuditWriteTimeout)
+	defer cancel()
+	if err := s.auditLogRepo.CreateAuditLog(writeCtx, log); err != nil {
+		s.logger.Error("写入管理员审计日志失败（不影响主操作）", zap.Error(err),
+			zap.String("adminUserID", log.AdminUserID),
+			zap.String("action", log.Action),
+			zap.String("targetID", log.TargetID),
+			zap.String("result", log.Result))
+	}
+}
+
+// ListAuditLogs 实现审计日志的分页查询。
+func (s *adminAuditLogService) ListAuditLogs(ctx context.Context, req *dto.ListAdminAuditLogsRequest) (*vo.ListAdminAuditLogsVO, error) {
+	logs, total, err := s.auditLogRepo.ListAuditLogs(ctx, req, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		s.logger.Error("查询管理员审计日志失败", zap.Error(err), zap.Any("request", req))
+		return nil, fmt.Errorf("查询管理员审计日志失败: %w", err)
+	}
+
+	result := &vo.ListAdminAuditLogsVO{
+		Logs:  make([]*vo.AdminAuditLogVO, 0, len(logs)),
+		Total: total,
+	}
+	for _, l := range logs {
+		result.Logs = append(result.Logs, &vo.AdminAuditLogVO{
+			ID:           l.ID,
+			AdminUserID:  l.AdminUserID,
+			Action:       l.Action,
+			TargetType:   l.TargetType,
+			TargetID:     l.TargetID,
+			Params:       l.Params,
+			Result:       l.Result,
+			ErrorMessage: l.ErrorMessage,
+			CreatedAt:    l.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// postAuditTarget 返回单个帖子作为审计目标时的 TargetID。
+func postAuditTarget(postID uint64) string {
+	return strconv.FormatUint(postID, 10)
+}
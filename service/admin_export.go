@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// adminPostExportHeader 是管理员导出帖子 CSV 的表头
+var adminPostExportHeader = []string{"id", "title", "author_username", "status", "view_count", "official_tag", "created_at"}
+
+// ExportPostsByCondition 实现按条件导出帖子 CSV。
+func (s *postAdminService) ExportPostsByCondition(ctx context.Context, req *dto.ExportPostsByConditionRequest, writer io.Writer) error {
+	// 复用游标分页查询，每批读取后立即写出并刷新，已写出的批次即可释放
+	batchReq := &dto.ListPostsByConditionRequest{
+		PostConditionFilter: req.PostConditionFilter,
+		OrderDesc:           req.OrderDesc,
+		PageSize:            constant.AdminPostExportBatchSize,
+	}
+
+	if _, err := io.WriteString(writer, "\xEF\xBB\xBF"); err != nil { // UTF-8 BOM，保证 Excel 打开中文不乱码
+		return fmt.Errorf("写入导出文件失败: %w", err)
+	}
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(adminPostExportHeader); err != nil {
+		return fmt.Errorf("写入导出表头失败: %w", err)
+	}
+
+	rowCount := 0
+	for {
+		posts, nextCursor, err := s.postAdminRepo.ListPostsByConditionCursor(ctx, batchReq)
+		if err != nil {
+			s.logger.Error("管理员导出帖子时查询失败", zap.Error(err), zap.Int("exportedRows", rowCount))
+			return fmt.Errorf("查询导出数据失败: %w", err)
+		}
+		for _, post := range posts {
+			record := []string{
+				strconv.FormatUint(post.ID, 10),
+				escapeCSVFormula(post.Title),
+				escapeCSVFormula(post.AuthorUsername),
+				strconv.Itoa(int(post.Status)),
+				strconv.FormatInt(post.ViewCount, 10),
+				formatOfficialTags(post.OfficialTags),
+				post.CreatedAt.Format(time.RFC3339),
+			}
+			if err := csvWriter.Write(record); err != nil {
+				return fmt.Errorf("写入导出数据失败: %w", err)
+			}
+		}
+		rowCount += len(posts)
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return fmt.Errorf("写入导出数据失败: %w", err)
+		}
+		if nextCursor == nil {
+			break
+		}
+		batchReq.CursorID = nextCursor
+	}
+
+	s.logger.Info("管理员导出帖子完成", zap.Int("rows", rowCount))
+	return nil
+}
+
+// escapeCSVFormula 为以公式字符开头的文本加上单引号前缀，防止在表格软件中打开时被当作公式执行（CSV 注入）。
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// formatOfficialTags 将官方标签位掩码格式化为导出列的值：多个标签以 "|" 分隔（如 "1|3"），无标签为 "0"。
+// - 只有一个标签的帖子与改为位掩码存储之前的导出结果一致。
+func formatOfficialTags(mask entities.OfficialTagMask) string {
+	tags := mask.Tags()
+	if len(tags) == 0 {
+		return "0"
+	}
+	parts := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		parts = append(parts, strconv.Itoa(int(tag)))
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// ResyncPosts 实现对账补偿。
+func (s *postAdminService) ResyncPosts(ctx context.Context, req *dto.ReconcileResyncRequest, adminUserID string) (*vo.ReconcileResyncResultVO, error) {
+	if s.kafkaSvc == nil {
+		return nil, myErrors.ErrEventPublishingUnavailable
+	}
+	postIDs := slices.Compact(slices.Sorted(slices.Values(req.PostIDs)))
+
+	result, err := s.resyncPosts(ctx, postIDs)
+	auditEntry := &AdminAuditEntry{
+		AdminUserID: adminUserID,
+		Action:      constant.AdminActionReconcileResync,
+		TargetType:  constant.AdminAuditTargetPostBatch,
+		Params:      req,
+		Err:         err,
+	}
+	if result != nil {
+		switch {
+		case len(result.FailedPostIDs) == len(postIDs):
+			auditEntry.Result = constant.AdminAuditResultFailure
+		case len(result.FailedPostIDs) > 0:
+			auditEntry.Result = constant.AdminAuditResultPartial
+		}
+		auditEntry.Summary = fmt.Sprintf("来源 %s：重新同步 %d 条，删除 %d 条，失败 %d 条",
+			req.Source, len(result.UpsertedPostIDs), len(result.RemovedPostIDs), len(result.FailedPostIDs))
+	}
+	s.auditLogSvc.Record(ctx, auditEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Warn("对账发现下游数据差异，已重新发送同步事件",
+		zap.String("source", req.Source),
+		zap.String("reason", req.Reason),
+		zap.Int("upserted", len(result.UpsertedPostIDs)),
+		zap.Int("removed", len(result.RemovedPostIDs)),
+		zap.Int("failed", len(result.FailedPostIDs)))
+	return result, nil
+}
+
+// resyncPosts 按帖子当前状态重新发送审核通过或删除事件。
+func (s *postAdminService) resyncPosts(ctx context.Context, postIDs []uint64) (*vo.ReconcileResyncResultVO, error) {
+	posts, err := s.postBatchRepo.GetPostsByIDs(ctx, postIDs)
+	if err != nil {
+		s.logger.Error("对账补偿批量获取帖子失败", zap.Error(err), zap.Int("count", len(postIDs)))
+		return nil, fmt.Errorf("批量获取帖子失败: %w", err)
+	}
+	approved := make(map[uint64]bool, len(posts))
+	approvedIDs := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		if post.Status == enums.Approved {
+			approved[post.ID] = true
+			approvedIDs = append(approvedIDs, post.ID)
+		}
+	}
+
+	result := &vo.ReconcileResyncResultVO{
+		UpsertedPostIDs: make([]uint64, 0, len(approvedIDs)),
+		RemovedPostIDs:  make([]uint64, 0, len(postIDs)-len(approvedIDs)),
+		FailedPostIDs:   make([]uint64, 0),
+	}
+
+	// 1. 公开的帖子：重新发送携带完整数据的审核通过事件
+	if len(approvedIDs) > 0 {
+		postsData, err := s.buildPostEventData(ctx, approvedIDs)
+		if err != nil {
+			s.logger.Error("对账补偿组装帖子事件数据失败", zap.Error(err), zap.Int("count", len(approvedIDs)))
+			return nil, fmt.Errorf("组装帖子事件数据失败: %w", err)
+		}
+		built := make(map[uint64]bool, len(postsData))
+		for _, data := range postsData {
+			built[data.ID] = true
+		}
+		if err := s.kafkaSvc.SendPostApprovedEvents(ctx, postsData); err != nil {
+			s.logger.Error("对账补偿发送审核通过事件失败", zap.Error(err), zap.Int("count", len(postsData)))
+			result.FailedPostIDs = append(result.FailedPostIDs, approvedIDs...)
+		} else {
+			for _, postID := range approvedIDs {
+				if built[postID] {
+					result.UpsertedPostIDs = append(result.UpsertedPostIDs, postID)
+				} else {
+					// 缺少详情的帖子无法组装事件
+					result.FailedPostIDs = append(result.FailedPostIDs, postID)
+				}
+			}
+		}
+	}
+
+	// 2. 不应对外可见的帖子（已删除、不存在、未审核通过）：发送删除事件，下游移除即可
+	for _, postID := range postIDs {
+		if approved[postID] {
+			continue
+		}
+		if err := s.kafkaSvc.SendPostDeleteEvent(ctx, postID, producer.PostDeleteMeta{Source: constant.PostDeleteSourceReconcile}); err != nil {
+			s.logger.Error("对账补偿发送删除事件失败", zap.Error(err), zap.Uint64("postID", postID))
+			result.FailedPostIDs = append(result.FailedPostIDs, postID)
+			continue
+		}
+		result.RemovedPostIDs = append(result.RemovedPostIDs, postID)
+	}
+	return result, nil
+}
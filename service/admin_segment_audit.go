@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+)
+
+// segmentVerdict 是一次审核结果在帖子正文段落上的合并方式。
+type segmentVerdict struct {
+	pending  bool  // 帖子有待审核的段落，即本次结果对应作者编辑后的送审
+	reused   bool  // 有沿用上次审核结果的段落，即本次是增量送审
+	rejected []int // 定位到的未通过段落 (SegmentIndex)，只在审核拒绝时计算
+}
+
+// loadSegmentVerdict 读取帖子的段落记录，计算审核结果在段落上的合并方式。
+// - 读取失败只记录日志，按没有段落记录处理：仍为待审核的段落在下次编辑时会重新送审，不会被误判为已通过。
+func (s *postAdminService) loadSegmentVerdict(ctx context.Context, req *dto.AuditPostRequest) segmentVerdict {
+	var verdict segmentVerdict
+	if req.Status == enums.Pending {
+		return verdict
+	}
+	records, err := s.segmentRepo.GetSegmentsByPostID(ctx, req.PostID)
+	if err != nil {
+		s.logger.Warn("审核帖子时读取正文段落失败，不按段落合并审核结果", zap.Error(err), zap.Uint64("postID", req.PostID))
+		return verdict
+	}
+	for _, record := range records {
+		if record.Status == enums.Pending {
+			verdict.pending = true
+		} else {
+			verdict.reused = true
+		}
+	}
+	if !verdict.pending || req.Status != enums.Rejected {
+		return verdict
+	}
+
+	// 审核服务未按段落回传时，按命中内容在当前正文中定位段落
+	var content string
+	if len(req.RejectedSegments) == 0 && len(req.MatchedContent) > 0 {
+		detail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, req.PostID)
+		if err != nil {
+			s.logger.Warn("审核帖子时读取正文失败，无法按命中内容定位未通过的段落", zap.Error(err), zap.Uint64("postID", req.PostID))
+		} else {
+			content = detail.Content
+		}
+	}
+	verdict.rejected = rejectedSegmentIndexes(records, content, req.RejectedSegments, req.MatchedContent)
+	return verdict
+}
+
+// applySegmentVerdict 在审核状态写入成功后合并段落的审核结果。
+//   - 审核通过：待审核的段落全部置为通过。
+//   - 审核拒绝：定位到的段落置为拒绝，本次送审的其余段落视为通过，作者只需修改未通过的段落；无法定位时本次送审的段落全部置为拒绝。
+//   - 写入失败只记录日志：仍为待审核的段落在下次编辑时会重新送审。
+//   - 增量送审通过后，审核服务发出的审核通过事件只包含变更的段落，补发一条带完整正文的审核通过事件供下游（如搜索服务）同步。
+func (s *postAdminService) applySegmentVerdict(ctx context.Context, req *dto.AuditPostRequest, verdict segmentVerdict) {
+	if !verdict.pending {
+		return
+	}
+	rest := enums.Approved
+	if req.Status == enums.Rejected && len(verdict.rejected) == 0 {
+		rest = enums.Rejected
+	}
+	if err := s.segmentRepo.ResolvePendingSegments(ctx, req.PostID, verdict.rejected, rest); err != nil {
+		s.logger.Warn("合并正文段落审核结果失败（下次编辑时待审核的段落会重新送审）", zap.Error(err), zap.Uint64("postID", req.PostID))
+	}
+	if req.Status == enums.Approved && verdict.reused && s.kafkaSvc != nil {
+		s.publishFullApprovedEvent(req.PostID)
+	}
+}
+
+// publishFullApprovedEvent 在后台补发带帖子完整数据的审核通过事件，发送前帖子已被改判时不再发送。
+// - 事件带本服务的来源标记，本服务的审核结果消费者不会再回写状态。
+func (s *postAdminService) publishFullApprovedEvent(postID uint64) {
+	go func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		postsData, err := s.buildPostEventData(bgCtx, []uint64{postID})
+		if err != nil {
+			s.logger.Error("组装增量审核通过后的完整帖子数据失败", zap.Error(err), zap.Uint64("postID", postID))
+			return
+		}
+		if len(postsData) == 0 || postsData[0].Status != enums.Approved {
+			s.logger.Info("帖子在增量审核通过后已被改判或删除，不补发审核通过事件", zap.Uint64("postID", postID))
+			return
+		}
+		if err := s.kafkaSvc.SendPostApprovedEvents(bgCtx, postsData); err != nil {
+			s.logger.Error("补发增量审核通过后的完整帖子数据失败", zap.Error(err), zap.Uint64("postID", postID))
+		}
+	}()
+}
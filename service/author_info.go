@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// AuthorInfoSyncService 定义同步帖子中作者冗余信息（头像、昵称）的接口。
+// - 帖子表中的作者头像与昵称是用户服务数据的冗余，用户修改资料后由用户信息变更事件触发同步。
+type AuthorInfoSyncService interface {
+	// SyncAuthorInfo 将作者全部帖子的冗余头像、昵称更新为新值，返回实际修改的帖子数。
+	// - avatar、username 为 nil 表示该字段未变更，不做修改。
+	// - 按 constant.AuthorInfoSyncBatchSize 分批更新，每批独立提交；中途失败时已完成的批次不回滚，重新执行会从剩余帖子继续。
+	// - 帖子详情、热门详情与时间线首页缓存不主动失效，按各自的 TTL 过期后展示新值。
+	SyncAuthorInfo(ctx context.Context, authorID string, avatar, username *string) (int64, error)
+}
+
+// authorInfoSyncService 是 AuthorInfoSyncService 接口的实现。
+type authorInfoSyncService struct {
+	postRepo mysql.PostRepository
+	logger   *core.ZapLogger
+}
+
+// NewAuthorInfoSyncService 创建 AuthorInfoSyncService 实例。
+func NewAuthorInfoSyncService(postRepo mysql.PostRepository, logger *core.ZapLogger) AuthorInfoSyncService {
+	return &authorInfoSyncService{
+		postRepo: postRepo,
+		logger:   logger,
+	}
+}
+
+// SyncAuthorInfo 实现作者冗余信息同步。
+func (s *authorInfoSyncService) SyncAuthorInfo(ctx context.Context, authorID string, avatar, username *string) (int64, error) {
+	if avatar == nil && username == nil {
+		return 0, nil
+	}
+	var total int64
+	for batch := 1; ; batch++ {
+		if err := ctx.Err(); err != nil {
+			return total, fmt.Errorf("同步作者信息在第 %d 批前中止: %w", batch, err)
+		}
+		affected, err := s.postRepo.UpdateAuthorInfoBatch(ctx, authorID, avatar, username, constant.AuthorInfoSyncBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("同步作者信息第 %d 批失败: %w", batch, err)
+		}
+		total += affected
+		if affected < constant.AuthorInfoSyncBatchSize {
+			break
+		}
+	}
+	s.logger.Info("作者冗余信息同步完成", zap.String("authorID", authorID), zap.Int64("updatedPosts", total))
+	return total, nil
+}
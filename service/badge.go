@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// BadgeService 定义浏览量里程碑徽章的颁发与查询接口。
+type BadgeService interface {
+	// AwardViewMilestones 根据刚写入 MySQL 的浏览量，为首次达到里程碑的帖子作者颁发徽章。
+	// - 由浏览量同步任务在写库成功后调用，保证徽章不会领先于已持久化的浏览量。
+	// - 已颁发过的里程碑会被跳过，重复调用是幂等的。
+	AwardViewMilestones(ctx context.Context, viewCounts map[uint64]int64) error
+
+	// GetAuthorBadges 查询作者获得的全部徽章。
+	GetAuthorBadges(ctx context.Context, authorID string) (*vo.AuthorBadgesVO, error)
+
+	// GetPostMilestoneProgress 查询帖子的里程碑进度。
+	// - 帖子不存在或未审核通过时返回 commonerrors.ErrRepoNotFound。
+	GetPostMilestoneProgress(ctx context.Context, postID uint64) (*vo.PostMilestoneProgressVO, error)
+}
+
+// badgeService 是 BadgeService 接口的实现。
+type badgeService struct {
+	badgeRepo     mysql.AuthorBadgeRepository
+	postRepo      mysql.PostRepository
+	postBatchRepo mysql.PostBatchOperationsRepository
+	logger        *core.ZapLogger
+}
+
+// NewBadgeService 初始化徽章服务。
+func NewBadgeService(badgeRepo mysql.AuthorBadgeRepository, postRepo mysql.PostRepository, postBatchRepo mysql.PostBatchOperationsRepository, logger *core.ZapLogger) BadgeService {
+	return &badgeService{
+		badgeRepo:     badgeRepo,
+		postRepo:      postRepo,
+		postBatchRepo: postBatchRepo,
+		logger:        logger,
+	}
+}
+
+// AwardViewMilestones 实现里程碑徽章的颁发。
+// - 只处理浏览量不低于最小里程碑的帖子，按批读取作者与已颁发的里程碑后一次性写入缺失的徽章。
+// - 单批失败不影响其他批次，失败的帖子在下次浏览量同步时会被重新检查。
+func (s *badgeService) AwardViewMilestones(ctx context.Context, viewCounts map[uint64]int64) error {
+	candidates := make([]uint64, 0)
+	for postID, count := range viewCounts {
+		if count >= constant.ViewMilestones[0] {
+			candidates = append(candidates, postID)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var (
+		awarded int64
+		errs    []error
+	)
+	for start := 0; start < len(candidates); start += constant.BadgeAwardBatchSize {
+		end := min(start+constant.BadgeAwardBatchSize, len(candidates))
+		n, err := s.awardBatch(ctx, candidates[start:end], viewCounts)
+		awarded += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if awarded > 0 {
+		s.logger.Info("已颁发浏览量里程碑徽章", zap.Int64("badges", awarded), zap.Int("candidatePosts", len(candidates)))
+	}
+	return errors.Join(errs...)
+}
+
+// awardBatch 为一批帖子颁发缺失的里程碑徽章，返回新增的徽章数量。
+func (s *badgeService) awardBatch(ctx context.Context, postIDs []uint64, viewCounts map[uint64]int64) (int64, error) {
+	awarded, err := s.badgeRepo.GetAwardedMilestones(ctx, postIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	// 先筛出存在未颁发里程碑的帖子，再读取其作者，已全部颁发的帖子无需查询
+	pending := make([]uint64, 0, len(postIDs))
+	for _, postID := range postIDs {
+		for _, milestone := range constant.ViewMilestones {
+			if viewCounts[postID] >= milestone && !awarded[postID][milestone] {
+				pending = append(pending, postID)
+				break
+			}
+		}
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	posts, err := s.postBatchRepo.GetPostsByIDs(ctx, pending)
+	if err != nil {
+		return 0, fmt.Errorf("获取待颁发徽章的帖子失败: %w", err)
+	}
+
+	badges := make([]*entities.AuthorBadge, 0, len(posts))
+	for _, post := range posts {
+		for _, milestone := range constant.ViewMilestones {
+			if viewCounts[post.ID] < milestone {
+				break
+			}
+			if awarded[post.ID][milestone] {
+				continue
+			}
+			badges = append(badges, &entities.AuthorBadge{AuthorID: post.AuthorID, PostID: post.ID, Milestone: milestone})
+		}
+	}
+	return s.badgeRepo.CreateBadges(ctx, badges)
+}
+
+// GetAuthorBadges 实现作者徽章的查询。
+func (s *badgeService) GetAuthorBadges(ctx context.Context, authorID string) (*vo.AuthorBadgesVO, error) {
+	badges, err := s.badgeRepo.ListBadgesByAuthor(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.AuthorBadgesVO{AuthorID: authorID, Badges: newAuthorBadgeVOs(badges)}, nil
+}
+
+// GetPostMilestoneProgress 实现帖子里程碑进度的查询。
+func (s *badgeService) GetPostMilestoneProgress(ctx context.Context, postID uint64) (*vo.PostMilestoneProgressVO, error) {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("查询里程碑进度时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if post.Status != enums.Approved {
+		return nil, commonerrors.ErrRepoNotFound
+	}
+	badges, err := s.badgeRepo.ListBadgesByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &vo.PostMilestoneProgressVO{
+		PostID:    postID,
+		ViewCount: post.ViewCount,
+		Achieved:  newAuthorBadgeVOs(badges),
+		Progress:  1,
+	}
+	for _, milestone := range constant.ViewMilestones {
+		if post.ViewCount < milestone {
+			next := milestone
+			progress.NextMilestone = &next
+			progress.NextBadgeName = constant.ViewMilestoneBadgeNames[milestone]
+			progress.Progress = float64(post.ViewCount) / float64(milestone)
+			break
+		}
+	}
+	return progress, nil
+}
+
+// newAuthorBadgeVOs 将徽章实体转换为 VO。
+func newAuthorBadgeVOs(badges []*entities.AuthorBadge) []*vo.AuthorBadgeVO {
+	result := make([]*vo.AuthorBadgeVO, 0, len(badges))
+	for _, b := range badges {
+		result = append(result, &vo.AuthorBadgeVO{
+			PostID:    b.PostID,
+			Milestone: b.Milestone,
+			BadgeName: constant.ViewMilestoneBadgeNames[b.Milestone],
+			AwardedAt: b.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,215 @@
+package service
+
+// 繁简转换使用的字符映射表与词组表。
+// - 只覆盖常用字，目标是让信息流和详情页的主体文字可读，而不是完整的 OpenCC 词库；未收录的字符原样保留。
+// - 不做地区用语转换（如“软件/軟體”），只做字形转换。
+
+// zhCharPairs 是“简繁”字符对，每两个字符为一组，按空白分隔。
+// - 简转繁时一简对多繁的字取最常用的繁体，其余用法由 zhS2TPhrases 覆盖。
+// - 繁转简由该表反向生成，再合并 zhT2SExtraChars。
+const zhCharPairs = "" +
+	"爱愛 碍礙 袄襖 罢罷 摆擺 败敗 颁頒 办辦 帮幫 绑綁 宝寶 饱飽 报報 贝貝 备備 笔筆 毕畢 边邊 编編 变變 " +
+	"标標 别別 宾賓 饼餅 并並 补補 财財 参參 残殘 蚕蠶 惭慚 惨慘 灿燦 仓倉 苍蒼 层層 产產 长長 尝嘗 场場 " +
+	"厂廠 车車 彻徹 尘塵 陈陳 衬襯 称稱 惩懲 诚誠 齿齒 冲衝 虫蟲 宠寵 丑醜 筹籌 础礎 处處 触觸 传傳 创創 " +
+	"疮瘡 纯純 词詞 辞辭 聪聰 从從 丛叢 错錯 达達 带帶 单單 担擔 胆膽 当當 党黨 档檔 导導 岛島 灯燈 邓鄧 " +
+	"敌敵 递遞 点點 电電 垫墊 钓釣 调調 东東 冻凍 动動 斗鬥 独獨 读讀 队隊 对對 吨噸 夺奪 堕墮 鹅鵝 额額 " +
+	"儿兒 尔爾 发發 罚罰 阀閥 范範 饭飯 访訪 纺紡 飞飛 废廢 费費 纷紛 坟墳 奋奮 愤憤 粪糞 丰豐 风風 疯瘋 " +
+	"冯馮 缝縫 讽諷 凤鳳 肤膚 辐輻 抚撫 辅輔 赋賦 复復 负負 妇婦 该該 盖蓋 赶趕 冈岡 刚剛 钢鋼 纲綱 岗崗 " +
+	"搁擱 鸽鴿 阁閣 个個 给給 巩鞏 沟溝 构構 购購 顾顧 关關 观觀 馆館 惯慣 贯貫 广廣 规規 归歸 龟龜 轨軌 " +
+	"柜櫃 贵貴 国國 过過 汉漢 号號 轰轟 后後 护護 沪滬 华華 划劃 画畫 话話 怀懷 坏壞 欢歡 环環 还還 换換 " +
+	"唤喚 挥揮 辉輝 汇匯 会會 绘繪 贿賄 秽穢 获獲 货貨 祸禍 击擊 机機 鸡雞 积積 极極 级級 际際 纪紀 记記 " +
+	"济濟 继繼 迹跡 几幾 计計 夹夾 价價 驾駕 艰艱 坚堅 监監 检檢 俭儉 减減 荐薦 见見 舰艦 剑劍 渐漸 贱賤 " +
+	"践踐 鉴鑑 键鍵 将將 奖獎 讲講 酱醬 胶膠 骄驕 娇嬌 脚腳 饺餃 搅攪 缴繳 较較 阶階 节節 洁潔 结結 杰傑 " +
+	"届屆 仅僅 紧緊 锦錦 尽盡 进進 劲勁 惊驚 经經 颈頸 镜鏡 竞競 旧舊 举舉 剧劇 据據 惧懼 觉覺 决決 绝絕 " +
+	"军軍 开開 凯凱 颗顆 壳殼 课課 垦墾 恳懇 库庫 裤褲 块塊 宽寬 矿礦 亏虧 扩擴 阔闊 腊臘 蜡蠟 来來 赖賴 " +
+	"兰蘭 拦攔 栏欄 蓝藍 篮籃 览覽 懒懶 烂爛 滥濫 劳勞 乐樂 垒壘 类類 离離 礼禮 里裡 历歷 厉厲 丽麗 励勵 " +
+	"连連 联聯 怜憐 帘簾 莲蓮 脸臉 练練 炼煉 恋戀 粮糧 两兩 辆輛 谅諒 疗療 辽遼 猎獵 临臨 邻鄰 灵靈 龄齡 " +
+	"铃鈴 领領 刘劉 龙龍 楼樓 录錄 陆陸 驴驢 虑慮 卢盧 鲁魯 乱亂 论論 轮輪 罗羅 萝蘿 逻邏 锣鑼 骆駱 络絡 " +
+	"妈媽 马馬 码碼 买買 卖賣 迈邁 麦麥 满滿 猫貓 贸貿 么麼 没沒 门門 们們 梦夢 弥彌 绵綿 庙廟 灭滅 鸣鳴 " +
+	"铭銘 谋謀 亩畝 纳納 难難 恼惱 脑腦 闹鬧 内內 腻膩 鸟鳥 宁寧 农農 浓濃 欧歐 盘盤 赔賠 喷噴 鹏鵬 骗騙 " +
+	"飘飄 频頻 贫貧 苹蘋 凭憑 评評 泼潑 颇頗 扑撲 铺鋪 谱譜 齐齊 骑騎 岂豈 启啟 气氣 弃棄 牵牽 铅鉛 迁遷 " +
+	"签簽 钱錢 浅淺 谴譴 枪槍 墙牆 强強 抢搶 桥橋 乔喬 侨僑 窍竅 亲親 轻輕 倾傾 庆慶 琼瓊 穷窮 区區 躯軀 " +
+	"趋趨 权權 劝勸 确確 让讓 扰擾 热熱 认認 荣榮 软軟 锐銳 润潤 洒灑 伞傘 丧喪 扫掃 杀殺 纱紗 晒曬 闪閃 " +
+	"陕陝 伤傷 赏賞 烧燒 绍紹 设設 摄攝 谁誰 审審 婶嬸 肾腎 渗滲 声聲 绳繩 胜勝 圣聖 师師 诗詩 狮獅 湿濕 " +
+	"时時 识識 实實 势勢 适適 释釋 饰飾 视視 试試 寿壽 兽獸 书書 输輸 属屬 术術 树樹 数數 帅帥 双雙 顺順 " +
+	"说說 硕碩 丝絲 饲飼 耸聳 颂頌 诉訴 肃肅 虽雖 随隨 岁歲 孙孫 损損 笋筍 缩縮 锁鎖 琐瑣 态態 谈談 叹嘆 " +
+	"汤湯 烫燙 涛濤 讨討 腾騰 题題 体體 条條 贴貼 铁鐵 厅廳 听聽 头頭 图圖 团團 颓頹 椭橢 袜襪 弯彎 湾灣 " +
+	"顽頑 万萬 网網 韦韋 违違 围圍 为為 伟偉 卫衛 纬緯 谓謂 闻聞 稳穩 问問 窝窩 卧臥 乌烏 务務 无無 雾霧 " +
+	"误誤 锡錫 牺犧 习習 戏戲 细細 虾蝦 吓嚇 峡峽 狭狹 厦廈 鲜鮮 闲閒 贤賢 显顯 险險 县縣 现現 线線 宪憲 " +
+	"献獻 乡鄉 详詳 响響 项項 协協 胁脅 写寫 谢謝 泻瀉 兴興 许許 续續 绪緒 选選 学學 寻尋 询詢 训訓 讯訊 " +
+	"逊遜 压壓 鸦鴉 亚亞 严嚴 颜顏 盐鹽 艳豔 验驗 阳陽 养養 样樣 杨楊 痒癢 谣謠 药藥 爷爺 页頁 业業 叶葉 " +
+	"医醫 仪儀 遗遺 亿億 忆憶 艺藝 议議 译譯 异異 谊誼 阴陰 银銀 饮飲 隐隱 应應 营營 樱櫻 鹰鷹 赢贏 拥擁 " +
+	"佣傭 涌湧 优優 忧憂 邮郵 犹猶 鱼魚 渔漁 与與 语語 狱獄 预預 誉譽 园園 员員 圆圓 远遠 愿願 约約 跃躍 " +
+	"钥鑰 阅閱 云雲 运運 韵韻 杂雜 灾災 载載 赞讚 凿鑿 枣棗 灶竈 责責 则則 泽澤 贼賊 赠贈 闸閘 诈詐 斋齋 " +
+	"债債 战戰 盏盞 崭嶄 张張 涨漲 帐帳 账賬 胀脹 赵趙 这這 针針 侦偵 诊診 阵陣 镇鎮 争爭 睁睜 挣掙 证證 " +
+	"郑鄭 织織 职職 执執 纸紙 质質 钟鐘 终終 种種 肿腫 众眾 轴軸 皱皺 昼晝 猪豬 烛燭 嘱囑 筑築 铸鑄 驻駐 " +
+	"专專 砖磚 转轉 赚賺 庄莊 装裝 妆妝 壮壯 状狀 准準 浊濁 资資 总總 纵縱 邹鄒 组組 钻鑽 着著 间間 吗嗎 " +
+	"脏髒 咸鹹 余餘 征徵 于於 " +
+	"净淨 请請 订訂 贷貸 赛賽 迟遲 链鏈 销銷 锅鍋 闭閉 韩韓 顶頂 须須 馒饅 驶駛 伪偽 侧側 俩倆 偿償 储儲 " +
+	"兑兌 凑湊 删刪 剂劑 剥剝 勋勳 却卻 厌厭 叠疊 哑啞 哗嘩 坛壇 坝壩 垄壟 娱娛 婴嬰 岭嶺 币幣 弹彈 径徑 " +
+	"恶惡 悦悅 拟擬 择擇 挂掛 挡擋 挤擠 掷擲 摊攤 断斷 晋晉 晓曉 暂暫 泪淚 测測 浏瀏 涂塗 涩澀 溃潰 滚滾 " +
+	"滤濾 炉爐 烟煙 烦煩 玛瑪 畅暢 简簡 纠糾 红紅 纹紋 绕繞 统統 绩績 维維 综綜 绿綠 缓緩 缘緣 肠腸 舱艙 " +
+	"苏蘇 虚虛 蚀蝕 蛮蠻 诸諸 贡貢 踪蹤 锋鋒 闯闖 顿頓 馈饋 驰馳 驱驅 鸭鴨 扬揚"
+
+// zhS2TPhrases 是简转繁时需要按词处理的一简对多繁用法，以及不应转换的固定词（映射到自身）。
+var zhS2TPhrases = map[string]string{
+	"头发":  "頭髮",
+	"理发":  "理髮",
+	"发型":  "髮型",
+	"白发":  "白髮",
+	"毛发":  "毛髮",
+	"发廊":  "髮廊",
+	"干净":  "乾淨",
+	"干燥":  "乾燥",
+	"饼干":  "餅乾",
+	"干杯":  "乾杯",
+	"干货":  "乾貨",
+	"晒干":  "曬乾",
+	"干部":  "幹部",
+	"干活":  "幹活",
+	"能干":  "能幹",
+	"干什么": "幹什麼",
+	"干嘛":  "幹嘛",
+	"骨干":  "骨幹",
+	"面条":  "麵條",
+	"面包":  "麵包",
+	"面粉":  "麵粉",
+	"拉面":  "拉麵",
+	"方便面": "方便麵",
+	"面食":  "麵食",
+	"台风":  "颱風",
+	"一只":  "一隻",
+	"两只":  "兩隻",
+	"几只":  "幾隻",
+	"日历":  "日曆",
+	"历法":  "曆法",
+	"农历":  "農曆",
+	"挂历":  "掛曆",
+	"复杂":  "複雜",
+	"重复":  "重複",
+	"复制":  "複製",
+	"复印":  "複印",
+	"复数":  "複數",
+	"答复":  "答覆",
+	"反复":  "反覆",
+	"复盖":  "覆蓋",
+	"皇后":  "皇后",
+	"太后":  "太后",
+	"王后":  "王后",
+	"公里":  "公里",
+	"里程":  "里程",
+	"千里":  "千里",
+	"邻里":  "鄰里",
+	"故里":  "故里",
+	"英里":  "英里",
+	"钟情":  "鍾情",
+	"批准":  "批准",
+	"准许":  "准許",
+	"关系":  "關係",
+	"联系":  "聯繫",
+	"系统":  "系統",
+	"放松":  "放鬆",
+	"轻松":  "輕鬆",
+	"松开":  "鬆開",
+	"松软":  "鬆軟",
+	"旅游":  "旅遊",
+	"游戏":  "遊戲",
+	"游客":  "遊客",
+	"游览":  "遊覽",
+	"心脏":  "心臟",
+	"内脏":  "內臟",
+	"肝脏":  "肝臟",
+	"征服":  "征服",
+	"长征":  "長征",
+	"出征":  "出征",
+	"征战":  "征戰",
+	"征途":  "征途",
+	"制造":  "製造",
+	"制作":  "製作",
+	"制品":  "製品",
+	"定制":  "訂製",
+	"复制品": "複製品",
+	"周末":  "週末",
+	"周刊":  "週刊",
+	"周年":  "週年",
+	"周报":  "週報",
+	"稻谷":  "稻穀",
+	"谷物":  "穀物",
+	"卷起":  "捲起",
+	"杂志":  "雜誌",
+	"标志":  "標誌",
+	"日志":  "日誌",
+	"北斗":  "北斗",
+	"漏斗":  "漏斗",
+	"冲洗":  "沖洗",
+	"冲泡":  "沖泡",
+	"尽管":  "儘管",
+	"尽量":  "儘量",
+	"合并":  "合併",
+	"吞并":  "吞併",
+	"收获":  "收穫",
+	"词汇":  "詞彙",
+	"手表":  "手錶",
+	"钟表":  "鐘錶",
+	"萝卜":  "蘿蔔",
+	"占卜":  "占卜",
+	"范围":  "範圍",
+	"只有":  "只有",
+	"只是":  "只是",
+}
+
+// zhT2SExtraChars 是反向生成的映射之外的繁转简字符，主要是多个繁体对应同一个简体的情况。
+var zhT2SExtraChars = map[rune]rune{
+	'乾': '干',
+	'幹': '干',
+	'麵': '面',
+	'颱': '台',
+	'隻': '只',
+	'曆': '历',
+	'複': '复',
+	'髮': '发',
+	'鍾': '钟',
+	'係': '系',
+	'繫': '系',
+	'鬆': '松',
+	'遊': '游',
+	'臟': '脏',
+	'製': '制',
+	'週': '周',
+	'穀': '谷',
+	'捲': '卷',
+	'誌': '志',
+	'儘': '尽',
+	'併': '并',
+	'穫': '获',
+	'彙': '汇',
+	'錶': '表',
+	'沖': '冲',
+	'裏': '里',
+	'蔔': '卜',
+	'贊': '赞',
+	'鑒': '鉴',
+	'眾': '众',
+	'衆': '众',
+	'綫': '线',
+	'臺': '台',
+	'喫': '吃',
+	'麼': '么',
+}
+
+// zhT2SKeptChars 是在简体中文中同样作为规范字使用的繁体字，繁转简时不做字符级转换，只在 zhT2SPhrases 中按词处理。
+var zhT2SKeptChars = map[rune]bool{
+	'著': true,
+}
+
+// zhT2SPhrases 是繁转简时需要按词处理的用法。
+var zhT2SPhrases = map[string]string{
+	"乾隆": "乾隆",
+	"乾坤": "乾坤",
+	"著名": "著名",
+	"著作": "著作",
+	"顯著": "顯著",
+	"覆蓋": "覆盖",
+	"答覆": "答复",
+	"反覆": "反复",
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/vo"
+)
+
+// ChineseScript 表示用户偏好的中文字形。
+type ChineseScript int
+
+const (
+	ChineseScriptOriginal    ChineseScript = iota // 不转换，返回原文
+	ChineseScriptSimplified                       // 转换为简体
+	ChineseScriptTraditional                      // 转换为繁体
+)
+
+// ChineseConverter 定义返回帖子时的繁简转换能力。
+// - 转换只作用于返回给客户端的副本，数据库与缓存中始终保存作者提交的原文。
+// - 一简对多繁的字按词组表处理常见用法，其余取最常用的写法，因此转换不保证可逆。
+type ChineseConverter interface {
+	// Convert 将文本转换为指定字形，script 为 ChineseScriptOriginal 时原样返回。
+	Convert(text string, script ChineseScript) string
+
+	// ConvertPostDetail 返回转换了标题、正文、FAQ 与原帖卡片标题的详情副本，不修改入参。
+	ConvertPostDetail(detail *vo.PostDetailVO, script ChineseScript) *vo.PostDetailVO
+
+	// ConvertPostResponses 返回转换了标题的列表副本，不修改入参。
+	ConvertPostResponses(posts []*vo.PostResponse, script ChineseScript) []*vo.PostResponse
+
+	// ConvertPostCards 返回转换了标题（含折叠的相似帖子）的卡片列表副本，不修改入参。
+	ConvertPostCards(cards []*vo.PostCardVO, script ChineseScript) []*vo.PostCardVO
+}
+
+// NewChineseConverter 根据配置创建繁简转换器。
+// - 配置关闭转换时返回原样透传的实现，调用方无需判断开关。
+func NewChineseConverter(cfg config.ChineseConvertConfig) ChineseConverter {
+	if !cfg.Enabled {
+		return noopChineseConverter{}
+	}
+	cacheSize := cfg.CacheSize
+	if cacheSize == 0 {
+		cacheSize = constant.ChineseConvertDefaultCacheSize
+	}
+	c := &tableChineseConverter{
+		s2tChars:   make(map[rune]rune),
+		t2sChars:   make(map[rune]rune),
+		s2tPhrases: zhS2TPhrases,
+		t2sPhrases: zhT2SPhrases,
+	}
+	for _, pair := range strings.Fields(zhCharPairs) {
+		runes := []rune(pair)
+		c.s2tChars[runes[0]] = runes[1]
+		if !zhT2SKeptChars[runes[1]] {
+			c.t2sChars[runes[1]] = runes[0]
+		}
+	}
+	for traditional, simplified := range zhT2SExtraChars {
+		c.t2sChars[traditional] = simplified
+	}
+	c.maxPhraseRunes = max(maxKeyRunes(c.s2tPhrases), maxKeyRunes(c.t2sPhrases))
+	if cacheSize > 0 {
+		c.cache = newConvertCache(cacheSize)
+	}
+	return c
+}
+
+// noopChineseConverter 在关闭转换时原样返回
+type noopChineseConverter struct{}
+
+func (noopChineseConverter) Convert(text string, _ ChineseScript) string { return text }
+
+func (noopChineseConverter) ConvertPostDetail(detail *vo.PostDetailVO, _ ChineseScript) *vo.PostDetailVO {
+	return detail
+}
+
+func (noopChineseConverter) ConvertPostResponses(posts []*vo.PostResponse, _ ChineseScript) []*vo.PostResponse {
+	return posts
+}
+
+func (noopChineseConverter) ConvertPostCards(cards []*vo.PostCardVO, _ ChineseScript) []*vo.PostCardVO {
+	return cards
+}
+
+// tableChineseConverter 基于字符映射表与词组表实现繁简转换。
+// - 逐字扫描，每个位置先按最长匹配查词组表，未命中时再查字符表。
+type tableChineseConverter struct {
+	s2tChars, t2sChars     map[rune]rune
+	s2tPhrases, t2sPhrases map[string]string
+	maxPhraseRunes         int
+	cache                  *convertCache // 为 nil 时不缓存
+}
+
+// Convert 实现文本转换，较长的文本先查缓存。
+func (c *tableChineseConverter) Convert(text string, script ChineseScript) string {
+	if text == "" || script == ChineseScriptOriginal {
+		return text
+	}
+	useCache := c.cache != nil && utf8.RuneCountInString(text) >= constant.ChineseConvertCacheMinRunes
+	var cacheKey string
+	if useCache {
+		cacheKey = string(rune('0'+script)) + text
+		if converted, ok := c.cache.get(cacheKey); ok {
+			return converted
+		}
+	}
+
+	var converted string
+	if script == ChineseScriptTraditional {
+		converted = c.convert(text, c.s2tChars, c.s2tPhrases)
+	} else {
+		converted = c.convert(text, c.t2sChars, c.t2sPhrases)
+	}
+	if useCache {
+		c.cache.add(cacheKey, converted)
+	}
+	return converted
+}
+
+// convert 按词组表最长匹配、字符表逐字替换的顺序完成转换。
+func (c *tableChineseConverter) convert(text string, chars map[rune]rune, phrases map[string]string) string {
+	runes := []rune(text)
+	var sb strings.Builder
+	sb.Grow(len(text))
+	for i := 0; i < len(runes); {
+		matched := false
+		for n := min(c.maxPhraseRunes, len(runes)-i); n >= 2; n-- {
+			if replacement, ok := phrases[string(runes[i:i+n])]; ok {
+				sb.WriteString(replacement)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if mapped, ok := chars[runes[i]]; ok {
+			sb.WriteRune(mapped)
+		} else {
+			sb.WriteRune(runes[i])
+		}
+		i++
+	}
+	return sb.String()
+}
+
+// ConvertPostDetail 实现详情转换。
+func (c *tableChineseConverter) ConvertPostDetail(detail *vo.PostDetailVO, script ChineseScript) *vo.PostDetailVO {
+	if detail == nil || script == ChineseScriptOriginal {
+		return detail
+	}
+	converted := *detail
+	converted.Title = c.Convert(detail.Title, script)
+	converted.Content = c.Convert(detail.Content, script)
+	if detail.QuotedPost != nil {
+		quoted := *detail.QuotedPost
+		quoted.Title = c.Convert(quoted.Title, script)
+		converted.QuotedPost = &quoted
+	}
+	if len(detail.FAQs) > 0 {
+		converted.FAQs = make([]vo.PostFAQVO, len(detail.FAQs))
+		for i, faq := range detail.FAQs {
+			faq.Question = c.Convert(faq.Question, script)
+			faq.Answer = c.Convert(faq.Answer, script)
+			converted.FAQs[i] = faq
+		}
+	}
+	return &converted
+}
+
+// ConvertPostResponses 实现列表转换。
+func (c *tableChineseConverter) ConvertPostResponses(posts []*vo.PostResponse, script ChineseScript) []*vo.PostResponse {
+	if len(posts) == 0 || script == ChineseScriptOriginal {
+		return posts
+	}
+	converted := make([]*vo.PostResponse, 0, len(posts))
+	for _, post := range posts {
+		if post == nil {
+			continue
+		}
+		copied := *post
+		copied.Title = c.Convert(post.Title, script)
+		converted = append(converted, &copied)
+	}
+	return converted
+}
+
+// ConvertPostCards 实现卡片列表转换。
+func (c *tableChineseConverter) ConvertPostCards(cards []*vo.PostCardVO, script ChineseScript) []*vo.PostCardVO {
+	if len(cards) == 0 || script == ChineseScriptOriginal {
+		return cards
+	}
+	converted := make([]*vo.PostCardVO, 0, len(cards))
+	for _, card := range cards {
+		if card == nil {
+			continue
+		}
+		copied := *card
+		copied.Title = c.Convert(card.Title, script)
+		copied.SimilarPosts = c.ConvertPostCards(card.SimilarPosts, script)
+		converted = append(converted, &copied)
+	}
+	return converted
+}
+
+// maxKeyRunes 返回词组表中最长词组的字符数。
+func maxKeyRunes(phrases map[string]string) int {
+	longest := 0
+	for phrase := range phrases {
+		longest = max(longest, utf8.RuneCountInString(phrase))
+	}
+	return longest
+}
+
+// convertCache 是并发安全的定长 LRU 缓存，保存最近的转换结果。
+type convertCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // 队首为最近使用
+	entries  map[string]*list.Element
+}
+
+// convertCacheEntry 是 convertCache 链表中的元素。
+type convertCacheEntry struct {
+	key, value string
+}
+
+func newConvertCache(capacity int) *convertCache {
+	return &convertCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element, capacity),
+	}
+}
+
+func (c *convertCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*convertCacheEntry).value, true
+}
+
+func (c *convertCache) add(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*convertCacheEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&convertCacheEntry{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*convertCacheEntry).key)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// ComplianceResult 是一次地区合规预检的结果
+type ComplianceResult struct {
+	Regions      []string // 参与规则合并的目标地区（已规整）
+	BlockedWords []string // 命中的禁止词，非空时帖子不允许创建/发布
+	ReviewWords  []string // 命中的待复审敏感词，随待审核事件提示审核服务
+	ManualReview bool     // 合并后的规则是否要求人工复审
+}
+
+// ContentComplianceChecker 定义按帖子目标地区进行内容合规预检的能力
+type ContentComplianceChecker interface {
+	// Check 合并默认规则与各目标地区的规则（取最严）后，检查标题与正文纯文本。
+	// - regions 为空表示帖子面向所有地区，只应用默认规则；未配置规则的地区同样只应用默认规则。
+	// - 匹配忽略大小写，按子串匹配；同一个词只记录一次。
+	Check(regions []string, title string, content string) *ComplianceResult
+}
+
+// NewContentComplianceChecker 根据配置创建合规预检器。
+// - 配置关闭预检时返回只记录地区、不做匹配的实现，调用方无需判断开关。
+func NewContentComplianceChecker(cfg config.ContentComplianceConfig) ContentComplianceChecker {
+	if !cfg.Enabled {
+		return noopComplianceChecker{}
+	}
+	checker := &regionComplianceChecker{
+		defaultRules: compileRuleSet(cfg.Default),
+		regionRules:  make(map[string]compiledRuleSet, len(cfg.Regions)),
+	}
+	for region, rules := range cfg.Regions {
+		checker.regionRules[strings.TrimSpace(region)] = compileRuleSet(rules)
+	}
+	return checker
+}
+
+// noopComplianceChecker 在关闭预检时只返回规整后的地区
+type noopComplianceChecker struct{}
+
+func (noopComplianceChecker) Check(regions []string, _ string, _ string) *ComplianceResult {
+	return &ComplianceResult{Regions: splitTargetValues(normalizeTargetValues(regions))}
+}
+
+// compiledRuleSet 是预先转为小写的规则集，避免每次检查重复转换
+type compiledRuleSet struct {
+	blockedWords []string
+	reviewWords  []string
+	manualReview bool
+}
+
+// compileRuleSet 去除空词并统一转为小写。
+func compileRuleSet(rules config.ComplianceRuleSet) compiledRuleSet {
+	return compiledRuleSet{
+		blockedWords: lowerWords(rules.BlockedWords),
+		reviewWords:  lowerWords(rules.ReviewWords),
+		manualReview: rules.ManualReview,
+	}
+}
+
+// lowerWords 去除空白与空值，并将词转为小写。
+func lowerWords(words []string) []string {
+	lowered := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			lowered = append(lowered, w)
+		}
+	}
+	return lowered
+}
+
+// regionComplianceChecker 按地区配置的规则集实现 ContentComplianceChecker
+type regionComplianceChecker struct {
+	defaultRules compiledRuleSet
+	regionRules  map[string]compiledRuleSet
+}
+
+func (c *regionComplianceChecker) Check(regions []string, title string, content string) *ComplianceResult {
+	result := &ComplianceResult{Regions: splitTargetValues(normalizeTargetValues(regions))}
+
+	// 1. 合并规则：默认规则 + 每个目标地区的规则，词库取并集，人工复审取或
+	ruleSets := []compiledRuleSet{c.defaultRules}
+	for _, region := range result.Regions {
+		if rules, ok := c.regionRules[region]; ok {
+			ruleSets = append(ruleSets, rules)
+		}
+	}
+
+	// 2. 标题与正文纯文本一起匹配，正文中的 HTML 标签不参与匹配
+	text := strings.ToLower(title + " " + htmlToPlainText(content))
+	blocked := make(map[string]bool)
+	review := make(map[string]bool)
+	for _, rules := range ruleSets {
+		result.ManualReview = result.ManualReview || rules.manualReview
+		for _, w := range rules.blockedWords {
+			if !blocked[w] && strings.Contains(text, w) {
+				blocked[w] = true
+				result.BlockedWords = append(result.BlockedWords, w)
+			}
+		}
+		for _, w := range rules.reviewWords {
+			if !review[w] && strings.Contains(text, w) {
+				review[w] = true
+				result.ReviewWords = append(result.ReviewWords, w)
+			}
+		}
+	}
+	return result
+}
+
+// Err 在命中禁止词时返回包装了 myErrors.ErrContentNotCompliant 的错误，否则返回 nil。
+func (r *ComplianceResult) Err() error {
+	if len(r.BlockedWords) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", myErrors.ErrContentNotCompliant, strings.Join(r.BlockedWords, ","))
+}
+
+// PendingAuditMeta 将预检结果与审核优先级组装为待审核事件的附加信息。
+// - 接收者为 nil 时只携带优先级。
+func (r *ComplianceResult) PendingAuditMeta(priority int) producer.PendingAuditMeta {
+	meta := producer.PendingAuditMeta{Priority: priority}
+	if r != nil {
+		meta.Regions = r.Regions
+		meta.FlaggedWords = r.ReviewWords
+		meta.ManualReview = r.ManualReview
+	}
+	return meta
+}
+
+// loadTargetRegions 读取帖子投放定向中的目标地区，未设置定向时返回 nil。
+func loadTargetRegions(ctx context.Context, targetingRepo mysql.PostTargetingRepository, postID uint64) ([]string, error) {
+	targeting, err := targetingRepo.GetTargetingByPostID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("获取帖子投放定向失败: %w", err)
+	}
+	return splitTargetValues(targeting.Regions), nil
+}
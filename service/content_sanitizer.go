@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/microcosm-cc/bluemonday"
+
+	"github.com/Xushengqwer/post_service/config"
+)
+
+// ContentSanitizer 定义帖子正文写库前的 HTML 清洗能力
+type ContentSanitizer interface {
+	// Sanitize 返回清洗后的正文。
+	// - 仅保留白名单内的标签与属性，危险标签（script、iframe 等）连同其内容一并移除。
+	// - 其余不在白名单内的标签只去掉标签本身，保留其中的文本。
+	Sanitize(content string) string
+}
+
+// NewContentSanitizer 根据配置创建正文清洗器。
+// - 配置关闭清洗时返回原样透传的实现，调用方无需判断开关。
+func NewContentSanitizer(cfg config.ContentSanitizeConfig) ContentSanitizer {
+	if cfg.Disabled {
+		return noopContentSanitizer{}
+	}
+	return &ugcContentSanitizer{policy: newUGCPolicy()}
+}
+
+// noopContentSanitizer 在关闭清洗时原样返回正文
+type noopContentSanitizer struct{}
+
+func (noopContentSanitizer) Sanitize(content string) string { return content }
+
+// ugcContentSanitizer 基于 bluemonday 的 UGC 策略清洗用户生成内容 (UGC)，
+// 允许常见的富文本排版标签、链接与图片。
+// - bluemonday.Policy 构建完成后可并发使用，整个服务共享一个实例。
+type ugcContentSanitizer struct {
+	policy *bluemonday.Policy
+}
+
+// newUGCPolicy 在 bluemonday.UGCPolicy 的基础上收紧策略。
+// - 链接只允许 http、https、mailto 与相对地址，javascript: 等伪协议（含实体编码、插入空白等变形）由 bluemonday 解析后拒绝。
+// - 外链统一追加 rel="nofollow noreferrer"。
+// - svg、math 等外来命名空间以及表单控件连同内部内容一并移除，不保留其中的文本。
+func newUGCPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.SkipElementsContent("svg", "math", "template", "textarea", "select", "embed", "head")
+	return p
+}
+
+// Sanitize 按 UGC 策略清洗正文，不在白名单内的标签只去掉标签本身，保留其中转义后的文本。
+func (s *ugcContentSanitizer) Sanitize(content string) string {
+	return s.policy.Sanitize(content)
+}
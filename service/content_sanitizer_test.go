@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Xushengqwer/post_service/config"
+)
+
+func TestContentSanitizerStripsXSSPayloads(t *testing.T) {
+	s := NewContentSanitizer(config.ContentSanitizeConfig{})
+
+	// 清洗结果中不得出现的片段（统一按小写比较）
+	forbidden := []string{"javascript:", "vbscript:", "data:", "<script", "<svg", "<math", "<noscript", "<iframe", "<style", "onerror", "onload", "onclick", "onmouseover", "onfocus", "alert("}
+
+	cases := []struct {
+		name    string
+		payload string
+	}{
+		{name: "script 标签", payload: `<script>alert(1)</script>`},
+		{name: "大小写混合的 script", payload: `<ScRiPt>alert(1)</sCrIpT>`},
+		{name: "javascript 伪协议", payload: `<a href="javascript:alert(1)">x</a>`},
+		{name: "十进制实体编码的协议", payload: `<a href="&#106;&#97;&#118;&#97;&#115;&#99;&#114;&#105;&#112;&#116;&#58;alert(1)">x</a>`},
+		{name: "十六进制实体编码的协议", payload: `<a href="&#x6A;avascript&#x3A;alert(1)">x</a>`},
+		{name: "命名实体插入的换行", payload: `<a href="java&NewLine;script:alert(1)">x</a>`},
+		{name: "协议中插入制表符", payload: "<a href=\"java\tscript:alert(1)\">x</a>"},
+		{name: "协议中插入实体制表符", payload: `<a href="jav&#x09;ascript:alert(1)">x</a>`},
+		{name: "前导空白与控制字符", payload: "<a href=\" \x01javascript:alert(1)\">x</a>"},
+		{name: "vbscript 伪协议", payload: `<a href="vbscript:msgbox(1)">x</a>`},
+		{name: "data URL 图片", payload: `<img src="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">`},
+		{name: "img onerror", payload: `<img src="x" onerror="alert(1)">`},
+		{name: "无引号的事件属性", payload: `<img src=x onerror=alert(1)>`},
+		{name: "斜杠分隔的事件属性", payload: `<img/src="x"/onerror="alert(1)">`},
+		{name: "body onload", payload: `<body onload="alert(1)">`},
+		{name: "p onmouseover", payload: `<p onmouseover="alert(1)">hover</p>`},
+		{name: "autofocus onfocus", payload: `<input autofocus onfocus="alert(1)">`},
+		{name: "svg onload", payload: `<svg onload="alert(1)"></svg>`},
+		{name: "svg 内嵌 script", payload: `<svg><script>alert(1)</script></svg>`},
+		{name: "svg animate 改写 href", payload: `<svg><a><animate attributeName="href" values="javascript:alert(1)"/><text y="20">x</text></a></svg>`},
+		{name: "svg foreignObject", payload: `<svg><foreignObject><iframe src="javascript:alert(1)"></iframe></foreignObject></svg>`},
+		{name: "math 中的链接", payload: `<math><mtext><a href="javascript:alert(1)">x</a></mtext></math>`},
+		{name: "math 命名空间混淆", payload: `<math><mtext><table><mglyph><style><img src=x onerror=alert(1)></style></mglyph></table></mtext></math>`},
+		{name: "嵌套 noscript", payload: `<noscript><noscript><p title="</noscript><img src=x onerror=alert(1)>"></p></noscript></noscript>`},
+		{name: "noscript 属性逃逸", payload: `<noscript><p title="</noscript><img src=x onerror=alert(1)>">`},
+		{name: "iframe srcdoc", payload: `<iframe srcdoc="<script>alert(1)</script>"></iframe>`},
+		{name: "style 表达式", payload: `<p style="background:url(javascript:alert(1))">x</p>`},
+		{name: "注释中的 script", payload: `<!--<script>alert(1)</script>-->`},
+		{name: "未闭合的属性", payload: `<a href="javascript:alert(1)`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := strings.ToLower(s.Sanitize(tc.payload))
+			for _, f := range forbidden {
+				if strings.Contains(got, f) {
+					t.Fatalf("Sanitize(%q) = %q, contains %q", tc.payload, got, f)
+				}
+			}
+		})
+	}
+}
+
+func TestContentSanitizerKeepsSafeMarkup(t *testing.T) {
+	s := NewContentSanitizer(config.ContentSanitizeConfig{})
+
+	cases := []struct {
+		name    string
+		payload string
+		want    []string
+	}{
+		{name: "排版标签", payload: `<p><strong>粗体</strong><em>斜体</em></p>`, want: []string{"<p>", "<strong>粗体</strong>", "<em>斜体</em>"}},
+		{name: "外链追加 rel", payload: `<a href="https://example.com/a?b=1">链接</a>`, want: []string{`href="https://example.com/a?b=1"`, "nofollow", "noreferrer"}},
+		{name: "mailto 链接", payload: `<a href="mailto:a@example.com">邮件</a>`, want: []string{`href="mailto:a@example.com"`}},
+		{name: "图片", payload: `<img src="https://example.com/a.png" alt="图">`, want: []string{`src="https://example.com/a.png"`, `alt="图"`}},
+		{name: "未知标签保留文本", payload: `<custom>保留的文字</custom>`, want: []string{"保留的文字"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := s.Sanitize(tc.payload)
+			for _, w := range tc.want {
+				if !strings.Contains(got, w) {
+					t.Fatalf("Sanitize(%q) = %q, want it to contain %q", tc.payload, got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestContentSanitizerDisabledPassesThrough(t *testing.T) {
+	s := NewContentSanitizer(config.ContentSanitizeConfig{Disabled: true})
+	payload := `<script>alert(1)</script>`
+	if got := s.Sanitize(payload); got != payload {
+		t.Fatalf("Sanitize() = %q, want the original content", got)
+	}
+}
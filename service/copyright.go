@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// normalizeSourceURL 规整转载来源地址。
+// - 只有转载帖才保存来源地址，其他版权类型一律置空，避免前端误传的脏数据入库。
+func normalizeSourceURL(copyrightType int, sourceURL string) string {
+	if copyrightType != constant.CopyrightTypeRepost {
+		return ""
+	}
+	return strings.TrimSpace(sourceURL)
+}
+
+// validateCopyright 校验帖子版权声明是否合理，供管理员审核通过前调用。
+// - 版权类型必须是已知取值。
+// - 转载帖必须带有来源地址。
+func validateCopyright(post *entities.Post) error {
+	switch post.CopyrightType {
+	case constant.CopyrightTypeOriginal, constant.CopyrightTypeNoRepost:
+		return nil
+	case constant.CopyrightTypeRepost:
+		if strings.TrimSpace(post.SourceURL) == "" {
+			return fmt.Errorf("转载帖未填写来源地址: %w", myErrors.ErrInvalidCopyright)
+		}
+		return nil
+	default:
+		return fmt.Errorf("未知的版权声明类型 %d: %w", post.CopyrightType, myErrors.ErrInvalidCopyright)
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// CoverExperimentService 定义帖子 A/B 封面实验的接口。
+// - 作者从帖子已上传的图片中选择候选封面，列表展示时按用户稳定分桶返回其中一张，并记录曝光与点击。
+// - 只对登录用户分桶与计数；匿名用户固定看到第一张候选封面，不计入实验数据。
+type CoverExperimentService interface {
+	// SetCoverCandidates 设置帖子的候选封面，imageIDs 为空表示关闭实验。
+	// - 只有帖子作者可以设置，否则返回 myErrors.ErrPermissionDenied；帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	// - 数量不在 [constant.CoverCandidatesMin, constant.CoverCandidatesMax] 内或图片不属于该帖子时返回 myErrors.ErrInvalidCoverCandidates。
+	// - 设置成功后旧的统计数据被清空，新一轮实验从零开始。
+	SetCoverCandidates(ctx context.Context, postID uint64, userID string, imageIDs []uint64) error
+
+	// AssignCovers 为列表中开启了实验的帖子填充分配给当前用户的封面，并为登录用户记录曝光。
+	// - 查询或计数失败只记录日志，不影响列表本身的返回。
+	AssignCovers(ctx context.Context, posts []*vo.PostResponse, userID string)
+
+	// AssignCardCovers 与 AssignCovers 相同，作用于公开信息流的帖子卡片。
+	AssignCardCovers(ctx context.Context, cards []*vo.PostCardVO, userID string)
+
+	// RecordCoverClick 记录登录用户对帖子封面的点击。
+	// - 只有 imageID 与该用户的分桶结果一致时才计数，其余情况（匿名、实验已关闭或封面不匹配）静默忽略，避免伪造点击。
+	RecordCoverClick(ctx context.Context, postID, imageID uint64, userID string) error
+
+	// GetCoverStats 查询帖子各候选封面的曝光、点击与点击率。
+	// - 权限与错误语义同 SetCoverCandidates。
+	GetCoverStats(ctx context.Context, postID uint64, userID string) (*vo.PostCoverStatsVO, error)
+}
+
+// coverExperimentService 是 CoverExperimentService 接口的实现。
+type coverExperimentService struct {
+	db             *gorm.DB
+	postRepo       mysql.PostRepository
+	postDetailRepo mysql.PostDetailRepository
+	imageRepo      mysql.PostDetailImageRepository
+	coverRepo      redis.CoverExperimentRepository
+	logger         *core.ZapLogger
+}
+
+// NewCoverExperimentService 初始化 A/B 封面实验服务。
+func NewCoverExperimentService(db *gorm.DB, postRepo mysql.PostRepository, postDetailRepo mysql.PostDetailRepository, imageRepo mysql.PostDetailImageRepository, coverRepo redis.CoverExperimentRepository, logger *core.ZapLogger) CoverExperimentService {
+	return &coverExperimentService{
+		db:             db,
+		postRepo:       postRepo,
+		postDetailRepo: postDetailRepo,
+		imageRepo:      imageRepo,
+		coverRepo:      coverRepo,
+		logger:         logger,
+	}
+}
+
+// SetCoverCandidates 实现候选封面设置。
+func (s *coverExperimentService) SetCoverCandidates(ctx context.Context, postID uint64, userID string, imageIDs []uint64) error {
+	imageIDs = dedupeUint64s(imageIDs)
+	if len(imageIDs) > 0 && (len(imageIDs) < constant.CoverCandidatesMin || len(imageIDs) > constant.CoverCandidatesMax) {
+		return fmt.Errorf("%w: 候选封面数量需在 %d 到 %d 之间", myErrors.ErrInvalidCoverCandidates, constant.CoverCandidatesMin, constant.CoverCandidatesMax)
+	}
+	if err := s.checkAuthor(ctx, postID, userID); err != nil {
+		return err
+	}
+
+	detail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return err
+		}
+		return fmt.Errorf("获取帖子详情失败: %w", err)
+	}
+	images, err := s.imageRepo.GetImagesByPostDetailID(ctx, detail.ID)
+	if err != nil {
+		return fmt.Errorf("获取帖子图片失败: %w", err)
+	}
+	owned := make(map[uint64]bool, len(images))
+	for _, image := range images {
+		owned[uint64(image.ID)] = true
+	}
+	for _, imageID := range imageIDs {
+		if !owned[imageID] {
+			return fmt.Errorf("%w: 图片 %d 不属于该帖子", myErrors.ErrInvalidCoverCandidates, imageID)
+		}
+	}
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.imageRepo.SetCoverCandidates(ctx, tx, detail.ID, imageIDs)
+	}); err != nil {
+		s.logger.Error("设置候选封面失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("设置候选封面失败: %w", err)
+	}
+	// 候选集合变化后分桶结果随之改变，旧数据已不可比，统计清理失败只影响报表准确性
+	if err := s.coverRepo.ResetCoverStats(ctx, postID); err != nil {
+		s.logger.Warn("清空旧的封面统计失败", zap.Error(err), zap.Uint64("postID", postID))
+	}
+	s.logger.Info("帖子候选封面已更新", zap.Uint64("postID", postID), zap.Int("candidates", len(imageIDs)))
+	return nil
+}
+
+// AssignCovers 实现列表封面分配。
+func (s *coverExperimentService) AssignCovers(ctx context.Context, posts []*vo.PostResponse, userID string) {
+	postIDs := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	s.assignCovers(ctx, postIDs, userID, func(i int, coverID uint64, coverURL string) {
+		posts[i].CoverImageID = &coverID
+		posts[i].CoverImageURL = coverURL
+	})
+}
+
+// AssignCardCovers 实现卡片列表封面分配。
+func (s *coverExperimentService) AssignCardCovers(ctx context.Context, cards []*vo.PostCardVO, userID string) {
+	postIDs := make([]uint64, 0, len(cards))
+	for _, card := range cards {
+		postIDs = append(postIDs, card.ID)
+	}
+	s.assignCovers(ctx, postIDs, userID, func(i int, coverID uint64, coverURL string) {
+		cards[i].CoverImageID = &coverID
+		cards[i].CoverImageURL = coverURL
+	})
+}
+
+// assignCovers 为 postIDs 中开启了实验的帖子分桶选取封面，通过 apply 回填到下标 i 对应的列表元素，并为登录用户记录曝光。
+func (s *coverExperimentService) assignCovers(ctx context.Context, postIDs []uint64, userID string, apply func(i int, coverID uint64, coverURL string)) {
+	if len(postIDs) == 0 {
+		return
+	}
+	candidates, err := s.imageRepo.GetCoverCandidatesByPostIDs(ctx, postIDs)
+	if err != nil {
+		s.logger.Error("批量获取候选封面失败，本次列表不返回实验封面", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	impressions := make(map[uint64]uint64)
+	for i, postID := range postIDs {
+		cover := pickCover(candidates[postID], postID, userID)
+		if cover == nil {
+			continue
+		}
+		coverID := uint64(cover.ID)
+		apply(i, coverID, cover.ImageURL)
+		if userID != "" {
+			impressions[postID] = coverID
+		}
+	}
+	if err := s.coverRepo.RecordImpressions(ctx, impressions); err != nil {
+		s.logger.Warn("记录封面曝光失败", zap.Error(err), zap.Int("posts", len(impressions)))
+	}
+}
+
+// RecordCoverClick 实现封面点击上报。
+func (s *coverExperimentService) RecordCoverClick(ctx context.Context, postID, imageID uint64, userID string) error {
+	if userID == "" {
+		return nil
+	}
+	candidates, err := s.imageRepo.GetCoverCandidatesByPostIDs(ctx, []uint64{postID})
+	if err != nil {
+		s.logger.Error("获取候选封面失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取候选封面失败: %w", err)
+	}
+	cover := pickCover(candidates[postID], postID, userID)
+	if cover == nil || uint64(cover.ID) != imageID {
+		return nil
+	}
+	return s.coverRepo.RecordClick(ctx, postID, imageID)
+}
+
+// GetCoverStats 实现封面表现统计查询。
+func (s *coverExperimentService) GetCoverStats(ctx context.Context, postID uint64, userID string) (*vo.PostCoverStatsVO, error) {
+	if err := s.checkAuthor(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+	candidates, err := s.imageRepo.GetCoverCandidatesByPostIDs(ctx, []uint64{postID})
+	if err != nil {
+		s.logger.Error("获取候选封面失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取候选封面失败: %w", err)
+	}
+	impressions, clicks, err := s.coverRepo.GetCoverStats(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	images := candidates[postID]
+	stats := &vo.PostCoverStatsVO{
+		PostID:   postID,
+		Active:   len(images) >= constant.CoverCandidatesMin,
+		Variants: make([]*vo.PostCoverVariantStatsVO, 0, len(images)),
+	}
+	for _, image := range images {
+		imageID := uint64(image.ID)
+		variant := &vo.PostCoverVariantStatsVO{
+			ImageID:     imageID,
+			ImageURL:    image.ImageURL,
+			Impressions: impressions[imageID],
+			Clicks:      clicks[imageID],
+		}
+		if variant.Impressions > 0 {
+			variant.ClickThroughRate = float64(variant.Clicks) / float64(variant.Impressions)
+		}
+		stats.Variants = append(stats.Variants, variant)
+	}
+	return stats, nil
+}
+
+// checkAuthor 校验帖子存在且 userID 是帖子作者。
+func (s *coverExperimentService) checkAuthor(ctx context.Context, postID uint64, userID string) error {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return err
+		}
+		s.logger.Error("获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if userID == "" || post.AuthorID != userID {
+		return myErrors.ErrPermissionDenied
+	}
+	return nil
+}
+
+// pickCover 按用户稳定分桶选出帖子的展示封面。
+// - 候选不足 constant.CoverCandidatesMin 张时不做实验，返回 nil。
+// - 同一用户对同一帖子的哈希值固定，只要候选集合不变就总是看到同一张；匿名用户固定返回第一张候选。
+func pickCover(candidates []*entities.PostDetailImage, postID uint64, userID string) *entities.PostDetailImage {
+	if len(candidates) < constant.CoverCandidatesMin {
+		return nil
+	}
+	if userID == "" {
+		return candidates[0]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(userID + ":" + strconv.FormatUint(postID, 10)))
+	return candidates[h.Sum32()%uint32(len(candidates))]
+}
+
+// dedupeUint64s 去除重复的 ID，保持原有顺序。
+func dedupeUint64s(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
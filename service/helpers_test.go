@@ -0,0 +1,18 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/core"
+)
+
+// newTestLogger 返回只输出错误日志的 logger，避免测试输出被调试日志淹没。
+func newTestLogger(t *testing.T) *core.ZapLogger {
+	t.Helper()
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	return logger
+}
@@ -7,52 +7,114 @@ import (
 	"fmt"
 	"time" // 用于 GetHotPostDetail 的异步调用超时（如果需要）
 
+	"github.com/Xushengqwer/go-common/commonerrors"
 	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
 	"go.uber.org/zap"
 
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
 	"github.com/Xushengqwer/post_service/models/vo"
-	"github.com/Xushengqwer/post_service/repo/redis" // 包含 PostCache 和 PostViewRepository 接口
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis" // 包含 PostReadCache 和 PostViewRepository 接口
 )
 
 // PostServiceInterface 定义了处理热门帖子相关查询的业务逻辑接口。
 type PostServiceInterface interface {
-	GetHotPostsByCursor(ctx context.Context, lastPostID *uint64, limit int) ([]*vo.PostResponse, *uint64, error)
-	GetHotPostDetail(ctx context.Context, postID uint64, userID string) (*vo.PostDetailVO, error)
+	GetHotPostsByCursor(ctx context.Context, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error)
+	GetHotPostsByTag(ctx context.Context, tag enums.OfficialTag, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error)
+	GetHotPostDetail(ctx context.Context, postID uint64, userID string, viewer *dto.ViewerAttributes) (*vo.PostDetailVO, error)
 }
 
 // HotPostService 是 PostServiceInterface 的具体实现。
 type HotPostService struct {
-	// 修改：使用更具体的 PostCache 接口，该接口应只包含服务层所需的读取方法
-	postCache    redis.Cache              // 依赖帖子缓存读取接口
-	postViewRepo redis.PostViewRepository // 依赖帖子浏览和排名操作接口
-	logger       *core.ZapLogger
+	postCache      redis.PostReadCache           // 依赖帖子缓存读取接口
+	postViewRepo   redis.PostViewRepository      // 依赖帖子浏览和排名操作接口
+	viewAggregator *ViewCountAggregator          // 浏览增量进程内聚合，为 nil 时每次浏览直接写入 Redis
+	targetRepo     mysql.PostTargetingRepository // 依赖帖子投放定向查询，用于过滤热榜中当前用户不可见的帖子
+	postService    PostService                   // 热门详情缓存未命中时回源数据库
+	accessGuard    *PostAccessGuard              // 帖子详情访问鉴权钩子链
+	coverSvc       CoverExperimentService        // 热榜按用户分配 A/B 实验封面
+	cacheHealth    *hotCacheHealth               // 热榜读取 Redis 的健康状态，不可用时降级
+	fallback       *hotPostsFallback             // Redis 不可用时从 MySQL 读取的近似热榜
+	logger         *core.ZapLogger
 }
 
 // NewHotPostService (原 NewPostQueryService) 是 HotPostService 的构造函数。
 func NewHotPostService(
-	postCache redis.Cache, // 修改：注入 PostCache
+	postCache redis.PostReadCache, // 注入 PostReadCache
 	postViewRepo redis.PostViewRepository,
+	viewAggregator *ViewCountAggregator,
+	targetRepo mysql.PostTargetingRepository,
+	postRepo mysql.PostRepository,
+	postService PostService,
+	accessGuard *PostAccessGuard,
+	coverSvc CoverExperimentService,
 	logger *core.ZapLogger,
 ) *HotPostService {
 	return &HotPostService{
-		postCache:    postCache,
-		postViewRepo: postViewRepo,
-		logger:       logger,
+		postCache:      postCache,
+		postViewRepo:   postViewRepo,
+		viewAggregator: viewAggregator,
+		targetRepo:     targetRepo,
+		postService:    postService,
+		accessGuard:    accessGuard,
+		coverSvc:       coverSvc,
+		cacheHealth:    &hotCacheHealth{logger: logger},
+		fallback:       &hotPostsFallback{postRepo: postRepo, logger: logger},
+		logger:         logger,
 	}
 }
 
 // GetHotPostsByCursor 实现游标方式获取热门帖子列表。
 // - lastPostID: 上一页最后一条帖子的 ID，为 nil 表示首次加载。
 // - limit: 希望获取的帖子数量。
-// - 返回: 帖子列表, 下一页游标, 错误。
-func (s *HotPostService) GetHotPostsByCursor(ctx context.Context, lastPostID *uint64, limit int) ([]*vo.PostResponse, *uint64, error) {
-	var start int64 // ZSet 范围查询的起始排名 (0-based)
-
+// - viewer: 当前用户画像，不满足投放定向条件的帖子会从结果中剔除（游标仍按 ZSet 推进，因此单页可能少于 limit 条）。
+// - Redis 不可用时降级为 MySQL 近似热榜（见 hotPostsFallback），响应的 Degraded 为 true。
+func (s *HotPostService) GetHotPostsByCursor(ctx context.Context, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error) {
 	if limit <= 0 { // 基本的参数校验
 		s.logger.Warn("GetHotPostsByCursor: 请求的 limit 小于或等于0", zap.Int("limit", limit))
-		return []*vo.PostResponse{}, nil, errors.New("limit 参数必须大于0")
+		return nil, errors.New("limit 参数必须大于0")
+	}
+	return s.withHotCacheFallback(ctx, nil, lastPostID, limit, viewer, func() (*vo.ListHotPostsByCursorResponse, error) {
+		return s.hotPostsFromCache(ctx, lastPostID, limit, viewer)
+	})
+}
+
+// withHotCacheFallback 优先从 Redis 热榜读取，Redis 不可用或读取失败时降级到 MySQL 近似热榜。
+// - tag 不为 nil 时降级结果只保留该官方标签的帖子。
+// - 非 Redis 错误（如投放定向查询失败）原样返回，不触发降级。
+func (s *HotPostService) withHotCacheFallback(ctx context.Context, tag *enums.OfficialTag, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes, fromCache func() (*vo.ListHotPostsByCursorResponse, error)) (*vo.ListHotPostsByCursorResponse, error) {
+	if s.cacheHealth.available() {
+		result, err := fromCache()
+		if err == nil {
+			s.cacheHealth.markSuccess()
+			return result, nil
+		}
+		// 请求被取消导致的失败不代表 Redis 不可用
+		if !errors.Is(err, errHotCacheUnavailable) || ctx.Err() != nil {
+			return nil, err
+		}
+		s.cacheHealth.markFailure(err)
 	}
 
+	result, err := s.fallback.page(ctx, tag, lastPostID, limit)
+	if err != nil {
+		s.logger.Error("获取降级热榜失败", zap.Error(err))
+		return nil, err
+	}
+	s.coverSvc.AssignCovers(ctx, result.Posts, viewerUserID(viewer))
+	return result, nil
+}
+
+// hotPostsFromCache 从 Redis 全站热榜按游标读取一页帖子，Redis 读取失败时返回可 errors.Is errHotCacheUnavailable 的错误。
+// - 热榜每轮缓存任务都会重建，游标帖子不在当前热榜中时不报错，由 estimateCursorStart 估算起点，响应中 CursorReset 为 true。
+func (s *HotPostService) hotPostsFromCache(ctx context.Context, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error) {
+	var start int64 // ZSet 范围查询的起始排名 (0-based)
+	cursorReset := false
+
 	if lastPostID == nil { // 首次加载
 		start = 0
 		s.logger.Debug("热门帖子首次加载 (游标分页)", zap.Int("limit", limit))
@@ -60,16 +122,18 @@ func (s *HotPostService) GetHotPostsByCursor(ctx context.Context, lastPostID *ui
 		rank, err := s.postCache.GetPostRank(ctx, *lastPostID)
 		if err != nil {
 			s.logger.Error("获取上一页最后帖子排名失败 (游标分页)", zap.Error(err), zap.Uint64p("lastPostID", lastPostID))
-			return nil, nil, fmt.Errorf("获取帖子排名失败: %w", err)
+			return nil, fmt.Errorf("获取帖子排名失败: %w: %w", errHotCacheUnavailable, err)
 		}
-		if rank == -1 { // 游标帖子已不在榜单中
-			s.logger.Warn("游标 lastPostID 已不在热榜中 (游标分页)", zap.Uint64p("lastPostID", lastPostID))
-			// 可以返回特定错误提示客户端游标失效，或作为空列表处理。
-			// 这里返回特定错误，让客户端决定如何响应（例如提示刷新或从头加载）。
-			return nil, nil, fmt.Errorf("提供的游标帖子(ID: %d)已不在热门榜单中，请刷新", *lastPostID)
+		if rank == -1 { // 游标帖子已不在榜单中（热榜已重建），降级为估算起点
+			start, err = s.estimateCursorStart(ctx, *lastPostID)
+			if err != nil {
+				return nil, err
+			}
+			cursorReset = true
+		} else {
+			start = rank + 1 // 下一页从上一页最后一条的下一名开始
 		}
-		start = rank + 1 // 下一页从上一页最后一条的下一名开始
-		s.logger.Debug("热门帖子分页加载", zap.Uint64p("lastPostID", lastPostID), zap.Int64("startRank", start), zap.Int("limit", limit))
+		s.logger.Debug("热门帖子分页加载", zap.Uint64p("lastPostID", lastPostID), zap.Int64("startRank", start), zap.Int("limit", limit), zap.Bool("cursorReset", cursorReset))
 	}
 
 	stop := start + int64(limit) - 1 // 计算 ZSet 查询的结束排名
@@ -78,12 +142,12 @@ func (s *HotPostService) GetHotPostsByCursor(ctx context.Context, lastPostID *ui
 	postIDs, err := s.postCache.GetPostsByRange(ctx, start, stop)
 	if err != nil {
 		s.logger.Error("从缓存按排名范围获取帖子 ID 失败 (游标分页)", zap.Error(err), zap.Int64("start", start), zap.Int64("stop", stop))
-		return nil, nil, fmt.Errorf("获取帖子 ID 列表失败: %w", err)
+		return nil, fmt.Errorf("获取帖子 ID 列表失败: %w: %w", errHotCacheUnavailable, err)
 	}
 
 	if len(postIDs) == 0 { // 未获取到任何 ID（可能已到达列表末尾或该范围无数据）
 		s.logger.Info("按排名范围未获取到帖子 ID (游标分页)，可能已到末尾", zap.Int64("start", start), zap.Int64("stop", stop))
-		return []*vo.PostResponse{}, nil, nil // 返回空列表和 nil 游标，表示没有更多数据
+		return &vo.ListHotPostsByCursorResponse{Posts: []*vo.PostResponse{}, CursorReset: cursorReset}, nil // 返回空列表和 nil 游标，表示没有更多数据
 	}
 	s.logger.Debug("成功从 ZSet 获取到帖子 ID 列表 (游标分页)", zap.Int("count", len(postIDs)))
 
@@ -91,38 +155,37 @@ func (s *HotPostService) GetHotPostsByCursor(ctx context.Context, lastPostID *ui
 	posts, err := s.postCache.GetPosts(ctx, postIDs)
 	if err != nil {
 		s.logger.Error("从缓存批量获取帖子实体失败 (游标分页)", zap.Error(err), zap.Any("postIDs", postIDs)) // 使用 zap.Any 因为 Uint64s 可能很长
-		return nil, nil, fmt.Errorf("获取帖子详情失败: %w", err)
+		return nil, fmt.Errorf("获取帖子详情失败: %w: %w", errHotCacheUnavailable, err)
 	}
 	// GetPosts 可能因部分 ID 缓存未命中而返回比 postIDs 数量少的记录。
 	// 游标的确定应基于从 ZSet 获取的 ID 数量。
 
+	// 批量查询定向条件，剔除当前用户不可见的帖子。
+	targetings, err := s.targetRepo.GetTargetingsByPostIDs(ctx, postIDs)
+	if err != nil {
+		s.logger.Error("批量获取热门帖子投放定向失败 (游标分页)", zap.Error(err), zap.Int("idCount", len(postIDs)))
+		return nil, fmt.Errorf("获取帖子投放定向失败: %w", err)
+	}
+
 	// 将数据库实体转换为前端视图对象 (VO)。
 	postResponses := make([]*vo.PostResponse, 0, len(posts))
 	for _, post := range posts { // post 是 *entities.Post
 		if post == nil { // 防御性检查，尽管 GetPosts 通常不应返回nil元素
 			continue
 		}
-		postResponses = append(postResponses, &vo.PostResponse{
-			ID:             post.ID,
-			Title:          post.Title,
-			Status:         post.Status,
-			ViewCount:      post.ViewCount, // 此 ViewCount 来自帖子 Hash 缓存，是快照值
-			AuthorID:       post.AuthorID,
-			AuthorAvatar:   post.AuthorAvatar,
-			AuthorUsername: post.AuthorUsername,
-			OfficialTag:    post.OfficialTag,
-			CreatedAt:      post.CreatedAt,
-			UpdatedAt:      post.UpdatedAt,
-		})
+		if !isTargetingMatched(targetings[post.ID], viewer) {
+			continue
+		}
+		postResponses = append(postResponses, newHotPostResponse(post))
 	}
 
 	// 确定下一页的游标。
 	var nextCursor *uint64
 	// 如果从 ZSet 获取的 ID 数量等于请求的 limit，说明可能还有更多数据。
 	// 使用 postIDs (来自ZSet) 的最后一个 ID 作为下一页的游标。
-	if len(postIDs) == limit && len(postResponses) > 0 {
-		// 确保 postResponses 非空才取最后一个 ID，以防 posts 列表为空（虽然理论上不应发生如果 postIDs 非空且GetPosts行为符合预期）
-		// 游标应该是 postIDs 中的最后一个，因为 postResponses 可能因 GetPosts 的部分未命中而比 postIDs 短。
+	if len(postIDs) == limit {
+		// 即使本页 postResponses 因定向过滤而为空，也要继续推进游标，否则客户端无法翻到后续可见的帖子。
+		// 游标应该是 postIDs 中的最后一个，因为 postResponses 可能因 GetPosts 的部分未命中或定向过滤而比 postIDs 短。
 		lastReturnedID := postIDs[len(postIDs)-1]
 		nextCursor = &lastReturnedID
 		s.logger.Debug("确定下一页游标 (游标分页)", zap.Uint64("nextCursor", *nextCursor))
@@ -131,47 +194,243 @@ func (s *HotPostService) GetHotPostsByCursor(ctx context.Context, lastPostID *ui
 		s.logger.Debug("已到达热门帖子列表末尾 (游标分页)")
 	}
 
-	return postResponses, nextCursor, nil
+	s.coverSvc.AssignCovers(ctx, postResponses, viewerUserID(viewer))
+	return &vo.ListHotPostsByCursorResponse{Posts: postResponses, NextCursor: nextCursor, CursorReset: cursorReset}, nil
+}
+
+// estimateCursorStart 为已不在当前热榜中的游标帖子估算下一页的起始排名。
+//   - 热榜是全站排行榜 (constant.PostsRankKey) 前 N 名的快照，游标帖子在全站排行榜中的当前排名可近似它在新热榜中的位置，
+//     从其下一名开始返回；名次变动可能导致少量帖子重复或被跳过，由客户端按 CursorReset 去重。
+//   - 帖子已不在全站排行榜中（已删除或计数器已归档）时从榜首重新开始；估算的排名超出热榜长度时返回空页，表示热榜已翻完。
+func (s *HotPostService) estimateCursorStart(ctx context.Context, lastPostID uint64) (int64, error) {
+	ranks, err := s.postViewRepo.GetPostRanks(ctx, []uint64{lastPostID})
+	if err != nil {
+		s.logger.Error("获取游标帖子在全站排行榜中的排名失败 (游标分页)", zap.Error(err), zap.Uint64("lastPostID", lastPostID))
+		return 0, fmt.Errorf("获取帖子排名失败: %w: %w", errHotCacheUnavailable, err)
+	}
+	rank, ok := ranks[lastPostID]
+	if !ok {
+		s.logger.Info("游标帖子已不在热榜与全站排行榜中，从榜首重新开始 (游标分页)", zap.Uint64("lastPostID", lastPostID))
+		return 0, nil
+	}
+	s.logger.Info("游标帖子已不在热榜中，按全站排行榜排名估算起点 (游标分页)", zap.Uint64("lastPostID", lastPostID), zap.Int64("globalRank", rank))
+	return rank + 1, nil
+}
+
+// GetHotPostsByTag 实现按官方标签游标获取热门帖子列表。
+// - 数据来源是热帖缓存任务按 OfficialTag 拆分出的标签热榜 ZSet，游标语义与 GetHotPostsByCursor 一致（上一页最后一条帖子的 ID）。
+// - 过滤（投放定向、Hash 缓存缺失、标签已变更）后不足一页时，会继续向后读取标签热榜，最多 constant.HotPostsTagFillMaxRounds 轮。
+// - 游标始终指向最后一个“已检查”的帖子，而不是最后一个返回的帖子，保证被过滤掉的帖子不会在下一页重复检查。
+// - Redis 不可用时与 GetHotPostsByCursor 一样降级为 MySQL 近似热榜，只保留该标签的帖子。
+func (s *HotPostService) GetHotPostsByTag(ctx context.Context, tag enums.OfficialTag, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error) {
+	if limit <= 0 {
+		s.logger.Warn("GetHotPostsByTag: 请求的 limit 小于或等于0", zap.Int("limit", limit))
+		return nil, errors.New("limit 参数必须大于0")
+	}
+	return s.withHotCacheFallback(ctx, &tag, lastPostID, limit, viewer, func() (*vo.ListHotPostsByCursorResponse, error) {
+		return s.tagHotPostsFromCache(ctx, tag, lastPostID, limit, viewer)
+	})
+}
+
+// tagHotPostsFromCache 从 Redis 标签热榜按游标读取一页帖子，Redis 读取失败时返回可 errors.Is errHotCacheUnavailable 的错误。
+// - 游标帖子不在当前标签热榜中时不报错，从榜首重新开始，响应中 CursorReset 为 true；全站排行榜排名无法对应到标签热榜中的位置，因此不做估算。
+func (s *HotPostService) tagHotPostsFromCache(ctx context.Context, tag enums.OfficialTag, lastPostID *uint64, limit int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error) {
+
+	// 1. 根据游标确定起始排名
+	var start int64
+	cursorReset := false
+	if lastPostID != nil {
+		rank, err := s.postCache.GetTagPostRank(ctx, tag, *lastPostID)
+		if err != nil {
+			s.logger.Error("获取上一页最后帖子在标签热榜中的排名失败", zap.Error(err), zap.Int("tag", int(tag)), zap.Uint64p("lastPostID", lastPostID))
+			return nil, fmt.Errorf("获取帖子排名失败: %w: %w", errHotCacheUnavailable, err)
+		}
+		if rank == -1 {
+			s.logger.Info("游标 lastPostID 已不在标签热榜中，从榜首重新开始", zap.Int("tag", int(tag)), zap.Uint64p("lastPostID", lastPostID))
+			cursorReset = true
+		} else {
+			start = rank + 1
+		}
+	}
+
+	// 2. 逐轮读取标签热榜，直到凑满一页
+	postResponses := make([]*vo.PostResponse, 0, limit)
+	var lastCheckedID *uint64
+	exhausted := false
+	for round := 0; round < constant.HotPostsTagFillMaxRounds && len(postResponses) < limit && !exhausted; round++ {
+		stop := start + int64(limit) - 1
+		postIDs, err := s.postCache.GetTagPostsByRange(ctx, tag, start, stop)
+		if err != nil {
+			s.logger.Error("从标签热榜按排名范围获取帖子 ID 失败", zap.Error(err), zap.Int("tag", int(tag)), zap.Int64("start", start), zap.Int64("stop", stop))
+			return nil, fmt.Errorf("获取帖子 ID 列表失败: %w: %w", errHotCacheUnavailable, err)
+		}
+		if len(postIDs) < limit {
+			exhausted = true
+		}
+		if len(postIDs) == 0 {
+			break
+		}
+
+		posts, err := s.postCache.GetPosts(ctx, postIDs)
+		if err != nil {
+			s.logger.Error("从缓存批量获取帖子实体失败 (标签热榜)", zap.Error(err), zap.Any("postIDs", postIDs))
+			return nil, fmt.Errorf("获取帖子详情失败: %w: %w", errHotCacheUnavailable, err)
+		}
+		targetings, err := s.targetRepo.GetTargetingsByPostIDs(ctx, postIDs)
+		if err != nil {
+			s.logger.Error("批量获取热门帖子投放定向失败 (标签热榜)", zap.Error(err), zap.Int("idCount", len(postIDs)))
+			return nil, fmt.Errorf("获取帖子投放定向失败: %w", err)
+		}
+		postMap := make(map[uint64]*entities.Post, len(posts))
+		for _, post := range posts {
+			if post != nil {
+				postMap[post.ID] = post
+			}
+		}
+
+		// 按 ZSet 中的排名顺序检查，凑满一页后立即停止，剩余的 ID 留给下一页
+		for i, id := range postIDs {
+			checkedID := id
+			lastCheckedID = &checkedID
+			post, ok := postMap[id]
+			if ok && post.OfficialTags.Has(tag) && isTargetingMatched(targetings[id], viewer) {
+				postResponses = append(postResponses, newHotPostResponse(post))
+			}
+			if len(postResponses) == limit {
+				if i < len(postIDs)-1 {
+					exhausted = false // 本批还有未检查的帖子
+				}
+				break
+			}
+		}
+		start += int64(len(postIDs))
+	}
+
+	// 3. 确定下一页游标：榜单已读完且本批全部检查完毕时没有下一页
+	var nextCursor *uint64
+	if !exhausted && lastCheckedID != nil {
+		nextCursor = lastCheckedID
+	}
+	s.logger.Debug("按官方标签获取热门帖子完成",
+		zap.Int("tag", int(tag)),
+		zap.Int("returnedCount", len(postResponses)),
+		zap.Uint64p("nextCursor", nextCursor),
+		zap.Bool("cursorReset", cursorReset),
+	)
+	s.coverSvc.AssignCovers(ctx, postResponses, viewerUserID(viewer))
+	return &vo.ListHotPostsByCursorResponse{Posts: postResponses, NextCursor: nextCursor, CursorReset: cursorReset}, nil
+}
+
+// newHotPostResponse 将热榜 Hash 缓存中的帖子实体转换为列表项 VO。
+func newHotPostResponse(post *entities.Post) *vo.PostResponse {
+	return &vo.PostResponse{
+		ID:             post.ID,
+		Title:          post.Title,
+		Status:         post.Status,
+		ViewCount:      post.ViewCount, // 此 ViewCount 来自帖子 Hash 缓存，是快照值
+		LikeCount:      post.LikeCount,
+		AuthorID:       post.AuthorID,
+		AuthorAvatar:   post.AuthorAvatar,
+		AuthorUsername: post.AuthorUsername,
+		OfficialTag:    post.OfficialTags.Primary(),
+		OfficialTags:   post.OfficialTags.Tags(),
+		CopyrightType:  post.CopyrightType,
+		RepostCount:    post.RepostCount,
+		QuotedPostID:   post.QuotedPostID,
+		CreatedAt:      post.CreatedAt,
+		UpdatedAt:      post.UpdatedAt,
+	}
 }
 
 // GetHotPostDetail 实现获取热门帖子详情的逻辑。
 // - userID 用于触发浏览量增加。如果 userID 为空字符串，通常不应增加浏览量（需在 Controller 或此处校验）。
-func (s *HotPostService) GetHotPostDetail(ctx context.Context, postID uint64, userID string) (*vo.PostDetailVO, error) {
+// - viewer 不满足帖子投放定向条件时，按帖子不存在处理，返回 commonerrors.ErrRepoNotFound。
+// - 按帖子的访问策略执行鉴权钩子链，不通过时返回 *PostAccessDeniedError，且不计入浏览量。
+// - 缓存未命中时回源数据库，并异步将结果写回 Redis；帖子在数据库中也不存在时返回 commonerrors.ErrRepoNotFound。
+func (s *HotPostService) GetHotPostDetail(ctx context.Context, postID uint64, userID string, viewer *dto.ViewerAttributes) (*vo.PostDetailVO, error) {
 	s.logger.Debug("获取热门帖子详情", zap.Uint64("postID", postID), zap.String("userID", userID))
 
-	// 1. 异步增加帖子的浏览计数。
-	//    前提：userID 不为空时才进行计数。此校验通常在 Controller 层完成，或在此处补充。
-	if userID != "" { // 确保有有效的用户ID才增加浏览量
-		go func(pID uint64, uID string) {
-			// 为异步 Goroutine 创建新的后台上下文，不直接使用原始请求的 ctx，以防请求提前结束。
-			// 但也可以考虑设置一个合理的短超时，例如1-2秒。
-			bgCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second) // 短超时
-			defer cancel()
-
-			if err := s.postViewRepo.IncrementViewCount(bgCtx, pID, uID); err != nil {
-				s.logger.Error("异步增加热门帖子浏览量失败",
-					zap.Error(err),
-					zap.Uint64("post_id", pID),
-					zap.String("user_id", uID))
-			} else {
-				s.logger.Debug("成功触发异步增加热门帖子浏览量", zap.Uint64("post_id", pID), zap.String("user_id", uID))
-			}
-		}(postID, userID)
-	} else {
-		s.logger.Debug("未提供 userID，跳过增加浏览量步骤", zap.Uint64("postID", postID))
+	// 0. 校验投放定向，不可见的帖子既不返回详情，也不计入浏览量。
+	targeting, err := s.targetRepo.GetTargetingByPostID(ctx, postID)
+	if err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		s.logger.Error("获取热门帖子投放定向失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子投放定向失败: %w", err)
+	}
+	if !isTargetingMatched(targeting, viewer) {
+		s.logger.Info("当前用户不满足热门帖子投放定向条件，隐藏该帖", zap.Uint64("postID", postID), zap.String("userID", userID))
+		return nil, commonerrors.ErrRepoNotFound
 	}
 
-	// 2. 从 Redis 缓存中获取帖子详情。
-	//    s.postCache.GetPostDetail 应该返回 *vo.PostDetailResponse
+	// 1. 从 Redis 缓存中获取帖子详情，命中时按访问策略鉴权通过后才计入浏览量。
 	postDetailVO, err := s.postCache.GetPostDetail(ctx, postID)
-	if err != nil {
-		// 直接返回从 cache 层获取的错误，包括 myErrors.ErrCacheMiss
+	if err == nil {
+		if err := s.accessGuard.Check(ctx, &PostAccessRequest{
+			PostID:       postDetailVO.ID,
+			AuthorID:     postDetailVO.AuthorID,
+			AccessPolicy: postDetailVO.AccessPolicy,
+			UserID:       userID,
+			Viewer:       viewer,
+		}); err != nil {
+			return nil, err
+		}
+		s.incrementViewCountAsync(postID, viewCounterMember(userID, viewer))
+		s.logger.Debug("成功从缓存获取帖子详情", zap.Uint64("postID", postID))
+		return postDetailVO, nil
+	}
+	if !errors.Is(err, myErrors.ErrCacheMiss) {
 		s.logger.Warn("从缓存获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
-		return nil, err // 直接传递错误，上层 Controller 处理回源或具体错误响应
+		return nil, err
+	}
+
+	// 2. 缓存未命中，回源数据库。
+	//    回源时由 PostService 完成访问鉴权与浏览计数，这里不再重复计数。
+	s.logger.Info("热门帖子详情缓存未命中，回源数据库", zap.Uint64("postID", postID))
+	postDetailVO, err = s.postService.GetPostDetailByPostID(ctx, postID, userID, viewer)
+	if err != nil {
+		return nil, err // ErrRepoNotFound 原样返回，由 Controller 映射为 404；鉴权失败返回 *PostAccessDeniedError
 	}
 
-	s.logger.Debug("成功从缓存获取帖子详情", zap.Uint64("postID", postID))
+	// 3. 异步写回 Redis，使用较短的 TTL，失败只记录日志。
+	// - 热门详情 Key 对所有访问者共享，只写回审核通过的非草稿帖子：作者回源看到的草稿或待审核帖子不能对其他用户可见
+	// - 审核原因只对作者可见，写回前清空
+	if postDetailVO.Status != enums.Approved || postDetailVO.IsDraft {
+		return postDetailVO, nil
+	}
+	cacheDetail := *postDetailVO
+	cacheDetail.AuditReason = nil
+	go func(detail vo.PostDetailVO) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if setErr := s.postCache.SetHotPostDetail(bgCtx, detail.ID, &detail, constant.HotPostDetailFallbackTTL); setErr != nil {
+			s.logger.Error("回源后写回热门帖子详情缓存失败", zap.Error(setErr), zap.Uint64("postID", detail.ID))
+		}
+	}(cacheDetail)
 
-	// 3. 返回详情 VO。
 	return postDetailVO, nil
 }
+
+// incrementViewCountAsync 异步增加热门帖子的浏览计数，viewer 为访客标识（见 viewCounterMember），Member 为空时跳过。
+func (s *HotPostService) incrementViewCountAsync(postID uint64, viewer redis.ViewerIdentity) {
+	if viewer.Member == "" {
+		s.logger.Debug("未提供访客标识，跳过增加浏览量步骤", zap.Uint64("postID", postID))
+		return
+	}
+	if s.viewAggregator != nil {
+		s.viewAggregator.Record(postID, viewer)
+		return
+	}
+	go func(pID uint64, v redis.ViewerIdentity) {
+		// 为异步 Goroutine 创建新的后台上下文，不直接使用原始请求的 ctx，以防请求提前结束。
+		bgCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second) // 短超时
+		defer cancel()
+
+		if err := s.postViewRepo.IncrementViewCount(bgCtx, pID, v); err != nil {
+			s.logger.Error("异步增加热门帖子浏览量失败",
+				zap.Error(err),
+				zap.Uint64("post_id", pID),
+				zap.String("user_id", v.Member))
+		} else {
+			s.logger.Debug("成功触发异步增加热门帖子浏览量", zap.Uint64("post_id", pID), zap.String("user_id", v.Member))
+		}
+	}(postID, viewer)
+}
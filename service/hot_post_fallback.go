@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// errHotCacheUnavailable 标记热榜读取 Redis 时发生的错误，调用方据此决定是否降级到 MySQL。
+var errHotCacheUnavailable = errors.New("hot post cache unavailable")
+
+// hotCacheHealth 记录热榜读取 Redis 的健康状态。
+// - Redis 读取失败后的 constant.HotPostsRedisRetryInterval 内直接走降级路径，不再让每个请求都等待 Redis 超时。
+// - 期满后的下一次请求重新尝试 Redis，成功即自动切回，无需后台探测。
+type hotCacheHealth struct {
+	mu             sync.Mutex
+	unhealthyUntil time.Time // 在此之前视为 Redis 不可用，零值表示健康
+	logger         *core.ZapLogger
+}
+
+// available 返回当前是否应该尝试读取 Redis。
+func (h *hotCacheHealth) available() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Now().After(h.unhealthyUntil)
+}
+
+// markFailure 记录一次 Redis 读取失败，在重试间隔内跳过 Redis。
+func (h *hotCacheHealth) markFailure(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.unhealthyUntil.IsZero() {
+		h.logger.Error("热榜读取 Redis 失败，切换到 MySQL 降级热榜", zap.Error(err), zap.Duration("retryAfter", constant.HotPostsRedisRetryInterval))
+	}
+	h.unhealthyUntil = time.Now().Add(constant.HotPostsRedisRetryInterval)
+}
+
+// markSuccess 记录一次 Redis 读取成功，之前处于降级状态时切回。
+func (h *hotCacheHealth) markSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.unhealthyUntil.IsZero() {
+		h.logger.Info("热榜读取 Redis 已恢复，切回 Redis 热榜")
+		h.unhealthyUntil = time.Time{}
+	}
+}
+
+// hotPostsFallback 在 Redis 不可用时提供近似热榜：从 MySQL 按 view_count DESC 读取已审核通过的帖子。
+// - 只读取对所有用户可见（未设置投放定向）的帖子，结果与用户无关，可以整体缓存在本地内存。
+// - 缓存 constant.HotPostsFallbackCacheTTL，分页与按官方标签筛选都在缓存的列表上完成。
+// - 浏览量是 MySQL 中已同步的值，排序与 Redis 热榜存在差异，响应中带降级标记。
+type hotPostsFallback struct {
+	postRepo mysql.PostRepository
+	logger   *core.ZapLogger
+
+	mu        sync.Mutex
+	posts     []*entities.Post
+	fetchedAt time.Time
+}
+
+// load 返回缓存的降级热榜，过期时从 MySQL 重新读取。
+// - 读取失败但有旧数据时继续使用旧数据，保证基本可用。
+func (f *hotPostsFallback) load(ctx context.Context) ([]*entities.Post, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.posts != nil && time.Since(f.fetchedAt) < constant.HotPostsFallbackCacheTTL {
+		return f.posts, nil
+	}
+
+	posts, _, err := f.postRepo.GetPostsByViewCountCursor(ctx, nil, nil, constant.HotPostsCacheSize)
+	if err != nil {
+		if f.posts != nil {
+			f.logger.Warn("刷新降级热榜失败，继续使用过期的本地缓存", zap.Error(err), zap.Time("fetchedAt", f.fetchedAt))
+			return f.posts, nil
+		}
+		return nil, fmt.Errorf("从数据库获取降级热榜失败: %w", err)
+	}
+	if posts == nil {
+		posts = []*entities.Post{}
+	}
+	f.posts, f.fetchedAt = posts, time.Now()
+	return posts, nil
+}
+
+// page 在降级热榜上按游标分页，tag 不为 nil 时只返回该官方标签的帖子。
+// - 游标语义与 Redis 热榜一致（上一页最后一条帖子的 ID）；游标帖子不在降级热榜中时从榜首重新开始，响应中 CursorReset 为 true。
+func (f *hotPostsFallback) page(ctx context.Context, tag *enums.OfficialTag, lastPostID *uint64, limit int) (*vo.ListHotPostsByCursorResponse, error) {
+	posts, err := f.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := posts
+	if tag != nil {
+		candidates = make([]*entities.Post, 0, len(posts))
+		for _, post := range posts {
+			if post.OfficialTags.Has(*tag) {
+				candidates = append(candidates, post)
+			}
+		}
+	}
+
+	start := 0
+	cursorReset := false
+	if lastPostID != nil {
+		cursorReset = true
+		for i, post := range candidates {
+			if post.ID == *lastPostID {
+				start, cursorReset = i+1, false
+				break
+			}
+		}
+	}
+
+	end := min(start+limit, len(candidates))
+	result := &vo.ListHotPostsByCursorResponse{
+		Posts:       make([]*vo.PostResponse, 0, end-start),
+		Degraded:    true,
+		CursorReset: cursorReset,
+	}
+	for _, post := range candidates[start:end] {
+		result.Posts = append(result.Posts, newHotPostResponse(post))
+	}
+	if end < len(candidates) {
+		lastID := candidates[end-1].ID
+		result.NextCursor = &lastID
+	}
+	return result, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// missReadCache 总是未命中，并记录回源后的写回。
+type missReadCache struct {
+	redis.PostReadCache
+	written chan vo.PostDetailVO
+}
+
+func (c *missReadCache) GetPostDetail(ctx context.Context, postID uint64) (*vo.PostDetailVO, error) {
+	return nil, myErrors.ErrCacheMiss
+}
+
+func (c *missReadCache) SetHotPostDetail(ctx context.Context, postID uint64, detail *vo.PostDetailVO, ttl time.Duration) error {
+	c.written <- *detail
+	return nil
+}
+
+// noTargetingRepo 表示帖子没有投放定向条件。
+type noTargetingRepo struct {
+	mysql.PostTargetingRepository
+}
+
+func (noTargetingRepo) GetTargetingByPostID(ctx context.Context, postID uint64) (*entities.PostTargeting, error) {
+	return nil, commonerrors.ErrRepoNotFound
+}
+
+func (noTargetingRepo) GetTargetingsByPostIDs(ctx context.Context, postIDs []uint64) (map[uint64]*entities.PostTargeting, error) {
+	return map[uint64]*entities.PostTargeting{}, nil
+}
+
+// fixedDetailPostService 回源时返回固定的详情，模拟 PostService 按访问者组装的结果。
+type fixedDetailPostService struct {
+	PostService
+	detail vo.PostDetailVO
+}
+
+func (s *fixedDetailPostService) GetPostDetailByPostID(ctx context.Context, postID uint64, userID string, viewer *dto.ViewerAttributes) (*vo.PostDetailVO, error) {
+	detail := s.detail
+	return &detail, nil
+}
+
+func TestGetHotPostDetailKeepsViewerSpecificFallbackOutOfSharedCache(t *testing.T) {
+	reason := "图片不清晰"
+	const authorID = "author-1"
+	tests := []struct {
+		name      string
+		detail    vo.PostDetailVO
+		wantWrite bool
+	}{
+		{name: "作者查看自己的草稿", detail: vo.PostDetailVO{ID: 1, AuthorID: authorID, Status: enums.Pending, IsDraft: true}},
+		{name: "作者查看自己被拒的帖子", detail: vo.PostDetailVO{ID: 2, AuthorID: authorID, Status: enums.Rejected, AuditReason: &reason}},
+		{name: "待审核帖子", detail: vo.PostDetailVO{ID: 3, AuthorID: authorID, Status: enums.Pending}},
+		{name: "审核通过的帖子", detail: vo.PostDetailVO{ID: 4, AuthorID: authorID, Status: enums.Approved, AuditReason: &reason}, wantWrite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := newTestLogger(t)
+			cache := &missReadCache{written: make(chan vo.PostDetailVO, 1)}
+			svc := NewHotPostService(cache, nil, nil, noTargetingRepo{}, nil,
+				&fixedDetailPostService{detail: tt.detail}, NewPostAccessGuard(logger), nil, logger)
+
+			got, err := svc.GetHotPostDetail(context.Background(), tt.detail.ID, authorID, nil)
+			if err != nil {
+				t.Fatalf("GetHotPostDetail 返回错误: %v", err)
+			}
+			if (got.AuditReason == nil) != (tt.detail.AuditReason == nil) {
+				t.Fatalf("返回给作者的审核原因不应被清空")
+			}
+
+			select {
+			case written := <-cache.written:
+				if !tt.wantWrite {
+					t.Fatalf("不应写回共享的热门详情缓存, 实际写入了帖子 %d", written.ID)
+				}
+				if written.AuditReason != nil {
+					t.Fatalf("写回共享缓存的详情不应包含审核原因")
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.wantWrite {
+					t.Fatalf("审核通过的帖子应写回热门详情缓存")
+				}
+			}
+		})
+	}
+}
+
+// rankedHotCache 是内存中的全站热榜与标签热榜，记录每次按排名范围读取的起点。
+type rankedHotCache struct {
+	redis.PostReadCache
+	hot    []uint64
+	tagHot []uint64
+	starts []int64
+}
+
+func rankIn(ids []uint64, postID uint64) int64 {
+	for i, id := range ids {
+		if id == postID {
+			return int64(i)
+		}
+	}
+	return -1
+}
+
+func rangeIn(ids []uint64, start, stop int64) []uint64 {
+	if start >= int64(len(ids)) {
+		return nil
+	}
+	stop = min(stop, int64(len(ids))-1)
+	return append([]uint64(nil), ids[start:stop+1]...)
+}
+
+func (c *rankedHotCache) GetPostRank(ctx context.Context, postID uint64) (int64, error) {
+	return rankIn(c.hot, postID), nil
+}
+
+func (c *rankedHotCache) GetPostsByRange(ctx context.Context, start, stop int64) ([]uint64, error) {
+	c.starts = append(c.starts, start)
+	return rangeIn(c.hot, start, stop), nil
+}
+
+func (c *rankedHotCache) GetTagPostRank(ctx context.Context, tag enums.OfficialTag, postID uint64) (int64, error) {
+	return rankIn(c.tagHot, postID), nil
+}
+
+func (c *rankedHotCache) GetTagPostsByRange(ctx context.Context, tag enums.OfficialTag, start, stop int64) ([]uint64, error) {
+	c.starts = append(c.starts, start)
+	return rangeIn(c.tagHot, start, stop), nil
+}
+
+func (c *rankedHotCache) GetPosts(ctx context.Context, postIDs []uint64) ([]*entities.Post, error) {
+	posts := make([]*entities.Post, 0, len(postIDs))
+	for _, id := range postIDs {
+		post := &entities.Post{Status: enums.Approved, OfficialTags: entities.OfficialTagFlag(enums.OfficialTagCertified)}
+		post.ID = id
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+// globalRankRepo 返回游标帖子在全站排行榜中的排名，并记录查询次数。
+type globalRankRepo struct {
+	redis.PostViewRepository
+	ranks map[uint64]int64
+	err   error
+	calls int
+}
+
+func (r *globalRankRepo) GetPostRanks(ctx context.Context, postIDs []uint64) (map[uint64]int64, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	result := make(map[uint64]int64)
+	for _, id := range postIDs {
+		if rank, ok := r.ranks[id]; ok {
+			result[id] = rank
+		}
+	}
+	return result, nil
+}
+
+// noopCoverService 表示没有帖子开启封面实验。
+type noopCoverService struct {
+	CoverExperimentService
+}
+
+func (noopCoverService) AssignCovers(ctx context.Context, posts []*vo.PostResponse, userID string) {}
+
+// viewCountPostRepo 是降级热榜读取的 MySQL 近似热榜。
+type viewCountPostRepo struct {
+	mysql.PostRepository
+	posts []*entities.Post
+}
+
+func (r *viewCountPostRepo) GetPostsByViewCountCursor(ctx context.Context, cursor *dto.PostViewCountCursor, viewer *dto.ViewerAttributes, pageSize int) ([]*entities.Post, *dto.PostViewCountCursor, error) {
+	return r.posts, nil, nil
+}
+
+func newCursorTestService(t *testing.T, cache *rankedHotCache, ranks *globalRankRepo, postRepo mysql.PostRepository) *HotPostService {
+	logger := newTestLogger(t)
+	return NewHotPostService(cache, ranks, nil, noTargetingRepo{}, postRepo, nil, NewPostAccessGuard(logger), noopCoverService{}, logger)
+}
+
+func responseIDs(posts []*vo.PostResponse) []uint64 {
+	ids := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	return ids
+}
+
+func TestGetHotPostsByCursorRecoversStaleCursor(t *testing.T) {
+	hot := []uint64{101, 102, 103, 104, 105, 106, 107, 108, 109, 110}
+	const staleID = 999
+	tests := []struct {
+		name            string
+		cursor          uint64
+		globalRanks     map[uint64]int64
+		wantStart       int64
+		wantIDs         []uint64
+		wantReset       bool
+		wantGlobalCalls int
+	}{
+		{name: "游标仍在热榜中", cursor: 103, wantStart: 3, wantIDs: []uint64{104, 105, 106}},
+		{name: "游标已出榜，按全站排名估算起点", cursor: staleID, globalRanks: map[uint64]int64{staleID: 5},
+			wantStart: 6, wantIDs: []uint64{107, 108, 109}, wantReset: true, wantGlobalCalls: 1},
+		{name: "游标已出榜且不在全站排行榜中", cursor: staleID,
+			wantStart: 0, wantIDs: []uint64{101, 102, 103}, wantReset: true, wantGlobalCalls: 1},
+		{name: "估算的起点超出热榜长度", cursor: staleID, globalRanks: map[uint64]int64{staleID: 20},
+			wantStart: 21, wantIDs: []uint64{}, wantReset: true, wantGlobalCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &rankedHotCache{hot: hot}
+			ranks := &globalRankRepo{ranks: tt.globalRanks}
+			svc := newCursorTestService(t, cache, ranks, nil)
+
+			cursor := tt.cursor
+			got, err := svc.GetHotPostsByCursor(context.Background(), &cursor, 3, nil)
+			if err != nil {
+				t.Fatalf("GetHotPostsByCursor 返回错误: %v", err)
+			}
+			if len(cache.starts) != 1 || cache.starts[0] != tt.wantStart {
+				t.Fatalf("读取热榜的起点 = %v, want [%d]", cache.starts, tt.wantStart)
+			}
+			if ids := responseIDs(got.Posts); !slices.Equal(ids, tt.wantIDs) {
+				t.Fatalf("返回的帖子 = %v, want %v", ids, tt.wantIDs)
+			}
+			if got.CursorReset != tt.wantReset {
+				t.Fatalf("CursorReset = %v, want %v", got.CursorReset, tt.wantReset)
+			}
+			if got.Degraded {
+				t.Fatalf("游标出榜不应触发降级")
+			}
+			if ranks.calls != tt.wantGlobalCalls {
+				t.Fatalf("查询全站排行榜 %d 次, want %d", ranks.calls, tt.wantGlobalCalls)
+			}
+		})
+	}
+}
+
+func TestGetHotPostsByCursorFallsBackWhenGlobalRankFails(t *testing.T) {
+	fallbackPosts := make([]*entities.Post, 0, 2)
+	for _, id := range []uint64{201, 202} {
+		post := &entities.Post{Status: enums.Approved}
+		post.ID = id
+		fallbackPosts = append(fallbackPosts, post)
+	}
+	cache := &rankedHotCache{hot: []uint64{101, 102}}
+	ranks := &globalRankRepo{err: errors.New("redis unavailable")}
+	svc := newCursorTestService(t, cache, ranks, &viewCountPostRepo{posts: fallbackPosts})
+
+	cursor := uint64(999)
+	got, err := svc.GetHotPostsByCursor(context.Background(), &cursor, 3, nil)
+	if err != nil {
+		t.Fatalf("GetHotPostsByCursor 返回错误: %v", err)
+	}
+	if !got.Degraded {
+		t.Fatalf("估算游标起点失败时应降级为 MySQL 近似热榜")
+	}
+	if !got.CursorReset {
+		t.Fatalf("游标不在降级热榜中时 CursorReset 应为 true")
+	}
+	if ids := responseIDs(got.Posts); !slices.Equal(ids, []uint64{201, 202}) {
+		t.Fatalf("返回的帖子 = %v, want [201 202]", ids)
+	}
+}
+
+func TestGetHotPostsByTagResetsStaleCursorToTop(t *testing.T) {
+	const staleID = 999
+	cache := &rankedHotCache{tagHot: []uint64{301, 302, 303, 304}}
+	// 全站排行榜中的排名对标签热榜没有意义，不应被用来估算起点
+	ranks := &globalRankRepo{ranks: map[uint64]int64{staleID: 1}}
+	svc := newCursorTestService(t, cache, ranks, nil)
+
+	cursor := uint64(staleID)
+	got, err := svc.GetHotPostsByTag(context.Background(), enums.OfficialTagCertified, &cursor, 2, nil)
+	if err != nil {
+		t.Fatalf("GetHotPostsByTag 返回错误: %v", err)
+	}
+	if len(cache.starts) == 0 || cache.starts[0] != 0 {
+		t.Fatalf("读取标签热榜的起点 = %v, want 从 0 开始", cache.starts)
+	}
+	if ids := responseIDs(got.Posts); !slices.Equal(ids, []uint64{301, 302}) {
+		t.Fatalf("返回的帖子 = %v, want [301 302]", ids)
+	}
+	if !got.CursorReset {
+		t.Fatalf("CursorReset = false, want true")
+	}
+	if got.NextCursor == nil || *got.NextCursor != 302 {
+		t.Fatalf("NextCursor = %v, want 302", got.NextCursor)
+	}
+	if ranks.calls != 0 {
+		t.Fatalf("标签热榜不应查询全站排行榜, 实际查询 %d 次", ranks.calls)
+	}
+}
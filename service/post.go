@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/go-common/models/kafkaevents"
+	"github.com/Xushengqwer/post_service/config"
 	"github.com/Xushengqwer/post_service/constant"
 	"github.com/Xushengqwer/post_service/dependencies"
 	"github.com/google/uuid"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/Xushengqwer/post_service/models/vo"
 	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/myErrors"
 	"github.com/Xushengqwer/post_service/repo/mysql"
 	"github.com/Xushengqwer/post_service/repo/redis"
 )
@@ -34,10 +36,17 @@ type PostService interface {
 	// CreatePost 处理用户发布新帖子的业务流程。
 	// - 接收 DTO 作为输入，封装了创建帖子所需的所有信息,包括帖子基础信息，帖子详情信息，帖子详情图
 	// - 负责将帖子及其详情原子性地写入数据库。
-	// - 成功创建后，异步触发 Kafka 事件通知审核服务。
+	// - 写库前按目标地区合并规则集做合规预检，命中禁止词时返回 myErrors.ErrContentNotCompliant。
+	// - 成功创建后，异步触发 Kafka 事件通知审核服务，事件消息头携带目标地区与命中的待复审敏感词。
 	// - 返回 VO，包含成功创建的帖子的基本信息。
+	// - 未设置 IgnoreSimilar 时做发布前查重，命中高度相似的已有帖子时返回 *SimilarPostsFoundError（转发帖与草稿不查重）。
+	// - 设置了 IdempotencyKey 时，同一作者相同键的重复请求直接返回首次创建的结果；首次请求仍在处理中时返回 myErrors.ErrIdempotentRequestInProgress。
 	CreatePost(ctx context.Context, req *dto.CreatePostRequest, imageFiles []*multipart.FileHeader) (*vo.PostDetailVO, error)
 
+	// CheckSimilarPosts 预览发布前查重结果：在作者本人近期帖子与全站近期已审核通过帖子中查找标题+内容高度相似的帖子。
+	// - 不创建帖子；Similar 为空表示可以直接发布。
+	CheckSimilarPosts(ctx context.Context, authorID string, req *dto.CheckSimilarPostsRequest) (*vo.SimilarPostsVO, error)
+
 	// DeletePost 处理用户删除帖子的操作。
 	// - 接收帖子 ID 作为输入。
 	// - 执行数据库软删除（帖子和详情），确保操作的原子性。
@@ -46,36 +55,167 @@ type PostService interface {
 
 	// GetPostDetailByPostID 获取单个帖子的详细信息。
 	// - 接收帖子 ID 作为输入。
-	// - 从数据库获取帖子详情数据。
-	// - 异步增加帖子的浏览计数（如果用户已登录）。
+	// - 优先从缓存获取帖子详情，未命中时从数据库获取并写入短 TTL 的普通详情缓存。
+	// - 如果帖子配置了投放定向且当前用户 (viewer) 不满足条件，按帖子不存在处理，返回 commonerrors.ErrRepoNotFound。
+	// - 按帖子的访问策略执行鉴权钩子链，不通过时返回 *PostAccessDeniedError（需登录/需付费/无权限）。
+	// - 异步增加帖子的浏览计数（如果用户已登录且通过鉴权）。
+	// - 返回的浏览量优先使用 Redis 实时值（与持久化值取较大者），Redis 不可用时降级为持久化值。
 	// - 将实体数据转换为前端展示所需的 VO。
-	GetPostDetailByPostID(ctx context.Context, postID uint64, userID string) (*vo.PostDetailVO, error)
+	GetPostDetailByPostID(ctx context.Context, postID uint64, userID string, viewer *dto.ViewerAttributes) (*vo.PostDetailVO, error)
+
+	// MergeAnonymousViews 在用户登录后将当前设备 (viewer.ClientID 中的设备标识) 的匿名浏览记录归并到用户。
+	// - 归并后用户在任何设备上浏览这些帖子都不会重复计数；浏览量本身不变。
+	// - 未登录返回 commonerrors.ErrUserNotLoggedIn；请求没有设备标识时返回 myErrors.ErrDeviceIDRequired。
+	MergeAnonymousViews(ctx context.Context, userID string, viewer *dto.ViewerAttributes) (*vo.MergeAnonymousViewsVO, error)
+
+	// UpdatePostFAQs 整体替换帖子的 FAQ 列表。
+	// - 只有帖子作者可以修改，否则返回 myErrors.ErrPermissionDenied。
+	// - 帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	// - 列表顺序即展示顺序，传入空列表表示清空。
+	UpdatePostFAQs(ctx context.Context, postID uint64, userID string, faqs []dto.PostFAQItem) ([]vo.PostFAQVO, error)
+
+	// GetPostSEO 基于帖子标题、正文摘要与官方标签实时生成 SEO 元数据（meta description / keywords）。
+	// - 帖子不存在、未审核通过或对匿名访客不可见时返回 commonerrors.ErrRepoNotFound。
+	GetPostSEO(ctx context.Context, postID uint64) (*vo.PostSEOVO, error)
+
+	// LikePost 为帖子点赞。
+	// - 只有已审核通过的帖子可以点赞，帖子不存在或未通过审核时返回 commonerrors.ErrRepoNotFound。
+	// - 重复点赞是幂等的：不会重复计数，返回当前点赞状态与点赞数。
+	LikePost(ctx context.Context, postID uint64, userID string) (*vo.PostLikeVO, error)
+
+	// UnlikePost 取消对帖子的点赞。
+	// - 帖子不存在时返回 commonerrors.ErrRepoNotFound；取消未点赞的帖子是幂等的，点赞数不变。
+	UnlikePost(ctx context.Context, postID uint64, userID string) (*vo.PostLikeVO, error)
+
+	// PublishDraft 发布草稿：将草稿转为待审核状态并发送待审核事件，送审优先级沿用创建草稿时确定的值。
+	// - 帖子不存在时返回 commonerrors.ErrRepoNotFound；非作者本人返回 myErrors.ErrPermissionDenied。
+	// - 帖子不是草稿（或已被并发发布）时返回 myErrors.ErrPostNotDraft。
+	// - 内容命中目标地区规则集中的禁止词时返回 myErrors.ErrContentNotCompliant。
+	PublishDraft(ctx context.Context, postID uint64, userID string) error
+
+	// ReportPost 用户举报帖子。
+	// - 只有已审核通过的帖子可以被举报，帖子不存在或未通过审核时返回 commonerrors.ErrRepoNotFound。
+	// - 同一用户对同一帖子只能举报一次，重复举报返回 myErrors.ErrPostAlreadyReported。
+	// - 举报数恰好达到 constant.PostReportReviewThreshold 时，在同一事务内写入自动下架审查事件的发件箱记录。
+	ReportPost(ctx context.Context, postID uint64, reporterID string, reason string) error
+
+	// UpdatePost 作者编辑审核通过或被拒的帖子，整体替换标题、正文、单价与联系方式后重新送审。
+	// - 正文按行分段，与上次送审的段落比对：未变更且已审核通过的段落沿用通过结果，只把变更的段落放入待审核事件（增量送审），
+	//   事件消息头标记各段落的序号与其中新增的段落；变更过多或没有可沿用的段落时整篇送审。
+	// - 送审期间帖子为待审核状态，对其他用户不可见；审核结果按段落合并，任一段落未通过则整帖被拒。
+	// - 帖子不存在时返回 commonerrors.ErrRepoNotFound；非作者本人返回 myErrors.ErrPermissionDenied。
+	// - 帖子是草稿、正在审核中（或编辑期间状态被并发修改）时返回 myErrors.ErrPostNotEditable。
+	// - 内容命中目标地区规则集中的禁止词时返回 myErrors.ErrContentNotCompliant；清洗后正文为空时返回 myErrors.ErrPostContentEmpty。
+	UpdatePost(ctx context.Context, postID uint64, userID string, req *dto.UpdatePostRequest) error
+
+	// AppealPost 作者申诉审核被拒的帖子：帖子重新置为待审核，并发送携带申诉理由的待审核事件（要求人工复审）。
+	// - 帖子不存在时返回 commonerrors.ErrRepoNotFound；非作者本人返回 myErrors.ErrPermissionDenied。
+	// - 帖子不是拒绝状态（或已被并发申诉）时返回 myErrors.ErrPostNotRejected；申诉次数达到 constant.PostAppealMaxCount 时返回 myErrors.ErrAppealLimitExceeded。
+	// - 内容命中目标地区规则集中的禁止词时返回 myErrors.ErrContentNotCompliant。
+	// - 申诉成功后写入帖子审计日志，操作人为 "author:{userID}"，原因为申诉理由。
+	AppealPost(ctx context.Context, postID uint64, userID string, appealReason string) error
+
+	// PrepareImageUpload 为客户端直传 COS 生成图片对象键与预签名上传 URL。
+	// - 客户端凭 URL 以 PUT 上传图片后，在创建帖子时通过 ImageObjectKeys 提交对象键，图片内容不经过本服务。
+	// - 文件扩展名不是支持的图片格式时返回 myErrors.ErrInvalidPostImage。
+	PrepareImageUpload(ctx context.Context, userID string, filename string) (*vo.PresignedImageUploadVO, error)
 }
 
 // postService 是 PostService 接口的具体实现。
 type postService struct {
-	postRepo            mysql.PostRepository            // 负责帖子的 MySQL 操作
-	postDetailRepo      mysql.PostDetailRepository      // 负责帖子详情的 MySQL 操作
-	postDetailImageRepo mysql.PostDetailImageRepository // 帖子详情图的MySQL操作
-	cosClient           dependencies.COSClientInterface // cos云服务依赖
-	postViewRepo        redis.PostViewRepository        // 负责帖子浏览量相关的 Redis 操作
-	db                  *gorm.DB                        // GORM 数据库实例，主要用于事务管理
-	kafkaSvc            *producer.KafkaProducer         // Kafka 生产者，用于发送异步消息
-	logger              *core.ZapLogger                 // 日志记录器，用于记录关键信息和错误
+	postRepo            mysql.PostRepository               // 负责帖子的 MySQL 操作
+	postDetailRepo      mysql.PostDetailRepository         // 负责帖子详情的 MySQL 操作
+	postDetailImageRepo mysql.PostDetailImageRepository    // 帖子详情图的MySQL操作
+	postTargetingRepo   mysql.PostTargetingRepository      // 帖子投放定向条件的 MySQL 操作
+	postFAQRepo         mysql.PostFAQRepository            // 帖子 FAQ 的 MySQL 操作
+	postReportRepo      mysql.PostReportRepository         // 用户举报帖子的 MySQL 操作
+	postAuditRepo       mysql.PostAuditLogRepository       // 帖子状态流转审计日志（作者申诉）
+	segmentRepo         mysql.PostContentSegmentRepository // 正文段落审核状态，编辑后只送审变更的段落
+	cosClient           dependencies.COSClientInterface    // cos云服务依赖
+	cosDeleteQueue      redis.COSDeleteQueue               // COS 图片延迟删除队列，软删除帖子后图片保留一段时间再删除
+	postViewRepo        redis.PostViewRepository           // 负责帖子浏览量相关的 Redis 操作
+	viewAggregator      *ViewCountAggregator               // 浏览增量进程内聚合，为 nil 时每次浏览直接写入 Redis
+	postLikeRepo        redis.PostLikeRepository           // 负责帖子点赞相关的 Redis 操作
+	postCache           redis.PostReadCache                // 帖子详情缓存（热门详情与普通详情）
+	db                  *gorm.DB                           // GORM 数据库实例，主要用于事务管理
+	kafkaSvc            *producer.KafkaProducer            // Kafka 生产者，用于发送异步消息
+	outboxRepo          mysql.OutboxRepository             // 事务性发件箱，保证待审核、删除事件与业务数据一起提交
+	auditPriorityCfg    config.AuditPriorityConfig         // 审核优先级配置，创建帖子时决定送审优先级
+	accessGuard         *PostAccessGuard                   // 帖子详情访问鉴权钩子链
+	contentSanitizer    ContentSanitizer                   // 正文写库前的 HTML 清洗器
+	complianceChecker   ContentComplianceChecker           // 按目标地区的内容合规预检
+	imageValidator      postImageValidator                 // 上传前校验图片数量、大小与类型
+	imageCompressor     postImageCompressor                // 上传前对图片做有损压缩，失败回退原图
+	imageImporter       postImageImporter                  // 从 URL 下载图片并与上传的文件合并
+	imagePresignExpiry  time.Duration                      // 图片直传预签名上传 URL 的有效期
+	idempotencyStore    redis.IdempotencyStore             // 创建帖子的幂等键记录，防止客户端重试导致重复创建
+	logger              *core.ZapLogger                    // 日志记录器，用于记录关键信息和错误
+}
+
+// PostServiceDeps 是 NewPostService 的依赖集合，各字段含义见 postService 的同名字段。
+// - 按字段名赋值，新增依赖时不会因参数位置错位而把同类型的依赖传错。
+type PostServiceDeps struct {
+	DB                  *gorm.DB
+	PostRepo            mysql.PostRepository
+	PostDetailRepo      mysql.PostDetailRepository
+	PostDetailImageRepo mysql.PostDetailImageRepository
+	PostTargetingRepo   mysql.PostTargetingRepository
+	PostFAQRepo         mysql.PostFAQRepository
+	PostReportRepo      mysql.PostReportRepository
+	PostAuditRepo       mysql.PostAuditLogRepository
+	SegmentRepo         mysql.PostContentSegmentRepository
+	COSClient           dependencies.COSClientInterface
+	COSDeleteQueue      redis.COSDeleteQueue
+	PostViewRepo        redis.PostViewRepository
+	ViewAggregator      *ViewCountAggregator // 可为 nil，每次浏览直接写入 Redis
+	PostLikeRepo        redis.PostLikeRepository
+	PostCache           redis.PostReadCache
+	KafkaSvc            *producer.KafkaProducer
+	OutboxRepo          mysql.OutboxRepository
+	AuditPriorityCfg    config.AuditPriorityConfig
+	AccessGuard         *PostAccessGuard
+	ContentSanitizer    ContentSanitizer
+	ComplianceChecker   ContentComplianceChecker
+	ImageUploadCfg      config.ImageUploadConfig // PresignExpiry 未配置时使用 constant.DefaultImagePresignExpiry
+	IdempotencyStore    redis.IdempotencyStore
+	Logger              *core.ZapLogger
 }
 
 // NewPostService 是 postService 的构造函数，通过依赖注入初始化服务实例。
 // - 这种方式便于单元测试和组件替换。
-func NewPostService(db *gorm.DB, postRepo mysql.PostRepository, postDetailRepo mysql.PostDetailRepository, postDetailImageRepo mysql.PostDetailImageRepository, cosClient dependencies.COSClientInterface, postViewRepo redis.PostViewRepository, kafkaSvc *producer.KafkaProducer, logger *core.ZapLogger) PostService {
+func NewPostService(deps PostServiceDeps) PostService {
+	imageUploadCfg := deps.ImageUploadCfg
+	if imageUploadCfg.PresignExpiry <= 0 {
+		imageUploadCfg.PresignExpiry = constant.DefaultImagePresignExpiry
+	}
 	return &postService{
-		postRepo:            postRepo,
-		postDetailRepo:      postDetailRepo,
-		postDetailImageRepo: postDetailImageRepo,
-		cosClient:           cosClient,
-		db:                  db,
-		postViewRepo:        postViewRepo,
-		kafkaSvc:            kafkaSvc,
-		logger:              logger,
+		postRepo:            deps.PostRepo,
+		postDetailRepo:      deps.PostDetailRepo,
+		postDetailImageRepo: deps.PostDetailImageRepo,
+		postTargetingRepo:   deps.PostTargetingRepo,
+		postFAQRepo:         deps.PostFAQRepo,
+		postReportRepo:      deps.PostReportRepo,
+		postAuditRepo:       deps.PostAuditRepo,
+		segmentRepo:         deps.SegmentRepo,
+		cosClient:           deps.COSClient,
+		cosDeleteQueue:      deps.COSDeleteQueue,
+		db:                  deps.DB,
+		postViewRepo:        deps.PostViewRepo,
+		viewAggregator:      deps.ViewAggregator,
+		postLikeRepo:        deps.PostLikeRepo,
+		postCache:           deps.PostCache,
+		kafkaSvc:            deps.KafkaSvc,
+		outboxRepo:          deps.OutboxRepo,
+		auditPriorityCfg:    deps.AuditPriorityCfg,
+		accessGuard:         deps.AccessGuard,
+		contentSanitizer:    deps.ContentSanitizer,
+		complianceChecker:   deps.ComplianceChecker,
+		imageValidator:      newPostImageValidator(imageUploadCfg),
+		imageCompressor:     newPostImageCompressor(imageUploadCfg.Compression, deps.Logger),
+		imageImporter:       newPostImageImporter(imageUploadCfg, deps.Logger),
+		imagePresignExpiry:  imageUploadCfg.PresignExpiry,
+		idempotencyStore:    deps.IdempotencyStore,
+		logger:              deps.Logger,
 	}
 }
 
@@ -100,83 +240,131 @@ func (s *postService) generatePostImageObjectKey(originalFilename string, userID
 	)
 }
 
-// CreatePost 处理用户创建新帖子的请求，包括图片上传和数据库操作。
-func (s *postService) CreatePost(ctx context.Context, req *dto.CreatePostRequest, imageFiles []*multipart.FileHeader) (*vo.PostDetailVO, error) {
-	// 1. 首先将图片上传到 COS
-	type UploadedImageInfo struct {
-		ImageURL     string
-		ObjectKey    string
-		DisplayOrder int
+// createPost 处理用户创建新帖子的请求，包括图片上传和数据库操作，幂等键由 CreatePost 处理。
+//   - 带图帖子按“先写库占位、再上传图片、最后回填 URL 并送审”的顺序执行（见 completeImageUpload）：
+//     对象键在上传前已随占位记录落库，写库失败时尚未上传任何文件；上传或回填失败时立即补偿，
+//     补偿失败或服务中途退出遗留的文件可凭记录中的对象键由上传清理任务回收，不会成为无主的孤立文件。
+//   - 占位期间帖子对所有人（包括作者）不可见，作者只会看到创建成功或失败两种结果。
+func (s *postService) createPost(ctx context.Context, req *dto.CreatePostRequest, imageFiles []*multipart.FileHeader) (*vo.PostDetailVO, error) {
+	// 0. 访问策略必须每一位都有可用的鉴权钩子，否则帖子将对所有人（作者除外）不可见
+	if !s.accessGuard.Supports(req.AccessPolicy) {
+		return nil, myErrors.ErrUnsupportedAccessPolicy
+	}
+
+	// 0.1 正文写库前做 HTML 白名单清洗，防止存储型 XSS；清洗后为空说明正文只有危险内容
+	content := s.contentSanitizer.Sanitize(req.Content)
+	if strings.TrimSpace(content) == "" {
+		return nil, myErrors.ErrPostContentEmpty
+	}
+
+	// 0.2 按目标地区合并规则集（取最严）做合规预检，命中禁止词时直接拒绝
+	compliance := s.complianceChecker.Check(req.TargetRegions, req.Title, content)
+	if complianceErr := compliance.Err(); complianceErr != nil {
+		s.logger.Warn("创建帖子的内容未通过地区合规预检", zap.Error(complianceErr), zap.String("authorID", req.AuthorID), zap.Strings("regions", compliance.Regions))
+		return nil, complianceErr
+	}
+
+	// 0.3 转发帖：先校验原帖是否可转发，避免为注定失败的请求写入占位记录
+	var quotedPost *entities.Post
+	if req.QuotedPostID != nil {
+		var err error
+		if quotedPost, err = s.getQuotablePost(ctx, *req.QuotedPostID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 0.3.1 发布前查重：命中高度相似的已有帖子时提示作者引用或确认后继续发布；转发帖与草稿不查重
+	fingerprint := contentSimHash(req.Title, content)
+	if quotedPost == nil && !req.SaveAsDraft && !req.IgnoreSimilar {
+		if err := s.checkSimilarBeforeCreate(ctx, req.AuthorID, fingerprint); err != nil {
+			return nil, err
+		}
+	}
+
+	// 0.3.2 下载 URL 导入的图片之前先按上传的文件、URL 与直传对象键的合计数量校验上限，超限的请求不触发任何下载
+	imageKind := postImageKind(req)
+	if err := s.imageValidator.CheckCount(len(imageFiles)+len(req.ImageURLs)+len(req.ImageObjectKeys), imageKind); err != nil {
+		s.logger.Warn("创建帖子的图片数量校验未通过", zap.Error(err), zap.String("authorID", req.AuthorID), zap.String("imageKind", imageKind))
+		return nil, err
+	}
+
+	// 0.3.3 下载 URL 导入的图片，按指定位置与上传的文件合并；合并后的列表统一参与校验、压缩与上传
+	if len(req.ImageURLs) > 0 || len(req.ImageURLPositions) > 0 {
+		mergedFiles, importErr := s.imageImporter.Merge(ctx, imageFiles, req.ImageURLs, req.ImageURLPositions)
+		if importErr != nil {
+			s.logger.Warn("创建帖子时从 URL 导入图片失败", zap.Error(importErr), zap.String("authorID", req.AuthorID), zap.Int("urlCount", len(req.ImageURLs)))
+			return nil, importErr
+		}
+		imageFiles = mergedFiles
 	}
-	uploadedImages := make([]UploadedImageInfo, 0, len(imageFiles))
 
+	// 0.4 写库前先校验整批图片（数量上下限按帖子类型选取，直传的图片一并计数），不合法时直接返回
+	imageContentTypes, imageErr := s.imageValidator.Validate(imageFiles, len(req.ImageObjectKeys), imageKind)
+	if imageErr == nil && len(req.ImageObjectKeys) > 0 {
+		imageErr = s.checkDirectUploadImages(ctx, req.AuthorID, req.ImageObjectKeys)
+	}
+	if imageErr != nil {
+		s.logger.Warn("创建帖子的图片校验未通过", zap.Error(imageErr), zap.String("authorID", req.AuthorID), zap.String("imageKind", imageKind))
+		return nil, imageErr
+	}
+
+	// 0.5 写库前压缩图片，压缩失败的图片回退原图；需要知道哪些图片被压缩才能决定是否为原图分配对象键
+	compressedImages := s.imageCompressor.CompressAll(ctx, imageFiles, imageContentTypes)
+
+	// 1. 预先生成每张图片的对象键，随占位记录一起写库；URL 在上传成功后回填
+	// 直传的图片排在最后，发帖时从暂存区复制到帖子图片目录，同样先以占位记录落库
+	createdDbImages := make([]*entities.PostDetailImage, len(imageFiles), len(imageFiles)+len(req.ImageObjectKeys)) // 存储数据库图片实体以用于VO
 	for i, fileHeader := range imageFiles {
-		file, err := fileHeader.Open()
-		if err != nil {
-			s.logger.Error("打开图片文件以上传失败",
-				zap.String("filename", fileHeader.Filename),
-				zap.Error(err))
-			return nil, fmt.Errorf("打开图片文件 %s 失败: %w", fileHeader.Filename, err)
-		}
-		// 确保文件被关闭，但 UploadFile 也需要读取它。
-		// 如果读取器未完全消耗或传递到其他地方，defer 理想情况下应在 UploadFile 之后。
-		// 目前，假设 UploadFile 完全处理了读取器。
-
-		// 确定内容类型
-		contentType := fileHeader.Header.Get("Content-Type")
-		if contentType == "" {
-			// 如果内容类型至关重要且未提供，则执行回退或报错
-			// 快速测试：读取前 512 字节以检测。
-			// 这意味着文件需要是可查找的或首先读入缓冲区。
-			// 为简单起见，我们假设客户端会发送它，或者 COS 可以推断出来。
-			// 如果没有，则使用默认值或增强此部分。
-			contentType = "application/octet-stream" // 常见的默认值
-			s.logger.Warn("未提供图片的内容类型，使用默认值",
-				zap.String("filename", fileHeader.Filename),
-				zap.String("defaultContentType", contentType))
-		}
-
-		objectKey := s.generatePostImageObjectKey(fileHeader.Filename, req.AuthorID)
-
-		imageURL, err := s.cosClient.UploadFile(ctx, objectKey, file, fileHeader.Size, contentType)
-		file.Close() // 在 UploadFile 使用完文件后关闭它。
-		if err != nil {
-			s.logger.Error("上传图片到 COS 失败",
-				zap.String("filename", fileHeader.Filename),
-				zap.String("objectKey", objectKey),
-				zap.Error(err))
-			// TODO：如果需要，为此请求已上传的 COS 文件实现回滚逻辑。
-			return nil, fmt.Errorf("上传图片 %s 到 COS 失败: %w", fileHeader.Filename, err)
-		}
-
-		uploadedImages = append(uploadedImages, UploadedImageInfo{
-			ImageURL:     imageURL,
-			ObjectKey:    objectKey,
-			DisplayOrder: i, // 基于前端文件列表的顺序
+		createdDbImages[i] = &entities.PostDetailImage{
+			ObjectKey:    s.generatePostImageObjectKey(fileHeader.Filename, req.AuthorID),
+			DisplayOrder: i, // 基于合并后的图片列表顺序（上传的文件与 URL 导入的图片）
+		}
+		// 只有被压缩过的图片才需要额外保留原图
+		if req.KeepOriginalImages && compressedImages[i] != nil {
+			createdDbImages[i].OriginalObjectKey = s.generatePostImageObjectKey(fileHeader.Filename, req.AuthorID)
+		}
+	}
+	for _, sourceKey := range req.ImageObjectKeys {
+		createdDbImages = append(createdDbImages, &entities.PostDetailImage{
+			ObjectKey:    s.generatePostImageObjectKey(sourceKey, req.AuthorID),
+			DisplayOrder: len(createdDbImages),
 		})
-		s.logger.Info("成功上传图片到 COS",
-			zap.String("filename", fileHeader.Filename),
-			zap.String("objectKey", objectKey),
-			zap.String("imageURL", imageURL))
 	}
+	uploadPending := len(createdDbImages) > 0
 
-	// 2. 在事务中执行数据库操作
+	// 2. 在事务中执行数据库操作：不带图的帖子一次完成创建；带图的帖子只写入占位记录，不增加转发数也不送审
 	var createdPost *entities.Post
 	var createdDetail *entities.PostDetail
-	var createdDbImages []*entities.PostDetailImage // 存储数据库图片实体以用于VO
+	var createdFAQs []*entities.PostFAQ  // 存储 FAQ 实体以用于VO
+	var auditEvent *entities.OutboxEvent // 待审核事件的发件箱记录，草稿为 nil
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 2.1 创建 Post 实体
+		now := time.Now()
 		post := &entities.Post{
-			Title:          req.Title,
-			AuthorID:       req.AuthorID,
-			AuthorAvatar:   req.AuthorAvatar,   // 假设 DTO 中有此字段
-			AuthorUsername: req.AuthorUsername, // 假设 DTO 中有此字段
-			Status:         enums.Pending,      // 默认为待审核
-			ViewCount:      0,
-			OfficialTag:    0, // 默认初始无标签
+			Title:              req.Title,
+			AuthorID:           req.AuthorID,
+			AuthorAvatar:       req.AuthorAvatar,   // 假设 DTO 中有此字段
+			AuthorUsername:     req.AuthorUsername, // 假设 DTO 中有此字段
+			Status:             enums.Pending,      // 默认为待审核
+			StatusUpdatedAt:    &now,
+			ViewCount:          0,
+			OfficialTags:       0, // 默认初始无标签
+			CopyrightType:      req.CopyrightType,
+			SourceURL:          normalizeSourceURL(req.CopyrightType, req.SourceURL),
+			AuditPriority:      s.decideAuditPriority(req),
+			AccessPolicy:       req.AccessPolicy,
+			IsDraft:            req.SaveAsDraft, // 草稿同样为待审核状态，但不送审
+			ImageUploadPending: uploadPending,   // 图片全部上传并回填 URL 前对所有人不可见
+			ContentSimHash:     fingerprint,
 			// AuditReason 最初为空/null
 		}
+		if quotedPost != nil {
+			post.QuotedPostID = &quotedPost.ID
+			post.QuotedTitle = quotedPost.Title
+			post.QuotedAuthorID = quotedPost.AuthorID
+			post.QuotedAuthorUsername = quotedPost.AuthorUsername
+		}
 		if repoErr := s.postRepo.CreatePost(ctx, tx, post); repoErr != nil {
 			return fmt.Errorf("创建帖子失败: %w", repoErr)
 		}
@@ -185,7 +373,7 @@ func (s *postService) CreatePost(ctx context.Context, req *dto.CreatePostRequest
 		// 2.2 创建 PostDetail 实体
 		postDetail := &entities.PostDetail{
 			PostID:       post.ID,
-			Content:      req.Content,
+			Content:      content,
 			PricePerUnit: req.PricePerUnit,
 			ContactInfo:  req.ContactInfo, // 确保 DTO 字段名匹配 (旧代码中为 ContactQRCode)
 		}
@@ -194,95 +382,71 @@ func (s *postService) CreatePost(ctx context.Context, req *dto.CreatePostRequest
 		}
 		createdDetail = postDetail
 
-		// 2.3 创建 PostDetailImage 实体
-		if len(uploadedImages) > 0 {
-			dbImagesToCreate := make([]*entities.PostDetailImage, len(uploadedImages))
-			for i, imgInfo := range uploadedImages {
-				dbImagesToCreate[i] = &entities.PostDetailImage{
-					PostDetailID: createdDetail.ID,
-					ImageURL:     imgInfo.ImageURL,
-					ObjectKey:    imgInfo.ObjectKey,
-					DisplayOrder: imgInfo.DisplayOrder,
-				}
+		// 2.3 创建 PostDetailImage 占位记录（URL 为空）
+		if uploadPending {
+			for _, img := range createdDbImages {
+				img.PostDetailID = createdDetail.ID
 			}
-			if repoErr := s.postDetailImageRepo.BatchCreatePostDetailImages(ctx, tx, dbImagesToCreate); repoErr != nil {
+			if repoErr := s.postDetailImageRepo.BatchCreatePostDetailImages(ctx, tx, createdDbImages); repoErr != nil {
 				return fmt.Errorf("创建帖子详情图片失败: %w", repoErr)
 			}
-			createdDbImages = dbImagesToCreate
 		}
+
+		// 2.4 创建投放定向记录（仅当请求设置了定向条件时）
+		if targeting := buildPostTargeting(req); targeting != nil {
+			targeting.PostID = post.ID
+			if repoErr := s.postTargetingRepo.CreateTargeting(ctx, tx, targeting); repoErr != nil {
+				return fmt.Errorf("创建帖子投放定向失败: %w", repoErr)
+			}
+		}
+
+		// 2.5 创建 FAQ 记录（列表顺序即展示顺序）
+		if len(req.FAQs) > 0 {
+			faqs := buildPostFAQs(post.ID, req.FAQs)
+			if repoErr := s.postFAQRepo.BatchCreateFAQs(ctx, tx, faqs); repoErr != nil {
+				return fmt.Errorf("创建帖子FAQ失败: %w", repoErr)
+			}
+			createdFAQs = faqs
+		}
+
+		// 2.6 不带图的帖子在同一事务内完成创建收尾（转发数、待审核事件）
+		if uploadPending {
+			return nil
+		}
+		event, finishErr := s.finishPostCreation(ctx, tx, post, createdDetail, nil, quotedPost, compliance)
+		if finishErr != nil {
+			return finishErr
+		}
+		auditEvent = event
 		return nil // 提交事务
 	})
-
 	if err != nil {
+		// 此时尚未上传任何图片，事务回滚后无需清理 COS
 		s.logger.Error("创建帖子事务失败", zap.Error(err))
-		// todo  后续考虑解决孤立图片的问题
-		// 如果数据库事务在 COS 图片上传成功后失败，这些图片将成为 COS 中的孤立文件。
-		// 如果需要严格的原子性，请为 `uploadedImages` 实现从 COS 清理的逻辑。
-		// 对 uploadedImages 中的每个 img，调用 s.cosClient.DeleteObject(context.Background(), img.ObjectKey)
-		// 此清理操作应记录其自身的错误，但不应掩盖原始的数据库错误。
-		for _, imgInfo := range uploadedImages {
-			s.logger.Warn("由于数据库事务失败，尝试清理孤立的 COS 文件", zap.String("objectKey", imgInfo.ObjectKey))
-			if cleanupErr := s.cosClient.DeleteObject(context.Background(), imgInfo.ObjectKey); cleanupErr != nil {
-				s.logger.Error("清理孤立的 COS 文件失败", zap.String("objectKey", imgInfo.ObjectKey), zap.Error(cleanupErr))
-			}
-		}
 		return nil, err
 	}
 
-	// --- 事务成功 ---
-
-	// 3. 异步发送 Kafka 事件
-	// todo 注意目前审核服务尚未加入图片审核，成本过高，仅仅是发送到审核服务保持数据完整性
-
-	// 将数据库实体图片列表转换为 Kafka 事件所需的图片数据列表
-	kafkaImagesData := make([]kafkaevents.ImageEventData, 0, len(createdDbImages))
-	for _, dbImg := range createdDbImages {
-		if dbImg == nil { // 安全检查
-			continue
+	// 3. 带图帖子：上传图片并回填 URL，成功后才增加转发数并送审；失败时已完成补偿
+	if uploadPending {
+		if auditEvent, err = s.completeImageUpload(ctx, createdPost, createdDetail, createdDbImages, imageFiles, imageContentTypes, compressedImages, req.ImageObjectKeys, quotedPost, compliance); err != nil {
+			return nil, err
 		}
-		kafkaImagesData = append(kafkaImagesData, kafkaevents.ImageEventData{
-			ImageURL:     dbImg.ImageURL,
-			ObjectKey:    dbImg.ObjectKey,
-			DisplayOrder: dbImg.DisplayOrder,
-		})
 	}
 
-	postDataForKafka := kafkaevents.PostData{
-		ID:           createdPost.ID,
-		Title:        createdPost.Title,
-		Content:      createdDetail.Content,
-		AuthorID:     createdPost.AuthorID,
-		AuthorAvatar: createdPost.AuthorAvatar,
+	// --- 创建完成 ---
 
-		AuthorUsername: createdPost.AuthorUsername,
-		Status:         createdPost.Status,
-		ViewCount:      createdPost.ViewCount,
-		OfficialTag:    createdPost.OfficialTag,
-		PricePerUnit:   createdDetail.PricePerUnit,
-
-		ContactInfo: createdDetail.ContactInfo, // 映射到 detail 中的 ContactInfo
-		CreatedAt:   createdPost.CreatedAt.UnixMilli(),
-		UpdatedAt:   createdPost.UpdatedAt.UnixMilli(),
-		Images:      kafkaImagesData,
+	// 4. 异步投递 Kafka 待审核事件；草稿不送审，等作者发布时再发送
+	// todo 注意目前审核服务尚未加入图片审核，成本过高，仅仅是发送到审核服务保持数据完整性
+	if createdPost.IsDraft {
+		s.logger.Info("帖子已保存为草稿，暂不送审", zap.Uint64("post_id", createdPost.ID))
+	} else {
+		s.relayOutboxEventAsync(auditEvent, createdPost.ID)
 	}
 
-	go func(pd kafkaevents.PostData) {
-		bgCtx := context.Background() // 为后台 goroutine 创建新的上下文
-		if kafkaErr := s.kafkaSvc.SendPostPendingAuditEvent(bgCtx, pd); kafkaErr != nil {
-			s.logger.Error("发送 Kafka 帖子待审核事件失败", zap.Error(kafkaErr), zap.Uint64("post_id", pd.ID))
-		} else {
-			s.logger.Info("成功发送 Kafka 帖子待审核事件", zap.Uint64("post_id", pd.ID))
-		}
-	}(postDataForKafka)
-
-	// 4. 构建并返回 PostDetailVO
+	// 5. 构建并返回 PostDetailVO
 	voImages := make([]vo.PostImageVO, len(createdDbImages))
 	for i, dbImg := range createdDbImages {
-		voImages[i] = vo.PostImageVO{
-			ImageURL:     dbImg.ImageURL,
-			DisplayOrder: dbImg.DisplayOrder,
-			ObjectKey:    dbImg.ObjectKey, // 假设VO中也需要ObjectKey
-		}
+		voImages[i] = vo.NewPostImageVOFromEntity(dbImg)
 	}
 
 	return &vo.PostDetailVO{
@@ -294,17 +458,107 @@ func (s *postService) CreatePost(ctx context.Context, req *dto.CreatePostRequest
 		AuthorAvatar:   createdPost.AuthorAvatar,
 		AuthorUsername: createdPost.AuthorUsername,
 		ViewCount:      createdPost.ViewCount,
-		OfficialTag:    createdPost.OfficialTag,
+		Status:         createdPost.Status,
+		IsDraft:        createdPost.IsDraft,
+		OfficialTag:    createdPost.OfficialTags.Primary(),
+		OfficialTags:   createdPost.OfficialTags.Tags(),
+		CopyrightType:  createdPost.CopyrightType,
+		SourceURL:      createdPost.SourceURL,
+		AccessPolicy:   createdPost.AccessPolicy,
 		Content:        createdDetail.Content,
 		PricePerUnit:   createdDetail.PricePerUnit,
 		ContactInfo:    createdDetail.ContactInfo,
 		Images:         voImages,
+		FAQs:           vo.NewPostFAQVOsFromEntities(createdFAQs),
+		QuotedPost:     vo.NewQuotedPostVO(createdPost, false),
 	}, nil
 }
 
+// newPendingAuditPostData 组装待审核事件所需的帖子数据。
+func newPendingAuditPostData(post *entities.Post, detail *entities.PostDetail, images []*entities.PostDetailImage) kafkaevents.PostData {
+	// 将数据库实体图片列表转换为 Kafka 事件所需的图片数据列表
+	kafkaImagesData := make([]kafkaevents.ImageEventData, 0, len(images))
+	for _, dbImg := range images {
+		if dbImg == nil { // 安全检查
+			continue
+		}
+		kafkaImagesData = append(kafkaImagesData, kafkaevents.ImageEventData{
+			ImageURL:     dbImg.ImageURL,
+			ObjectKey:    dbImg.ObjectKey,
+			DisplayOrder: dbImg.DisplayOrder,
+		})
+	}
+
+	return kafkaevents.PostData{
+		ID:           post.ID,
+		Title:        post.Title,
+		Content:      detail.Content,
+		AuthorID:     post.AuthorID,
+		AuthorAvatar: post.AuthorAvatar,
+
+		AuthorUsername: post.AuthorUsername,
+		Status:         post.Status,
+		ViewCount:      post.ViewCount,
+		OfficialTag:    post.OfficialTags.Primary(), // 事件结构只有单个标签字段，取主标签
+		PricePerUnit:   detail.PricePerUnit,
+
+		ContactInfo: detail.ContactInfo, // 映射到 detail 中的 ContactInfo
+		CreatedAt:   post.CreatedAt.UnixMilli(),
+		UpdatedAt:   post.UpdatedAt.UnixMilli(),
+		Images:      kafkaImagesData,
+	}
+}
+
+// getQuotablePost 获取并校验可被转发的原帖。
+// - 原帖不存在、已删除或未审核通过时返回 myErrors.ErrQuotedPostUnavailable。
+// - 原帖声明禁止转载时返回 myErrors.ErrRepostNotAllowed。
+func (s *postService) getQuotablePost(ctx context.Context, quotedPostID uint64) (*entities.Post, error) {
+	quotedPost, err := s.postRepo.GetPostByID(ctx, quotedPostID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, myErrors.ErrQuotedPostUnavailable
+		}
+		s.logger.Error("获取被转发的原帖失败", zap.Error(err), zap.Uint64("quotedPostID", quotedPostID))
+		return nil, fmt.Errorf("获取被转发的原帖失败: %w", err)
+	}
+	if quotedPost.Status != enums.Approved {
+		return nil, myErrors.ErrQuotedPostUnavailable
+	}
+	if quotedPost.CopyrightType == constant.CopyrightTypeNoRepost {
+		return nil, myErrors.ErrRepostNotAllowed
+	}
+	return quotedPost, nil
+}
+
+// isQuotedPostDeleted 判断转发帖引用的原帖是否已被删除。
+// - 非转发帖返回 false；查询出错时按未删除处理（继续展示快照），只记录日志。
+func (s *postService) isQuotedPostDeleted(ctx context.Context, post *entities.Post) bool {
+	if post.QuotedPostID == nil {
+		return false
+	}
+	_, err := s.postRepo.GetPostByID(ctx, *post.QuotedPostID)
+	if err == nil {
+		return false
+	}
+	if !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		s.logger.Warn("查询被转发的原帖失败，按未删除处理", zap.Error(err), zap.Uint64("postID", post.ID), zap.Uint64("quotedPostID", *post.QuotedPostID))
+		return false
+	}
+	return true
+}
+
 // DeletePost 实现帖子的软删除逻辑。
 func (s *postService) DeletePost(ctx context.Context, postID uint64) error {
 	var actualPostDetailID uint64
+	var deleteEvent *entities.OutboxEvent // 删除事件的发件箱记录
+
+	// 0. 读取帖子作者，随删除事件下发；帖子不存在或查询失败时事件不携带作者，不影响删除
+	deleteMeta := producer.PostDeleteMeta{Source: constant.PostDeleteSourceUser}
+	if post, postErr := s.postRepo.GetPostByID(ctx, postID); postErr == nil {
+		deleteMeta.AuthorID = post.AuthorID
+	} else if !errors.Is(postErr, commonerrors.ErrRepoNotFound) {
+		s.logger.Warn("删除帖子：读取帖子作者失败，删除事件将不携带作者", zap.Error(postErr), zap.Uint64("post_id", postID))
+	}
 
 	// 1. 尝试获取帖子详情，以得到其 PostDetail.ID (即 actualPostDetailID)
 	postDetail, repoErr := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
@@ -321,15 +575,22 @@ func (s *postService) DeletePost(ctx context.Context, postID uint64) error {
 		}
 	}
 
+	// 1.1 软删除前读取详情图片，事务成功后把对象键投递到 COS 延迟删除队列
+	var images []*entities.PostDetailImage
+	if postDetail != nil {
+		images, repoErr = s.postDetailImageRepo.GetImagesByPostDetailID(ctx, postDetail.ID)
+		if repoErr != nil {
+			s.logger.Error("删除帖子：获取帖子详情图失败", zap.Error(repoErr), zap.Uint64("post_id", postID))
+			return fmt.Errorf("获取帖子详情图失败: %w", repoErr)
+		}
+	}
+
 	// 使用 GORM Transaction 确保所有数据库操作是原子的。
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 获取到帖子详情的主键ID
 		if postDetail != nil {
 			actualPostDetailID = postDetail.ID
 
-			// TODO: 如果需要删除COS中的图片文件：
-			// 在这里（软删除数据库记录之前），调用COS的接口方法，删除在COS中的图片文件
-
 			// 2. (软)删除对应的帖子详情图 (使用 actualPostDetailID)
 			if repoErr := s.postDetailImageRepo.DeleteImagesByPostDetailID(ctx, tx, actualPostDetailID); repoErr != nil {
 				s.logger.Error("删除帖子：软删除帖子详情图失败",
@@ -347,6 +608,14 @@ func (s *postService) DeletePost(ctx context.Context, postID uint64) error {
 			}
 		}
 
+		// 3.1 (软)删除帖子 FAQ
+		if repoErr := s.postFAQRepo.DeleteFAQsByPostID(ctx, tx, postID); repoErr != nil {
+			s.logger.Error("删除帖子：软删除帖子FAQ失败",
+				zap.Uint64("post_id", postID),
+				zap.Error(repoErr))
+			return fmt.Errorf("软删除帖子FAQ失败: %w", repoErr)
+		}
+
 		// 4. (软)删除帖子主记录
 		if repoErr := s.postRepo.DeletePost(ctx, tx, postID); repoErr != nil {
 			s.logger.Error("删除帖子：软删除帖子主记录失败",
@@ -355,6 +624,16 @@ func (s *postService) DeletePost(ctx context.Context, postID uint64) error {
 			return fmt.Errorf("软删除帖子主记录失败: %w", repoErr)
 		}
 
+		// 5. 写入删除事件的发件箱记录
+		event, outboxErr := s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+			deleteMeta.DeletedAt = time.Now()
+			return s.kafkaSvc.NewPostDeleteOutboxEvent(postID, deleteMeta)
+		})
+		if outboxErr != nil {
+			return outboxErr
+		}
+		deleteEvent = event
+
 		// 事务成功完成。
 		return nil
 	})
@@ -367,24 +646,42 @@ func (s *postService) DeletePost(ctx context.Context, postID uint64) error {
 		return err
 	}
 
-	// TODO: （事务成功后）异步删除COS中的图片文件。
+	// 4.0 图片不立即删除（帖子可能被管理员恢复），投递到延迟删除队列，超过保留期后由 COS 延迟删除任务删除
+	enqueuePostImageDeletion(ctx, s.cosDeleteQueue, s.logger, postID, images)
 
-	// 5. 异步发送 Kafka 删除事件。
-	go func(postIDToNotify uint64) {
-		bgCtx := context.Background()
-		if kafkaErr := s.kafkaSvc.SendPostDeleteEvent(bgCtx, postIDToNotify); kafkaErr != nil {
-			s.logger.Error("发送 Kafka 删除事件失败", zap.Error(kafkaErr), zap.Uint64("post_id", postIDToNotify))
-		} else {
-			s.logger.Info("成功发送 Kafka 删除事件", zap.Uint64("post_id", postIDToNotify))
-		}
-	}(postID) // 使用原始传入的 postID
+	// 4.1 主动清除详情缓存，避免删除后仍能从缓存读到帖子
+	s.invalidatePostDetailCache(ctx, postID)
+
+	// 5.1 异步投递 Kafka 删除事件。
+	s.relayOutboxEventAsync(deleteEvent, postID)
 
 	s.logger.Info("帖子及其关联数据（软）删除请求处理完成", zap.Uint64("post_id", postID))
 	return nil
 }
 
 // GetPostDetailByPostID 实现获取帖子详情的逻辑，并接收 UserID。
-func (s *postService) GetPostDetailByPostID(ctx context.Context, postID uint64, userID string) (*vo.PostDetailVO, error) {
+// - 先读缓存（热门详情优先，其次普通详情），未命中时查库并异步写入普通详情缓存。
+// - 无论是否命中缓存，都会校验投放定向并触发浏览计数。
+func (s *postService) GetPostDetailByPostID(ctx context.Context, postID uint64, userID string, viewer *dto.ViewerAttributes) (*vo.PostDetailVO, error) {
+	// 0. 尝试从缓存读取；缓存异常时降级查库，不影响主流程
+	cached, cacheErr := s.postCache.GetPostDetail(ctx, postID)
+	if cacheErr == nil {
+		if err := s.checkPostTargeting(ctx, postID, userID, viewer); err != nil {
+			return nil, err
+		}
+		if err := s.checkPostAccess(ctx, cached, userID, viewer); err != nil {
+			return nil, err
+		}
+		s.incrementViewCountAsync(postID, viewCounterMember(userID, viewer))
+		cached.ViewCount = s.realtimeViewCount(ctx, postID, cached.ViewCount)
+		s.fillCachedAuditReason(ctx, cached, userID)
+		s.logger.Debug("从缓存获取帖子详情", zap.Uint64("postID", postID))
+		return cached, nil
+	}
+	if !errors.Is(cacheErr, myErrors.ErrCacheMiss) {
+		s.logger.Warn("读取帖子详情缓存失败，降级查询数据库", zap.Error(cacheErr), zap.Uint64("postID", postID))
+	}
+
 	s.logger.Debug("从数据库获取帖子详情", zap.Uint64("postID", postID), zap.String("userID", userID))
 
 	// 1. 从数据库获取 Post 核心数据
@@ -398,6 +695,31 @@ func (s *postService) GetPostDetailByPostID(ctx context.Context, postID uint64,
 		return nil, err // 返回错误
 	}
 
+	// 1.1 草稿只有作者本人可见，其他用户按不存在处理
+	if post.IsDraft && post.AuthorID != userID {
+		return nil, commonerrors.ErrRepoNotFound
+	}
+	// 图片仍在上传中的占位帖子尚未创建完成，对所有人按不存在处理
+	if post.ImageUploadPending {
+		return nil, commonerrors.ErrRepoNotFound
+	}
+
+	// 1.2 校验投放定向：不满足条件的用户对该帖完全不可见，按不存在处理
+	if err := s.checkPostTargeting(ctx, postID, userID, viewer); err != nil {
+		return nil, err
+	}
+
+	// 1.3 按访问策略执行鉴权钩子链
+	if err := s.accessGuard.Check(ctx, &PostAccessRequest{
+		PostID:       post.ID,
+		AuthorID:     post.AuthorID,
+		AccessPolicy: post.AccessPolicy,
+		UserID:       userID,
+		Viewer:       viewer,
+	}); err != nil {
+		return nil, err
+	}
+
 	// 2. 获取帖子详情数据
 	postDetail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
 	if err != nil {
@@ -420,43 +742,257 @@ func (s *postService) GetPostDetailByPostID(ctx context.Context, postID uint64,
 		return nil, err // 返回错误
 	}
 
-	// 3. 检查传入的 UserID 是否为空。
-	if userID == "" {
-		// 如果 UserID 为空（例如未登录用户访问），则记录日志并跳过增加浏览量。
-		s.logger.Warn("未提供 UserID，跳过增加浏览量", zap.Uint64("postID", postID))
-	} else {
-		// 4. 如果 UserID 存在，则异步增加帖子的浏览计数。
-		go func(pID uint64, uID string) {
-			// 使用独立的 context.Background()，因为增加浏览量操作不应阻塞主流程，
-			// 并且其生命周期独立于原始请求。
-			if redisErr := s.postViewRepo.IncrementViewCount(context.Background(), pID, uID); redisErr != nil {
-				// 记录增加浏览量失败的错误，便于监控。
-				s.logger.Error("异步增加浏览量失败",
-					zap.Error(redisErr),
-					zap.Uint64("post_id", pID),
-					zap.String("user_id", uID))
-			} else {
-				s.logger.Debug("成功触发异步增加浏览量", zap.Uint64("post_id", pID), zap.String("user_id", uID))
-			}
-		}(postID, userID)
+	// 2.1 获取帖子 FAQ 列表
+	postFAQs, err := s.postFAQRepo.GetFAQsByPostID(ctx, postID)
+	if err != nil {
+		s.logger.Error("获取帖子FAQ失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, err
+	}
+
+	// 3. 异步增加浏览计数（匿名访客按客户端标识去重，是否统计由配置决定）；作者查看自己的草稿不计入浏览量
+	if !post.IsDraft {
+		s.incrementViewCountAsync(postID, viewCounterMember(userID, viewer))
 	}
 
-	// 5. 组装并返回详情 VO。
+	// 4. 组装并返回详情 VO。
 	postDetailResponse := &vo.PostDetailVO{
 		ID:             post.ID,
 		Title:          post.Title,
-		ViewCount:      post.ViewCount, // 注意：这里显示的是数据库中的浏览量，而不是实时增加后的。
-		OfficialTag:    post.OfficialTag,
+		ViewCount:      s.realtimeViewCount(ctx, postID, post.ViewCount), // 本次浏览异步计数，不包含在内
+		LikeCount:      post.LikeCount,
+		Status:         post.Status,
+		IsDraft:        post.IsDraft,
+		OfficialTag:    post.OfficialTags.Primary(),
+		OfficialTags:   post.OfficialTags.Tags(),
 		AuthorID:       post.AuthorID,
 		AuthorAvatar:   post.AuthorAvatar,
 		AuthorUsername: post.AuthorUsername,
+		CopyrightType:  post.CopyrightType,
+		SourceURL:      post.SourceURL,
 		CreatedAt:      post.CreatedAt,
 		UpdatedAt:      post.UpdatedAt,
 		Content:        postDetail.Content,
 		PricePerUnit:   postDetail.PricePerUnit,
 		ContactInfo:    postDetail.ContactInfo,
+		RepostCount:    post.RepostCount,
+		AccessPolicy:   post.AccessPolicy,
 		Images:         vo.NewPostImageVOsFromEntities(postDetailImages),
+		FAQs:           vo.NewPostFAQVOsFromEntities(postFAQs),
+		QuotedPost:     vo.NewQuotedPostVO(post, s.isQuotedPostDeleted(ctx, post)),
+		AuditReason:    authorAuditReason(post, userID),
 	}
 
+	// 5. 异步写入普通详情缓存（短 TTL），失败只记录日志。
+	// - 草稿不写缓存：缓存命中路径不区分访问者，写入后会对其他用户暴露草稿
+	if post.IsDraft {
+		return postDetailResponse, nil
+	}
+	// - 审核原因只对作者可见，同理不写入缓存
+	cacheDetail := *postDetailResponse
+	cacheDetail.AuditReason = nil
+	go func(detail vo.PostDetailVO) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if setErr := s.postCache.SetPostDetail(bgCtx, detail.ID, &detail, constant.PostDetailNormalCacheTTL); setErr != nil {
+			s.logger.Error("写入普通帖子详情缓存失败", zap.Error(setErr), zap.Uint64("postID", detail.ID))
+		}
+	}(cacheDetail)
+
 	return postDetailResponse, nil
 }
+
+// authorAuditReason 返回作者本人可见的审核原因；非作者或帖子没有审核原因时返回 nil。
+func authorAuditReason(post *entities.Post, userID string) *string {
+	if userID == "" || post.AuthorID != userID || !post.AuditReason.Valid || post.AuditReason.String == "" {
+		return nil
+	}
+	reason := post.AuditReason.String
+	return &reason
+}
+
+// fillCachedAuditReason 为命中缓存的详情补充审核原因。
+// - 缓存中不保存审核原因，只有作者查看自己被拒绝的帖子时才回查一次数据库；查询失败只记录日志，不影响详情返回。
+func (s *postService) fillCachedAuditReason(ctx context.Context, detail *vo.PostDetailVO, userID string) {
+	if userID == "" || detail.AuthorID != userID || detail.Status != enums.Rejected {
+		return
+	}
+	post, err := s.postRepo.GetPostByID(ctx, detail.ID)
+	if err != nil {
+		s.logger.Warn("查询审核原因失败，本次详情不返回审核原因", zap.Error(err), zap.Uint64("postID", detail.ID))
+		return
+	}
+	detail.AuditReason = authorAuditReason(post, userID)
+}
+
+// checkPostTargeting 校验当前用户是否满足帖子的投放定向条件。
+// - 不满足时返回 commonerrors.ErrRepoNotFound（对该用户而言帖子不存在）。
+func (s *postService) checkPostTargeting(ctx context.Context, postID uint64, userID string, viewer *dto.ViewerAttributes) error {
+	targeting, err := s.postTargetingRepo.GetTargetingByPostID(ctx, postID)
+	if err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		s.logger.Error("获取帖子投放定向失败", zap.Error(err), zap.Uint64("postID", postID))
+		return err
+	}
+	if !isTargetingMatched(targeting, viewer) {
+		s.logger.Info("当前用户不满足帖子投放定向条件，隐藏该帖", zap.Uint64("postID", postID), zap.String("userID", userID))
+		return commonerrors.ErrRepoNotFound
+	}
+	return nil
+}
+
+// checkPostAccess 按缓存中帖子详情的访问策略执行鉴权钩子链。
+func (s *postService) checkPostAccess(ctx context.Context, detail *vo.PostDetailVO, userID string, viewer *dto.ViewerAttributes) error {
+	return s.accessGuard.Check(ctx, &PostAccessRequest{
+		PostID:       detail.ID,
+		AuthorID:     detail.AuthorID,
+		AccessPolicy: detail.AccessPolicy,
+		UserID:       userID,
+		Viewer:       viewer,
+	})
+}
+
+// realtimeViewCount 返回帖子的实时浏览量：Redis 计数器与已持久化的浏览量（MySQL 或详情缓存）取较大者。
+// - Redis 计数器不存在（已归档或同步后被清理）时其值小于持久化值，取较大者可避免浏览量倒退。
+// - Redis 读取超时或不可用时降级为持久化值。
+func (s *postService) realtimeViewCount(ctx context.Context, postID uint64, persisted int64) int64 {
+	if s.postViewRepo == nil {
+		return persisted
+	}
+	readCtx, cancel := context.WithTimeout(ctx, constant.RealtimeViewCountReadTimeout)
+	defer cancel()
+	realtime, err := s.postViewRepo.GetViewCount(readCtx, postID)
+	if err != nil {
+		s.logger.Warn("读取实时浏览量失败，降级使用持久化的浏览量", zap.Error(err), zap.Uint64("postID", postID))
+		return persisted
+	}
+	return max(realtime, persisted)
+}
+
+// viewCounterMember 返回浏览去重使用的访客标识：登录用户以 userID 为主键，匿名访客为带 constant.AnonymousViewerPrefix 前缀的客户端标识。
+// - 请求携带设备标识时附带设备成员，登录前后在同一设备上的浏览只计一次（见 redis.ViewerIdentity）。
+// - 既没有登录用户也没有客户端标识时 Member 为空，不计数；是否统计匿名访客由浏览计数仓库按配置决定。
+func viewCounterMember(userID string, viewer *dto.ViewerAttributes) redis.ViewerIdentity {
+	identity := redis.ViewerIdentity{Member: userID}
+	if viewer == nil || viewer.ClientID == "" {
+		return identity
+	}
+	anonymousMember := constant.AnonymousViewerPrefix + viewer.ClientID
+	if strings.HasPrefix(viewer.ClientID, constant.AnonymousDeviceClientPrefix) {
+		identity.DeviceMember = anonymousMember
+	}
+	if identity.Member == "" {
+		identity.Member = anonymousMember
+	}
+	return identity
+}
+
+// incrementViewCountAsync 异步增加帖子浏览计数，viewer 为访客标识（见 viewCounterMember），Member 为空时跳过。
+func (s *postService) incrementViewCountAsync(postID uint64, viewer redis.ViewerIdentity) {
+	if viewer.Member == "" {
+		// 既没有登录用户也没有客户端标识时无法去重，跳过增加浏览量。
+		s.logger.Warn("未提供访客标识，跳过增加浏览量", zap.Uint64("postID", postID))
+		return
+	}
+	if s.viewAggregator != nil {
+		s.viewAggregator.Record(postID, viewer)
+		return
+	}
+	go func(pID uint64, v redis.ViewerIdentity) {
+		// 使用独立的 context.Background()，因为增加浏览量操作不应阻塞主流程，
+		// 并且其生命周期独立于原始请求。
+		if redisErr := s.postViewRepo.IncrementViewCount(context.Background(), pID, v); redisErr != nil {
+			// 记录增加浏览量失败的错误，便于监控。
+			s.logger.Error("异步增加浏览量失败",
+				zap.Error(redisErr),
+				zap.Uint64("post_id", pID),
+				zap.String("user_id", v.Member))
+		} else {
+			s.logger.Debug("成功触发异步增加浏览量", zap.Uint64("post_id", pID), zap.String("user_id", v.Member))
+		}
+	}(postID, viewer)
+}
+
+// MergeAnonymousViews 实现登录后设备匿名浏览记录的归并。
+func (s *postService) MergeAnonymousViews(ctx context.Context, userID string, viewer *dto.ViewerAttributes) (*vo.MergeAnonymousViewsVO, error) {
+	if userID == "" {
+		return nil, commonerrors.ErrUserNotLoggedIn
+	}
+	deviceMember := viewCounterMember(userID, viewer).DeviceMember
+	if deviceMember == "" {
+		return nil, myErrors.ErrDeviceIDRequired
+	}
+	merged, err := s.postViewRepo.MergeAnonymousViews(ctx, userID, deviceMember)
+	if err != nil {
+		return nil, fmt.Errorf("归并设备匿名浏览记录失败: %w", err)
+	}
+	return &vo.MergeAnonymousViewsVO{MergedPosts: merged}, nil
+}
+
+// UpdatePostFAQs 实现帖子 FAQ 列表的整体替换。
+func (s *postService) UpdatePostFAQs(ctx context.Context, postID uint64, userID string, faqs []dto.PostFAQItem) ([]vo.PostFAQVO, error) {
+	// 1. 校验帖子存在且当前用户为作者
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if !errors.Is(err, commonerrors.ErrRepoNotFound) {
+			s.logger.Error("更新FAQ：获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		}
+		return nil, err
+	}
+	if post.AuthorID != userID {
+		s.logger.Warn("更新FAQ：非作者尝试修改帖子FAQ", zap.Uint64("postID", postID), zap.String("userID", userID))
+		return nil, myErrors.ErrPermissionDenied
+	}
+
+	// 2. 在事务中先删除旧 FAQ，再写入新 FAQ
+	newFAQs := buildPostFAQs(postID, faqs)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if repoErr := s.postFAQRepo.DeleteFAQsByPostID(ctx, tx, postID); repoErr != nil {
+			return fmt.Errorf("删除旧FAQ失败: %w", repoErr)
+		}
+		if repoErr := s.postFAQRepo.BatchCreateFAQs(ctx, tx, newFAQs); repoErr != nil {
+			return fmt.Errorf("写入新FAQ失败: %w", repoErr)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("更新帖子FAQ事务失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, err
+	}
+
+	s.logger.Info("更新帖子FAQ成功", zap.Uint64("postID", postID), zap.Int("count", len(newFAQs)))
+	s.invalidatePostDetailCache(ctx, postID)
+	return vo.NewPostFAQVOsFromEntities(newFAQs), nil
+}
+
+// invalidatePostDetailCache 帖子数据变更后清除详情缓存，失败只记录日志，缓存会在 TTL 后自然过期。
+func (s *postService) invalidatePostDetailCache(ctx context.Context, postID uint64) {
+	if err := s.postCache.DeletePostDetail(ctx, postID); err != nil {
+		s.logger.Warn("帖子变更后清除详情缓存失败", zap.Error(err), zap.Uint64("postID", postID))
+	}
+}
+
+// decideAuditPriority 根据加急标记与作者等级决定帖子的送审优先级。
+// - 带加急标记的帖子一律为高优先级。
+// - 配置了作者等级阈值时，等级达到阈值的作者（热门作者）也按高优先级送审。
+func (s *postService) decideAuditPriority(req *dto.CreatePostRequest) int {
+	if req.Urgent {
+		return constant.AuditPriorityHigh
+	}
+	if minLevel := s.auditPriorityCfg.HighPriorityMinAuthorLevel; minLevel > 0 && req.AuthorLevel >= minLevel {
+		return constant.AuditPriorityHigh
+	}
+	return constant.AuditPriorityNormal
+}
+
+// buildPostFAQs 将请求中的 FAQ 列表转换为实体，列表下标即展示顺序。
+func buildPostFAQs(postID uint64, items []dto.PostFAQItem) []*entities.PostFAQ {
+	faqs := make([]*entities.PostFAQ, 0, len(items))
+	for i, item := range items {
+		faqs = append(faqs, &entities.PostFAQ{
+			PostID:       postID,
+			Question:     strings.TrimSpace(item.Question),
+			Answer:       strings.TrimSpace(item.Answer),
+			DisplayOrder: i,
+		})
+	}
+	return faqs
+}
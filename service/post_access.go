@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// accessPolicyOrder 是访问策略的校验顺序，同时也是策略位到名称的映射。
+var accessPolicyOrder = []struct {
+	policy int
+	name   string
+}{
+	{constant.AccessPolicyLogin, constant.AccessPolicyNameLogin},
+	{constant.AccessPolicyPaid, constant.AccessPolicyNamePaid},
+	{constant.AccessPolicyFollower, constant.AccessPolicyNameFollower},
+}
+
+// PostAccessRequest 是帖子详情访问鉴权钩子的输入。
+type PostAccessRequest struct {
+	PostID       uint64
+	AuthorID     string
+	AccessPolicy int    // 帖子的访问策略 (constant.AccessPolicy* 按位组合)
+	UserID       string // 当前用户，未登录时为空
+	Viewer       *dto.ViewerAttributes
+}
+
+// PostAccessHook 定义了单个访问策略的鉴权钩子，由业务线按需实现并注入 PostAccessGuard。
+type PostAccessHook interface {
+	// Policy 返回该钩子负责校验的策略位（constant.AccessPolicy* 中的一个）。
+	Policy() int
+
+	// Check 校验当前用户是否满足该策略。
+	// - 不满足时返回 myErrors.ErrAccessLoginRequired / ErrAccessPaymentRequired / ErrAccessForbidden 之一。
+	// - 其他错误视为钩子自身故障（例如依赖服务不可用），按内部错误处理。
+	Check(ctx context.Context, req *PostAccessRequest) error
+}
+
+// PostAccessDeniedError 表示帖子详情访问鉴权不通过，携带帖子的策略与未通过的策略，供 Controller 引导用户操作。
+// - Unwrap 返回具体原因 (myErrors.ErrAccessLoginRequired 等)，可用 errors.Is 判断。
+type PostAccessDeniedError struct {
+	PostID       uint64
+	AccessPolicy int
+	DeniedPolicy int
+	Err          error
+}
+
+func (e *PostAccessDeniedError) Error() string {
+	return fmt.Sprintf("帖子 %d 访问被拒绝 (策略: %s): %v", e.PostID, accessPolicyName(e.DeniedPolicy), e.Err)
+}
+
+func (e *PostAccessDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// VO 将拒绝原因转换为返回给前端的视图对象。
+func (e *PostAccessDeniedError) VO() *vo.PostAccessDeniedVO {
+	return &vo.PostAccessDeniedVO{
+		PostID:           e.PostID,
+		AccessPolicy:     e.AccessPolicy,
+		RequiredPolicies: accessPolicyNames(e.AccessPolicy),
+		DeniedPolicy:     accessPolicyName(e.DeniedPolicy),
+	}
+}
+
+// PostAccessGuard 按帖子的访问策略依次执行对应的鉴权钩子。
+//   - 公开帖子与作者本人访问直接放行。
+//   - 帖子设置了某个策略但没有注册对应钩子时按无权限处理（失败关闭），
+//     创建帖子时也会通过 Supports 拒绝这类策略。
+type PostAccessGuard struct {
+	hooks  map[int]PostAccessHook
+	logger *core.ZapLogger
+}
+
+// NewPostAccessGuard 创建访问鉴权钩子链。同一策略注册多个钩子时，后注册的覆盖先注册的。
+func NewPostAccessGuard(logger *core.ZapLogger, hooks ...PostAccessHook) *PostAccessGuard {
+	g := &PostAccessGuard{
+		hooks:  make(map[int]PostAccessHook, len(hooks)),
+		logger: logger,
+	}
+	for _, hook := range hooks {
+		g.hooks[hook.Policy()] = hook
+	}
+	return g
+}
+
+// Supports 判断访问策略中的每一位都有已注册的钩子。
+func (g *PostAccessGuard) Supports(accessPolicy int) bool {
+	if accessPolicy&^constant.AccessPolicyMask != 0 {
+		return false
+	}
+	for _, p := range accessPolicyOrder {
+		if accessPolicy&p.policy == 0 {
+			continue
+		}
+		if _, ok := g.hooks[p.policy]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Check 校验当前用户能否访问帖子详情。
+// - 不通过时返回 *PostAccessDeniedError；钩子自身出错时返回包装后的原始错误。
+func (g *PostAccessGuard) Check(ctx context.Context, req *PostAccessRequest) error {
+	if req.AccessPolicy == constant.AccessPolicyPublic {
+		return nil
+	}
+	if req.UserID != "" && req.UserID == req.AuthorID {
+		return nil
+	}
+
+	for _, p := range accessPolicyOrder {
+		if req.AccessPolicy&p.policy == 0 {
+			continue
+		}
+		hook, ok := g.hooks[p.policy]
+		if !ok {
+			g.logger.Warn("帖子访问策略没有对应的鉴权钩子，按无权限处理", zap.Uint64("postID", req.PostID), zap.String("policy", p.name))
+			return g.denied(req, p.policy, myErrors.ErrAccessForbidden)
+		}
+		if err := hook.Check(ctx, req); err != nil {
+			if errors.Is(err, myErrors.ErrAccessLoginRequired) || errors.Is(err, myErrors.ErrAccessPaymentRequired) || errors.Is(err, myErrors.ErrAccessForbidden) {
+				return g.denied(req, p.policy, err)
+			}
+			g.logger.Error("帖子访问鉴权钩子执行失败", zap.Error(err), zap.Uint64("postID", req.PostID), zap.String("policy", p.name))
+			return fmt.Errorf("帖子访问鉴权失败 (策略: %s): %w", p.name, err)
+		}
+	}
+	return nil
+}
+
+// denied 构造访问拒绝错误并记录日志。
+func (g *PostAccessGuard) denied(req *PostAccessRequest, policy int, err error) error {
+	g.logger.Info("帖子详情访问鉴权未通过",
+		zap.Uint64("postID", req.PostID),
+		zap.String("userID", req.UserID),
+		zap.String("deniedPolicy", accessPolicyName(policy)),
+	)
+	return &PostAccessDeniedError{
+		PostID:       req.PostID,
+		AccessPolicy: req.AccessPolicy,
+		DeniedPolicy: policy,
+		Err:          err,
+	}
+}
+
+// accessPolicyName 返回单个策略位的名称。
+func accessPolicyName(policy int) string {
+	for _, p := range accessPolicyOrder {
+		if p.policy == policy {
+			return p.name
+		}
+	}
+	return ""
+}
+
+// accessPolicyNames 返回访问策略中所有策略位的名称，按校验顺序排列。
+func accessPolicyNames(accessPolicy int) []string {
+	names := make([]string, 0, len(accessPolicyOrder))
+	for _, p := range accessPolicyOrder {
+		if accessPolicy&p.policy != 0 {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// --- 内置钩子 ---
+
+// PaymentChecker 查询用户是否已为帖子付费，由支付相关服务的客户端实现。
+type PaymentChecker interface {
+	HasPaid(ctx context.Context, userID string, postID uint64) (bool, error)
+}
+
+// FollowChecker 查询用户是否关注了作者，由用户关系服务的客户端实现。
+type FollowChecker interface {
+	IsFollowing(ctx context.Context, userID string, authorID string) (bool, error)
+}
+
+// loginRequiredHook 校验“需登录”策略。
+type loginRequiredHook struct{}
+
+// NewLoginRequiredHook 创建“需登录”策略的鉴权钩子。
+func NewLoginRequiredHook() PostAccessHook {
+	return loginRequiredHook{}
+}
+
+func (loginRequiredHook) Policy() int { return constant.AccessPolicyLogin }
+
+func (loginRequiredHook) Check(_ context.Context, req *PostAccessRequest) error {
+	if req.UserID == "" {
+		return myErrors.ErrAccessLoginRequired
+	}
+	return nil
+}
+
+// paidAccessHook 校验“需付费”策略，未登录用户先引导登录。
+type paidAccessHook struct {
+	checker PaymentChecker
+}
+
+// NewPaidAccessHook 创建“需付费”策略的鉴权钩子。
+func NewPaidAccessHook(checker PaymentChecker) PostAccessHook {
+	return &paidAccessHook{checker: checker}
+}
+
+func (h *paidAccessHook) Policy() int { return constant.AccessPolicyPaid }
+
+func (h *paidAccessHook) Check(ctx context.Context, req *PostAccessRequest) error {
+	if req.UserID == "" {
+		return myErrors.ErrAccessLoginRequired
+	}
+	paid, err := h.checker.HasPaid(ctx, req.UserID, req.PostID)
+	if err != nil {
+		return fmt.Errorf("查询付费状态失败: %w", err)
+	}
+	if !paid {
+		return myErrors.ErrAccessPaymentRequired
+	}
+	return nil
+}
+
+// followerAccessHook 校验“需关注作者”策略，未登录用户先引导登录。
+type followerAccessHook struct {
+	checker FollowChecker
+}
+
+// NewFollowerAccessHook 创建“需关注作者”策略的鉴权钩子。
+func NewFollowerAccessHook(checker FollowChecker) PostAccessHook {
+	return &followerAccessHook{checker: checker}
+}
+
+func (h *followerAccessHook) Policy() int { return constant.AccessPolicyFollower }
+
+func (h *followerAccessHook) Check(ctx context.Context, req *PostAccessRequest) error {
+	if req.UserID == "" {
+		return myErrors.ErrAccessLoginRequired
+	}
+	following, err := h.checker.IsFollowing(ctx, req.UserID, req.AuthorID)
+	if err != nil {
+		return fmt.Errorf("查询关注关系失败: %w", err)
+	}
+	if !following {
+		return myErrors.ErrAccessForbidden
+	}
+	return nil
+}
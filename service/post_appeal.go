@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// AppealPost 实现作者申诉被拒帖子。
+// - 申诉重审一律要求人工复审，送审优先级沿用帖子创建时确定的值。
+// - 状态更新与待审核事件的发件箱记录在同一事务内提交；审计日志在提交后写入，失败不影响申诉结果。
+func (s *postService) AppealPost(ctx context.Context, postID uint64, userID string, appealReason string) error {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return err
+		}
+		s.logger.Error("申诉帖子时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if post.AuthorID != userID {
+		return myErrors.ErrPermissionDenied
+	}
+	if post.Status != enums.Rejected {
+		return myErrors.ErrPostNotRejected
+	}
+	if post.AppealCount >= constant.PostAppealMaxCount {
+		return myErrors.ErrAppealLimitExceeded
+	}
+
+	detail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
+	if err != nil {
+		s.logger.Error("申诉帖子时获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子详情失败: %w", err)
+	}
+	images, err := s.postDetailImageRepo.GetImagesByPostDetailID(ctx, detail.ID)
+	if err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		s.logger.Error("申诉帖子时获取帖子详情图失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子详情图失败: %w", err)
+	}
+
+	// 被拒期间规则集可能已更新，命中禁止词的内容申诉也不会通过，直接拒绝
+	regions, err := loadTargetRegions(ctx, s.postTargetingRepo, postID)
+	if err != nil {
+		s.logger.Error("申诉帖子时获取目标地区失败", zap.Error(err), zap.Uint64("postID", postID))
+		return err
+	}
+	compliance := s.complianceChecker.Check(regions, post.Title, detail.Content)
+	if complianceErr := compliance.Err(); complianceErr != nil {
+		s.logger.Warn("申诉帖子的内容未通过地区合规预检", zap.Error(complianceErr), zap.Uint64("postID", postID), zap.Strings("regions", compliance.Regions))
+		return complianceErr
+	}
+
+	post.Status = enums.Pending
+	post.AppealCount++
+	meta := compliance.PendingAuditMeta(post.AuditPriority)
+	meta.ManualReview = true
+	meta.AppealReason = appealReason
+	meta.AppealCount = post.AppealCount
+
+	var auditEvent *entities.OutboxEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if repoErr := s.postRepo.AppealRejectedPost(ctx, tx, postID, constant.PostAppealMaxCount); repoErr != nil {
+			if errors.Is(repoErr, commonerrors.ErrRepoNotFound) {
+				// 读取之后被并发申诉、重新审核或删除
+				return myErrors.ErrPostNotRejected
+			}
+			return fmt.Errorf("申诉重审帖子失败: %w", repoErr)
+		}
+		event, outboxErr := s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+			return s.kafkaSvc.NewPostPendingAuditOutboxEvent(newPendingAuditPostData(post, detail, images), meta)
+		})
+		if outboxErr != nil {
+			return outboxErr
+		}
+		auditEvent = event
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.relayOutboxEventAsync(auditEvent, postID)
+	s.invalidatePostDetailCache(ctx, postID)
+	s.recordAppeal(ctx, postID, userID, appealReason)
+	s.logger.Info("被拒帖子已申诉并重新送审", zap.Uint64("postID", postID), zap.String("userID", userID), zap.Int("appealCount", post.AppealCount))
+	return nil
+}
+
+// recordAppeal 写入申诉的帖子审计日志，与管理员审核记录在同一条状态流转历史中，便于运营追溯。
+func (s *postService) recordAppeal(ctx context.Context, postID uint64, userID string, appealReason string) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.AdminAuditWriteTimeout)
+	defer cancel()
+	log := &entities.PostAuditLog{
+		PostID:      postID,
+		AdminUserID: constant.PostAuditOperatorAuthorPrefix + userID,
+		Action:      constant.AdminActionAppealPost,
+		OldStatus:   enums.Rejected,
+		NewStatus:   enums.Pending,
+		Reason:      appealReason,
+	}
+	if err := s.postAuditRepo.CreatePostAuditLog(writeCtx, log); err != nil {
+		s.logger.Error("写入申诉审计日志失败（不影响申诉结果）", zap.Error(err), zap.Uint64("postID", postID), zap.String("userID", userID))
+	}
+}
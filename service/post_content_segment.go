@@ -0,0 +1,178 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// auditReasonMaxRunes 与 posts.audit_reason 的列宽一致。
+const auditReasonMaxRunes = 255
+
+// contentSegment 是正文按行切分后的一个段落。
+type contentSegment struct {
+	text string // 去除首尾空白后的段落文本
+	hash string // 段落指纹，见 entities.PostContentSegment.ContentHash
+}
+
+// splitContentSegments 将正文按行切分为段落，忽略空行。
+// - 返回切片的下标即段落记录的 SegmentIndex，消息头中的段落序号为下标加 1。
+func splitContentSegments(content string) []contentSegment {
+	lines := strings.Split(content, "\n")
+	segments := make([]contentSegment, 0, len(lines))
+	for _, line := range lines {
+		text := strings.TrimSpace(line)
+		if text == "" {
+			continue
+		}
+		sum := sha256.Sum256([]byte(text))
+		segments = append(segments, contentSegment{text: text, hash: hex.EncodeToString(sum[:8])})
+	}
+	return segments
+}
+
+// newSegmentRecords 为正文的全部段落生成状态为 status 的段落记录。
+func newSegmentRecords(postID uint64, segments []contentSegment, status enums.Status) []*entities.PostContentSegment {
+	records := make([]*entities.PostContentSegment, len(segments))
+	for i, seg := range segments {
+		records[i] = &entities.PostContentSegment{PostID: postID, SegmentIndex: i, ContentHash: seg.hash, Status: status}
+	}
+	return records
+}
+
+// segmentAuditPlan 是作者编辑帖子后重新送审的方式。
+type segmentAuditPlan struct {
+	incremental bool                           // 是否只送审变更的段落
+	records     []*entities.PostContentSegment // 送审后帖子的段落记录，整体替换已有记录
+	content     string                         // 事件的 Content：增量送审时只包含需要审核的段落，整篇送审时为完整正文
+	segments    []int                          // 增量送审的段落序号（从 1 开始）
+	added       []int                          // 其中新增段落的序号
+}
+
+// planSegmentAudit 比对编辑前的段落记录与编辑后的正文，决定整篇送审还是只送审变更的段落。
+//   - 按段落指纹的最长公共子序列对齐：未变更且上次审核通过的段落沿用通过结果，其余段落（新增、修改，以及上次未通过或仍待审核的段落）送审。
+//   - 两个未变更段落之间被删除的旧段落与插入的新段落一一对应视为修改，多出的新段落视为新增。
+//   - 没有编辑前的段落记录，或需要送审的段落超过 constant.IncrementalAuditMaxSegments、
+//     constant.IncrementalAuditMaxChangedPercent 时整篇送审，全部段落置为待审核。
+func planSegmentAudit(postID uint64, previous []*entities.PostContentSegment, content string) segmentAuditPlan {
+	current := splitContentSegments(content)
+	full := segmentAuditPlan{records: newSegmentRecords(postID, current, enums.Pending), content: content}
+	if len(previous) == 0 || len(current) == 0 {
+		return full
+	}
+
+	// lcs[i][j] 为 previous[i:] 与 current[j:] 的最长公共子序列长度
+	n, m := len(previous), len(current)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if previous[i].ContentHash == current[j].hash {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	plan := segmentAuditPlan{incremental: true, records: newSegmentRecords(postID, current, enums.Pending)}
+	var sent []string
+	removed, inserted := 0, []int(nil)
+	closeHunk := func() {
+		for k, idx := range inserted {
+			if k >= removed {
+				plan.added = append(plan.added, idx+1)
+			}
+		}
+		removed, inserted = 0, nil
+	}
+	for i, j := 0, 0; i < n || j < m; {
+		switch {
+		case i < n && j < m && previous[i].ContentHash == current[j].hash:
+			closeHunk()
+			if previous[i].Status == enums.Approved {
+				plan.records[j].Status = enums.Approved
+			}
+			i, j = i+1, j+1
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			removed++
+			i++
+		default:
+			inserted = append(inserted, j)
+			j++
+		}
+	}
+	closeHunk()
+
+	for j, record := range plan.records {
+		if record.Status == enums.Pending {
+			plan.segments = append(plan.segments, j+1)
+			sent = append(sent, current[j].text)
+		}
+	}
+	if len(plan.segments) > constant.IncrementalAuditMaxSegments || len(plan.segments)*100 > len(current)*constant.IncrementalAuditMaxChangedPercent {
+		return full
+	}
+	plan.content = strings.Join(sent, "\n")
+	return plan
+}
+
+// rejectedSegmentIndexes 定位审核拒绝结果对应的段落，返回段落记录的 SegmentIndex，只包含本次送审（待审核）的段落。
+// - 审核服务按段落回传了未通过的段落序号 (reported) 时直接使用。
+// - 否则在待审核段落的文本（取自帖子当前正文）中查找拒绝详情的命中内容 (matched)。
+// - 都无法定位时返回 nil，由调用方将本次送审的段落全部置为拒绝。
+func rejectedSegmentIndexes(records []*entities.PostContentSegment, content string, reported []int, matched []string) []int {
+	pending := make(map[int]bool, len(records))
+	for _, record := range records {
+		if record.Status == enums.Pending {
+			pending[record.SegmentIndex] = true
+		}
+	}
+
+	var rejected []int
+	if len(reported) > 0 {
+		for _, number := range reported {
+			if pending[number-1] {
+				rejected = append(rejected, number-1)
+				delete(pending, number-1) // 回传的序号重复时只记录一次
+			}
+		}
+		sort.Ints(rejected)
+		return rejected
+	}
+
+	segments := splitContentSegments(content)
+	for _, record := range records {
+		idx := record.SegmentIndex
+		// 段落记录与当前正文不一致（如手工修改了数据）时不按文本定位
+		if !pending[idx] || idx >= len(segments) || segments[idx].hash != record.ContentHash {
+			continue
+		}
+		for _, words := range matched {
+			if words != "" && strings.Contains(segments[idx].text, words) {
+				rejected = append(rejected, idx)
+				break
+			}
+		}
+	}
+	return rejected
+}
+
+// rejectedSegmentsReason 在拒绝原因前注明未通过的段落序号，方便作者只修改这些段落后重新提交；超出列宽时截断。
+func rejectedSegmentsReason(rejected []int, reason string) string {
+	numbers := make([]string, len(rejected))
+	for i, idx := range rejected {
+		numbers[i] = strconv.Itoa(idx + 1)
+	}
+	return truncateRunes(fmt.Sprintf("第 %s 段未通过审核。%s", strings.Join(numbers, "、"), reason), auditReasonMaxRunes)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Xushengqwer/go-common/models/enums"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// numberedParagraphs 生成 "段落1" 到 "段落n" 的正文段落。
+func numberedParagraphs(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("段落%d", i+1)
+	}
+	return lines
+}
+
+// segmentStatuses 返回段落记录的状态序列。
+func segmentStatuses(records []*entities.PostContentSegment) []enums.Status {
+	statuses := make([]enums.Status, len(records))
+	for i, record := range records {
+		statuses[i] = record.Status
+	}
+	return statuses
+}
+
+func TestPlanSegmentAuditWithoutBaselineAuditsWholePost(t *testing.T) {
+	content := "第一段\n\n第二段"
+	plan := planSegmentAudit(1, nil, content)
+	if plan.incremental {
+		t.Fatal("没有段落记录时应整篇送审")
+	}
+	if plan.content != content {
+		t.Errorf("content = %q, 期望完整正文", plan.content)
+	}
+	if want := []enums.Status{enums.Pending, enums.Pending}; !slices.Equal(segmentStatuses(plan.records), want) {
+		t.Errorf("段落状态 = %v, 期望 %v（空行不计为段落）", segmentStatuses(plan.records), want)
+	}
+}
+
+func TestPlanSegmentAuditSendsOnlyChangedSegments(t *testing.T) {
+	lines := numberedParagraphs(10)
+	previous := newSegmentRecords(1, splitContentSegments(strings.Join(lines, "\n")), enums.Approved)
+
+	// 修改第 4 段，在第 8 段之后插入一段
+	edited := slices.Clone(lines)
+	edited[3] = "段落4（已修改）"
+	edited = slices.Insert(edited, 8, "新增的段落")
+
+	plan := planSegmentAudit(1, previous, strings.Join(edited, "\n"))
+	if !plan.incremental {
+		t.Fatal("少量变更应增量送审")
+	}
+	if want := []int{4, 9}; !slices.Equal(plan.segments, want) {
+		t.Errorf("segments = %v, 期望 %v", plan.segments, want)
+	}
+	if want := []int{9}; !slices.Equal(plan.added, want) {
+		t.Errorf("added = %v, 期望 %v（修改的段落不计为新增）", plan.added, want)
+	}
+	if want := "段落4（已修改）\n新增的段落"; plan.content != want {
+		t.Errorf("content = %q, 期望 %q", plan.content, want)
+	}
+	if len(plan.records) != 11 {
+		t.Fatalf("段落记录数 = %d, 期望 11", len(plan.records))
+	}
+	for i, record := range plan.records {
+		want := enums.Approved
+		if i == 3 || i == 8 {
+			want = enums.Pending
+		}
+		if record.SegmentIndex != i || record.Status != want {
+			t.Errorf("第 %d 条记录 = {index %d, status %v}, 期望 {index %d, status %v}", i, record.SegmentIndex, record.Status, i, want)
+		}
+	}
+}
+
+func TestPlanSegmentAuditResendsSegmentsNotApproved(t *testing.T) {
+	lines := numberedParagraphs(6)
+	previous := newSegmentRecords(1, splitContentSegments(strings.Join(lines, "\n")), enums.Approved)
+	previous[2].Status = enums.Rejected
+
+	// 只修改第 6 段，上次未通过的第 3 段原样保留
+	edited := slices.Clone(lines)
+	edited[5] = "段落6（已修改）"
+
+	plan := planSegmentAudit(1, previous, strings.Join(edited, "\n"))
+	if !plan.incremental {
+		t.Fatal("少量变更应增量送审")
+	}
+	if want := []int{3, 6}; !slices.Equal(plan.segments, want) {
+		t.Errorf("segments = %v, 期望 %v（上次未通过的段落需重新送审）", plan.segments, want)
+	}
+	if len(plan.added) != 0 {
+		t.Errorf("added = %v, 期望为空", plan.added)
+	}
+}
+
+func TestPlanSegmentAuditFallsBackWhenTooManyChanges(t *testing.T) {
+	lines := numberedParagraphs(4)
+	previous := newSegmentRecords(1, splitContentSegments(strings.Join(lines, "\n")), enums.Approved)
+
+	edited := slices.Clone(lines)
+	edited[0], edited[1], edited[2] = "改1", "改2", "改3"
+	content := strings.Join(edited, "\n")
+
+	plan := planSegmentAudit(1, previous, content)
+	if plan.incremental {
+		t.Fatal("变更超过比例上限时应整篇送审")
+	}
+	if plan.content != content || len(plan.segments) != 0 {
+		t.Errorf("整篇送审应携带完整正文且不标记段落, got content=%q segments=%v", plan.content, plan.segments)
+	}
+	for _, record := range plan.records {
+		if record.Status != enums.Pending {
+			t.Fatalf("整篇送审时全部段落应为待审核, got %v", segmentStatuses(plan.records))
+		}
+	}
+}
+
+func TestRejectedSegmentIndexes(t *testing.T) {
+	content := "段落1\n联系我加微信\n段落3\n段落4"
+	records := newSegmentRecords(1, splitContentSegments(content), enums.Approved)
+	records[1].Status = enums.Pending
+	records[3].Status = enums.Pending
+
+	tests := []struct {
+		name     string
+		content  string
+		reported []int
+		matched  []string
+		want     []int
+	}{
+		{name: "使用回传的段落序号并去重", reported: []int{4, 2, 4}, want: []int{1, 3}},
+		{name: "忽略未送审的段落序号", reported: []int{1, 9}, want: nil},
+		{name: "按命中内容定位", content: content, matched: []string{"加微信"}, want: []int{1}},
+		{name: "命中内容只在已通过的段落中", content: content, matched: []string{"段落1"}, want: nil},
+		{name: "正文与段落记录不一致", content: "段落1\n已被改写\n段落3\n段落4", matched: []string{"已被改写"}, want: nil},
+		{name: "无法定位"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rejectedSegmentIndexes(records, tt.content, tt.reported, tt.matched)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rejectedSegmentIndexes = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejectedSegmentsReason(t *testing.T) {
+	if got, want := rejectedSegmentsReason([]int{1, 4}, "含有广告"), "第 2、5 段未通过审核。含有广告"; got != want {
+		t.Errorf("rejectedSegmentsReason = %q, 期望 %q", got, want)
+	}
+	long := rejectedSegmentsReason([]int{0}, strings.Repeat("长", 400))
+	if n := utf8.RuneCountInString(long); n > auditReasonMaxRunes {
+		t.Errorf("拒绝原因长度 = %d, 超过列宽 %d", n, auditReasonMaxRunes)
+	}
+}
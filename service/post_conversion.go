@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// PostConversionService 定义帖子转化归因分析的接口。
+// - 客户端上报浏览、联系、成交三类转化事件，服务端按天与浏览来源聚合为漏斗，供推广帖的作者与管理员分析效果。
+// - 只统计登录用户，同一用户同一阶段每天只计一次；作者本人的事件不计入。统计存储在 Redis，不落库。
+type PostConversionService interface {
+	// ReportConversion 记录用户对帖子的一次转化事件。
+	// - eventType 为 constant.ConversionEvent*；source 只对浏览事件生效，为空时按 "other" 处理。
+	// - 帖子不存在或未审核通过时返回 commonerrors.ErrRepoNotFound；匿名用户与作者本人的上报静默忽略。
+	ReportConversion(ctx context.Context, postID uint64, userID, eventType, source string) error
+
+	// GetPostConversions 查询帖子最近 constant.ConversionStatsDays 天的转化漏斗，帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	GetPostConversions(ctx context.Context, postID uint64) (*vo.PostConversionVO, error)
+
+	// GetAuthorPostConversions 供作者查询自己帖子的转化漏斗。
+	// - 未登录返回 commonerrors.ErrUserNotLoggedIn，非作者返回 myErrors.ErrPermissionDenied。
+	GetAuthorPostConversions(ctx context.Context, postID uint64, userID string) (*vo.PostConversionVO, error)
+}
+
+// postConversionService 是 PostConversionService 接口的实现。
+type postConversionService struct {
+	postRepo       mysql.PostRepository
+	conversionRepo redis.PostConversionRepository
+	postViewRepo   redis.PostViewRepository // 读取累计浏览量，与漏斗中的去重浏览人数对照
+	logger         *core.ZapLogger
+}
+
+// NewPostConversionService 初始化帖子转化归因分析服务。
+func NewPostConversionService(postRepo mysql.PostRepository, conversionRepo redis.PostConversionRepository, postViewRepo redis.PostViewRepository, logger *core.ZapLogger) PostConversionService {
+	return &postConversionService{
+		postRepo:       postRepo,
+		conversionRepo: conversionRepo,
+		postViewRepo:   postViewRepo,
+		logger:         logger,
+	}
+}
+
+// ReportConversion 实现转化事件上报。
+func (s *postConversionService) ReportConversion(ctx context.Context, postID uint64, userID, eventType, source string) error {
+	if userID == "" {
+		return nil
+	}
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.Status != enums.Approved || post.IsDraft {
+		return commonerrors.ErrRepoNotFound
+	}
+	if post.AuthorID == userID {
+		return nil
+	}
+	if eventType != constant.ConversionEventView || !constant.ConversionSources[source] {
+		source = "other"
+	}
+
+	counted, err := s.conversionRepo.RecordEvent(ctx, postID, userID, eventType, source, time.Now())
+	if err != nil {
+		return err
+	}
+	if counted != "" {
+		s.logger.Debug("转化事件已记录", zap.Uint64("postID", postID), zap.String("eventType", eventType), zap.String("source", counted))
+	}
+	return nil
+}
+
+// GetAuthorPostConversions 实现作者查询自己帖子的转化漏斗。
+func (s *postConversionService) GetAuthorPostConversions(ctx context.Context, postID uint64, userID string) (*vo.PostConversionVO, error) {
+	if userID == "" {
+		return nil, commonerrors.ErrUserNotLoggedIn
+	}
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.AuthorID != userID {
+		return nil, myErrors.ErrPermissionDenied
+	}
+	return s.buildConversions(ctx, post)
+}
+
+// GetPostConversions 实现转化漏斗查询。
+func (s *postConversionService) GetPostConversions(ctx context.Context, postID uint64) (*vo.PostConversionVO, error) {
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildConversions(ctx, post)
+}
+
+// buildConversions 读取最近 constant.ConversionStatsDays 天的统计，汇总为整体、按天与按来源的漏斗。
+func (s *postConversionService) buildConversions(ctx context.Context, post *entities.Post) (*vo.PostConversionVO, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := make([]time.Time, constant.ConversionStatsDays)
+	for i := range days {
+		days[i] = today.AddDate(0, 0, i-constant.ConversionStatsDays+1)
+	}
+	dailyStats, err := s.conversionRepo.GetDailyStats(ctx, post.ID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &vo.PostConversionVO{
+		PostID:     post.ID,
+		Days:       constant.ConversionStatsDays,
+		TotalViews: s.totalViews(ctx, post),
+		Daily:      make([]*vo.DailyConversionVO, 0, len(dailyStats)),
+	}
+	sources := make(map[string]*vo.ConversionFunnelVO)
+	for _, day := range dailyStats {
+		daily := &vo.DailyConversionVO{Date: day.Date.Format(time.DateOnly)}
+		for field, count := range day.Counts {
+			stage, source, bySource := strings.Cut(field, constant.ConversionStatsSourceSeparator)
+			if !bySource {
+				addConversionCount(&daily.ConversionFunnelVO, stage, count)
+				addConversionCount(&result.Funnel, stage, count)
+				continue
+			}
+			funnel, ok := sources[source]
+			if !ok {
+				funnel = &vo.ConversionFunnelVO{}
+				sources[source] = funnel
+			}
+			addConversionCount(funnel, stage, count)
+		}
+		fillConversionRates(&daily.ConversionFunnelVO)
+		result.Daily = append(result.Daily, daily)
+	}
+	fillConversionRates(&result.Funnel)
+
+	result.Sources = make([]*vo.SourceConversionVO, 0, len(sources))
+	for source, funnel := range sources {
+		fillConversionRates(funnel)
+		result.Sources = append(result.Sources, &vo.SourceConversionVO{Source: source, ConversionFunnelVO: *funnel})
+	}
+	sort.Slice(result.Sources, func(i, j int) bool {
+		if result.Sources[i].Views != result.Sources[j].Views {
+			return result.Sources[i].Views > result.Sources[j].Views
+		}
+		return result.Sources[i].Source < result.Sources[j].Source
+	})
+	return result, nil
+}
+
+// totalViews 返回帖子的累计浏览量，实时计数读取失败时降级使用 MySQL 中的持久化值。
+func (s *postConversionService) totalViews(ctx context.Context, post *entities.Post) int64 {
+	realtime, err := s.postViewRepo.GetViewCount(ctx, post.ID)
+	if err != nil {
+		s.logger.Warn("转化分析读取实时浏览量失败，降级使用持久化的浏览量", zap.Error(err), zap.Uint64("postID", post.ID))
+		return post.ViewCount
+	}
+	return max(realtime, post.ViewCount)
+}
+
+// addConversionCount 将某一阶段的人数累加到漏斗中，未知阶段被忽略。
+func addConversionCount(funnel *vo.ConversionFunnelVO, stage string, count int64) {
+	switch stage {
+	case constant.ConversionEventView:
+		funnel.Views += count
+	case constant.ConversionEventContact:
+		funnel.Contacts += count
+	case constant.ConversionEventDeal:
+		funnel.Deals += count
+	}
+}
+
+// fillConversionRates 根据各阶段人数计算转化率，分母为 0 时转化率为 0。
+func fillConversionRates(funnel *vo.ConversionFunnelVO) {
+	if funnel.Views > 0 {
+		funnel.ContactRate = float64(funnel.Contacts) / float64(funnel.Views)
+		funnel.OverallRate = float64(funnel.Deals) / float64(funnel.Views)
+	}
+	if funnel.Contacts > 0 {
+		funnel.DealRate = float64(funnel.Deals) / float64(funnel.Contacts)
+	}
+}
+
+// getPost 获取帖子，不存在时返回 commonerrors.ErrRepoNotFound。
+func (s *postConversionService) getPost(ctx context.Context, postID uint64) (*entities.Post, error) {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("转化分析时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子失败: %w", err)
+	}
+	return post, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// PublishDraft 实现草稿发布。
+// - 发布前按帖子目标地区重新做合规预检：保存草稿后地区规则可能已经收紧。
+// - 先读取待审核事件所需的数据，再在同一事务内更新状态并写入发件箱记录，保证状态变为待审核时事件一定会被投递。
+func (s *postService) PublishDraft(ctx context.Context, postID uint64, userID string) error {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return err
+		}
+		s.logger.Error("发布草稿时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if post.AuthorID != userID {
+		return myErrors.ErrPermissionDenied
+	}
+	if !post.IsDraft {
+		return myErrors.ErrPostNotDraft
+	}
+
+	detail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
+	if err != nil {
+		s.logger.Error("发布草稿时获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子详情失败: %w", err)
+	}
+	images, err := s.postDetailImageRepo.GetImagesByPostDetailID(ctx, detail.ID)
+	if err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		s.logger.Error("发布草稿时获取帖子详情图失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子详情图失败: %w", err)
+	}
+
+	regions, err := loadTargetRegions(ctx, s.postTargetingRepo, postID)
+	if err != nil {
+		s.logger.Error("发布草稿时获取目标地区失败", zap.Error(err), zap.Uint64("postID", postID))
+		return err
+	}
+	compliance := s.complianceChecker.Check(regions, post.Title, detail.Content)
+	if complianceErr := compliance.Err(); complianceErr != nil {
+		s.logger.Warn("发布草稿的内容未通过地区合规预检", zap.Error(complianceErr), zap.Uint64("postID", postID), zap.Strings("regions", compliance.Regions))
+		return complianceErr
+	}
+
+	post.IsDraft = false
+	post.Status = enums.Pending
+	var auditEvent *entities.OutboxEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if repoErr := s.postRepo.PublishDraft(ctx, tx, postID); repoErr != nil {
+			if errors.Is(repoErr, commonerrors.ErrRepoNotFound) {
+				// 读取之后被并发发布或删除
+				return myErrors.ErrPostNotDraft
+			}
+			return fmt.Errorf("发布草稿失败: %w", repoErr)
+		}
+		event, outboxErr := s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+			return s.kafkaSvc.NewPostPendingAuditOutboxEvent(newPendingAuditPostData(post, detail, images), compliance.PendingAuditMeta(post.AuditPriority))
+		})
+		if outboxErr != nil {
+			return outboxErr
+		}
+		auditEvent = event
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.relayOutboxEventAsync(auditEvent, postID)
+	s.logger.Info("草稿已发布并送审", zap.Uint64("postID", postID), zap.String("userID", userID))
+	return nil
+}
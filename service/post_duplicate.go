@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// SimilarPostsFoundError 表示发布前查重命中了高度相似的已有帖子，携带相似帖子供作者选择引用或继续发布。
+// - Unwrap 返回 myErrors.ErrSimilarPostsFound，可用 errors.Is 判断。
+type SimilarPostsFoundError struct {
+	Similar []*vo.SimilarPostVO
+}
+
+func (e *SimilarPostsFoundError) Error() string {
+	return fmt.Sprintf("%v: 命中 %d 个相似帖子", myErrors.ErrSimilarPostsFound, len(e.Similar))
+}
+
+func (e *SimilarPostsFoundError) Unwrap() error {
+	return myErrors.ErrSimilarPostsFound
+}
+
+// VO 将相似帖子转换为返回给前端的视图对象。
+func (e *SimilarPostsFoundError) VO() *vo.SimilarPostsVO {
+	return &vo.SimilarPostsVO{Similar: e.Similar}
+}
+
+// CheckSimilarPosts 在发布前预览查重结果，不创建帖子。
+// - 与 CreatePost 使用相同的清洗与查重逻辑；正文清洗后为空时返回 myErrors.ErrPostContentEmpty。
+func (s *postService) CheckSimilarPosts(ctx context.Context, authorID string, req *dto.CheckSimilarPostsRequest) (*vo.SimilarPostsVO, error) {
+	content := s.contentSanitizer.Sanitize(req.Content)
+	if strings.TrimSpace(content) == "" {
+		return nil, myErrors.ErrPostContentEmpty
+	}
+	similar, err := s.findSimilarPosts(ctx, authorID, contentSimHash(req.Title, content))
+	if err != nil {
+		return nil, err
+	}
+	return &vo.SimilarPostsVO{Similar: similar}, nil
+}
+
+// findSimilarPosts 在作者本人近期帖子与全站近期已审核通过帖子中查找与指纹高度相似的帖子。
+// - 两个范围分别按 constant.DuplicateCheck* 限定时间与扫描数量，指纹比较在内存中完成。
+// - 结果按汉明距离升序（相同时较新的帖子在前），最多返回 constant.DuplicateCheckMaxSuggestions 个。
+// - 指纹为 0（没有有效文本）时不查重，返回空列表。
+func (s *postService) findSimilarPosts(ctx context.Context, authorID string, fingerprint uint64) ([]*vo.SimilarPostVO, error) {
+	similar := make([]*vo.SimilarPostVO, 0)
+	if fingerprint == 0 {
+		return similar, nil
+	}
+
+	now := time.Now()
+	ownPosts, err := s.postRepo.ListDuplicateCandidates(ctx, &authorID, now.Add(-constant.DuplicateCheckAuthorWindow), constant.DuplicateCheckAuthorScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("查询作者近期帖子失败: %w", err)
+	}
+	platformPosts, err := s.postRepo.ListDuplicateCandidates(ctx, nil, now.Add(-constant.DuplicateCheckPlatformWindow), constant.DuplicateCheckPlatformScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("查询全站近期帖子失败: %w", err)
+	}
+
+	seen := make(map[uint64]bool, len(ownPosts))
+	for _, post := range append(ownPosts, platformPosts...) {
+		if seen[post.ID] || !isSimilarContent(fingerprint, post.ContentSimHash) {
+			continue
+		}
+		seen[post.ID] = true
+		similar = append(similar, &vo.SimilarPostVO{
+			PostID:         post.ID,
+			Title:          post.Title,
+			AuthorID:       post.AuthorID,
+			AuthorUsername: post.AuthorUsername,
+			Status:         post.Status,
+			CreatedAt:      post.CreatedAt,
+			IsOwnPost:      post.AuthorID == authorID,
+			Distance:       bits.OnesCount64(fingerprint ^ post.ContentSimHash),
+		})
+	}
+
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Distance != similar[j].Distance {
+			return similar[i].Distance < similar[j].Distance
+		}
+		return similar[i].PostID > similar[j].PostID
+	})
+	if len(similar) > constant.DuplicateCheckMaxSuggestions {
+		similar = similar[:constant.DuplicateCheckMaxSuggestions]
+	}
+	return similar, nil
+}
+
+// checkSimilarBeforeCreate 在创建帖子前执行查重，命中时返回 *SimilarPostsFoundError。
+// - 查重只是发布建议，查询失败时记录日志并放行，不影响正常发布。
+func (s *postService) checkSimilarBeforeCreate(ctx context.Context, authorID string, fingerprint uint64) error {
+	similar, err := s.findSimilarPosts(ctx, authorID, fingerprint)
+	if err != nil {
+		s.logger.Warn("发布前查重失败，跳过查重继续创建帖子", zap.Error(err), zap.String("authorID", authorID))
+		return nil
+	}
+	if len(similar) == 0 {
+		return nil
+	}
+	s.logger.Info("发布前查重命中相似帖子，提示作者确认", zap.String("authorID", authorID), zap.Int("similar", len(similar)))
+	return &SimilarPostsFoundError{Similar: similar}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// UpdatePost 实现作者编辑帖子后的分段增量送审。
+// - 先在事务外读取数据、做合规预检并与上次送审的段落比对（见 planSegmentAudit），再在同一事务内更新帖子、正文与段落记录并写入发件箱记录。
+// - 帖子状态以读取时为准做乐观更新：编辑期间被管理员改判或收到审核结果时本次编辑失败，作者刷新后重新编辑即可。
+// - 增量送审的事件不携带图片（图片不能通过编辑修改，已随上次送审审核过）。
+func (s *postService) UpdatePost(ctx context.Context, postID uint64, userID string, req *dto.UpdatePostRequest) error {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return err
+		}
+		s.logger.Error("编辑帖子时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if post.AuthorID != userID {
+		return myErrors.ErrPermissionDenied
+	}
+	// 草稿在发布时整篇送审；审核中的帖子等待结果回传后再编辑，避免旧结果被合并到新内容上
+	if post.IsDraft || (post.Status != enums.Approved && post.Status != enums.Rejected) {
+		return myErrors.ErrPostNotEditable
+	}
+
+	content := s.contentSanitizer.Sanitize(req.Content)
+	if strings.TrimSpace(content) == "" {
+		return myErrors.ErrPostContentEmpty
+	}
+
+	detail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
+	if err != nil {
+		s.logger.Error("编辑帖子时获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子详情失败: %w", err)
+	}
+	regions, err := loadTargetRegions(ctx, s.postTargetingRepo, postID)
+	if err != nil {
+		s.logger.Error("编辑帖子时获取目标地区失败", zap.Error(err), zap.Uint64("postID", postID))
+		return err
+	}
+	compliance := s.complianceChecker.Check(regions, req.Title, content)
+	if complianceErr := compliance.Err(); complianceErr != nil {
+		s.logger.Warn("编辑后的帖子内容未通过地区合规预检", zap.Error(complianceErr), zap.Uint64("postID", postID), zap.Strings("regions", compliance.Regions))
+		return complianceErr
+	}
+
+	previous, err := s.segmentRepo.GetSegmentsByPostID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("获取帖子正文段落失败: %w", err)
+	}
+	// 从未编辑过的帖子没有段落记录：审核通过的帖子正文整体视为已通过；被拒的帖子无法判断哪些段落未通过，整篇送审
+	if len(previous) == 0 && post.Status == enums.Approved {
+		previous = newSegmentRecords(postID, splitContentSegments(detail.Content), enums.Approved)
+	}
+	plan := planSegmentAudit(postID, previous, content)
+
+	var images []*entities.PostDetailImage
+	if !plan.incremental {
+		images, err = s.postDetailImageRepo.GetImagesByPostDetailID(ctx, detail.ID)
+		if err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+			s.logger.Error("编辑帖子时获取帖子详情图失败", zap.Error(err), zap.Uint64("postID", postID))
+			return fmt.Errorf("获取帖子详情图失败: %w", err)
+		}
+	}
+
+	fromStatus := post.Status
+	post.Title = req.Title
+	post.Status = enums.Pending
+	post.ContentSimHash = contentSimHash(req.Title, content)
+	detail.Content = content
+	detail.PricePerUnit = req.PricePerUnit
+	detail.ContactInfo = req.ContactInfo
+
+	postData := newPendingAuditPostData(post, detail, images)
+	meta := compliance.PendingAuditMeta(post.AuditPriority)
+	if plan.incremental {
+		postData.Content = plan.content
+		meta.Incremental = true
+		meta.Segments = plan.segments
+		meta.Added = plan.added
+	}
+
+	var auditEvent *entities.OutboxEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if repoErr := s.postRepo.ResubmitEditedPost(ctx, tx, postID, fromStatus, post.Title, post.ContentSimHash); repoErr != nil {
+			if errors.Is(repoErr, commonerrors.ErrRepoNotFound) {
+				// 读取之后状态被并发修改或帖子已删除
+				return myErrors.ErrPostNotEditable
+			}
+			return fmt.Errorf("更新帖子失败: %w", repoErr)
+		}
+		if repoErr := s.postDetailRepo.UpdatePostDetail(ctx, tx, detail); repoErr != nil {
+			return fmt.Errorf("更新帖子详情失败: %w", repoErr)
+		}
+		if repoErr := s.segmentRepo.ReplaceSegments(ctx, tx, postID, plan.records); repoErr != nil {
+			return fmt.Errorf("更新帖子正文段落失败: %w", repoErr)
+		}
+		event, outboxErr := s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+			return s.kafkaSvc.NewPostPendingAuditOutboxEvent(postData, meta)
+		})
+		if outboxErr != nil {
+			return outboxErr
+		}
+		auditEvent = event
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, myErrors.ErrPostNotEditable) {
+			s.logger.Error("编辑帖子事务失败", zap.Error(err), zap.Uint64("postID", postID))
+		}
+		return err
+	}
+
+	s.relayOutboxEventAsync(auditEvent, postID)
+	s.invalidatePostDetailCache(ctx, postID)
+	s.logger.Info("帖子已编辑并重新送审",
+		zap.Uint64("postID", postID),
+		zap.String("userID", userID),
+		zap.Bool("incremental", plan.incremental),
+		zap.Ints("segments", plan.segments),
+		zap.Int("totalSegments", len(plan.records)),
+	)
+	return nil
+}
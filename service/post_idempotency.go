@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// CreatePost 实现创建帖子，携带幂等键时保证同一次提交只创建一个帖子。
+//   - 幂等键按作者隔离，首次请求以 SET NX 占用并标记为处理中，成功后记录创建结果，失败后释放，客户端可用相同的键重试。
+//   - 相同键的重复请求：首次已完成时直接返回记录的结果，不再走创建流程；仍在处理中时返回 myErrors.ErrIdempotentRequestInProgress。
+//   - Redis 不可用时放弃幂等保护照常创建，只记录警告，不让幂等能力成为发帖的单点。
+func (s *postService) CreatePost(ctx context.Context, req *dto.CreatePostRequest, imageFiles []*multipart.FileHeader) (*vo.PostDetailVO, error) {
+	if req.IdempotencyKey == "" || s.idempotencyStore == nil {
+		return s.createPost(ctx, req, imageFiles)
+	}
+
+	key := constant.PostCreateIdempotencyPrefix + req.AuthorID + ":" + req.IdempotencyKey
+	token := uuid.NewString()
+	existing, err := s.idempotencyStore.Acquire(ctx, key, token, constant.PostCreateIdempotencyTTL)
+	if err != nil {
+		s.logger.Warn("占用幂等键失败，本次创建不做幂等保护", zap.Error(err), zap.String("authorID", req.AuthorID))
+		return s.createPost(ctx, req, imageFiles)
+	}
+	if existing != nil {
+		return s.replayIdempotentCreate(existing, req)
+	}
+
+	// 完成或释放幂等键不应受客户端断开的影响，否则键会一直处于处理中直到过期
+	storeCtx := context.WithoutCancel(ctx)
+	detail, err := s.createPost(ctx, req, imageFiles)
+	if err != nil {
+		if releaseErr := s.idempotencyStore.Release(storeCtx, key, token); releaseErr != nil {
+			s.logger.Error("创建帖子失败后释放幂等键失败，该键过期前的重试将被拒绝", zap.Error(releaseErr), zap.String("authorID", req.AuthorID))
+		}
+		return nil, err
+	}
+
+	result, marshalErr := json.Marshal(detail)
+	if marshalErr == nil {
+		marshalErr = s.idempotencyStore.Complete(storeCtx, key, token, result, constant.PostCreateIdempotencyTTL)
+	}
+	if marshalErr != nil {
+		s.logger.Error("记录幂等键的创建结果失败，该键过期前的重试将被拒绝", zap.Error(marshalErr), zap.Uint64("postID", detail.ID))
+	}
+	return detail, nil
+}
+
+// replayIdempotentCreate 处理幂等键已被占用的重复请求。
+func (s *postService) replayIdempotentCreate(record *redis.IdempotencyRecord, req *dto.CreatePostRequest) (*vo.PostDetailVO, error) {
+	if record.State != redis.IdempotencyStateCompleted {
+		s.logger.Info("相同幂等键的创建请求仍在处理中", zap.String("authorID", req.AuthorID))
+		return nil, myErrors.ErrIdempotentRequestInProgress
+	}
+	var detail vo.PostDetailVO
+	if err := json.Unmarshal(record.Result, &detail); err != nil {
+		s.logger.Error("解析幂等键记录的创建结果失败", zap.Error(err), zap.String("authorID", req.AuthorID))
+		return nil, fmt.Errorf("解析幂等键记录的创建结果失败: %w", err)
+	}
+	s.logger.Info("重复的创建请求，返回首次创建的结果", zap.String("authorID", req.AuthorID), zap.Uint64("postID", detail.ID))
+	return &detail, nil
+}
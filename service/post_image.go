@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// postImageValidator 在上传任何图片前校验整批图片，避免部分图片已上传后才发现不合法。
+type postImageValidator struct {
+	maxFileBytes int64
+	defaultLimit config.ImageCountLimit            // 全局图片数量上下限
+	kindLimits   map[string]config.ImageCountLimit // 按帖子类型覆盖的上下限，已合并全局值
+}
+
+// newPostImageValidator 根据配置创建图片校验器，未配置的参数使用默认值。
+func newPostImageValidator(cfg config.ImageUploadConfig) postImageValidator {
+	v := postImageValidator{
+		maxFileBytes: cfg.MaxFileBytes,
+		defaultLimit: config.ImageCountLimit{MinCount: max(cfg.MinCount, 0), MaxCount: cfg.MaxCount},
+		kindLimits:   make(map[string]config.ImageCountLimit, len(cfg.KindCountLimits)),
+	}
+	if v.maxFileBytes <= 0 {
+		v.maxFileBytes = constant.DefaultPostImageMaxBytes
+	}
+	if v.defaultLimit.MaxCount <= 0 {
+		v.defaultLimit.MaxCount = constant.DefaultPostImageMaxCount
+	}
+	for kind, limit := range cfg.KindCountLimits {
+		if limit.MinCount <= 0 {
+			limit.MinCount = v.defaultLimit.MinCount
+		}
+		if limit.MaxCount <= 0 {
+			limit.MaxCount = v.defaultLimit.MaxCount
+		}
+		v.kindLimits[kind] = limit
+	}
+	return v
+}
+
+// postImageKind 根据创建请求推断帖子类型，用于选择图片数量上下限。
+func postImageKind(req *dto.CreatePostRequest) string {
+	switch {
+	case req.QuotedPostID != nil:
+		return constant.PostImageKindQuote
+	case req.PricePerUnit > 0:
+		return constant.PostImageKindGoods
+	default:
+		return constant.PostImageKindDefault
+	}
+}
+
+// countLimit 返回指定帖子类型的图片数量上下限，未单独配置的类型使用全局值。
+func (v postImageValidator) countLimit(kind string) config.ImageCountLimit {
+	if limit, ok := v.kindLimits[kind]; ok {
+		return limit
+	}
+	return v.defaultLimit
+}
+
+// Validate 校验图片数量、单张大小以及真实的文件类型，返回每张图片识别出的 MIME 类型（与 files 一一对应）。
+// - 图片数量上下限按 kind（constant.PostImageKind*）选取，在读取任何文件内容之前校验；directCount 为客户端直传的图片数，一并计入数量。
+// - 文件类型以文件头 (前 512 字节) 经 http.DetectContentType 识别的结果为准，不信任客户端提交的 Content-Type。
+// - 校验不通过时返回可 errors.Is myErrors.ErrInvalidPostImage 的错误，错误信息可直接展示给用户。
+func (v postImageValidator) Validate(files []*multipart.FileHeader, directCount int, kind string) ([]string, error) {
+	if err := v.CheckCount(len(files)+directCount, kind); err != nil {
+		return nil, err
+	}
+
+	contentTypes := make([]string, 0, len(files))
+	for _, fh := range files {
+		if fh.Size > v.maxFileBytes {
+			return nil, fmt.Errorf("%w: 图片 %s 大小 %d 字节，超过上限 %d 字节", myErrors.ErrInvalidPostImage, fh.Filename, fh.Size, v.maxFileBytes)
+		}
+		contentType, err := sniffContentType(fh)
+		if err != nil {
+			return nil, fmt.Errorf("读取图片 %s 失败: %w", fh.Filename, err)
+		}
+		if !strings.HasPrefix(contentType, "image/") {
+			return nil, fmt.Errorf("%w: 文件 %s 不是图片 (识别为 %s)", myErrors.ErrInvalidPostImage, fh.Filename, contentType)
+		}
+		contentTypes = append(contentTypes, contentType)
+	}
+	return contentTypes, nil
+}
+
+// CheckCount 只校验图片总数是否在 kind 对应的上下限内，不读取任何文件内容。
+// - 需要下载 URL 图片时应先调用，避免为注定超限的请求下载图片。
+func (v postImageValidator) CheckCount(total int, kind string) error {
+	limit := v.countLimit(kind)
+	if total < limit.MinCount {
+		return fmt.Errorf("%w: 该类帖子至少需要上传 %d 张图片，实际 %d 张", myErrors.ErrInvalidPostImage, limit.MinCount, total)
+	}
+	if total > limit.MaxCount {
+		return fmt.Errorf("%w: 最多上传 %d 张图片，实际 %d 张", myErrors.ErrInvalidPostImage, limit.MaxCount, total)
+	}
+	return nil
+}
+
+// sniffContentType 读取文件头识别真实的 MIME 类型。
+func sniffContentType(fh *multipart.FileHeader) (string, error) {
+	file, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, constant.PostImageSniffBytes)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"sync"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// postImageCompressor 在上传 COS 前对图片做有损压缩，以减小存储与带宽开销。
+// - JPEG 按配置的质量重新编码；PNG 为无损格式，按最高压缩级别重新编码；其他格式（GIF、WebP）不压缩。
+// - 压缩失败、超时、超出像素上限或压缩后体积没有变小时一律回退原图，压缩不会导致帖子创建失败。
+type postImageCompressor struct {
+	enabled     bool
+	jpegQuality int
+	minBytes    int64
+	maxPixels   int
+	timeout     time.Duration
+	logger      *core.ZapLogger
+}
+
+// newPostImageCompressor 根据配置创建图片压缩器，未配置的参数使用默认值。
+func newPostImageCompressor(cfg config.ImageCompressionConfig, logger *core.ZapLogger) postImageCompressor {
+	c := postImageCompressor{
+		enabled:     cfg.Enabled,
+		jpegQuality: cfg.JPEGQuality,
+		minBytes:    cfg.MinBytes,
+		maxPixels:   cfg.MaxPixels,
+		timeout:     cfg.Timeout,
+		logger:      logger,
+	}
+	if c.jpegQuality < 1 || c.jpegQuality > 100 {
+		c.jpegQuality = constant.DefaultImageJPEGQuality
+	}
+	if c.minBytes <= 0 {
+		c.minBytes = constant.DefaultImageCompressMinBytes
+	}
+	if c.maxPixels <= 0 {
+		c.maxPixels = constant.DefaultImageCompressMaxPixels
+	}
+	if c.timeout <= 0 {
+		c.timeout = constant.DefaultImageCompressTimeout
+	}
+	return c
+}
+
+// CompressAll 并发压缩整批图片，返回与 files 一一对应的压缩结果。
+// - contentTypes 为校验阶段识别出的真实类型；结果中为 nil 的元素表示该图片未压缩，应上传原图。
+// - 并发数受 constant.ImageCompressConcurrency 限制，每张图片的耗时受配置的超时限制。
+func (c postImageCompressor) CompressAll(ctx context.Context, files []*multipart.FileHeader, contentTypes []string) [][]byte {
+	results := make([][]byte, len(files))
+	if !c.enabled || len(files) == 0 {
+		return results
+	}
+
+	sem := make(chan struct{}, constant.ImageCompressConcurrency)
+	var wg sync.WaitGroup
+	for i, fileHeader := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, fileHeader *multipart.FileHeader) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			startTime := time.Now()
+			data, err := c.compress(ctx, fileHeader, contentTypes[i])
+			if err != nil {
+				c.logger.Warn("图片压缩失败，回退上传原图",
+					zap.Error(err),
+					zap.String("filename", fileHeader.Filename),
+					zap.String("contentType", contentTypes[i]))
+				return
+			}
+			if data == nil {
+				return
+			}
+			results[i] = data
+			c.logger.Debug("图片压缩完成",
+				zap.String("filename", fileHeader.Filename),
+				zap.Int64("originalBytes", fileHeader.Size),
+				zap.Int("compressedBytes", len(data)),
+				zap.Duration("duration", time.Since(startTime)))
+		}(i, fileHeader)
+	}
+	wg.Wait()
+	return results
+}
+
+// compress 压缩单张图片。
+// - 返回 nil, nil 表示该图片无需压缩（格式不支持、体积过小或压缩后没有变小）。
+func (c postImageCompressor) compress(ctx context.Context, fileHeader *multipart.FileHeader, contentType string) ([]byte, error) {
+	if fileHeader.Size < c.minBytes {
+		return nil, nil
+	}
+	var encode func(io.Writer, image.Image) error
+	var decodeConfig func(io.Reader) (image.Config, error)
+	var decode func(io.Reader) (image.Image, error)
+	switch contentType {
+	case "image/jpeg":
+		decodeConfig, decode = jpeg.DecodeConfig, jpeg.Decode
+		encode = func(w io.Writer, img image.Image) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: c.jpegQuality})
+		}
+	case "image/png":
+		decodeConfig, decode = png.DecodeConfig, png.Decode
+		encoder := &png.Encoder{CompressionLevel: png.BestCompression}
+		encode = encoder.Encode
+	default:
+		return nil, nil
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("打开图片文件失败: %w", err)
+	}
+	raw, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("读取图片文件失败: %w", err)
+	}
+
+	// 重新编码会丢弃 EXIF，带旋转方向的 JPEG 压缩后会显示为躺倒的图片，保留原图
+	if contentType == "image/jpeg" && jpegOrientation(raw) > 1 {
+		return nil, nil
+	}
+
+	// 解码前先读取图片头检查像素数，避免超大图片解码时占满内存
+	cfg, err := decodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("读取图片尺寸失败: %w", err)
+	}
+	if cfg.Width*cfg.Height > c.maxPixels {
+		return nil, fmt.Errorf("图片尺寸 %dx%d 超过压缩像素上限 %d", cfg.Width, cfg.Height, c.maxPixels)
+	}
+
+	// 解码与编码无法中途取消，在独立协程中执行并按超时放弃等待
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	type encodeResult struct {
+		data []byte
+		err  error
+	}
+	done := make(chan encodeResult, 1)
+	go func() {
+		img, decodeErr := decode(bytes.NewReader(raw))
+		if decodeErr != nil {
+			done <- encodeResult{err: fmt.Errorf("解码图片失败: %w", decodeErr)}
+			return
+		}
+		var buf bytes.Buffer
+		buf.Grow(len(raw) / 2)
+		if encodeErr := encode(&buf, img); encodeErr != nil {
+			done <- encodeResult{err: fmt.Errorf("重新编码图片失败: %w", encodeErr)}
+			return
+		}
+		done <- encodeResult{data: buf.Bytes()}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("图片压缩超过 %s: %w", c.timeout, ctx.Err())
+	case result := <-done:
+		if result.err != nil {
+			return nil, result.err
+		}
+		// 原图已经高度压缩时重新编码可能反而变大，保留原图
+		if len(result.data) >= len(raw) {
+			return nil, nil
+		}
+		return result.data, nil
+	}
+}
+
+// jpegOrientation 读取 JPEG 中 EXIF 的方向标签 (0x0112)，未找到或格式错误时返回 1（正常方向）。
+func jpegOrientation(raw []byte) int {
+	const orientationTag = 0x0112
+	if len(raw) < 4 || raw[0] != 0xFF || raw[1] != 0xD8 {
+		return 1
+	}
+	// 逐个遍历 SOS 之前的段，查找 APP1 中的 Exif 数据
+	for pos := 2; pos+4 <= len(raw); {
+		if raw[pos] != 0xFF {
+			return 1
+		}
+		marker := raw[pos+1]
+		if marker == 0xDA || marker == 0xD9 { // SOS / EOI 之后不会再有 EXIF
+			return 1
+		}
+		segmentLen := int(binary.BigEndian.Uint16(raw[pos+2 : pos+4]))
+		if segmentLen < 2 || pos+2+segmentLen > len(raw) {
+			return 1
+		}
+		segment := raw[pos+4 : pos+2+segmentLen]
+		pos += 2 + segmentLen
+		if marker != 0xE1 || len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+			continue
+		}
+
+		tiff := segment[6:]
+		var order binary.ByteOrder
+		switch string(tiff[:2]) {
+		case "II":
+			order = binary.LittleEndian
+		case "MM":
+			order = binary.BigEndian
+		default:
+			return 1
+		}
+		ifdOffset := int(order.Uint32(tiff[4:8]))
+		if ifdOffset+2 > len(tiff) {
+			return 1
+		}
+		entries := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+		for i := 0; i < entries; i++ {
+			entry := ifdOffset + 2 + i*12
+			if entry+12 > len(tiff) {
+				return 1
+			}
+			if order.Uint16(tiff[entry:entry+2]) == orientationTag {
+				return int(order.Uint16(tiff[entry+8 : entry+10]))
+			}
+		}
+		return 1
+	}
+	return 1
+}
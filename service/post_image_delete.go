@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// imageObjectKeys 提取图片的 COS 对象键（含保留的原图），跳过为空的对象键。
+func imageObjectKeys(images []*entities.PostDetailImage) []string {
+	keys := make([]string, 0, len(images))
+	for _, img := range images {
+		if img.ObjectKey != "" {
+			keys = append(keys, img.ObjectKey)
+		}
+		if img.OriginalObjectKey != "" {
+			keys = append(keys, img.OriginalObjectKey)
+		}
+	}
+	return keys
+}
+
+// enqueuePostImageDeletion 在帖子软删除成功后，把其图片对象键投递到 COS 延迟删除队列，计划在保留期满后删除。
+// - 投递失败只记录日志，不影响删除结果；遗留的 COS 对象可凭日志中的对象键人工清理。
+func enqueuePostImageDeletion(ctx context.Context, queue redis.COSDeleteQueue, logger *core.ZapLogger, postID uint64, images []*entities.PostDetailImage) {
+	objectKeys := imageObjectKeys(images)
+	if len(objectKeys) == 0 {
+		return
+	}
+	deleteAt := time.Now().Add(constant.COSDeleteRetention)
+	if err := queue.Enqueue(context.WithoutCancel(ctx), postID, objectKeys, deleteAt); err != nil {
+		logger.Error("帖子图片投递 COS 延迟删除队列失败", zap.Error(err), zap.Uint64("postID", postID), zap.Strings("objectKeys", objectKeys))
+		return
+	}
+	logger.Info("帖子图片已投递 COS 延迟删除队列", zap.Uint64("postID", postID), zap.Int("images", len(objectKeys)), zap.Time("deleteAt", deleteAt))
+}
+
+// cancelPostImageDeletion 在帖子恢复成功后，把其图片对象键从 COS 延迟删除队列中移除。
+// - 移除失败只记录日志：COS 延迟删除任务删除前会确认对象键不再被未删除的帖子引用，不会误删。
+func cancelPostImageDeletion(ctx context.Context, queue redis.COSDeleteQueue, logger *core.ZapLogger, postID uint64, images []*entities.PostDetailImage) {
+	objectKeys := imageObjectKeys(images)
+	if len(objectKeys) == 0 {
+		return
+	}
+	if err := queue.Cancel(context.WithoutCancel(ctx), postID, objectKeys); err != nil {
+		logger.Error("从 COS 延迟删除队列移除恢复帖子的图片失败", zap.Error(err), zap.Uint64("postID", postID))
+	}
+}
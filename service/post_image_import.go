@@ -0,0 +1,350 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// errImageURLInternalAddress 标记图片 URL（或其重定向目标）解析到了禁止访问的内网、回环或保留地址。
+var errImageURLInternalAddress = errors.New("image url resolves to an internal address")
+
+// sharedAddressSpace 是运营商级 NAT 使用的 100.64.0.0/10，net.IP.IsPrivate 不包含该网段。
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// imageExtensionsByContentType 为 URL 中没有扩展名的图片补全扩展名，生成的对象键依赖扩展名。
+var imageExtensionsByContentType = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// postImageImporter 在创建帖子时从 URL 下载图片，并与直接上传的文件合并为同一个图片列表。
+// - 下载结果转换为内存中的 multipart.FileHeader，之后与上传的文件一样经过校验、压缩与上传。
+// - 连接建立时校验实际连接的 IP，拒绝回环、内网、链路本地等地址；重定向同样经过该校验，DNS 重绑定也无法绕过。
+// - 单张图片的下载受超时、重定向次数与大小上限约束，超过大小上限时立即中止读取。
+type postImageImporter struct {
+	enabled      bool
+	maxFileBytes int64
+	client       *http.Client
+	logger       *core.ZapLogger
+}
+
+// newPostImageImporter 根据配置创建图片导入器，未配置的参数使用默认值；大小上限与图片校验器一致。
+func newPostImageImporter(cfg config.ImageUploadConfig, logger *core.ZapLogger) postImageImporter {
+	timeout := cfg.URLImport.Timeout
+	if timeout <= 0 {
+		timeout = constant.DefaultImageURLImportTimeout
+	}
+	maxRedirects := cfg.URLImport.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = constant.DefaultImageURLImportMaxRedirects
+	}
+	maxFileBytes := cfg.MaxFileBytes
+	if maxFileBytes <= 0 {
+		maxFileBytes = constant.DefaultPostImageMaxBytes
+	}
+
+	dialer := &net.Dialer{Timeout: constant.ImageURLImportDialTimeout, Control: denyInternalAddress}
+	transport := &http.Transport{
+		Proxy:                  nil, // 经代理访问时校验的是代理地址，导入图片必须直连
+		DialContext:            dialer.DialContext,
+		ForceAttemptHTTP2:      true,
+		TLSHandshakeTimeout:    constant.ImageURLImportDialTimeout,
+		ResponseHeaderTimeout:  timeout,
+		MaxIdleConnsPerHost:    2,
+		MaxResponseHeaderBytes: 64 << 10,
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if maxRedirects < 0 {
+				return http.ErrUseLastResponse
+			}
+			if len(via) > maxRedirects {
+				return fmt.Errorf("重定向次数超过 %d 次", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("不支持重定向到 %s 协议", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+
+	return postImageImporter{
+		enabled:      cfg.URLImport.Enabled,
+		maxFileBytes: maxFileBytes,
+		client:       client,
+		logger:       logger,
+	}
+}
+
+// denyInternalAddress 在建立连接前校验目标 IP，拒绝访问内网、回环与保留地址。
+func denyInternalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errImageURLInternalAddress, address)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIP(ip) {
+		return fmt.Errorf("%w: %s", errImageURLInternalAddress, host)
+	}
+	return nil
+}
+
+// isInternalIP 判断 IP 是否属于不允许服务端主动访问的地址段。
+func isInternalIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		if ip4[0] == 0 || sharedAddressSpace.Contains(ip4) {
+			return true
+		}
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// importedImage 是下载完成的一张图片
+type importedImage struct {
+	filename string
+	data     []byte
+}
+
+// Merge 下载 imageURLs 中的图片，并按 positions 与上传的 files 合并为最终的图片列表，列表顺序即 DisplayOrder。
+//   - positions 与 imageURLs 一一对应，表示 URL 图片在最终列表中的位置 (从 0 开始)；为空时 URL 图片按顺序排在文件之后。
+//   - 下载任何图片前先校验开关、URL 与位置，任一图片下载失败则整体失败。
+//   - 错误均可 errors.Is myErrors.ErrInvalidPostImage，错误信息可直接展示给用户。
+func (im postImageImporter) Merge(ctx context.Context, files []*multipart.FileHeader, imageURLs []string, positions []int) ([]*multipart.FileHeader, error) {
+	if len(imageURLs) == 0 {
+		if len(positions) > 0 {
+			return nil, fmt.Errorf("%w: 未提供图片 URL，不能指定 image_url_positions", myErrors.ErrInvalidPostImage)
+		}
+		return files, nil
+	}
+	if !im.enabled {
+		return nil, fmt.Errorf("%w: 暂不支持从 URL 导入图片，请直接上传图片文件", myErrors.ErrInvalidPostImage)
+	}
+
+	total := len(files) + len(imageURLs)
+	if len(positions) > 0 {
+		if err := validateImageURLPositions(positions, len(imageURLs), total); err != nil {
+			return nil, err
+		}
+	}
+
+	parsedURLs := make([]*url.URL, len(imageURLs))
+	for i, rawURL := range imageURLs {
+		u, err := url.Parse(strings.TrimSpace(rawURL))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return nil, fmt.Errorf("%w: 图片地址 %q 不是合法的 http/https 地址", myErrors.ErrInvalidPostImage, rawURL)
+		}
+		parsedURLs[i] = u
+	}
+
+	images, err := im.downloadAll(ctx, parsedURLs)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := toFileHeaders(images)
+	if err != nil {
+		return nil, fmt.Errorf("转换导入的图片失败: %w", err)
+	}
+
+	if len(positions) == 0 {
+		merged := make([]*multipart.FileHeader, 0, total)
+		return append(append(merged, files...), imported...), nil
+	}
+	merged := make([]*multipart.FileHeader, total)
+	for i, pos := range positions {
+		merged[pos] = imported[i]
+	}
+	next := 0
+	for i := range merged {
+		if merged[i] == nil {
+			merged[i] = files[next]
+			next++
+		}
+	}
+	return merged, nil
+}
+
+// validateImageURLPositions 校验 URL 图片的位置：数量与 URL 一致、在最终列表范围内且互不重复。
+func validateImageURLPositions(positions []int, urlCount, total int) error {
+	if len(positions) != urlCount {
+		return fmt.Errorf("%w: image_url_positions 数量 (%d) 与 image_urls 数量 (%d) 不一致", myErrors.ErrInvalidPostImage, len(positions), urlCount)
+	}
+	seen := make(map[int]struct{}, len(positions))
+	for _, pos := range positions {
+		if pos < 0 || pos >= total {
+			return fmt.Errorf("%w: 图片位置 %d 超出范围 [0, %d)", myErrors.ErrInvalidPostImage, pos, total)
+		}
+		if _, dup := seen[pos]; dup {
+			return fmt.Errorf("%w: 图片位置 %d 重复", myErrors.ErrInvalidPostImage, pos)
+		}
+		seen[pos] = struct{}{}
+	}
+	return nil
+}
+
+// downloadAll 并发下载整批图片，返回与 urls 一一对应的结果；任一图片失败时取消其余下载并返回第一个错误。
+func (im postImageImporter) downloadAll(ctx context.Context, urls []*url.URL) ([]importedImage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	images := make([]importedImage, len(urls))
+	errs := make([]error, len(urls))
+	sem := make(chan struct{}, constant.ImageURLImportConcurrency)
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, u *url.URL) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			image, err := im.download(ctx, u)
+			if err != nil {
+				errs[i] = err
+				cancel()
+				return
+			}
+			images[i] = image
+		}(i, u)
+	}
+	wg.Wait()
+
+	// 优先返回非取消导致的错误，取消只是其他图片失败的连带结果
+	var firstErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		im.logger.Warn("从 URL 导入图片失败", zap.Error(err), zap.String("url", urls[i].Redacted()))
+		if firstErr == nil || errors.Is(firstErr, context.Canceled) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return images, nil
+}
+
+// download 下载单张图片并校验响应状态、Content-Type 与大小。
+func (im postImageImporter) download(ctx context.Context, u *url.URL) (importedImage, error) {
+	displayURL := u.Redacted()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return importedImage{}, fmt.Errorf("%w: 图片地址 %s 无效: %v", myErrors.ErrInvalidPostImage, displayURL, err)
+	}
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := im.client.Do(req)
+	if err != nil {
+		return importedImage{}, downloadError(displayURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return importedImage{}, fmt.Errorf("%w: 下载图片 %s 失败，响应状态 %d", myErrors.ErrInvalidPostImage, displayURL, resp.StatusCode)
+	}
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(contentType, "image/") {
+		return importedImage{}, fmt.Errorf("%w: 地址 %s 返回的内容不是图片 (Content-Type: %s)", myErrors.ErrInvalidPostImage, displayURL, resp.Header.Get("Content-Type"))
+	}
+	if resp.ContentLength > im.maxFileBytes {
+		return importedImage{}, fmt.Errorf("%w: 图片 %s 大小 %d 字节，超过上限 %d 字节", myErrors.ErrInvalidPostImage, displayURL, resp.ContentLength, im.maxFileBytes)
+	}
+
+	// 多读 1 个字节用于判断是否超过上限，未声明 Content-Length 的响应也不会被完整读入内存
+	data, err := io.ReadAll(io.LimitReader(resp.Body, im.maxFileBytes+1))
+	if err != nil {
+		return importedImage{}, downloadError(displayURL, err)
+	}
+	if int64(len(data)) > im.maxFileBytes {
+		return importedImage{}, fmt.Errorf("%w: 图片 %s 超过大小上限 %d 字节", myErrors.ErrInvalidPostImage, displayURL, im.maxFileBytes)
+	}
+	if len(data) == 0 {
+		return importedImage{}, fmt.Errorf("%w: 图片 %s 内容为空", myErrors.ErrInvalidPostImage, displayURL)
+	}
+
+	return importedImage{filename: importedImageFilename(u, contentType), data: data}, nil
+}
+
+// downloadError 将下载过程中的网络错误转换为可展示给用户的图片错误。
+func downloadError(displayURL string, err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errImageURLInternalAddress):
+		return fmt.Errorf("%w: 图片地址 %s 指向内网或保留地址，不允许导入", myErrors.ErrInvalidPostImage, displayURL)
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return fmt.Errorf("%w: 下载图片 %s 超时", myErrors.ErrInvalidPostImage, displayURL)
+	default:
+		return fmt.Errorf("%w: 下载图片 %s 失败: %v", myErrors.ErrInvalidPostImage, displayURL, err)
+	}
+}
+
+// importedImageFilename 根据 URL 路径生成文件名，扩展名以响应的 Content-Type 为准，用于生成对象键。
+func importedImageFilename(u *url.URL, contentType string) string {
+	base := path.Base(u.Path)
+	if base == "." || base == "/" {
+		base = "image"
+	}
+	ext := path.Ext(base)
+	if known, ok := imageExtensionsByContentType[contentType]; ok {
+		return strings.TrimSuffix(base, ext) + known
+	}
+	return base
+}
+
+// toFileHeaders 将下载的图片编码为 multipart 表单再解析，得到内存中的 multipart.FileHeader，
+// 使导入的图片可以复用上传文件的校验、压缩与上传流程。
+func toFileHeaders(images []importedImage) ([]*multipart.FileHeader, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	var totalBytes int64
+	for _, image := range images {
+		part, err := writer.CreateFormFile("images", image.filename)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(image.data); err != nil {
+			return nil, err
+		}
+		totalBytes += int64(len(image.data))
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	// 内存上限覆盖全部图片，保证不会落盘生成临时文件
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(totalBytes + 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	headers := form.File["images"]
+	if len(headers) != len(images) {
+		return nil, fmt.Errorf("解析出 %d 张图片，期望 %d 张", len(headers), len(images))
+	}
+	return headers, nil
+}
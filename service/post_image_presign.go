@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// directUploadImageExtensions 是允许客户端直传的图片扩展名，对象键沿用该扩展名。
+var directUploadImageExtensions = map[string]struct{}{
+	".jpg":  {},
+	".jpeg": {},
+	".png":  {},
+	".gif":  {},
+	".webp": {},
+	".bmp":  {},
+}
+
+// directUploadKeyPrefix 返回作者本人的直传对象键前缀，发帖时只接受该前缀下的对象键。
+func directUploadKeyPrefix(userID string) string {
+	return constant.COSObjectKeyPrefixPostDirectUploads + userID + "/"
+}
+
+// PrepareImageUpload 实现申请图片直传 URL。
+// - 对象键由服务端生成：posts/direct/{userID}/{yyyyMMdd}/{uuid}{ext}，客户端只能决定扩展名。
+// - 生成 URL 只在本地计算签名，不检查对象是否已存在；uuid 保证不会覆盖已有对象。
+func (s *postService) PrepareImageUpload(ctx context.Context, userID string, filename string) (*vo.PresignedImageUploadVO, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if _, ok := directUploadImageExtensions[ext]; !ok {
+		return nil, fmt.Errorf("%w: 不支持的图片格式 %q", myErrors.ErrInvalidPostImage, ext)
+	}
+
+	objectKey := fmt.Sprintf("%s%s/%s%s", directUploadKeyPrefix(userID), time.Now().Format("20060102"), uuid.NewString(), ext)
+	expiresAt := time.Now().Add(s.imagePresignExpiry)
+	uploadURL, err := s.cosClient.GeneratePresignedPutURL(ctx, objectKey, s.imagePresignExpiry)
+	if err != nil {
+		s.logger.Error("生成图片直传 URL 失败", zap.Error(err), zap.String("userID", userID))
+		return nil, fmt.Errorf("生成图片直传 URL 失败: %w", err)
+	}
+	s.logger.Info("已生成图片直传 URL", zap.String("userID", userID), zap.String("objectKey", objectKey))
+	return &vo.PresignedImageUploadVO{
+		ObjectKey: objectKey,
+		UploadURL: uploadURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// checkDirectUploadImages 校验创建帖子时引用的直传图片对象键。
+//   - 对象键必须位于作者本人的直传前缀下且不含路径穿越，同一请求内不能重复。
+//   - 并发查询对象元数据：对象必须已上传，上传时声明的 Content-Type 为图片，大小不超过单张上限。
+//     对象内容不经过本服务，无法像上传的文件那样识别文件头，依赖发帖后的内容审核兜底。
+//   - 校验不通过时返回可 errors.Is myErrors.ErrInvalidPostImage 的错误。
+func (s *postService) checkDirectUploadImages(ctx context.Context, authorID string, objectKeys []string) error {
+	prefix := directUploadKeyPrefix(authorID)
+	seen := make(map[string]struct{}, len(objectKeys))
+	for _, key := range objectKeys {
+		if !strings.HasPrefix(key, prefix) || path.Clean(key) != key {
+			return fmt.Errorf("%w: 图片对象键 %s 不属于当前用户的直传目录", myErrors.ErrInvalidPostImage, key)
+		}
+		if _, ok := directUploadImageExtensions[strings.ToLower(path.Ext(key))]; !ok {
+			return fmt.Errorf("%w: 图片对象键 %s 的格式不受支持", myErrors.ErrInvalidPostImage, key)
+		}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("%w: 图片对象键 %s 重复", myErrors.ErrInvalidPostImage, key)
+		}
+		seen[key] = struct{}{}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(constant.ImageDirectUploadCheckConcurrency)
+	for _, key := range objectKeys {
+		g.Go(func() error {
+			info, err := s.cosClient.HeadObject(gctx, key)
+			if err != nil {
+				return fmt.Errorf("查询直传图片 %s 失败: %w", key, err)
+			}
+			if info == nil {
+				return fmt.Errorf("%w: 图片 %s 尚未上传或已过期", myErrors.ErrInvalidPostImage, key)
+			}
+			if !strings.HasPrefix(info.ContentType, "image/") {
+				return fmt.Errorf("%w: 文件 %s 不是图片 (声明为 %s)", myErrors.ErrInvalidPostImage, key, info.ContentType)
+			}
+			if info.Size > s.imageValidator.maxFileBytes {
+				return fmt.Errorf("%w: 图片 %s 大小 %d 字节，超过上限 %d 字节", myErrors.ErrInvalidPostImage, key, info.Size, s.imageValidator.maxFileBytes)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
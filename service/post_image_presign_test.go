@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// fakeCOSClient 是内存中的 COS 客户端，记录上传、复制与删除的对象键，并统计同时进行的上传数。
+type fakeCOSClient struct {
+	dependencies.COSClientInterface
+	mu         sync.Mutex
+	objects    map[string]*dependencies.ObjectInfo
+	headErr    error
+	presignErr error
+	failUpload map[string]bool // 上传这些对象键时返回错误
+	uploaded   []string
+	deleted    []string
+	inFlight   int
+	maxFlight  int
+	uploadWait time.Duration
+}
+
+func newFakeCOSClient() *fakeCOSClient {
+	return &fakeCOSClient{objects: map[string]*dependencies.ObjectInfo{}, failUpload: map[string]bool{}}
+}
+
+func (c *fakeCOSClient) GeneratePresignedPutURL(_ context.Context, objectKey string, _ time.Duration) (string, error) {
+	if c.presignErr != nil {
+		return "", c.presignErr
+	}
+	return "https://cos.example.com/" + objectKey + "?sign=x", nil
+}
+
+func (c *fakeCOSClient) HeadObject(_ context.Context, objectKey string) (*dependencies.ObjectInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.headErr != nil {
+		return nil, c.headErr
+	}
+	return c.objects[objectKey], nil
+}
+
+func (c *fakeCOSClient) UploadFile(ctx context.Context, objectKey string, reader io.Reader, _ int64, _ string) (string, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.maxFlight = max(c.maxFlight, c.inFlight)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(c.uploadWait):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	if _, err := io.ReadAll(reader); err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failUpload[objectKey] {
+		return "", errors.New("cos: upload rejected")
+	}
+	c.uploaded = append(c.uploaded, objectKey)
+	return "https://cdn.example.com/" + objectKey, nil
+}
+
+func (c *fakeCOSClient) CopyObject(_ context.Context, srcKey, dstKey string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[srcKey]; !ok {
+		return "", fmt.Errorf("cos: source %s not found", srcKey)
+	}
+	c.uploaded = append(c.uploaded, dstKey)
+	return "https://cdn.example.com/" + dstKey, nil
+}
+
+func (c *fakeCOSClient) DeleteObject(_ context.Context, objectKey string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deleted = append(c.deleted, objectKey)
+	return nil
+}
+
+// purgeRecordingPostRepo 只记录被物理删除的占位帖子。
+type purgeRecordingPostRepo struct {
+	mysql.PostRepository
+	purged []uint64
+}
+
+func (r *purgeRecordingPostRepo) PurgeUploadPendingPost(_ context.Context, postID uint64) error {
+	r.purged = append(r.purged, postID)
+	return nil
+}
+
+func TestPrepareImageUpload(t *testing.T) {
+	cos := newFakeCOSClient()
+	s := &postService{cosClient: cos, imagePresignExpiry: 10 * time.Minute, logger: newTestLogger(t)}
+
+	got, err := s.PrepareImageUpload(context.Background(), "user-1", "Photo.JPG")
+	if err != nil {
+		t.Fatalf("PrepareImageUpload: %v", err)
+	}
+	if !strings.HasPrefix(got.ObjectKey, directUploadKeyPrefix("user-1")) || !strings.HasSuffix(got.ObjectKey, ".jpg") {
+		t.Fatalf("object key = %q, want it under the user's direct-upload prefix with a lower-case extension", got.ObjectKey)
+	}
+	if !strings.Contains(got.UploadURL, got.ObjectKey) || time.Until(got.ExpiresAt) <= 0 {
+		t.Fatalf("upload = %+v, want a URL for the object key that expires in the future", got)
+	}
+
+	if _, err := s.PrepareImageUpload(context.Background(), "user-1", "script.svg"); !errors.Is(err, myErrors.ErrInvalidPostImage) {
+		t.Fatalf("svg error = %v, want ErrInvalidPostImage", err)
+	}
+
+	cos.presignErr = errors.New("signer unavailable")
+	if _, err := s.PrepareImageUpload(context.Background(), "user-1", "a.png"); err == nil || errors.Is(err, myErrors.ErrInvalidPostImage) {
+		t.Fatalf("presign failure error = %v, want a non-validation error", err)
+	}
+}
+
+func TestCheckDirectUploadImages(t *testing.T) {
+	prefix := directUploadKeyPrefix("author-1")
+	okKey := prefix + "20260101/a.png"
+	cos := newFakeCOSClient()
+	cos.objects[okKey] = &dependencies.ObjectInfo{Size: 1024, ContentType: "image/png"}
+	cos.objects[prefix+"20260101/b.png"] = &dependencies.ObjectInfo{Size: 1024, ContentType: "image/png"}
+	cos.objects[prefix+"20260101/doc.png"] = &dependencies.ObjectInfo{Size: 1024, ContentType: "application/pdf"}
+	cos.objects[prefix+"20260101/huge.png"] = &dependencies.ObjectInfo{Size: 1 << 30, ContentType: "image/png"}
+	s := &postService{
+		cosClient:      cos,
+		imageValidator: newPostImageValidator(config.ImageUploadConfig{}),
+		logger:         newTestLogger(t),
+	}
+
+	cases := []struct {
+		name    string
+		keys    []string
+		wantErr bool
+	}{
+		{name: "本人已上传的图片", keys: []string{okKey, prefix + "20260101/b.png"}},
+		{name: "其他用户的前缀", keys: []string{directUploadKeyPrefix("author-2") + "20260101/a.png"}, wantErr: true},
+		{name: "前缀匹配但不是本人目录", keys: []string{constant.COSObjectKeyPrefixPostDirectUploads + "author-10/20260101/a.png"}, wantErr: true},
+		{name: "路径穿越", keys: []string{prefix + "../author-2/20260101/a.png"}, wantErr: true},
+		{name: "不支持的扩展名", keys: []string{prefix + "20260101/a.svg"}, wantErr: true},
+		{name: "重复的对象键", keys: []string{okKey, okKey}, wantErr: true},
+		{name: "对象不存在", keys: []string{prefix + "20260101/missing.png"}, wantErr: true},
+		{name: "声明的类型不是图片", keys: []string{prefix + "20260101/doc.png"}, wantErr: true},
+		{name: "超过单张大小上限", keys: []string{prefix + "20260101/huge.png"}, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.checkDirectUploadImages(context.Background(), "author-1", tc.keys)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("checkDirectUploadImages(%v) error = %v, wantErr %v", tc.keys, err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, myErrors.ErrInvalidPostImage) {
+				t.Fatalf("error = %v, want ErrInvalidPostImage", err)
+			}
+		})
+	}
+}
+
+// HeadObject 本身失败属于依赖故障，不能被当作图片不合法返回 400。
+func TestCheckDirectUploadImagesHeadObjectFailure(t *testing.T) {
+	cos := newFakeCOSClient()
+	cos.headErr = errors.New("cos: timeout")
+	s := &postService{cosClient: cos, imageValidator: newPostImageValidator(config.ImageUploadConfig{}), logger: newTestLogger(t)}
+
+	err := s.checkDirectUploadImages(context.Background(), "author-1", []string{directUploadKeyPrefix("author-1") + "20260101/a.png"})
+	if err == nil || errors.Is(err, myErrors.ErrInvalidPostImage) || !errors.Is(err, cos.headErr) {
+		t.Fatalf("error = %v, want the wrapped HeadObject error", err)
+	}
+}
+
+// newPendingImages 构造 n 张待上传图片的占位记录、文件头与压缩后的内容，第 0 张额外保留原图。
+func newPendingImages(n int) ([]*entities.PostDetailImage, []*multipart.FileHeader, []string, [][]byte) {
+	images := make([]*entities.PostDetailImage, n)
+	files := make([]*multipart.FileHeader, n)
+	contentTypes := make([]string, n)
+	compressed := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		images[i] = &entities.PostDetailImage{ObjectKey: fmt.Sprintf("posts/images/1/%d.jpg", i), DisplayOrder: i}
+		files[i] = &multipart.FileHeader{Filename: fmt.Sprintf("%d.jpg", i), Size: 3}
+		contentTypes[i] = "image/jpeg"
+		compressed[i] = []byte("jpg")
+	}
+	return images, files, contentTypes, compressed
+}
+
+func TestUploadPendingImagesConcurrently(t *testing.T) {
+	cos := newFakeCOSClient()
+	cos.uploadWait = 20 * time.Millisecond
+	directKey := directUploadKeyPrefix("author-1") + "20260101/d.png"
+	cos.objects[directKey] = &dependencies.ObjectInfo{Size: 10, ContentType: "image/png"}
+	s := &postService{cosClient: cos, logger: newTestLogger(t)}
+
+	images, files, contentTypes, compressed := newPendingImages(8)
+	images = append(images, &entities.PostDetailImage{ObjectKey: "posts/images/1/8.png", DisplayOrder: 8})
+
+	if err := s.uploadPendingImages(context.Background(), images, files, contentTypes, compressed, []string{directKey}); err != nil {
+		t.Fatalf("uploadPendingImages: %v", err)
+	}
+	for i, img := range images {
+		if img.ImageURL != "https://cdn.example.com/"+img.ObjectKey {
+			t.Fatalf("images[%d].ImageURL = %q, want the URL of its own object key", i, img.ImageURL)
+		}
+	}
+	if cos.maxFlight < 2 || cos.maxFlight > constant.ImageUploadConcurrency {
+		t.Fatalf("max concurrent uploads = %d, want between 2 and %d", cos.maxFlight, constant.ImageUploadConcurrency)
+	}
+}
+
+func TestCompleteImageUploadRollsBackOnUploadFailure(t *testing.T) {
+	cos := newFakeCOSClient()
+	postRepo := &purgeRecordingPostRepo{}
+	s := &postService{cosClient: cos, postRepo: postRepo, logger: newTestLogger(t)}
+
+	images, files, contentTypes, compressed := newPendingImages(6)
+	images[0].OriginalObjectKey = "posts/images/1/0-original.jpg"
+	cos.failUpload[images[3].ObjectKey] = true
+	post := &entities.Post{ImageUploadPending: true}
+	post.ID = 1
+
+	_, err := s.completeImageUpload(context.Background(), post, &entities.PostDetail{}, images, files, contentTypes, compressed, nil, nil, nil)
+	if err == nil {
+		t.Fatal("completeImageUpload succeeded, want the upload error")
+	}
+
+	// 无论哪些图片已上传成功，回滚都要删除占位记录中的全部对象键，再删除占位帖子
+	wantDeleted := imageObjectKeys(images)
+	if len(cos.deleted) != len(wantDeleted) {
+		t.Fatalf("deleted = %v, want every object key %v", cos.deleted, wantDeleted)
+	}
+	deleted := make(map[string]bool, len(cos.deleted))
+	for _, key := range cos.deleted {
+		deleted[key] = true
+	}
+	for _, key := range wantDeleted {
+		if !deleted[key] {
+			t.Fatalf("object %s was not deleted during rollback", key)
+		}
+	}
+	if len(postRepo.purged) != 1 || postRepo.purged[0] != post.ID {
+		t.Fatalf("purged posts = %v, want [%d]", postRepo.purged, post.ID)
+	}
+}
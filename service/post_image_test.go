@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+func TestPostImageValidatorCheckCount(t *testing.T) {
+	v := newPostImageValidator(config.ImageUploadConfig{
+		KindCountLimits: map[string]config.ImageCountLimit{
+			constant.PostImageKindGoods: {MinCount: 1, MaxCount: 3},
+		},
+	})
+
+	cases := []struct {
+		name    string
+		total   int
+		kind    string
+		wantErr bool
+	}{
+		{name: "默认上限以内", total: constant.DefaultPostImageMaxCount, kind: constant.PostImageKindDefault},
+		{name: "超过默认上限", total: constant.DefaultPostImageMaxCount + 1, kind: constant.PostImageKindDefault, wantErr: true},
+		{name: "商品帖低于下限", total: 0, kind: constant.PostImageKindGoods, wantErr: true},
+		{name: "商品帖超过类型上限", total: 4, kind: constant.PostImageKindGoods, wantErr: true},
+		{name: "商品帖在类型上下限内", total: 3, kind: constant.PostImageKindGoods},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.CheckCount(tc.total, tc.kind)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("CheckCount(%d, %q) error = %v, wantErr %v", tc.total, tc.kind, err, tc.wantErr)
+			}
+			if err != nil && !errors.Is(err, myErrors.ErrInvalidPostImage) {
+				t.Fatalf("error = %v, want ErrInvalidPostImage", err)
+			}
+		})
+	}
+}
+
+// 上传的文件、URL 与直传对象键合计超限时，必须在下载任何 URL 图片之前拒绝。
+func TestCreatePostRejectsTooManyImagesBeforeDownloading(t *testing.T) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downloads.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n"))
+	}))
+	defer server.Close()
+
+	logger := newTestLogger(t)
+	cfg := config.ImageUploadConfig{MaxCount: 3}
+	s := &postService{
+		accessGuard:       NewPostAccessGuard(logger),
+		contentSanitizer:  NewContentSanitizer(config.ContentSanitizeConfig{}),
+		complianceChecker: noopComplianceChecker{},
+		imageValidator:    newPostImageValidator(cfg),
+		// 直接使用测试服务器的客户端，绕过导入器对回环地址的拦截
+		imageImporter: postImageImporter{enabled: true, maxFileBytes: constant.DefaultPostImageMaxBytes, client: server.Client(), logger: logger},
+		logger:        logger,
+	}
+
+	req := &dto.CreatePostRequest{
+		Title:           "标题",
+		Content:         "正文",
+		AuthorID:        "author-1",
+		IgnoreSimilar:   true,
+		ImageURLs:       []string{server.URL + "/1.png", server.URL + "/2.png"},
+		ImageObjectKeys: []string{"posts/images/direct/author-1_a.png", "posts/images/direct/author-1_b.png"},
+	}
+	_, err := s.createPost(context.Background(), req, nil)
+	if !errors.Is(err, myErrors.ErrInvalidPostImage) {
+		t.Fatalf("createPost error = %v, want ErrInvalidPostImage", err)
+	}
+	if n := downloads.Load(); n != 0 {
+		t.Fatalf("downloaded %d URL images before rejecting the request, want 0", n)
+	}
+}
+
+// 各类文件的文件头，http.DetectContentType 据此识别真实类型。
+var (
+	testPNGHeader  = "\x89PNG\r\n\x1a\n"
+	testJPEGHeader = "\xff\xd8\xff\xe0"
+)
+
+// newTestFileHeaders 按 name/content 成对的参数构造 multipart 文件头，与 Gin 解析表单得到的结果一致。
+func newTestFileHeaders(t *testing.T, nameContents ...string) []*multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for i := 0; i+1 < len(nameContents); i += 2 {
+		part, err := w.CreateFormFile("images", nameContents[i])
+		if err != nil {
+			t.Fatalf("CreateFormFile: %v", err)
+		}
+		if _, err := part.Write([]byte(nameContents[i+1])); err != nil {
+			t.Fatalf("write form file: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("ReadForm: %v", err)
+	}
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["images"]
+}
+
+func TestPostImageValidatorValidate(t *testing.T) {
+	v := newPostImageValidator(config.ImageUploadConfig{MaxFileBytes: 64, MaxCount: 2})
+
+	cases := []struct {
+		name         string
+		files        []string
+		directCount  int
+		wantTypes    []string
+		wantErrMatch string
+	}{
+		{name: "按文件头识别类型", files: []string{"a.png", testPNGHeader, "b.jpg", testJPEGHeader}, wantTypes: []string{"image/png", "image/jpeg"}},
+		{name: "扩展名与内容不符时以内容为准", files: []string{"a.jpg", testPNGHeader}, wantTypes: []string{"image/png"}},
+		{name: "伪装成图片的 HTML", files: []string{"a.png", "<html><script>alert(1)</script></html>"}, wantErrMatch: "不是图片"},
+		{name: "纯文本", files: []string{"a.gif", "hello"}, wantErrMatch: "不是图片"},
+		{name: "单张超过大小上限", files: []string{"a.png", testPNGHeader + strings.Repeat("\x00", 64)}, wantErrMatch: "超过上限"},
+		{name: "直传图片计入数量上限", files: []string{"a.png", testPNGHeader}, directCount: 2, wantErrMatch: "最多上传"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			types, err := v.Validate(newTestFileHeaders(t, tc.files...), tc.directCount, constant.PostImageKindDefault)
+			if tc.wantErrMatch != "" {
+				if !errors.Is(err, myErrors.ErrInvalidPostImage) || !strings.Contains(err.Error(), tc.wantErrMatch) {
+					t.Fatalf("Validate error = %v, want ErrInvalidPostImage containing %q", err, tc.wantErrMatch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if strings.Join(types, ",") != strings.Join(tc.wantTypes, ",") {
+				t.Fatalf("content types = %v, want %v", types, tc.wantTypes)
+			}
+		})
+	}
+}
+
+// 整批图片中任一张不合法时，必须在写库和上传任何一张图片之前拒绝。
+func TestCreatePostRejectsInvalidImageBeforeUploading(t *testing.T) {
+	logger := newTestLogger(t)
+	cos := newFakeCOSClient()
+	s := &postService{
+		accessGuard:       NewPostAccessGuard(logger),
+		contentSanitizer:  NewContentSanitizer(config.ContentSanitizeConfig{}),
+		complianceChecker: noopComplianceChecker{},
+		imageValidator:    newPostImageValidator(config.ImageUploadConfig{}),
+		cosClient:         cos,
+		logger:            logger,
+	}
+	files := newTestFileHeaders(t, "ok.png", testPNGHeader, "evil.png", "<svg onload=alert(1)></svg>")
+
+	req := &dto.CreatePostRequest{Title: "标题", Content: "正文", AuthorID: "author-1", IgnoreSimilar: true}
+	_, err := s.createPost(context.Background(), req, files)
+	if !errors.Is(err, myErrors.ErrInvalidPostImage) {
+		t.Fatalf("createPost error = %v, want ErrInvalidPostImage", err)
+	}
+	if len(cos.uploaded) != 0 {
+		t.Fatalf("uploaded %v before rejecting the request, want nothing", cos.uploaded)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// finishPostCreation 在事务内完成帖子创建的收尾：转发帖的原帖转发数加 1，并写入待审核事件的发件箱记录。
+// - 不带图的帖子与帖子记录在同一事务内执行；带图的帖子在图片全部上传并回填 URL 的事务内执行。
+// - 草稿不送审，返回的事件为 nil。
+// - compliance 为创建时的地区合规预检结果，其目标地区与待复审敏感词随待审核事件携带。
+func (s *postService) finishPostCreation(ctx context.Context, tx *gorm.DB, post *entities.Post, detail *entities.PostDetail, images []*entities.PostDetailImage, quotedPost *entities.Post, compliance *ComplianceResult) (*entities.OutboxEvent, error) {
+	// 原帖在校验之后被删除时整体回滚
+	if quotedPost != nil {
+		if repoErr := s.postRepo.IncrementRepostCount(ctx, tx, quotedPost.ID); repoErr != nil {
+			if errors.Is(repoErr, commonerrors.ErrRepoNotFound) {
+				return nil, myErrors.ErrQuotedPostUnavailable
+			}
+			return nil, fmt.Errorf("增加原帖转发数失败: %w", repoErr)
+		}
+	}
+
+	// 草稿不送审，等作者发布时再写入
+	if post.IsDraft {
+		return nil, nil
+	}
+	return s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+		return s.kafkaSvc.NewPostPendingAuditOutboxEvent(newPendingAuditPostData(post, detail, images), compliance.PendingAuditMeta(post.AuditPriority))
+	})
+}
+
+// completeImageUpload 上传占位帖子的图片，并在一个事务内回填 URL、解除占位、完成创建收尾。
+//   - images 为已写库的占位图片记录：前 len(imageFiles) 条与 imageFiles、contentTypes、compressed 按下标一一对应，
+//     其后的记录依次对应 directKeys 中客户端直传的暂存对象；上传或复制成功后原地写入 ImageURL。
+//   - 任一步骤失败都会同步补偿（删除已上传的对象与占位记录）后返回原始错误；直传的暂存对象不删除，客户端可用相同的对象键重试。
+func (s *postService) completeImageUpload(ctx context.Context, post *entities.Post, detail *entities.PostDetail, images []*entities.PostDetailImage, imageFiles []*multipart.FileHeader, contentTypes []string, compressed [][]byte, directKeys []string, quotedPost *entities.Post, compliance *ComplianceResult) (*entities.OutboxEvent, error) {
+	if err := s.uploadPendingImages(ctx, images, imageFiles, contentTypes, compressed, directKeys); err != nil {
+		s.compensatePendingPost(post.ID, images)
+		return nil, err
+	}
+
+	var auditEvent *entities.OutboxEvent
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if repoErr := s.postDetailImageRepo.BackfillImageURLs(ctx, tx, images); repoErr != nil {
+			return fmt.Errorf("回填帖子图片 URL 失败: %w", repoErr)
+		}
+		// 占位记录已被清理任务回收时不能再复活，按创建失败处理
+		if repoErr := s.postRepo.CompleteImageUpload(ctx, tx, post.ID); repoErr != nil {
+			return fmt.Errorf("标记帖子图片上传完成失败: %w", repoErr)
+		}
+		post.ImageUploadPending = false
+
+		event, finishErr := s.finishPostCreation(ctx, tx, post, detail, images, quotedPost, compliance)
+		if finishErr != nil {
+			return finishErr
+		}
+		auditEvent = event
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("帖子图片上传后完成创建的事务失败，回滚占位帖子", zap.Error(err), zap.Uint64("postID", post.ID))
+		s.compensatePendingPost(post.ID, images)
+		return nil, err
+	}
+	return auditEvent, nil
+}
+
+// uploadPendingImages 按占位记录中预先生成的对象键并发上传图片，并发数受 constant.ImageUploadConcurrency 限制。
+// - compressed 中不为 nil 的元素为压缩后的内容，上传到 ObjectKey；记录了 OriginalObjectKey 的图片再额外上传一份原图。
+// - directKeys 中的暂存对象在 COS 服务端复制到 images[len(imageFiles)+j] 的对象键，与上传共用并发限制。
+// - 每个任务只写入自己下标对应的 images[i]，结果顺序与输入一致，DisplayOrder 不受完成先后影响。
+// - 任一张失败即取消其余上传并返回第一个错误；已上传的对象由调用方按占位记录中的全部对象键统一清理。
+func (s *postService) uploadPendingImages(ctx context.Context, images []*entities.PostDetailImage, imageFiles []*multipart.FileHeader, contentTypes []string, compressed [][]byte, directKeys []string) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(constant.ImageUploadConcurrency)
+	for i, fileHeader := range imageFiles {
+		g.Go(func() error {
+			// 前面的任务已失败或请求已取消时不再发起新的上传
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			// 使用文件头识别出的真实类型，不信任客户端提交的 Content-Type
+			imageURL, err := s.uploadImageObject(gctx, images[i].ObjectKey, fileHeader, compressed[i], contentTypes[i])
+			if err != nil {
+				return err
+			}
+			images[i].ImageURL = imageURL
+
+			if images[i].OriginalObjectKey != "" {
+				originalURL, err := s.uploadImageObject(gctx, images[i].OriginalObjectKey, fileHeader, nil, contentTypes[i])
+				if err != nil {
+					return err
+				}
+				images[i].OriginalImageURL = originalURL
+			}
+			return nil
+		})
+	}
+	for j, sourceKey := range directKeys {
+		img := images[len(imageFiles)+j]
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			imageURL, err := s.cosClient.CopyObject(gctx, sourceKey, img.ObjectKey)
+			if err != nil {
+				return fmt.Errorf("复制直传图片 %s 失败: %w", sourceKey, err)
+			}
+			img.ImageURL = imageURL
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// uploadImageObject 将单张图片上传到指定对象键，data 不为 nil 时上传 data（压缩后的内容），否则上传原文件。
+func (s *postService) uploadImageObject(ctx context.Context, objectKey string, fileHeader *multipart.FileHeader, data []byte, contentType string) (string, error) {
+	var reader io.Reader
+	size := fileHeader.Size
+	if data != nil {
+		reader, size = bytes.NewReader(data), int64(len(data))
+	} else {
+		file, err := fileHeader.Open()
+		if err != nil {
+			s.logger.Error("打开图片文件以上传失败",
+				zap.String("filename", fileHeader.Filename),
+				zap.Error(err))
+			return "", fmt.Errorf("打开图片文件 %s 失败: %w", fileHeader.Filename, err)
+		}
+		defer file.Close() // 在 UploadFile 使用完文件后关闭它。
+		reader = file
+	}
+
+	imageURL, err := s.cosClient.UploadFile(ctx, objectKey, reader, size, contentType)
+	if err != nil {
+		s.logger.Error("上传图片到 COS 失败",
+			zap.String("filename", fileHeader.Filename),
+			zap.String("objectKey", objectKey),
+			zap.Error(err))
+		return "", fmt.Errorf("上传图片 %s 到 COS 失败: %w", fileHeader.Filename, err)
+	}
+	s.logger.Info("成功上传图片到 COS",
+		zap.String("filename", fileHeader.Filename),
+		zap.String("objectKey", objectKey),
+		zap.Int64("size", size),
+		zap.String("imageURL", imageURL))
+	return imageURL, nil
+}
+
+// compensatePendingPost 创建失败时同步回滚占位帖子：先删除全部对象键对应的 COS 对象，再物理删除占位记录。
+// - 使用独立的上下文，请求被取消后补偿依然执行；未上传的对象键删除时视为成功（COS 删除不存在的对象返回 204）。
+// - 任一对象删除失败时保留占位记录，由上传清理任务凭记录中的对象键重试，避免文件失去最后的引用。
+func (s *postService) compensatePendingPost(postID uint64, images []*entities.PostDetailImage) {
+	ctx, cancel := context.WithTimeout(context.Background(), constant.PostUploadCompensateTimeout)
+	defer cancel()
+
+	for _, objectKey := range imageObjectKeys(images) {
+		if err := s.cosClient.DeleteObject(ctx, objectKey); err != nil {
+			s.logger.Error("回滚占位帖子时删除 COS 对象失败，保留占位记录等待清理任务重试",
+				zap.Error(err), zap.Uint64("postID", postID), zap.String("objectKey", objectKey))
+			return
+		}
+	}
+	if err := s.postRepo.PurgeUploadPendingPost(ctx, postID); err != nil && !errors.Is(err, commonerrors.ErrRepoNotFound) {
+		// COS 对象已删除，遗留的占位记录不可见，由清理任务再次删除
+		s.logger.Error("回滚占位帖子时删除数据库记录失败", zap.Error(err), zap.Uint64("postID", postID))
+		return
+	}
+	s.logger.Warn("帖子创建失败，已回滚占位记录与已上传的图片", zap.Uint64("postID", postID), zap.Int("images", len(images)))
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+)
+
+// LikePost 实现帖子点赞。
+func (s *postService) LikePost(ctx context.Context, postID uint64, userID string) (*vo.PostLikeVO, error) {
+	post, err := s.getPostForLike(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != enums.Approved {
+		return nil, commonerrors.ErrRepoNotFound
+	}
+
+	changed, likeCount, err := s.postLikeRepo.AddLike(ctx, postID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("点赞失败: %w", err)
+	}
+	if !changed {
+		s.logger.Debug("用户已点赞过该帖子，忽略重复点赞", zap.Uint64("postID", postID), zap.String("userID", userID))
+	}
+	return &vo.PostLikeVO{PostID: postID, Liked: true, LikeCount: likeCount}, nil
+}
+
+// UnlikePost 实现取消点赞。
+// - 不要求帖子仍处于审核通过状态，帖子被驳回后用户依然可以撤回自己的点赞。
+func (s *postService) UnlikePost(ctx context.Context, postID uint64, userID string) (*vo.PostLikeVO, error) {
+	if _, err := s.getPostForLike(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	changed, likeCount, err := s.postLikeRepo.RemoveLike(ctx, postID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("取消点赞失败: %w", err)
+	}
+	if !changed {
+		s.logger.Debug("用户未点赞该帖子，忽略取消点赞", zap.Uint64("postID", postID), zap.String("userID", userID))
+	}
+	return &vo.PostLikeVO{PostID: postID, Liked: false, LikeCount: likeCount}, nil
+}
+
+// getPostForLike 获取点赞操作的目标帖子，不存在时返回 commonerrors.ErrRepoNotFound。
+func (s *postService) getPostForLike(ctx context.Context, postID uint64) (*entities.Post, error) {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("点赞时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子失败: %w", err)
+	}
+	return post, nil
+}
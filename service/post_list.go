@@ -2,14 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/myErrors"
 	// 确保以下包路径与你的项目结构一致
 	"github.com/Xushengqwer/post_service/repo/mysql" // 假设 PostRepository 定义在此
+	"github.com/Xushengqwer/post_service/repo/redis"
 
+	"github.com/Xushengqwer/go-common/commonerrors"
 	"github.com/Xushengqwer/go-common/core" // ZapLogger 等核心组件
+	"github.com/Xushengqwer/go-common/models/enums"
 	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
 	"github.com/Xushengqwer/post_service/models/vo"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 // PostListService 定义了与获取帖子列表相关的服务接口。
@@ -22,8 +35,9 @@ type PostListService interface {
 
 	// GetPostsByTimeline 根据查询参数获取最新的帖子时间线列表（游标查询）。
 	// - queryDTO: 包含所有查询条件和分页游标的DTO。
+	// - 匿名用户、无游标、无筛选条件的首页请求在启用首页缓存时读取 Redis 缓存，未命中时同一 Key 的并发请求只查库一次。
 	// - 返回: 包含帖子列表和下一页游标的VO，以及可能发生的错误。
-	GetPostsByTimeline(ctx context.Context, queryDTO *dto.TimelineQueryDTO) (*vo.PostTimelinePageVO, error)
+	GetPostsByTimeline(ctx context.Context, queryDTO *dto.TimelineQueryDTO) (*vo.PostTimelineCardPageVO, error)
 
 	// ListPostsByUserID 获取指定用户发布的帖子列表（游标分页）。
 	// - req: 包含 userID, 可选的游标 (cursor), 以及每页数量 (pageSize) 的DTO。
@@ -31,19 +45,137 @@ type PostListService interface {
 	// - 调用仓库层实现具体的游标查询逻辑。
 	// - 将查询结果转换为前端展示所需的VO列表。
 	ListPostsByUserID(ctx context.Context, req *dto.ListPostsByUserIDRequest) (*vo.ListHotPostsByCursorResponse, error)
+
+	// ListPostsByAuthors 获取多个作者已审核通过的帖子，按时间线混合排序（游标分页）。
+	// - 用于推荐/关注流等需要一次查询多个作者最新帖子的场景。
+	// - authorIDs 会去除空值与重复值；去重后为空时直接返回空列表。
+	// - cursor 为 nil 表示首次加载；返回结构与 GetPostsByTimeline 一致。
+	ListPostsByAuthors(ctx context.Context, authorIDs []string, cursor *dto.PostTimelineCursor, pageSize int) (*vo.PostTimelinePageVO, error)
+
+	// GetPostReferences 获取转发/引用了指定帖子的已审核通过帖子，按时间线倒序（游标分页）。
+	// - 原帖不存在、已删除或未审核通过时返回 commonerrors.ErrRepoNotFound。
+	// - 已删除的转发帖不会出现在结果中；cursor 为 nil 表示首次加载，返回结构与 GetPostsByTimeline 一致。
+	GetPostReferences(ctx context.Context, postID uint64, cursor *dto.PostTimelineCursor, pageSize int) (*vo.PostTimelinePageVO, error)
+
+	// SearchPosts 按关键词全文检索已审核通过的帖子（标题或正文命中），按相关性排序并游标分页。
+	// - keyword 按空白与全文检索运算符拆分为词，每个词都必须命中；拆分后为空时返回 myErrors.ErrInvalidSearchKeyword。
+	// - cursor 为上一页响应的 next_cursor（结果偏移量），nil 表示首次加载；最多翻到 constant.SearchMaxResults 条。
+	// - viewer 用于投放定向过滤，与时间线一致。
+	SearchPosts(ctx context.Context, keyword string, cursor *uint64, pageSize int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error)
+
+	// GetPostsByViewCount 获取按浏览量倒序排列的全部已审核通过帖子（非实时热榜），以 (浏览量, 帖子ID) 复合游标分页。
+	// - 浏览量取 MySQL 中的持久化值，由定时任务同步，不反映最近的实时浏览。
+	// - cursor 为 nil 表示首次加载；viewer 用于投放定向过滤，与时间线一致。
+	GetPostsByViewCount(ctx context.Context, cursor *dto.PostViewCountCursor, pageSize int, viewer *dto.ViewerAttributes) (*vo.PopularPostsPageVO, error)
 }
 
 // postListService 提供了获取帖子列表的服务。
 type postListService struct {
-	logger   *core.ZapLogger
-	postRepo mysql.PostRepository // 使用接口类型的仓库依赖
+	logger            *core.ZapLogger
+	postRepo          mysql.PostRepository            // 使用接口类型的仓库依赖
+	imageRepo         mysql.PostDetailImageRepository // 批量查询列表帖子的首图作为默认封面
+	coverSvc          CoverExperimentService          // 公开信息流按用户分配 A/B 实验封面
+	postViewRepo      redis.PostViewRepository        // 读取列表帖子的实时浏览量
+	realtimeViewCount bool                            // 是否用 Redis 实时浏览量覆盖 MySQL 值
+	postCache         redis.PostReadCache             // 时间线首页缓存
+	timelineCacheCfg  config.TimelineCacheConfig
+	timelineGroup     singleflight.Group // 首页缓存未命中时合并同一 Key 的并发回源
 }
 
 // NewPostListService 创建一个新的 PostListService 实例。
-func NewPostListService(logger *core.ZapLogger, postRepo mysql.PostRepository) PostListService {
+// - realtimeViewCount 为 false 或 postViewRepo 为 nil 时，列表中的浏览量直接使用 MySQL 中的持久化值。
+// - timelineCacheCfg.Enabled 为 false 或 postCache 为 nil 时不启用时间线首页缓存。
+func NewPostListService(logger *core.ZapLogger, postRepo mysql.PostRepository, imageRepo mysql.PostDetailImageRepository, coverSvc CoverExperimentService, postViewRepo redis.PostViewRepository, realtimeViewCount bool, postCache redis.PostReadCache, timelineCacheCfg config.TimelineCacheConfig) PostListService {
+	if timelineCacheCfg.TTL <= 0 {
+		timelineCacheCfg.TTL = constant.TimelineHomeCacheTTL
+	}
+	timelineCacheCfg.Enabled = timelineCacheCfg.Enabled && postCache != nil
 	return &postListService{
-		logger:   logger,
-		postRepo: postRepo,
+		logger:            logger,
+		postRepo:          postRepo,
+		imageRepo:         imageRepo,
+		coverSvc:          coverSvc,
+		postViewRepo:      postViewRepo,
+		realtimeViewCount: realtimeViewCount && postViewRepo != nil,
+		postCache:         postCache,
+		timelineCacheCfg:  timelineCacheCfg,
+	}
+}
+
+// overlayRealtimeViewCounts 用 Redis 中的实时浏览量覆盖当前页帖子的 MySQL 浏览量，在转换为 VO 之前调用。
+//   - 整页只发起一次 MGET；计数器不存在（未被浏览过或已归档）的帖子保留 MySQL 值，两者都存在时取较大者。
+//   - 读取失败或超过 constant.ListViewCountReadTimeout 时只记录警告并保留 MySQL 值，不影响列表返回。
+//   - 按浏览量排序的列表 (GetPostsByViewCount) 不调用此方法，其排序与游标基于 MySQL 值，覆盖后会出现顺序与游标不一致。
+func (s *postListService) overlayRealtimeViewCounts(ctx context.Context, posts []*entities.Post) {
+	if !s.realtimeViewCount || len(posts) == 0 {
+		return
+	}
+	postIDs := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, constant.ListViewCountReadTimeout)
+	defer cancel()
+	viewCounts, err := s.postViewRepo.GetViewCountsByIDs(readCtx, postIDs)
+	if err != nil {
+		s.logger.Warn("批量读取列表帖子实时浏览量失败，使用 MySQL 中的浏览量", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return
+	}
+	for _, post := range posts {
+		if viewCount, ok := viewCounts[post.ID]; ok && viewCount > post.ViewCount {
+			post.ViewCount = viewCount
+		}
+	}
+}
+
+// firstImageCovers 批量查询帖子的首图 URL，作为没有实验封面的帖子的默认封面。
+// - 整页只查询一次；查询失败只记录警告，列表照常返回，封面为空。
+func (s *postListService) firstImageCovers(ctx context.Context, postIDs []uint64) map[uint64]string {
+	if len(postIDs) == 0 {
+		return nil
+	}
+	images, err := s.imageRepo.GetFirstImagesByPostIDs(ctx, postIDs)
+	if err != nil {
+		s.logger.Warn("批量获取列表帖子首图失败，本次列表不返回默认封面", zap.Error(err), zap.Int("posts", len(postIDs)))
+		return nil
+	}
+	covers := make(map[uint64]string, len(images))
+	for postID, image := range images {
+		covers[postID] = image.ImageURL
+	}
+	return covers
+}
+
+// fillDefaultCovers 为尚未分配实验封面的帖子填充首图封面，无图帖子的封面保持为空；需在 AssignCovers 之后调用。
+func (s *postListService) fillDefaultCovers(ctx context.Context, posts []*vo.PostResponse) {
+	postIDs := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		if post.CoverImageURL == "" {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	covers := s.firstImageCovers(ctx, postIDs)
+	for _, post := range posts {
+		if post.CoverImageURL == "" {
+			post.CoverImageURL = covers[post.ID]
+		}
+	}
+}
+
+// fillDefaultCardCovers 与 fillDefaultCovers 相同，作用于公开信息流的帖子卡片。
+func (s *postListService) fillDefaultCardCovers(ctx context.Context, cards []*vo.PostCardVO) {
+	postIDs := make([]uint64, 0, len(cards))
+	for _, card := range cards {
+		if card.CoverImageURL == "" {
+			postIDs = append(postIDs, card.ID)
+		}
+	}
+	covers := s.firstImageCovers(ctx, postIDs)
+	for _, card := range cards {
+		if card.CoverImageURL == "" {
+			card.CoverImageURL = covers[card.ID]
+		}
 	}
 }
 
@@ -60,6 +192,7 @@ func (s *postListService) GetUserPosts(ctx context.Context, userID string, query
 		queryDTO.OfficialTag,
 		queryDTO.Title,
 		queryDTO.Status,
+		queryDTO.IsDraft,
 		offset,
 		limit,
 	)
@@ -74,7 +207,9 @@ func (s *postListService) GetUserPosts(ctx context.Context, userID string, query
 		zap.Int64("totalCount", totalCount))
 
 	// 2. 将 entities.Post 列表转换为 vo.PostResponse 列表 ，使用我们在VO包定义的辅助转换函数。
+	s.overlayRealtimeViewCounts(ctx, posts)
 	postResponses := vo.MapPostsToPostResponsesVO(posts)
+	s.fillDefaultCovers(ctx, postResponses)
 
 	// 3. 构建并返回响应 VO
 	responseVO := &vo.ListUserPostPageVO{
@@ -85,8 +220,76 @@ func (s *postListService) GetUserPosts(ctx context.Context, userID string, query
 	return responseVO, nil
 }
 
-// GetPostsByTimeline 根据查询参数获取帖子时间线列表。
-func (s *postListService) GetPostsByTimeline(ctx context.Context, queryDTO *dto.TimelineQueryDTO) (*vo.PostTimelinePageVO, error) {
+// GetPostsByTimeline 根据查询参数获取帖子时间线列表，可缓存的首页请求优先读取首页缓存。
+//   - 缓存读写失败只记录警告并直接查库，不影响结果。
+//   - singleflight 的结果由并发请求共享，每个请求拿到的是页对象的浅拷贝；控制器只替换 Posts 切片而不修改卡片本身，共享是安全的。
+func (s *postListService) GetPostsByTimeline(ctx context.Context, queryDTO *dto.TimelineQueryDTO) (*vo.PostTimelineCardPageVO, error) {
+	variant, cacheable := s.timelineHomeCacheVariant(queryDTO)
+	if !cacheable {
+		return s.getPostsByTimeline(ctx, queryDTO)
+	}
+
+	page, err := s.postCache.GetTimelineHomePage(ctx, variant)
+	if err == nil {
+		return page, nil
+	}
+	if !errors.Is(err, myErrors.ErrCacheMiss) {
+		s.logger.Warn("读取时间线首页缓存失败，直接查库", zap.Error(err), zap.String("variant", variant))
+	}
+
+	// 缓存击穿保护：同一 Key 的并发未命中只有一个请求查库并回填缓存；
+	// 查询不受发起请求的客户端断开影响，否则其他等待同一结果的请求会一起失败
+	result, err, shared := s.timelineGroup.Do(variant, func() (interface{}, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		page, err := s.getPostsByTimeline(loadCtx, queryDTO)
+		if err != nil {
+			return nil, err
+		}
+		if setErr := s.postCache.SetTimelineHomePage(loadCtx, variant, page, s.timelineCacheCfg.TTL); setErr != nil {
+			s.logger.Warn("写入时间线首页缓存失败", zap.Error(setErr), zap.String("variant", variant))
+		}
+		return page, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	page = result.(*vo.PostTimelineCardPageVO)
+	if shared {
+		copied := *page
+		page = &copied
+	}
+	return page, nil
+}
+
+// timelineHomeCacheVariant 判断时间线请求是否可以使用首页缓存，可以时返回区分缓存 Key 的变体 "{pageSize}:{地区}:{是否折叠}"。
+// - 只有匿名用户、无游标、无任何筛选条件的请求可缓存；地区参与投放定向过滤，因此计入 Key，超长的地区不缓存。
+// - 等级、标签不为空的访客可能看到定向投放的帖子，不缓存。
+func (s *postListService) timelineHomeCacheVariant(queryDTO *dto.TimelineQueryDTO) (string, bool) {
+	if !s.timelineCacheCfg.Enabled {
+		return "", false
+	}
+	if queryDTO.LastPostID != nil || queryDTO.LastCreatedAt != nil || queryDTO.OfficialTag != nil || len(queryDTO.AuthorIDs) > 0 {
+		return "", false
+	}
+	if (queryDTO.Title != nil && *queryDTO.Title != "") || (queryDTO.AuthorUsername != nil && *queryDTO.AuthorUsername != "") {
+		return "", false
+	}
+	region := ""
+	if viewer := queryDTO.Viewer; viewer != nil {
+		if viewer.UserID != "" || viewer.Level > 0 || len(viewer.Tags) > 0 || len(viewer.Region) > constant.TimelineHomeCacheMaxRegionLen {
+			return "", false
+		}
+		region = viewer.Region
+	}
+	collapse := 0
+	if queryDTO.CollapseSimilar {
+		collapse = 1
+	}
+	return fmt.Sprintf("%d:%s:%d", queryDTO.PageSize, region, collapse), true
+}
+
+// getPostsByTimeline 查库获取帖子时间线列表。
+func (s *postListService) getPostsByTimeline(ctx context.Context, queryDTO *dto.TimelineQueryDTO) (*vo.PostTimelineCardPageVO, error) {
 	s.logger.Info("服务层 GetPostsByTimeline: 开始按时间线获取帖子", zap.Any("queryDTO", queryDTO))
 
 	// 1. 调用仓库层获取数据
@@ -102,18 +305,39 @@ func (s *postListService) GetPostsByTimeline(ctx context.Context, queryDTO *dto.
 		zap.Any("nextPostID", nextPostID),
 	)
 
-	// 2. 将 entities.Post 列表转换为相应的 VO 列表 -----[]*vo.PostResponse:
-	postItems := vo.MapPostsToPostResponsesVO(posts)
-
-	// 3. 构建并返回响应 VO
-	//    确保 Posts 字段的类型与转换结果一致。
-	responseVO := &vo.PostTimelinePageVO{
-		Posts:         postItems,
+	// 2. 公开时间线只返回精简的帖子卡片（不含审核状态与原因），并为开启了 A/B 封面实验的帖子分配封面，其余带图帖子以首图为封面
+	//    （在折叠前分配，被折叠的帖子展开后同样展示封面）
+	s.overlayRealtimeViewCounts(ctx, posts)
+	pageVO := &vo.PostTimelineCardPageVO{
+		Posts:         vo.MapPostsToCardVO(posts),
 		NextCreatedAt: nextCreatedAt,
 		NextPostID:    nextPostID,
 	}
+	s.coverSvc.AssignCardCovers(ctx, pageVO.Posts, viewerUserID(queryDTO.Viewer))
+	s.fillDefaultCardCovers(ctx, pageVO.Posts)
 
-	return responseVO, nil
+	// 3. 按需折叠当前页内容相似的帖子；游标仍为仓库层返回的最后一条，翻页不受影响
+	if queryDTO.CollapseSimilar {
+		pageVO.Posts = collapseSimilarPosts(posts, pageVO.Posts)
+	}
+	return pageVO, nil
+}
+
+// viewerUserID 返回当前访问用户的ID，viewer 为 nil 时按匿名用户处理。
+func viewerUserID(viewer *dto.ViewerAttributes) string {
+	if viewer == nil {
+		return ""
+	}
+	return viewer.UserID
+}
+
+// buildPostTimelinePageVO 将时间线查询结果转换为分页响应 VO，供多作者时间线与引用列表复用。
+func buildPostTimelinePageVO(posts []*entities.Post, nextCreatedAt *time.Time, nextPostID *uint64) *vo.PostTimelinePageVO {
+	return &vo.PostTimelinePageVO{
+		Posts:         vo.MapPostsToPostResponsesVO(posts),
+		NextCreatedAt: nextCreatedAt,
+		NextPostID:    nextPostID,
+	}
 }
 
 // ListPostsByUserID 实现获取指定用户的帖子列表的逻辑（游标分页）。
@@ -123,7 +347,14 @@ func (s *postListService) ListPostsByUserID(ctx context.Context, req *dto.ListPo
 		zap.Any("cursor", req.Cursor),
 		zap.Int("pageSize", req.PageSize))
 
-	posts, nextCursor, err := s.postRepo.GetPostsByUserIDCursor(ctx, req.UserID, req.Cursor, req.PageSize)
+	// 作者本人可以看到自己全部状态的帖子（可按状态筛选）；他人主页只暴露已审核通过的帖子
+	statusFilter := req.Status
+	if req.ViewerID == "" || req.ViewerID != req.UserID {
+		approved := enums.Approved
+		statusFilter = &approved
+	}
+
+	posts, nextCursor, err := s.postRepo.GetPostsByUserIDCursor(ctx, req.UserID, statusFilter, req.Cursor, req.PageSize)
 	if err != nil {
 		s.logger.Error("服务层 ListPostsByUserID: 调用仓库 GetPostsByUserIDCursor 失败", zap.Error(err), zap.String("userID", req.UserID))
 		return nil, fmt.Errorf("获取用户帖子列表 (游标) 失败: %w", err)
@@ -135,7 +366,9 @@ func (s *postListService) ListPostsByUserID(ctx context.Context, req *dto.ListPo
 		zap.Any("nextCursor", nextCursor))
 
 	// 将 entities.Post 列表转换为相应的 VO 列表 -----[]*vo.PostResponse:
+	s.overlayRealtimeViewCounts(ctx, posts)
 	postResponses := vo.MapPostsToPostResponsesVO(posts)
+	s.fillDefaultCovers(ctx, postResponses)
 	// 构造最终的响应结构体。
 	response := &vo.ListHotPostsByCursorResponse{
 		Posts:      postResponses,
@@ -144,3 +377,153 @@ func (s *postListService) ListPostsByUserID(ctx context.Context, req *dto.ListPo
 
 	return response, nil
 }
+
+// ListPostsByAuthors 实现多作者帖子时间线查询。
+func (s *postListService) ListPostsByAuthors(ctx context.Context, authorIDs []string, cursor *dto.PostTimelineCursor, pageSize int) (*vo.PostTimelinePageVO, error) {
+	// 1. 规整作者列表：去空、去重，避免无意义地放大 IN 列表
+	seen := make(map[string]struct{}, len(authorIDs))
+	uniqueAuthorIDs := make([]string, 0, len(authorIDs))
+	for _, authorID := range authorIDs {
+		authorID = strings.TrimSpace(authorID)
+		if authorID == "" {
+			continue
+		}
+		if _, ok := seen[authorID]; ok {
+			continue
+		}
+		seen[authorID] = struct{}{}
+		uniqueAuthorIDs = append(uniqueAuthorIDs, authorID)
+	}
+	if len(uniqueAuthorIDs) == 0 {
+		return buildPostTimelinePageVO([]*entities.Post{}, nil, nil), nil
+	}
+
+	s.logger.Info("服务层 ListPostsByAuthors: 开始按多作者获取帖子时间线",
+		zap.Int("authorCount", len(uniqueAuthorIDs)),
+		zap.Any("cursor", cursor),
+		zap.Int("pageSize", pageSize))
+
+	// 2. 调用仓库层查询（作者过多时仓库层会分批查询并归并）
+	posts, nextCreatedAt, nextPostID, err := s.postRepo.GetPostsByAuthorsTimeline(ctx, uniqueAuthorIDs, cursor, pageSize)
+	if err != nil {
+		s.logger.Error("服务层 ListPostsByAuthors: 调用仓库 GetPostsByAuthorsTimeline 失败", zap.Error(err), zap.Int("authorCount", len(uniqueAuthorIDs)))
+		return nil, fmt.Errorf("获取多作者帖子列表失败: %w", err)
+	}
+
+	// 3. 转换为响应 VO（与单条件时间线复用同一转换逻辑）
+	s.overlayRealtimeViewCounts(ctx, posts)
+	pageVO := buildPostTimelinePageVO(posts, nextCreatedAt, nextPostID)
+	s.fillDefaultCovers(ctx, pageVO.Posts)
+	return pageVO, nil
+}
+
+// GetPostReferences 实现帖子被引用列表的查询。
+func (s *postListService) GetPostReferences(ctx context.Context, postID uint64, cursor *dto.PostTimelineCursor, pageSize int) (*vo.PostTimelinePageVO, error) {
+	// 1. 原帖必须仍然公开可见，否则按不存在处理，避免通过引用列表探测已下架的帖子
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("服务层 GetPostReferences: 获取原帖失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取原帖失败: %w", err)
+	}
+	if post.Status != enums.Approved {
+		return nil, commonerrors.ErrRepoNotFound
+	}
+
+	// 2. 按 quoted_post_id 反向查询转发帖
+	posts, nextCreatedAt, nextPostID, err := s.postRepo.GetQuotingPostsTimeline(ctx, postID, cursor, pageSize)
+	if err != nil {
+		s.logger.Error("服务层 GetPostReferences: 调用仓库 GetQuotingPostsTimeline 失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子引用列表失败: %w", err)
+	}
+	s.overlayRealtimeViewCounts(ctx, posts)
+	pageVO := buildPostTimelinePageVO(posts, nextCreatedAt, nextPostID)
+	s.fillDefaultCovers(ctx, pageVO.Posts)
+	return pageVO, nil
+}
+
+// SearchPosts 实现帖子全文检索。
+// - 相关性排序不具备稳定的键值游标，游标使用结果偏移量；同一关键词翻页期间若有新帖子命中，可能出现少量重复或遗漏。
+func (s *postListService) SearchPosts(ctx context.Context, keyword string, cursor *uint64, pageSize int, viewer *dto.ViewerAttributes) (*vo.ListHotPostsByCursorResponse, error) {
+	booleanQuery := buildBooleanSearchQuery(keyword)
+	if booleanQuery == "" {
+		return nil, myErrors.ErrInvalidSearchKeyword
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := 0
+	if cursor != nil {
+		offset = int(min(*cursor, uint64(constant.SearchMaxResults)))
+	}
+	if offset >= constant.SearchMaxResults {
+		return &vo.ListHotPostsByCursorResponse{Posts: []*vo.PostResponse{}}, nil
+	}
+
+	// 多取一条用于判断是否还有下一页，且不超过最大可翻页结果数
+	limit := min(pageSize+1, constant.SearchMaxResults-offset)
+	posts, err := s.postRepo.SearchPostsFullText(ctx, booleanQuery, viewer, offset, limit)
+	if err != nil {
+		s.logger.Error("服务层 SearchPosts: 调用仓库 SearchPostsFullText 失败", zap.Error(err), zap.String("keyword", keyword))
+		return nil, fmt.Errorf("搜索帖子失败: %w", err)
+	}
+
+	var nextCursor *uint64
+	if len(posts) > pageSize {
+		posts = posts[:pageSize]
+		next := uint64(offset + pageSize)
+		nextCursor = &next
+	}
+	s.overlayRealtimeViewCounts(ctx, posts)
+	postResponses := vo.MapPostsToPostResponsesVO(posts)
+	s.coverSvc.AssignCovers(ctx, postResponses, viewerUserID(viewer))
+	s.fillDefaultCovers(ctx, postResponses)
+	return &vo.ListHotPostsByCursorResponse{
+		Posts:      postResponses,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetPostsByViewCount 实现按浏览量倒序的公开帖子列表。
+func (s *postListService) GetPostsByViewCount(ctx context.Context, cursor *dto.PostViewCountCursor, pageSize int, viewer *dto.ViewerAttributes) (*vo.PopularPostsPageVO, error) {
+	posts, nextCursor, err := s.postRepo.GetPostsByViewCountCursor(ctx, cursor, viewer, pageSize)
+	if err != nil {
+		s.logger.Error("服务层 GetPostsByViewCount: 调用仓库 GetPostsByViewCountCursor 失败", zap.Error(err), zap.Any("cursor", cursor))
+		return nil, fmt.Errorf("按浏览量获取帖子列表失败: %w", err)
+	}
+
+	pageVO := &vo.PopularPostsPageVO{Posts: vo.MapPostsToPostResponsesVO(posts)}
+	if nextCursor != nil {
+		pageVO.NextViewCount = &nextCursor.ViewCount
+		pageVO.NextPostID = &nextCursor.PostID
+	}
+	s.coverSvc.AssignCovers(ctx, pageVO.Posts, viewerUserID(viewer))
+	s.fillDefaultCovers(ctx, pageVO.Posts)
+	return pageVO, nil
+}
+
+// buildBooleanSearchQuery 将用户输入的关键词转换为 MySQL BOOLEAN MODE 查询表达式。
+// - 按空白与 constant.SearchKeywordOperators 中的运算符拆分，运算符本身被丢弃，避免用户输入改变查询语义或导致语法错误。
+// - 每个词以 +"词" 的形式出现：必须命中，且作为短语交给 ngram 分词器匹配；重复的词只保留一次。
+// - 没有有效的词时返回空字符串。
+func buildBooleanSearchQuery(keyword string) string {
+	terms := strings.FieldsFunc(keyword, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(constant.SearchKeywordOperators, r)
+	})
+	seen := make(map[string]struct{}, len(terms))
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		key := strings.ToLower(term)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		parts = append(parts, `+"`+term+`"`)
+		if len(parts) >= constant.SearchKeywordMaxTerms {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
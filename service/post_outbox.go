@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// writeOutboxEvent 在事务 tx 内写入 build 构建的发件箱记录。
+// - 未配置 Kafka 时不写入，返回 nil 记录，行为与不发送事件一致。
+// - 构建或写入失败时返回错误，由调用方回滚整个事务，保证不会出现“数据已提交但事件丢失”。
+func writeOutboxEvent(ctx context.Context, tx *gorm.DB, kafkaSvc *producer.KafkaProducer, outboxRepo mysql.OutboxRepository, build func() (*entities.OutboxEvent, error)) (*entities.OutboxEvent, error) {
+	if kafkaSvc == nil {
+		return nil, nil
+	}
+	event, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if err := outboxRepo.CreateEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// relayOutboxEventAsync 事务提交后立即在后台投递发件箱记录，成功后删除记录。
+// - 投递失败（含本地重试）时保留记录，由发件箱补偿任务在 constant.OutboxRelayDelay 之后重新投递。
+// - 删除记录失败时补偿任务会再投递一次，下游按 EventID 幂等处理。
+func relayOutboxEventAsync(kafkaSvc *producer.KafkaProducer, outboxRepo mysql.OutboxRepository, logger *core.ZapLogger, event *entities.OutboxEvent, postID uint64) {
+	if event == nil {
+		return
+	}
+	go func() {
+		bgCtx := context.Background() // 为后台 goroutine 创建新的上下文
+		if err := kafkaSvc.SendOutboxEvent(bgCtx, event); err != nil {
+			logger.Error("投递 Kafka 事件失败，事件保留在发件箱中等待补偿任务重试",
+				zap.Error(err), zap.Uint64("post_id", postID), zap.String("topic", event.Topic), zap.Uint64("outbox_id", event.ID))
+			return
+		}
+		logger.Info("成功投递 Kafka 事件", zap.Uint64("post_id", postID), zap.String("topic", event.Topic))
+		if err := outboxRepo.DeleteEvent(bgCtx, event.ID); err != nil {
+			logger.Warn("删除已投递的发件箱记录失败，补偿任务将重复投递一次", zap.Error(err), zap.Uint64("outbox_id", event.ID))
+		}
+	}()
+}
+
+// writeOutboxEvent 见包级函数 writeOutboxEvent。
+func (s *postService) writeOutboxEvent(ctx context.Context, tx *gorm.DB, build func() (*entities.OutboxEvent, error)) (*entities.OutboxEvent, error) {
+	return writeOutboxEvent(ctx, tx, s.kafkaSvc, s.outboxRepo, build)
+}
+
+// relayOutboxEventAsync 见包级函数 relayOutboxEventAsync。
+func (s *postService) relayOutboxEventAsync(event *entities.OutboxEvent, postID uint64) {
+	relayOutboxEventAsync(s.kafkaSvc, s.outboxRepo, s.logger, event, postID)
+}
+
+// writeOutboxEvent 见包级函数 writeOutboxEvent。
+func (s *postAdminService) writeOutboxEvent(ctx context.Context, tx *gorm.DB, build func() (*entities.OutboxEvent, error)) (*entities.OutboxEvent, error) {
+	return writeOutboxEvent(ctx, tx, s.kafkaSvc, s.outboxRepo, build)
+}
+
+// relayOutboxEventAsync 见包级函数 relayOutboxEventAsync。
+func (s *postAdminService) relayOutboxEventAsync(event *entities.OutboxEvent, postID uint64) {
+	relayOutboxEventAsync(s.kafkaSvc, s.outboxRepo, s.logger, event, postID)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// PostReadDepthService 定义帖子阅读深度（浏览完成率）统计的接口。
+// - 客户端在阅读过程中上报滚动到的内容百分比，服务端按帖子聚合阅读次数、平均深度与深度分布。
+// - 只统计登录用户；同一用户的同一次阅读 (readID) 只保留最大深度，统计存储在 Redis，不落库。
+type PostReadDepthService interface {
+	// ReportReadDepth 记录用户某次阅读滚动到的深度 (0-100)。
+	// - 帖子不存在或未审核通过时返回 commonerrors.ErrRepoNotFound；匿名用户的上报静默忽略。
+	// - 同一次阅读两次上报间隔小于 constant.ReadDepthReportInterval 时返回 myErrors.ErrReadDepthTooFrequent。
+	ReportReadDepth(ctx context.Context, postID uint64, userID, readID string, depth int) error
+
+	// GetReadDepthStats 查询帖子的阅读深度统计，帖子不存在时返回 commonerrors.ErrRepoNotFound。
+	GetReadDepthStats(ctx context.Context, postID uint64) (*vo.PostReadDepthVO, error)
+}
+
+// postReadDepthService 是 PostReadDepthService 接口的实现。
+type postReadDepthService struct {
+	postRepo      mysql.PostRepository
+	readDepthRepo redis.PostReadDepthRepository
+	logger        *core.ZapLogger
+}
+
+// NewPostReadDepthService 初始化帖子阅读深度统计服务。
+func NewPostReadDepthService(postRepo mysql.PostRepository, readDepthRepo redis.PostReadDepthRepository, logger *core.ZapLogger) PostReadDepthService {
+	return &postReadDepthService{
+		postRepo:      postRepo,
+		readDepthRepo: readDepthRepo,
+		logger:        logger,
+	}
+}
+
+// ReportReadDepth 实现阅读深度上报。
+func (s *postReadDepthService) ReportReadDepth(ctx context.Context, postID uint64, userID, readID string, depth int) error {
+	if userID == "" {
+		return nil
+	}
+	post, err := s.getPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.Status != enums.Approved {
+		return commonerrors.ErrRepoNotFound
+	}
+
+	result, err := s.readDepthRepo.RecordDepth(ctx, postID, userID, readID, depth)
+	if err != nil {
+		return err
+	}
+	if result == redis.ReadDepthThrottled {
+		return myErrors.ErrReadDepthTooFrequent
+	}
+	return nil
+}
+
+// GetReadDepthStats 实现阅读深度统计查询。
+func (s *postReadDepthService) GetReadDepthStats(ctx context.Context, postID uint64) (*vo.PostReadDepthVO, error) {
+	if _, err := s.getPost(ctx, postID); err != nil {
+		return nil, err
+	}
+	stats, err := s.readDepthRepo.GetDepthStats(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 深度 100 单独成桶 (桶序号 100 / 桶宽)，表示读完
+	completedBucket := 100 / constant.ReadDepthBucketSize
+	result := &vo.PostReadDepthVO{
+		PostID:         postID,
+		Reads:          stats.Reads,
+		CompletedReads: stats.Buckets[completedBucket],
+		Distribution:   make([]*vo.ReadDepthBucketVO, 0, completedBucket+1),
+	}
+	if stats.Reads > 0 {
+		result.AverageDepth = float64(stats.SumDepth) / float64(stats.Reads)
+		result.CompletionRate = float64(result.CompletedReads) / float64(stats.Reads)
+	}
+	for bucket := 0; bucket < completedBucket; bucket++ {
+		result.Distribution = append(result.Distribution, &vo.ReadDepthBucketVO{
+			MinDepth: bucket * constant.ReadDepthBucketSize,
+			MaxDepth: min((bucket+1)*constant.ReadDepthBucketSize, 100) - 1,
+			Reads:    stats.Buckets[bucket],
+		})
+	}
+	result.Distribution = append(result.Distribution, &vo.ReadDepthBucketVO{MinDepth: 100, MaxDepth: 100, Reads: result.CompletedReads})
+	return result, nil
+}
+
+// getPost 获取帖子，不存在时返回 commonerrors.ErrRepoNotFound。
+func (s *postReadDepthService) getPost(ctx context.Context, postID uint64) (*entities.Post, error) {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("阅读深度统计时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子失败: %w", err)
+	}
+	return post, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/myErrors"
+)
+
+// ReportPost 实现用户举报帖子。
+func (s *postService) ReportPost(ctx context.Context, postID uint64, reporterID string, reason string) error {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return err
+		}
+		s.logger.Error("举报时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if post.Status != enums.Approved {
+		return commonerrors.ErrRepoNotFound
+	}
+
+	var thresholdEvent *entities.OutboxEvent
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reportCount, repoErr := s.postReportRepo.CreateReport(ctx, tx, &entities.PostReport{
+			PostID:     postID,
+			ReporterID: reporterID,
+			Reason:     strings.TrimSpace(reason),
+		})
+		if repoErr != nil {
+			return repoErr
+		}
+
+		// 举报在帖子行锁下串行写入，只有一次举报会看到举报数恰好等于阈值
+		if reportCount != constant.PostReportReviewThreshold || s.kafkaSvc == nil || !s.kafkaSvc.ReportThresholdEnabled() {
+			return nil
+		}
+		event, outboxErr := s.writeOutboxEvent(ctx, tx, func() (*entities.OutboxEvent, error) {
+			return s.kafkaSvc.NewPostReportThresholdOutboxEvent(&producer.PostReportThresholdEvent{
+				PostID:      postID,
+				Title:       post.Title,
+				AuthorID:    post.AuthorID,
+				ReportCount: reportCount,
+				Threshold:   constant.PostReportReviewThreshold,
+			})
+		})
+		if outboxErr != nil {
+			return outboxErr
+		}
+		thresholdEvent = event
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, myErrors.ErrPostAlreadyReported) {
+			return err
+		}
+		s.logger.Error("举报帖子失败", zap.Error(err), zap.Uint64("postID", postID), zap.String("reporterID", reporterID))
+		return fmt.Errorf("举报帖子失败: %w", err)
+	}
+
+	if thresholdEvent != nil {
+		s.logger.Warn("帖子举报数达到阈值，已提交自动下架审查", zap.Uint64("postID", postID), zap.Int("threshold", constant.PostReportReviewThreshold))
+		s.relayOutboxEventAsync(thresholdEvent, postID)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+)
+
+// GetPostSEO 实时生成帖子的 SEO 元数据。
+// - 只对已审核通过、且匿名访客可见（无投放定向限制）的帖子生成，其余按帖子不存在处理，避免向爬虫泄露受限内容。
+// - 摘要取自正文纯文本；非公开访问策略的帖子不读取正文，以标题代替，避免付费/受限内容出现在 meta 中。
+func (s *postService) GetPostSEO(ctx context.Context, postID uint64) (*vo.PostSEOVO, error) {
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			return nil, err
+		}
+		s.logger.Error("生成 SEO 元数据时获取帖子失败", zap.Error(err), zap.Uint64("postID", postID))
+		return nil, fmt.Errorf("获取帖子失败: %w", err)
+	}
+	if post.Status != enums.Approved {
+		return nil, commonerrors.ErrRepoNotFound
+	}
+	if err := s.checkPostTargeting(ctx, postID, "", nil); err != nil {
+		return nil, err
+	}
+
+	descriptionSource := post.Title
+	if post.AccessPolicy == constant.AccessPolicyPublic {
+		detail, err := s.postDetailRepo.GetPostDetailByPostID(ctx, postID)
+		switch {
+		case err == nil:
+			if text := htmlToPlainText(detail.Content); text != "" {
+				descriptionSource = text
+			}
+		case errors.Is(err, commonerrors.ErrRepoNotFound):
+			// 详情缺失时退回标题
+		default:
+			s.logger.Error("生成 SEO 元数据时获取帖子详情失败", zap.Error(err), zap.Uint64("postID", postID))
+			return nil, fmt.Errorf("获取帖子详情失败: %w", err)
+		}
+	}
+
+	seo := &vo.PostSEOVO{
+		PostID:      post.ID,
+		Title:       post.Title,
+		Description: truncateRunes(descriptionSource, constant.SEODescriptionMaxRunes),
+		Keywords:    buildSEOKeywords(post),
+	}
+	seo.MetaTags = buildSEOMetaTags(seo)
+	return seo, nil
+}
+
+// seoBlockElements 是提取纯文本时需要以空白分隔的块级标签
+var seoBlockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Li: true, atom.Blockquote: true, atom.Pre: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Tr: true, atom.Td: true, atom.Th: true,
+}
+
+// seoSkippedElements 是内容不属于正文、提取纯文本时整体跳过的标签
+var seoSkippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+	atom.Math: true, atom.Frameset: true, atom.Frame: true, atom.Textarea: true,
+	atom.Select: true, atom.Title: true, atom.Head: true,
+}
+
+// htmlToPlainText 提取 HTML 正文中的纯文本并折叠空白。
+// - 文本 token 已由分词器完成实体解码（如 &amp; -> &），输出为未转义的纯文本。
+// - script、style 等标签内的内容不属于正文，一并跳过。
+func htmlToPlainText(content string) string {
+	var (
+		sb        strings.Builder
+		skipDepth int
+	)
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+		switch tt {
+		case html.TextToken:
+			if skipDepth == 0 {
+				sb.WriteString(tok.Data)
+			}
+		case html.StartTagToken:
+			if seoSkippedElements[tok.DataAtom] {
+				skipDepth++
+			} else if tok.DataAtom == atom.Br || tok.DataAtom == atom.Hr {
+				sb.WriteString(" ")
+			}
+		case html.SelfClosingTagToken:
+			if tok.DataAtom == atom.Br || tok.DataAtom == atom.Hr {
+				sb.WriteString(" ")
+			}
+		case html.EndTagToken:
+			if seoSkippedElements[tok.DataAtom] && skipDepth > 0 {
+				skipDepth--
+			} else if seoBlockElements[tok.DataAtom] {
+				// 块级标签闭合时补一个空白，避免相邻段落的文字粘连
+				sb.WriteString(" ")
+			}
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// truncateRunes 将文本截断到最多 maxRunes 个字符，超出时以省略号结尾（省略号计入长度）。
+func truncateRunes(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return strings.TrimRightFunc(string(runes[:maxRunes-1]), unicode.IsSpace) + "…"
+}
+
+// buildSEOKeywords 基于官方标签、标题与作者生成关键词。
+// - 标题按标点与空白拆分为短语，过短或过长的片段被丢弃。
+// - 关键词去重（忽略大小写），最多保留 constant.SEOKeywordsMax 个。
+func buildSEOKeywords(post *entities.Post) []string {
+	keywords := make([]string, 0, constant.SEOKeywordsMax)
+	seen := make(map[string]bool)
+	add := func(kw string) {
+		kw = strings.TrimSpace(kw)
+		key := strings.ToLower(kw)
+		if kw == "" || seen[key] || len(keywords) >= constant.SEOKeywordsMax {
+			return
+		}
+		seen[key] = true
+		keywords = append(keywords, kw)
+	}
+
+	for _, tag := range post.OfficialTags.Tags() {
+		if label, ok := constant.OfficialTagLabels[tag]; ok {
+			add(label)
+		}
+	}
+	phrases := strings.FieldsFunc(post.Title, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	for _, phrase := range phrases {
+		if n := len([]rune(phrase)); n >= constant.SEOKeywordMinRunes && n <= constant.SEOKeywordMaxRunes {
+			add(phrase)
+		}
+	}
+	add(post.AuthorUsername)
+	return keywords
+}
+
+// buildSEOMetaTags 生成可直接嵌入页面 <head> 的标签片段，所有文本均经过 HTML 转义。
+func buildSEOMetaTags(seo *vo.PostSEOVO) string {
+	var sb strings.Builder
+	sb.WriteString("<title>" + html.EscapeString(seo.Title) + "</title>")
+	sb.WriteString(`<meta name="description" content="` + html.EscapeString(seo.Description) + `">`)
+	if len(seo.Keywords) > 0 {
+		sb.WriteString(`<meta name="keywords" content="` + html.EscapeString(strings.Join(seo.Keywords, ",")) + `">`)
+	}
+	return sb.String()
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+)
+
+// contentSimHash 计算帖子标题与正文纯文本的 64 位 SimHash 指纹。
+// - 只保留字母与数字并统一小写，忽略标点、空白与 HTML 标签，转发时常见的排版差异不影响指纹。
+// - 中文没有分词边界，以连续 constant.SimHashShingleRunes 个字符为一个特征；文本短于一个特征时整体作为一个特征。
+// - 没有任何有效字符时返回 0，表示不参与相似折叠。
+func contentSimHash(title string, content string) uint64 {
+	runes := make([]rune, 0, len(title)+len(content))
+	for _, r := range strings.ToLower(title + " " + htmlToPlainText(content)) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			runes = append(runes, r)
+		}
+	}
+	if len(runes) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	addFeature := func(feature []rune) {
+		h := fnv.New64a()
+		h.Write([]byte(string(feature)))
+		sum := h.Sum64()
+		for i := range weights {
+			if sum&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	if len(runes) < constant.SimHashShingleRunes {
+		addFeature(runes)
+	} else {
+		for i := 0; i+constant.SimHashShingleRunes <= len(runes); i++ {
+			addFeature(runes[i : i+constant.SimHashShingleRunes])
+		}
+	}
+
+	var fingerprint uint64
+	for i, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+	return fingerprint
+}
+
+// isSimilarContent 判断两个 SimHash 指纹是否足够接近，任一指纹为 0 时视为不相似。
+func isSimilarContent(a uint64, b uint64) bool {
+	if a == 0 || b == 0 {
+		return false
+	}
+	return bits.OnesCount64(a^b) <= constant.SimilarPostMaxHammingDistance
+}
+
+// collapseSimilarPosts 将一页帖子中内容相似的帖子折叠为一组，只保留组内最先出现的帖子作为代表帖。
+// - posts 与 responses 一一对应；被折叠的帖子放入代表帖的 SimilarPosts，供客户端展开查看。
+// - 只在当前页内比较，每个帖子与已有代表帖逐一比较指纹，页大小有上限，开销可以忽略。
+func collapseSimilarPosts(posts []*entities.Post, responses []*vo.PostCardVO) []*vo.PostCardVO {
+	if len(posts) != len(responses) {
+		return responses
+	}
+	type group struct {
+		fingerprint uint64
+		response    *vo.PostCardVO
+	}
+	groups := make([]*group, 0, len(posts))
+	collapsed := make([]*vo.PostCardVO, 0, len(responses))
+	for i, post := range posts {
+		var matched *group
+		for _, g := range groups {
+			if isSimilarContent(g.fingerprint, post.ContentSimHash) {
+				matched = g
+				break
+			}
+		}
+		if matched != nil {
+			matched.response.SimilarPosts = append(matched.response.SimilarPosts, responses[i])
+			continue
+		}
+		groups = append(groups, &group{fingerprint: post.ContentSimHash, response: responses[i]})
+		collapsed = append(collapsed, responses[i])
+	}
+	return collapsed
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/myErrors"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// PostSnapshotService 定义了帖子指标快照的业务接口。
+// - 快照由定时任务每小时生成，记录活跃帖子的浏览量、点赞数、状态与排行榜排名，供数据分析回溯历史。
+// - 只对活跃帖子（最近有浏览）做快照，并按粒度设置不同的保留期，控制存储量。
+type PostSnapshotService interface {
+	// TakeSnapshots 为 now 所在小时生成一轮小时级快照；now 为零点时额外生成覆盖前一天活跃帖子的日级快照。
+	// - 输出: 本轮写入的快照数量。
+	TakeSnapshots(ctx context.Context, now time.Time) (int, error)
+
+	// PurgeExpiredSnapshots 删除超过保留期的小时级与日级快照。
+	PurgeExpiredSnapshots(ctx context.Context, now time.Time) error
+
+	// ListPostSnapshots 查询单个帖子在时间范围内的历史快照，按快照时间升序。
+	// - 时间范围不合法或跨度超过 constant.SnapshotQueryMaxRange 时返回 myErrors.ErrInvalidTimeRange。
+	ListPostSnapshots(ctx context.Context, postID uint64, req *dto.ListPostSnapshotsRequest) (*vo.ListPostSnapshotsVO, error)
+}
+
+// postSnapshotService 是 PostSnapshotService 接口的实现。
+type postSnapshotService struct {
+	snapshotRepo  mysql.PostSnapshotRepository
+	postBatchRepo mysql.PostBatchOperationsRepository // 批量读取帖子的状态、点赞数与持久化浏览量
+	postViewRepo  redis.PostViewRepository            // 活跃帖子、实时浏览量与排行榜排名
+	cfg           appConfig.SnapshotConfig
+	logger        *core.ZapLogger
+}
+
+// NewPostSnapshotService 是 postSnapshotService 的构造函数。
+// - cfg 中未配置（为 0）的参数退回 constant.Snapshot* 默认值。
+func NewPostSnapshotService(
+	snapshotRepo mysql.PostSnapshotRepository,
+	postBatchRepo mysql.PostBatchOperationsRepository,
+	postViewRepo redis.PostViewRepository,
+	cfg appConfig.SnapshotConfig,
+	logger *core.ZapLogger,
+) PostSnapshotService {
+	if cfg.ActiveWindow <= 0 {
+		cfg.ActiveWindow = constant.SnapshotActiveWindow
+	}
+	if cfg.MaxPostsPerRun <= 0 {
+		cfg.MaxPostsPerRun = constant.SnapshotMaxPostsPerRun
+	}
+	if cfg.HourlyRetentionDays <= 0 {
+		cfg.HourlyRetentionDays = constant.SnapshotHourlyRetentionDays
+	}
+	if cfg.DailyRetentionDays <= 0 {
+		cfg.DailyRetentionDays = constant.SnapshotDailyRetentionDays
+	}
+	return &postSnapshotService{
+		snapshotRepo:  snapshotRepo,
+		postBatchRepo: postBatchRepo,
+		postViewRepo:  postViewRepo,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+// TakeSnapshots 实现一轮快照的生成。
+// - 快照时刻取 now 所在整点，同一轮内所有帖子相同，便于按时间对齐比较。
+func (s *postSnapshotService) TakeSnapshots(ctx context.Context, now time.Time) (int, error) {
+	snapshotAt := now.Truncate(time.Hour)
+	written, err := s.takeSnapshots(ctx, constant.SnapshotGranularityHourly, snapshotAt, now.Add(-s.cfg.ActiveWindow))
+	if err != nil {
+		return written, err
+	}
+	if snapshotAt.Hour() == 0 {
+		daily, err := s.takeSnapshots(ctx, constant.SnapshotGranularityDaily, snapshotAt, now.Add(-constant.SnapshotDailyActiveWindow))
+		written += daily
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// takeSnapshots 为 since 之后活跃的帖子生成一种粒度的快照，分批读取并写入。
+func (s *postSnapshotService) takeSnapshots(ctx context.Context, granularity string, snapshotAt, since time.Time) (int, error) {
+	postIDs, err := s.postViewRepo.GetActivePostIDs(ctx, since, s.cfg.MaxPostsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("获取活跃帖子失败: %w", err)
+	}
+
+	written := 0
+	for start := 0; start < len(postIDs); start += constant.SnapshotBatchSize {
+		batchIDs := postIDs[start:min(start+constant.SnapshotBatchSize, len(postIDs))]
+		snapshots, err := s.buildSnapshots(ctx, granularity, snapshotAt, batchIDs)
+		if err != nil {
+			return written, err
+		}
+		if err := s.snapshotRepo.BatchCreateSnapshots(ctx, snapshots); err != nil {
+			return written, err
+		}
+		written += len(snapshots)
+	}
+	s.logger.Info("帖子指标快照生成完成",
+		zap.String("granularity", granularity),
+		zap.Time("snapshotAt", snapshotAt),
+		zap.Int("activePosts", len(postIDs)),
+		zap.Int("written", written))
+	return written, nil
+}
+
+// buildSnapshots 组装一批帖子的快照：状态与点赞数取 MySQL，浏览量优先取 Redis 实时计数，排名取全站排行榜。
+// - 已删除的帖子不在 GetPostsByIDs 的结果中，自然被跳过。
+func (s *postSnapshotService) buildSnapshots(ctx context.Context, granularity string, snapshotAt time.Time, postIDs []uint64) ([]*entities.PostMetricSnapshot, error) {
+	posts, err := s.postBatchRepo.GetPostsByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取帖子失败: %w", err)
+	}
+	viewCounts, err := s.postViewRepo.GetViewCountsByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取实时浏览量失败: %w", err)
+	}
+	ranks, err := s.postViewRepo.GetPostRanks(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取排行榜排名失败: %w", err)
+	}
+
+	snapshots := make([]*entities.PostMetricSnapshot, 0, len(posts))
+	for _, p := range posts {
+		snapshot := &entities.PostMetricSnapshot{
+			PostID:      p.ID,
+			Granularity: granularity,
+			SnapshotAt:  snapshotAt,
+			ViewCount:   p.ViewCount,
+			LikeCount:   p.LikeCount,
+			Status:      p.Status,
+		}
+		if viewCount, ok := viewCounts[p.ID]; ok {
+			snapshot.ViewCount = viewCount
+		}
+		if rank, ok := ranks[p.ID]; ok {
+			snapshot.Rank = &rank
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// PurgeExpiredSnapshots 实现过期快照的清理。
+func (s *postSnapshotService) PurgeExpiredSnapshots(ctx context.Context, now time.Time) error {
+	retention := map[string]int{
+		constant.SnapshotGranularityHourly: s.cfg.HourlyRetentionDays,
+		constant.SnapshotGranularityDaily:  s.cfg.DailyRetentionDays,
+	}
+	for granularity, days := range retention {
+		deleted, err := s.snapshotRepo.PurgeSnapshotsBefore(ctx, granularity, now.AddDate(0, 0, -days))
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			s.logger.Info("已清理过期帖子指标快照", zap.String("granularity", granularity), zap.Int("retentionDays", days), zap.Int64("deleted", deleted))
+		}
+	}
+	return nil
+}
+
+// ListPostSnapshots 实现单个帖子历史快照的查询。
+func (s *postSnapshotService) ListPostSnapshots(ctx context.Context, postID uint64, req *dto.ListPostSnapshotsRequest) (*vo.ListPostSnapshotsVO, error) {
+	granularity := req.Granularity
+	if granularity == "" {
+		granularity = constant.SnapshotGranularityHourly
+	}
+	end := time.Now()
+	if req.EndTime != nil {
+		end = *req.EndTime
+	}
+	start := end.AddDate(0, 0, -7)
+	if req.StartTime != nil {
+		start = *req.StartTime
+	}
+	if !start.Before(end) || end.Sub(start) > constant.SnapshotQueryMaxRange {
+		return nil, myErrors.ErrInvalidTimeRange
+	}
+
+	// 多取一条用于判断结果是否被截断
+	snapshots, err := s.snapshotRepo.ListPostSnapshots(ctx, postID, granularity, start, end, constant.SnapshotQueryMaxRows+1)
+	if err != nil {
+		return nil, fmt.Errorf("查询帖子(ID: %d)历史快照失败: %w", postID, err)
+	}
+	result := &vo.ListPostSnapshotsVO{
+		PostID:      postID,
+		Granularity: granularity,
+		StartTime:   start,
+		EndTime:     end,
+		Snapshots:   make([]*vo.PostMetricSnapshotVO, 0, min(len(snapshots), constant.SnapshotQueryMaxRows)),
+	}
+	if len(snapshots) > constant.SnapshotQueryMaxRows {
+		snapshots = snapshots[:constant.SnapshotQueryMaxRows]
+		result.Truncated = true
+	}
+	for _, snapshot := range snapshots {
+		result.Snapshots = append(result.Snapshots, &vo.PostMetricSnapshotVO{
+			SnapshotAt: snapshot.SnapshotAt,
+			ViewCount:  snapshot.ViewCount,
+			LikeCount:  snapshot.LikeCount,
+			Status:     snapshot.Status,
+			Rank:       snapshot.Rank,
+		})
+	}
+	return result, nil
+}
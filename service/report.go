@@ -0,0 +1,259 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// ReportService 定义了帖子数据报表的业务接口。
+// - 报表由定时任务周期性生成（CSV 格式，带 UTF-8 BOM 以便 Excel 直接打开），上传 COS 后记录元信息。
+// - 管理后台通过列表接口查看历史报表，并通过下载接口获取文件。
+type ReportService interface {
+	// GenerateReport 按配置的周期与分组维度生成一份报表并上传 COS。
+	// - now 为生成时刻，用于计算统计区间（日报为前一天，周报为上一周）。
+	GenerateReport(ctx context.Context, now time.Time) (*vo.DataReportVO, error)
+
+	// ListReports 分页查询历史报表。
+	ListReports(ctx context.Context, period string, page, pageSize int) (*vo.ListDataReportsVO, error)
+
+	// GetReportDownloadURL 获取指定报表的下载地址。
+	// - 报表不存在时返回 commonerrors.ErrRepoNotFound。
+	GetReportDownloadURL(ctx context.Context, reportID uint64) (string, error)
+}
+
+// CommentCountProvider 批量提供帖子的评论数。
+// - 评论数据由评论服务维护，本服务不存储，报表通过该接口获取。
+// - 返回的 map 中缺少的帖子按 0 条评论处理。
+type CommentCountProvider interface {
+	BatchGetCommentCounts(ctx context.Context, postIDs []uint64) (map[uint64]int64, error)
+}
+
+// reportService 是 ReportService 接口的实现。
+type reportService struct {
+	reportRepo      mysql.DataReportRepository
+	commentProvider CommentCountProvider // 为 nil 时报表的评论数列留空
+	cosClient       dependencies.COSClientInterface
+	cfg             appConfig.ReportConfig
+	logger          *core.ZapLogger
+}
+
+// NewReportService 是 reportService 的构造函数。
+// - commentProvider 可以为 nil（未接入评论服务），此时报表的评论数列留空，其余列不受影响。
+func NewReportService(
+	reportRepo mysql.DataReportRepository,
+	commentProvider CommentCountProvider,
+	cosClient dependencies.COSClientInterface,
+	cfg appConfig.ReportConfig,
+	logger *core.ZapLogger,
+) ReportService {
+	return &reportService{
+		reportRepo:      reportRepo,
+		commentProvider: commentProvider,
+		cosClient:       cosClient,
+		cfg:             cfg,
+		logger:          logger,
+	}
+}
+
+// reportPeriodRange 根据统计周期计算报表区间 [start, end)。
+// - 日报: 前一天 00:00 到当天 00:00。
+// - 周报: 上周一 00:00 到本周一 00:00。
+func reportPeriodRange(period string, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if period == constant.ReportPeriodWeekly {
+		// time.Weekday 中周日为 0，这里换算为“距离本周一的天数”
+		offset := (int(today.Weekday()) + 6) % 7
+		thisMonday := today.AddDate(0, 0, -offset)
+		return thisMonday.AddDate(0, 0, -7), thisMonday
+	}
+	return today.AddDate(0, 0, -1), today
+}
+
+// GenerateReport 实现报表生成逻辑。
+func (s *reportService) GenerateReport(ctx context.Context, now time.Time) (*vo.DataReportVO, error) {
+	period := s.cfg.Period
+	if period != constant.ReportPeriodWeekly {
+		period = constant.ReportPeriodDaily
+	}
+	groupBy := s.cfg.GroupBy
+	if groupBy != constant.ReportGroupByOfficialTag {
+		groupBy = constant.ReportGroupByNone
+	}
+	periodStart, periodEnd := reportPeriodRange(period, now)
+
+	s.logger.Info("开始生成帖子数据报表",
+		zap.String("period", period),
+		zap.String("groupBy", groupBy),
+		zap.Time("periodStart", periodStart),
+		zap.Time("periodEnd", periodEnd),
+	)
+
+	// 1. 从从库分页读取数据并写入 CSV
+	var buf bytes.Buffer
+	buf.WriteString("\xEF\xBB\xBF") // UTF-8 BOM，保证 Excel 打开中文不乱码
+	writer := csv.NewWriter(&buf)
+	// 只统计区间内创建的帖子；点赞数为 MySQL 中定时同步的持久化值，评论数来自评论服务
+	header := []string{"分组", "组内排名", "全站排名", "帖子ID", "标题", "作者ID", "作者用户名", "官方标签", "浏览量", "点赞数", "评论数", "创建时间"}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("写入报表表头失败: %w", err)
+	}
+
+	groupRanks := make(map[string]int) // 分组 -> 当前组内排名
+	globalRank := 0
+	rowCount := 0
+	for offset := 0; ; offset += constant.ReportQueryBatchSize {
+		posts, err := s.reportRepo.ListApprovedPostsForReport(ctx, periodStart, periodEnd, offset, constant.ReportQueryBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("读取报表数据失败: %w", err)
+		}
+		commentCounts, err := s.commentCounts(ctx, posts)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			globalRank++
+			group := reportGroupKey(post, groupBy)
+			groupRanks[group]++
+			if s.cfg.TopN > 0 && groupRanks[group] > s.cfg.TopN {
+				continue
+			}
+			record := []string{
+				group,
+				strconv.Itoa(groupRanks[group]),
+				strconv.Itoa(globalRank),
+				strconv.FormatUint(post.ID, 10),
+				post.Title,
+				post.AuthorID,
+				post.AuthorUsername,
+				strconv.Itoa(int(post.OfficialTags.Primary())),
+				strconv.FormatInt(post.ViewCount, 10),
+				strconv.FormatInt(post.LikeCount, 10),
+				reportCommentCell(commentCounts, post.ID),
+				post.CreatedAt.Format(time.DateTime),
+			}
+			if err := writer.Write(record); err != nil {
+				return nil, fmt.Errorf("写入报表数据行失败: %w", err)
+			}
+			rowCount++
+		}
+		if len(posts) < constant.ReportQueryBatchSize {
+			break
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("生成报表 CSV 失败: %w", err)
+	}
+
+	// 2. 上传 COS
+	objectKey := fmt.Sprintf("%s%s/%s_%s.csv", constant.COSObjectKeyPrefixPostReports, period, periodStart.Format("20060102"), groupBy)
+	fileURL, err := s.cosClient.UploadFile(ctx, objectKey, bytes.NewReader(buf.Bytes()), int64(buf.Len()), "text/csv; charset=utf-8")
+	if err != nil {
+		s.logger.Error("上传帖子数据报表到 COS 失败", zap.Error(err), zap.String("objectKey", objectKey))
+		return nil, fmt.Errorf("上传报表失败: %w", err)
+	}
+
+	// 3. 记录报表元信息
+	report := &entities.PostDataReport{
+		Period:      period,
+		GroupBy:     groupBy,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		ObjectKey:   objectKey,
+		FileURL:     fileURL,
+		RowCount:    rowCount,
+	}
+	if err := s.reportRepo.CreateReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("保存报表记录失败: %w", err)
+	}
+
+	s.logger.Info("帖子数据报表生成完成",
+		zap.Uint64("reportID", report.ID),
+		zap.String("objectKey", objectKey),
+		zap.Int("rowCount", rowCount),
+	)
+	return vo.NewDataReportVOFromEntity(report), nil
+}
+
+// commentCounts 批量获取一页帖子的评论数；未接入评论服务时返回 nil。
+func (s *reportService) commentCounts(ctx context.Context, posts []*entities.Post) (map[uint64]int64, error) {
+	if s.commentProvider == nil || len(posts) == 0 {
+		return nil, nil
+	}
+	postIDs := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	counts, err := s.commentProvider.BatchGetCommentCounts(ctx, postIDs)
+	if err != nil {
+		s.logger.Error("获取报表帖子评论数失败", zap.Error(err), zap.Int("count", len(postIDs)))
+		return nil, fmt.Errorf("获取帖子评论数失败: %w", err)
+	}
+	if counts == nil {
+		counts = make(map[uint64]int64)
+	}
+	return counts, nil
+}
+
+// reportCommentCell 返回报表中评论数单元格的内容；counts 为 nil（未接入评论服务）时留空，以免与“0 条评论”混淆。
+func reportCommentCell(counts map[uint64]int64, postID uint64) string {
+	if counts == nil {
+		return ""
+	}
+	return strconv.FormatInt(counts[postID], 10)
+}
+
+// reportGroupKey 根据分组维度返回帖子所属的分组名。
+func reportGroupKey(post *entities.Post, groupBy string) string {
+	if groupBy == constant.ReportGroupByOfficialTag {
+		return "official_tag_" + strconv.Itoa(int(post.OfficialTags.Primary()))
+	}
+	return "all"
+}
+
+// ListReports 实现历史报表分页查询。
+func (s *reportService) ListReports(ctx context.Context, period string, page, pageSize int) (*vo.ListDataReportsVO, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	reports, total, err := s.reportRepo.ListReports(ctx, period, (page-1)*pageSize, pageSize)
+	if err != nil {
+		s.logger.Error("查询历史报表失败", zap.Error(err), zap.String("period", period))
+		return nil, fmt.Errorf("查询历史报表失败: %w", err)
+	}
+
+	result := &vo.ListDataReportsVO{
+		Reports: make([]*vo.DataReportVO, 0, len(reports)),
+		Total:   total,
+	}
+	for _, r := range reports {
+		result.Reports = append(result.Reports, vo.NewDataReportVOFromEntity(r))
+	}
+	return result, nil
+}
+
+// GetReportDownloadURL 实现获取报表下载地址。
+func (s *reportService) GetReportDownloadURL(ctx context.Context, reportID uint64) (string, error) {
+	report, err := s.reportRepo.GetReportByID(ctx, reportID)
+	if err != nil {
+		return "", fmt.Errorf("获取报表记录失败: %w", err)
+	}
+	return report.FileURL, nil
+}
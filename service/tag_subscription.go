@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/Xushengqwer/go-common/models/enums"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/models/vo"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// TagSubscriptionService 定义用户标签订阅与新帖推送的接口。
+// - 可订阅的标签即帖子的官方标签（enums.OfficialTag，不含“无标签”）。
+type TagSubscriptionService interface {
+	// Subscribe 为用户订阅标签，重复订阅是幂等的。
+	Subscribe(ctx context.Context, userID string, tag enums.OfficialTag) error
+
+	// Unsubscribe 取消用户对标签的订阅，未订阅时同样视为成功。
+	Unsubscribe(ctx context.Context, userID string, tag enums.OfficialTag) error
+
+	// ListSubscriptions 查询用户已订阅的标签。
+	ListSubscriptions(ctx context.Context, userID string) (*vo.TagSubscriptionsVO, error)
+
+	// NotifyNewPost 向订阅了 tag 的用户推送新帖事件（Kafka tag.new_post）。
+	// - 按用户 ID 游标分批扫描订阅者，每批生成一条事件，帖子作者本人不会收到推送。
+	// - 调用耗时与订阅者数量成正比，调用方应在后台 goroutine 中执行。
+	// - 未配置 Kafka 或推送主题时直接返回 nil。
+	NotifyNewPost(ctx context.Context, post *entities.Post, tag enums.OfficialTag) error
+}
+
+// tagSubscriptionService 是 TagSubscriptionService 接口的实现。
+type tagSubscriptionService struct {
+	subRepo  mysql.TagSubscriptionRepository
+	kafkaSvc *producer.KafkaProducer
+	logger   *core.ZapLogger
+}
+
+// NewTagSubscriptionService 初始化标签订阅服务。
+func NewTagSubscriptionService(subRepo mysql.TagSubscriptionRepository, kafkaSvc *producer.KafkaProducer, logger *core.ZapLogger) TagSubscriptionService {
+	return &tagSubscriptionService{
+		subRepo:  subRepo,
+		kafkaSvc: kafkaSvc,
+		logger:   logger,
+	}
+}
+
+// Subscribe 实现标签订阅。
+func (s *tagSubscriptionService) Subscribe(ctx context.Context, userID string, tag enums.OfficialTag) error {
+	if err := s.subRepo.Subscribe(ctx, userID, tag); err != nil {
+		return fmt.Errorf("订阅标签 %d 失败: %w", tag, err)
+	}
+	s.logger.Info("用户订阅标签", zap.String("userID", userID), zap.Int("tag", int(tag)))
+	return nil
+}
+
+// Unsubscribe 实现取消标签订阅。
+func (s *tagSubscriptionService) Unsubscribe(ctx context.Context, userID string, tag enums.OfficialTag) error {
+	if err := s.subRepo.Unsubscribe(ctx, userID, tag); err != nil {
+		return fmt.Errorf("取消订阅标签 %d 失败: %w", tag, err)
+	}
+	s.logger.Info("用户取消订阅标签", zap.String("userID", userID), zap.Int("tag", int(tag)))
+	return nil
+}
+
+// ListSubscriptions 实现用户订阅列表的查询。
+func (s *tagSubscriptionService) ListSubscriptions(ctx context.Context, userID string) (*vo.TagSubscriptionsVO, error) {
+	tags, err := s.subRepo.ListTagsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("查询标签订阅失败: %w", err)
+	}
+	if tags == nil {
+		tags = []enums.OfficialTag{}
+	}
+	return &vo.TagSubscriptionsVO{Tags: tags}, nil
+}
+
+// NotifyNewPost 实现新帖推送。
+// - 某一批写入 Kafka 失败时停止推送并返回错误，已发送的批次不会回滚，日志中记录中断位置。
+func (s *tagSubscriptionService) NotifyNewPost(ctx context.Context, post *entities.Post, tag enums.OfficialTag) error {
+	if s.kafkaSvc == nil || !s.kafkaSvc.TagNewPostEnabled() || tag == enums.OfficialTagNone {
+		return nil
+	}
+
+	var (
+		cursor     string // 上一批最后一个订阅者的用户 ID
+		batches    int
+		recipients int
+	)
+	for {
+		userIDs, err := s.subRepo.ListSubscriberIDsAfter(ctx, tag, cursor, constant.TagNewPostBatchSize)
+		if err != nil {
+			return fmt.Errorf("查询标签 %d 的订阅者失败（已推送 %d 批）: %w", tag, batches, err)
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+		cursor = userIDs[len(userIDs)-1]
+
+		subscriberIDs := make([]string, 0, len(userIDs))
+		for _, id := range userIDs {
+			if id != post.AuthorID {
+				subscriberIDs = append(subscriberIDs, id)
+			}
+		}
+		if len(subscriberIDs) > 0 {
+			event := producer.TagNewPostEvent{
+				EventID:        uuid.New().String(),
+				Timestamp:      time.Now(),
+				Tag:            tag,
+				PostID:         post.ID,
+				Title:          post.Title,
+				AuthorID:       post.AuthorID,
+				AuthorUsername: post.AuthorUsername,
+				SubscriberIDs:  subscriberIDs,
+			}
+			if err := s.kafkaSvc.SendTagNewPostEvents(ctx, []producer.TagNewPostEvent{event}); err != nil {
+				s.logger.Error("发送标签新帖推送事件失败，停止本次推送", zap.Error(err),
+					zap.Uint64("postID", post.ID),
+					zap.Int("tag", int(tag)),
+					zap.Int("sentBatches", batches),
+					zap.String("cursor", cursor))
+				return fmt.Errorf("发送标签 %d 新帖推送事件失败: %w", tag, err)
+			}
+			batches++
+			recipients += len(subscriberIDs)
+		}
+
+		if len(userIDs) < constant.TagNewPostBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info("标签新帖推送完成",
+		zap.Uint64("postID", post.ID),
+		zap.Int("tag", int(tag)),
+		zap.Int("batches", batches),
+		zap.Int("recipients", recipients))
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Xushengqwer/post_service/models/dto"
+	"github.com/Xushengqwer/post_service/models/entities"
+)
+
+// buildPostTargeting 根据创建请求中的定向字段构造定向实体。
+// - 如果请求没有设置任何定向条件，返回 nil，表示帖子对所有用户可见。
+func buildPostTargeting(req *dto.CreatePostRequest) *entities.PostTargeting {
+	regions := normalizeTargetValues(req.TargetRegions)
+	tags := normalizeTargetValues(req.TargetTags)
+	if regions == "" && tags == "" && req.TargetMinLevel <= 0 {
+		return nil
+	}
+	minLevel := req.TargetMinLevel
+	if minLevel < 0 {
+		minLevel = 0
+	}
+	return &entities.PostTargeting{
+		Regions:      regions,
+		MinUserLevel: minLevel,
+		Tags:         tags,
+	}
+}
+
+// isTargetingMatched 判断当前用户是否满足帖子的定向条件。
+// - 与 repo 层 applyTargetingFilter 的 SQL 语义保持一致：地区、等级、标签三个维度需同时满足。
+// - targeting 为 nil 表示未设置定向，任何用户可见。
+func isTargetingMatched(targeting *entities.PostTargeting, viewer *dto.ViewerAttributes) bool {
+	if targeting == nil {
+		return true
+	}
+	if viewer == nil {
+		viewer = &dto.ViewerAttributes{}
+	}
+
+	if targeting.Regions != "" && !containsTargetValue(targeting.Regions, viewer.Region) {
+		return false
+	}
+	if viewer.Level < targeting.MinUserLevel {
+		return false
+	}
+	if targeting.Tags != "" {
+		matched := false
+		for _, tag := range viewer.Tags {
+			if containsTargetValue(targeting.Tags, tag) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeTargetValues 将定向值列表规整为逗号分隔的字符串。
+// - 去除首尾空白、空值、重复值，以及值内部的逗号（逗号是存储分隔符）。
+func normalizeTargetValues(values []string) string {
+	seen := make(map[string]struct{}, len(values))
+	cleaned := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(strings.ReplaceAll(v, ",", ""))
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		cleaned = append(cleaned, v)
+	}
+	return strings.Join(cleaned, ",")
+}
+
+// splitTargetValues 将逗号分隔的定向值拆分为列表，空字符串返回 nil。
+func splitTargetValues(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+// containsTargetValue 判断逗号分隔的定向值列表中是否包含指定值（等价于 MySQL 的 FIND_IN_SET）。
+func containsTargetValue(list string, value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	for _, item := range strings.Split(list, ",") {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/metrics"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// ViewCountAggregator 在进程内按帖子聚合浏览增量，每个窗口批量写入 Redis 一次，避免热点帖子的每次浏览都写同一组计数 Key。
+// - 逐次浏览: 去重判断 (PostViewRepository.MarkViewed) 依赖访客身份，必须每次浏览单独执行，只有判定为新访客的浏览才计入本地增量。
+// - 可聚合: 计数器、排行榜分数、最近活跃时间、脏集合与全站分钟桶只依赖增量之和，由 PostViewRepository.IncrementViewCountBy 每个窗口每个帖子写一次。
+// - 代价: 浏览量与热榜分数最多延迟一个窗口；分钟桶按写入时间计入。进程异常退出时最多丢失一个窗口内的增量，正常关停时 Stop 会写出剩余增量。
+// - 写入失败的增量放回本地重试，但重试的窗口数与帖子数都有上限，Redis 长时间不可用时丢弃超限的增量并上报指标，避免本地增量无限增长。
+type ViewCountAggregator struct {
+	postViewRepo redis.PostViewRepository
+	window       time.Duration
+	reporter     metrics.MetricsReporter
+	logger       *core.ZapLogger
+
+	mu       sync.Mutex
+	pending  map[uint64]int64 // postID -> 尚未写入 Redis 的浏览增量
+	attempts map[uint64]int   // postID -> 放回重试的增量已连续写入失败的次数，写入成功或丢弃后清除
+
+	marking  sync.WaitGroup // 进行中的去重判断，关停时等待其完成后再做最后一次写入
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	loopDone chan struct{}
+}
+
+// NewViewCountAggregator 初始化并启动浏览增量聚合器。
+// - window 为 0 或负数时使用 constant.ViewCountAggregateWindow。
+// - reporter 可以为 nil，此时丢弃增量只记录日志。
+func NewViewCountAggregator(postViewRepo redis.PostViewRepository, window time.Duration, reporter metrics.MetricsReporter, logger *core.ZapLogger) *ViewCountAggregator {
+	if window <= 0 {
+		window = constant.ViewCountAggregateWindow
+	}
+	a := &ViewCountAggregator{
+		postViewRepo: postViewRepo,
+		window:       window,
+		reporter:     reporter,
+		logger:       logger,
+		pending:      make(map[uint64]int64),
+		attempts:     make(map[uint64]int),
+		stopCh:       make(chan struct{}),
+		loopDone:     make(chan struct{}),
+	}
+	go a.run()
+	a.logger.Info("浏览增量聚合器已启动", zap.Duration("window", window))
+	return a
+}
+
+// Record 异步记录一次浏览：去重判断立即执行，通过去重的浏览累加到本地增量，等待下一个窗口写入。
+// - viewer.Member 为空的判断由调用方完成。
+func (a *ViewCountAggregator) Record(postID uint64, viewer redis.ViewerIdentity) {
+	a.marking.Add(1)
+	go func() {
+		defer a.marking.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ViewCountMarkTimeout)
+		defer cancel()
+
+		counted, err := a.postViewRepo.MarkViewed(ctx, postID, viewer, 0)
+		if err != nil {
+			a.logger.Error("浏览去重判断失败，本次浏览不计数", zap.Error(err), zap.Uint64("post_id", postID), zap.String("user_id", viewer.Member))
+			return
+		}
+		if counted {
+			a.add(postID, 1)
+		}
+	}()
+}
+
+// add 累加帖子的本地浏览增量。
+func (a *ViewCountAggregator) add(postID uint64, delta int64) {
+	a.mu.Lock()
+	a.pending[postID] += delta
+	a.mu.Unlock()
+}
+
+// run 按窗口周期写出本地增量，收到停止信号后等待进行中的去重判断完成，再写出剩余增量。
+func (a *ViewCountAggregator) run() {
+	defer close(a.loopDone)
+	ticker := time.NewTicker(a.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.flush(true)
+		case <-a.stopCh:
+			a.marking.Wait()
+			a.flush(false)
+			return
+		}
+	}
+}
+
+// flush 把当前累积的增量逐帖子写入 Redis。
+//   - retry 为 true 时，写入失败的增量放回本地，下一个窗口重试；关停时的最后一次写入失败只记录日志。
+//   - 同一帖子连续失败 constant.ViewCountAggregateRetryMaxAttempts 次，或本窗口放回的帖子数已达 constant.ViewCountAggregateRetryMaxPosts 时，
+//     失败的增量直接丢弃，并通过指标 (dropped_views / dropped_posts) 上报。
+func (a *ViewCountAggregator) flush(retry bool) {
+	a.mu.Lock()
+	batch := a.pending
+	a.pending = make(map[uint64]int64, len(batch))
+	a.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constant.ViewCountFlushTimeout)
+	defer cancel()
+	failed := make(map[uint64]int64)
+	succeeded := make([]uint64, 0, len(batch))
+	for postID, delta := range batch {
+		if err := a.postViewRepo.IncrementViewCountBy(ctx, postID, delta); err != nil {
+			failed[postID] = delta
+			continue
+		}
+		succeeded = append(succeeded, postID)
+	}
+
+	var failedViews, droppedViews int64
+	var retained, droppedPosts int
+	a.mu.Lock()
+	for _, postID := range succeeded {
+		delete(a.attempts, postID)
+	}
+	for postID, delta := range failed {
+		failedViews += delta
+		attempt := a.attempts[postID] + 1
+		if !retry || attempt >= constant.ViewCountAggregateRetryMaxAttempts || retained >= constant.ViewCountAggregateRetryMaxPosts {
+			delete(a.attempts, postID)
+			droppedPosts++
+			droppedViews += delta
+			continue
+		}
+		a.attempts[postID] = attempt
+		a.pending[postID] += delta
+		retained++
+	}
+	a.mu.Unlock()
+
+	if droppedPosts > 0 {
+		metrics.AddTaskEvents(a.reporter, constant.ViewCountAggregatorTaskName, constant.ViewCountAggregatorEventDroppedPosts, droppedPosts)
+		metrics.AddTaskEvents(a.reporter, constant.ViewCountAggregatorTaskName, constant.ViewCountAggregatorEventDroppedViews, int(droppedViews))
+	}
+	if len(failed) > 0 {
+		a.logger.Error("批量写入浏览增量部分失败",
+			zap.Int("posts", len(batch)),
+			zap.Int("failedPosts", len(failed)),
+			zap.Int64("failedViews", failedViews),
+			zap.Int("retainedPosts", retained),
+			zap.Int("droppedPosts", droppedPosts),
+			zap.Int64("droppedViews", droppedViews))
+		return
+	}
+	a.logger.Debug("批量写入浏览增量完成", zap.Int("posts", len(batch)))
+}
+
+// Stop 停止聚合器并写出剩余增量，返回的 context 在写出完成后结束。
+// - 应在 HTTP 服务关闭之后调用，此后不再有新的 Record。
+func (a *ViewCountAggregator) Stop() context.Context {
+	a.logger.Info("正在停止浏览增量聚合器...")
+	a.stopOnce.Do(func() { close(a.stopCh) })
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-a.loopDone
+		cancel()
+	}()
+	return ctx
+}
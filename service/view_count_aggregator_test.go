@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// flakyViewRepo 是按开关决定写入成功与否的浏览计数仓库。
+type flakyViewRepo struct {
+	redis.PostViewRepository
+	mu      sync.Mutex
+	fail    bool
+	written map[uint64]int64
+}
+
+func (r *flakyViewRepo) IncrementViewCountBy(_ context.Context, postID uint64, delta int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("redis unavailable")
+	}
+	r.written[postID] += delta
+	return nil
+}
+
+// recordingReporter 记录 AddTaskEvents 的累计值。
+type recordingReporter struct {
+	events map[string]int
+}
+
+func (r *recordingReporter) ObserveTaskStep(string, string, time.Duration, error) {}
+func (r *recordingReporter) SetTaskGauge(string, string, float64)                 {}
+func (r *recordingReporter) AddTaskEvents(task, event string, delta int) {
+	r.events[task+"/"+event] += delta
+}
+
+func newTestAggregator(t *testing.T, repo redis.PostViewRepository, reporter *recordingReporter) *ViewCountAggregator {
+	return &ViewCountAggregator{
+		postViewRepo: repo,
+		reporter:     reporter,
+		logger:       newTestLogger(t),
+		pending:      make(map[uint64]int64),
+		attempts:     make(map[uint64]int),
+	}
+}
+
+func TestViewCountAggregatorDropsAfterMaxAttempts(t *testing.T) {
+	repo := &flakyViewRepo{fail: true, written: map[uint64]int64{}}
+	reporter := &recordingReporter{events: map[string]int{}}
+	a := newTestAggregator(t, repo, reporter)
+
+	a.add(1, 3)
+	for i := 1; i < constant.ViewCountAggregateRetryMaxAttempts; i++ {
+		a.flush(true)
+		if a.pending[1] != 3 {
+			t.Fatalf("after %d failed flushes pending = %d, want the delta kept for retry", i, a.pending[1])
+		}
+	}
+	a.flush(true)
+	if len(a.pending) != 0 || len(a.attempts) != 0 {
+		t.Fatalf("pending = %v, attempts = %v, want both empty after max attempts", a.pending, a.attempts)
+	}
+	if got := reporter.events[constant.ViewCountAggregatorTaskName+"/"+constant.ViewCountAggregatorEventDroppedViews]; got != 3 {
+		t.Fatalf("dropped_views = %d, want 3", got)
+	}
+}
+
+func TestViewCountAggregatorResetsAttemptsAfterSuccess(t *testing.T) {
+	repo := &flakyViewRepo{fail: true, written: map[uint64]int64{}}
+	a := newTestAggregator(t, repo, &recordingReporter{events: map[string]int{}})
+
+	a.add(1, 2)
+	a.flush(true)
+	a.add(1, 1) // 重试期间的新浏览与放回的增量合并
+	repo.fail = false
+	a.flush(true)
+
+	if repo.written[1] != 3 {
+		t.Fatalf("written = %d, want 3", repo.written[1])
+	}
+	if len(a.attempts) != 0 {
+		t.Fatalf("attempts = %v, want cleared after a successful write", a.attempts)
+	}
+}
+
+func TestViewCountAggregatorCapsRetainedPosts(t *testing.T) {
+	repo := &flakyViewRepo{fail: true, written: map[uint64]int64{}}
+	reporter := &recordingReporter{events: map[string]int{}}
+	a := newTestAggregator(t, repo, reporter)
+
+	total := constant.ViewCountAggregateRetryMaxPosts + 5
+	for id := 1; id <= total; id++ {
+		a.add(uint64(id), 1)
+	}
+	a.flush(true)
+
+	if len(a.pending) != constant.ViewCountAggregateRetryMaxPosts {
+		t.Fatalf("retained posts = %d, want %d", len(a.pending), constant.ViewCountAggregateRetryMaxPosts)
+	}
+	if got := reporter.events[constant.ViewCountAggregatorTaskName+"/"+constant.ViewCountAggregatorEventDroppedPosts]; got != 5 {
+		t.Fatalf("dropped_posts = %d, want 5", got)
+	}
+}
+
+func TestViewCountAggregatorFinalFlushDoesNotRetry(t *testing.T) {
+	repo := &flakyViewRepo{fail: true, written: map[uint64]int64{}}
+	reporter := &recordingReporter{events: map[string]int{}}
+	a := newTestAggregator(t, repo, reporter)
+
+	a.add(1, 4)
+	a.flush(false)
+	if len(a.pending) != 0 {
+		t.Fatalf("pending = %v, want empty after the final flush", a.pending)
+	}
+	if got := reporter.events[constant.ViewCountAggregatorTaskName+"/"+constant.ViewCountAggregatorEventDroppedViews]; got != 4 {
+		t.Fatalf("dropped_views = %d, want 4", got)
+	}
+}
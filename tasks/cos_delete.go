@@ -0,0 +1,139 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// COSDeleteTask 负责定时删除 COS 延迟删除队列中已超过保留期的图片对象。
+// - 帖子软删除时图片对象键以计划删除时间入队，恢复帖子时出队；本任务只处理已到期的条目。
+// - 删除前再次确认对象键不再被未删除的帖子引用（恢复后出队失败等情况），仍被引用的条目直接出队、不删除。
+// - 删除失败的条目改期 constant.COSDeleteRetryDelay 后重试；COS 对象不存在视为删除成功。
+type COSDeleteTask struct {
+	deleteQueue redis.COSDeleteQueue
+	imageRepo   mysql.PostDetailImageRepository
+	cosClient   dependencies.COSClientInterface
+	lock        *dependencies.RedisLock // 分布式锁，多副本部署时保证只有一个实例删除
+	cron        *cron.Cron
+	logger      *core.ZapLogger
+}
+
+// NewCOSDeleteTask 初始化并启动 COS 延迟删除定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+func NewCOSDeleteTask(deleteQueue redis.COSDeleteQueue, imageRepo mysql.PostDetailImageRepository, cosClient dependencies.COSClientInterface, lock *dependencies.RedisLock, logger *core.ZapLogger) *COSDeleteTask {
+	task := &COSDeleteTask{
+		deleteQueue: deleteQueue,
+		imageRepo:   imageRepo,
+		cosClient:   cosClient,
+		lock:        lock,
+		cron:        cron.New(),
+		logger:      logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *COSDeleteTask) startCronJob() {
+	schedule := constant.COSDeleteCronSpec
+	t.logger.Info("准备启动 COS 延迟删除定时任务", zap.String("schedule", schedule), zap.Duration("retention", constant.COSDeleteRetention))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constant.COSDeleteTimeout)
+		defer cancel()
+
+		runWithLock(ctx, t.lock, "COS 延迟删除", t.logger, t.deleteDueObjects)
+	})
+	if err != nil {
+		t.logger.Fatal("添加 COS 延迟删除 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("COS 延迟删除定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// deleteDueObjects 是定时任务执行的实际删除逻辑，按批处理到期条目，直到队列中没有到期条目或超时。
+func (t *COSDeleteTask) deleteDueObjects(ctx context.Context) {
+	now := time.Now()
+	var deleted, skipped, failed int
+	for ctx.Err() == nil {
+		items, err := t.deleteQueue.ListDue(ctx, now, constant.COSDeleteBatchSize)
+		if err != nil {
+			t.logger.Error("读取 COS 延迟删除队列失败，提前结束本次删除", zap.Error(err))
+			break
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		batchDeleted, batchSkipped, batchFailed, err := t.deleteBatch(ctx, items)
+		deleted += batchDeleted
+		skipped += batchSkipped
+		failed += batchFailed
+		if err != nil {
+			t.logger.Error("处理 COS 延迟删除队列失败，提前结束本次删除", zap.Error(err))
+			break
+		}
+		if len(items) < constant.COSDeleteBatchSize {
+			break
+		}
+	}
+	if deleted+skipped+failed > 0 {
+		t.logger.Info("COS 延迟删除完成", zap.Int("deleted", deleted), zap.Int("stillReferenced", skipped), zap.Int("failed", failed))
+	}
+}
+
+// deleteBatch 处理一批到期条目，返回删除、跳过（仍被引用）与失败的数量。
+// - 返回的 error 表示队列或数据库操作失败，此时本批条目保持原状，下一轮重新处理。
+func (t *COSDeleteTask) deleteBatch(ctx context.Context, items []redis.PendingCOSDelete) (int, int, int, error) {
+	objectKeys := make([]string, 0, len(items))
+	for _, item := range items {
+		objectKeys = append(objectKeys, item.ObjectKey)
+	}
+	referenced, err := t.imageRepo.FilterReferencedObjectKeys(ctx, objectKeys)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var done, retry []redis.PendingCOSDelete
+	skipped := 0
+	for _, item := range items {
+		if referenced[item.ObjectKey] {
+			skipped++
+			done = append(done, item)
+			continue
+		}
+		if err := t.cosClient.DeleteObject(ctx, item.ObjectKey); err != nil {
+			t.logger.Warn("删除 COS 对象失败，稍后重试", zap.Error(err), zap.Uint64("postID", item.PostID), zap.String("objectKey", item.ObjectKey))
+			retry = append(retry, item)
+			continue
+		}
+		done = append(done, item)
+	}
+
+	if err := t.deleteQueue.Ack(ctx, done); err != nil {
+		// 已删除的对象下一轮会再次删除，COS 对象不存在视为成功，不影响正确性
+		return len(done) - skipped, skipped, len(retry), err
+	}
+	if err := t.deleteQueue.Retry(ctx, retry, time.Now().Add(constant.COSDeleteRetryDelay)); err != nil {
+		return len(done) - skipped, skipped, len(retry), err
+	}
+	return len(done) - skipped, skipped, len(retry), nil
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *COSDeleteTask) Stop() context.Context {
+	t.logger.Info("正在停止 COS 延迟删除定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("COS 延迟删除定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -10,25 +10,36 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/metrics"
 	"github.com/Xushengqwer/post_service/repo/redis" // 假设 PostTaskCache 接口定义在此
 )
 
 // HotPostsCacheTask 负责定时刷新 Redis 中的热门帖子缓存。
 // 它协调生成热榜快照，并基于该快照更新帖子基本信息Hash和帖子详情缓存。
 type HotPostsCacheTask struct {
-	taskCache redis.PostTaskCache // 修改：依赖新的 PostTaskCache 接口
+	taskCache redis.PostTaskCache     // 修改：依赖新的 PostTaskCache 接口
+	lock      *dependencies.RedisLock // 分布式锁，多副本部署时保证只有一个实例刷新热榜
+	metrics   metrics.MetricsReporter // 各步骤的成功/失败次数、耗时与最后成功时间，为 nil 时不上报
+	schedule  string                  // cron 调度表达式
 	cron      *cron.Cron
 	logger    *core.ZapLogger
 }
 
 // NewHotPostsCacheTask 初始化并启动热门帖子缓存的定时任务。
 // - taskCache: 实现了 redis.PostTaskCache 接口的实例。
+// - lock: 分布式锁，为 nil 时不加锁，每次调度都会执行。
+// - reporter: 指标上报，为 nil 时不上报，任务照常运行。
+// - schedule: cron 调度表达式，为空时使用 constant.HotPostsCacheCronSpec，应在启动时先经 ValidateTaskConfig 校验。
 // - logger: ZapLogger 实例。
-func NewHotPostsCacheTask(taskCache redis.PostTaskCache, logger *core.ZapLogger) *HotPostsCacheTask {
+func NewHotPostsCacheTask(taskCache redis.PostTaskCache, lock *dependencies.RedisLock, reporter metrics.MetricsReporter, schedule string, logger *core.ZapLogger) *HotPostsCacheTask {
 	cronV3 := cron.New() // 默认分钟级精度
 
 	task := &HotPostsCacheTask{
 		taskCache: taskCache, // 修改：使用 taskCache
+		lock:      lock,
+		metrics:   reporter,
+		schedule:  resolveCronSpec(schedule, constant.HotPostsCacheCronSpec),
 		cron:      cronV3,
 		logger:    logger,
 	}
@@ -38,19 +49,22 @@ func NewHotPostsCacheTask(taskCache redis.PostTaskCache, logger *core.ZapLogger)
 
 // startCronJob 配置并启动 cron 作业。
 func (t *HotPostsCacheTask) startCronJob() {
-	schedule := constant.HotPostsCacheCronSpec
+	schedule := t.schedule
 	t.logger.Info("准备启动热门帖子相关缓存刷新定时任务", zap.String("schedule", schedule))
 
 	entryID, err := t.cron.AddFunc(schedule, func() {
 		t.logger.Info("热门帖子相关缓存刷新任务开始执行...")
 		startTime := time.Now()
-		// 为单次任务执行设置超时，例如 10 分钟，以防止任务卡死。
+		// 为单次任务执行设置超时，以防止任务卡死。
 		// 超时时间的设定应大于各子任务（CreateHotList, CacheHotPostsToRedis, CacheHotPostDetailsToRedis）
 		// 正常执行时间的总和，并留有一定余量。
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), constant.HotPostsCacheTimeout)
 		defer cancel()
 
-		t.syncHotCaches(ctx) // 调用新的同步逻辑
+		// 多副本部署时只有拿到锁的实例刷新热榜，避免重复生成
+		if !runWithLock(ctx, t.lock, "热帖缓存刷新", t.logger, t.syncHotCaches) {
+			return
+		}
 
 		duration := time.Since(startTime)
 		t.logger.Info("热门帖子相关缓存刷新任务执行完毕", zap.Duration("duration", duration))
@@ -73,7 +87,9 @@ func (t *HotPostsCacheTask) syncHotCaches(ctx context.Context) {
 	// 步骤 1: 创建/更新热榜快照 (constant.HotPostsRankKey)
 	// 这个快照将作为后续两个缓存更新步骤的数据源。
 	t.logger.Info("任务步骤1: 开始创建/更新热榜快照 ZSet...")
-	if err := t.taskCache.CreateHotList(ctx, constant.HotPostsCacheSize); err != nil {
+	if err := t.runStep(ctx, constant.HotPostsCacheStepHotList, func(ctx context.Context) error {
+		return t.taskCache.CreateHotList(ctx, constant.HotPostsCacheSize)
+	}); err != nil {
 		// 如果创建热榜快照失败，后续的缓存更新可能基于旧的或不一致的数据源，
 		// 或者如果热榜 ZSet 不存在，后续步骤会失败。
 		// 这是一个关键步骤，其失败应被高度关注。
@@ -88,7 +104,7 @@ func (t *HotPostsCacheTask) syncHotCaches(ctx context.Context) {
 	// 步骤 2: 同步热门帖子基本信息到 Hash 缓存。
 	// 此方法现在依赖于步骤 1 生成的 `constant.HotPostsRankKey`。
 	t.logger.Info("任务步骤2: 开始同步热门帖子基本信息到 Redis Hash...")
-	if err := t.taskCache.CacheHotPostsToRedis(ctx); err != nil {
+	if err := t.runStep(ctx, constant.HotPostsCacheStepPostsHash, t.taskCache.CacheHotPostsToRedis); err != nil {
 		t.logger.Error("同步热门帖子基本信息到 Redis Hash 失败", zap.Error(err))
 		// 记录错误，但允许任务继续到下一步。
 	} else {
@@ -98,7 +114,7 @@ func (t *HotPostsCacheTask) syncHotCaches(ctx context.Context) {
 	// 步骤 3: 同步热门帖子详情到独立的 Redis Key。
 	// 此方法也依赖于步骤 1 生成的 `constant.HotPostsRankKey` 作为热门ID的来源。
 	t.logger.Info("任务步骤3: 开始同步热门帖子详情到 Redis...")
-	if err := t.taskCache.CacheHotPostDetailsToRedis(ctx); err != nil {
+	if err := t.runStep(ctx, constant.HotPostsCacheStepPostDetail, t.taskCache.CacheHotPostDetailsToRedis); err != nil {
 		t.logger.Error("同步热门帖子详情到 Redis 失败", zap.Error(err))
 		// 记录错误。
 	} else {
@@ -106,6 +122,14 @@ func (t *HotPostsCacheTask) syncHotCaches(ctx context.Context) {
 	}
 }
 
+// runStep 执行一个子步骤，并上报其结果与耗时。
+func (t *HotPostsCacheTask) runStep(ctx context.Context, step string, fn func(ctx context.Context) error) error {
+	start := time.Now()
+	err := fn(ctx)
+	metrics.ObserveTaskStep(t.metrics, constant.HotPostsCacheTaskName, step, time.Since(start), err)
+	return err
+}
+
 // Stop 优雅地停止 cron 调度器。
 func (t *HotPostsCacheTask) Stop() context.Context {
 	t.logger.Info("正在停止热门帖子相关缓存刷新定时任务...")
@@ -0,0 +1,91 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// LikeCountSyncTask 负责定时将 Redis 中发生过变化的帖子点赞数同步到 MySQL posts.like_count。
+// - 只同步点赞数脏集合中的帖子；写入失败时保留脏标记到下一轮重试，写入的是绝对值，重复同步无副作用。
+type LikeCountSyncTask struct {
+	postLikeRepo  redis.PostLikeRepository
+	postBatchRepo mysql.PostBatchOperationsRepository
+	lock          *dependencies.RedisLock // 分布式锁，多副本部署时保证只有一个实例执行同步
+	cron          *cron.Cron
+	logger        *core.ZapLogger
+}
+
+// NewLikeCountSyncTask 初始化并启动点赞数同步的定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+func NewLikeCountSyncTask(postLikeRepo redis.PostLikeRepository, postBatchRepo mysql.PostBatchOperationsRepository, lock *dependencies.RedisLock, logger *core.ZapLogger) *LikeCountSyncTask {
+	task := &LikeCountSyncTask{
+		postLikeRepo:  postLikeRepo,
+		postBatchRepo: postBatchRepo,
+		lock:          lock,
+		cron:          cron.New(),
+		logger:        logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *LikeCountSyncTask) startCronJob() {
+	schedule := constant.SyncLikeCountCronSpec
+	t.logger.Info("准备启动帖子点赞数同步MySQL定时任务", zap.String("schedule", schedule))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), constant.LikeCountSyncTimeout)
+		defer cancel()
+
+		if !runWithLock(ctx, t.lock, "点赞数同步", t.logger, t.syncLikeCountsToDB) {
+			return
+		}
+		t.logger.Info("帖子点赞数同步MySQL任务执行完毕", zap.Duration("duration", time.Since(startTime)))
+	})
+	if err != nil {
+		t.logger.Fatal("添加帖子点赞数同步 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("帖子点赞数同步MySQL定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// syncLikeCountsToDB 是定时任务执行的实际同步逻辑。
+func (t *LikeCountSyncTask) syncLikeCountsToDB(ctx context.Context) {
+	likeCounts, err := t.postLikeRepo.GetDirtyLikeCounts(ctx)
+	if err != nil {
+		t.logger.Error("从 Redis 获取点赞数失败，本次同步中止。", zap.Error(err))
+		return
+	}
+	if len(likeCounts) == 0 {
+		// 脏集合可能为空或其中的计数器均已不存在，同样确认，避免下一轮重复读取
+		_ = t.postLikeRepo.AckDirtyLikeCounts(ctx)
+		return
+	}
+
+	if err := t.postBatchRepo.BatchUpdatePostLikeCounts(ctx, likeCounts); err != nil {
+		t.logger.Error("点赞数写回 MySQL 失败，保留脏集合到下一轮重试", zap.Error(err), zap.Int("posts", len(likeCounts)))
+		return
+	}
+	t.logger.Info("点赞数已同步到 MySQL", zap.Int("posts", len(likeCounts)))
+	_ = t.postLikeRepo.AckDirtyLikeCounts(ctx)
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *LikeCountSyncTask) Stop() context.Context {
+	t.logger.Info("正在停止帖子点赞数同步MySQL定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("帖子点赞数同步MySQL定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
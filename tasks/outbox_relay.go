@@ -0,0 +1,124 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// OutboxRelayTask 负责定时扫描事务性发件箱，重新投递即时投递失败（或服务在投递前退出）的 Kafka 事件。
+// - 投递成功后删除记录；失败时按失败次数指数退避，最长间隔 constant.OutboxRetryMaxBackoff，不会丢弃记录。
+// - 事件按写入顺序投递，同一帖子的待审核与删除事件保持先后关系（单条失败不阻塞后续记录）。
+type OutboxRelayTask struct {
+	outboxRepo mysql.OutboxRepository
+	kafkaSvc   *producer.KafkaProducer
+	lock       *dependencies.RedisLock // 分布式锁，多副本部署时避免同一记录被多个实例同时投递
+	cron       *cron.Cron
+	logger     *core.ZapLogger
+}
+
+// NewOutboxRelayTask 初始化并启动发件箱补偿投递定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+func NewOutboxRelayTask(outboxRepo mysql.OutboxRepository, kafkaSvc *producer.KafkaProducer, lock *dependencies.RedisLock, logger *core.ZapLogger) *OutboxRelayTask {
+	task := &OutboxRelayTask{
+		outboxRepo: outboxRepo,
+		kafkaSvc:   kafkaSvc,
+		lock:       lock,
+		cron:       cron.New(),
+		logger:     logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *OutboxRelayTask) startCronJob() {
+	schedule := constant.OutboxRelayCronSpec
+	t.logger.Info("准备启动发件箱补偿投递定时任务", zap.String("schedule", schedule))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constant.OutboxRelayTimeout)
+		defer cancel()
+
+		runWithLock(ctx, t.lock, "发件箱补偿投递", t.logger, t.relayDueEvents)
+	})
+	if err != nil {
+		t.logger.Fatal("添加发件箱补偿投递 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("发件箱补偿投递定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// relayDueEvents 是定时任务执行的实际投递逻辑，每轮至多处理 constant.OutboxRelayBatchSize 条到期记录。
+func (t *OutboxRelayTask) relayDueEvents(ctx context.Context) {
+	events, err := t.outboxRepo.ListDueEvents(ctx, time.Now(), constant.OutboxRelayBatchSize)
+	if err != nil {
+		t.logger.Error("读取发件箱到期记录失败，跳过本次投递", zap.Error(err))
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	startTime := time.Now()
+	var sent, failed int
+	for _, event := range events {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := t.kafkaSvc.SendOutboxEvent(ctx, event); err != nil {
+			failed++
+			t.markFailed(ctx, event, err)
+			continue
+		}
+		sent++
+		if err := t.outboxRepo.DeleteEvent(ctx, event.ID); err != nil {
+			// 记录会在下一轮被重复投递一次，下游按 EventID 幂等处理
+			t.logger.Warn("删除已投递的发件箱记录失败", zap.Error(err), zap.Uint64("outboxID", event.ID))
+		}
+	}
+	t.logger.Info("发件箱补偿投递完成",
+		zap.Int("due", len(events)),
+		zap.Int("sent", sent),
+		zap.Int("failed", failed),
+		zap.Duration("duration", time.Since(startTime)))
+}
+
+// markFailed 记录投递失败并按失败次数计算下次重试时间。
+func (t *OutboxRelayTask) markFailed(ctx context.Context, event *entities.OutboxEvent, sendErr error) {
+	backoff := constant.OutboxRelayDelay << min(event.Attempts, 16)
+	if backoff <= 0 || backoff > constant.OutboxRetryMaxBackoff {
+		backoff = constant.OutboxRetryMaxBackoff
+	}
+	lastError := sendErr.Error()
+	if runes := []rune(lastError); len(runes) > constant.OutboxLastErrorMaxLen {
+		lastError = string(runes[:constant.OutboxLastErrorMaxLen])
+	}
+	t.logger.Error("发件箱事件补偿投递失败",
+		zap.Error(sendErr),
+		zap.Uint64("outboxID", event.ID),
+		zap.String("topic", event.Topic),
+		zap.Int("attempts", event.Attempts+1),
+		zap.Duration("nextRetryIn", backoff))
+	if err := t.outboxRepo.MarkEventFailed(ctx, event.ID, time.Now().Add(backoff), lastError); err != nil {
+		t.logger.Error("记录发件箱事件投递失败状态失败，下一轮将立即重试", zap.Error(err), zap.Uint64("outboxID", event.ID))
+	}
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *OutboxRelayTask) Stop() context.Context {
+	t.logger.Info("正在停止发件箱补偿投递定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("发件箱补偿投递定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -0,0 +1,199 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/mq/producer"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// PostReconcileTask 负责定期把本服务的帖子状态快照（ID、状态、更新时间）增量导出到 Kafka，供下游服务对账。
+// - 每次只导出上次检查点之后发生变更（更新或删除）的帖子，避免全量传输；检查点保存在 Redis。
+// - 窗口内任一快照发送失败时不推进检查点，下一轮从同一位置重新导出，下游按帖子 ID 幂等处理。
+// - 下游发现缺失/过期的帖子后调用管理员补偿接口 (PostAdminService.ResyncPosts) 重新获取同步事件。
+type PostReconcileTask struct {
+	postBatchRepo mysql.PostBatchOperationsRepository
+	kafkaSvc      *producer.KafkaProducer
+	rdb           goredis.Cmdable         // 保存对账检查点
+	lock          *dependencies.RedisLock // 分布式锁，多副本部署时保证只有一个实例导出
+	cron          *cron.Cron
+	logger        *core.ZapLogger
+}
+
+// reconcileWindowStats 汇总一次对账窗口导出的结果。
+type reconcileWindowStats struct {
+	updated int // 按更新时间导出的帖子数量
+	deleted int // 按删除时间导出的帖子数量
+	events  int // 发送的快照事件数量
+}
+
+// NewPostReconcileTask 初始化并启动对账快照导出定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+func NewPostReconcileTask(postBatchRepo mysql.PostBatchOperationsRepository, kafkaSvc *producer.KafkaProducer, rdb goredis.Cmdable, lock *dependencies.RedisLock, logger *core.ZapLogger) *PostReconcileTask {
+	task := &PostReconcileTask{
+		postBatchRepo: postBatchRepo,
+		kafkaSvc:      kafkaSvc,
+		rdb:           rdb,
+		lock:          lock,
+		cron:          cron.New(),
+		logger:        logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *PostReconcileTask) startCronJob() {
+	schedule := constant.PostReconcileCronSpec
+	t.logger.Info("准备启动帖子对账快照导出定时任务", zap.String("schedule", schedule))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), constant.PostReconcileTimeout)
+		defer cancel()
+
+		if !runWithLock(ctx, t.lock, "帖子对账快照导出", t.logger, t.exportSnapshots) {
+			return
+		}
+		t.logger.Info("帖子对账快照导出任务执行完毕", zap.Duration("duration", time.Since(startTime)))
+	})
+	if err != nil {
+		t.logger.Fatal("添加帖子对账快照导出 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("帖子对账快照导出定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// exportSnapshots 是定时任务执行的实际导出逻辑。
+// - 窗口为 [上次检查点 - 重叠时长, 当前时间 - 稳定延迟)，首次运行时回看 constant.PostReconcileInitialLookback。
+func (t *PostReconcileTask) exportSnapshots(ctx context.Context) {
+	until := time.Now().Add(-constant.PostReconcileSettleDelay)
+	since, err := t.loadWindowStart(ctx, until)
+	if err != nil {
+		t.logger.Error("读取对账检查点失败，跳过本次导出", zap.Error(err))
+		return
+	}
+	if !since.Before(until) {
+		return
+	}
+
+	var stats reconcileWindowStats
+	for _, column := range []string{constant.PostReconcileColumnUpdatedAt, constant.PostReconcileColumnDeletedAt} {
+		exported, events, err := t.exportColumn(ctx, column, since, until)
+		stats.events += events
+		if column == constant.PostReconcileColumnDeletedAt {
+			stats.deleted = exported
+		} else {
+			stats.updated = exported
+		}
+		if err != nil {
+			t.logger.Error("导出帖子对账快照失败，检查点保持不变，下一轮重新导出",
+				zap.Error(err),
+				zap.String("column", column),
+				zap.Time("since", since),
+				zap.Time("until", until),
+				zap.Int("eventsSent", stats.events))
+			return
+		}
+	}
+
+	if err := t.rdb.Set(ctx, constant.PostReconcileCheckpointKey, until.UnixMilli(), 0).Err(); err != nil {
+		// 检查点未推进只会导致下一轮重复导出同一窗口，下游按帖子 ID 幂等处理
+		t.logger.Error("保存对账检查点失败", zap.Error(err), zap.Time("until", until))
+	}
+	t.logger.Info("帖子对账快照导出完成",
+		zap.Time("since", since),
+		zap.Time("until", until),
+		zap.Int("updatedPosts", stats.updated),
+		zap.Int("deletedPosts", stats.deleted),
+		zap.Int("events", stats.events))
+}
+
+// loadWindowStart 根据检查点计算本次窗口的起点。
+func (t *PostReconcileTask) loadWindowStart(ctx context.Context, until time.Time) (time.Time, error) {
+	raw, err := t.rdb.Get(ctx, constant.PostReconcileCheckpointKey).Result()
+	if errors.Is(err, goredis.Nil) {
+		return until.Add(-constant.PostReconcileInitialLookback), nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	checkpointMillis, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		t.logger.Warn("对账检查点格式错误，按首次运行处理", zap.String("value", raw))
+		return until.Add(-constant.PostReconcileInitialLookback), nil
+	}
+	return time.UnixMilli(checkpointMillis).Add(-constant.PostReconcileWindowOverlap), nil
+}
+
+// exportColumn 按指定时间列分页读取窗口内变更的帖子，每页发送一条快照事件。
+// - 返回导出的帖子数量与发送的事件数量。
+func (t *PostReconcileTask) exportColumn(ctx context.Context, column string, since, until time.Time) (int, int, error) {
+	var (
+		afterTime         time.Time
+		afterID           uint64
+		exported, batches int
+	)
+	for {
+		posts, err := t.postBatchRepo.ListChangedPostSnapshots(ctx, column, since, until, afterTime, afterID, constant.PostReconcilePageSize)
+		if err != nil {
+			return exported, batches, err
+		}
+		if len(posts) == 0 {
+			return exported, batches, nil
+		}
+
+		event := &producer.PostReconcileSnapshotEvent{
+			WindowStart: since,
+			WindowEnd:   until,
+			Posts:       make([]producer.PostSnapshot, 0, len(posts)),
+		}
+		for _, p := range posts {
+			event.Posts = append(event.Posts, producer.PostSnapshot{
+				ID:        p.ID,
+				Status:    p.Status,
+				Deleted:   p.DeletedAt.Valid,
+				UpdatedAt: p.UpdatedAt.UnixMilli(),
+			})
+		}
+		if err := t.kafkaSvc.SendPostReconcileSnapshotEvent(ctx, event); err != nil {
+			return exported, batches, err
+		}
+		exported += len(posts)
+		batches++
+
+		last := posts[len(posts)-1]
+		afterTime, afterID = reconcileCursorTime(last, column), last.ID
+		if len(posts) < constant.PostReconcilePageSize {
+			return exported, batches, nil
+		}
+	}
+}
+
+// reconcileCursorTime 返回帖子在指定时间列上的值，作为下一页的游标。
+func reconcileCursorTime(post *entities.Post, column string) time.Time {
+	if column == constant.PostReconcileColumnDeletedAt {
+		return post.DeletedAt.Time
+	}
+	return post.UpdatedAt
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *PostReconcileTask) Stop() context.Context {
+	t.logger.Info("正在停止帖子对账快照导出定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("帖子对账快照导出定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -0,0 +1,89 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	appConfig "github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// PostReportTask 负责按周期（日/周）定时生成帖子数据报表并上传 COS。
+// - 多副本部署时通过分布式锁保证每个周期只有一个实例生成报表。
+type PostReportTask struct {
+	reportService service.ReportService
+	lock          *dependencies.RedisLock
+	cfg           appConfig.ReportConfig
+	cron          *cron.Cron
+	logger        *core.ZapLogger
+}
+
+// NewPostReportTask 初始化并启动帖子数据报表定时任务。
+// - cfg.CronSpec 为空时，根据 cfg.Period 选择默认调度表达式。
+func NewPostReportTask(reportService service.ReportService, lock *dependencies.RedisLock, cfg appConfig.ReportConfig, logger *core.ZapLogger) *PostReportTask {
+	task := &PostReportTask{
+		reportService: reportService,
+		lock:          lock,
+		cfg:           cfg,
+		cron:          cron.New(),
+		logger:        logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *PostReportTask) startCronJob() {
+	schedule := t.cfg.CronSpec
+	if schedule == "" {
+		schedule = constant.ReportDailyCronSpec
+		if t.cfg.Period == constant.ReportPeriodWeekly {
+			schedule = constant.ReportWeeklyCronSpec
+		}
+	}
+	t.logger.Info("准备启动帖子数据报表定时任务", zap.String("schedule", schedule), zap.String("period", t.cfg.Period))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		t.logger.Info("帖子数据报表任务开始执行...")
+		startTime := time.Now()
+		// 报表需要全量扫描从库，给予较宽裕的超时时间
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ReportTimeout)
+		defer cancel()
+
+		runWithLock(ctx, t.lock, "帖子数据报表", t.logger, func(ctx context.Context) { t.generateReport(ctx, startTime) })
+	})
+	if err != nil {
+		t.logger.Fatal("添加帖子数据报表 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("帖子数据报表定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// generateReport 是定时任务执行的实际逻辑：生成一份报表并记录结果。
+func (t *PostReportTask) generateReport(ctx context.Context, startTime time.Time) {
+	report, err := t.reportService.GenerateReport(ctx, startTime)
+	if err != nil {
+		t.logger.Error("帖子数据报表生成失败", zap.Error(err))
+		return
+	}
+	t.logger.Info("帖子数据报表任务执行完毕",
+		zap.Uint64("reportID", report.ID),
+		zap.Int("rowCount", report.RowCount),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *PostReportTask) Stop() context.Context {
+	t.logger.Info("正在停止帖子数据报表定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("帖子数据报表定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
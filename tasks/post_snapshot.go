@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/service"
+)
+
+// PostSnapshotTask 负责每小时为活跃帖子生成指标快照，零点额外生成日级快照并清理过期快照。
+type PostSnapshotTask struct {
+	snapshotService service.PostSnapshotService
+	lock            *dependencies.RedisLock // 分布式锁，多副本部署时保证每轮只生成一份快照
+	cron            *cron.Cron
+	logger          *core.ZapLogger
+}
+
+// NewPostSnapshotTask 初始化并启动帖子指标快照定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+func NewPostSnapshotTask(snapshotService service.PostSnapshotService, lock *dependencies.RedisLock, logger *core.ZapLogger) *PostSnapshotTask {
+	task := &PostSnapshotTask{
+		snapshotService: snapshotService,
+		lock:            lock,
+		cron:            cron.New(),
+		logger:          logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *PostSnapshotTask) startCronJob() {
+	schedule := constant.SnapshotCronSpec
+	t.logger.Info("准备启动帖子指标快照定时任务", zap.String("schedule", schedule))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), constant.SnapshotTimeout)
+		defer cancel()
+
+		if !runWithLock(ctx, t.lock, "帖子指标快照", t.logger, func(ctx context.Context) { t.takeSnapshots(ctx, startTime) }) {
+			return
+		}
+		t.logger.Info("帖子指标快照任务执行完毕", zap.Duration("duration", time.Since(startTime)))
+	})
+	if err != nil {
+		t.logger.Fatal("添加帖子指标快照 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("帖子指标快照定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// takeSnapshots 是定时任务执行的实际逻辑：生成快照，零点那一轮顺带清理过期快照。
+func (t *PostSnapshotTask) takeSnapshots(ctx context.Context, now time.Time) {
+	written, err := t.snapshotService.TakeSnapshots(ctx, now)
+	if err != nil {
+		t.logger.Error("生成帖子指标快照失败", zap.Error(err), zap.Int("written", written))
+	}
+	if now.Hour() != 0 {
+		return
+	}
+	if err := t.snapshotService.PurgeExpiredSnapshots(ctx, now); err != nil {
+		t.logger.Error("清理过期帖子指标快照失败", zap.Error(err))
+	}
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *PostSnapshotTask) Stop() context.Context {
+	t.logger.Info("正在停止帖子指标快照定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("帖子指标快照定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -0,0 +1,114 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Xushengqwer/go-common/commonerrors"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+)
+
+// PostUploadCleanupTask 负责定时回收带图帖子创建过程中遗留的占位记录及其 COS 文件。
+// - 创建请求在上传或回填失败时会同步补偿；补偿失败或服务在上传途中退出时，占位记录会一直停留在图片上传中状态。
+// - 只回收创建时间早于 constant.PostUploadStaleAfter 的记录，避免误删仍在上传的帖子。
+// - 先删除记录中全部对象键对应的 COS 对象，成功后再删除占位记录；删除失败的帖子保留到下一轮重试。
+type PostUploadCleanupTask struct {
+	postRepo  mysql.PostRepository
+	cosClient dependencies.COSClientInterface
+	lock      *dependencies.RedisLock // 分布式锁，多副本部署时保证只有一个实例回收
+	cron      *cron.Cron
+	logger    *core.ZapLogger
+}
+
+// NewPostUploadCleanupTask 初始化并启动占位帖子回收定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+func NewPostUploadCleanupTask(postRepo mysql.PostRepository, cosClient dependencies.COSClientInterface, lock *dependencies.RedisLock, logger *core.ZapLogger) *PostUploadCleanupTask {
+	task := &PostUploadCleanupTask{
+		postRepo:  postRepo,
+		cosClient: cosClient,
+		lock:      lock,
+		cron:      cron.New(),
+		logger:    logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *PostUploadCleanupTask) startCronJob() {
+	schedule := constant.PostUploadCleanupCronSpec
+	t.logger.Info("准备启动占位帖子回收定时任务", zap.String("schedule", schedule))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constant.PostUploadCleanupTimeout)
+		defer cancel()
+
+		runWithLock(ctx, t.lock, "占位帖子回收", t.logger, t.cleanupStalePosts)
+	})
+	if err != nil {
+		t.logger.Fatal("添加占位帖子回收 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("占位帖子回收定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// cleanupStalePosts 是定时任务执行的实际回收逻辑，每轮最多处理 constant.PostUploadCleanupBatchSize 个帖子。
+func (t *PostUploadCleanupTask) cleanupStalePosts(ctx context.Context) {
+	stalePosts, err := t.postRepo.ListStaleUploadPendingPosts(ctx, time.Now().Add(-constant.PostUploadStaleAfter), constant.PostUploadCleanupBatchSize)
+	if err != nil {
+		t.logger.Error("查询遗留的占位帖子失败，跳过本次回收", zap.Error(err))
+		return
+	}
+	if len(stalePosts) == 0 {
+		return
+	}
+
+	purged, failed := 0, 0
+	for postID, objectKeys := range stalePosts {
+		if ctx.Err() != nil {
+			break
+		}
+		if t.purgePost(ctx, postID, objectKeys) {
+			purged++
+		} else {
+			failed++
+		}
+	}
+	t.logger.Info("占位帖子回收完成", zap.Int("found", len(stalePosts)), zap.Int("purged", purged), zap.Int("failed", failed))
+}
+
+// purgePost 删除单个占位帖子的 COS 对象与数据库记录，返回是否回收成功。
+func (t *PostUploadCleanupTask) purgePost(ctx context.Context, postID uint64, objectKeys []string) bool {
+	for _, objectKey := range objectKeys {
+		if err := t.cosClient.DeleteObject(ctx, objectKey); err != nil {
+			t.logger.Error("回收占位帖子时删除 COS 对象失败，下一轮重试",
+				zap.Error(err), zap.Uint64("postID", postID), zap.String("objectKey", objectKey))
+			return false
+		}
+	}
+	if err := t.postRepo.PurgeUploadPendingPost(ctx, postID); err != nil {
+		if errors.Is(err, commonerrors.ErrRepoNotFound) {
+			// 查询之后该帖子已完成上传或已被回收
+			return true
+		}
+		t.logger.Error("回收占位帖子时删除数据库记录失败，下一轮重试", zap.Error(err), zap.Uint64("postID", postID))
+		return false
+	}
+	return true
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *PostUploadCleanupTask) Stop() context.Context {
+	t.logger.Info("正在停止占位帖子回收定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("占位帖子回收定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -0,0 +1,36 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+// resolveCronSpec 返回配置的调度表达式，未配置时使用默认值。
+func resolveCronSpec(spec, defaultSpec string) string {
+	if spec == "" {
+		return defaultSpec
+	}
+	return spec
+}
+
+// ValidateTaskConfig 校验定时任务配置中的调度表达式（未配置的项按默认值校验），供启动时提前发现配置错误。
+// - cron 实例使用标准解析器，与 cron.ParseStandard 的规则一致。
+func ValidateTaskConfig(cfg config.TaskConfig) error {
+	specs := []struct {
+		field string
+		spec  string
+	}{
+		{field: "taskConfig.viewCountSyncCron", spec: resolveCronSpec(cfg.ViewCountSyncCron, constant.SyncViewCountInterval)},
+		{field: "taskConfig.hotCacheCron", spec: resolveCronSpec(cfg.HotCacheCron, constant.HotPostsCacheCronSpec)},
+	}
+	for _, s := range specs {
+		if _, err := cron.ParseStandard(s.spec); err != nil {
+			return fmt.Errorf("%s 的 cron 表达式 %q 无效: %w", s.field, s.spec, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+)
+
+func TestValidateTaskConfig(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       config.TaskConfig
+		wantField string // 为空表示应通过校验
+	}{
+		{name: "未配置时使用默认值", cfg: config.TaskConfig{}},
+		{name: "五段式表达式", cfg: config.TaskConfig{ViewCountSyncCron: "*/5 * * * *", HotCacheCron: "0 3 * * 1-5"}},
+		{name: "描述符", cfg: config.TaskConfig{ViewCountSyncCron: "@hourly", HotCacheCron: "@every 30s"}},
+		{name: "指定时区", cfg: config.TaskConfig{ViewCountSyncCron: "CRON_TZ=Asia/Shanghai 0 0 * * *"}},
+		{name: "标准解析器不支持秒字段", cfg: config.TaskConfig{ViewCountSyncCron: "0 0 0 * * *"}, wantField: "taskConfig.viewCountSyncCron"},
+		{name: "分钟越界", cfg: config.TaskConfig{ViewCountSyncCron: "61 * * * *"}, wantField: "taskConfig.viewCountSyncCron"},
+		{name: "@every 缺少间隔", cfg: config.TaskConfig{HotCacheCron: "@every"}, wantField: "taskConfig.hotCacheCron"},
+		{name: "任意文本", cfg: config.TaskConfig{HotCacheCron: "every 15 minutes"}, wantField: "taskConfig.hotCacheCron"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTaskConfig(tc.cfg)
+			if tc.wantField == "" {
+				if err != nil {
+					t.Fatalf("ValidateTaskConfig(%+v) = %v, want nil", tc.cfg, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantField) {
+				t.Fatalf("ValidateTaskConfig(%+v) = %v, want an error naming %s", tc.cfg, err, tc.wantField)
+			}
+		})
+	}
+}
+
+// 通过校验的表达式必须能被任务实际使用的 cron 实例注册，默认值同样如此。
+func TestValidatedSpecsAreAcceptedByScheduler(t *testing.T) {
+	specs := []string{constant.SyncViewCountInterval, constant.HotPostsCacheCronSpec, "*/5 * * * *", "@every 30s", "CRON_TZ=Asia/Shanghai 0 0 * * *"}
+	for _, spec := range specs {
+		if err := ValidateTaskConfig(config.TaskConfig{ViewCountSyncCron: spec, HotCacheCron: spec}); err != nil {
+			t.Fatalf("ValidateTaskConfig(%q): %v", spec, err)
+		}
+		if _, err := cron.New().AddFunc(spec, func() {}); err != nil {
+			t.Fatalf("cron.New().AddFunc(%q): %v", spec, err)
+		}
+	}
+}
+
+func TestResolveCronSpec(t *testing.T) {
+	if got := resolveCronSpec("", "@daily"); got != "@daily" {
+		t.Fatalf("resolveCronSpec(\"\") = %q, want the default", got)
+	}
+	if got := resolveCronSpec("@hourly", "@daily"); got != "@hourly" {
+		t.Fatalf("resolveCronSpec(\"@hourly\") = %q, want the configured spec", got)
+	}
+}
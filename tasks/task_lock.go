@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/dependencies"
+)
+
+// taskUnlockTimeout 是释放任务锁的超时时间。任务 ctx 可能已经超时，因此释放锁使用独立的 ctx。
+const taskUnlockTimeout = 5 * time.Second
+
+// NewTaskLock 根据配置创建定时任务使用的分布式锁。
+// - key 为空时使用 defaultKey；ttl 为 0 或不大于任务超时 taskTimeout 时使用 defaultTTL（并记录警告），保证锁不会在任务执行期间过期。
+func NewTaskLock(client goredis.Cmdable, key string, ttl time.Duration, defaultKey string, defaultTTL, taskTimeout time.Duration, logger *core.ZapLogger) *dependencies.RedisLock {
+	if key == "" {
+		key = defaultKey
+	}
+	if ttl <= taskTimeout {
+		if ttl > 0 {
+			logger.Warn("任务锁 TTL 不大于任务超时时间，使用默认 TTL",
+				zap.String("lockKey", key),
+				zap.Duration("configuredTTL", ttl),
+				zap.Duration("taskTimeout", taskTimeout),
+				zap.Duration("defaultTTL", defaultTTL),
+			)
+		}
+		ttl = defaultTTL
+	}
+	return dependencies.NewRedisLock(client, key, ttl)
+}
+
+// runWithLock 在持有分布式锁的前提下执行 fn。
+// - 锁被其他实例持有时跳过本次执行；获取锁出错时同样跳过，宁可少跑一次也不重复执行。
+// - lock 为 nil 时直接执行 fn（单实例部署或未启用锁）。
+// - 返回 fn 是否被执行。
+func runWithLock(ctx context.Context, lock *dependencies.RedisLock, taskName string, logger *core.ZapLogger, fn func(ctx context.Context)) bool {
+	if lock == nil {
+		fn(ctx)
+		return true
+	}
+
+	token, acquired, err := lock.TryLock(ctx)
+	if err != nil {
+		logger.Error("获取任务分布式锁失败，跳过本次执行", zap.String("task", taskName), zap.String("lockKey", lock.Key()), zap.Error(err))
+		return false
+	}
+	if !acquired {
+		logger.Info("任务分布式锁已被其他实例持有，跳过本次执行", zap.String("task", taskName), zap.String("lockKey", lock.Key()))
+		return false
+	}
+
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.Background(), taskUnlockTimeout)
+		defer cancel()
+		released, err := lock.Unlock(unlockCtx, token)
+		if err != nil {
+			logger.Error("释放任务分布式锁失败，锁将在 TTL 到期后自动释放", zap.String("task", taskName), zap.String("lockKey", lock.Key()), zap.Error(err))
+			return
+		}
+		if !released {
+			logger.Warn("任务分布式锁在执行结束前已过期，TTL 可能小于任务执行时长", zap.String("task", taskName), zap.String("lockKey", lock.Key()), zap.Duration("ttl", lock.TTL()))
+		}
+	}()
+
+	fn(ctx)
+	return true
+}
@@ -0,0 +1,132 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Xushengqwer/go-common/config"
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Xushengqwer/post_service/dependencies"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+func newTestLogger(t *testing.T) *core.ZapLogger {
+	t.Helper()
+	logger, err := core.NewZapLogger(config.ZapConfig{Level: "error", Encoding: "console"})
+	if err != nil {
+		t.Fatalf("创建 logger 失败: %v", err)
+	}
+	return logger
+}
+
+func TestNewTaskLockDefaults(t *testing.T) {
+	_, client := newTestRedis(t)
+	logger := newTestLogger(t)
+
+	cases := []struct {
+		name    string
+		key     string
+		ttl     time.Duration
+		wantKey string
+		wantTTL time.Duration
+	}{
+		{name: "未配置时使用默认值", wantKey: "task_lock:default", wantTTL: 10 * time.Minute},
+		{name: "使用配置的 Key 与 TTL", key: "task_lock:custom", ttl: 8 * time.Minute, wantKey: "task_lock:custom", wantTTL: 8 * time.Minute},
+		{name: "TTL 不大于任务超时时退回默认值", key: "task_lock:custom", ttl: 5 * time.Minute, wantKey: "task_lock:custom", wantTTL: 10 * time.Minute},
+		{name: "TTL 小于任务超时时退回默认值", ttl: time.Minute, wantKey: "task_lock:default", wantTTL: 10 * time.Minute},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lock := NewTaskLock(client, tc.key, tc.ttl, "task_lock:default", 10*time.Minute, 5*time.Minute, logger)
+			if lock.Key() != tc.wantKey || lock.TTL() != tc.wantTTL {
+				t.Fatalf("lock = (%q, %v), want (%q, %v)", lock.Key(), lock.TTL(), tc.wantKey, tc.wantTTL)
+			}
+		})
+	}
+}
+
+func TestRunWithLockReleasesAfterRun(t *testing.T) {
+	mr, client := newTestRedis(t)
+	lock := dependencies.NewRedisLock(client, "task_lock:test", time.Minute)
+
+	var heldDuringRun bool
+	ran := runWithLock(context.Background(), lock, "test", newTestLogger(t), func(context.Context) {
+		heldDuringRun = mr.Exists("task_lock:test")
+	})
+	if !ran || !heldDuringRun {
+		t.Fatalf("ran = %v, heldDuringRun = %v, want the task to run while holding the lock", ran, heldDuringRun)
+	}
+	if mr.Exists("task_lock:test") {
+		t.Fatal("lock still held after the task finished")
+	}
+}
+
+func TestRunWithLockSkipsWhenContended(t *testing.T) {
+	_, client := newTestRedis(t)
+	holder := dependencies.NewRedisLock(client, "task_lock:test", time.Minute)
+	if _, ok, err := holder.TryLock(context.Background()); err != nil || !ok {
+		t.Fatalf("pre-acquire: %v, %v", ok, err)
+	}
+
+	lock := dependencies.NewRedisLock(client, "task_lock:test", time.Minute)
+	ran := runWithLock(context.Background(), lock, "test", newTestLogger(t), func(context.Context) {
+		t.Fatal("task ran while another instance held the lock")
+	})
+	if ran {
+		t.Fatal("runWithLock reported the task as run")
+	}
+}
+
+func TestRunWithLockSkipsOnRedisError(t *testing.T) {
+	mr, client := newTestRedis(t)
+	lock := dependencies.NewRedisLock(client, "task_lock:test", time.Minute)
+	mr.Close()
+
+	ran := runWithLock(context.Background(), lock, "test", newTestLogger(t), func(context.Context) {
+		t.Fatal("task ran although the lock could not be acquired")
+	})
+	if ran {
+		t.Fatal("runWithLock reported the task as run")
+	}
+}
+
+func TestRunWithLockDoesNotReleaseLockTakenAfterExpiry(t *testing.T) {
+	mr, client := newTestRedis(t)
+	lock := dependencies.NewRedisLock(client, "task_lock:test", time.Second)
+	other := dependencies.NewRedisLock(client, "task_lock:test", time.Minute)
+
+	var otherToken string
+	ran := runWithLock(context.Background(), lock, "test", newTestLogger(t), func(ctx context.Context) {
+		// 任务执行超过 TTL，锁过期后被其他实例获取
+		mr.FastForward(2 * time.Second)
+		token, ok, err := other.TryLock(ctx)
+		if err != nil || !ok {
+			t.Fatalf("other TryLock after expiry = %v, %v", ok, err)
+		}
+		otherToken = token
+	})
+	if !ran {
+		t.Fatal("task did not run")
+	}
+	if got, _ := mr.Get("task_lock:test"); got != otherToken {
+		t.Fatalf("lock value = %q, want the other instance's lock %q to survive", got, otherToken)
+	}
+}
+
+func TestRunWithNilLockRunsDirectly(t *testing.T) {
+	var ran bool
+	if !runWithLock(context.Background(), nil, "test", newTestLogger(t), func(context.Context) { ran = true }) || !ran {
+		t.Fatal("runWithLock with a nil lock did not run the task")
+	}
+}
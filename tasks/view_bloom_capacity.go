@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/metrics"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// ViewBloomCapacityTask 负责定期检查热榜帖子浏览去重 Bloom Filter 的填充率与内存占用，接近容量时自动扩容，避免超热帖的误判率上升吞掉真实浏览。
+// - 只检查当前热榜 (constant.HotPostsRankKey) 内的帖子：访问用户多的帖子才可能接近容量。
+// - 扩容由 Lua 脚本原子完成，同一帖子在迁移期间不会被重复扩容，多副本同时执行也无副作用，因此不加分布式锁。
+type ViewBloomCapacityTask struct {
+	postViewRepo redis.PostViewRepository
+	postCache    redis.PostReadCache     // 读取当前热榜中的帖子
+	topN         int                     // 每次检查的热榜帖子数量
+	fillRatio    float64                 // 触发扩容的填充率
+	metrics      metrics.MetricsReporter // 内存占用、最高填充率与扩容次数，为 nil 时不上报
+	cron         *cron.Cron
+	logger       *core.ZapLogger
+}
+
+// NewViewBloomCapacityTask 初始化并启动 Bloom Filter 扩容检查任务。
+// - viewCountCfg.BloomCheckTopN、BloomFillRatio 未配置时退回 constant.BloomCapacityCheckTopN、constant.BloomFillRatioThreshold。
+func NewViewBloomCapacityTask(postViewRepo redis.PostViewRepository, postCache redis.PostReadCache, viewCountCfg config.ViewCountConfig, reporter metrics.MetricsReporter, logger *core.ZapLogger) *ViewBloomCapacityTask {
+	topN := viewCountCfg.BloomCheckTopN
+	if topN <= 0 {
+		topN = constant.BloomCapacityCheckTopN
+	}
+	fillRatio := viewCountCfg.BloomFillRatio
+	if fillRatio <= 0 || fillRatio > 1 {
+		fillRatio = constant.BloomFillRatioThreshold
+	}
+	task := &ViewBloomCapacityTask{
+		postViewRepo: postViewRepo,
+		postCache:    postCache,
+		topN:         topN,
+		fillRatio:    fillRatio,
+		metrics:      reporter,
+		cron:         cron.New(),
+		logger:       logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *ViewBloomCapacityTask) startCronJob() {
+	schedule := constant.BloomCapacityCheckCronSpec
+	entryID, err := t.cron.AddFunc(schedule, t.expandBloomFilters)
+	if err != nil {
+		t.logger.Fatal("添加 Bloom Filter 扩容检查 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+	t.cron.Start()
+	t.logger.Info("Bloom Filter 扩容检查任务已启动", zap.String("schedule", schedule), zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// expandBloomFilters 执行一次检查：读取热榜帖子过滤器的信息并上报内存占用，对已插入数量达到容量 fillRatio 的过滤器扩容重建。
+// - 单个帖子扩容失败只记录日志，下一轮重试，不影响其余帖子。
+func (t *ViewBloomCapacityTask) expandBloomFilters() {
+	ctx, cancel := context.WithTimeout(context.Background(), constant.BloomCapacityCheckTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	postIDs, err := t.postCache.GetPostsByRange(ctx, 0, int64(t.topN-1))
+	if err != nil {
+		t.logger.Error("Bloom Filter 扩容检查读取热榜失败", zap.Error(err))
+		return
+	}
+	infos, err := t.postViewRepo.GetBloomInfo(ctx, postIDs)
+	if err != nil {
+		t.logger.Error("Bloom Filter 扩容检查读取过滤器信息失败", zap.Error(err))
+		return
+	}
+
+	var totalBytes int64
+	var maxFill float64
+	var expanded int
+	for _, postID := range postIDs {
+		info, ok := infos[postID]
+		if !ok {
+			continue
+		}
+		totalBytes += info.SizeBytes
+		if info.Capacity <= 0 {
+			continue
+		}
+		fill := float64(info.Items) / float64(info.Capacity)
+		maxFill = max(maxFill, fill)
+		if fill < t.fillRatio {
+			continue
+		}
+
+		e, err := t.postViewRepo.RebuildBloom(ctx, postID, info)
+		if err != nil {
+			t.logger.Error("Bloom Filter 扩容失败，下一轮重试", zap.Error(err), zap.Uint64("postID", postID))
+			continue
+		}
+		if e == nil {
+			continue
+		}
+		expanded++
+		t.logger.Warn("浏览去重 Bloom Filter 已扩容",
+			zap.Uint64("postID", e.PostID),
+			zap.Int64("items", e.Items),
+			zap.Int64("capacity", e.Capacity),
+			zap.Int64("subFilters", e.Filters),
+			zap.Int64("sizeBytes", info.SizeBytes),
+			zap.Int64("newCapacity", e.NewCapacity))
+	}
+
+	metrics.SetTaskGauge(t.metrics, constant.BloomCapacityTaskName, constant.BloomCapacityGaugeMemoryBytes, float64(totalBytes))
+	metrics.SetTaskGauge(t.metrics, constant.BloomCapacityTaskName, constant.BloomCapacityGaugeMaxFill, maxFill)
+	metrics.AddTaskEvents(t.metrics, constant.BloomCapacityTaskName, constant.BloomCapacityEventRebuilt, expanded)
+	t.logger.Debug("Bloom Filter 扩容检查完成",
+		zap.Int("hotPosts", len(postIDs)),
+		zap.Int("filters", len(infos)),
+		zap.Int64("memoryBytes", totalBytes),
+		zap.Float64("maxFillRatio", maxFill),
+		zap.Int("expanded", expanded),
+		zap.Duration("duration", time.Since(startTime)))
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *ViewBloomCapacityTask) Stop() context.Context {
+	t.logger.Info("正在停止 Bloom Filter 扩容检查任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("Bloom Filter 扩容检查任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// ViewCountArchiveTask 负责每天将长期无浏览增量的帖子浏览量计数器从 Redis 归档到 MySQL 并删除，控制 Redis 内存占用。
+// - 热榜中的帖子始终保留在 Redis；被归档的帖子再次被浏览时，计数器会从 MySQL 回源。
+type ViewCountArchiveTask struct {
+	postViewRepo  redis.PostViewRepository
+	lock          *dependencies.RedisLock // 与浏览量同步任务共用的分布式锁，两者不会同时写 view_count
+	coldThreshold time.Duration
+	batchSize     int
+	cron          *cron.Cron
+	logger        *core.ZapLogger
+}
+
+// NewViewCountArchiveTask 初始化并启动浏览量冷数据归档定时任务。
+// - lock 应与浏览量同步任务使用同一把锁，为 nil 时不加锁。
+func NewViewCountArchiveTask(postViewRepo redis.PostViewRepository, lock *dependencies.RedisLock, viewCountCfg config.ViewCountConfig, logger *core.ZapLogger) *ViewCountArchiveTask {
+	task := &ViewCountArchiveTask{
+		postViewRepo:  postViewRepo,
+		lock:          lock,
+		coldThreshold: viewCountCfg.ColdThreshold,
+		batchSize:     viewCountCfg.ArchiveBatchSize,
+		cron:          cron.New(),
+		logger:        logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *ViewCountArchiveTask) startCronJob() {
+	schedule := constant.ViewCountArchiveCronSpec
+	t.logger.Info("准备启动浏览量冷数据归档定时任务", zap.String("schedule", schedule))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		t.logger.Info("浏览量冷数据归档任务开始执行...")
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ViewCountArchiveTimeout)
+		defer cancel()
+
+		if !runWithLock(ctx, t.lock, "浏览量冷数据归档", t.logger, t.archiveColdViewCounts) {
+			return
+		}
+		t.logger.Info("浏览量冷数据归档任务执行完毕", zap.Duration("duration", time.Since(startTime)))
+	})
+	if err != nil {
+		t.logger.Fatal("添加浏览量冷数据归档 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("浏览量冷数据归档定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// archiveColdViewCounts 是定时任务执行的实际归档逻辑。
+// - 中途失败时已归档的部分保持有效，剩余部分留到下次执行。
+func (t *ViewCountArchiveTask) archiveColdViewCounts(ctx context.Context) {
+	archived, err := t.postViewRepo.ArchiveColdViewCounts(ctx, t.coldThreshold, t.batchSize)
+	if err != nil {
+		t.logger.Error("浏览量冷数据归档未全部完成，剩余部分将在下次执行时继续", zap.Error(err), zap.Int("archived", archived))
+		return
+	}
+	t.logger.Info("浏览量冷数据归档成功", zap.Int("archived", archived))
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *ViewCountArchiveTask) Stop() context.Context {
+	t.logger.Info("正在停止浏览量冷数据归档定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("浏览量冷数据归档定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -0,0 +1,238 @@
+package tasks
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/config"
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/models/entities"
+	"github.com/Xushengqwer/post_service/repo/mysql"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// ViewCountConsistencyTask 负责每天抽样比对 Redis 浏览量计数器与 MySQL posts.view_count，
+// 发现因同步失败或清理逻辑缺陷产生的显著漂移时记录并告警，可选以 Redis 为准修正 MySQL。
+// - 从随机的帖子 ID 起按主键分批抽样，每次只读取 SampleSize 个帖子，不做全量扫描。
+// - 仍在脏集合/同步中集合里的帖子尚未同步，其差值属于正常延迟，不参与比对；Redis 中没有计数器的帖子同样跳过。
+type ViewCountConsistencyTask struct {
+	postViewRepo  redis.PostViewRepository
+	postBatchRepo mysql.PostBatchOperationsRepository
+	lock          *dependencies.RedisLock // 与浏览量同步任务共用的分布式锁，校验期间不会有同步或归档写 view_count
+	cfg           config.ViewConsistencyConfig
+	cron          *cron.Cron
+	logger        *core.ZapLogger
+}
+
+// viewCountDrift 描述一个显著漂移的帖子。
+type viewCountDrift struct {
+	PostID     uint64
+	RedisCount int64
+	DBCount    int64
+}
+
+// NewViewCountConsistencyTask 初始化并启动浏览量一致性校验定时任务。
+// - lock 应与浏览量同步任务使用同一把锁，为 nil 时不加锁。
+// - cfg 中未配置（为 0）的参数退回 constant.ViewConsistency* 默认值。
+func NewViewCountConsistencyTask(postViewRepo redis.PostViewRepository, postBatchRepo mysql.PostBatchOperationsRepository, lock *dependencies.RedisLock, cfg config.ViewConsistencyConfig, logger *core.ZapLogger) *ViewCountConsistencyTask {
+	if cfg.SampleSize <= 0 {
+		cfg.SampleSize = constant.ViewConsistencySampleSize
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = constant.ViewConsistencyBatchSize
+	}
+	if cfg.MinAbsDrift <= 0 {
+		cfg.MinAbsDrift = constant.ViewConsistencyMinAbsDrift
+	}
+	if cfg.MinDriftRatio <= 0 {
+		cfg.MinDriftRatio = constant.ViewConsistencyMinDriftRatio
+	}
+	task := &ViewCountConsistencyTask{
+		postViewRepo:  postViewRepo,
+		postBatchRepo: postBatchRepo,
+		lock:          lock,
+		cfg:           cfg,
+		cron:          cron.New(),
+		logger:        logger,
+	}
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *ViewCountConsistencyTask) startCronJob() {
+	schedule := constant.ViewConsistencyCheckCronSpec
+	t.logger.Info("准备启动浏览量一致性校验定时任务", zap.String("schedule", schedule), zap.Any("config", t.cfg))
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		t.logger.Info("浏览量一致性校验任务开始执行...")
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ViewConsistencyCheckTimeout)
+		defer cancel()
+
+		if !runWithLock(ctx, t.lock, "浏览量一致性校验", t.logger, t.checkViewCounts) {
+			return
+		}
+		t.logger.Info("浏览量一致性校验任务执行完毕", zap.Duration("duration", time.Since(startTime)))
+	})
+	if err != nil {
+		t.logger.Fatal("添加浏览量一致性校验 cron 作业失败", zap.Error(err), zap.String("schedule", schedule))
+	}
+
+	t.cron.Start()
+	t.logger.Info("浏览量一致性校验定时任务已启动", zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// checkViewCounts 是定时任务执行的实际校验逻辑。
+// - 从 [0, 最大帖子 ID) 中随机选取起点向后分批读取，读到末尾后从头回绕，直到抽满 SampleSize 或回到起点。
+func (t *ViewCountConsistencyTask) checkViewCounts(ctx context.Context) {
+	maxID, err := t.postBatchRepo.GetMaxPostID(ctx)
+	if err != nil {
+		t.logger.Error("浏览量一致性校验获取帖子 ID 范围失败，跳过本次校验", zap.Error(err))
+		return
+	}
+	if maxID == 0 {
+		t.logger.Info("没有帖子，跳过浏览量一致性校验")
+		return
+	}
+
+	start := rand.Uint64N(maxID)
+	cursor, wrapped := start, false
+	var (
+		sampled, compared int
+		drifts            []viewCountDrift
+	)
+	for sampled < t.cfg.SampleSize {
+		limit := min(t.cfg.BatchSize, t.cfg.SampleSize-sampled)
+		posts, err := t.postBatchRepo.ListPostViewCountsAfter(ctx, cursor, limit)
+		if err != nil {
+			t.logger.Error("浏览量一致性校验读取帖子失败，提前结束本次校验", zap.Error(err), zap.Uint64("cursor", cursor))
+			break
+		}
+		if wrapped {
+			// 回绕后只读到起点为止，避免重复抽样
+			for i, p := range posts {
+				if p.ID > start {
+					posts = posts[:i]
+					break
+				}
+			}
+		}
+		if len(posts) == 0 {
+			if wrapped || start == 0 {
+				break
+			}
+			cursor, wrapped = 0, true
+			continue
+		}
+		cursor = posts[len(posts)-1].ID
+		sampled += len(posts)
+
+		batchCompared, batchDrifts, err := t.compareBatch(ctx, posts)
+		if err != nil {
+			t.logger.Error("浏览量一致性校验读取 Redis 计数失败，提前结束本次校验", zap.Error(err), zap.Uint64("cursor", cursor))
+			break
+		}
+		compared += batchCompared
+		drifts = append(drifts, batchDrifts...)
+	}
+
+	t.reportDrifts(ctx, sampled, compared, drifts)
+}
+
+// compareBatch 比对一批帖子的 Redis 与 MySQL 浏览量，返回参与比对的帖子数量与显著漂移的帖子。
+func (t *ViewCountConsistencyTask) compareBatch(ctx context.Context, posts []*entities.Post) (int, []viewCountDrift, error) {
+	postIDs := make([]uint64, 0, len(posts))
+	for _, p := range posts {
+		postIDs = append(postIDs, p.ID)
+	}
+	redisCounts, err := t.postViewRepo.GetViewCountsByIDs(ctx, postIDs)
+	if err != nil {
+		return 0, nil, err
+	}
+	pending, err := t.postViewRepo.GetPendingSyncPostIDs(ctx, postIDs)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	compared := 0
+	var drifts []viewCountDrift
+	for _, p := range posts {
+		redisCount, ok := redisCounts[p.ID]
+		if !ok || pending[p.ID] {
+			continue
+		}
+		compared++
+		if t.isSignificantDrift(redisCount, p.ViewCount) {
+			drifts = append(drifts, viewCountDrift{PostID: p.ID, RedisCount: redisCount, DBCount: p.ViewCount})
+		}
+	}
+	return compared, drifts, nil
+}
+
+// isSignificantDrift 判断差值是否同时超过绝对阈值与相对阈值。
+func (t *ViewCountConsistencyTask) isSignificantDrift(redisCount, dbCount int64) bool {
+	diff := redisCount - dbCount
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff < t.cfg.MinAbsDrift {
+		return false
+	}
+	return float64(diff) >= t.cfg.MinDriftRatio*float64(max(redisCount, dbCount))
+}
+
+// reportDrifts 汇总校验结果：存在显著漂移时以 Error 级别记录告警，并按配置修正 MySQL。
+func (t *ViewCountConsistencyTask) reportDrifts(ctx context.Context, sampled, compared int, drifts []viewCountDrift) {
+	if len(drifts) == 0 {
+		t.logger.Info("浏览量一致性校验通过", zap.Int("sampled", sampled), zap.Int("compared", compared))
+		return
+	}
+
+	fixes := make(map[uint64]int64)
+	redisLower := 0
+	for i, d := range drifts {
+		if d.RedisCount > d.DBCount {
+			fixes[d.PostID] = d.RedisCount
+		} else {
+			redisLower++
+		}
+		if i < constant.ViewConsistencyMaxLoggedPosts {
+			t.logger.Warn("帖子浏览量漂移",
+				zap.Uint64("postID", d.PostID),
+				zap.Int64("redisCount", d.RedisCount),
+				zap.Int64("dbCount", d.DBCount))
+		}
+	}
+	t.logger.Error("浏览量一致性校验发现显著漂移（告警）",
+		zap.Int("sampled", sampled),
+		zap.Int("compared", compared),
+		zap.Int("drifted", len(drifts)),
+		zap.Int("redisLowerThanDB", redisLower),
+		zap.Int64("minAbsDrift", t.cfg.MinAbsDrift),
+		zap.Float64("minDriftRatio", t.cfg.MinDriftRatio),
+		zap.Bool("autoFix", t.cfg.AutoFix))
+
+	if !t.cfg.AutoFix || len(fixes) == 0 {
+		return
+	}
+	if err := t.postBatchRepo.BatchUpdatePostViewCounts(ctx, fixes); err != nil {
+		t.logger.Error("以 Redis 为准修正 MySQL 浏览量失败", zap.Error(err), zap.Int("posts", len(fixes)))
+		return
+	}
+	t.logger.Info("已以 Redis 为准修正 MySQL 浏览量", zap.Int("posts", len(fixes)))
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *ViewCountConsistencyTask) Stop() context.Context {
+	t.logger.Info("正在停止浏览量一致性校验定时任务...")
+	stopCtx := t.cron.Stop()
+	t.logger.Info("浏览量一致性校验定时任务已停止调度。等待正在执行的任务完成...")
+	return stopCtx
+}
@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Xushengqwer/go-common/core"
@@ -9,8 +10,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/dependencies"
+	"github.com/Xushengqwer/post_service/metrics"
 	"github.com/Xushengqwer/post_service/repo/mysql" // 确保导入的是包含 PostBatchOperationsRepository 的包
 	"github.com/Xushengqwer/post_service/repo/redis"
+	"github.com/Xushengqwer/post_service/service"
 )
 
 // ViewCountSyncTask 负责定时将 Redis 中的帖子浏览量同步到 MySQL 数据库。
@@ -18,20 +22,45 @@ import (
 type ViewCountSyncTask struct {
 	postViewRepo  redis.PostViewRepository            // Redis 仓库，用于获取浏览量
 	postBatchRepo mysql.PostBatchOperationsRepository // MySQL 批量操作仓库，用于更新浏览量
+	retryStore    redis.ViewCountRetryStore           // 写入失败批次的重试记录，为 nil 时不持久化失败批次
+	lock          *dependencies.RedisLock             // 分布式锁，多副本部署时保证只有一个实例执行同步
+	metrics       metrics.MetricsReporter             // 失败批次与重试结果的事件计数，为 nil 时不上报
+	syncMode      string                              // 同步数据来源: constant.ViewSyncModeIncremental / constant.ViewSyncModeFull
+	schedule      string                              // cron 调度表达式
+	badgeSvc      service.BadgeService                // 浏览量里程碑徽章颁发，为 nil 时不颁发
 	cron          *cron.Cron                          // cron V3 实例
 	logger        *core.ZapLogger                     // 日志记录器
 }
 
 // NewViewCountSyncTask 初始化并启动浏览量同步的定时任务。
+// - lock 为 nil 时不加锁，每次调度都会执行。
+// - retryStore 为 nil 时失败批次只记录日志，依赖增量模式保留的脏集合在下一轮重试。
+// - syncMode 为空或无法识别时按增量模式同步。
+// - schedule 为空时使用 constant.SyncViewCountInterval，应在启动时先经 ValidateTaskConfig 校验。
 func NewViewCountSyncTask(
 	postViewRepo redis.PostViewRepository,
 	postBatchRepo mysql.PostBatchOperationsRepository, // 修改依赖为 PostBatchOperationsRepository
+	retryStore redis.ViewCountRetryStore,
+	lock *dependencies.RedisLock,
+	reporter metrics.MetricsReporter,
+	syncMode string,
+	schedule string,
+	badgeSvc service.BadgeService,
 	logger *core.ZapLogger,
 ) *ViewCountSyncTask {
+	if syncMode != constant.ViewSyncModeFull {
+		syncMode = constant.ViewSyncModeIncremental
+	}
 	cronV3 := cron.New() // 默认分钟级精度
 	task := &ViewCountSyncTask{
 		postViewRepo:  postViewRepo,
 		postBatchRepo: postBatchRepo, // 修改赋值
+		retryStore:    retryStore,
+		lock:          lock,
+		metrics:       reporter,
+		syncMode:      syncMode,
+		schedule:      resolveCronSpec(schedule, constant.SyncViewCountInterval),
+		badgeSvc:      badgeSvc,
 		cron:          cronV3,
 		logger:        logger,
 	}
@@ -40,20 +69,22 @@ func NewViewCountSyncTask(
 }
 
 // startCronJob 配置并启动 cron 作业。
-// 使用 constant.SyncViewCountInterval 定义的 cron 表达式来调度 syncViewCountsToDB 方法。
+// 使用配置的 cron 表达式（默认 constant.SyncViewCountInterval）来调度 syncViewCountsToDB 方法。
 func (t *ViewCountSyncTask) startCronJob() {
-	schedule := constant.SyncViewCountInterval
+	schedule := t.schedule
 	t.logger.Info("准备启动帖子浏览量同步MySQL定时任务", zap.String("schedule", schedule))
 
 	entryID, err := t.cron.AddFunc(schedule, func() {
 		t.logger.Info("帖子浏览量同步MySQL任务开始执行...")
 		startTime := time.Now()
-		// 为单次任务执行设置超时，例如 3 分钟。
-		// 这个超时应该足够完成 Redis 数据获取和 MySQL 批量更新。
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+		// 为单次任务执行设置超时，这个超时应该足够完成 Redis 数据获取和 MySQL 批量更新。
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ViewCountSyncTimeout)
 		defer cancel()
 
-		t.syncViewCountsToDB(ctx) // 调用核心同步逻辑
+		// 多副本部署时只有拿到锁的实例执行同步，避免重复写库
+		if !runWithLock(ctx, t.lock, "浏览量同步", t.logger, t.syncViewCountsToDB) {
+			return
+		}
 
 		duration := time.Since(startTime)
 		t.logger.Info("帖子浏览量同步MySQL任务执行完毕", zap.Duration("duration", duration))
@@ -69,21 +100,39 @@ func (t *ViewCountSyncTask) startCronJob() {
 }
 
 // syncViewCountsToDB 是定时任务执行的实际同步逻辑。
-// 1. 从 Redis 获取全量的帖子浏览量数据。
-// 2. 调用 MySQL 仓库的 BatchUpdatePostViewCount 方法批量更新到数据库。
+// 0. 先重试此前持久化的失败批次。
+// 1. 从 Redis 获取帖子浏览量数据：增量模式只读取脏集合中的帖子，全量模式 SCAN 所有计数器。
+// 2. 调用 MySQL 仓库的 BatchUpdatePostViewCount 方法批量更新到数据库，失败批次的浏览量持久化到重试存储。
+// 3. 增量模式下全部批次写入成功才确认脏集合，否则保留脏标记到下一轮重试。
 func (t *ViewCountSyncTask) syncViewCountsToDB(ctx context.Context) {
-	t.logger.Info("任务步骤1: 开始从 Redis 获取全量帖子浏览量...")
-	// 调用 PostViewRepository 的 GetAllViewCounts 方法
-	viewCounts, err := t.postViewRepo.GetAllViewCounts(ctx)
+	t.retryFailedBatches(ctx)
+
+	incremental := t.syncMode != constant.ViewSyncModeFull
+
+	var (
+		viewCounts map[uint64]int64
+		err        error
+	)
+	if incremental {
+		t.logger.Info("任务步骤1: 开始从 Redis 获取脏集合中的帖子浏览量...")
+		viewCounts, err = t.postViewRepo.GetDirtyViewCounts(ctx)
+	} else {
+		t.logger.Info("任务步骤1: 开始从 Redis 获取全量帖子浏览量...")
+		viewCounts, err = t.postViewRepo.GetAllViewCounts(ctx)
+	}
 	if err != nil {
 		// 如果从 Redis 获取数据失败，记录错误并中止本次同步。
-		t.logger.Error("从 Redis 获取全量浏览量失败，本次同步中止。", zap.Error(err))
+		t.logger.Error("从 Redis 获取浏览量失败，本次同步中止。", zap.Error(err), zap.Bool("incremental", incremental))
 		return
 	}
 
 	countFromRedis := len(viewCounts)
 	if countFromRedis == 0 {
 		t.logger.Info("从 Redis 获取到的浏览量数据为空，无需同步到 MySQL。")
+		// 脏集合中的帖子可能都已被归档，同样需要确认，避免下一轮重复读取
+		if incremental {
+			_ = t.postViewRepo.AckDirtyViewCounts(ctx)
+		}
 		return // 没有数据需要同步
 	}
 	t.logger.Info("任务步骤1: 成功从 Redis 获取到浏览量数据。", zap.Int("帖子数量", countFromRedis))
@@ -99,9 +148,135 @@ func (t *ViewCountSyncTask) syncViewCountsToDB(ctx context.Context) {
 			zap.Error(err),
 			zap.Int("提交数量", countFromRedis),
 		)
+		t.persistFailedBatches(ctx, err)
+		if incremental {
+			t.logger.Warn("部分批次写入失败，保留浏览量脏集合到下一轮重试", zap.Int("提交数量", countFromRedis))
+		}
 	} else {
 		// 这里的日志表示调用已完成。实际的成功/失败情况需查看 BatchUpdatePostViewCount 的内部日志。
 		t.logger.Info("任务步骤2: 调用 MySQL 批量更新浏览量操作已完成。", zap.Int("提交数量", countFromRedis))
+		if incremental {
+			// 确认失败只会导致下一轮重复同步这些帖子，写入的是绝对值，重复同步无副作用
+			_ = t.postViewRepo.AckDirtyViewCounts(ctx)
+		}
+		t.awardViewMilestones(ctx, viewCounts)
+	}
+}
+
+// retryFailedBatches 重试此前持久化的失败批次，每轮至多读取 constant.ViewCountRetryReadLimit 条记录。
+//   - 重试以 GREATEST 写入 (ArchivePostViewCounts)，记录中的浏览量可能早于之后已成功同步的值，只增不减避免回退。
+//   - 重试失败的记录递增重试次数后追加回队尾；超过 constant.ViewCountRetryMaxAttempts 次的记录告警后丢弃。
+//   - 重新失败的记录追加成功后才移除已读取的记录，中途退出时最多重复重试一次，写入的是下限值，重复无副作用。
+func (t *ViewCountSyncTask) retryFailedBatches(ctx context.Context) {
+	if t.retryStore == nil {
+		return
+	}
+	batches, read, err := t.retryStore.List(ctx, constant.ViewCountRetryReadLimit)
+	if err != nil {
+		t.logger.Error("读取浏览量失败批次的重试记录失败，本轮跳过重试", zap.Error(err))
+		return
+	}
+	if read == 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	var requeue []*redis.ViewCountRetryBatch
+	var recovered, dropped int
+	for _, batch := range batches {
+		err := t.postBatchRepo.ArchivePostViewCounts(ctx, batch.Counts)
+		switch {
+		case err == nil:
+			recovered++
+		case ctx.Err() != nil:
+			// 任务超时导致的失败不计入重试次数
+			requeue = append(requeue, batch)
+		default:
+			batch.Attempts++
+			batch.LastFailedAt = now
+			if batch.Attempts < constant.ViewCountRetryMaxAttempts {
+				requeue = append(requeue, batch)
+				continue
+			}
+			dropped++
+			t.logger.Error("浏览量失败批次超过最大重试次数，已丢弃，需人工核对或执行全量同步",
+				zap.Error(err),
+				zap.Int("帖子数量", len(batch.Counts)),
+				zap.Int("重试次数", batch.Attempts),
+				zap.Time("首次失败时间", time.Unix(batch.FirstFailedAt, 0)),
+			)
+		}
+	}
+
+	// 写回与移除不受任务超时影响，否则已读取的记录会在下一轮被重复处理
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.ViewCountRetryStoreTimeout)
+	defer cancel()
+	if err := t.retryStore.Push(storeCtx, requeue); err != nil {
+		t.logger.Error("写回重试失败的浏览量批次失败，保留已读取的记录到下一轮", zap.Error(err), zap.Int("记录数", len(requeue)))
+		return
+	}
+	if err := t.retryStore.Ack(storeCtx, read); err != nil {
+		t.logger.Error("移除已处理的浏览量重试记录失败，下一轮将重复重试", zap.Error(err), zap.Int64("记录数", read))
+	}
+	metrics.AddTaskEvents(t.metrics, constant.ViewCountSyncTaskName, constant.ViewCountSyncEventRetryRecover, recovered)
+	metrics.AddTaskEvents(t.metrics, constant.ViewCountSyncTaskName, constant.ViewCountSyncEventRetryDropped, dropped)
+	t.logger.Info("浏览量失败批次重试完成",
+		zap.Int64("读取记录数", read),
+		zap.Int("成功", recovered),
+		zap.Int("待再次重试", len(requeue)),
+		zap.Int("丢弃", dropped),
+	)
+}
+
+// persistFailedBatches 把写入失败的浏览量按 constant.ViewCountRetryRecordSize 拆分为重试记录持久化，下一轮同步开始时重试。
+// - err 不是 *mysql.ViewCountBatchError 时无法确定失败范围，只记录日志。
+func (t *ViewCountSyncTask) persistFailedBatches(ctx context.Context, err error) {
+	var batchErr *mysql.ViewCountBatchError
+	if !errors.As(err, &batchErr) {
+		return
+	}
+	metrics.AddTaskEvents(t.metrics, constant.ViewCountSyncTaskName, constant.ViewCountSyncEventFailedBatches, batchErr.FailedBatches)
+	if t.retryStore == nil || len(batchErr.Failed) == 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	var records []*redis.ViewCountRetryBatch
+	current := make(map[uint64]int64, constant.ViewCountRetryRecordSize)
+	for postID, count := range batchErr.Failed {
+		current[postID] = count
+		if len(current) == constant.ViewCountRetryRecordSize {
+			records = append(records, &redis.ViewCountRetryBatch{Counts: current, FirstFailedAt: now, LastFailedAt: now})
+			current = make(map[uint64]int64, constant.ViewCountRetryRecordSize)
+		}
+	}
+	if len(current) > 0 {
+		records = append(records, &redis.ViewCountRetryBatch{Counts: current, FirstFailedAt: now, LastFailedAt: now})
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.ViewCountRetryStoreTimeout)
+	defer cancel()
+	if pushErr := t.retryStore.Push(storeCtx, records); pushErr != nil {
+		metrics.AddTaskEvents(t.metrics, constant.ViewCountSyncTaskName, constant.ViewCountSyncEventRetryPersist, len(records))
+		t.logger.Error("持久化浏览量失败批次失败", zap.Error(pushErr), zap.Int("帖子数量", len(batchErr.Failed)))
+		return
+	}
+	metrics.AddTaskEvents(t.metrics, constant.ViewCountSyncTaskName, constant.ViewCountSyncEventRetryEnqueued, len(records))
+	t.logger.Warn("浏览量失败批次已持久化，下一轮同步开始时重试",
+		zap.Int("失败批次数", batchErr.FailedBatches),
+		zap.Int("帖子数量", len(batchErr.Failed)),
+		zap.Int("重试记录数", len(records)),
+	)
+}
+
+// awardViewMilestones 以刚写入 MySQL 的浏览量为准颁发里程碑徽章，失败只记录日志，不影响同步结果。
+// - 只在写库成功后调用，徽章不会领先于已持久化的浏览量；失败的帖子会在其下次被同步时重新检查。
+func (t *ViewCountSyncTask) awardViewMilestones(ctx context.Context, viewCounts map[uint64]int64) {
+	if t.badgeSvc == nil {
+		return
+	}
+	if err := t.badgeSvc.AwardViewMilestones(ctx, viewCounts); err != nil {
+		t.logger.Error("颁发浏览量里程碑徽章失败", zap.Error(err))
 	}
 }
 
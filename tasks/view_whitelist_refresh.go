@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xushengqwer/go-common/core"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/Xushengqwer/post_service/constant"
+	"github.com/Xushengqwer/post_service/repo/redis"
+)
+
+// ViewWhitelistRefreshTask 负责定期将 Redis 中的浏览量防刷白名单加载到本地内存，实现白名单热更新。
+type ViewWhitelistRefreshTask struct {
+	postViewRepo redis.PostViewRepository
+	interval     time.Duration
+	cron         *cron.Cron
+	logger       *core.ZapLogger
+}
+
+// NewViewWhitelistRefreshTask 初始化并启动白名单刷新任务。
+// - 启动时会先同步加载一次，保证服务开始处理请求前 Redis 中的白名单已生效。
+// - interval 为 0 或负数时使用 constant.ViewWhitelistRefreshInterval。
+func NewViewWhitelistRefreshTask(postViewRepo redis.PostViewRepository, interval time.Duration, logger *core.ZapLogger) *ViewWhitelistRefreshTask {
+	if interval <= 0 {
+		interval = constant.ViewWhitelistRefreshInterval
+	}
+	task := &ViewWhitelistRefreshTask{
+		postViewRepo: postViewRepo,
+		interval:     interval,
+		cron:         cron.New(),
+		logger:       logger,
+	}
+	task.refresh()
+	task.startCronJob()
+	return task
+}
+
+// startCronJob 配置并启动 cron 作业。
+func (t *ViewWhitelistRefreshTask) startCronJob() {
+	entryID := t.cron.Schedule(cron.Every(t.interval), cron.FuncJob(t.refresh))
+	t.cron.Start()
+	t.logger.Info("浏览量白名单刷新任务已启动", zap.Duration("interval", t.interval), zap.Uint("cronEntryID", uint(entryID)))
+}
+
+// refresh 执行一次白名单加载，失败时保留旧白名单，等待下一轮重试。
+func (t *ViewWhitelistRefreshTask) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := t.postViewRepo.RefreshViewWhitelist(ctx); err != nil {
+		t.logger.Warn("刷新浏览量白名单失败", zap.Error(err))
+	}
+}
+
+// Stop 优雅地停止 cron 调度器。
+func (t *ViewWhitelistRefreshTask) Stop() context.Context {
+	t.logger.Info("正在停止浏览量白名单刷新任务...")
+	return t.cron.Stop()
+}